@@ -0,0 +1,182 @@
+package app
+
+import (
+	"math/rand"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAnteHandlerFeelessExecution(t *testing.T) {
+	wasmApp := Setup(t)
+	ctx := wasmApp.NewContextLegacy(true, cmtproto.Header{ChainID: "testing", Height: 2}).
+		WithMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(sdk.DefaultBondDenom, sdkmath.LegacyNewDec(1))))
+	priv := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(priv.PubKey().Address())
+	acc := wasmApp.AccountKeeper.NewAccountWithAddress(ctx, sender)
+	wasmApp.AccountKeeper.SetAccount(ctx, acc)
+	contractAddr := wasmkeeper.BuildContractAddressClassic(1, 1)
+	params := wasmApp.WasmKeeper.GetParams(ctx)
+	params.FeelessExecutions = types.FeelessExecutions{
+		Allowed: []types.FeelessExecution{{Contract: contractAddr.String(), MsgKey: "push_price"}},
+		MaxGas:  simtestutil.DefaultGenTxGas,
+	}
+	require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+
+	specs := map[string]struct {
+		msg    string
+		expErr error
+	}{
+		"listed execution without fees": {
+			msg: `{"push_price":{}}`,
+		},
+		"other execution without fees": {
+			msg:    `{"other":{}}`,
+			expErr: sdkerrors.ErrInsufficientFee,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			msg := &types.MsgExecuteContract{Sender: sender.String(), Contract: contractAddr.String(), Msg: []byte(spec.msg)}
+			tx, err := simtestutil.GenSignedMockTx(rand.New(rand.NewSource(1)), wasmApp.TxConfig(), []sdk.Msg{msg}, sdk.NewCoins(),
+				simtestutil.DefaultGenTxGas, "testing", []uint64{acc.GetAccountNumber()}, []uint64{0}, priv)
+			require.NoError(t, err)
+
+			// when
+			_, gotErr := wasmApp.AnteHandler()(ctx, tx, false)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestAnteHandlerContractGasBudget(t *testing.T) {
+	wasmApp := Setup(t)
+	// state of the finalized block is written to the root store directly before commit
+	ctx := wasmApp.NewUncachedContext(false, cmtproto.Header{ChainID: "testing", Height: wasmApp.LastBlockHeight()})
+	priv := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(priv.PubKey().Address())
+	acc := wasmApp.AccountKeeper.NewAccountWithAddress(ctx, sender)
+	wasmApp.AccountKeeper.SetAccount(ctx, acc)
+	// executions of the unknown contract fail
+	contractAddr := wasmkeeper.BuildContractAddressClassic(1, 1)
+	params := wasmApp.WasmKeeper.GetParams(ctx)
+	params.ContractGasBudgets = []types.ContractGasBudget{{Contract: contractAddr.String(), MaxGasPerBlock: simtestutil.DefaultGenTxGas}}
+	require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+	_, err := wasmApp.Commit()
+	require.NoError(t, err)
+
+	txs := make([][]byte, 3)
+	for i := range txs {
+		msg := &types.MsgExecuteContract{Sender: sender.String(), Contract: contractAddr.String(), Msg: []byte(`{}`)}
+		tx, err := simtestutil.GenSignedMockTx(rand.New(rand.NewSource(1)), wasmApp.TxConfig(), []sdk.Msg{msg}, sdk.NewCoins(),
+			simtestutil.DefaultGenTxGas, "testing", []uint64{acc.GetAccountNumber()}, []uint64{uint64(i)}, priv)
+		require.NoError(t, err)
+		txs[i], err = wasmApp.TxConfig().TxEncoder()(tx)
+		require.NoError(t, err)
+	}
+	rateLimitedCode := types.ErrRateLimited.ABCICode()
+
+	// when checked for the mempool
+	var checkCodes []uint32
+	for _, tx := range txs {
+		rsp, err := wasmApp.CheckTx(&abci.RequestCheckTx{Tx: tx, Type: abci.CheckTxType_New})
+		require.NoError(t, err)
+		checkCodes = append(checkCodes, rsp.Code)
+	}
+	// then the gas limits of the first two txs are charged to the budget
+	assert.Equal(t, []uint32{0, 0, rateLimitedCode}, checkCodes)
+
+	// when executed in the same block
+	rsp, err := wasmApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: wasmApp.LastBlockHeight() + 1, Txs: txs})
+	require.NoError(t, err)
+	// then the results are consistent with the mempool check
+	require.Len(t, rsp.TxResults, 3)
+	assert.Equal(t, rateLimitedCode, rsp.TxResults[2].Code)
+	assert.Equal(t, types.DefaultCodespace, rsp.TxResults[2].Codespace)
+	// and the failed executions are charged to the budget, too
+	for _, r := range rsp.TxResults[:2] {
+		assert.NotEqual(t, uint32(0), r.Code)
+		assert.NotEqual(t, rateLimitedCode, r.Code)
+	}
+	_, err = wasmApp.Commit()
+	require.NoError(t, err)
+
+	// when the third tx is executed with a new budget in the next block
+	rsp, err = wasmApp.FinalizeBlock(&abci.RequestFinalizeBlock{Height: wasmApp.LastBlockHeight() + 1, Txs: txs[2:]})
+	require.NoError(t, err)
+	// then it is not rate limited
+	require.Len(t, rsp.TxResults, 1)
+	assert.NotEqual(t, rateLimitedCode, rsp.TxResults[0].Code)
+}
+
+func TestAnteHandlerGasBreakdown(t *testing.T) {
+	wasmApp := Setup(t)
+	ctx := wasmApp.NewUncachedContext(false, cmtproto.Header{ChainID: "testing", Height: wasmApp.LastBlockHeight()})
+	priv := secp256k1.GenPrivKey()
+	sender := sdk.AccAddress(priv.PubKey().Address())
+	wasmApp.AccountKeeper.SetAccount(ctx, wasmApp.AccountKeeper.NewAccountWithAddress(ctx, sender))
+	_, err := wasmApp.Commit()
+	require.NoError(t, err)
+	option, err := codectypes.NewAnyWithValue(&types.GasBreakdownExtensionOption{})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		options      []*codectypes.Any
+		expBreakdown bool
+	}{
+		"with option": {
+			options:      []*codectypes.Any{option},
+			expBreakdown: true,
+		},
+		"without option": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			txBuilder := wasmApp.TxConfig().NewTxBuilder()
+			require.NoError(t, txBuilder.SetMsgs(&types.MsgStoreCode{Sender: sender.String(), WASMByteCode: testdata.HackatomContractWasm()}))
+			txBuilder.SetGasLimit(simtestutil.DefaultGenTxGas)
+			txBuilder.(authtx.ExtensionOptionsTxBuilder).SetNonCriticalExtensionOptions(spec.options...)
+			require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+				PubKey: priv.PubKey(),
+				Data:   &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+			}))
+			txBytes, err := wasmApp.TxConfig().TxEncoder()(txBuilder.GetTx())
+			require.NoError(t, err)
+
+			// when
+			_, result, err := wasmApp.Simulate(txBytes)
+
+			// then
+			require.NoError(t, err)
+			var found bool
+			for _, e := range result.Events {
+				found = found || e.Type == "cosmwasm.wasm.v1.EventGasBreakdown"
+			}
+			assert.Equal(t, spec.expBreakdown, found)
+		})
+	}
+}
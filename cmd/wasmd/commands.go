@@ -110,6 +110,9 @@ func initRootCmd(
 		confixcmd.ConfigCommand(),
 		pruning.Cmd(newApp, app.DefaultNodeHome),
 		snapshot.Cmd(newApp),
+		RevalidateCmd(newApp, app.DefaultNodeHome),
+		VerifyCacheCmd(app.DefaultNodeHome),
+		WasmTestCmd(),
 	)
 
 	server.AddCommands(rootCmd, app.DefaultNodeHome, newApp, appExport, addModuleInitFlags)
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/pruning"
+	"github.com/cosmos/cosmos-sdk/server"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/CosmWasm/wasmd/app"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagOnlyPinned = "only-pinned"
+
+	// revalidationMemoryLimit is the memory limit in MiB of the temporary VM instance cache
+	revalidationMemoryLimit = 32
+)
+
+// revalidationCode is a stored code to validate
+type revalidationCode struct {
+	CodeID   uint64
+	Checksum []byte
+	Pinned   bool
+	Code     []byte
+}
+
+// revalidationResult is the outcome of the validation of a single code
+type revalidationResult struct {
+	CodeID uint64 `json:"code_id"`
+	Pinned bool   `json:"pinned"`
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+}
+
+// codeIterator calls the callback for every stored code until an error is returned
+type codeIterator func(cb func(revalidationCode) error) error
+
+// RevalidateCmd returns a command to validate all stored codes with the wasmvm version of this binary
+func RevalidateCmd(appCreator servertypes.AppCreator, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wasm-revalidate",
+		Short: "Verify that all stored codes pass the validation of the current wasmvm",
+		Long: `Run the code validation and analysis of the wasmvm version of this binary on all stored codes.
+This helps to find codes that would fail after a libwasmvm upgrade before users transact with them.
+
+By default, the application database in the home directory is read. The node must be stopped for this.
+When --node is set, the codes are queried from a running node instead.
+The command exits with an error when a pinned code fails the validation.`,
+		Example: fmt.Sprintf("%s wasm-revalidate --only-pinned --output json", version.AppName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			onlyPinned, err := cmd.Flags().GetBool(flagOnlyPinned)
+			if err != nil {
+				return err
+			}
+			outputFormat, err := cmd.Flags().GetString(flags.FlagOutput)
+			if err != nil {
+				return err
+			}
+
+			var iterate codeIterator
+			if cmd.Flags().Changed(flags.FlagNode) {
+				clientCtx, err := client.GetClientQueryContext(cmd)
+				if err != nil {
+					return err
+				}
+				iterate = queriedCodes(cmd.Context(), wasmtypes.NewQueryClient(clientCtx), onlyPinned)
+			} else {
+				serverCtx := server.GetServerContextFromCmd(cmd)
+				home := serverCtx.Config.RootDir
+				if home == "" {
+					home = defaultNodeHome
+				}
+				db, err := dbm.NewDB("application", server.GetAppDBBackend(serverCtx.Viper), filepath.Join(home, "data"))
+				if err != nil {
+					return err
+				}
+				defer db.Close()
+				wasmApp, ok := appCreator(log.NewNopLogger(), db, nil, serverCtx.Viper).(*app.WasmApp)
+				if !ok {
+					return errors.New("unsupported app type")
+				}
+				iterate = storedCodes(wasmApp, onlyPinned)
+			}
+
+			// a temporary VM is used so that the node's cache is not modified
+			tmpDir, err := os.MkdirTemp("", "wasm-revalidate")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+			vm, err := wasmvm.NewVM(tmpDir, wasmkeeper.BuiltInCapabilities(), revalidationMemoryLimit, false, 0)
+			if err != nil {
+				return err
+			}
+			defer vm.Cleanup()
+
+			results, err := revalidateCodes(vm, iterate)
+			if err != nil {
+				return err
+			}
+			if err := printRevalidationResults(cmd.OutOrStdout(), outputFormat, results); err != nil {
+				return err
+			}
+			var failedPinned int
+			for _, r := range results {
+				if r.Pinned && !r.OK {
+					failedPinned++
+				}
+			}
+			if failedPinned != 0 {
+				return fmt.Errorf("%d pinned code(s) failed validation", failedPinned)
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(pruning.FlagAppDBBackend, "", "The type of database for application and snapshots databases")
+	cmd.Flags().String(flags.FlagNode, "", "<host>:<port> to CometBFT RPC interface of a node to query the codes from instead of reading the database")
+	cmd.Flags().String(flags.FlagGRPC, "", "the gRPC endpoint to use for this chain")
+	cmd.Flags().Bool(flags.FlagGRPCInsecure, false, "allow gRPC over insecure channels, if not the server must use tls")
+	cmd.Flags().StringP(flags.FlagOutput, "o", flags.OutputFormatText, "Output format (text|json)")
+	cmd.Flags().Bool(flagOnlyPinned, false, "Only validate pinned codes")
+	return cmd
+}
+
+// storedCodes iterates the codes from the application state
+func storedCodes(wasmApp *app.WasmApp, onlyPinned bool) codeIterator {
+	return func(cb func(revalidationCode) error) error {
+		ctx := wasmApp.NewUncachedContext(false, cmtproto.Header{})
+		k := wasmApp.WasmKeeper
+		var err error
+		k.IterateCodeInfos(ctx, func(codeID uint64, info wasmtypes.CodeInfo) bool {
+			pinned := k.IsPinnedCode(ctx, codeID)
+			if onlyPinned && !pinned {
+				return false
+			}
+			c := revalidationCode{CodeID: codeID, Checksum: info.CodeHash, Pinned: pinned}
+			// a missing or unreadable code is reported as validation failure
+			c.Code, _ = k.GetByteCode(ctx, codeID)
+			err = cb(c)
+			return err != nil
+		})
+		return err
+	}
+}
+
+// queriedCodes iterates the codes via gRPC queries to a node
+func queriedCodes(ctx context.Context, queryClient wasmtypes.QueryClient, onlyPinned bool) codeIterator {
+	return func(cb func(revalidationCode) error) error {
+		pinned := make(map[uint64]struct{})
+		var pageKey []byte
+		for {
+			res, err := queryClient.PinnedCodes(ctx, &wasmtypes.QueryPinnedCodesRequest{Pagination: &query.PageRequest{Key: pageKey}})
+			if err != nil {
+				return err
+			}
+			for _, id := range res.CodeIDs {
+				pinned[id] = struct{}{}
+			}
+			if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+				break
+			}
+			pageKey = res.Pagination.NextKey
+		}
+
+		pageKey = nil
+		for {
+			res, err := queryClient.Codes(ctx, &wasmtypes.QueryCodesRequest{Pagination: &query.PageRequest{Key: pageKey}})
+			if err != nil {
+				return err
+			}
+			for _, info := range res.CodeInfos {
+				_, isPinned := pinned[info.CodeID]
+				if onlyPinned && !isPinned {
+					continue
+				}
+				c := revalidationCode{CodeID: info.CodeID, Checksum: info.DataHash, Pinned: isPinned}
+				codeRes, err := queryClient.Code(ctx, &wasmtypes.QueryCodeRequest{CodeId: info.CodeID})
+				if err != nil {
+					return err
+				}
+				c.Code = codeRes.Data
+				if err := cb(c); err != nil {
+					return err
+				}
+			}
+			if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+				return nil
+			}
+			pageKey = res.Pagination.NextKey
+		}
+	}
+}
+
+// revalidateCodes runs the validation and analysis of the VM on all codes
+func revalidateCodes(vm wasmtypes.WasmEngine, iterate codeIterator) ([]revalidationResult, error) {
+	var results []revalidationResult
+	err := iterate(func(c revalidationCode) error {
+		r := revalidationResult{CodeID: c.CodeID, Pinned: c.Pinned, OK: true}
+		if err := validateCode(vm, c); err != nil {
+			r.OK, r.Error = false, err.Error()
+		}
+		results = append(results, r)
+		return nil
+	})
+	return results, err
+}
+
+func validateCode(vm wasmtypes.WasmEngine, c revalidationCode) error {
+	if len(c.Code) == 0 {
+		return errors.New("code not found")
+	}
+	checksum, err := wasmvm.CreateChecksum(c.Code)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(checksum, c.Checksum) {
+		return fmt.Errorf("checksum mismatch: got %X, expected %X", checksum, c.Checksum)
+	}
+	if _, _, err := vm.StoreCode(c.Code, math.MaxUint64); err != nil {
+		return err
+	}
+	_, err = vm.AnalyzeCode(checksum)
+	return err
+}
+
+func printRevalidationResults(out io.Writer, format string, results []revalidationResult) error {
+	switch format {
+	case flags.OutputFormatJSON:
+		if results == nil {
+			results = []revalidationResult{}
+		}
+		bz, err := json.Marshal(results)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(bz))
+		return err
+	case flags.OutputFormatText:
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		if _, err := fmt.Fprintln(w, "CODE ID\tPINNED\tSTATUS"); err != nil {
+			return err
+		}
+		for _, r := range results {
+			status := "ok"
+			if !r.OK {
+				status = "error: " + r.Error
+			}
+			if _, err := fmt.Fprintf(w, "%d\t%t\t%s\n", r.CodeID, r.Pinned, status); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
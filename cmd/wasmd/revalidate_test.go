@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+)
+
+func TestRevalidateCodes(t *testing.T) {
+	vm, err := wasmvm.NewVM(t.TempDir(), wasmkeeper.BuiltInCapabilities(), revalidationMemoryLimit, false, 0)
+	require.NoError(t, err)
+	t.Cleanup(vm.Cleanup)
+
+	goodCode, err := os.ReadFile("../../x/wasm/keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	goodChecksum, err := wasmvm.CreateChecksum(goodCode)
+	require.NoError(t, err)
+	// a valid wasm module without the exports required for a contract
+	noContractCode := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	noContractChecksum, err := wasmvm.CreateChecksum(noContractCode)
+	require.NoError(t, err)
+	corruptedCode := bytes.Clone(goodCode)
+	corruptedCode[len(corruptedCode)/2] ^= 0xff
+
+	stored := []revalidationCode{
+		{CodeID: 1, Checksum: goodChecksum, Pinned: true, Code: goodCode},
+		{CodeID: 2, Checksum: goodChecksum, Pinned: true, Code: corruptedCode},
+		{CodeID: 3, Checksum: noContractChecksum, Code: noContractCode},
+		{CodeID: 4, Checksum: goodChecksum},
+	}
+	iterate := func(cb func(revalidationCode) error) error {
+		for _, c := range stored {
+			if err := cb(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// when
+	results, err := revalidateCodes(vm, iterate)
+
+	// then
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, revalidationResult{CodeID: 1, Pinned: true, OK: true}, results[0])
+	assert.False(t, results[1].OK)
+	assert.Contains(t, results[1].Error, "checksum mismatch")
+	assert.False(t, results[2].OK)
+	assert.NotEmpty(t, results[2].Error)
+	assert.Equal(t, revalidationResult{CodeID: 4, Error: "code not found"}, results[3])
+}
+
+func TestPrintRevalidationResults(t *testing.T) {
+	results := []revalidationResult{
+		{CodeID: 1, Pinned: true, OK: true},
+		{CodeID: 2, Error: "checksum mismatch"},
+	}
+	specs := map[string]struct {
+		format string
+		src    []revalidationResult
+		exp    string
+		expErr bool
+	}{
+		"text": {
+			format: "text",
+			src:    results,
+			exp: "CODE ID  PINNED  STATUS\n" +
+				"1        true    ok\n" +
+				"2        false   error: checksum mismatch\n",
+		},
+		"json": {
+			format: "json",
+			src:    results,
+			exp:    `[{"code_id":1,"pinned":true,"ok":true},{"code_id":2,"pinned":false,"ok":false,"error":"checksum mismatch"}]` + "\n",
+		},
+		"json without results": {
+			format: "json",
+			exp:    "[]\n",
+		},
+		"unsupported format": {
+			format: "yaml",
+			src:    results,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			gotErr := printRevalidationResults(&out, spec.format, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
@@ -0,0 +1,569 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"cosmossdk.io/log"
+
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/testutil/mock"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/CosmWasm/wasmd/app"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const scenarioChainID = "wasm-test"
+
+// scenario is a script of contract operations that is run against an embedded app
+type scenario struct {
+	// Accounts are the names of the test accounts with their balance at genesis
+	Accounts map[string]string `yaml:"accounts"`
+	Steps    []scenarioStep    `yaml:"steps"`
+}
+
+// scenarioStep is a single contract operation. Exactly one operation must be set.
+type scenarioStep struct {
+	Name        string           `yaml:"name"`
+	Store       *storeStep       `yaml:"store"`
+	Instantiate *instantiateStep `yaml:"instantiate"`
+	Execute     *executeStep     `yaml:"execute"`
+	Query       *queryStep       `yaml:"query"`
+	Expect      *stepExpectation `yaml:"expect"`
+}
+
+type storeStep struct {
+	// File is the path of the wasm file, relative to the scenario file
+	File   string `yaml:"file"`
+	Sender string `yaml:"sender"`
+	// As is the name the code id is referenced with in later steps
+	As string `yaml:"as"`
+}
+
+type instantiateStep struct {
+	// Code is the name of a stored code or a code id
+	Code   string `yaml:"code"`
+	Sender string `yaml:"sender"`
+	Admin  string `yaml:"admin"`
+	Label  string `yaml:"label"`
+	Msg    string `yaml:"msg"`
+	Funds  string `yaml:"funds"`
+	// As is the name the contract is referenced with in later steps
+	As string `yaml:"as"`
+}
+
+type executeStep struct {
+	Contract string `yaml:"contract"`
+	Sender   string `yaml:"sender"`
+	Msg      string `yaml:"msg"`
+	Funds    string `yaml:"funds"`
+}
+
+type queryStep struct {
+	Contract string `yaml:"contract"`
+	Msg      string `yaml:"msg"`
+}
+
+// stepExpectation is checked against the outcome of a step. Without expectation, a step must succeed.
+type stepExpectation struct {
+	// Response is the expected JSON response. Object keys order and whitespace are ignored.
+	Response string `yaml:"response"`
+	// Error is a substring of the expected error
+	Error string `yaml:"error"`
+}
+
+// WasmTestCmd returns the commands to test contracts locally
+func WasmTestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "wasm-test",
+		Short:                      "Test contracts against an embedded app",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(WasmTestRunCmd())
+	return cmd
+}
+
+// WasmTestRunCmd returns the command to run a scenario file
+func WasmTestRunCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run [scenario.yaml]",
+		Short: "Run the steps of a scenario file against an in-memory app",
+		Long: `Run the steps of a scenario file against an in-memory app with funded test accounts.
+No node is required and no state is kept after the run.
+
+The accounts are funded at genesis. A step stores code, instantiates, executes or queries a contract.
+Messages are JSON where ${name} is replaced by the address of an account or a contract, or by a code id.
+A step fails when it returns an error unless an error substring is expected. The run stops at the first
+failed step and exits with an error that contains the step number.
+
+accounts:
+  alice: 1000000stake
+steps:
+  - store: {file: hackatom.wasm, sender: alice, as: hackatom}
+  - instantiate: {code: hackatom, sender: alice, label: demo, msg: '{"verifier": "${alice}", "beneficiary": "${alice}"}', as: demo}
+  - query: {contract: demo, msg: '{"verifier": {}}'}
+    expect: {response: '{"verifier": "${alice}"}'}`,
+		Example: fmt.Sprintf("%s wasm-test run scenario.yaml", version.AppName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScenarioFile(cmd.OutOrStdout(), args[0])
+		},
+		SilenceUsage: true,
+	}
+	return cmd
+}
+
+// runScenarioFile runs a scenario file against a new embedded app
+func runScenarioFile(out io.Writer, path string) error {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	s, err := parseScenario(bz)
+	if err != nil {
+		return fmt.Errorf("scenario %s: %w", path, err)
+	}
+	balances := make(map[string]sdk.Coins, len(s.Accounts))
+	for name, amount := range s.Accounts {
+		if balances[name], err = sdk.ParseCoinsNormalized(amount); err != nil {
+			return fmt.Errorf("balance of account %s: %w", name, err)
+		}
+	}
+	chain, err := newScenarioChain(balances)
+	if err != nil {
+		return err
+	}
+	defer chain.Close()
+
+	r := newScenarioRunner(chain.ctx, wasmkeeper.NewDefaultPermissionKeeper(&chain.app.WasmKeeper), &chain.app.WasmKeeper, filepath.Dir(path))
+	for name := range balances {
+		r.accounts[name] = scenarioAccountAddress(name)
+	}
+	return r.run(out, s.Steps)
+}
+
+// parseScenario decodes and validates a scenario
+func parseScenario(bz []byte) (*scenario, error) {
+	var s scenario
+	if err := yaml.UnmarshalStrict(bz, &s); err != nil {
+		return nil, err
+	}
+	if len(s.Accounts) == 0 {
+		return nil, errors.New("no accounts")
+	}
+	if len(s.Steps) == 0 {
+		return nil, errors.New("no steps")
+	}
+	for i, step := range s.Steps {
+		if _, err := step.kind(); err != nil {
+			return nil, fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return &s, nil
+}
+
+// kind returns the name of the operation of the step
+func (s scenarioStep) kind() (string, error) {
+	var kinds []string
+	if s.Store != nil {
+		kinds = append(kinds, "store")
+	}
+	if s.Instantiate != nil {
+		kinds = append(kinds, "instantiate")
+	}
+	if s.Execute != nil {
+		kinds = append(kinds, "execute")
+	}
+	if s.Query != nil {
+		kinds = append(kinds, "query")
+	}
+	if len(kinds) != 1 {
+		return "", errors.New("exactly one of store, instantiate, execute or query must be set")
+	}
+	return kinds[0], nil
+}
+
+// scenarioAccountAddress returns the address of a test account
+func scenarioAccountAddress(name string) sdk.AccAddress {
+	return sdk.AccAddress(crypto.AddressHash([]byte(name)))
+}
+
+// scenarioChain is an in-memory app with a single validator
+type scenarioChain struct {
+	app  *app.WasmApp
+	vm   wasmtypes.WasmEngine
+	ctx  sdk.Context
+	home string
+}
+
+// newScenarioChain returns an initialized app with the test accounts funded at genesis. It must be closed after use.
+func newScenarioChain(balances map[string]sdk.Coins) (*scenarioChain, error) {
+	home, err := os.MkdirTemp("", "wasm-test")
+	if err != nil {
+		return nil, err
+	}
+	c := &scenarioChain{home: home}
+	captureVM := wasmkeeper.WithWasmEngineDecorator(func(old wasmtypes.WasmEngine) wasmtypes.WasmEngine {
+		c.vm = old
+		return old
+	})
+	appOptions := simtestutil.AppOptionsMap{flags.FlagHome: home}
+	c.app = app.NewWasmApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, appOptions, []wasmkeeper.Option{captureVM}, bam.SetChainID(scenarioChainID))
+	if err := c.initChain(balances); err != nil {
+		c.Close()
+		return nil, err
+	}
+	c.ctx = c.app.NewUncachedContext(false, cmtproto.Header{
+		ChainID: scenarioChainID,
+		Height:  c.app.LastBlockHeight() + 1,
+		Time:    time.Now().UTC(),
+	})
+	return c, nil
+}
+
+func (c *scenarioChain) initChain(balances map[string]sdk.Coins) error {
+	pubKey, err := mock.NewPV().GetPubKey()
+	if err != nil {
+		return err
+	}
+	valSet := cmttypes.NewValidatorSet([]*cmttypes.Validator{cmttypes.NewValidator(pubKey, 1)})
+
+	// sorted for a deterministic genesis, the first account delegates to the validator
+	var (
+		genAccs     []authtypes.GenesisAccount
+		genBalances []banktypes.Balance
+	)
+	for _, name := range slices.Sorted(maps.Keys(balances)) {
+		addr := scenarioAccountAddress(name)
+		genAccs = append(genAccs, authtypes.NewBaseAccount(addr, nil, 0, 0))
+		genBalances = append(genBalances, banktypes.Balance{Address: addr.String(), Coins: balances[name]})
+	}
+	genesisState, err := app.GenesisStateWithValSet(c.app.AppCodec(), c.app.DefaultGenesis(), valSet, genAccs, genBalances...)
+	if err != nil {
+		return err
+	}
+	stateBytes, err := json.Marshal(genesisState)
+	if err != nil {
+		return err
+	}
+	if _, err := c.app.InitChain(&abci.RequestInitChain{
+		ChainId:         scenarioChainID,
+		Time:            time.Now().UTC(),
+		ConsensusParams: simtestutil.DefaultConsensusParams,
+		InitialHeight:   1,
+		AppStateBytes:   stateBytes,
+	}); err != nil {
+		return err
+	}
+	if _, err := c.app.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height:             1,
+		Hash:               c.app.LastCommitID().Hash,
+		NextValidatorsHash: valSet.Hash(),
+	}); err != nil {
+		return err
+	}
+	_, err = c.app.Commit()
+	return err
+}
+
+// Close releases the VM and removes the app home directory
+func (c *scenarioChain) Close() {
+	if c.vm != nil {
+		c.vm.Cleanup()
+	}
+	_ = c.app.Close()
+	_ = os.RemoveAll(c.home)
+}
+
+// scenarioRunner executes the steps of a scenario
+type scenarioRunner struct {
+	ctx     sdk.Context
+	ops     wasmtypes.ContractOpsKeeper
+	viewer  wasmtypes.ViewKeeper
+	baseDir string
+
+	accounts  map[string]sdk.AccAddress
+	contracts map[string]sdk.AccAddress
+	codes     map[string]uint64
+}
+
+func newScenarioRunner(ctx sdk.Context, ops wasmtypes.ContractOpsKeeper, viewer wasmtypes.ViewKeeper, baseDir string) *scenarioRunner {
+	return &scenarioRunner{
+		ctx:       ctx,
+		ops:       ops,
+		viewer:    viewer,
+		baseDir:   baseDir,
+		accounts:  make(map[string]sdk.AccAddress),
+		contracts: make(map[string]sdk.AccAddress),
+		codes:     make(map[string]uint64),
+	}
+}
+
+// run executes the steps in order and prints a report line per step. It stops at the first failed step.
+// State changes of a failed operation are discarded.
+func (r *scenarioRunner) run(out io.Writer, steps []scenarioStep) error {
+	for i, step := range steps {
+		kind, err := step.kind()
+		if err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+		title := kind
+		if step.Name != "" {
+			title = fmt.Sprintf("%s %q", kind, step.Name)
+		}
+		result, err := r.runStep(step)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "step %d %s: FAIL\n", i+1, title)
+			return fmt.Errorf("step %d %s: %w", i+1, title, err)
+		}
+		if _, err := fmt.Fprintf(out, "step %d %s: ok%s\n", i+1, title, result); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "passed: %d steps\n", len(steps))
+	return err
+}
+
+// runStep executes the operation of the step and checks the expectation. The returned result is printed in the report.
+func (r *scenarioRunner) runStep(step scenarioStep) (string, error) {
+	ctx, commit := r.ctx.CacheContext()
+	var (
+		rsp    []byte
+		result string
+		err    error
+	)
+	switch {
+	case step.Store != nil:
+		result, err = r.store(ctx, *step.Store)
+	case step.Instantiate != nil:
+		rsp, result, err = r.instantiate(ctx, *step.Instantiate)
+	case step.Execute != nil:
+		rsp, err = r.execute(ctx, *step.Execute)
+	case step.Query != nil:
+		rsp, err = r.query(ctx, *step.Query)
+	}
+	if err == nil {
+		commit()
+	}
+	if err := r.checkExpectation(step.Expect, rsp, err); err != nil {
+		return "", err
+	}
+	if err != nil {
+		return fmt.Sprintf(" (expected error: %s)", err), nil
+	}
+	return result, nil
+}
+
+func (r *scenarioRunner) store(ctx sdk.Context, s storeStep) (string, error) {
+	sender, err := r.address(s.Sender)
+	if err != nil {
+		return "", err
+	}
+	if err := r.checkUnusedName(s.As); err != nil {
+		return "", err
+	}
+	file := s.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(r.baseDir, file)
+	}
+	code, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	codeID, _, err := r.ops.Create(ctx, sender, code, nil)
+	if err != nil {
+		return "", err
+	}
+	if s.As != "" {
+		r.codes[s.As] = codeID
+	}
+	return fmt.Sprintf(" (code id %d)", codeID), nil
+}
+
+func (r *scenarioRunner) instantiate(ctx sdk.Context, s instantiateStep) ([]byte, string, error) {
+	codeID, err := r.codeID(s.Code)
+	if err != nil {
+		return nil, "", err
+	}
+	sender, err := r.address(s.Sender)
+	if err != nil {
+		return nil, "", err
+	}
+	var admin sdk.AccAddress
+	if s.Admin != "" {
+		if admin, err = r.address(s.Admin); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := r.checkUnusedName(s.As); err != nil {
+		return nil, "", err
+	}
+	msg, err := r.expand(s.Msg)
+	if err != nil {
+		return nil, "", err
+	}
+	funds, err := sdk.ParseCoinsNormalized(s.Funds)
+	if err != nil {
+		return nil, "", fmt.Errorf("funds: %w", err)
+	}
+	contractAddr, data, err := r.ops.Instantiate(ctx, codeID, sender, admin, []byte(msg), s.Label, funds)
+	if err != nil {
+		return nil, "", err
+	}
+	if s.As != "" {
+		r.contracts[s.As] = contractAddr
+	}
+	return data, fmt.Sprintf(" (contract %s)", contractAddr), nil
+}
+
+func (r *scenarioRunner) execute(ctx sdk.Context, s executeStep) ([]byte, error) {
+	contractAddr, err := r.address(s.Contract)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := r.address(s.Sender)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := r.expand(s.Msg)
+	if err != nil {
+		return nil, err
+	}
+	funds, err := sdk.ParseCoinsNormalized(s.Funds)
+	if err != nil {
+		return nil, fmt.Errorf("funds: %w", err)
+	}
+	return r.ops.Execute(ctx, contractAddr, sender, []byte(msg), funds)
+}
+
+func (r *scenarioRunner) query(ctx sdk.Context, s queryStep) ([]byte, error) {
+	contractAddr, err := r.address(s.Contract)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := r.expand(s.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return r.viewer.QuerySmart(ctx, contractAddr, []byte(msg))
+}
+
+// checkExpectation returns an error when the outcome of a step does not match the expectation
+func (r *scenarioRunner) checkExpectation(exp *stepExpectation, rsp []byte, opErr error) error {
+	if exp != nil && exp.Error != "" {
+		switch {
+		case opErr == nil:
+			return fmt.Errorf("expected error containing %q", exp.Error)
+		case !strings.Contains(opErr.Error(), exp.Error):
+			return fmt.Errorf("expected error containing %q, got %q", exp.Error, opErr)
+		}
+		return nil
+	}
+	if opErr != nil {
+		return opErr
+	}
+	if exp == nil || exp.Response == "" {
+		return nil
+	}
+	expRsp, err := r.expand(exp.Response)
+	if err != nil {
+		return fmt.Errorf("expected response: %w", err)
+	}
+	var expValue, gotValue any
+	if err := json.Unmarshal([]byte(expRsp), &expValue); err != nil {
+		return fmt.Errorf("expected response: %w", err)
+	}
+	if err := json.Unmarshal(rsp, &gotValue); err != nil || !reflect.DeepEqual(expValue, gotValue) {
+		var compact bytes.Buffer
+		if json.Compact(&compact, rsp) != nil {
+			compact.Reset()
+			compact.Write(rsp)
+		}
+		return fmt.Errorf("expected response %s, got %s", expRsp, compact.String())
+	}
+	return nil
+}
+
+// address returns the address of a test account or a contract
+func (r *scenarioRunner) address(name string) (sdk.AccAddress, error) {
+	if addr, ok := r.accounts[name]; ok {
+		return addr, nil
+	}
+	if addr, ok := r.contracts[name]; ok {
+		return addr, nil
+	}
+	return nil, fmt.Errorf("unknown account or contract %q", name)
+}
+
+// codeID returns the code id stored with the name or parses a code id
+func (r *scenarioRunner) codeID(name string) (uint64, error) {
+	if codeID, ok := r.codes[name]; ok {
+		return codeID, nil
+	}
+	codeID, err := strconv.ParseUint(name, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unknown code %q", name)
+	}
+	return codeID, nil
+}
+
+func (r *scenarioRunner) checkUnusedName(name string) error {
+	if name == "" {
+		return nil
+	}
+	_, isAccount := r.accounts[name]
+	_, isContract := r.contracts[name]
+	_, isCode := r.codes[name]
+	if isAccount || isContract || isCode {
+		return fmt.Errorf("name %q is already used", name)
+	}
+	return nil
+}
+
+// expand replaces ${name} in s by the address of an account or a contract, or by a code id
+func (r *scenarioRunner) expand(s string) (string, error) {
+	var unknown []string
+	expanded := os.Expand(s, func(name string) string {
+		if addr, err := r.address(name); err == nil {
+			return addr.String()
+		}
+		if codeID, ok := r.codes[name]; ok {
+			return strconv.FormatUint(codeID, 10)
+		}
+		unknown = append(unknown, name)
+		return ""
+	})
+	if len(unknown) != 0 {
+		return "", fmt.Errorf("unknown name(s): %s", strings.Join(unknown, ", "))
+	}
+	return expanded, nil
+}
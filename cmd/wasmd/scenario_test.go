@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+)
+
+func TestParseScenario(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		expErr string
+	}{
+		"valid": {
+			src: `
+accounts: {alice: 1stake}
+steps:
+  - store: {file: hackatom.wasm, sender: alice}
+  - query: {contract: alice, msg: '{}'}
+    expect: {error: not found}
+`,
+		},
+		"no accounts": {
+			src:    `steps: [{store: {file: hackatom.wasm, sender: alice}}]`,
+			expErr: "no accounts",
+		},
+		"no steps": {
+			src:    `accounts: {alice: 1stake}`,
+			expErr: "no steps",
+		},
+		"step without operation": {
+			src:    "accounts: {alice: 1stake}\nsteps: [{name: foo}]",
+			expErr: "step 1: exactly one of",
+		},
+		"step with multiple operations": {
+			src:    "accounts: {alice: 1stake}\nsteps: [{query: {contract: a}, execute: {contract: a}}]",
+			expErr: "step 1: exactly one of",
+		},
+		"unknown field": {
+			src:    "accounts: {alice: 1stake}\nsteps: [{query: {contract: a, foo: bar}}]",
+			expErr: "field foo not found",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, gotErr := parseScenario([]byte(spec.src))
+			if spec.expErr != "" {
+				require.ErrorContains(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestScenarioRunner(t *testing.T) {
+	const setup = `
+  - store: {file: hackatom.wasm, sender: alice, as: hackatom}
+  - instantiate: {code: hackatom, sender: alice, label: demo, msg: '{"verifier": "${alice}", "beneficiary": "${bob}"}', funds: 100stake, as: demo}
+`
+	specs := map[string]struct {
+		steps     string
+		expErr    string
+		expReport string
+	}{
+		"all passed": {
+			steps: setup + `
+  - name: verifier
+    query: {contract: demo, msg: '{"verifier": {}}'}
+    expect: {response: '{"verifier":"${alice}"}'}
+  - execute: {contract: demo, sender: bob, msg: '{"release": {}}'}
+    expect: {error: Unauthorized}
+  - execute: {contract: demo, sender: alice, msg: '{"release": {}}'}
+`,
+			expReport: `step 1 store: ok (code id 1)
+step 2 instantiate: ok (contract ${demo})
+step 3 query "verifier": ok
+step 4 execute: ok (expected error: Unauthorized: execute wasm contract failed)
+step 5 execute: ok
+passed: 5 steps
+`,
+		},
+		"code id instead of name": {
+			steps: setup + `
+  - instantiate: {code: "1", sender: alice, label: other, msg: '{"verifier": "${demo}", "beneficiary": "${bob}"}'}
+`,
+		},
+		"unexpected response": {
+			steps: setup + `
+  - query: {contract: demo, msg: '{"verifier": {}}'}
+    expect: {response: '{"verifier": "${bob}"}'}
+`,
+			expErr: `step 3 query: expected response {"verifier": "${bob}"}, got {"verifier":"${alice}"}`,
+		},
+		"unexpected error": {
+			steps: setup + `
+  - execute: {contract: demo, sender: bob, msg: '{"release": {}}'}
+`,
+			expErr: "step 3 execute: Unauthorized",
+		},
+		"expected error not returned": {
+			steps: setup + `
+  - query: {contract: demo, msg: '{"verifier": {}}'}
+    expect: {error: Unauthorized}
+`,
+			expErr: `step 3 query: expected error containing "Unauthorized"`,
+		},
+		"other error than expected": {
+			steps: setup + `
+  - execute: {contract: demo, sender: bob, msg: '{"release": {}}'}
+    expect: {error: not found}
+`,
+			expErr: `step 3 execute: expected error containing "not found", got "Unauthorized`,
+		},
+		"unknown name in msg": {
+			steps: setup + `
+  - query: {contract: demo, msg: '{"other_balance": {"address": "${carol}"}}'}
+`,
+			expErr: "step 3 query: unknown name(s): carol",
+		},
+		"unknown contract": {
+			steps:  setup + "  - query: {contract: other, msg: '{}'}\n",
+			expErr: `step 3 query: unknown account or contract "other"`,
+		},
+		"unknown code": {
+			steps:  "  - instantiate: {code: hackatom, sender: alice, msg: '{}'}\n",
+			expErr: `step 1 instantiate: unknown code "hackatom"`,
+		},
+		"name already used": {
+			steps:  setup + "  - store: {file: hackatom.wasm, sender: alice, as: demo}\n",
+			expErr: `step 3 store: name "demo" is already used`,
+		},
+		"missing wasm file": {
+			steps:  "  - store: {file: unknown.wasm, sender: alice}\n",
+			expErr: "step 1 store: open",
+		},
+		"failed step is not registered": {
+			steps: setup + `
+  - instantiate: {code: hackatom, sender: alice, label: too expensive, msg: '{"verifier": "${alice}", "beneficiary": "${bob}"}', funds: 1000000000stake, as: failed}
+    expect: {error: insufficient funds}
+  - query: {contract: failed, msg: '{"verifier": {}}'}
+`,
+			expErr: `step 4 query: unknown account or contract "failed"`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := keeper.CreateTestInput(t, false, keeper.BuiltInCapabilities())
+			deposit := sdk.NewCoins(sdk.NewInt64Coin("stake", 1_000_000))
+			r := newScenarioRunner(ctx, keepers.ContractKeeper, keepers.WasmKeeper, "../../x/wasm/keeper/testdata")
+			for _, account := range []string{"alice", "bob"} {
+				r.accounts[account] = scenarioAccountAddress(account)
+				keepers.Faucet.Fund(ctx, r.accounts[account], deposit...)
+			}
+			s, err := parseScenario([]byte("accounts: {alice: 1stake}\nsteps:\n" + spec.steps))
+			require.NoError(t, err)
+
+			// when
+			var out bytes.Buffer
+			gotErr := r.run(&out, s.Steps)
+
+			// then
+			if spec.expErr != "" {
+				expErr, err := r.expand(spec.expErr)
+				require.NoError(t, err)
+				require.ErrorContains(t, gotErr, expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			if spec.expReport != "" {
+				expReport, err := r.expand(spec.expReport)
+				require.NoError(t, err)
+				assert.Equal(t, expReport, out.String())
+			}
+		})
+	}
+}
+
+func TestRunScenarioExamples(t *testing.T) {
+	for _, file := range []string{"hackatom.yaml", "reflect.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, runScenarioFile(&out, "testdata/scenarios/"+file))
+			assert.Contains(t, out.String(), "passed: 6 steps")
+		})
+	}
+}
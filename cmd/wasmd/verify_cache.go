@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/pruning"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/CosmWasm/wasmd/app"
+	wasmkeeper "github.com/CosmWasm/wasmd/x/wasm/keeper"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagRepair = "repair"
+
+// cache check status values
+const (
+	cacheStatusOK       = "ok"
+	cacheStatusMissing  = "missing"
+	cacheStatusRepaired = "repaired"
+)
+
+// cacheCode is a stored code that must exist in the wasm cache
+type cacheCode struct {
+	CodeID   uint64
+	Checksum []byte
+	Pinned   bool
+}
+
+// cacheCheckResult is the outcome of the cache check of a single code
+type cacheCheckResult struct {
+	CodeID uint64
+	Pinned bool
+	Status string
+	Error  string
+}
+
+// codeFetcher returns the wasm code for a code id from an external source
+type codeFetcher func(codeID uint64) ([]byte, error)
+
+// VerifyCacheCmd returns a command to check that all stored codes exist in the wasm cache directory
+func VerifyCacheCmd(defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wasm-verify-cache",
+		Short: "Verify that the wasm cache directory contains all stored codes",
+		Long: `Check that the code of every code id in the application database exists in the wasm cache directory.
+State-synced nodes can end up with missing code files which fail contract executions.
+
+The node must be stopped for this. With --repair, missing codes are fetched from a healthy node set
+with --node, verified against the stored checksum and saved into the wasm cache. Pinned codes are pinned
+again. The command exits with an error when missing codes remain.`,
+		Example: fmt.Sprintf("%s wasm-verify-cache --repair --node https://rpc.example.com:443", version.AppName),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			repair, err := cmd.Flags().GetBool(flagRepair)
+			if err != nil {
+				return err
+			}
+			var fetch codeFetcher
+			if repair {
+				if !cmd.Flags().Changed(flags.FlagNode) {
+					return errors.New("--repair requires --node to fetch missing codes")
+				}
+				clientCtx, err := client.GetClientQueryContext(cmd)
+				if err != nil {
+					return err
+				}
+				fetch = queriedCode(cmd.Context(), wasmtypes.NewQueryClient(clientCtx))
+			}
+
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			home := serverCtx.Config.RootDir
+			if home == "" {
+				home = defaultNodeHome
+			}
+			db, err := dbm.NewDB("application", server.GetAppDBBackend(serverCtx.Viper), filepath.Join(home, "data"))
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			// use the VM of the app so that the node's wasm directory is checked
+			var vm wasmtypes.WasmEngine
+			captureVM := wasmkeeper.WithWasmEngineDecorator(func(old wasmtypes.WasmEngine) wasmtypes.WasmEngine {
+				vm = old
+				return old
+			})
+			// the latest version is loaded without pinning codes as this fails for missing codes
+			wasmApp := app.NewWasmApp(log.NewNopLogger(), db, nil, false, serverCtx.Viper, []wasmkeeper.Option{captureVM})
+			if err := wasmApp.LoadLatestVersion(); err != nil {
+				return err
+			}
+			defer vm.Cleanup()
+
+			results := verifyCache(vm, cachedCodes(wasmApp), fetch)
+			if err := printCacheCheckResults(cmd.OutOrStdout(), results); err != nil {
+				return err
+			}
+			if missing := countCacheStatus(results, cacheStatusMissing); missing != 0 {
+				return fmt.Errorf("%d code(s) missing in the wasm cache", missing)
+			}
+			return nil
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flags.FlagHome, defaultNodeHome, "The application home directory")
+	cmd.Flags().String(pruning.FlagAppDBBackend, "", "The type of database for application and snapshots databases")
+	cmd.Flags().Bool(flagRepair, false, "Save missing codes fetched from --node into the wasm cache")
+	cmd.Flags().String(flags.FlagNode, "", "<host>:<port> to CometBFT RPC interface of a healthy node to fetch missing codes from")
+	cmd.Flags().String(flags.FlagGRPC, "", "the gRPC endpoint to use for this chain")
+	cmd.Flags().Bool(flags.FlagGRPCInsecure, false, "allow gRPC over insecure channels, if not the server must use tls")
+	return cmd
+}
+
+// cachedCodes returns all codes from the application state
+func cachedCodes(wasmApp *app.WasmApp) []cacheCode {
+	ctx := wasmApp.NewUncachedContext(false, cmtproto.Header{})
+	k := wasmApp.WasmKeeper
+	var r []cacheCode
+	k.IterateCodeInfos(ctx, func(codeID uint64, info wasmtypes.CodeInfo) bool {
+		r = append(r, cacheCode{CodeID: codeID, Checksum: info.CodeHash, Pinned: k.IsPinnedCode(ctx, codeID)})
+		return false
+	})
+	return r
+}
+
+// queriedCode fetches the code via gRPC queries to a node
+func queriedCode(ctx context.Context, queryClient wasmtypes.QueryClient) codeFetcher {
+	return func(codeID uint64) ([]byte, error) {
+		res, err := queryClient.Code(ctx, &wasmtypes.QueryCodeRequest{CodeId: codeID})
+		if err != nil {
+			return nil, err
+		}
+		return res.Data, nil
+	}
+}
+
+// verifyCache checks that all codes exist in the VM cache. Missing codes are repaired when a fetcher is set.
+func verifyCache(vm wasmtypes.WasmEngine, codes []cacheCode, fetch codeFetcher) []cacheCheckResult {
+	results := make([]cacheCheckResult, len(codes))
+	for i, c := range codes {
+		r := cacheCheckResult{CodeID: c.CodeID, Pinned: c.Pinned, Status: cacheStatusOK}
+		if _, err := vm.GetCode(c.Checksum); err != nil {
+			r.Status, r.Error = cacheStatusMissing, err.Error()
+			if fetch != nil {
+				if err := repairCode(vm, c, fetch); err != nil {
+					r.Error = fmt.Sprintf("repair: %s", err)
+				} else {
+					r.Status, r.Error = cacheStatusRepaired, ""
+				}
+			}
+		}
+		results[i] = r
+	}
+	return results
+}
+
+func repairCode(vm wasmtypes.WasmEngine, c cacheCode, fetch codeFetcher) error {
+	code, err := fetch(c.CodeID)
+	if err != nil {
+		return err
+	}
+	checksum, err := wasmvm.CreateChecksum(code)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(checksum, c.Checksum) {
+		return fmt.Errorf("checksum mismatch: got %X, expected %X", checksum, c.Checksum)
+	}
+	if _, err := vm.StoreCodeUnchecked(code); err != nil {
+		return err
+	}
+	if c.Pinned {
+		return vm.Pin(checksum)
+	}
+	return nil
+}
+
+func countCacheStatus(results []cacheCheckResult, status string) int {
+	var n int
+	for _, r := range results {
+		if r.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func printCacheCheckResults(out io.Writer, results []cacheCheckResult) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "CODE ID\tPINNED\tSTATUS"); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.Status == cacheStatusOK {
+			continue
+		}
+		status := r.Status
+		if r.Error != "" {
+			status += ": " + r.Error
+		}
+		if _, err := fmt.Fprintf(w, "%d\t%t\t%s\n", r.CodeID, r.Pinned, status); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "%d codes checked, %d missing, %d repaired\n", len(results),
+		countCacheStatus(results, cacheStatusMissing), countCacheStatus(results, cacheStatusRepaired))
+	return err
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+)
+
+func TestVerifyCache(t *testing.T) {
+	hackatomCode, err := os.ReadFile("../../x/wasm/keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	hackatomChecksum, err := wasmvm.CreateChecksum(hackatomCode)
+	require.NoError(t, err)
+	reflectCode, err := os.ReadFile("../../x/wasm/keeper/testdata/reflect_2_0.wasm")
+	require.NoError(t, err)
+	reflectChecksum, err := wasmvm.CreateChecksum(reflectCode)
+	require.NoError(t, err)
+
+	stored := []cacheCode{
+		{CodeID: 1, Checksum: hackatomChecksum},
+		{CodeID: 2, Checksum: reflectChecksum, Pinned: true},
+	}
+	chainCodes := map[uint64][]byte{1: hackatomCode, 2: reflectCode}
+
+	specs := map[string]struct {
+		fetch          codeFetcher
+		exp            []cacheCheckResult
+		expExecutable  bool
+		expPinned      bool
+		expErrContains string
+	}{
+		"missing without repair": {
+			exp: []cacheCheckResult{
+				{CodeID: 1, Status: cacheStatusOK},
+				{CodeID: 2, Pinned: true, Status: cacheStatusMissing, Error: "code not found"},
+			},
+		},
+		"repaired": {
+			fetch: func(codeID uint64) ([]byte, error) { return chainCodes[codeID], nil },
+			exp: []cacheCheckResult{
+				{CodeID: 1, Status: cacheStatusOK},
+				{CodeID: 2, Pinned: true, Status: cacheStatusRepaired},
+			},
+			expExecutable: true,
+			expPinned:     true,
+		},
+		"fetched code does not match checksum": {
+			fetch: func(codeID uint64) ([]byte, error) { return hackatomCode, nil },
+			exp: []cacheCheckResult{
+				{CodeID: 1, Status: cacheStatusOK},
+				{CodeID: 2, Pinned: true, Status: cacheStatusMissing},
+			},
+			expErrContains: "repair: checksum mismatch",
+		},
+		"fetch fails": {
+			fetch: func(codeID uint64) ([]byte, error) { return nil, errors.New("testing") },
+			exp: []cacheCheckResult{
+				{CodeID: 1, Status: cacheStatusOK},
+				{CodeID: 2, Pinned: true, Status: cacheStatusMissing, Error: "repair: testing"},
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			vm, cache, pinned := newCacheMockWasmEngine()
+			for _, code := range chainCodes {
+				_, err := vm.StoreCodeUnchecked(code)
+				require.NoError(t, err)
+			}
+			// the code file of the pinned code got lost
+			delete(cache, string(reflectChecksum))
+
+			// when
+			got := verifyCache(vm, stored, spec.fetch)
+
+			// then
+			if spec.expErrContains != "" {
+				require.Len(t, got, len(spec.exp))
+				assert.Contains(t, got[1].Error, spec.expErrContains)
+				got[1].Error = ""
+			}
+			assert.Equal(t, spec.exp, got)
+			_, _, gotErr := vm.Execute(reflectChecksum, wasmvmtypes.Env{}, wasmvmtypes.MessageInfo{}, []byte(`{}`), nil, wasmvm.GoAPI{}, nil, nil, 0, wasmvmtypes.UFraction{})
+			if spec.expExecutable {
+				assert.NoError(t, gotErr)
+			} else {
+				assert.Error(t, gotErr)
+			}
+			_, gotPinned := pinned[string(reflectChecksum)]
+			assert.Equal(t, spec.expPinned, gotPinned)
+		})
+	}
+}
+
+func TestPrintCacheCheckResults(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printCacheCheckResults(&out, []cacheCheckResult{
+		{CodeID: 1, Status: cacheStatusOK},
+		{CodeID: 2, Pinned: true, Status: cacheStatusRepaired},
+		{CodeID: 3, Status: cacheStatusMissing, Error: "code not found"},
+	}))
+	exp := "CODE ID  PINNED  STATUS\n" +
+		"2        true    repaired\n" +
+		"3        false   missing: code not found\n" +
+		"3 codes checked, 1 missing, 1 repaired\n"
+	assert.Equal(t, exp, out.String())
+}
+
+// newCacheMockWasmEngine returns a mock VM with the code cache and pinned checksums kept in memory
+func newCacheMockWasmEngine() (*wasmtesting.MockWasmEngine, map[string][]byte, map[string]struct{}) {
+	cache := make(map[string][]byte)
+	pinned := make(map[string]struct{})
+	vm := &wasmtesting.MockWasmEngine{
+		StoreCodeUncheckedFn: func(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
+			checksum, err := wasmvm.CreateChecksum(code)
+			if err != nil {
+				return nil, err
+			}
+			cache[string(checksum)] = code
+			return checksum, nil
+		},
+		GetCodeFn: func(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+			code, ok := cache[string(checksum)]
+			if !ok {
+				return nil, errors.New("code not found")
+			}
+			return code, nil
+		},
+		PinFn: func(checksum wasmvm.Checksum) error {
+			if _, ok := cache[string(checksum)]; !ok {
+				return errors.New("code not found")
+			}
+			pinned[string(checksum)] = struct{}{}
+			return nil
+		},
+		ExecuteFn: func(checksum wasmvm.Checksum, _ wasmvmtypes.Env, _ wasmvmtypes.MessageInfo, _ []byte, _ wasmvm.KVStore, _ wasmvm.GoAPI, _ wasmvm.Querier, _ wasmvm.GasMeter, _ uint64, _ wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+			if _, ok := cache[string(checksum)]; !ok {
+				return nil, 0, errors.New("file not found")
+			}
+			return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 0, nil
+		},
+	}
+	return vm, cache, pinned
+}
@@ -52,6 +52,8 @@ func TestModuleMigrations(t *testing.T) {
 			exp: types.Params{
 				CodeUploadAccess:             types.AllowNobody,
 				InstantiateDefaultPermission: types.AccessTypeNobody,
+				DefaultAdminPolicy:           types.DefaultAdminPolicyError,
+				IndexContractLabels:          true,
 			},
 		},
 		"with legacy one address type replaced": {
@@ -75,6 +77,8 @@ func TestModuleMigrations(t *testing.T) {
 			exp: types.Params{
 				CodeUploadAccess:             types.AccessTypeAnyOfAddresses.With(myAddress),
 				InstantiateDefaultPermission: types.AccessTypeNobody,
+				DefaultAdminPolicy:           types.DefaultAdminPolicyError,
+				IndexContractLabels:          true,
 			},
 		},
 		"fresh from genesis": {
@@ -99,7 +103,7 @@ func TestModuleMigrations(t *testing.T) {
 
 			// then
 			require.NoError(t, err)
-			var expModuleVersion uint64 = 4
+			var expModuleVersion uint64 = 8
 			assert.Equal(t, expModuleVersion, gotVM[types.ModuleName])
 			gotParams := wasmApp.WasmKeeper.GetParams(ctx)
 			assert.Equal(t, spec.exp, gotParams)
@@ -143,7 +147,7 @@ func TestAccessConfigMigrations(t *testing.T) {
 
 	// then
 	require.NoError(t, err)
-	var expModuleVersion uint64 = 4
+	var expModuleVersion uint64 = 8
 	assert.Equal(t, expModuleVersion, gotVM[types.ModuleName])
 
 	// any address was not migrated
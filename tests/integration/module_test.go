@@ -503,6 +503,16 @@ func TestReadNodeConfig(t *testing.T) {
 				ContractDebugMode:  true,
 			},
 		},
+		"set execution receipts limit via opts": {
+			src: AppOptionsMock{
+				"wasm.execution_receipts_limit": 4,
+			},
+			exp: types.NodeConfig{
+				SmartQueryGasLimit:     defaults.SmartQueryGasLimit,
+				MemoryCacheSize:        defaults.MemoryCacheSize,
+				ExecutionReceiptsLimit: 4,
+			},
+		},
 		"all defaults when no options set": {
 			src: AppOptionsMock{},
 			exp: defaults,
@@ -513,15 +523,17 @@ func TestReadNodeConfig(t *testing.T) {
 		},
 		"custom config template values": {
 			src: withViper(types.ConfigTemplate(types.NodeConfig{
-				SimulationGasLimit: &one,
-				SmartQueryGasLimit: 2,
-				MemoryCacheSize:    3,
+				SimulationGasLimit:     &one,
+				SmartQueryGasLimit:     2,
+				MemoryCacheSize:        3,
+				ExecutionReceiptsLimit: 4,
 			})),
 			exp: types.NodeConfig{
-				SimulationGasLimit: &one,
-				SmartQueryGasLimit: 2,
-				MemoryCacheSize:    3,
-				ContractDebugMode:  false,
+				SimulationGasLimit:     &one,
+				SmartQueryGasLimit:     2,
+				MemoryCacheSize:        3,
+				ContractDebugMode:      false,
+				ExecutionReceiptsLimit: 4,
 			},
 		},
 	}
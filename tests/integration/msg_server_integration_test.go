@@ -3,17 +3,24 @@ package integration
 import (
 	_ "embed"
 	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 
 	"github.com/CosmWasm/wasmd/app"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
@@ -145,6 +152,22 @@ func TestUpdateParams(t *testing.T) {
 	}
 }
 
+func TestUpdateParamsIndexContractLabels(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContext(false)
+
+	params := wasmApp.WasmKeeper.GetParams(ctx)
+	params.IndexContractLabels = !params.IndexContractLabels
+	msg := &types.MsgUpdateParams{Authority: wasmApp.WasmKeeper.GetAuthority(), Params: params}
+
+	// when
+	_, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+	// then
+	require.ErrorIs(t, err, types.ErrInvalid)
+	assert.NotEqual(t, params.IndexContractLabels, wasmApp.WasmKeeper.GetParams(ctx).IndexContractLabels)
+}
+
 func TestAddCodeUploadParamsAddresses(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContext(false)
@@ -975,6 +998,82 @@ func TestInstantiateContract2(t *testing.T) {
 	}
 }
 
+func TestInstantiateDefaultAdminPolicy(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, creator := testdata.KeyTestPubAddr()
+	_, _, otherAdmin := testdata.KeyTestPubAddr()
+
+	storeMsg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = wasmContract
+		m.Sender = creator.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(storeMsg)(ctx, storeMsg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+	codeID := storeCodeResponse.CodeID
+
+	instantiateVariants := map[string]func(admin string, noAdmin bool) sdk.Msg{
+		"instantiate": func(admin string, noAdmin bool) sdk.Msg {
+			return &types.MsgInstantiateContract{Sender: creator.String(), Admin: admin, NoAdmin: noAdmin, CodeID: codeID, Label: "test", Msg: []byte(`{}`)}
+		},
+		"instantiate2": func(admin string, noAdmin bool) sdk.Msg {
+			return &types.MsgInstantiateContract2{Sender: creator.String(), Admin: admin, NoAdmin: noAdmin, CodeID: codeID, Label: "test", Msg: []byte(`{}`), Salt: []byte("salt")}
+		},
+	}
+	specs := map[string]struct {
+		policy   types.DefaultAdminPolicy
+		admin    string
+		noAdmin  bool
+		expAdmin string
+	}{
+		"unspecified - admin":   {policy: types.DefaultAdminPolicyUnspecified, admin: otherAdmin.String(), expAdmin: otherAdmin.String()},
+		"unspecified - empty":   {policy: types.DefaultAdminPolicyUnspecified},
+		"unspecified - noAdmin": {policy: types.DefaultAdminPolicyUnspecified, noAdmin: true},
+		"error - admin":         {policy: types.DefaultAdminPolicyError, admin: otherAdmin.String(), expAdmin: otherAdmin.String()},
+		"error - empty":         {policy: types.DefaultAdminPolicyError},
+		"error - noAdmin":       {policy: types.DefaultAdminPolicyError, noAdmin: true},
+		"creator - admin":       {policy: types.DefaultAdminPolicyCreator, admin: otherAdmin.String(), expAdmin: otherAdmin.String()},
+		"creator - empty":       {policy: types.DefaultAdminPolicyCreator, expAdmin: creator.String()},
+		"creator - noAdmin":     {policy: types.DefaultAdminPolicyCreator, noAdmin: true},
+		"none - admin":          {policy: types.DefaultAdminPolicyNone, admin: otherAdmin.String(), expAdmin: otherAdmin.String()},
+		"none - empty":          {policy: types.DefaultAdminPolicyNone},
+		"none - noAdmin":        {policy: types.DefaultAdminPolicyNone, noAdmin: true},
+	}
+	for variant, newMsg := range instantiateVariants {
+		for name, spec := range specs {
+			t.Run(variant+" - "+name, func(t *testing.T) {
+				ctx, _ := ctx.CacheContext()
+				params := wasmApp.WasmKeeper.GetParams(ctx)
+				params.DefaultAdminPolicy = spec.policy
+				require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+				msg := newMsg(spec.admin, spec.noAdmin)
+
+				// when
+				rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+				// then
+				require.NoError(t, err)
+				var contractAddr string
+				switch msg.(type) {
+				case *types.MsgInstantiateContract:
+					var result types.MsgInstantiateContractResponse
+					require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+					contractAddr = result.Address
+				case *types.MsgInstantiateContract2:
+					var result types.MsgInstantiateContract2Response
+					require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+					contractAddr = result.Address
+				}
+				info := wasmApp.WasmKeeper.GetContractInfo(ctx, sdk.MustAccAddressFromBech32(contractAddr))
+				require.NotNil(t, info)
+				assert.Equal(t, spec.expAdmin, info.Admin)
+			})
+		}
+	}
+}
+
 func TestUpdateInstantiateConfig(t *testing.T) {
 	wasmApp := app.Setup(t)
 	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
@@ -1227,3 +1326,864 @@ func TestUpdateContractLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestSetContractState(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myAddress       sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                      = wasmApp.WasmKeeper.GetAuthority()
+		_, _, otherAddr                = testdata.KeyTestPubAddr()
+	)
+
+	// setup
+	msg := &types.MsgStoreAndInstantiateContract{
+		Authority:             authority,
+		WASMByteCode:          wasmContract,
+		InstantiatePermission: &types.AllowEverybody,
+		Admin:                 myAddress.String(),
+		Label:                 "test",
+		Msg:                   []byte(`{}`),
+		Funds:                 sdk.Coins{},
+	}
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+	require.NoError(t, err)
+	var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+	contractAddr, err := sdk.AccAddressFromBech32(storeAndInstantiateResponse.Address)
+	require.NoError(t, err)
+
+	models := []types.Model{
+		{Key: []byte("foo"), Value: []byte(`"bar"`)},
+		{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
+	}
+	specs := map[string]struct {
+		allowRawWrites bool
+		authority      string
+		contract       string
+		expErr         error
+	}{
+		"authority with writes allowed": {
+			allowRawWrites: true,
+			authority:      authority,
+			contract:       contractAddr.String(),
+		},
+		"authority with writes disabled": {
+			authority: authority,
+			contract:  contractAddr.String(),
+			expErr:    types.ErrRawStateWritesDisabled,
+		},
+		"other address": {
+			allowRawWrites: true,
+			authority:      otherAddr.String(),
+			contract:       contractAddr.String(),
+			expErr:         types.ErrInvalid,
+		},
+		"unknown contract": {
+			allowRawWrites: true,
+			authority:      authority,
+			contract:       myAddress.String(),
+			expErr:         types.ErrNoSuchContractFn(myAddress.String()),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			// the param is set at genesis
+			params := wasmApp.WasmKeeper.GetParams(ctx)
+			params.AllowRawStateWrites = spec.allowRawWrites
+			require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+
+			// when
+			msgSetState := &types.MsgSetContractState{
+				Authority: spec.authority,
+				Contract:  spec.contract,
+				Models:    models,
+			}
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msgSetState)(ctx, msgSetState)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				assert.Nil(t, wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("foo")))
+				return
+			}
+			require.NoError(t, err)
+			for _, m := range models {
+				assert.Equal(t, m.Value, wasmApp.WasmKeeper.QueryRaw(ctx, contractAddr, m.Key))
+			}
+			var found bool
+			for _, e := range rsp.Events {
+				if e.Type != types.EventTypeSetContractState {
+					continue
+				}
+				found = true
+				attr, ok := sdk.Event(e).GetAttribute(types.AttributeKeyKeyCount)
+				require.True(t, ok)
+				assert.Equal(t, "2", attr.Value)
+			}
+			assert.True(t, found)
+		})
+	}
+
+	t.Run("param can not be changed by update params", func(t *testing.T) {
+		ctx, _ := ctx.CacheContext()
+		params := wasmApp.WasmKeeper.GetParams(ctx)
+		params.AllowRawStateWrites = true
+		msgUpdateParams := &types.MsgUpdateParams{Authority: authority, Params: params}
+		_, err := wasmApp.MsgServiceRouter().Handler(msgUpdateParams)(ctx, msgUpdateParams)
+		require.ErrorIs(t, err, types.ErrInvalid)
+		assert.False(t, wasmApp.WasmKeeper.GetParams(ctx).AllowRawStateWrites)
+	})
+}
+
+func TestSetFeelessExecutions(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myContract      sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                      = wasmApp.WasmKeeper.GetAuthority()
+		_, _, otherAddr                = testdata.KeyTestPubAddr()
+	)
+	myConfig := types.FeelessExecutions{
+		Allowed: []types.FeelessExecution{{Contract: myContract.String(), MsgKey: "push_price"}},
+		MaxGas:  200_000,
+	}
+	specs := map[string]struct {
+		authority string
+		src       types.FeelessExecutions
+		exp       types.FeelessExecutions
+		expErr    error
+	}{
+		"authority sets list": {
+			authority: authority,
+			src:       myConfig,
+			exp:       myConfig,
+		},
+		"authority clears list": {
+			authority: authority,
+			src:       types.FeelessExecutions{},
+			exp:       types.FeelessExecutions{},
+		},
+		"other address": {
+			authority: otherAddr.String(),
+			src:       myConfig,
+			exp:       types.FeelessExecutions{Allowed: []types.FeelessExecution{{Contract: myContract.String(), MsgKey: "other"}}, MaxGas: 1},
+			expErr:    types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			params := wasmApp.WasmKeeper.GetParams(ctx)
+			params.FeelessExecutions = types.FeelessExecutions{Allowed: []types.FeelessExecution{{Contract: myContract.String(), MsgKey: "other"}}, MaxGas: 1}
+			require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+
+			// when
+			msg := &types.MsgSetFeelessExecutions{
+				Authority:         spec.authority,
+				FeelessExecutions: spec.src,
+			}
+			_, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, spec.exp, wasmApp.WasmKeeper.GetParams(ctx).FeelessExecutions)
+		})
+	}
+}
+
+func TestSetContractGasBudgets(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		myContract      sdk.AccAddress = make([]byte, types.ContractAddrLen)
+		authority                      = wasmApp.WasmKeeper.GetAuthority()
+		_, _, otherAddr                = testdata.KeyTestPubAddr()
+	)
+	myBudgets := []types.ContractGasBudget{{Contract: myContract.String(), MaxGasPerBlock: 1_000_000}}
+	oldBudgets := []types.ContractGasBudget{{Contract: myContract.String(), MaxGasPerBlock: 1}}
+	specs := map[string]struct {
+		authority string
+		src       []types.ContractGasBudget
+		exp       []types.ContractGasBudget
+		expErr    error
+	}{
+		"authority sets budgets": {
+			authority: authority,
+			src:       myBudgets,
+			exp:       myBudgets,
+		},
+		"authority clears budgets": {
+			authority: authority,
+		},
+		"other address": {
+			authority: otherAddr.String(),
+			src:       myBudgets,
+			exp:       oldBudgets,
+			expErr:    types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			params := wasmApp.WasmKeeper.GetParams(ctx)
+			params.ContractGasBudgets = oldBudgets
+			require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+
+			// when
+			msg := &types.MsgSetContractGasBudgets{
+				Authority: spec.authority,
+				Budgets:   spec.src,
+			}
+			_, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, spec.exp, wasmApp.WasmKeeper.GetParams(ctx).ContractGasBudgets)
+		})
+	}
+}
+
+func TestCodeUploadProvenance(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+
+	var (
+		authority          = wasmApp.WasmKeeper.GetAuthority()
+		_, _, myAddr       = testdata.KeyTestPubAddr()
+		_, _, granteeAddr  = testdata.KeyTestPubAddr()
+		myTxBytes          = []byte("my tx")
+		expTxHash          = tmhash.Sum(myTxBytes)
+		checksum, checkErr = wasmvm.CreateChecksum(wasmContract)
+	)
+	require.NoError(t, checkErr)
+	require.NoError(t, wasmApp.AuthzKeeper.SaveGrant(ctx, granteeAddr, myAddr, authz.NewGenericAuthorization(sdk.MsgTypeURL(&types.MsgStoreCode{})), nil))
+
+	specs := map[string]struct {
+		txBytes    []byte
+		msg        sdk.Msg
+		expCreator string
+		expRecord  *types.CodeProvenance
+	}{
+		"direct upload": {
+			txBytes:    myTxBytes,
+			msg:        &types.MsgStoreCode{Sender: myAddr.String(), WASMByteCode: wasmContract},
+			expCreator: myAddr.String(),
+			expRecord:  &types.CodeProvenance{TxHash: expTxHash},
+		},
+		"authz wrapped upload": {
+			txBytes: myTxBytes,
+			msg: func() sdk.Msg {
+				msg := authz.NewMsgExec(granteeAddr, []sdk.Msg{&types.MsgStoreCode{Sender: myAddr.String(), WASMByteCode: wasmContract}})
+				return &msg
+			}(),
+			expCreator: myAddr.String(),
+			expRecord:  &types.CodeProvenance{TxHash: expTxHash},
+		},
+		"gov stored code": {
+			msg: &types.MsgStoreAndInstantiateContract{
+				Authority:    authority,
+				WASMByteCode: wasmContract,
+				Label:        "test",
+				Msg:          []byte(`{}`),
+				Source:       "https://example.com/reflect",
+				Builder:      "cosmwasm/workspace-optimizer:0.12.9",
+				CodeHash:     checksum,
+			},
+			expCreator: authority,
+			expRecord:  &types.CodeProvenance{Source: "https://example.com/reflect", Builder: "cosmwasm/workspace-optimizer:0.12.9"},
+		},
+		"gov stored code in tx": {
+			txBytes: myTxBytes,
+			msg: &types.MsgStoreAndInstantiateContract{
+				Authority:    authority,
+				WASMByteCode: wasmContract,
+				Label:        "test",
+				Msg:          []byte(`{}`),
+			},
+			expCreator: authority,
+			expRecord:  &types.CodeProvenance{TxHash: expTxHash},
+		},
+		"without tx and source": {
+			msg:        &types.MsgStoreCode{Sender: myAddr.String(), WASMByteCode: wasmContract},
+			expCreator: myAddr.String(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			ctx = ctx.WithTxBytes(spec.txBytes)
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(spec.msg)(ctx, spec.msg)
+
+			// then
+			require.NoError(t, err)
+			nextCodeID, err := wasmApp.WasmKeeper.PeekAutoIncrementID(ctx, types.KeySequenceCodeID)
+			require.NoError(t, err)
+			codeID := nextCodeID - 1
+			assert.Equal(t, spec.expCreator, wasmApp.WasmKeeper.GetCodeInfo(ctx, codeID).Creator)
+			assert.Equal(t, spec.expRecord, wasmApp.WasmKeeper.GetCodeProvenance(ctx, codeID))
+			// and exposed in the code info query
+			res, err := keeper.Querier(&wasmApp.WasmKeeper).CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: codeID})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expRecord, res.Provenance)
+			// and emitted
+			var gotTxHash string
+			for _, e := range rsp.Events {
+				if e.Type != types.EventTypeStoreCode {
+					continue
+				}
+				for _, a := range e.Attributes {
+					if a.Key == types.AttributeKeyTxHash {
+						gotTxHash = a.Value
+					}
+				}
+			}
+			var expEventTxHash string
+			if spec.expRecord != nil && len(spec.expRecord.TxHash) != 0 {
+				expEventTxHash = spec.expRecord.TxHash.String()
+			}
+			assert.Equal(t, expEventTxHash, gotTxHash)
+		})
+	}
+}
+
+func TestFreezeCodeByChecksum(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, creator := testdata.KeyTestPubAddr()
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+	authority := wasmApp.WasmKeeper.GetAuthority()
+
+	storeCode := func(t *testing.T, ctx sdk.Context, code []byte) uint64 {
+		msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+			m.WASMByteCode = code
+			m.Sender = creator.String()
+		})
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+		var result types.MsgStoreCodeResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+		return result.CodeID
+	}
+	checksum, err := wasmvm.CreateChecksum(wasmContract)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		authority string
+		checksum  []byte
+		expFrozen bool
+		expErr    error
+	}{
+		"authority freezes all codes with checksum": {
+			authority: authority,
+			checksum:  checksum[:],
+			expFrozen: true,
+		},
+		"other address": {
+			authority: otherAddr.String(),
+			checksum:  checksum[:],
+			expErr:    types.ErrInvalid,
+		},
+		"unknown checksum": {
+			authority: authority,
+			checksum:  make([]byte, 32),
+			expErr:    types.ErrNotFound,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			codeIDs := []uint64{storeCode(t, ctx, wasmContract), storeCode(t, ctx, wasmContract)}
+			otherCodeID := storeCode(t, ctx, hackatomContract)
+			expOtherConfig := wasmApp.WasmKeeper.GetCodeInfo(ctx, otherCodeID).InstantiateConfig
+			// with contracts running the code
+			for i := 0; i < 2; i++ {
+				instMsg := &types.MsgInstantiateContract{Sender: creator.String(), CodeID: codeIDs[0], Label: "frozen", Msg: []byte("{}"), Funds: sdk.Coins{}}
+				_, err := wasmApp.MsgServiceRouter().Handler(instMsg)(ctx, instMsg)
+				require.NoError(t, err)
+			}
+
+			// proposal submitted
+			msg := &types.MsgFreezeCodeByChecksum{
+				Authority: spec.authority,
+				Checksum:  spec.checksum,
+			}
+			// code with the same checksum stored before the execution
+			codeIDs = append(codeIDs, storeCode(t, ctx, wasmContract))
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+			} else {
+				require.NoError(t, err)
+				var result types.MsgFreezeCodeByChecksumResponse
+				require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+				assert.Equal(t, codeIDs, result.CodeIDs)
+				// and a management event per code and per contract
+				var gotCodeEvents, gotContractEvents int
+				for _, e := range rsp.Events {
+					if e.Type != proto.MessageName(&types.EventContractManagementChanged{}) {
+						continue
+					}
+					evt, err := sdk.ParseTypedEvent(abci.Event(e))
+					require.NoError(t, err)
+					if evt.(*types.EventContractManagementChanged).ContractAddress == "" {
+						gotCodeEvents++
+					} else {
+						gotContractEvents++
+					}
+				}
+				assert.Equal(t, len(codeIDs), gotCodeEvents)
+				assert.Equal(t, 2, gotContractEvents)
+			}
+			for _, codeID := range codeIDs {
+				gotConfig := wasmApp.WasmKeeper.GetCodeInfo(ctx, codeID).InstantiateConfig
+				assert.Equal(t, spec.expFrozen, gotConfig.Equals(types.AllowNobody), "code %d", codeID)
+			}
+			assert.Equal(t, expOtherConfig, wasmApp.WasmKeeper.GetCodeInfo(ctx, otherCodeID).InstantiateConfig)
+		})
+	}
+}
+
+func TestDeprecateCode(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, creator := testdata.KeyTestPubAddr()
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+	authority := wasmApp.WasmKeeper.GetAuthority()
+
+	storeMsg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = hackatomContract
+		m.Sender = creator.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(storeMsg)(ctx, storeMsg)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+	codeID := storeCodeResponse.CodeID
+
+	specs := map[string]struct {
+		authority string
+		codeID    uint64
+		expErr    error
+	}{
+		"authority deprecates code": {
+			authority: authority,
+			codeID:    codeID,
+		},
+		"other address": {
+			authority: otherAddr.String(),
+			codeID:    codeID,
+			expErr:    types.ErrInvalid,
+		},
+		"unknown code": {
+			authority: authority,
+			codeID:    codeID + 1,
+			expErr:    types.ErrNoSuchCodeFn(codeID + 1),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			msg := &types.MsgDeprecateCode{
+				Authority: spec.authority,
+				CodeID:    spec.codeID,
+			}
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			gotDeprecated := wasmApp.WasmKeeper.GetCodeInfo(ctx, codeID).Deprecated
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				assert.False(t, gotDeprecated)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, gotDeprecated)
+			expEvent := sdk.NewEvent(types.EventTypeDeprecateCode, sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)))
+			// the router collects the events in the result
+			assert.Contains(t, rsp.Events, abci.Event(expEvent))
+		})
+	}
+}
+
+func TestDeprecatedCodeUsage(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, creator := testdata.KeyTestPubAddr()
+	authority := wasmApp.WasmKeeper.GetAuthority()
+
+	storeCode := func(t *testing.T) uint64 {
+		msg := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+			m.WASMByteCode = hackatomContract
+			m.Sender = creator.String()
+		})
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+		var result types.MsgStoreCodeResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+		return result.CodeID
+	}
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{Verifier: creator, Beneficiary: creator})
+	require.NoError(t, err)
+	instantiateMsg := func(codeID uint64) *types.MsgInstantiateContract {
+		return &types.MsgInstantiateContract{
+			Sender: creator.String(),
+			Admin:  creator.String(),
+			CodeID: codeID,
+			Label:  "test",
+			Msg:    initMsgBz,
+			Funds:  sdk.Coins{},
+		}
+	}
+	instantiate := func(t *testing.T, codeID uint64) string {
+		msg := instantiateMsg(codeID)
+		rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+		var result types.MsgInstantiateContractResponse
+		require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+		return result.Address
+	}
+	migMsgBz, err := json.Marshal(struct {
+		Verifier sdk.AccAddress `json:"verifier"`
+	}{Verifier: creator})
+	require.NoError(t, err)
+	migrateMsg := func(contract string, codeID uint64) *types.MsgMigrateContract {
+		return &types.MsgMigrateContract{
+			Sender:   creator.String(),
+			Contract: contract,
+			CodeID:   codeID,
+			Msg:      migMsgBz,
+		}
+	}
+
+	deprecatedCodeID, otherCodeID := storeCode(t), storeCode(t)
+	deprecatedCodeContract := instantiate(t, deprecatedCodeID)
+	otherCodeContract := instantiate(t, otherCodeID)
+	deprecateMsg := &types.MsgDeprecateCode{Authority: authority, CodeID: deprecatedCodeID}
+	_, err = wasmApp.MsgServiceRouter().Handler(deprecateMsg)(ctx, deprecateMsg)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		msg    sdk.Msg
+		expErr error
+	}{
+		"instantiate deprecated code rejected": {
+			msg:    instantiateMsg(deprecatedCodeID),
+			expErr: types.ErrCodeDeprecated,
+		},
+		"instantiate other code": {
+			msg: instantiateMsg(otherCodeID),
+		},
+		"migrate to deprecated code rejected": {
+			msg:    migrateMsg(otherCodeContract, deprecatedCodeID),
+			expErr: types.ErrCodeDeprecated,
+		},
+		"migrate from deprecated code": {
+			msg: migrateMsg(deprecatedCodeContract, otherCodeID),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+
+			// when
+			_, err := wasmApp.MsgServiceRouter().Handler(spec.msg)(ctx, spec.msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPruneContractHistory(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+	authority := wasmApp.WasmKeeper.GetAuthority()
+	params := wasmApp.WasmKeeper.GetParams(ctx)
+	params.RecordContractInfoChanges = true
+	require.NoError(t, wasmApp.WasmKeeper.SetParams(ctx, params))
+
+	// setup contract with init and 3 admin change entries
+	storeAndInstantiateMsg := &types.MsgStoreAndInstantiateContract{
+		Authority:             authority,
+		WASMByteCode:          wasmContract,
+		InstantiatePermission: &types.AllowEverybody,
+		Admin:                 authority,
+		Label:                 "test",
+		Msg:                   []byte(`{}`),
+		Funds:                 sdk.Coins{},
+	}
+	ctx = ctx.WithBlockHeight(1)
+	rsp, err := wasmApp.MsgServiceRouter().Handler(storeAndInstantiateMsg)(ctx, storeAndInstantiateMsg)
+	require.NoError(t, err)
+	var storeAndInstantiateResponse types.MsgStoreAndInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeAndInstantiateResponse))
+	contractAddr := storeAndInstantiateResponse.Address
+	// the admin alternates between the authority and the other address at heights 2, 3 and 4
+	admins := []string{authority, otherAddr.String()}
+	for i := 0; i < 3; i++ {
+		ctx = ctx.WithBlockHeight(int64(i + 2))
+		msg := &types.MsgUpdateAdmin{Sender: admins[i%2], NewAdmin: admins[(i+1)%2], Contract: contractAddr}
+		_, err = wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+		require.NoError(t, err)
+	}
+	fullHistory := wasmApp.WasmKeeper.GetContractHistory(ctx, sdk.MustAccAddressFromBech32(contractAddr))
+	require.Len(t, fullHistory, 4)
+	require.Equal(t, types.ContractCodeHistoryOperationTypeInit, fullHistory[0].Operation)
+
+	specs := map[string]struct {
+		authority  string
+		contract   string
+		expErr     error
+		expPruned  uint64
+		expHistory []types.ContractCodeHistoryEntry
+	}{
+		"authority prunes history": {
+			authority:  authority,
+			contract:   contractAddr,
+			expPruned:  2,
+			expHistory: []types.ContractCodeHistoryEntry{fullHistory[0], fullHistory[3]},
+		},
+		"other address": {
+			authority:  otherAddr.String(),
+			contract:   contractAddr,
+			expErr:     types.ErrInvalid,
+			expHistory: fullHistory,
+		},
+		"unknown contract": {
+			authority:  authority,
+			contract:   otherAddr.String(),
+			expErr:     types.ErrNoSuchContractFn(otherAddr.String()),
+			expHistory: fullHistory,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			msg := &types.MsgPruneContractHistory{
+				Authority: spec.authority,
+				Contract:  spec.contract,
+				KeepLastN: 1,
+			}
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			gotHistory := wasmApp.WasmKeeper.GetContractHistory(ctx, sdk.MustAccAddressFromBech32(contractAddr))
+			assert.Equal(t, spec.expHistory, gotHistory)
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				return
+			}
+			require.NoError(t, err)
+			var pruneResponse types.MsgPruneContractHistoryResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &pruneResponse))
+			assert.Equal(t, spec.expPruned, pruneResponse.Pruned)
+
+			// and the remaining history can still be queried
+			q := keeper.Querier(&wasmApp.WasmKeeper)
+			historyRsp, err := q.ContractHistory(ctx, &types.QueryContractHistoryRequest{Address: contractAddr})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expHistory, historyRsp.Entries)
+			infoRsp, err := q.ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: contractAddr, Height: 4})
+			require.NoError(t, err)
+			assert.Equal(t, otherAddr.String(), infoRsp.Admin)
+			_, err = q.ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: contractAddr, Height: 2})
+			assert.ErrorIs(t, err, types.ErrNotFound)
+		})
+	}
+}
+
+func TestForceMigrateWithoutAdminCheck(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, creator := testdata.KeyTestPubAddr()
+	_, _, otherAddr := testdata.KeyTestPubAddr()
+	authority := wasmApp.WasmKeeper.GetAuthority()
+
+	// store code
+	msgStoreCode := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = hackatomContract
+		m.Sender = creator.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msgStoreCode)(ctx, msgStoreCode)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+
+	// instantiate contract without admin
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{Verifier: creator, Beneficiary: creator})
+	require.NoError(t, err)
+	msgInstantiate := &types.MsgInstantiateContract{
+		Sender: creator.String(),
+		CodeID: storeCodeResponse.CodeID,
+		Label:  "test",
+		Msg:    initMsgBz,
+		Funds:  sdk.Coins{},
+	}
+	rsp, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+	require.NoError(t, err)
+	var instantiateResponse types.MsgInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+
+	migMsgBz, err := json.Marshal(map[string]any{"verifier": otherAddr})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		authority string
+		expErr    error
+	}{
+		"authority migrates contract without admin": {
+			authority: authority,
+		},
+		"other address": {
+			authority: otherAddr.String(),
+			expErr:    types.ErrInvalid,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			msg := &types.MsgForceMigrateWithoutAdminCheck{
+				Authority: spec.authority,
+				Contracts: []string{instantiateResponse.Address},
+				CodeID:    storeCodeResponse.CodeID,
+				Msg:       migMsgBz,
+			}
+
+			// when
+			rsp, err := wasmApp.MsgServiceRouter().Handler(msg)(ctx, msg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, err, spec.expErr)
+				return
+			}
+			require.NoError(t, err)
+			var result types.MsgForceMigrateWithoutAdminCheckResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &result))
+			assert.Equal(t, []types.ForceMigrateResult{{Contract: instantiateResponse.Address, Success: true}}, result.Results)
+			history := wasmApp.WasmKeeper.GetContractHistory(ctx, sdk.MustAccAddressFromBech32(instantiateResponse.Address))
+			assert.Equal(t, types.ContractCodeHistoryOperationTypeMigrate, history[len(history)-1].Operation)
+		})
+	}
+}
+
+func TestExecuteContractCompat(t *testing.T) {
+	wasmApp := app.Setup(t)
+	ctx := wasmApp.BaseApp.NewContextLegacy(false, tmproto.Header{Time: time.Now()})
+	_, _, creator := testdata.KeyTestPubAddr()
+	_, _, beneficiary := testdata.KeyTestPubAddr()
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("stake", 100), sdk.NewInt64Coin("ucoin", 100))
+	require.NoError(t, wasmApp.BankKeeper.MintCoins(ctx, minttypes.ModuleName, deposit))
+	require.NoError(t, wasmApp.BankKeeper.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, creator, deposit))
+
+	// store code and instantiate contract
+	msgStoreCode := types.MsgStoreCodeFixture(func(m *types.MsgStoreCode) {
+		m.WASMByteCode = hackatomContract
+		m.Sender = creator.String()
+	})
+	rsp, err := wasmApp.MsgServiceRouter().Handler(msgStoreCode)(ctx, msgStoreCode)
+	require.NoError(t, err)
+	var storeCodeResponse types.MsgStoreCodeResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &storeCodeResponse))
+	initMsgBz, err := json.Marshal(keeper.HackatomExampleInitMsg{Verifier: creator, Beneficiary: beneficiary})
+	require.NoError(t, err)
+	msgInstantiate := &types.MsgInstantiateContract{
+		Sender: creator.String(),
+		CodeID: storeCodeResponse.CodeID,
+		Label:  "test",
+		Msg:    initMsgBz,
+		Funds:  sdk.Coins{},
+	}
+	rsp, err = wasmApp.MsgServiceRouter().Handler(msgInstantiate)(ctx, msgInstantiate)
+	require.NoError(t, err)
+	var instantiateResponse types.MsgInstantiateContractResponse
+	require.NoError(t, wasmApp.AppCodec().Unmarshal(rsp.Data, &instantiateResponse))
+
+	specs := map[string]struct {
+		funds  sdk.Coins
+		compat string
+		expErr error
+	}{
+		"no funds": {},
+		"with funds": {
+			funds:  sdk.NewCoins(sdk.NewInt64Coin("stake", 5), sdk.NewInt64Coin("ucoin", 7)),
+			compat: "5stake,7ucoin",
+		},
+		"unsorted funds": {
+			compat: "7ucoin,5stake",
+			expErr: sdkerrors.ErrInvalidCoins,
+		},
+		"insufficient funds": {
+			funds:  sdk.NewCoins(sdk.NewInt64Coin("stake", 101)),
+			compat: "101stake",
+			expErr: sdkerrors.ErrInsufficientFunds,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			canonicalCtx, _ := ctx.CacheContext()
+			compatCtx, _ := ctx.CacheContext()
+			msg := &types.MsgExecuteContract{
+				Sender:   creator.String(),
+				Contract: instantiateResponse.Address,
+				Msg:      []byte(`{"release":{}}`),
+				Funds:    spec.funds,
+			}
+			compatMsg := &types.MsgExecuteContractCompat{
+				Sender:   msg.Sender,
+				Contract: msg.Contract,
+				Msg:      msg.Msg,
+				Funds:    spec.compat,
+			}
+
+			// when
+			compatRsp, compatErr := wasmApp.MsgServiceRouter().Handler(compatMsg)(compatCtx, compatMsg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, compatErr, spec.expErr)
+				return
+			}
+			require.NoError(t, compatErr)
+			canonicalRsp, err := wasmApp.MsgServiceRouter().Handler(msg)(canonicalCtx, msg)
+			require.NoError(t, err)
+
+			var canonicalResult types.MsgExecuteContractResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(canonicalRsp.Data, &canonicalResult))
+			var compatResult types.MsgExecuteContractCompatResponse
+			require.NoError(t, wasmApp.AppCodec().Unmarshal(compatRsp.Data, &compatResult))
+			assert.Equal(t, canonicalResult.Data, compatResult.Data)
+			assert.Equal(t, canonicalRsp.Events, compatRsp.Events)
+			assert.Equal(t, wasmApp.BankKeeper.GetAllBalances(canonicalCtx, beneficiary), wasmApp.BankKeeper.GetAllBalances(compatCtx, beneficiary))
+			assert.Equal(t, wasmApp.BankKeeper.GetAllBalances(canonicalCtx, creator), wasmApp.BankKeeper.GetAllBalances(compatCtx, creator))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagSkipAddressCheck = "skip-address-check"
+
+// address kinds of the instantiate permission addresses
+const (
+	addressKindAccount  = "account"
+	addressKindContract = "contract"
+	addressKindUnknown  = "unknown"
+)
+
+// resolvedAddress is an address of the instantiate permission with the kind found on chain
+type resolvedAddress struct {
+	address string
+	kind    string
+}
+
+// resolveAddressKinds queries the kind of each address. Contracts are resolved first as they have an account, too.
+// Addresses that are neither a contract nor an account are unknown.
+func resolveAddressKinds(ctx context.Context, authQuery authtypes.QueryClient, wasmQuery types.QueryClient, addrs []string) ([]resolvedAddress, error) {
+	r := make([]resolvedAddress, len(addrs))
+	for i, addr := range addrs {
+		r[i].address = addr
+		if _, err := wasmQuery.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: addr}); err == nil {
+			r[i].kind = addressKindContract
+			continue
+		}
+		_, err := authQuery.Account(ctx, &authtypes.QueryAccountRequest{Address: addr})
+		switch {
+		case err == nil:
+			r[i].kind = addressKindAccount
+		case status.Code(err) == codes.NotFound:
+			r[i].kind = addressKindUnknown
+		default:
+			return nil, fmt.Errorf("query account %s: %s", addr, err)
+		}
+	}
+	return r, nil
+}
+
+// printAddressKinds writes the resolved addresses as table followed by a warning for each unknown address
+func printAddressKinds(out io.Writer, addrs []resolvedAddress) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ADDRESS\tKIND")
+	for _, a := range addrs {
+		_, _ = fmt.Fprintf(w, "%s\t%s\n", a.address, a.kind)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, a := range addrs {
+		if a.kind == addressKindUnknown {
+			_, _ = fmt.Fprintf(out, "warning: address %s was never seen on chain, check for typos\n", a.address)
+		}
+	}
+	return nil
+}
+
+// checkInstantiatePermissionAddresses resolves the addresses of an any of addresses permission on chain and prints
+// their kinds before broadcast. Nothing is checked in offline mode or with --skip-address-check.
+func checkInstantiatePermissionAddresses(cmd *cobra.Command, clientCtx client.Context, perm *types.AccessConfig) error {
+	if perm == nil || perm.Permission != types.AccessTypeAnyOfAddresses {
+		return nil
+	}
+	if clientCtx.Offline {
+		recordPreflight(cmd.Context(), preflightInstantiatePermissionAddresses, preflightSkipped, "offline")
+		return nil
+	}
+	skip, err := cmd.Flags().GetBool(flagSkipAddressCheck)
+	if err != nil {
+		return fmt.Errorf("skip address check: %s", err)
+	}
+	if skip {
+		recordPreflight(cmd.Context(), preflightInstantiatePermissionAddresses, preflightSkipped, "--"+flagSkipAddressCheck)
+		return nil
+	}
+	addrs, err := resolveAddressKinds(cmd.Context(), authtypes.NewQueryClient(clientCtx), types.NewQueryClient(clientCtx), perm.Addresses)
+	if err != nil {
+		recordPreflightResult(cmd.Context(), preflightInstantiatePermissionAddresses, "", err)
+		return err
+	}
+	var unknown []string
+	for _, a := range addrs {
+		if a.kind == addressKindUnknown {
+			unknown = append(unknown, a.address)
+		}
+	}
+	var warning string
+	if len(unknown) != 0 {
+		warning = "never seen on chain: " + strings.Join(unknown, ", ")
+	}
+	recordPreflightResult(cmd.Context(), preflightInstantiatePermissionAddresses, warning, nil)
+	return printAddressKinds(cmd.ErrOrStderr(), addrs)
+}
+
+func addSkipAddressCheckFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagSkipAddressCheck, false, fmt.Sprintf("Skip querying the chain for the kind of the --%s addresses", flagInstantiateByAnyOfAddress))
+}
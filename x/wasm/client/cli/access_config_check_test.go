@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestResolveAddressKinds(t *testing.T) {
+	const (
+		myAccount  = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+		myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+		myUnknown  = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	authQuery := &addressKindsAuthQueryClient{
+		accounts: map[string]bool{myAccount: true, myContract: true},
+	}
+	wasmQuery := &addressKindsWasmQueryClient{contracts: map[string]bool{myContract: true}}
+
+	specs := map[string]struct {
+		src     []string
+		authErr error
+		exp     []resolvedAddress
+		expErr  bool
+	}{
+		"account": {
+			src: []string{myAccount},
+			exp: []resolvedAddress{{address: myAccount, kind: addressKindAccount}},
+		},
+		"contract": {
+			src: []string{myContract},
+			exp: []resolvedAddress{{address: myContract, kind: addressKindContract}},
+		},
+		"unknown": {
+			src: []string{myUnknown},
+			exp: []resolvedAddress{{address: myUnknown, kind: addressKindUnknown}},
+		},
+		"mixed": {
+			src: []string{myContract, myUnknown, myAccount},
+			exp: []resolvedAddress{
+				{address: myContract, kind: addressKindContract},
+				{address: myUnknown, kind: addressKindUnknown},
+				{address: myAccount, kind: addressKindAccount},
+			},
+		},
+		"account query fails": {
+			src:     []string{myAccount},
+			authErr: errors.New("testing"),
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			authQuery.err = spec.authErr
+			got, gotErr := resolveAddressKinds(context.Background(), authQuery, wasmQuery, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestPrintAddressKinds(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printAddressKinds(&out, []resolvedAddress{
+		{address: "contract1", kind: addressKindContract},
+		{address: "addr2", kind: addressKindUnknown},
+		{address: "addr3", kind: addressKindAccount},
+	}))
+	exp := "ADDRESS    KIND\n" +
+		"contract1  contract\n" +
+		"addr2      unknown\n" +
+		"addr3      account\n" +
+		"warning: address addr2 was never seen on chain, check for typos\n"
+	assert.Equal(t, exp, out.String())
+}
+
+// addressKindsAuthQueryClient returns an account for the known addresses only
+type addressKindsAuthQueryClient struct {
+	authtypes.QueryClient
+	accounts map[string]bool
+	err      error
+}
+
+func (m *addressKindsAuthQueryClient) Account(_ context.Context, req *authtypes.QueryAccountRequest, _ ...grpc.CallOption) (*authtypes.QueryAccountResponse, error) {
+	switch {
+	case m.err != nil:
+		return nil, m.err
+	case !m.accounts[req.Address]:
+		return nil, status.Error(codes.NotFound, "account not found")
+	}
+	return &authtypes.QueryAccountResponse{}, nil
+}
+
+// addressKindsWasmQueryClient returns the contract info for the known contracts only
+type addressKindsWasmQueryClient struct {
+	types.QueryClient
+	contracts map[string]bool
+}
+
+func (m *addressKindsWasmQueryClient) ContractInfo(_ context.Context, req *types.QueryContractInfoRequest, _ ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	if !m.contracts[req.Address] {
+		return nil, status.Error(codes.NotFound, "no such contract")
+	}
+	return &types.QueryContractInfoResponse{Address: req.Address}, nil
+}
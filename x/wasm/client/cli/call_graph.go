@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	callGraphFormatTree = "tree"
+	callGraphFormatDot  = "dot"
+)
+
+// callGraphActions maps the wasm event types to the node actions of the call graph
+var callGraphActions = map[string]string{
+	types.EventTypeInstantiate: "instantiate",
+	types.EventTypeExecute:     "execute",
+	types.EventTypeMigrate:     "migrate",
+	types.EventTypeSudo:        "sudo",
+	types.EventTypeReply:       "reply",
+}
+
+// callGraph is the tree of contract calls of a tx, grouped by tx message
+type callGraph struct {
+	TxHash    string
+	GasWanted int64
+	GasUsed   int64
+	Msgs      []callGraphMsg
+	// Flat is set when the events have no call depth attributes, as for txs before they were added.
+	// The calls are listed in event order then.
+	Flat bool
+}
+
+type callGraphMsg struct {
+	MsgIndex int
+	Calls    []*callGraphNode
+}
+
+type callGraphNode struct {
+	Action   string
+	Contract string
+	CodeID   string
+	Children []*callGraphNode
+}
+
+// GetCmdCallGraph prints the contract calls of a tx as a tree
+func GetCmdCallGraph() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "call-graph [tx_hash]",
+		Short: "Print the contract calls of a tx as a tree",
+		Long: `Print the contract calls of a tx as a tree per tx message. The tree is built from the wasm events
+and their call depth attributes. Replies are shown below the contract that dispatched the submessage.
+For txs without call depth attributes, the calls are listed in event order without nesting.
+With --format dot, the graph is written in the Graphviz dot language.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			format, err := cmd.Flags().GetString(flagFormat)
+			if err != nil {
+				return err
+			}
+			if format != callGraphFormatTree && format != callGraphFormatDot {
+				return fmt.Errorf("unsupported format %q: use %s or %s", format, callGraphFormatTree, callGraphFormatDot)
+			}
+			res, err := authtx.QueryTx(clientCtx, args[0])
+			if err != nil {
+				return err
+			}
+			graph := buildCallGraph(res)
+			if format == callGraphFormatDot {
+				return renderCallGraphDot(cmd.OutOrStdout(), graph)
+			}
+			return renderCallGraphTree(cmd.OutOrStdout(), graph)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagFormat, callGraphFormatTree, "Output format: tree or dot")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// buildCallGraph returns the contract calls of the tx. The events of a call are emitted before the events of its
+// submessages. Reply events carry the call depth of the contract that dispatched the submessage.
+func buildCallGraph(res *sdk.TxResponse) callGraph {
+	graph := callGraph{TxHash: res.TxHash, GasWanted: res.GasWanted, GasUsed: res.GasUsed, Flat: true}
+	type stackEntry struct {
+		depth int
+		node  *callGraphNode
+	}
+	var (
+		msg   *callGraphMsg
+		stack []stackEntry
+	)
+	for _, e := range res.Events {
+		action, ok := callGraphActions[e.Type]
+		if !ok {
+			continue
+		}
+		node := &callGraphNode{
+			Action:   action,
+			Contract: eventAttribute(e, types.AttributeKeyContractAddr),
+			CodeID:   eventAttribute(e, types.AttributeKeyCodeID),
+		}
+		msgIndex, _ := strconv.Atoi(eventAttribute(e, attributeKeyMsgIndex))
+		if msg == nil || msg.MsgIndex != msgIndex {
+			graph.Msgs = append(graph.Msgs, callGraphMsg{MsgIndex: msgIndex})
+			msg = &graph.Msgs[len(graph.Msgs)-1]
+			stack = stack[:0]
+		}
+		var depth int
+		if v := eventAttribute(e, types.AttributeKeyCallDepth); v != "" {
+			graph.Flat = false
+			depth, _ = strconv.Atoi(v)
+			if action == callGraphActions[types.EventTypeReply] {
+				// the reply is a call into the dispatching contract
+				depth++
+			}
+		}
+		for len(stack) != 0 && stack[len(stack)-1].depth >= depth {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			msg.Calls = append(msg.Calls, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, stackEntry{depth: depth, node: node})
+	}
+	return graph
+}
+
+func eventAttribute(e abci.Event, key string) string {
+	for _, a := range e.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// label returns the action and the contract address of the node
+func (n callGraphNode) label() string {
+	if n.CodeID != "" {
+		return fmt.Sprintf("%s %s (code id %s)", n.Action, n.Contract, n.CodeID)
+	}
+	return fmt.Sprintf("%s %s", n.Action, n.Contract)
+}
+
+// renderCallGraphTree writes the calls indented by their depth
+func renderCallGraphTree(out io.Writer, graph callGraph) error {
+	if _, err := fmt.Fprintf(out, "tx %s gas used: %d gas wanted: %d\n", graph.TxHash, graph.GasUsed, graph.GasWanted); err != nil {
+		return err
+	}
+	if len(graph.Msgs) == 0 {
+		_, err := fmt.Fprintln(out, "no contract calls")
+		return err
+	}
+	if graph.Flat {
+		if _, err := fmt.Fprintln(out, "call depth not recorded: calls are listed in event order"); err != nil {
+			return err
+		}
+	}
+	var writeNodes func(nodes []*callGraphNode, indent int) error
+	writeNodes = func(nodes []*callGraphNode, indent int) error {
+		for _, n := range nodes {
+			if _, err := fmt.Fprintf(out, "%s%s\n", strings.Repeat("  ", indent), n.label()); err != nil {
+				return err
+			}
+			if err := writeNodes(n.Children, indent+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, m := range graph.Msgs {
+		if _, err := fmt.Fprintf(out, "msg %d\n", m.MsgIndex); err != nil {
+			return err
+		}
+		if err := writeNodes(m.Calls, 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderCallGraphDot writes the calls as a Graphviz digraph with an edge from the caller to each call
+func renderCallGraphDot(out io.Writer, graph callGraph) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", "tx "+graph.TxHash)
+	var nextID int
+	var writeNodes func(parent string, nodes []*callGraphNode)
+	writeNodes = func(parent string, nodes []*callGraphNode) {
+		for _, n := range nodes {
+			id := fmt.Sprintf("n%d", nextID)
+			nextID++
+			fmt.Fprintf(&b, "  %s [label=%q];\n", id, n.label())
+			fmt.Fprintf(&b, "  %s -> %s;\n", parent, id)
+			writeNodes(id, n.Children)
+		}
+	}
+	for _, m := range graph.Msgs {
+		id := fmt.Sprintf("msg%d", m.MsgIndex)
+		fmt.Fprintf(&b, "  %s [label=%q, shape=box];\n", id, fmt.Sprintf("msg %d", m.MsgIndex))
+		writeNodes(id, m.Calls)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
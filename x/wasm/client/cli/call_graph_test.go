@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRenderCallGraph(t *testing.T) {
+	specs := map[string]struct {
+		src     *sdk.TxResponse
+		expTree string
+		expDot  string
+	}{
+		"nested": {
+			src: &sdk.TxResponse{TxHash: "AB01", GasWanted: 300000, GasUsed: 250000, Events: []abci.Event{
+				{Type: "message", Attributes: []abci.EventAttribute{{Key: attributeKeyMsgIndex, Value: "0"}}},
+				callGraphEvent(types.EventTypeExecute, "contractA", 0, -1),
+				callGraphEvent(types.WasmModuleEventType, "contractA", 0, -1),
+				callGraphEvent(types.EventTypeExecute, "contractB", 0, 1),
+				callGraphEvent(types.EventTypeInstantiate, "contractC", 0, 2, abci.EventAttribute{Key: types.AttributeKeyCodeID, Value: "3"}),
+				callGraphEvent(types.EventTypeReply, "contractB", 0, 1),
+				callGraphEvent(types.EventTypeReply, "contractA", 0, 0),
+				callGraphEvent(types.EventTypeExecute, "contractD", 0, 1),
+				callGraphEvent(types.EventTypeExecute, "contractD", 1, -1),
+			}},
+			expTree: "tx AB01 gas used: 250000 gas wanted: 300000\n" +
+				"msg 0\n" +
+				"  execute contractA\n" +
+				"    execute contractB\n" +
+				"      instantiate contractC (code id 3)\n" +
+				"      reply contractB\n" +
+				"    reply contractA\n" +
+				"    execute contractD\n" +
+				"msg 1\n" +
+				"  execute contractD\n",
+			expDot: "digraph \"tx AB01\" {\n" +
+				"  msg0 [label=\"msg 0\", shape=box];\n" +
+				"  n0 [label=\"execute contractA\"];\n" +
+				"  msg0 -> n0;\n" +
+				"  n1 [label=\"execute contractB\"];\n" +
+				"  n0 -> n1;\n" +
+				"  n2 [label=\"instantiate contractC (code id 3)\"];\n" +
+				"  n1 -> n2;\n" +
+				"  n3 [label=\"reply contractB\"];\n" +
+				"  n1 -> n3;\n" +
+				"  n4 [label=\"reply contractA\"];\n" +
+				"  n0 -> n4;\n" +
+				"  n5 [label=\"execute contractD\"];\n" +
+				"  n0 -> n5;\n" +
+				"  msg1 [label=\"msg 1\", shape=box];\n" +
+				"  n6 [label=\"execute contractD\"];\n" +
+				"  msg1 -> n6;\n" +
+				"}\n",
+		},
+		"flat without call depth": {
+			src: &sdk.TxResponse{TxHash: "AB02", GasWanted: 200000, GasUsed: 150000, Events: []abci.Event{
+				callGraphEvent(types.EventTypeExecute, "contractA", 0, -1),
+				callGraphEvent(types.EventTypeExecute, "contractB", 0, -1),
+				callGraphEvent(types.EventTypeReply, "contractA", 0, -1),
+			}},
+			expTree: "tx AB02 gas used: 150000 gas wanted: 200000\n" +
+				"call depth not recorded: calls are listed in event order\n" +
+				"msg 0\n" +
+				"  execute contractA\n" +
+				"  execute contractB\n" +
+				"  reply contractA\n",
+			expDot: "digraph \"tx AB02\" {\n" +
+				"  msg0 [label=\"msg 0\", shape=box];\n" +
+				"  n0 [label=\"execute contractA\"];\n" +
+				"  msg0 -> n0;\n" +
+				"  n1 [label=\"execute contractB\"];\n" +
+				"  msg0 -> n1;\n" +
+				"  n2 [label=\"reply contractA\"];\n" +
+				"  msg0 -> n2;\n" +
+				"}\n",
+		},
+		"no contract calls": {
+			src: &sdk.TxResponse{TxHash: "AB03", GasWanted: 100000, GasUsed: 50000, Events: []abci.Event{
+				{Type: "transfer", Attributes: []abci.EventAttribute{{Key: attributeKeyMsgIndex, Value: "0"}}},
+			}},
+			expTree: "tx AB03 gas used: 50000 gas wanted: 100000\n" +
+				"no contract calls\n",
+			expDot: "digraph \"tx AB03\" {\n}\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			graph := buildCallGraph(spec.src)
+			var tree, dot bytes.Buffer
+			require.NoError(t, renderCallGraphTree(&tree, graph))
+			require.NoError(t, renderCallGraphDot(&dot, graph))
+			assert.Equal(t, spec.expTree, tree.String())
+			assert.Equal(t, spec.expDot, dot.String())
+		})
+	}
+}
+
+// callGraphEvent returns a wasm event of a contract. The call depth attribute is not set when depth is negative.
+func callGraphEvent(eventType, contract string, msgIndex, depth int, attrs ...abci.EventAttribute) abci.Event {
+	e := abci.Event{Type: eventType, Attributes: append([]abci.EventAttribute{
+		{Key: types.AttributeKeyContractAddr, Value: contract},
+	}, attrs...)}
+	if depth >= 0 {
+		e.Attributes = append(e.Attributes,
+			abci.EventAttribute{Key: types.AttributeKeyMsgIndex, Value: "0"},
+			abci.EventAttribute{Key: types.AttributeKeyCallDepth, Value: strconv.Itoa(depth)},
+		)
+	}
+	e.Attributes = append(e.Attributes, abci.EventAttribute{Key: attributeKeyMsgIndex, Value: strconv.Itoa(msgIndex)})
+	return e
+}
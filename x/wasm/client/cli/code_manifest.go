@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagVerify = "verify"
+
+	// codeManifestPageLimit is the number of codes queried per page
+	codeManifestPageLimit = 100
+)
+
+// codeManifest lists the checksums of all stored codes at a height, sorted by code id
+type codeManifest struct {
+	Height int64               `json:"height"`
+	Codes  []codeManifestEntry `json:"codes"`
+}
+
+type codeManifestEntry struct {
+	CodeID   uint64 `json:"code_id"`
+	Checksum string `json:"checksum"`
+	Creator  string `json:"creator"`
+}
+
+// GetCmdCodeManifest writes the checksums of all stored codes or verifies a manifest against the chain
+func GetCmdCodeManifest() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-manifest",
+		Short: "Export or verify a manifest of all stored code checksums",
+		Long: `Export a manifest of all stored code checksums for reproducible build audits. All pages are queried
+at the same height, the latest height unless --height is set. The manifest is sorted by code id and encoded
+canonically so that the same chain state always results in the same bytes. The sha256 of the manifest is printed.
+With --verify, the manifest file is compared to the chain and the added, removed and changed codes are listed.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			outFile, err := cmd.Flags().GetString(flagOut)
+			if err != nil {
+				return err
+			}
+			verifyFile, err := cmd.Flags().GetString(flagVerify)
+			if err != nil {
+				return err
+			}
+			if outFile != "" && verifyFile != "" {
+				return fmt.Errorf("--%s can not be combined with --%s", flagOut, flagVerify)
+			}
+			if clientCtx.Height == 0 {
+				height, err := latestHeight(cmd.Context(), clientCtx)
+				if err != nil {
+					return err
+				}
+				clientCtx = clientCtx.WithHeight(height)
+			}
+			manifest, err := buildCodeManifest(cmd.Context(), types.NewQueryClient(clientCtx), clientCtx.Height)
+			if err != nil {
+				return err
+			}
+
+			if verifyFile != "" {
+				bz, err := os.ReadFile(verifyFile)
+				if err != nil {
+					return err
+				}
+				var expected codeManifest
+				if err := json.Unmarshal(bz, &expected); err != nil {
+					return fmt.Errorf("manifest %s: %s", verifyFile, err)
+				}
+				equal, err := renderCodeManifestDiff(cmd.OutOrStdout(), expected, manifest)
+				if err != nil {
+					return err
+				}
+				if !equal {
+					return errors.New("manifest does not match the chain")
+				}
+				return nil
+			}
+
+			bz, err := encodeCodeManifest(manifest)
+			if err != nil {
+				return err
+			}
+			digest := sha256.Sum256(bz)
+			if outFile == "" {
+				if _, err := cmd.OutOrStdout().Write(bz); err != nil {
+					return err
+				}
+				_, err = fmt.Fprintf(cmd.ErrOrStderr(), "sha256: %s\n", hex.EncodeToString(digest[:]))
+				return err
+			}
+			if err := os.WriteFile(outFile, bz, 0o644); err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "sha256: %s\n", hex.EncodeToString(digest[:]))
+			return err
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagOut, "", "Write the manifest into this file instead of stdout")
+	cmd.Flags().String(flagVerify, "", "Compare this manifest file to the chain")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// buildCodeManifest queries all codes page by page
+func buildCodeManifest(ctx context.Context, queryClient types.QueryClient, height int64) (codeManifest, error) {
+	manifest := codeManifest{Height: height, Codes: []codeManifestEntry{}}
+	var pageKey []byte
+	for {
+		res, err := queryClient.Codes(ctx, &types.QueryCodesRequest{
+			Pagination: &query.PageRequest{Key: pageKey, Limit: codeManifestPageLimit},
+		})
+		if err != nil {
+			return codeManifest{}, err
+		}
+		for _, c := range res.CodeInfos {
+			manifest.Codes = append(manifest.Codes, codeManifestEntry{
+				CodeID:   c.CodeID,
+				Checksum: hex.EncodeToString(c.DataHash),
+				Creator:  c.Creator,
+			})
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		pageKey = res.Pagination.NextKey
+	}
+	slices.SortFunc(manifest.Codes, func(a, b codeManifestEntry) int {
+		return cmp.Compare(a.CodeID, b.CodeID)
+	})
+	return manifest, nil
+}
+
+// encodeCodeManifest returns the canonical encoding of the manifest
+func encodeCodeManifest(manifest codeManifest) ([]byte, error) {
+	bz, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(bz, '\n'), nil
+}
+
+// renderCodeManifestDiff writes the codes that were added, removed or changed on the chain compared to the expected
+// manifest. True is returned when there is no difference.
+func renderCodeManifestDiff(out io.Writer, expected, actual codeManifest) (bool, error) {
+	expCodes := make(map[uint64]codeManifestEntry, len(expected.Codes))
+	for _, c := range expected.Codes {
+		expCodes[c.CodeID] = c
+	}
+	actCodes := make(map[uint64]codeManifestEntry, len(actual.Codes))
+	for _, c := range actual.Codes {
+		actCodes[c.CodeID] = c
+	}
+	codeIDs := make([]uint64, 0, len(expCodes)+len(actCodes))
+	for id := range expCodes {
+		codeIDs = append(codeIDs, id)
+	}
+	for id := range actCodes {
+		if _, ok := expCodes[id]; !ok {
+			codeIDs = append(codeIDs, id)
+		}
+	}
+	slices.Sort(codeIDs)
+
+	equal := true
+	for _, id := range codeIDs {
+		exp, inExp := expCodes[id]
+		act, inAct := actCodes[id]
+		var err error
+		switch {
+		case !inExp:
+			_, err = fmt.Fprintf(out, "+ %d %s %s\n", id, act.Checksum, act.Creator)
+		case !inAct:
+			_, err = fmt.Fprintf(out, "- %d %s %s\n", id, exp.Checksum, exp.Creator)
+		case exp != act:
+			_, err = fmt.Fprintf(out, "~ %d %s %s -> %s %s\n", id, exp.Checksum, exp.Creator, act.Checksum, act.Creator)
+		default:
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		equal = false
+	}
+	if equal {
+		_, err := fmt.Fprintf(out, "manifest matches %d codes at height %d\n", len(actual.Codes), actual.Height)
+		return true, err
+	}
+	return false, nil
+}
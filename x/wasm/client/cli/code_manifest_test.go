@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestBuildCodeManifest(t *testing.T) {
+	queryClient := &mockCodesQueryClient{pages: [][]types.CodeInfoResponse{
+		{
+			{CodeID: 3, DataHash: []byte{0x3}, Creator: "creator3"},
+			{CodeID: 1, DataHash: []byte{0x1}, Creator: "creator1"},
+		},
+		{
+			{CodeID: 2, DataHash: []byte{0x2}, Creator: "creator2"},
+		},
+	}}
+
+	// when
+	got, err := buildCodeManifest(context.Background(), queryClient, 7)
+	require.NoError(t, err)
+
+	// then
+	exp := codeManifest{Height: 7, Codes: []codeManifestEntry{
+		{CodeID: 1, Checksum: "01", Creator: "creator1"},
+		{CodeID: 2, Checksum: "02", Creator: "creator2"},
+		{CodeID: 3, Checksum: "03", Creator: "creator3"},
+	}}
+	assert.Equal(t, exp, got)
+	assert.Equal(t, 2, queryClient.calls)
+
+	// and the encoding is deterministic
+	bz1, err := encodeCodeManifest(got)
+	require.NoError(t, err)
+	queryClient.calls = 0
+	again, err := buildCodeManifest(context.Background(), queryClient, 7)
+	require.NoError(t, err)
+	bz2, err := encodeCodeManifest(again)
+	require.NoError(t, err)
+	assert.Equal(t, bz1, bz2)
+	expJSON := `{
+  "height": 7,
+  "codes": [
+    {
+      "code_id": 1,
+      "checksum": "01",
+      "creator": "creator1"
+    },
+    {
+      "code_id": 2,
+      "checksum": "02",
+      "creator": "creator2"
+    },
+    {
+      "code_id": 3,
+      "checksum": "03",
+      "creator": "creator3"
+    }
+  ]
+}
+`
+	assert.Equal(t, expJSON, string(bz1))
+}
+
+func TestRenderCodeManifestDiff(t *testing.T) {
+	manifest := codeManifest{Height: 7, Codes: []codeManifestEntry{
+		{CodeID: 1, Checksum: "01", Creator: "creator1"},
+		{CodeID: 2, Checksum: "02", Creator: "creator2"},
+	}}
+	specs := map[string]struct {
+		actual   codeManifest
+		expEqual bool
+		exp      string
+	}{
+		"equal": {
+			actual:   codeManifest{Height: 9, Codes: manifest.Codes},
+			expEqual: true,
+			exp:      "manifest matches 2 codes at height 9\n",
+		},
+		"added": {
+			actual: codeManifest{Height: 9, Codes: append(append([]codeManifestEntry{}, manifest.Codes...), codeManifestEntry{CodeID: 3, Checksum: "03", Creator: "creator3"})},
+			exp:    "+ 3 03 creator3\n",
+		},
+		"removed": {
+			actual: codeManifest{Height: 9, Codes: manifest.Codes[:1]},
+			exp:    "- 2 02 creator2\n",
+		},
+		"changed": {
+			actual: codeManifest{Height: 9, Codes: []codeManifestEntry{
+				{CodeID: 1, Checksum: "01", Creator: "creator1"},
+				{CodeID: 2, Checksum: "ff", Creator: "creator2"},
+			}},
+			exp: "~ 2 02 creator2 -> ff creator2\n",
+		},
+		"all": {
+			actual: codeManifest{Height: 9, Codes: []codeManifestEntry{
+				{CodeID: 2, Checksum: "02", Creator: "other"},
+				{CodeID: 4, Checksum: "04", Creator: "creator4"},
+			}},
+			exp: "- 1 01 creator1\n" +
+				"~ 2 02 creator2 -> 02 other\n" +
+				"+ 4 04 creator4\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			gotEqual, err := renderCodeManifestDiff(&out, manifest, spec.actual)
+			require.NoError(t, err)
+			assert.Equal(t, spec.expEqual, gotEqual)
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
+
+type mockCodesQueryClient struct {
+	types.QueryClient
+	pages [][]types.CodeInfoResponse
+	calls int
+}
+
+func (m *mockCodesQueryClient) Codes(_ context.Context, _ *types.QueryCodesRequest, _ ...grpc.CallOption) (*types.QueryCodesResponse, error) {
+	page := m.pages[m.calls]
+	m.calls++
+	res := &types.QueryCodesResponse{CodeInfos: page, Pagination: &query.PageResponse{}}
+	if m.calls < len(m.pages) {
+		res.Pagination.NextKey = []byte{byte(m.calls)}
+	}
+	return res, nil
+}
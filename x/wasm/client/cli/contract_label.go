@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/client/input"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// validateLabel checks a contract label with the same rules for instantiate and label updates
+func validateLabel(label string) error {
+	if label == "" {
+		return errors.New("label is required on all contracts")
+	}
+	if err := types.ValidateLabel(label); err != nil {
+		return fmt.Errorf("label: %s", err)
+	}
+	return nil
+}
+
+// confirmContractLabelUpdate shows the current and the new label of the contract and asks for confirmation
+// unless skipConfirm is set. An error is returned when the label is unchanged or the update is not confirmed.
+func confirmContractLabelUpdate(ctx context.Context, queryClient types.QueryClient, msg types.MsgUpdateContractLabel, in *bufio.Reader, out io.Writer, skipConfirm bool) error {
+	res, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: msg.Contract})
+	if err != nil {
+		return err
+	}
+	if res.Label == msg.NewLabel {
+		return fmt.Errorf("contract %s has the label %q already", msg.Contract, msg.NewLabel)
+	}
+	if _, err := fmt.Fprintf(out, "current label: %q\nnew label: %q\n", res.Label, msg.NewLabel); err != nil {
+		return err
+	}
+	if skipConfirm {
+		return nil
+	}
+	ok, err := input.GetConfirmation("update the contract label?", in, out)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("update canceled")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestValidateLabelParity(t *testing.T) {
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+	specs := map[string]struct {
+		label  string
+		expErr bool
+	}{
+		"valid":              {label: "my label"},
+		"max size":           {label: strings.Repeat("a", types.MaxLabelSize)},
+		"empty":              {label: "", expErr: true},
+		"too long":           {label: strings.Repeat("a", types.MaxLabelSize+1), expErr: true},
+		"leading whitespace": {label: " my label", expErr: true},
+		"trailing newline":   {label: "my label\n", expErr: true},
+		"non printable":      {label: "my\x00label", expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateLabel(spec.label)
+			instantiateErr := types.MsgInstantiateContract{Sender: myAddr, CodeID: 1, Label: spec.label, Msg: []byte("{}")}.ValidateBasic()
+			updateErr := types.MsgUpdateContractLabel{Sender: myAddr, Contract: myContract, NewLabel: spec.label}.ValidateBasic()
+			if spec.expErr {
+				assert.Error(t, gotErr)
+				assert.Error(t, instantiateErr)
+				assert.Error(t, updateErr)
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.NoError(t, instantiateErr)
+			assert.NoError(t, updateErr)
+		})
+	}
+}
+
+func TestConfirmContractLabelUpdate(t *testing.T) {
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+	specs := map[string]struct {
+		newLabel    string
+		input       string
+		skipConfirm bool
+		expErr      string
+		expOut      string
+	}{
+		"confirmed": {
+			newLabel: "new label",
+			input:    "y\n",
+			expOut:   "current label: \"old label\"\nnew label: \"new label\"\n",
+		},
+		"declined": {
+			newLabel: "new label",
+			input:    "n\n",
+			expErr:   "update canceled",
+		},
+		"skip confirmation": {
+			newLabel:    "new label",
+			skipConfirm: true,
+			expOut:      "current label: \"old label\"\nnew label: \"new label\"\n",
+		},
+		"unchanged label": {
+			newLabel: "old label",
+			input:    "y\n",
+			expErr:   "has the label \"old label\" already",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockContractLabelQueryClient{label: "old label"}
+			msg := types.MsgUpdateContractLabel{Contract: myContract, NewLabel: spec.newLabel}
+			var out bytes.Buffer
+
+			gotErr := confirmContractLabelUpdate(context.Background(), queryClient, msg, bufio.NewReader(strings.NewReader(spec.input)), &out, spec.skipConfirm)
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expOut, out.String())
+			assert.Equal(t, myContract, queryClient.address)
+		})
+	}
+}
+
+type mockContractLabelQueryClient struct {
+	types.QueryClient
+	label   string
+	address string
+}
+
+func (m *mockContractLabelQueryClient) ContractInfo(_ context.Context, req *types.QueryContractInfoRequest, _ ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	m.address = req.Address
+	return &types.QueryContractInfoResponse{Address: req.Address, ContractInfo: types.ContractInfo{Label: m.label}}, nil
+}
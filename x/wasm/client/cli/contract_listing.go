@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagDetails = "details"
+
+// contractListEntry is a contract of a listing with the creator and admin for permission audits
+type contractListEntry struct {
+	Address string `json:"address"`
+	CodeID  uint64 `json:"code_id"`
+	Creator string `json:"creator"`
+	Admin   string `json:"admin"`
+	Label   string `json:"label"`
+}
+
+// contractListing is a page of contracts with details
+type contractListing struct {
+	Contracts  []contractListEntry `json:"contracts"`
+	Pagination *query.PageResponse `json:"pagination,omitempty"`
+}
+
+// contractListFilter selects contracts by creator and admin. Empty values match all.
+type contractListFilter struct {
+	Creator string
+	Admin   string
+}
+
+func (f contractListFilter) matches(e contractListEntry) bool {
+	return (f.Creator == "" || f.Creator == e.Creator) && (f.Admin == "" || f.Admin == e.Admin)
+}
+
+// addContractListingFlags adds the details flag and the creator and admin display filters when requested
+func addContractListingFlags(cmd *cobra.Command, withFilters bool) {
+	cmd.Flags().Bool(flagDetails, false, "Show the code id, creator, admin and label of each contract")
+	if withFilters {
+		cmd.Flags().String(flagCreator, "", "Only show contracts with this creator address, implies --details")
+		cmd.Flags().String(flagAdmin, "", "Only show contracts with this admin address, implies --details")
+	}
+}
+
+// readContractListFilter returns the display filter and if the contract details should be shown
+func readContractListFilter(cmd *cobra.Command, withFilters bool) (contractListFilter, bool, error) {
+	details, err := cmd.Flags().GetBool(flagDetails)
+	if err != nil || !withFilters {
+		return contractListFilter{}, details, err
+	}
+	var filter contractListFilter
+	if filter.Creator, err = cmd.Flags().GetString(flagCreator); err != nil {
+		return filter, false, err
+	}
+	if filter.Admin, err = cmd.Flags().GetString(flagAdmin); err != nil {
+		return filter, false, err
+	}
+	for _, addr := range []string{filter.Creator, filter.Admin} {
+		if addr == "" {
+			continue
+		}
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return filter, false, fmt.Errorf("filter address: %s", err)
+		}
+		details = true
+	}
+	return filter, details, nil
+}
+
+// printContractListing queries the details of the contracts and prints the entries that match the filter
+func printContractListing(cmd *cobra.Command, clientCtx client.Context, contracts []string, pagination *query.PageResponse, filter contractListFilter) error {
+	entries, err := loadContractListEntries(cmd.Context(), types.NewQueryClient(clientCtx), contracts, filter)
+	if err != nil {
+		return err
+	}
+	return renderContractListing(cmd.OutOrStdout(), clientCtx.OutputFormat, contractListing{Contracts: entries, Pagination: pagination})
+}
+
+// loadContractListEntries returns the details of the contracts that match the filter
+func loadContractListEntries(ctx context.Context, queryClient types.QueryClient, contracts []string, filter contractListFilter) ([]contractListEntry, error) {
+	entries := make([]contractListEntry, 0, len(contracts))
+	for _, addr := range contracts {
+		res, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: addr})
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", addr, err)
+		}
+		entry := contractListEntry{
+			Address: addr,
+			CodeID:  res.CodeID,
+			Creator: res.Creator,
+			Admin:   res.Admin,
+			Label:   res.Label,
+		}
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// renderContractListing writes the listing as json or as table with the next page key below
+func renderContractListing(out io.Writer, outputFormat string, listing contractListing) error {
+	if outputFormat == flags.OutputFormatJSON {
+		bz, err := json.Marshal(listing)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", bz)
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ADDRESS\tCODE ID\tCREATOR\tADMIN\tLABEL"); err != nil {
+		return err
+	}
+	for _, e := range listing.Contracts {
+		admin := e.Admin
+		if admin == "" {
+			admin = "-"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", e.Address, e.CodeID, e.Creator, admin, e.Label); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if listing.Pagination != nil && len(listing.Pagination.NextKey) != 0 {
+		_, err := fmt.Fprintf(out, "next page key: %s\n", base64.StdEncoding.EncodeToString(listing.Pagination.NextKey))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestLoadContractListEntries(t *testing.T) {
+	contractA := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	contractB := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+	creator := sdk.AccAddress(bytes.Repeat([]byte{3}, 20)).String()
+	admin := sdk.AccAddress(bytes.Repeat([]byte{4}, 20)).String()
+	queryClient := &contractListingQueryClientMock{contracts: map[string]types.ContractInfo{
+		contractA: {CodeID: 1, Creator: creator, Admin: admin, Label: "first"},
+		contractB: {CodeID: 2, Creator: admin, Label: "second"},
+	}}
+	entryA := contractListEntry{Address: contractA, CodeID: 1, Creator: creator, Admin: admin, Label: "first"}
+	entryB := contractListEntry{Address: contractB, CodeID: 2, Creator: admin, Label: "second"}
+
+	specs := map[string]struct {
+		filter contractListFilter
+		exp    []contractListEntry
+	}{
+		"all": {
+			exp: []contractListEntry{entryA, entryB},
+		},
+		"by creator": {
+			filter: contractListFilter{Creator: admin},
+			exp:    []contractListEntry{entryB},
+		},
+		"by admin": {
+			filter: contractListFilter{Admin: admin},
+			exp:    []contractListEntry{entryA},
+		},
+		"by creator and admin": {
+			filter: contractListFilter{Creator: admin, Admin: admin},
+			exp:    []contractListEntry{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := loadContractListEntries(context.Background(), queryClient, []string{contractA, contractB}, spec.filter)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestRenderContractListing(t *testing.T) {
+	listing := contractListing{
+		Contracts: []contractListEntry{
+			{Address: "contract1", CodeID: 1, Creator: "creator1", Admin: "admin1", Label: "first"},
+			{Address: "contract2", CodeID: 12, Creator: "creator2", Label: "second"},
+		},
+		Pagination: &query.PageResponse{NextKey: []byte{0x1, 0x2}},
+	}
+	specs := map[string]struct {
+		format string
+		exp    string
+	}{
+		"table": {
+			format: flags.OutputFormatText,
+			exp: "ADDRESS    CODE ID  CREATOR   ADMIN   LABEL\n" +
+				"contract1  1        creator1  admin1  first\n" +
+				"contract2  12       creator2  -       second\n" +
+				"next page key: AQI=\n",
+		},
+		"json": {
+			format: flags.OutputFormatJSON,
+			exp: `{"contracts":[` +
+				`{"address":"contract1","code_id":1,"creator":"creator1","admin":"admin1","label":"first"},` +
+				`{"address":"contract2","code_id":12,"creator":"creator2","admin":"","label":"second"}],` +
+				`"pagination":{"next_key":"AQI="}}` + "\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, renderContractListing(&out, spec.format, listing))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
+
+type contractListingQueryClientMock struct {
+	types.QueryClient
+	contracts map[string]types.ContractInfo
+}
+
+func (m contractListingQueryClientMock) ContractInfo(_ context.Context, req *types.QueryContractInfoRequest, _ ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	info, ok := m.contracts[req.Address]
+	if !ok {
+		return nil, types.ErrNoSuchContractFn(req.Address)
+	}
+	return &types.QueryContractInfoResponse{Address: req.Address, ContractInfo: info}, nil
+}
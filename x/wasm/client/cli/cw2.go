@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagCheckCW2          = "check-cw2"
+	flagReferenceContract = "reference-contract"
+	flagExpectedName      = "expected-name"
+	flagExpectedVersion   = "expected-version"
+
+	// cw2ContractInfoKey is the raw store key of the cw2 contract version info
+	cw2ContractInfoKey = "contract_info"
+)
+
+// cw2ContractVersion is the version info stored by cw2 compliant contracts
+type cw2ContractVersion struct {
+	Contract string `json:"contract"`
+	Version  string `json:"version"`
+}
+
+func (v cw2ContractVersion) String() string {
+	return v.Contract + "@" + v.Version
+}
+
+func addCW2CheckFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagCheckCW2, false, "Abort when the cw2 contract name differs or the version does not increase")
+	cmd.Flags().String(flagReferenceContract, "", "Address of a contract instance of the new code to read the cw2 version from")
+	cmd.Flags().String(flagExpectedName, "", "The cw2 contract name of the new code, when no reference contract exists")
+	cmd.Flags().String(flagExpectedVersion, "", "The cw2 contract version of the new code, when no reference contract exists")
+}
+
+// checkCW2FromFlags runs the cw2 migration check when requested by flag
+func checkCW2FromFlags(cmd *cobra.Command, clientCtx client.Context, contract string) error {
+	check, err := cmd.Flags().GetBool(flagCheckCW2)
+	if err != nil || !check {
+		return err
+	}
+	reference, err := cmd.Flags().GetString(flagReferenceContract)
+	if err != nil {
+		return err
+	}
+	expName, err := cmd.Flags().GetString(flagExpectedName)
+	if err != nil {
+		return err
+	}
+	expVersion, err := cmd.Flags().GetString(flagExpectedVersion)
+	if err != nil {
+		return err
+	}
+	return checkCW2Migration(context.Background(), types.NewQueryClient(clientCtx), contract, reference, expName, expVersion)
+}
+
+// checkCW2Migration ensures that the new code is a newer version of the same contract. The new version is read
+// from a reference contract instance or taken from the expected name and version.
+func checkCW2Migration(ctx context.Context, queryClient types.QueryClient, contract, reference, expName, expVersion string) error {
+	var target cw2ContractVersion
+	switch {
+	case reference != "" && (expName != "" || expVersion != ""):
+		return errors.New("cannot set reference contract and expected name or version for the cw2 check")
+	case reference != "":
+		v, err := queryCW2Version(ctx, queryClient, reference)
+		if err != nil {
+			return fmt.Errorf("reference contract: %w", err)
+		}
+		target = *v
+	case expName != "" && expVersion != "":
+		target = cw2ContractVersion{Contract: expName, Version: expVersion}
+	default:
+		return errors.New("cw2 check requires a reference contract or the expected name and version")
+	}
+
+	current, err := queryCW2Version(ctx, queryClient, contract)
+	if err != nil {
+		return err
+	}
+	if current.Contract != target.Contract {
+		return fmt.Errorf("cw2 contract name mismatch: current %s, new %s", current, target)
+	}
+	cmp, err := compareSemver(current.Version, target.Version)
+	if err != nil {
+		return fmt.Errorf("cw2 version of current %s, new %s: %w", current, target, err)
+	}
+	if cmp >= 0 {
+		return fmt.Errorf("cw2 version does not increase: current %s, new %s", current, target)
+	}
+	return nil
+}
+
+// queryCW2Version reads the cw2 version info from the raw contract state
+func queryCW2Version(ctx context.Context, queryClient types.QueryClient, contract string) (*cw2ContractVersion, error) {
+	res, err := queryClient.RawContractState(ctx, &types.QueryRawContractStateRequest{
+		Address:   contract,
+		QueryData: []byte(cw2ContractInfoKey),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query cw2 info of %s: %w", contract, err)
+	}
+	if len(res.Data) == 0 {
+		return nil, fmt.Errorf("no cw2 info stored for contract %s", contract)
+	}
+	var v cw2ContractVersion
+	if err := json.Unmarshal(res.Data, &v); err != nil {
+		return nil, fmt.Errorf("decode cw2 info of %s: %w", contract, err)
+	}
+	if v.Contract == "" || v.Version == "" {
+		return nil, fmt.Errorf("incomplete cw2 info for contract %s", contract)
+	}
+	return &v, nil
+}
+
+// compareSemver compares two semantic versions and returns -1, 0 or 1. Build metadata is ignored.
+func compareSemver(a, b string) (int, error) {
+	aCore, aPre, err := parseSemver(a)
+	if err != nil {
+		return 0, err
+	}
+	bCore, bPre, err := parseSemver(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range aCore {
+		if aCore[i] != bCore[i] {
+			return cmpUint(aCore[i], bCore[i]), nil
+		}
+	}
+	// a version without pre-release has a higher precedence
+	switch {
+	case len(aPre) == 0 && len(bPre) == 0:
+		return 0, nil
+	case len(aPre) == 0:
+		return 1, nil
+	case len(bPre) == 0:
+		return -1, nil
+	}
+	for i := 0; i < len(aPre) && i < len(bPre); i++ {
+		if c := comparePreRelease(aPre[i], bPre[i]); c != 0 {
+			return c, nil
+		}
+	}
+	return cmpUint(uint64(len(aPre)), uint64(len(bPre))), nil
+}
+
+func parseSemver(v string) ([3]uint64, []string, error) {
+	var core [3]uint64
+	s, _, _ := strings.Cut(strings.TrimPrefix(v, "v"), "+")
+	s, pre, hasPre := strings.Cut(s, "-")
+	parts := strings.Split(s, ".")
+	if len(parts) != len(core) {
+		return core, nil, fmt.Errorf("invalid semantic version %q", v)
+	}
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 64)
+		if err != nil {
+			return core, nil, fmt.Errorf("invalid semantic version %q", v)
+		}
+		core[i] = n
+	}
+	if !hasPre {
+		return core, nil, nil
+	}
+	return core, strings.Split(pre, "."), nil
+}
+
+// comparePreRelease compares numeric identifiers numerically and others lexically. Numeric identifiers have a
+// lower precedence.
+func comparePreRelease(a, b string) int {
+	aNum, aErr := strconv.ParseUint(a, 10, 64)
+	bNum, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmpUint(aNum, bNum)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func cmpUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCheckCW2Migration(t *testing.T) {
+	const (
+		myContract  = "contract"
+		myReference = "reference"
+	)
+	specs := map[string]struct {
+		state      map[string]string
+		reference  string
+		expName    string
+		expVersion string
+		expErr     []string
+	}{
+		"same name upgrade": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.1.0",
+		},
+		"same name upgrade from reference contract": {
+			state: map[string]string{
+				myContract:  `{"contract":"crates.io:cw20-base","version":"1.0.0-rc.2"}`,
+				myReference: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`,
+			},
+			reference: myReference,
+		},
+		"name mismatch": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			expName:    "crates.io:cw721-base",
+			expVersion: "2.0.0",
+			expErr:     []string{"name mismatch", "crates.io:cw20-base@1.0.0", "crates.io:cw721-base@2.0.0"},
+		},
+		"same version": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.0.0",
+			expErr:     []string{"does not increase", "1.0.0"},
+		},
+		"version downgrade": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.10.0"}`},
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.9.0",
+			expErr:     []string{"does not increase", "1.10.0", "1.9.0"},
+		},
+		"pre-release of same version": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.0.0-beta.1",
+			expErr:     []string{"does not increase"},
+		},
+		"invalid version": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0"}`},
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.1.0",
+			expErr:     []string{"invalid semantic version", "1.0", "1.1.0"},
+		},
+		"missing cw2 data": {
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.1.0",
+			expErr:     []string{"no cw2 info"},
+		},
+		"missing cw2 data on reference contract": {
+			state:     map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			reference: myReference,
+			expErr:    []string{"reference contract", "no cw2 info"},
+		},
+		"malformed cw2 data": {
+			state:      map[string]string{myContract: `"1.0.0"`},
+			expName:    "crates.io:cw20-base",
+			expVersion: "1.1.0",
+			expErr:     []string{"decode cw2 info"},
+		},
+		"neither reference nor expected version": {
+			state:   map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			expName: "crates.io:cw20-base",
+			expErr:  []string{"requires"},
+		},
+		"reference and expected version": {
+			state:      map[string]string{myContract: `{"contract":"crates.io:cw20-base","version":"1.0.0"}`},
+			reference:  myReference,
+			expVersion: "1.1.0",
+			expErr:     []string{"cannot set"},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockRawStateQueryClient{state: spec.state}
+			gotErr := checkCW2Migration(context.Background(), queryClient, myContract, spec.reference, spec.expName, spec.expVersion)
+			if len(spec.expErr) != 0 {
+				require.Error(t, gotErr)
+				for _, exp := range spec.expErr {
+					assert.Contains(t, gotErr.Error(), exp)
+				}
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	specs := map[string]struct {
+		a, b string
+		exp  int
+	}{
+		"equal":                       {a: "1.2.3", b: "1.2.3", exp: 0},
+		"major":                       {a: "1.9.9", b: "2.0.0", exp: -1},
+		"minor numeric":               {a: "0.10.0", b: "0.9.0", exp: 1},
+		"patch":                       {a: "0.1.1", b: "0.1.2", exp: -1},
+		"v prefix":                    {a: "v1.0.0", b: "1.0.0", exp: 0},
+		"build metadata ignored":      {a: "1.0.0+abc", b: "1.0.0+def", exp: 0},
+		"release over pre-release":    {a: "1.0.0", b: "1.0.0-rc.1", exp: 1},
+		"numeric pre-release":         {a: "1.0.0-rc.2", b: "1.0.0-rc.10", exp: -1},
+		"alphanumeric pre-release":    {a: "1.0.0-alpha", b: "1.0.0-beta", exp: -1},
+		"numeric before alphanumeric": {a: "1.0.0-1", b: "1.0.0-alpha", exp: -1},
+		"longer pre-release":          {a: "1.0.0-alpha.1", b: "1.0.0-alpha", exp: 1},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := compareSemver(spec.a, spec.b)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+type mockRawStateQueryClient struct {
+	types.QueryClient
+	state map[string]string
+}
+
+func (m mockRawStateQueryClient) RawContractState(_ context.Context, req *types.QueryRawContractStateRequest, _ ...grpc.CallOption) (*types.QueryRawContractStateResponse, error) {
+	if string(req.QueryData) != cw2ContractInfoKey {
+		return &types.QueryRawContractStateResponse{}, nil
+	}
+	return &types.QueryRawContractStateResponse{Data: []byte(m.state[req.Address])}, nil
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// checkDryRunFlags rejects flags that can not be combined with --dry-run
+func checkDryRunFlags(clientCtx client.Context, flagSet *flag.FlagSet) error {
+	switch {
+	case clientCtx.GenerateOnly:
+		return errors.New("--dry-run can not be combined with --generate-only: use --dry-run to estimate the gas or --generate-only to print the unsigned tx")
+	case clientCtx.Offline:
+		return errors.New("--dry-run can not be combined with --offline: the simulation requires a node")
+	case isGasPreview(flagSet):
+		return errors.New("--dry-run can not be combined with --gas-preview: both simulate the tx, use one of them")
+	case isMultisig(flagSet):
+		return errors.New("--dry-run can not be combined with --multisig")
+	}
+	return nil
+}
+
+// dryRun simulates the tx and prints the estimated gas. The tx is neither signed nor broadcast.
+func dryRun(clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) error {
+	if err := checkDryRunFlags(clientCtx, flagSet); err != nil {
+		return err
+	}
+	txf, err := tx.NewFactoryCLI(clientCtx, flagSet)
+	if err != nil {
+		return err
+	}
+	txf, err = txf.Prepare(clientCtx)
+	if err != nil {
+		return err
+	}
+	_, adjusted, err := tx.CalculateGas(clientCtx, txf, msgs...)
+	if err != nil {
+		return err
+	}
+	var out io.Writer = os.Stdout
+	if clientCtx.Output != nil {
+		out = clientCtx.Output
+	}
+	return printGasEstimate(out, clientCtx.OutputFormat, adjusted)
+}
+
+// printGasEstimate prints the gas estimate as JSON or text depending on the output format
+func printGasEstimate(out io.Writer, outputFormat string, gas uint64) error {
+	estimate := tx.GasEstimateResponse{GasEstimate: gas}
+	if outputFormat == flags.OutputFormatJSON {
+		bz, err := json.Marshal(estimate)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(out, "%s\n", bz)
+		return err
+	}
+	_, err := fmt.Fprintf(out, "%s\n", estimate)
+	return err
+}
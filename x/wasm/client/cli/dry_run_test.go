@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	rpcclient "github.com/cometbft/cometbft/rpc/client"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDryRun(t *testing.T) {
+	const (
+		mySender   = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+		myGrantee  = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+		myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	)
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(stateFile, []byte(`[{"key":"Y29uZmln","value":"eyJjb3VudCI6MX0="}]`), 0o600))
+	// all commands of GetTxCmd that build a tx
+	commands := map[string][]string{
+		"store":                     {"store", "../../keeper/testdata/hackatom.wasm"},
+		"store-many":                {"store-many", "../../keeper/testdata/hackatom.wasm", "../../keeper/testdata/burner.wasm"},
+		"instantiate":               {"instantiate", "1", "{}", "--label=testing", "--no-admin", "--skip-preflight"},
+		"instantiate with funds":    {"instantiate", "1", "{}", "--label=testing", "--no-admin", "--skip-preflight", "--amount=1stake", "--warn-locked-funds"},
+		"instantiate2":              {"instantiate2", "1", "{}", "01", "--label=testing", "--no-admin", "--skip-preflight"},
+		"execute":                   {"execute", myContract, "{}"},
+		"migrate":                   {"migrate", myContract, "2", "{}"},
+		"set-contract-admin":        {"set-contract-admin", myContract, myGrantee},
+		"clear-contract-admin":      {"clear-contract-admin", myContract},
+		"grant":                     {"grant", "contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-calls=1", "--no-token-transfer", "--expiration=1667979596"},
+		"update-instantiate-config": {"update-instantiate-config", "1", "--instantiate-nobody=true"},
+		"submit-proposal":           {"submit-proposal", "clear-contract-admin", myContract, "--title=testing", "--summary=testing", "--deposit=1stake"},
+		"set-contract-label":        {"set-contract-label", myContract, "new label"},
+		"set-state":                 {"set-state", myContract, stateFile},
+		"set-state-access":          {"set-state-access", myContract, "true"},
+		"sudo":                      {"sudo", myContract, "{}", "--authority=" + mySender},
+	}
+	flagSpecs := map[string]struct {
+		args         []string
+		requiresFlag string
+		expOut       string
+		expErr       string
+	}{
+		"dry run": {
+			args:   []string{"--dry-run"},
+			expOut: `{"gas_estimate":123456}` + "\n",
+		},
+		"dry run with json output": {
+			args:   []string{"--dry-run", "--output=json"},
+			expOut: `{"gas_estimate":123456}` + "\n",
+		},
+		"dry run with text output": {
+			args:   []string{"--dry-run", "--output=text"},
+			expOut: "gas estimate: 123456\n",
+		},
+		"dry run with gas adjustment": {
+			args:   []string{"--dry-run", "--gas-adjustment=1.5", "--output=text"},
+			expOut: "gas estimate: 185184\n",
+		},
+		"dry run without confirmation": {
+			args:   []string{"--dry-run", "--yes"},
+			expOut: `{"gas_estimate":123456}` + "\n",
+		},
+		"dry run with generate only": {
+			args:   []string{"--dry-run", "--generate-only"},
+			expErr: "--dry-run can not be combined with --generate-only",
+		},
+		"dry run with offline": {
+			args:   []string{"--dry-run", "--offline", "--account-number=1", "--sequence=1"},
+			expErr: "--dry-run can not be combined with --offline",
+		},
+		"dry run with gas preview": {
+			args:         []string{"--dry-run", "--gas-preview"},
+			requiresFlag: flagGasPreview,
+			expErr:       "--dry-run can not be combined with --gas-preview",
+		},
+		"dry run with multisig": {
+			args:         []string{"--dry-run", "--multisig"},
+			requiresFlag: flagMultisig,
+			expErr:       "--dry-run can not be combined with --multisig",
+		},
+		"dry run with wait": {
+			args:         []string{"--dry-run", "--wait"},
+			requiresFlag: flagWait,
+			expErr:       "wait can not be combined with generate only, dry run or offline mode",
+		},
+	}
+	encodingConfig := keeper.MakeEncodingConfig(t)
+	for cmdName, cmdArgs := range commands {
+		for flagName, spec := range flagSpecs {
+			t.Run(cmdName+" - "+flagName, func(t *testing.T) {
+				txCmd := GetTxCmd()
+				subCmd, _, err := txCmd.Find(cmdArgs)
+				require.NoError(t, err)
+				if spec.requiresFlag != "" && subCmd.Flags().Lookup(spec.requiresFlag) == nil {
+					t.Skipf("no --%s flag", spec.requiresFlag)
+				}
+				node := &dryRunTestNode{t: t, gasUsed: 123456}
+				var out bytes.Buffer
+				clientCtx := client.Context{}.
+					WithCodec(encodingConfig.Codec).
+					WithInterfaceRegistry(encodingConfig.InterfaceRegistry).
+					WithTxConfig(encodingConfig.TxConfig).
+					WithLegacyAmino(encodingConfig.Amino).
+					WithKeyring(keyring.NewInMemory(encodingConfig.Codec)).
+					WithAccountRetriever(client.TestAccountRetriever{Accounts: map[string]client.TestAccount{
+						mySender: {Address: sdk.MustAccAddressFromBech32(mySender), Num: 1, Seq: 1},
+					}}).
+					WithClient(node).
+					WithInput(&bytes.Buffer{}).
+					WithOutput(&out)
+				txCmd.SetContext(context.WithValue(context.Background(), client.ClientContextKey, &clientCtx))
+				txCmd.SetOut(&bytes.Buffer{})
+				txCmd.SetErr(&bytes.Buffer{})
+				args := append(append([]string{}, cmdArgs...), spec.args...)
+				txCmd.SetArgs(append(args, "--from="+mySender, "--chain-id=testing"))
+
+				// when
+				gotErr := txCmd.Execute()
+
+				// then
+				assert.Zero(t, node.broadcasts)
+				if spec.expErr != "" {
+					require.ErrorContains(t, gotErr, spec.expErr)
+					assert.Zero(t, node.simulations)
+					assert.Empty(t, out.String())
+					return
+				}
+				require.NoError(t, gotErr)
+				assert.Equal(t, 1, node.simulations)
+				assert.Equal(t, spec.expOut, out.String())
+			})
+		}
+	}
+}
+
+func TestPrintGasEstimate(t *testing.T) {
+	specs := map[string]struct {
+		outputFormat string
+		exp          string
+	}{
+		"json": {outputFormat: "json", exp: `{"gas_estimate":100}` + "\n"},
+		"text": {outputFormat: "text", exp: "gas estimate: 100\n"},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, printGasEstimate(&out, spec.outputFormat, 100))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
+
+// dryRunTestNode answers tx simulations and contract info queries. Other queries fail.
+type dryRunTestNode struct {
+	client.CometRPC
+	t           *testing.T
+	gasUsed     uint64
+	simulations int
+	broadcasts  int
+}
+
+func (n *dryRunTestNode) ABCIQueryWithOptions(_ context.Context, path string, _ cmtbytes.HexBytes, _ rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
+	var rsp proto.Message
+	switch path {
+	case "/cosmos.tx.v1beta1.Service/Simulate":
+		n.simulations++
+		rsp = &txtypes.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: n.gasUsed}, Result: &sdk.Result{}}
+	case "/cosmwasm.wasm.v1.Query/ContractInfo":
+		rsp = &types.QueryContractInfoResponse{ContractInfo: types.ContractInfo{Label: "old label"}}
+	default:
+		return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Code: 1, Log: "not supported"}}, nil
+	}
+	bz, err := proto.Marshal(rsp)
+	require.NoError(n.t, err)
+	return &coretypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: bz}}, nil
+}
+
+func (n *dryRunTestNode) BroadcastTxSync(context.Context, cmttypes.Tx) (*coretypes.ResultBroadcastTx, error) {
+	n.broadcasts++
+	return &coretypes.ResultBroadcastTx{}, nil
+}
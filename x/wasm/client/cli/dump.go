@@ -0,0 +1,361 @@
+package cli
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagFormat     = "format"
+	flagWhat       = "what"
+	flagStartAfter = "start-after"
+
+	dumpFormatNDJSON = "ndjson"
+	dumpFormatCSV    = "csv"
+
+	dumpWhatCodes     = "codes"
+	dumpWhatContracts = "contracts"
+
+	// dumpPageLimit is the number of entries requested per page
+	dumpPageLimit = 100
+)
+
+var (
+	// codeDumpColumns are the stable column names for a code record
+	codeDumpColumns = []string{"id", "creator", "checksum", "pinned"}
+	// contractDumpColumns are the stable column names for a contract record
+	contractDumpColumns = []string{"address", "code_id", "creator", "admin", "label", "created_height"}
+)
+
+// GetCmdDump writes all codes or contracts as CSV or NDJSON records
+func GetCmdDump() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump [output file,optional]",
+		Short: "Export codes or contracts as CSV or NDJSON records",
+		Long: fmt.Sprintf(`Export codes or contracts as CSV or NDJSON records with one record per line.
+Code columns: %v
+Contract columns: %v
+All queries are executed against the same block height. An interrupted dump can be
+continued with --start-after and the last exported code id or contract address.
+Records are written to stdout when no output file is given.`, codeDumpColumns, contractDumpColumns),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			format, err := cmd.Flags().GetString(flagFormat)
+			if err != nil {
+				return err
+			}
+			what, err := cmd.Flags().GetString(flagWhat)
+			if err != nil {
+				return err
+			}
+			startAfter, err := cmd.Flags().GetString(flagStartAfter)
+			if err != nil {
+				return err
+			}
+			columns, err := dumpColumns(what)
+			if err != nil {
+				return err
+			}
+			if clientCtx.Height == 0 {
+				// pin all queries to the latest height for a consistent snapshot
+				height, err := latestHeight(context.Background(), clientCtx)
+				if err != nil {
+					return err
+				}
+				clientCtx = clientCtx.WithHeight(height)
+			}
+
+			out, writeHeader := cmd.OutOrStdout(), startAfter == ""
+			if len(args) == 1 {
+				f, isEmpty, err := openDumpFile(args[0], startAfter != "")
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out, writeHeader = f, isEmpty
+			}
+			w, err := newDumpWriter(out, format, columns, writeHeader)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			switch what {
+			case dumpWhatCodes:
+				err = dumpCodes(context.Background(), queryClient, w, startAfter)
+			case dumpWhatContracts:
+				err = dumpContracts(context.Background(), queryClient, w, startAfter)
+			}
+			if err != nil {
+				return err
+			}
+			return w.Flush()
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagFormat, dumpFormatNDJSON, "Output format: ndjson or csv")
+	cmd.Flags().String(flagWhat, dumpWhatContracts, "Records to export: codes or contracts")
+	cmd.Flags().String(flagStartAfter, "", "Continue after this code id or contract address")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// latestHeight returns the latest block height of the node
+func latestHeight(ctx context.Context, clientCtx client.Context) (int64, error) {
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return 0, err
+	}
+	status, err := node.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+func dumpColumns(what string) ([]string, error) {
+	switch what {
+	case dumpWhatCodes:
+		return codeDumpColumns, nil
+	case dumpWhatContracts:
+		return contractDumpColumns, nil
+	default:
+		return nil, fmt.Errorf("unsupported records %q: use %s or %s", what, dumpWhatCodes, dumpWhatContracts)
+	}
+}
+
+// openDumpFile opens the output file. Existing content is kept when resuming.
+func openDumpFile(name string, resume bool) (*os.File, bool, error) {
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(name, mode, 0o644)
+	if err != nil {
+		return nil, false, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, false, err
+	}
+	return f, stat.Size() == 0, nil
+}
+
+// dumpWriter writes a record with values in column order
+type dumpWriter interface {
+	Write(values ...any) error
+	Flush() error
+}
+
+func newDumpWriter(out io.Writer, format string, columns []string, writeHeader bool) (dumpWriter, error) {
+	switch format {
+	case dumpFormatNDJSON:
+		return &ndjsonDumpWriter{out: out, columns: columns}, nil
+	case dumpFormatCSV:
+		w := &csvDumpWriter{out: csv.NewWriter(out)}
+		if writeHeader {
+			if err := w.out.Write(columns); err != nil {
+				return nil, err
+			}
+		}
+		return w, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q: use %s or %s", format, dumpFormatNDJSON, dumpFormatCSV)
+	}
+}
+
+type csvDumpWriter struct {
+	out *csv.Writer
+}
+
+func (w *csvDumpWriter) Write(values ...any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = fmt.Sprint(v)
+	}
+	return w.out.Write(record)
+}
+
+func (w *csvDumpWriter) Flush() error {
+	w.out.Flush()
+	return w.out.Error()
+}
+
+type ndjsonDumpWriter struct {
+	out     io.Writer
+	columns []string
+}
+
+// Write encodes the values as json object with the keys in column order
+func (w *ndjsonDumpWriter) Write(values ...any) error {
+	if len(values) != len(w.columns) {
+		return errors.New("values do not match columns")
+	}
+	line := []byte{'{'}
+	for i, v := range values {
+		if i != 0 {
+			line = append(line, ',')
+		}
+		key, err := json.Marshal(w.columns[i])
+		if err != nil {
+			return err
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		line = append(append(append(line, key...), ':'), value...)
+	}
+	_, err := w.out.Write(append(line, '}', '\n'))
+	return err
+}
+
+func (w *ndjsonDumpWriter) Flush() error {
+	return nil
+}
+
+// dumpCodes writes a record for each code with an id greater than startAfter
+func dumpCodes(ctx context.Context, queryClient types.QueryClient, w dumpWriter, startAfter string) error {
+	var pageKey []byte
+	if startAfter != "" {
+		codeID, err := strconv.ParseUint(startAfter, 10, 64)
+		if err != nil {
+			return fmt.Errorf("start after: %s", err)
+		}
+		pageKey = binary.BigEndian.AppendUint64(nil, codeID+1)
+	}
+	pinned, err := queryPinnedCodes(ctx, queryClient)
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := queryClient.Codes(ctx, &types.QueryCodesRequest{
+			Pagination: &query.PageRequest{Key: pageKey, Limit: dumpPageLimit},
+		})
+		if err != nil {
+			return err
+		}
+		for _, c := range res.CodeInfos {
+			_, isPinned := pinned[c.CodeID]
+			if err := w.Write(c.CodeID, c.Creator, hex.EncodeToString(c.DataHash), isPinned); err != nil {
+				return err
+			}
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return nil
+		}
+		pageKey = res.Pagination.NextKey
+	}
+}
+
+func queryPinnedCodes(ctx context.Context, queryClient types.QueryClient) (map[uint64]struct{}, error) {
+	pinned := make(map[uint64]struct{})
+	var pageKey []byte
+	for {
+		res, err := queryClient.PinnedCodes(ctx, &types.QueryPinnedCodesRequest{
+			Pagination: &query.PageRequest{Key: pageKey, Limit: dumpPageLimit},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range res.CodeIDs {
+			pinned[id] = struct{}{}
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return pinned, nil
+		}
+		pageKey = res.Pagination.NextKey
+	}
+}
+
+// dumpContracts writes a record for each contract ordered by code id and creation. When startAfter is set,
+// only the contracts following this contract address are written.
+func dumpContracts(ctx context.Context, queryClient types.QueryClient, w dumpWriter, startAfter string) error {
+	var codesPageKey []byte
+	if startAfter != "" {
+		if _, err := sdk.AccAddressFromBech32(startAfter); err != nil {
+			return fmt.Errorf("start after: %s", err)
+		}
+		res, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: startAfter})
+		if err != nil {
+			return fmt.Errorf("start after: %s", err)
+		}
+		codesPageKey = binary.BigEndian.AppendUint64(nil, res.CodeID)
+	}
+	for {
+		res, err := queryClient.Codes(ctx, &types.QueryCodesRequest{
+			Pagination: &query.PageRequest{Key: codesPageKey, Limit: dumpPageLimit},
+		})
+		if err != nil {
+			return err
+		}
+		for _, c := range res.CodeInfos {
+			if err := dumpContractsByCode(ctx, queryClient, w, c.CodeID, &startAfter); err != nil {
+				return err
+			}
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return nil
+		}
+		codesPageKey = res.Pagination.NextKey
+	}
+}
+
+// dumpContractsByCode writes the contracts of a code. Contracts are skipped until the startAfter address was seen
+// which is then reset.
+func dumpContractsByCode(ctx context.Context, queryClient types.QueryClient, w dumpWriter, codeID uint64, startAfter *string) error {
+	var pageKey []byte
+	for {
+		res, err := queryClient.ContractsByCode(ctx, &types.QueryContractsByCodeRequest{
+			CodeId:     codeID,
+			Pagination: &query.PageRequest{Key: pageKey, Limit: dumpPageLimit},
+		})
+		if err != nil {
+			return err
+		}
+		for _, addr := range res.Contracts {
+			if *startAfter != "" {
+				if addr == *startAfter {
+					*startAfter = ""
+				}
+				continue
+			}
+			info, err := queryClient.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: addr})
+			if err != nil {
+				return err
+			}
+			var createdHeight uint64
+			if info.Created != nil {
+				createdHeight = info.Created.BlockHeight
+			}
+			if err := w.Write(addr, info.CodeID, info.Creator, info.Admin, info.Label, createdHeight); err != nil {
+				return err
+			}
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return nil
+		}
+		pageKey = res.Pagination.NextKey
+	}
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDump(t *testing.T) {
+	contractA := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	contractB := sdk.AccAddress(bytes.Repeat([]byte{2}, 20)).String()
+	contractC := sdk.AccAddress(bytes.Repeat([]byte{3}, 20)).String()
+	queryClient := &dumpQueryClientMock{
+		codes: []types.CodeInfoResponse{
+			{CodeID: 1, Creator: "creator1", DataHash: []byte{0x1, 0x2}},
+			{CodeID: 2, Creator: "creator2", DataHash: []byte{0xa, 0xb}},
+		},
+		pinned: []uint64{2},
+		contracts: map[uint64][]types.ContractInfo{
+			1: {
+				{CodeID: 1, Creator: contractA, Admin: "admin", Label: "first", Created: &types.AbsoluteTxPosition{BlockHeight: 10}},
+				{CodeID: 1, Creator: contractB, Label: "second", Created: &types.AbsoluteTxPosition{BlockHeight: 11}},
+			},
+			2: {
+				{CodeID: 2, Creator: contractC, Label: "with \"quotes\", and comma", Created: &types.AbsoluteTxPosition{BlockHeight: 12}},
+			},
+		},
+	}
+	specs := map[string]struct {
+		format     string
+		what       string
+		startAfter string
+		expOut     string
+		expErr     bool
+	}{
+		"codes as csv": {
+			format: dumpFormatCSV,
+			what:   dumpWhatCodes,
+			expOut: "id,creator,checksum,pinned\n1,creator1,0102,false\n2,creator2,0a0b,true\n",
+		},
+		"codes as ndjson": {
+			format: dumpFormatNDJSON,
+			what:   dumpWhatCodes,
+			expOut: `{"id":1,"creator":"creator1","checksum":"0102","pinned":false}` + "\n" +
+				`{"id":2,"creator":"creator2","checksum":"0a0b","pinned":true}` + "\n",
+		},
+		"codes resumed": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatCodes,
+			startAfter: "1",
+			expOut:     "2,creator2,0a0b,true\n",
+		},
+		"codes resumed after last": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatCodes,
+			startAfter: "2",
+			expOut:     "",
+		},
+		"codes resumed with invalid id": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatCodes,
+			startAfter: "foo",
+			expErr:     true,
+		},
+		"contracts as csv": {
+			format: dumpFormatCSV,
+			what:   dumpWhatContracts,
+			expOut: "address,code_id,creator,admin,label,created_height\n" +
+				contractA + ",1," + contractA + ",admin,first,10\n" +
+				contractB + ",1," + contractB + ",,second,11\n" +
+				contractC + ",2," + contractC + `,,"with ""quotes"", and comma",12` + "\n",
+		},
+		"contracts as ndjson": {
+			format: dumpFormatNDJSON,
+			what:   dumpWhatContracts,
+			expOut: `{"address":"` + contractA + `","code_id":1,"creator":"` + contractA + `","admin":"admin","label":"first","created_height":10}` + "\n" +
+				`{"address":"` + contractB + `","code_id":1,"creator":"` + contractB + `","admin":"","label":"second","created_height":11}` + "\n" +
+				`{"address":"` + contractC + `","code_id":2,"creator":"` + contractC + `","admin":"","label":"with \"quotes\", and comma","created_height":12}` + "\n",
+		},
+		"contracts resumed within code": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatContracts,
+			startAfter: contractA,
+			expOut: contractB + ",1," + contractB + ",,second,11\n" +
+				contractC + ",2," + contractC + `,,"with ""quotes"", and comma",12` + "\n",
+		},
+		"contracts resumed at end of code": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatContracts,
+			startAfter: contractB,
+			expOut:     contractC + ",2," + contractC + `,,"with ""quotes"", and comma",12` + "\n",
+		},
+		"contracts resumed after last": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatContracts,
+			startAfter: contractC,
+			expOut:     "",
+		},
+		"contracts resumed with invalid address": {
+			format:     dumpFormatCSV,
+			what:       dumpWhatContracts,
+			startAfter: "invalid",
+			expErr:     true,
+		},
+		"unsupported format": {
+			format: "xml",
+			what:   dumpWhatCodes,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			columns, err := dumpColumns(spec.what)
+			require.NoError(t, err)
+			w, gotErr := newDumpWriter(&out, spec.format, columns, spec.startAfter == "")
+			if gotErr == nil {
+				if spec.what == dumpWhatCodes {
+					gotErr = dumpCodes(context.Background(), queryClient, w, spec.startAfter)
+				} else {
+					gotErr = dumpContracts(context.Background(), queryClient, w, spec.startAfter)
+				}
+			}
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.NoError(t, w.Flush())
+			assert.Equal(t, spec.expOut, out.String())
+		})
+	}
+}
+
+// dumpQueryClientMock returns a single code per page to cover pagination
+type dumpQueryClientMock struct {
+	types.QueryClient
+	codes     []types.CodeInfoResponse
+	pinned    []uint64
+	contracts map[uint64][]types.ContractInfo
+}
+
+func (m dumpQueryClientMock) Codes(_ context.Context, req *types.QueryCodesRequest, _ ...grpc.CallOption) (*types.QueryCodesResponse, error) {
+	var start uint64
+	if len(req.Pagination.Key) != 0 {
+		start = binary.BigEndian.Uint64(req.Pagination.Key)
+	}
+	for i, c := range m.codes {
+		if c.CodeID < start {
+			continue
+		}
+		res := &types.QueryCodesResponse{CodeInfos: []types.CodeInfoResponse{c}, Pagination: &query.PageResponse{}}
+		if i+1 < len(m.codes) {
+			res.Pagination.NextKey = binary.BigEndian.AppendUint64(nil, m.codes[i+1].CodeID)
+		}
+		return res, nil
+	}
+	return &types.QueryCodesResponse{Pagination: &query.PageResponse{}}, nil
+}
+
+func (m dumpQueryClientMock) PinnedCodes(_ context.Context, _ *types.QueryPinnedCodesRequest, _ ...grpc.CallOption) (*types.QueryPinnedCodesResponse, error) {
+	return &types.QueryPinnedCodesResponse{CodeIDs: m.pinned, Pagination: &query.PageResponse{}}, nil
+}
+
+func (m dumpQueryClientMock) ContractsByCode(_ context.Context, req *types.QueryContractsByCodeRequest, _ ...grpc.CallOption) (*types.QueryContractsByCodeResponse, error) {
+	res := &types.QueryContractsByCodeResponse{Pagination: &query.PageResponse{}}
+	for _, c := range m.contracts[req.CodeId] {
+		// the creator is used as contract address in this mock
+		res.Contracts = append(res.Contracts, c.Creator)
+	}
+	return res, nil
+}
+
+func (m dumpQueryClientMock) ContractInfo(_ context.Context, req *types.QueryContractInfoRequest, _ ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	for _, contracts := range m.contracts {
+		for _, c := range contracts {
+			if c.Creator == req.Address {
+				return &types.QueryContractInfoResponse{Address: req.Address, ContractInfo: c}, nil
+			}
+		}
+	}
+	return nil, types.ErrNoSuchContractFn(req.Address)
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagCodeID = "code-id"
+
+// GetCmdQueryFootprint returns the bytes a contract or all contracts of a code id add to the state
+func GetCmdQueryFootprint() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "footprint [bech32_address]",
+		Short: "Query the bytes a contract adds to the state",
+		Long: `Query the bytes a contract adds to the state with a breakdown into the contract info, the code history,
+the contract state and the share of the wasm code. The code size is shared by all instantiations of the code id.
+With --code-id, the footprints of the contracts of the code id are listed with the sum of the page.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			codeID, err := cmd.Flags().GetUint64(flagCodeID)
+			if err != nil {
+				return err
+			}
+			if (len(args) == 1) == (codeID != 0) {
+				return errors.New("either a contract address or --code-id is required")
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			if codeID != 0 {
+				pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+				if err != nil {
+					return err
+				}
+				res, err := queryClient.CodesFootprint(cmd.Context(), &types.QueryCodesFootprintRequest{
+					CodeId:     codeID,
+					Pagination: pageReq,
+				})
+				if err != nil {
+					return err
+				}
+				if clientCtx.OutputFormat == flags.OutputFormatJSON {
+					return clientCtx.PrintProto(res)
+				}
+				return renderCodesFootprint(cmd.OutOrStdout(), res)
+			}
+
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			res, err := queryClient.ContractFootprint(cmd.Context(), &types.QueryContractFootprintRequest{Address: args[0]})
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatJSON {
+				return clientCtx.PrintProto(res)
+			}
+			return renderContractFootprint(cmd.OutOrStdout(), res.Footprint)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagCodeID, 0, "List the footprints of all contracts of this code id")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "contracts of the code id")
+	return cmd
+}
+
+// renderContractFootprint writes the components of the footprint as table
+func renderContractFootprint(out io.Writer, f types.ContractFootprint) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	rows := [][]any{
+		{"COMPONENT", "ENTRIES", "BYTES"},
+		{"contract info", 1, f.ContractInfoBytes},
+		{"history", f.HistoryEntries, f.HistoryBytes},
+		{"state", f.StateEntries, f.StateBytes},
+		{"code share", "-", f.CodeShareBytes},
+		{"total", "-", f.TotalBytes},
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "%v\t%v\t%v\n", row...); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return printFootprintTruncated(out, f.Truncated)
+}
+
+// renderCodesFootprint writes a table row per contract and the sum of the page
+func renderCodesFootprint(out io.Writer, res *types.QueryCodesFootprintResponse) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "CONTRACT\tINFO\tHISTORY\tSTATE\tCODE SHARE\tTOTAL"); err != nil {
+		return err
+	}
+	rows := make([]types.ContractFootprint, 0, len(res.Contracts)+1)
+	for _, f := range append(append(rows, res.Contracts...), res.Sum) {
+		name := f.Address
+		if name == "" {
+			name = "sum"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\n", name, f.ContractInfoBytes, f.HistoryBytes, f.StateBytes, f.CodeShareBytes, f.TotalBytes); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := printFootprintTruncated(out, res.Sum.Truncated); err != nil {
+		return err
+	}
+	if res.Pagination != nil && len(res.Pagination.NextKey) != 0 {
+		_, err := fmt.Fprintf(out, "next page key: %s\n", base64.StdEncoding.EncodeToString(res.Pagination.NextKey))
+		return err
+	}
+	return nil
+}
+
+func printFootprintTruncated(out io.Writer, truncated bool) error {
+	if !truncated {
+		return nil
+	}
+	_, err := fmt.Fprintln(out, "state iteration was capped: the state bytes are a lower bound")
+	return err
+}
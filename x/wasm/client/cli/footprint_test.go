@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRenderContractFootprint(t *testing.T) {
+	specs := map[string]struct {
+		src types.ContractFootprint
+		exp string
+	}{
+		"all components": {
+			src: types.ContractFootprint{
+				Address: "contract1", ContractInfoBytes: 120, HistoryEntries: 2, HistoryBytes: 300,
+				StateEntries: 10, StateBytes: 4000, CodeShareBytes: 50000, TotalBytes: 54420,
+			},
+			exp: "COMPONENT      ENTRIES  BYTES\n" +
+				"contract info  1        120\n" +
+				"history        2        300\n" +
+				"state          10       4000\n" +
+				"code share     -        50000\n" +
+				"total          -        54420\n",
+		},
+		"truncated": {
+			src: types.ContractFootprint{Address: "contract1", StateEntries: 1, StateBytes: 10, TotalBytes: 10, Truncated: true},
+			exp: "COMPONENT      ENTRIES  BYTES\n" +
+				"contract info  1        0\n" +
+				"history        0        0\n" +
+				"state          1        10\n" +
+				"code share     -        0\n" +
+				"total          -        10\n" +
+				"state iteration was capped: the state bytes are a lower bound\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, renderContractFootprint(&out, spec.src))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
+
+func TestRenderCodesFootprint(t *testing.T) {
+	res := &types.QueryCodesFootprintResponse{
+		Contracts: []types.ContractFootprint{
+			{Address: "contract1", ContractInfoBytes: 100, HistoryBytes: 200, StateBytes: 300, CodeShareBytes: 400, TotalBytes: 1000},
+			{Address: "contract2", ContractInfoBytes: 10, HistoryBytes: 20, StateBytes: 30, CodeShareBytes: 40, TotalBytes: 100},
+		},
+		Sum:        types.ContractFootprint{ContractInfoBytes: 110, HistoryBytes: 220, StateBytes: 330, CodeShareBytes: 440, TotalBytes: 1100},
+		Pagination: &query.PageResponse{NextKey: []byte{0x1, 0x2}},
+	}
+	var out bytes.Buffer
+	require.NoError(t, renderCodesFootprint(&out, res))
+	exp := "CONTRACT   INFO  HISTORY  STATE  CODE SHARE  TOTAL\n" +
+		"contract1  100   200      300    400         1000\n" +
+		"contract2  10    20       30     40          100\n" +
+		"sum        110   220      330    440         1100\n" +
+		"next page key: AQI=\n"
+	assert.Equal(t, exp, out.String())
+}
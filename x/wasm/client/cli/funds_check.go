@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagCheckFundsConsistency = "check-funds-consistency"
+	flagStrict                = "strict"
+)
+
+func addFundsConsistencyFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagCheckFundsConsistency, false, "Warn when coins in the json msg, like {\"denom\":\"ustake\",\"amount\":\"100\"}, are not covered by --amount")
+	cmd.Flags().Bool(flagStrict, false, "Fail instead of warn when coins in the json msg are not covered by --amount, implies --"+flagCheckFundsConsistency)
+}
+
+// coinInMsg is a coin shaped object in a contract message
+type coinInMsg struct {
+	Path string
+	Coin sdk.Coin
+}
+
+// checkFundsConsistency warns about coins in the contract message that are not covered by the funds when enabled.
+// An error is returned for them in strict mode only.
+func checkFundsConsistency(ctx context.Context, out io.Writer, flagSet *flag.FlagSet, msg []byte, funds sdk.Coins) error {
+	check, err := flagSet.GetBool(flagCheckFundsConsistency)
+	if err != nil {
+		return fmt.Errorf("check funds consistency: %s", err)
+	}
+	strict, err := flagSet.GetBool(flagStrict)
+	if err != nil {
+		return fmt.Errorf("strict: %s", err)
+	}
+	if !check && !strict {
+		recordPreflight(ctx, preflightFundsConsistency, preflightSkipped, "--"+flagCheckFundsConsistency+" not set")
+		return nil
+	}
+	uncovered := uncoveredCoins(findCoinsInMsg(msg), funds)
+	if len(uncovered) == 0 {
+		recordPreflight(ctx, preflightFundsConsistency, preflightPass, "")
+		return nil
+	}
+	var b strings.Builder
+	b.WriteString("--amount does not cover the coins in the msg:")
+	for _, c := range uncovered {
+		fmt.Fprintf(&b, "\n  %s: %s, --amount has %s", c.Path, c.Coin, sdk.NewCoin(c.Coin.Denom, funds.AmountOf(c.Coin.Denom)))
+	}
+	if strict {
+		recordPreflight(ctx, preflightFundsConsistency, preflightFail, b.String())
+		return errors.New(b.String())
+	}
+	recordPreflight(ctx, preflightFundsConsistency, preflightWarn, b.String())
+	_, err = fmt.Fprintf(out, "warning: %s\n", b.String())
+	return err
+}
+
+// findCoinsInMsg returns the objects with a valid denom and an integer amount, as string or number, in the json msg.
+// Invalid json has no coins.
+func findCoinsInMsg(msg []byte) []coinInMsg {
+	dec := json.NewDecoder(bytes.NewReader(msg))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil
+	}
+	var r []coinInMsg
+	walkJSONCoins("$", v, func(c coinInMsg) {
+		r = append(r, c)
+	})
+	return r
+}
+
+func walkJSONCoins(path string, v any, cb func(coinInMsg)) {
+	switch x := v.(type) {
+	case map[string]any:
+		if coin, ok := jsonCoin(x); ok {
+			cb(coinInMsg{Path: path, Coin: coin})
+			return
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			walkJSONCoins(path+"."+k, x[k], cb)
+		}
+	case []any:
+		for i, e := range x {
+			walkJSONCoins(path+"["+strconv.Itoa(i)+"]", e, cb)
+		}
+	}
+}
+
+func jsonCoin(obj map[string]any) (sdk.Coin, bool) {
+	denom, ok := obj["denom"].(string)
+	if !ok || sdk.ValidateDenom(denom) != nil {
+		return sdk.Coin{}, false
+	}
+	var rawAmount string
+	switch a := obj["amount"].(type) {
+	case string:
+		rawAmount = a
+	case json.Number:
+		rawAmount = a.String()
+	default:
+		return sdk.Coin{}, false
+	}
+	amount, ok := sdkmath.NewIntFromString(rawAmount)
+	if !ok || amount.IsNegative() {
+		return sdk.Coin{}, false
+	}
+	return sdk.Coin{Denom: denom, Amount: amount}, true
+}
+
+// uncoveredCoins returns the coins that exceed the funds of the same denom
+func uncoveredCoins(coins []coinInMsg, funds sdk.Coins) []coinInMsg {
+	var r []coinInMsg
+	for _, c := range coins {
+		if funds.AmountOf(c.Coin.Denom).LT(c.Coin.Amount) {
+			r = append(r, c)
+		}
+	}
+	return r
+}
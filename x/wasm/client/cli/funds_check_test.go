@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFindCoinsInMsg(t *testing.T) {
+	specs := map[string]struct {
+		src string
+		exp []coinInMsg
+	}{
+		"top level coin": {
+			src: `{"denom":"ustake","amount":"1000000"}`,
+			exp: []coinInMsg{{Path: "$", Coin: sdk.NewInt64Coin("ustake", 1000000)}},
+		},
+		"nested coin objects": {
+			src: `{"deposit":{"collateral":{"denom":"ustake","amount":"1000000"}},"fee":{"amount":10,"denom":"uatom"}}`,
+			exp: []coinInMsg{
+				{Path: "$.deposit.collateral", Coin: sdk.NewInt64Coin("ustake", 1000000)},
+				{Path: "$.fee", Coin: sdk.NewInt64Coin("uatom", 10)},
+			},
+		},
+		"arrays of coins": {
+			src: `{"funds":[{"denom":"ustake","amount":"1"},{"denom":"uatom","amount":"2"}]}`,
+			exp: []coinInMsg{
+				{Path: "$.funds[0]", Coin: sdk.NewInt64Coin("ustake", 1)},
+				{Path: "$.funds[1]", Coin: sdk.NewInt64Coin("uatom", 2)},
+			},
+		},
+		"clean message": {
+			src: `{"amount":"1000000","recipient":"foo"}`,
+		},
+		"invalid denom": {
+			src: `{"denom":"1","amount":"1"}`,
+		},
+		"non integer amount": {
+			src: `{"denom":"ustake","amount":"1.5"}`,
+		},
+		"invalid json": {
+			src: `{"denom":`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := findCoinsInMsg([]byte(spec.src))
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestCheckFundsConsistency(t *testing.T) {
+	const myMsg = `{"deposit":{"denom":"ustake","amount":"1000000"},"fees":[{"denom":"uatom","amount":"10"}]}`
+	specs := map[string]struct {
+		src       string
+		funds     sdk.Coins
+		flags     []string
+		expOut    string
+		expErrMsg string
+	}{
+		"not enabled": {
+			src: myMsg,
+		},
+		"covered": {
+			src:   myMsg,
+			funds: sdk.NewCoins(sdk.NewInt64Coin("ustake", 1000000), sdk.NewInt64Coin("uatom", 10)),
+			flags: []string{"--check-funds-consistency"},
+		},
+		"clean message": {
+			src:   `{"release":{}}`,
+			flags: []string{"--check-funds-consistency"},
+		},
+		"not covered warns": {
+			src:   myMsg,
+			funds: sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			flags: []string{"--check-funds-consistency"},
+			expOut: "warning: --amount does not cover the coins in the msg:\n" +
+				"  $.deposit: 1000000ustake, --amount has 0ustake\n",
+		},
+		"partially covered warns": {
+			src:   myMsg,
+			funds: sdk.NewCoins(sdk.NewInt64Coin("ustake", 1), sdk.NewInt64Coin("uatom", 1)),
+			flags: []string{"--check-funds-consistency"},
+			expOut: "warning: --amount does not cover the coins in the msg:\n" +
+				"  $.deposit: 1000000ustake, --amount has 1ustake\n" +
+				"  $.fees[0]: 10uatom, --amount has 1uatom\n",
+		},
+		"not covered in strict mode": {
+			src:       myMsg,
+			funds:     sdk.NewCoins(sdk.NewInt64Coin("uatom", 10)),
+			flags:     []string{"--strict"},
+			expErrMsg: "--amount does not cover the coins in the msg:\n  $.deposit: 1000000ustake, --amount has 0ustake",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := ExecuteContractCmd()
+			require.NoError(t, cmd.Flags().Parse(spec.flags))
+			var out bytes.Buffer
+
+			gotErr := checkFundsConsistency(context.Background(), &out, cmd.Flags(), []byte(spec.src), spec.funds)
+			if spec.expErrMsg != "" {
+				require.EqualError(t, gotErr, spec.expErrMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expOut, out.String())
+		})
+	}
+}
+
+func TestUncoveredCoins(t *testing.T) {
+	coins := []coinInMsg{
+		{Path: "$.a", Coin: sdk.NewInt64Coin("ustake", 2)},
+		{Path: "$.b", Coin: sdk.Coin{Denom: "uatom", Amount: sdkmath.ZeroInt()}},
+	}
+	got := uncoveredCoins(coins, sdk.NewCoins(sdk.NewInt64Coin("ustake", 1)))
+	assert.Equal(t, coins[:1], got)
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagGasPreview = "gas-preview"
+
+// sdkMsgIndexAttribute is added to all events of a tx message by the sdk
+const sdkMsgIndexAttribute = "msg_index"
+
+func addGasPreviewFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagGasPreview, false, "Simulate the tx and print the wasm gas breakdown instead of broadcasting it")
+}
+
+func isGasPreview(flagSet *flag.FlagSet) bool {
+	f := flagSet.Lookup(flagGasPreview)
+	return f != nil && f.Value.String() == "true"
+}
+
+// gasBreakdown is the gas breakdown of a single tx message
+type gasBreakdown struct {
+	MsgIndex string
+	types.EventGasBreakdown
+}
+
+// previewGas simulates the tx and prints the gas breakdown of the wasm messages
+func previewGas(clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) error {
+	txf, err := tx.NewFactoryCLI(clientCtx, flagSet)
+	if err != nil {
+		return err
+	}
+	txf, err = txf.Prepare(clientCtx)
+	if err != nil {
+		return err
+	}
+	simRes, err := simulateWithGasBreakdown(clientCtx, txf, msgs...)
+	if err != nil {
+		return err
+	}
+	var events []abci.Event
+	if simRes.Result != nil {
+		events = simRes.Result.Events
+	}
+	breakdowns, err := parseGasBreakdowns(events)
+	if err != nil {
+		return err
+	}
+	var out io.Writer = os.Stdout
+	if clientCtx.Output != nil {
+		out = clientCtx.Output
+	}
+	var gasUsed uint64
+	if simRes.GasInfo != nil {
+		gasUsed = simRes.GasInfo.GasUsed
+	}
+	return printGasBreakdowns(out, gasUsed, breakdowns)
+}
+
+// simulateWithGasBreakdown simulates the tx like tx.CalculateGas but with a GasBreakdownExtensionOption
+// that requests the gas breakdown events
+func simulateWithGasBreakdown(clientCtx client.Context, txf tx.Factory, msgs ...sdk.Msg) (*txtypes.SimulateResponse, error) {
+	txb, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+	extTxb, ok := txb.(authtx.ExtensionOptionsTxBuilder)
+	if !ok {
+		return nil, errors.New("tx builder does not support extension options")
+	}
+	option, err := codectypes.NewAnyWithValue(&types.GasBreakdownExtensionOption{})
+	if err != nil {
+		return nil, err
+	}
+	extTxb.SetNonCriticalExtensionOptions(option)
+	// an empty signature as in tx.Factory.BuildSimTx, the ante handler populates a sentinel pubkey
+	sig := signing.SignatureV2{
+		PubKey:   &secp256k1.PubKey{},
+		Data:     &signing.SingleSignatureData{SignMode: txf.SignMode()},
+		Sequence: txf.Sequence(),
+	}
+	if err := txb.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	txBytes, err := clientCtx.TxConfig.TxEncoder()(txb.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	return txtypes.NewServiceClient(clientCtx).Simulate(context.Background(), &txtypes.SimulateRequest{TxBytes: txBytes})
+}
+
+// parseGasBreakdowns returns the gas breakdowns from the simulation events
+func parseGasBreakdowns(events []abci.Event) ([]gasBreakdown, error) {
+	eventType := proto.MessageName(&types.EventGasBreakdown{})
+	var r []gasBreakdown
+	for _, e := range events {
+		if e.Type != eventType {
+			continue
+		}
+		var b gasBreakdown
+		typedEvent := abci.Event{Type: e.Type}
+		for _, a := range e.Attributes {
+			if a.Key == sdkMsgIndexAttribute {
+				b.MsgIndex = a.Value
+				continue
+			}
+			typedEvent.Attributes = append(typedEvent.Attributes, a)
+		}
+		msg, err := sdk.ParseTypedEvent(typedEvent)
+		if err != nil {
+			return nil, fmt.Errorf("parse gas breakdown: %w", err)
+		}
+		b.EventGasBreakdown = *msg.(*types.EventGasBreakdown)
+		r = append(r, b)
+	}
+	return r, nil
+}
+
+func printGasBreakdowns(out io.Writer, gasUsed uint64, breakdowns []gasBreakdown) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "MSG\tSETUP\tEXECUTION\tSTORAGE\tEVENTS\tSUBMESSAGES\tOTHER\tTOTAL"); err != nil {
+		return err
+	}
+	for i, b := range breakdowns {
+		msgIndex := b.MsgIndex
+		if msgIndex == "" {
+			msgIndex = strconv.Itoa(i)
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", msgIndex,
+			b.Setup, b.Execution, b.Storage, b.Events, b.Submessages, b.Other, b.Total); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "gas used by tx: %d\n", gasUsed)
+	return err
+}
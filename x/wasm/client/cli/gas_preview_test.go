@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestParseGasBreakdowns(t *testing.T) {
+	myBreakdown := types.EventGasBreakdown{Setup: 1, Execution: 2, Storage: 3, Events: 4, Submessages: 5, Other: 6, Total: 21}
+	typedEvent, err := sdk.TypedEventToEvent(&myBreakdown)
+	require.NoError(t, err)
+	// the sdk adds the msg index to all events of a tx message
+	withMsgIndex := typedEvent.AppendAttributes(sdk.NewAttribute("msg_index", "1"))
+
+	specs := map[string]struct {
+		src    []abci.Event
+		exp    []gasBreakdown
+		expErr bool
+	}{
+		"with msg index": {
+			src: []abci.Event{
+				abci.Event(sdk.NewEvent("execute", sdk.NewAttribute("msg_index", "1"))),
+				abci.Event(withMsgIndex),
+			},
+			exp: []gasBreakdown{{MsgIndex: "1", EventGasBreakdown: myBreakdown}},
+		},
+		"without msg index": {
+			src: []abci.Event{abci.Event(typedEvent)},
+			exp: []gasBreakdown{{EventGasBreakdown: myBreakdown}},
+		},
+		"no breakdown": {
+			src: []abci.Event{abci.Event(sdk.NewEvent("execute"))},
+		},
+		"invalid attribute": {
+			src: []abci.Event{{
+				Type:       "cosmwasm.wasm.v1.EventGasBreakdown",
+				Attributes: []abci.EventAttribute{{Key: "setup", Value: "not a number"}},
+			}},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseGasBreakdowns(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestPrintGasBreakdowns(t *testing.T) {
+	var out bytes.Buffer
+	breakdowns := []gasBreakdown{
+		{MsgIndex: "0", EventGasBreakdown: types.EventGasBreakdown{Setup: 1, Execution: 2, Storage: 3, Events: 4, Submessages: 5, Other: 6, Total: 21}},
+		{EventGasBreakdown: types.EventGasBreakdown{Setup: 100, Execution: 200, Total: 300}},
+	}
+	require.NoError(t, printGasBreakdowns(&out, 12345, breakdowns))
+	exp := "MSG  SETUP  EXECUTION  STORAGE  EVENTS  SUBMESSAGES  OTHER  TOTAL\n" +
+		"0    1      2          3        4       5            6      21\n" +
+		"1    100    200        0        0       0            0      300\n" +
+		"gas used by tx: 12345\n"
+	assert.Equal(t, exp, out.String())
+}
@@ -2,9 +2,11 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 
@@ -16,7 +18,6 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/cosmos/cosmos-sdk/client/tx"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -33,8 +34,11 @@ var DefaultGovAuthority = sdk.AccAddress(address.Module("gov"))
 
 func SubmitProposalCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "submit-proposal",
-		Short:        "Submit a wasm proposal.",
+		Use:   "submit-proposal",
+		Short: "Submit a wasm proposal.",
+		Long: `Submit a wasm proposal.
+With --generate-only, a human-readable summary of the proposal messages is written to stderr
+for review. The generated tx on stdout is not modified.`,
 		SilenceUsage: true,
 	}
 	cmd.AddCommand(
@@ -53,6 +57,12 @@ func SubmitProposalCmd() *cobra.Command {
 		ProposalAddCodeUploadParamsAddresses(),
 		ProposalRemoveCodeUploadParamsAddresses(),
 		ProposalStoreAndMigrateContractCmd(),
+		ProposalSetFeelessExecutionsCmd(),
+		ProposalSetContractGasBudgetsCmd(),
+		ProposalFreezeChecksumCmd(),
+		ProposalDeprecateCodeCmd(),
+		ProposalForceMigrateCmd(),
+		ProposalPruneContractHistoryCmd(),
 	)
 	return cmd
 }
@@ -86,7 +96,7 @@ func ProposalStoreCodeCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -106,9 +116,15 @@ func parseVerificationFlags(gzippedWasm []byte, flags *flag.FlagSet) (string, st
 	if err != nil {
 		return "", "", nil, fmt.Errorf("builder: %s", err)
 	}
-	codeHash, err := flags.GetBytesHex(flagCodeHash)
+	codeHashHex, err := flags.GetString(flagCodeHash)
 	if err != nil {
-		return "", "", nil, fmt.Errorf("codeHash: %s", err)
+		return "", "", nil, fmt.Errorf("code hash: %s", err)
+	}
+	var codeHash []byte
+	if codeHashHex != "" {
+		if codeHash, err = hexDecodeString(codeHashHex); err != nil {
+			return "", "", nil, fmt.Errorf("invalid --%s: %w", flagCodeHash, err)
+		}
 	}
 
 	// if any set require others to be set
@@ -166,7 +182,7 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 				return errors.New("authority address is required")
 			}
 
-			instantiateMsg, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, authority, cmd.Flags())
+			instantiateMsg, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, authority, queryDefaultAdminPolicy(cmd.Context(), clientCtx), cmd.Flags())
 			if err != nil {
 				return err
 			}
@@ -176,14 +192,14 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	addAmountFlag(cmd, "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
-	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin, unless the chain default admin policy allows an empty admin")
 
 	// proposal flags
 	addCommonProposalFlags(cmd)
@@ -191,7 +207,7 @@ func ProposalInstantiateContractCmd() *cobra.Command {
 }
 
 func ProposalInstantiateContract2Cmd() *cobra.Command {
-	decoder := newArgDecoder(hex.DecodeString)
+	decoder := newArgDecoder(hexDecodeString)
 	cmd := &cobra.Command{
 		Use: "instantiate-contract-2 [code_id_int64] [json_encoded_init_args] [salt] --authority [address] --label [text] --title [text] " +
 			"--summary [text] --admin [address,optional] --amount [coins,optional] --fix-msg [bool,optional]",
@@ -204,7 +220,7 @@ func ProposalInstantiateContract2Cmd() *cobra.Command {
 			}
 			salt, err := decoder.DecodeString(args[2])
 			if err != nil {
-				return fmt.Errorf("salt: %w", err)
+				return err
 			}
 			fixMsg, err := cmd.Flags().GetBool(flagFixMsg)
 			if err != nil {
@@ -219,19 +235,20 @@ func ProposalInstantiateContract2Cmd() *cobra.Command {
 				return errors.New("authority address is required")
 			}
 
-			data, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, authority, cmd.Flags())
+			data, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, authority, queryDefaultAdminPolicy(cmd.Context(), clientCtx), cmd.Flags())
 			if err != nil {
 				return err
 			}
 			instantiateMsg := &types.MsgInstantiateContract2{
-				Sender: data.Sender,
-				Admin:  data.Admin,
-				CodeID: data.CodeID,
-				Label:  data.Label,
-				Msg:    data.Msg,
-				Funds:  data.Funds,
-				Salt:   salt,
-				FixMsg: fixMsg,
+				Sender:  data.Sender,
+				Admin:   data.Admin,
+				CodeID:  data.CodeID,
+				Label:   data.Label,
+				Msg:     data.Msg,
+				Funds:   data.Funds,
+				Salt:    salt,
+				FixMsg:  fixMsg,
+				NoAdmin: data.NoAdmin,
 			}
 
 			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{instantiateMsg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
@@ -239,15 +256,15 @@ func ProposalInstantiateContract2Cmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	addAmountFlag(cmd, "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address of an admin")
-	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin, unless the chain default admin policy allows an empty admin")
 	cmd.Flags().Bool(flagFixMsg, false, "An optional flag to include the json_encoded_init_args for the predictable address generation mode")
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
 
@@ -293,11 +310,7 @@ func ProposalStoreAndInstantiateContractCmd() *cobra.Command {
 				return err
 			}
 
-			amountStr, err := cmd.Flags().GetString(flagAmount)
-			if err != nil {
-				return fmt.Errorf("amount: %s", err)
-			}
-			amount, err := sdk.ParseCoinsNormalized(amountStr)
+			amount, err := parseAmountFlag(cmd.Flags())
 			if err != nil {
 				return fmt.Errorf("amount: %s", err)
 			}
@@ -305,8 +318,8 @@ func ProposalStoreAndInstantiateContractCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("label: %s", err)
 			}
-			if label == "" {
-				return errors.New("label is required on all contracts")
+			if err := validateLabel(label); err != nil {
+				return err
 			}
 			adminStr, err := cmd.Flags().GetString(flagAdmin)
 			if err != nil {
@@ -364,7 +377,7 @@ func ProposalStoreAndInstantiateContractCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -372,8 +385,8 @@ func ProposalStoreAndInstantiateContractCmd() *cobra.Command {
 	cmd.Flags().Bool(flagUnpinCode, false, "Unpin code on upload, optional")
 	cmd.Flags().String(flagSource, "", "Code Source URL is a valid absolute HTTPS URI to the contract's source code,")
 	cmd.Flags().String(flagBuilder, "", "Builder is a valid docker image name with tag, such as \"cosmwasm/workspace-optimizer:0.12.9\"")
-	cmd.Flags().BytesHex(flagCodeHash, nil, "CodeHash is the sha256 hash of the wasm code")
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	cmd.Flags().String(flagCodeHash, "", "CodeHash is the hex encoded sha256 hash of the wasm code, optional 0x prefix")
+	addAmountFlag(cmd, "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
 	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
@@ -407,16 +420,20 @@ func ProposalMigrateContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if err := checkCW2FromFlags(cmd, clientCtx, migrateMsg.Contract); err != nil {
+				return err
+			}
 
 			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&migrateMsg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
 			if err != nil {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
+	addCW2CheckFlags(cmd)
 	// proposal flags
 	addCommonProposalFlags(cmd)
 	return cmd
@@ -444,11 +461,7 @@ func ProposalExecuteContractCmd() *cobra.Command {
 
 			contract := args[0]
 			execMsg := []byte(args[1])
-			amountStr, err := cmd.Flags().GetString(flagAmount)
-			if err != nil {
-				return fmt.Errorf("amount: %s", err)
-			}
-			funds, err := sdk.ParseCoinsNormalized(amountStr)
+			funds, err := parseAmountFlag(cmd.Flags())
 			if err != nil {
 				return fmt.Errorf("amount: %s", err)
 			}
@@ -468,11 +481,11 @@ func ProposalExecuteContractCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	addAmountFlag(cmd, "Coins to send to the contract during instantiation")
 
 	// proposal flags
 	addCommonProposalFlags(cmd)
@@ -513,7 +526,7 @@ func ProposalSudoContractCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -552,7 +565,7 @@ func ProposalUpdateContractAdminCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -594,7 +607,7 @@ func ProposalClearContractAdminCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -641,7 +654,7 @@ func ProposalPinCodesCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -699,7 +712,7 @@ func ProposalUnpinCodesCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -812,7 +825,7 @@ $ %s tx gov submit-proposal update-instantiate-config 1:nobody 2:everybody 3:%s1
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -851,7 +864,7 @@ func ProposalAddCodeUploadParamsAddresses() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -890,7 +903,138 @@ func ProposalRemoveCodeUploadParamsAddresses() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+func ProposalSetFeelessExecutionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-feeless-executions [contract:msg_key]... --max-gas [uint] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to replace the contract executions that can be sent without fees",
+		Long: fmt.Sprintf(`Submit a proposal to replace the contract executions that can be sent without fees.
+A tx consisting solely of executions of the listed contracts with the listed top-level message keys bypasses the
+min fee check when its gas limit does not exceed the max gas. Run without arguments to clear the list.
+
+Example:
+$ %s tx wasm submit-proposal set-feeless-executions [oracle_addr]:push_price --max-gas 300000 \
+  --title "Fee-less oracle" --summary "Allow oracle price pushes without fees" --from mykey
+`, version.AppName),
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			maxGas, err := cmd.Flags().GetUint64(flagMaxGas)
+			if err != nil {
+				return fmt.Errorf("max gas: %s", err)
+			}
+			allowed, err := parseFeelessExecutions(args)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgSetFeelessExecutions{
+				Authority:         authority,
+				FeelessExecutions: types.FeelessExecutions{Allowed: allowed, MaxGas: maxGas},
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagMaxGas, 0, "The max gas limit of a fee-less tx")
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// parseFeelessExecutions parses args in the format contract:msg_key
+func parseFeelessExecutions(args []string) ([]types.FeelessExecution, error) {
+	r := make([]types.FeelessExecution, len(args))
+	for i, v := range args {
+		contract, msgKey, ok := strings.Cut(v, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid format %q: expected contract:msg_key", v)
+		}
+		if _, err := sdk.AccAddressFromBech32(contract); err != nil {
+			return nil, fmt.Errorf("contract %q: %s", contract, err)
+		}
+		r[i] = types.FeelessExecution{Contract: contract, MsgKey: msgKey}
+	}
+	return r, nil
+}
+
+func ProposalSetContractGasBudgetsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-contract-gas-budgets [contract:max_gas_per_block]... --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to replace the per block execution gas budgets of contracts",
+		Long: fmt.Sprintf(`Submit a proposal to replace the per block execution gas budgets of contracts.
+Once the execution gas of a contract within a block exceeds its budget, further executions of the contract
+fail in this block. Contracts without a budget are unlimited. Run without arguments to remove all budgets.
+
+Example:
+$ %s tx wasm submit-proposal set-contract-gas-budgets [contract_addr]:10000000 \
+  --title "Limit bot" --summary "Limit the gas of the bot contract per block" --from mykey
+`, version.AppName),
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			budgets, err := parseContractGasBudgets(args)
+			if err != nil {
+				return err
+			}
+
+			msg := types.MsgSetContractGasBudgets{
+				Authority: authority,
+				Budgets:   budgets,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -899,6 +1043,303 @@ func ProposalRemoveCodeUploadParamsAddresses() *cobra.Command {
 	return cmd
 }
 
+// ProposalFreezeChecksumCmd submits a proposal to set the instantiate config of all codes with a checksum to nobody
+func ProposalFreezeChecksumCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "freeze-checksum [checksum] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to stop new instantiations of all code ids with a hex encoded checksum",
+		Long: fmt.Sprintf(`Submit a proposal to set the instantiate config of all code ids with a hex encoded checksum to nobody.
+The code ids are resolved when the proposal is executed so that codes stored after the submission are covered.
+The code ids currently stored with the checksum are printed for the reviewers.
+
+Example:
+$ %s tx wasm submit-proposal freeze-checksum [checksum] \
+  --title "Freeze vulnerable code" --summary "Stop new instances of the vulnerable build" --from mykey
+`, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			checksum, err := hexDecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid checksum: %w", err)
+			}
+
+			msg := types.MsgFreezeCodeByChecksum{
+				Authority: authority,
+				Checksum:  checksum,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			if !clientCtx.Offline {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), freezeChecksumNotice(cmd.Context(), types.NewQueryClient(clientCtx), hex.EncodeToString(checksum)))
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// ProposalDeprecateCodeCmd submits a proposal to deprecate a code id
+func ProposalDeprecateCodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deprecate-code [code_id] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to stop new instantiations of a code id and migrations to it",
+		Long: fmt.Sprintf(`Submit a proposal to deprecate a code id. Deprecated codes can not be instantiated or used as
+migration target anymore. Existing contracts keep running and can still migrate to other code ids.
+
+Example:
+$ %s tx wasm submit-proposal deprecate-code 1 \
+  --title "Deprecate code 1" --summary "Stop new instances of the vulnerable build" --from mykey
+`, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("code id: %s", err)
+			}
+
+			msg := types.MsgDeprecateCode{
+				Authority: authority,
+				CodeID:    codeID,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// ProposalPruneContractHistoryCmd submits a proposal to delete old code history entries of a contract
+func ProposalPruneContractHistoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune-contract-history [contract_addr_bech32] [keep_last_n] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to delete all but the newest history entries of a contract",
+		Long: fmt.Sprintf(`Submit a proposal to delete all but the newest keep_last_n code history entries of a contract.
+The init entry and the latest code change are always kept. Use "query wasm contract-history --count-only"
+to find contracts with a long history.
+
+Example:
+$ %s tx wasm submit-proposal prune-contract-history wasm1... 10 \
+  --title "Prune contract history" --summary "Bound the state of an often updated contract" --from mykey
+`, version.AppName),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			keepLastN, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("keep last n: %s", err)
+			}
+
+			msg := types.MsgPruneContractHistory{
+				Authority: authority,
+				Contract:  args[0],
+				KeepLastN: keepLastN,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// ProposalForceMigrateCmd submits a proposal to migrate a list of contracts without checking their admins
+func ProposalForceMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "force-migrate [contracts_file] [new_code_id_int64] [json_encoded_migration_args] --title [text] --summary [text] --authority [address]",
+		Short: "Submit a proposal to migrate a list of contracts to a new code version without admin checks",
+		Long: fmt.Sprintf(`Submit a proposal to migrate a list of contracts to a new code version in an emergency.
+The contract admins are not checked so that contracts with unresponsive or cleared admins are covered.
+The migrate entry point of each contract is called with the same message.
+
+The contracts file contains one contract address per line. Empty lines and lines starting with '#' are ignored.
+By default, a failed migration is reverted and reported in the events while the other contracts are migrated.
+With --%s, the proposal fails when any migration fails.
+
+Example:
+$ %s tx wasm submit-proposal force-migrate contracts.txt 42 '{"fix":{}}' \
+  --title "Emergency migration" --summary "Patch the vulnerable contracts" --from mykey
+`, flagAtomic, version.AppName),
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, summary, deposit, expedite, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+
+			if len(authority) == 0 {
+				return errors.New("authority address is required")
+			}
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("contracts file: %s", err)
+			}
+			contracts := parseContractsFile(bz)
+
+			codeID, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("code id: %s", err)
+			}
+
+			atomic, err := cmd.Flags().GetBool(flagAtomic)
+			if err != nil {
+				return fmt.Errorf("atomic: %s", err)
+			}
+
+			msg := types.MsgForceMigrateWithoutAdminCheck{
+				Authority: authority,
+				Contracts: contracts,
+				CodeID:    codeID,
+				Msg:       []byte(args[2]),
+				Atomic:    atomic,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			proposalMsg, err := v1.NewMsgSubmitProposal([]sdk.Msg{&msg}, deposit, clientCtx.GetFromAddress().String(), "", proposalTitle, summary, expedite)
+			if err != nil {
+				return err
+			}
+			if err := checkTxSize(cmd.Context(), clientCtx, cmd.Flags(), "split the contracts file into multiple proposals", proposalMsg); err != nil {
+				return err
+			}
+
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
+		},
+		SilenceUsage: true,
+	}
+	addMaxTxBytesFlag(cmd)
+	cmd.Flags().Bool(flagAtomic, false, "Fail the proposal when any migration fails")
+	// proposal flags
+	addCommonProposalFlags(cmd)
+	return cmd
+}
+
+// parseContractsFile returns the contract addresses of a file with one address per line. Empty lines and comments
+// are skipped.
+func parseContractsFile(bz []byte) []string {
+	var r []string
+	for _, l := range strings.Split(string(bz), "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		r = append(r, l)
+	}
+	return r
+}
+
+// freezeChecksumNotice lists the code ids currently stored with the checksum
+func freezeChecksumNotice(ctx context.Context, queryClient types.QueryClient, checksum string) string {
+	res, err := queryClient.CodeIdByChecksum(ctx, &types.QueryCodeIdByChecksumRequest{Checksum: checksum})
+	if err != nil {
+		return fmt.Sprintf("code ids with checksum %s not resolved: %s", checksum, err)
+	}
+	codeIDs := make([]string, len(res.CodeIDs))
+	for i, id := range res.CodeIDs {
+		codeIDs[i] = strconv.FormatUint(id, 10)
+	}
+	return fmt.Sprintf("code ids currently stored with checksum %s: %s", checksum, strings.Join(codeIDs, ", "))
+}
+
+// parseContractGasBudgets parses args in the format contract:max_gas_per_block
+func parseContractGasBudgets(args []string) ([]types.ContractGasBudget, error) {
+	r := make([]types.ContractGasBudget, len(args))
+	for i, v := range args {
+		contract, rawGas, ok := strings.Cut(v, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid format %q: expected contract:max_gas_per_block", v)
+		}
+		if _, err := sdk.AccAddressFromBech32(contract); err != nil {
+			return nil, fmt.Errorf("contract %q: %s", contract, err)
+		}
+		maxGas, err := strconv.ParseUint(rawGas, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("max gas per block %q: %s", rawGas, err)
+		}
+		r[i] = types.ContractGasBudget{Contract: contract, MaxGasPerBlock: maxGas}
+	}
+	return r, nil
+}
+
 func addCommonProposalFlags(cmd *cobra.Command) {
 	flags.AddTxFlagsToCmd(cmd)
 	cmd.Flags().String(cli.FlagTitle, "", "Title of proposal")
@@ -981,7 +1422,7 @@ func ProposalStoreAndMigrateContractCmd() *cobra.Command {
 				return err
 			}
 
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposalMsg)
+			return generateOrBroadcastProposal(cmd, clientCtx, proposalMsg)
 		},
 		SilenceUsage: true,
 	}
@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"os"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -136,6 +139,18 @@ func TestParseCodeInfoFlags(t *testing.T) {
 			args:   []string{"--code-source-url=" + correctSource, "--builder=" + correctBuilderRef, "--code-hash=" + "AA"},
 			expErr: true,
 		},
+		"code hash with 0x prefix": {
+			args:   []string{"--code-source-url=" + correctSource, "--builder=" + correctBuilderRef, "--code-hash=0x" + checksumStr},
+			expErr: false,
+		},
+		"code hash with odd length": {
+			args:   []string{"--code-source-url=" + correctSource, "--builder=" + correctBuilderRef, "--code-hash=" + checksumStr[1:]},
+			expErr: true,
+		},
+		"code hash not hex": {
+			args:   []string{"--code-source-url=" + correctSource, "--builder=" + correctBuilderRef, "--code-hash=" + strings.Repeat("x", 64)},
+			expErr: true,
+		},
 		"happy path, none set": {
 			args:   []string{},
 			expErr: false,
@@ -158,3 +173,143 @@ func TestParseCodeInfoFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFeelessExecutions(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	specs := map[string]struct {
+		src    []string
+		exp    []types.FeelessExecution
+		expErr bool
+	}{
+		"single": {
+			src: []string{myContract + ":push_price"},
+			exp: []types.FeelessExecution{{Contract: myContract, MsgKey: "push_price"}},
+		},
+		"multiple": {
+			src: []string{myContract + ":push_price", myContract + ":push_prices"},
+			exp: []types.FeelessExecution{{Contract: myContract, MsgKey: "push_price"}, {Contract: myContract, MsgKey: "push_prices"}},
+		},
+		"none": {
+			src: []string{},
+			exp: []types.FeelessExecution{},
+		},
+		"missing msg key separator": {
+			src:    []string{myContract},
+			expErr: true,
+		},
+		"invalid contract": {
+			src:    []string{"foo:push_price"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseFeelessExecutions(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestParseContractGasBudgets(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	specs := map[string]struct {
+		src    []string
+		exp    []types.ContractGasBudget
+		expErr bool
+	}{
+		"single": {
+			src: []string{myContract + ":1000000"},
+			exp: []types.ContractGasBudget{{Contract: myContract, MaxGasPerBlock: 1_000_000}},
+		},
+		"none": {
+			src: []string{},
+			exp: []types.ContractGasBudget{},
+		},
+		"missing separator": {
+			src:    []string{myContract},
+			expErr: true,
+		},
+		"invalid contract": {
+			src:    []string{"foo:1000000"},
+			expErr: true,
+		},
+		"invalid max gas": {
+			src:    []string{myContract + ":-1"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := parseContractGasBudgets(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestFreezeChecksumNotice(t *testing.T) {
+	specs := map[string]struct {
+		rsp *types.QueryCodeIdByChecksumResponse
+		err error
+		exp string
+	}{
+		"matching codes": {
+			rsp: &types.QueryCodeIdByChecksumResponse{CodeIDs: []uint64{1, 3}},
+			exp: "code ids currently stored with checksum abcd: 1, 3",
+		},
+		"no matching code": {
+			err: errors.New("not found"),
+			exp: "code ids with checksum abcd not resolved: not found",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockCodeIDByChecksumQueryClient{rsp: spec.rsp, err: spec.err}
+			got := freezeChecksumNotice(context.Background(), queryClient, "abcd")
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+type mockCodeIDByChecksumQueryClient struct {
+	types.QueryClient
+	rsp *types.QueryCodeIdByChecksumResponse
+	err error
+}
+
+func (m mockCodeIDByChecksumQueryClient) CodeIdByChecksum(_ context.Context, _ *types.QueryCodeIdByChecksumRequest, _ ...grpc.CallOption) (*types.QueryCodeIdByChecksumResponse, error) {
+	return m.rsp, m.err
+}
+
+func TestParseContractsFile(t *testing.T) {
+	specs := map[string]struct {
+		src string
+		exp []string
+	}{
+		"one per line": {
+			src: "cosmos1a\ncosmos1b\n",
+			exp: []string{"cosmos1a", "cosmos1b"},
+		},
+		"skip empty lines and comments": {
+			src: "# vulnerable contracts\n\n  cosmos1a  \r\n\t\n#cosmos1b\ncosmos1c",
+			exp: []string{"cosmos1a", "cosmos1c"},
+		},
+		"empty": {
+			src: "\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, parseContractsFile([]byte(spec.src)))
+		})
+	}
+}
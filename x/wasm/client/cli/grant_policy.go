@@ -0,0 +1,372 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// grantPolicy is the desired state of the wasm authz grants of a granter
+type grantPolicy struct {
+	Grantees []granteePolicy `yaml:"grantees"`
+}
+
+// granteePolicy declares the wasm grants of a grantee. At least one grant must be set.
+type granteePolicy struct {
+	Grantee   string                 `yaml:"grantee"`
+	Execution *contractGrantsPolicy  `yaml:"execution"`
+	Migration *contractGrantsPolicy  `yaml:"migration"`
+	StoreCode *storeCodeGrantsPolicy `yaml:"store_code"`
+}
+
+// contractGrantsPolicy is a contract execution or migration authorization
+type contractGrantsPolicy struct {
+	Expiration *time.Time            `yaml:"expiration"`
+	Contracts  []contractGrantPolicy `yaml:"contracts"`
+}
+
+// contractGrantPolicy has the same settings as the flags of the grant contract command
+type contractGrantPolicy struct {
+	Contract         string   `yaml:"contract"`
+	MaxCalls         uint64   `yaml:"max_calls"`
+	MaxFunds         string   `yaml:"max_funds"`
+	NoTokenTransfer  bool     `yaml:"no_token_transfer"`
+	AllowAllMessages bool     `yaml:"allow_all_messages"`
+	AllowMsgKeys     []string `yaml:"allow_msg_keys"`
+	AllowRawMsgs     []string `yaml:"allow_raw_msgs"`
+}
+
+// storeCodeGrantsPolicy is a store code authorization
+type storeCodeGrantsPolicy struct {
+	Expiration *time.Time `yaml:"expiration"`
+	// Grants have the code_hash:permission format of the grant store-code command
+	Grants []string `yaml:"grants"`
+}
+
+// managedGrantMsgTypes are the msg types of the grants that are converged to the policy
+var managedGrantMsgTypes = map[string]struct{}{
+	sdk.MsgTypeURL(&types.MsgExecuteContract{}): {},
+	sdk.MsgTypeURL(&types.MsgMigrateContract{}): {},
+	sdk.MsgTypeURL(&types.MsgStoreCode{}):       {},
+}
+
+// grantState is an authz grant of the granter
+type grantState struct {
+	grantee       string
+	msgTypeURL    string
+	authorization *codectypes.Any
+	expiration    *time.Time
+}
+
+func (g grantState) key() string {
+	return g.grantee + "/" + g.msgTypeURL
+}
+
+// equal returns true when the authorization and expiration match
+func (g grantState) equal(o grantState) bool {
+	switch {
+	case g.authorization.TypeUrl != o.authorization.TypeUrl || !bytes.Equal(g.authorization.Value, o.authorization.Value):
+		return false
+	case g.expiration == nil || o.expiration == nil:
+		return g.expiration == o.expiration
+	default:
+		return g.expiration.Equal(*o.expiration)
+	}
+}
+
+const (
+	grantActionGrant  = "grant"
+	grantActionRevoke = "revoke"
+)
+
+// grantChange is a step to converge the grants to the policy
+type grantChange struct {
+	action string
+	grant  grantState
+}
+
+// GrantApplyPolicyCmd converges the wasm authz grants of the sender to a policy file
+func GrantApplyPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply [policy.yaml]",
+		Short: "Converge the wasm grants of the sender to a policy file",
+		Long: fmt.Sprintf(`Converge the wasm grants of the sender to a policy file.
+The policy declares all contract execution, contract migration and store code grants of the sender.
+The grants are compared with the grants on chain and the plan of the required revokes and grants is printed.
+Changed grants are revoked before they are granted again. Grants of these msg types that are not in the policy are revoked.
+Other grants are not modified. The plan is broadcasted with --yes only.
+
+The contract grants have the same settings as the flags of the "grant contract" command, the store code grants
+have the code_hash:permission format of the "grant store-code" command. Unknown fields are rejected.
+
+grantees:
+  - grantee: <grantee_addr>
+    execution:
+      expiration: 2030-01-01T00:00:00Z
+      contracts:
+        - {contract: <contract_addr>, allow_all_messages: true, max_calls: 5, no_token_transfer: true}
+        - {contract: <contract_addr>, allow_msg_keys: [transfer], max_funds: 100000uwasm}
+    store_code:
+      grants: ["*:everybody"]
+
+Example:
+$ %s tx wasm grant apply policy.yaml --from mykey --yes
+`, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			granter := clientCtx.GetFromAddress()
+			desired, err := parseGrantPolicy(bz, granter)
+			if err != nil {
+				return fmt.Errorf("policy %s: %w", args[0], err)
+			}
+			existing, err := queryGranterGrants(cmd.Context(), authz.NewQueryClient(clientCtx), clientCtx.InterfaceRegistry, granter)
+			if err != nil {
+				return err
+			}
+			changes := planGrantChanges(desired, existing)
+			if err := printGrantPlan(cmd.ErrOrStderr(), changes); err != nil {
+				return err
+			}
+			if len(changes) == 0 {
+				return nil
+			}
+			if !clientCtx.SkipConfirm && !clientCtx.GenerateOnly && !clientCtx.Simulate {
+				_, err := fmt.Fprintf(cmd.ErrOrStderr(), "run again with --%s to broadcast the plan\n", flags.FlagSkipConfirmation)
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), grantChangeMsgs(granter, changes)...)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseGrantPolicy parses the policy file strictly and returns the declared grants of the granter
+func parseGrantPolicy(bz []byte, granter sdk.AccAddress) ([]grantState, error) {
+	var p grantPolicy
+	if err := yaml.UnmarshalStrict(bz, &p); err != nil {
+		return nil, err
+	}
+	var r []grantState
+	seen := make(map[string]struct{}, len(p.Grantees))
+	for i, g := range p.Grantees {
+		grantee, err := sdk.AccAddressFromBech32(g.Grantee)
+		if err != nil {
+			return nil, fmt.Errorf("grantee %d: %s", i, err)
+		}
+		switch _, exists := seen[grantee.String()]; {
+		case exists:
+			return nil, fmt.Errorf("grantee %s: duplicate", grantee)
+		case grantee.Equals(granter):
+			return nil, fmt.Errorf("grantee %s: must not be the granter", grantee)
+		case g.Execution == nil && g.Migration == nil && g.StoreCode == nil:
+			return nil, fmt.Errorf("grantee %s: no grants", grantee)
+		}
+		seen[grantee.String()] = struct{}{}
+
+		var authorizations []authz.Authorization
+		var expirations []*time.Time
+		if g.Execution != nil {
+			grants, err := parseContractGrantsPolicy(*g.Execution)
+			if err != nil {
+				return nil, fmt.Errorf("grantee %s: execution: %w", grantee, err)
+			}
+			authorizations = append(authorizations, types.NewContractExecutionAuthorization(grants...))
+			expirations = append(expirations, g.Execution.Expiration)
+		}
+		if g.Migration != nil {
+			grants, err := parseContractGrantsPolicy(*g.Migration)
+			if err != nil {
+				return nil, fmt.Errorf("grantee %s: migration: %w", grantee, err)
+			}
+			authorizations = append(authorizations, types.NewContractMigrationAuthorization(grants...))
+			expirations = append(expirations, g.Migration.Expiration)
+		}
+		if g.StoreCode != nil {
+			if len(g.StoreCode.Grants) == 0 {
+				return nil, fmt.Errorf("grantee %s: store code: no grants", grantee)
+			}
+			grants, err := parseStoreCodeGrants(g.StoreCode.Grants)
+			if err != nil {
+				return nil, fmt.Errorf("grantee %s: store code: %w", grantee, err)
+			}
+			authorizations = append(authorizations, types.NewStoreCodeAuthorization(grants...))
+			expirations = append(expirations, g.StoreCode.Expiration)
+		}
+		for j, a := range authorizations {
+			if err := a.ValidateBasic(); err != nil {
+				return nil, fmt.Errorf("grantee %s: %s: %w", grantee, a.MsgTypeURL(), err)
+			}
+			anyAuthorization, err := codectypes.NewAnyWithValue(a)
+			if err != nil {
+				return nil, err
+			}
+			r = append(r, grantState{grantee: grantee.String(), msgTypeURL: a.MsgTypeURL(), authorization: anyAuthorization, expiration: expirations[j]})
+		}
+	}
+	return r, nil
+}
+
+// parseContractGrantsPolicy returns the contract grants of a contract execution or migration authorization
+func parseContractGrantsPolicy(p contractGrantsPolicy) ([]types.ContractGrant, error) {
+	if p.Expiration == nil {
+		return nil, errors.New("expiration must be set")
+	}
+	if len(p.Contracts) == 0 {
+		return nil, errors.New("no contracts")
+	}
+	r := make([]types.ContractGrant, len(p.Contracts))
+	for i, c := range p.Contracts {
+		contract, err := sdk.AccAddressFromBech32(c.Contract)
+		if err != nil {
+			return nil, fmt.Errorf("contract %d: %s", i, err)
+		}
+		limit, err := newContractAuthzLimit(c.MaxCalls, c.MaxFunds, c.NoTokenTransfer)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", contract, err)
+		}
+		filter, err := newContractAuthzFilter(c.AllowAllMessages, c.AllowMsgKeys, c.AllowRawMsgs)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", contract, err)
+		}
+		g, err := types.NewContractGrant(contract, limit, filter)
+		if err != nil {
+			return nil, fmt.Errorf("contract %s: %w", contract, err)
+		}
+		r[i] = *g
+	}
+	return r, nil
+}
+
+// queryGranterGrants returns all grants of the granter with a msg type that is managed by the policy
+func queryGranterGrants(ctx context.Context, queryClient authz.QueryClient, unpacker codectypes.AnyUnpacker, granter sdk.AccAddress) ([]grantState, error) {
+	var r []grantState
+	var pageKey []byte
+	for {
+		res, err := queryClient.GranterGrants(ctx, &authz.QueryGranterGrantsRequest{
+			Granter:    granter.String(),
+			Pagination: &query.PageRequest{Key: pageKey},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("granter grants: %w", err)
+		}
+		for _, g := range res.Grants {
+			var a authz.Authorization
+			if err := unpacker.UnpackAny(g.Authorization, &a); err != nil {
+				return nil, fmt.Errorf("grant to %s: %w", g.Grantee, err)
+			}
+			if _, ok := managedGrantMsgTypes[a.MsgTypeURL()]; !ok {
+				continue
+			}
+			r = append(r, grantState{grantee: g.Grantee, msgTypeURL: a.MsgTypeURL(), authorization: g.Authorization, expiration: g.Expiration})
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return r, nil
+		}
+		pageKey = res.Pagination.NextKey
+	}
+}
+
+// planGrantChanges returns the revokes followed by the grants to converge the existing grants to the desired grants.
+// Changed grants are revoked and granted again.
+func planGrantChanges(desired, existing []grantState) []grantChange {
+	desiredByKey := make(map[string]grantState, len(desired))
+	for _, g := range desired {
+		desiredByKey[g.key()] = g
+	}
+	existingByKey := make(map[string]grantState, len(existing))
+	var revokes, grants []grantChange
+	for _, g := range existing {
+		existingByKey[g.key()] = g
+		if d, ok := desiredByKey[g.key()]; !ok || !d.equal(g) {
+			revokes = append(revokes, grantChange{action: grantActionRevoke, grant: g})
+		}
+	}
+	for _, g := range desired {
+		if e, ok := existingByKey[g.key()]; !ok || !e.equal(g) {
+			grants = append(grants, grantChange{action: grantActionGrant, grant: g})
+		}
+	}
+	for _, changes := range [][]grantChange{revokes, grants} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].grant.key() < changes[j].grant.key() })
+	}
+	return append(revokes, grants...)
+}
+
+// grantChangeMsgs returns the authz messages of the plan in order
+func grantChangeMsgs(granter sdk.AccAddress, changes []grantChange) []sdk.Msg {
+	r := make([]sdk.Msg, len(changes))
+	for i, c := range changes {
+		switch c.action {
+		case grantActionRevoke:
+			r[i] = &authz.MsgRevoke{Granter: granter.String(), Grantee: c.grant.grantee, MsgTypeUrl: c.grant.msgTypeURL}
+		default:
+			r[i] = &authz.MsgGrant{
+				Granter: granter.String(),
+				Grantee: c.grant.grantee,
+				Grant:   authz.Grant{Authorization: c.grant.authorization, Expiration: c.grant.expiration},
+			}
+		}
+	}
+	return r
+}
+
+// printGrantPlan writes the plan as table with a summary below
+func printGrantPlan(out io.Writer, changes []grantChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(out, "no changes: the grants match the policy")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ACTION\tGRANTEE\tMSG TYPE\tEXPIRATION"); err != nil {
+		return err
+	}
+	var granted, revoked int
+	for _, c := range changes {
+		expiration := "-"
+		if c.grant.expiration != nil {
+			expiration = c.grant.expiration.UTC().Format(time.RFC3339)
+		}
+		if c.action == grantActionRevoke {
+			revoked++
+		} else {
+			granted++
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.action, c.grant.grantee, c.grant.msgTypeURL, expiration); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "plan: %d to revoke, %d to grant\n", revoked, granted)
+	return err
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	myPolicyGranter  = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	myPolicyGrantee  = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	myPolicyContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+)
+
+const myGrantPolicy = `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    execution:
+      expiration: 2030-01-01T00:00:00Z
+      contracts:
+        - {contract: ` + myPolicyContract + `, allow_all_messages: true, max_calls: 1, no_token_transfer: true}
+    store_code:
+      grants: ["*:everybody"]
+`
+
+func TestParseGrantPolicy(t *testing.T) {
+	granter := sdk.MustAccAddressFromBech32(myPolicyGranter)
+	myExpiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	myContractGrant, err := types.NewContractGrant(sdk.MustAccAddressFromBech32(myPolicyContract), types.NewMaxCallsLimit(1), types.NewAllowAllMessagesFilter())
+	require.NoError(t, err)
+
+	got, err := parseGrantPolicy([]byte(myGrantPolicy), granter)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, myPolicyGrantee, got[0].grantee)
+	assert.Equal(t, "/cosmwasm.wasm.v1.MsgExecuteContract", got[0].msgTypeURL)
+	assert.Equal(t, types.NewContractExecutionAuthorization(*myContractGrant), got[0].authorization.GetCachedValue())
+	require.NotNil(t, got[0].expiration)
+	assert.True(t, myExpiration.Equal(*got[0].expiration))
+	assert.Equal(t, "/cosmwasm.wasm.v1.MsgStoreCode", got[1].msgTypeURL)
+	assert.Equal(t, types.NewStoreCodeAuthorization(types.CodeGrant{CodeHash: []byte("*"), InstantiatePermission: &types.AllowEverybody}), got[1].authorization.GetCachedValue())
+	assert.Nil(t, got[1].expiration)
+
+	specs := map[string]string{
+		"unknown field": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    foo: bar
+    store_code: {grants: ["*:everybody"]}`,
+		"invalid grantee": `
+grantees:
+  - grantee: invalid
+    store_code: {grants: ["*:everybody"]}`,
+		"duplicate grantee": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    store_code: {grants: ["*:everybody"]}
+  - grantee: ` + myPolicyGrantee + `
+    store_code: {grants: ["*:nobody"]}`,
+		"grantee is granter": `
+grantees:
+  - grantee: ` + myPolicyGranter + `
+    store_code: {grants: ["*:everybody"]}`,
+		"no grants": `
+grantees:
+  - grantee: ` + myPolicyGrantee,
+		"contract grant without expiration": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    execution:
+      contracts: [{contract: ` + myPolicyContract + `, allow_all_messages: true, max_calls: 1, no_token_transfer: true}]`,
+		"contract grant without contracts": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    migration: {expiration: 2030-01-01T00:00:00Z}`,
+		"contract grant without limit": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    execution:
+      expiration: 2030-01-01T00:00:00Z
+      contracts: [{contract: ` + myPolicyContract + `, allow_all_messages: true}]`,
+		"contract grant with multiple filters": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    execution:
+      expiration: 2030-01-01T00:00:00Z
+      contracts: [{contract: ` + myPolicyContract + `, allow_all_messages: true, allow_msg_keys: [foo], max_calls: 1, no_token_transfer: true}]`,
+		"store code without grants": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    store_code: {}`,
+		"invalid store code grant": `
+grantees:
+  - grantee: ` + myPolicyGrantee + `
+    store_code: {grants: ["everybody"]}`,
+	}
+	for name, src := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, gotErr := parseGrantPolicy([]byte(src), granter)
+			assert.Error(t, gotErr)
+		})
+	}
+}
+
+func TestPlanGrantChanges(t *testing.T) {
+	granter := sdk.MustAccAddressFromBech32(myPolicyGranter)
+	mustParse := func(t *testing.T, src string) []grantState {
+		t.Helper()
+		r, err := parseGrantPolicy([]byte(src), granter)
+		require.NoError(t, err)
+		return r
+	}
+	const (
+		execMsgType  = "/cosmwasm.wasm.v1.MsgExecuteContract"
+		storeMsgType = "/cosmwasm.wasm.v1.MsgStoreCode"
+	)
+	specs := map[string]struct {
+		existing string
+		desired  string
+		exp      []string
+	}{
+		"create from empty": {
+			existing: `grantees: []`,
+			desired:  myGrantPolicy,
+			exp:      []string{"grant " + myPolicyGrantee + "/" + execMsgType, "grant " + myPolicyGrantee + "/" + storeMsgType},
+		},
+		"no-op convergence": {
+			existing: myGrantPolicy,
+			desired:  myGrantPolicy,
+		},
+		"limit change": {
+			existing: myGrantPolicy,
+			desired:  strings.Replace(myGrantPolicy, "max_calls: 1", "max_calls: 2", 1),
+			exp:      []string{"revoke " + myPolicyGrantee + "/" + execMsgType, "grant " + myPolicyGrantee + "/" + execMsgType},
+		},
+		"expiration change": {
+			existing: myGrantPolicy,
+			desired:  strings.Replace(myGrantPolicy, "2030-01-01", "2031-01-01", 1),
+			exp:      []string{"revoke " + myPolicyGrantee + "/" + execMsgType, "grant " + myPolicyGrantee + "/" + execMsgType},
+		},
+		"removed from policy": {
+			existing: myGrantPolicy,
+			desired:  `grantees: []`,
+			exp:      []string{"revoke " + myPolicyGrantee + "/" + execMsgType, "revoke " + myPolicyGrantee + "/" + storeMsgType},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			changes := planGrantChanges(mustParse(t, spec.desired), mustParse(t, spec.existing))
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.action+" "+c.grant.key())
+			}
+			assert.Equal(t, spec.exp, got)
+
+			// and the messages follow the plan order
+			msgs := grantChangeMsgs(granter, changes)
+			require.Len(t, msgs, len(changes))
+			for i, c := range changes {
+				switch c.action {
+				case grantActionRevoke:
+					assert.Equal(t, &authz.MsgRevoke{Granter: myPolicyGranter, Grantee: c.grant.grantee, MsgTypeUrl: c.grant.msgTypeURL}, msgs[i])
+				default:
+					require.IsType(t, &authz.MsgGrant{}, msgs[i])
+					assert.Equal(t, c.grant.authorization, msgs[i].(*authz.MsgGrant).Grant.Authorization)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryGranterGrants(t *testing.T) {
+	granter := sdk.MustAccAddressFromBech32(myPolicyGranter)
+	desired, err := parseGrantPolicy([]byte(myGrantPolicy), granter)
+	require.NoError(t, err)
+	otherAuthorization, err := codectypes.NewAnyWithValue(authz.NewGenericAuthorization("/cosmos.bank.v1beta1.MsgSend"))
+	require.NoError(t, err)
+	queryClient := &granterGrantsQueryClient{pages: []*authz.QueryGranterGrantsResponse{
+		{
+			Grants:     []*authz.GrantAuthorization{{Granter: myPolicyGranter, Grantee: myPolicyGrantee, Authorization: desired[0].authorization, Expiration: desired[0].expiration}},
+			Pagination: &query.PageResponse{NextKey: []byte("next")},
+		},
+		{
+			Grants: []*authz.GrantAuthorization{
+				{Granter: myPolicyGranter, Grantee: myPolicyGrantee, Authorization: otherAuthorization},
+				{Granter: myPolicyGranter, Grantee: myPolicyGrantee, Authorization: desired[1].authorization},
+			},
+		},
+	}}
+	registry := codectypes.NewInterfaceRegistry()
+	authz.RegisterInterfaces(registry)
+	types.RegisterInterfaces(registry)
+
+	got, err := queryGranterGrants(context.Background(), queryClient, registry, granter)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{nil, []byte("next")}, queryClient.pageKeys)
+	assert.Empty(t, planGrantChanges(desired, got))
+}
+
+func TestPrintGrantPlan(t *testing.T) {
+	myExpiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	specs := map[string]struct {
+		src []grantChange
+		exp string
+	}{
+		"changes": {
+			src: []grantChange{
+				{action: grantActionRevoke, grant: grantState{grantee: "grantee1", msgTypeURL: "/cosmwasm.wasm.v1.MsgExecuteContract"}},
+				{action: grantActionGrant, grant: grantState{grantee: "grantee1", msgTypeURL: "/cosmwasm.wasm.v1.MsgExecuteContract", expiration: &myExpiration}},
+			},
+			exp: "ACTION  GRANTEE   MSG TYPE                              EXPIRATION\n" +
+				"revoke  grantee1  /cosmwasm.wasm.v1.MsgExecuteContract  -\n" +
+				"grant   grantee1  /cosmwasm.wasm.v1.MsgExecuteContract  2030-01-01T00:00:00Z\n" +
+				"plan: 1 to revoke, 1 to grant\n",
+		},
+		"no changes": {
+			exp: "no changes: the grants match the policy\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, printGrantPlan(&out, spec.src))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
+
+// granterGrantsQueryClient returns the granter grants page by page
+type granterGrantsQueryClient struct {
+	authz.QueryClient
+	pages    []*authz.QueryGranterGrantsResponse
+	pageKeys [][]byte
+}
+
+func (m *granterGrantsQueryClient) GranterGrants(_ context.Context, req *authz.QueryGranterGrantsRequest, _ ...grpc.CallOption) (*authz.QueryGranterGrantsResponse, error) {
+	m.pageKeys = append(m.pageKeys, req.Pagination.Key)
+	return m.pages[len(m.pageKeys)-1], nil
+}
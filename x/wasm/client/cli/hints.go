@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// instantiateNotPermittedHint explains how to get permission to instantiate a code
+func instantiateNotPermittedHint() string {
+	return fmt.Sprintf(`The instantiate config of the code does not allow the sender.
+Ask the code creator to extend it with "%[1]s tx wasm update-instantiate-config" or submit a gov proposal
+with "%[1]s tx wasm submit-proposal update-instantiate-config".`, version.AppName)
+}
+
+// explainTxError adds an explanation to errors of a failed simulation or broadcast.
+// The abci code is not preserved by the gRPC status of a failed simulation so that the error message is matched
+// as well.
+func explainTxError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, types.ErrInstantiateNotPermitted) || strings.Contains(err.Error(), types.ErrInstantiateNotPermitted.Error()) {
+		return fmt.Errorf("%w\n%s", err, instantiateNotPermittedHint())
+	}
+	return err
+}
+
+// txResultError returns the error of a failed tx with an explanation for known codes
+func txResultError(codespace string, code uint32, rawLog string) error {
+	err := fmt.Errorf("tx failed with code %d: %s", code, rawLog)
+	if chain := submsgErrorChain(rawLog); len(chain) != 0 {
+		err = fmt.Errorf("%w\nerror chain:\n%s", err, strings.Join(chain, "\n"))
+	}
+	if codespace == types.DefaultCodespace && code == types.ErrInstantiateNotPermitted.ABCICode() {
+		return fmt.Errorf("%w\n%s", err, instantiateNotPermittedHint())
+	}
+	return err
+}
+
+// submsgBreadcrumb matches the position of a failed submessage or reply that the dispatcher adds to the error
+var submsgBreadcrumb = regexp.MustCompile(`(reply to )?submessage \d+ \(reply id \d+\)`)
+
+// submsgErrorChain splits the log of a failed tx at the submessage breadcrumbs into one indented line per level.
+// Breadcrumbs within quoted errors of a failed submessage are kept on the level of their reply.
+// Returns nil when the log has no breadcrumbs.
+func submsgErrorChain(rawLog string) []string {
+	var starts []int
+	for _, loc := range submsgBreadcrumb.FindAllStringIndex(rawLog, -1) {
+		if !withinQuotes(rawLog, loc[0]) {
+			starts = append(starts, loc[0])
+		}
+	}
+	if len(starts) == 0 {
+		return nil
+	}
+	if starts[0] != 0 {
+		starts = append([]int{0}, starts...)
+	}
+	chain := make([]string, len(starts))
+	for i, start := range starts {
+		end := len(rawLog)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		chain[i] = strings.Repeat("  ", i+1) + strings.TrimSuffix(rawLog[start:end], ": ")
+	}
+	return chain
+}
+
+// withinQuotes returns true when the position is within a double quoted string that may contain escaped quotes
+func withinQuotes(s string, pos int) bool {
+	var quoted bool
+	for i := 0; i < pos; i++ {
+		switch {
+		case quoted && s[i] == '\\':
+			i++
+		case s[i] == '"':
+			quoted = !quoted
+		}
+	}
+	return quoted
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestExplainTxError(t *testing.T) {
+	notPermittedErr := types.NewInstantiateNotPermittedError(1, types.AllowNobody)
+	specs := map[string]struct {
+		src     error
+		expHint bool
+	}{
+		"instantiate not permitted": {
+			src:     errorsmod.Wrap(notPermittedErr, "dispatch"),
+			expHint: true,
+		},
+		"instantiate not permitted in simulation": {
+			src:     status.Error(codes.Unknown, "failed to execute message; message index: 0: "+notPermittedErr.Error()),
+			expHint: true,
+		},
+		"other error": {
+			src: types.ErrUnknownAdmin,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := explainTxError(spec.src)
+			require.Error(t, gotErr)
+			assert.ErrorIs(t, gotErr, spec.src)
+			if spec.expHint {
+				assert.Contains(t, gotErr.Error(), "update-instantiate-config")
+				return
+			}
+			assert.Equal(t, spec.src, gotErr)
+		})
+	}
+	assert.NoError(t, explainTxError(nil))
+}
+
+func TestTxResultError(t *testing.T) {
+	specs := map[string]struct {
+		codespace string
+		code      uint32
+		expHint   bool
+	}{
+		"instantiate not permitted": {
+			codespace: types.DefaultCodespace,
+			code:      types.ErrInstantiateNotPermitted.ABCICode(),
+			expHint:   true,
+		},
+		"other wasm code": {
+			codespace: types.DefaultCodespace,
+			code:      types.ErrUnknownAdmin.ABCICode(),
+		},
+		"other codespace": {
+			codespace: "sdk",
+			code:      types.ErrInstantiateNotPermitted.ABCICode(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := txResultError(spec.codespace, spec.code, "my log")
+			require.Error(t, gotErr)
+			assert.Contains(t, gotErr.Error(), "my log")
+			if spec.expHint {
+				assert.Contains(t, gotErr.Error(), "update-instantiate-config")
+				return
+			}
+			assert.NotContains(t, gotErr.Error(), "update-instantiate-config")
+		})
+	}
+}
+
+func TestSubmsgErrorChain(t *testing.T) {
+	specs := map[string]struct {
+		src string
+		exp []string
+	}{
+		"dispatch failed": {
+			src: "failed to execute message; message index: 0: dispatch: submessages: submessage 0 (reply id 1): submessage 1 (reply id 2): my error: invalid",
+			exp: []string{
+				"  failed to execute message; message index: 0: dispatch: submessages",
+				"    submessage 0 (reply id 1)",
+				"      submessage 1 (reply id 2): my error: invalid",
+			},
+		},
+		"reply failed after submessage error": {
+			src: `reply to submessage 0 (reply id 7) after submessage error [codespace: wasm, code: 14, log: "submessage 1 (reply id 2): \"quoted\": invalid"]: submessage 3 (reply id 9): reply failed`,
+			exp: []string{
+				`  reply to submessage 0 (reply id 7) after submessage error [codespace: wasm, code: 14, log: "submessage 1 (reply id 2): \"quoted\": invalid"]`,
+				"    submessage 3 (reply id 9): reply failed",
+			},
+		},
+		"no breadcrumbs": {
+			src: "out of gas in location: wasm contract; gasWanted: 1, gasUsed: 2: out of gas",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, submsgErrorChain(spec.src))
+		})
+	}
+	gotErr := txResultError(types.DefaultCodespace, types.ErrInvalid.ABCICode(), specs["dispatch failed"].src)
+	assert.Contains(t, gotErr.Error(), "\nerror chain:\n  failed to execute message")
+}
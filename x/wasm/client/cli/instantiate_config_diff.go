@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagShowDiff = "show-diff"
+
+// proposedInstantiateConfig returns the current instantiate config of the code and the config after the update
+func proposedInstantiateConfig(ctx context.Context, queryClient types.QueryClient, msg types.MsgUpdateInstantiateConfig) (types.AccessConfig, types.AccessConfig, error) {
+	res, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: msg.CodeID})
+	if err != nil {
+		return types.AccessConfig{}, types.AccessConfig{}, err
+	}
+	if !msg.ResetToDefault {
+		return res.InstantiatePermission, *msg.NewInstantiatePermission, nil
+	}
+	params, err := queryClient.Params(ctx, &types.QueryParamsRequest{})
+	if err != nil {
+		return types.AccessConfig{}, types.AccessConfig{}, err
+	}
+	creator, err := sdk.AccAddressFromBech32(res.Creator)
+	if err != nil {
+		return types.AccessConfig{}, types.AccessConfig{}, fmt.Errorf("code creator: %s", err)
+	}
+	return res.InstantiatePermission, params.Params.InstantiateDefaultPermission.With(creator), nil
+}
+
+// renderAccessConfigDiff writes the current and the proposed permission with the added and removed addresses.
+// False is returned when both configs are equal.
+func renderAccessConfigDiff(out io.Writer, current, proposed types.AccessConfig) (bool, error) {
+	added, removed := addressSetDiff(current.Addresses, proposed.Addresses)
+	if current.Permission == proposed.Permission && len(added) == 0 && len(removed) == 0 {
+		_, err := fmt.Fprintln(out, "no change")
+		return false, err
+	}
+	if _, err := fmt.Fprintf(out, "permission: %s -> %s\n", current.Permission, proposed.Permission); err != nil {
+		return true, err
+	}
+	for _, addr := range removed {
+		if _, err := fmt.Fprintf(out, "- %s\n", addr); err != nil {
+			return true, err
+		}
+	}
+	for _, addr := range added {
+		if _, err := fmt.Fprintf(out, "+ %s\n", addr); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+// addressSetDiff returns the sorted addresses that are only in the proposed or only in the current set
+func addressSetDiff(current, proposed []string) ([]string, []string) {
+	var added, removed []string
+	for _, addr := range proposed {
+		if !slices.Contains(current, addr) {
+			added = append(added, addr)
+		}
+	}
+	for _, addr := range current {
+		if !slices.Contains(proposed, addr) {
+			removed = append(removed, addr)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRenderAccessConfigDiff(t *testing.T) {
+	const (
+		addrA = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+		addrB = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+		addrC = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	specs := map[string]struct {
+		current    types.AccessConfig
+		proposed   types.AccessConfig
+		expChanged bool
+		expOut     string
+	}{
+		"address added": {
+			current:    types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrA}},
+			proposed:   types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrA, addrB}},
+			expChanged: true,
+			expOut:     "permission: AnyOfAddresses -> AnyOfAddresses\n+ " + addrB + "\n",
+		},
+		"address removed": {
+			current:    types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrA, addrB}},
+			proposed:   types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrB}},
+			expChanged: true,
+			expOut:     "permission: AnyOfAddresses -> AnyOfAddresses\n- " + addrA + "\n",
+		},
+		"addresses replaced": {
+			current:    types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrC, addrA}},
+			proposed:   types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrB}},
+			expChanged: true,
+			expOut:     "permission: AnyOfAddresses -> AnyOfAddresses\n- " + addrC + "\n- " + addrA + "\n+ " + addrB + "\n",
+		},
+		"permission type changed": {
+			current:    types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrA}},
+			proposed:   types.AllowNobody,
+			expChanged: true,
+			expOut:     "permission: AnyOfAddresses -> Nobody\n- " + addrA + "\n",
+		},
+		"no change": {
+			current:  types.AllowEverybody,
+			proposed: types.AllowEverybody,
+			expOut:   "no change\n",
+		},
+		"no change with other address order": {
+			current:  types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrA, addrB}},
+			proposed: types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{addrB, addrA}},
+			expOut:   "no change\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			gotChanged, err := renderAccessConfigDiff(&out, spec.current, spec.proposed)
+			require.NoError(t, err)
+			assert.Equal(t, spec.expChanged, gotChanged)
+			assert.Equal(t, spec.expOut, out.String())
+		})
+	}
+}
+
+func TestProposedInstantiateConfig(t *testing.T) {
+	const myCreator = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	current := types.AccessConfig{Permission: types.AccessTypeAnyOfAddresses, Addresses: []string{myCreator}}
+	queryClient := &instantiateConfigQueryClientMock{
+		codeInfo: &types.QueryCodeInfoResponse{CodeID: 1, Creator: myCreator, InstantiatePermission: current},
+		params:   &types.QueryParamsResponse{Params: types.Params{InstantiateDefaultPermission: types.AccessTypeEverybody}},
+	}
+	specs := map[string]struct {
+		src         types.MsgUpdateInstantiateConfig
+		expProposed types.AccessConfig
+	}{
+		"new permission": {
+			src:         types.MsgUpdateInstantiateConfig{CodeID: 1, NewInstantiatePermission: &types.AllowNobody},
+			expProposed: types.AllowNobody,
+		},
+		"reset to default": {
+			src:         types.MsgUpdateInstantiateConfig{CodeID: 1, ResetToDefault: true},
+			expProposed: types.AllowEverybody,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotCurrent, gotProposed, err := proposedInstantiateConfig(context.Background(), queryClient, spec.src)
+			require.NoError(t, err)
+			assert.Equal(t, current, gotCurrent)
+			assert.Equal(t, spec.expProposed, gotProposed)
+		})
+	}
+}
+
+type instantiateConfigQueryClientMock struct {
+	types.QueryClient
+	codeInfo *types.QueryCodeInfoResponse
+	params   *types.QueryParamsResponse
+}
+
+func (m instantiateConfigQueryClientMock) CodeInfo(_ context.Context, _ *types.QueryCodeInfoRequest, _ ...grpc.CallOption) (*types.QueryCodeInfoResponse, error) {
+	return m.codeInfo, nil
+}
+
+func (m instantiateConfigQueryClientMock) Params(_ context.Context, _ *types.QueryParamsRequest, _ ...grpc.CallOption) (*types.QueryParamsResponse, error) {
+	return m.params, nil
+}
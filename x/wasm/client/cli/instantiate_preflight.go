@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagSkipPreflight = "skip-preflight"
+
+func addInstantiatePreflightFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagSkipPreflight, false, "Skip querying the code id, its instantiate permission and the instantiate2 address before broadcasting")
+}
+
+// checkInstantiatePreflight ensures that the code id exists and is not deprecated and warns when the sender is not permitted to instantiate
+// it. The check is skipped by flag and for txs that are not broadcast.
+func checkInstantiatePreflight(cmd *cobra.Command, clientCtx client.Context, codeID uint64, sender string) error {
+	skip, err := skipPreflight(cmd, clientCtx)
+	if err != nil {
+		return err
+	}
+	if skip {
+		recordPreflight(cmd.Context(), preflightInstantiateCode, preflightSkipped, "--"+flagSkipPreflight+", offline or generate only")
+		return nil
+	}
+	warning, err := instantiatePreflight(cmd.Context(), types.NewQueryClient(clientCtx), codeID, sender)
+	recordPreflightResult(cmd.Context(), preflightInstantiateCode, warning, err)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+	}
+	return nil
+}
+
+// checkInstantiate2AddressPreflight ensures that no contract exists at the predictable address and warns when the
+// address is used by an account or holds funds already. The check is skipped like checkInstantiatePreflight.
+func checkInstantiate2AddressPreflight(cmd *cobra.Command, clientCtx client.Context, msg *types.MsgInstantiateContract2) error {
+	skip, err := skipPreflight(cmd, clientCtx)
+	if err != nil {
+		return err
+	}
+	if skip {
+		recordPreflight(cmd.Context(), preflightInstantiate2Address, preflightSkipped, "--"+flagSkipPreflight+", offline or generate only")
+		return nil
+	}
+	warning, err := instantiate2AddressPreflight(cmd.Context(), types.NewQueryClient(clientCtx), msg)
+	recordPreflightResult(cmd.Context(), preflightInstantiate2Address, warning, err)
+	if err != nil {
+		return err
+	}
+	if warning != "" {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+	}
+	return nil
+}
+
+// skipPreflight returns true when the preflight checks are disabled by flag or the tx is not broadcast
+func skipPreflight(cmd *cobra.Command, clientCtx client.Context) (bool, error) {
+	skip, err := cmd.Flags().GetBool(flagSkipPreflight)
+	if err != nil {
+		return false, fmt.Errorf("skip preflight: %s", err)
+	}
+	return skip || clientCtx.GenerateOnly || clientCtx.Offline, nil
+}
+
+// instantiatePreflight returns an error when the code info can not be queried and a warning when the instantiate
+// permission of the code does not include the sender
+func instantiatePreflight(ctx context.Context, queryClient types.QueryClient, codeID uint64, sender string) (string, error) {
+	res, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: codeID})
+	if err != nil {
+		return "", fmt.Errorf("code id %d: %s: set --%s to skip this check", codeID, err, flagSkipPreflight)
+	}
+	if res.Deprecated {
+		return "", fmt.Errorf("code id %d is deprecated and can not be instantiated: set --%s to skip this check", codeID, flagSkipPreflight)
+	}
+	senderAddr, err := sdk.AccAddressFromBech32(sender)
+	if err != nil {
+		return "", fmt.Errorf("sender: %s", err)
+	}
+	if res.InstantiatePermission.Allowed(senderAddr) {
+		return "", nil
+	}
+	permission := res.InstantiatePermission.Permission.String()
+	if len(res.InstantiatePermission.Addresses) != 0 {
+		permission += ": " + strings.Join(res.InstantiatePermission.Addresses, ", ")
+	}
+	return fmt.Sprintf("sender %s is not permitted to instantiate code id %d, instantiate permission is %s", sender, codeID, permission), nil
+}
+
+// instantiate2AddressPreflight returns an error when a contract exists at the predictable address and a warning when
+// the address is used by an account or holds funds. Nothing is checked when the node does not support the query.
+func instantiate2AddressPreflight(ctx context.Context, queryClient types.QueryClient, msg *types.MsgInstantiateContract2) (string, error) {
+	res, err := queryClient.CheckInstantiate2Address(ctx, &types.QueryCheckInstantiate2AddressRequest{
+		Creator: msg.Sender,
+		CodeId:  msg.CodeID,
+		Salt:    msg.Salt,
+		FixMsg:  msg.FixMsg,
+		Msg:     msg.Msg,
+	})
+	if err != nil {
+		// the query is not available on older nodes
+		return "", nil
+	}
+	switch {
+	case res.IsContract:
+		return "", fmt.Errorf("contract %s exists already: use a different salt or set --%s to skip this check", res.Address, flagSkipPreflight)
+	case res.HasBalance:
+		return fmt.Sprintf("address %s holds %s already: the funds are kept or burned on instantiation depending on the account type", res.Address, res.Balance), nil
+	case res.AccountExists:
+		return fmt.Sprintf("address %s has an account already: the instantiation fails when the account was used to sign txs", res.Address), nil
+	}
+	return "", nil
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestInstantiatePreflight(t *testing.T) {
+	mySender := sdk.AccAddress(bytes.Repeat([]byte{1}, 20)).String()
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{2}, 20)).String()
+	specs := map[string]struct {
+		rsp        *types.QueryCodeInfoResponse
+		err        error
+		expWarning string
+		expErr     bool
+	}{
+		"allowed - everybody": {
+			rsp: &types.QueryCodeInfoResponse{CodeID: 1, InstantiatePermission: types.AllowEverybody},
+		},
+		"allowed - any of addresses": {
+			rsp: &types.QueryCodeInfoResponse{CodeID: 1, InstantiatePermission: types.AccessTypeAnyOfAddresses.With(sdk.MustAccAddressFromBech32(mySender))},
+		},
+		"nobody permission": {
+			rsp:        &types.QueryCodeInfoResponse{CodeID: 1, InstantiatePermission: types.AllowNobody},
+			expWarning: "sender " + mySender + " is not permitted to instantiate code id 1, instantiate permission is Nobody",
+		},
+		"not in addresses": {
+			rsp:        &types.QueryCodeInfoResponse{CodeID: 1, InstantiatePermission: types.AccessTypeAnyOfAddresses.With(sdk.MustAccAddressFromBech32(otherAddr))},
+			expWarning: "sender " + mySender + " is not permitted to instantiate code id 1, instantiate permission is AnyOfAddresses: " + otherAddr,
+		},
+		"deprecated code": {
+			rsp:    &types.QueryCodeInfoResponse{CodeID: 1, InstantiatePermission: types.AllowEverybody, Deprecated: true},
+			expErr: true,
+		},
+		"missing code": {
+			err:    types.ErrNoSuchCodeFn(1).Wrap("code id 1"),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockCodeInfoQueryClient{rsp: spec.rsp, err: spec.err}
+			gotWarning, gotErr := instantiatePreflight(context.Background(), queryClient, 1, mySender)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, "--"+flagSkipPreflight)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expWarning, gotWarning)
+			assert.Equal(t, uint64(1), queryClient.gotCodeID)
+		})
+	}
+}
+
+type mockCodeInfoQueryClient struct {
+	types.QueryClient
+	rsp       *types.QueryCodeInfoResponse
+	err       error
+	gotCodeID uint64
+}
+
+func (m *mockCodeInfoQueryClient) CodeInfo(_ context.Context, req *types.QueryCodeInfoRequest, _ ...grpc.CallOption) (*types.QueryCodeInfoResponse, error) {
+	m.gotCodeID = req.CodeId
+	return m.rsp, m.err
+}
+
+func TestInstantiate2AddressPreflight(t *testing.T) {
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	msg := &types.MsgInstantiateContract2{
+		Sender: sdk.AccAddress(bytes.Repeat([]byte{2}, 20)).String(),
+		CodeID: 1,
+		Msg:    []byte(`{}`),
+		Salt:   []byte("my salt"),
+		FixMsg: true,
+	}
+	specs := map[string]struct {
+		rsp        *types.QueryCheckInstantiate2AddressResponse
+		err        error
+		expWarning string
+		expErr     bool
+	}{
+		"fresh address": {
+			rsp: &types.QueryCheckInstantiate2AddressResponse{Address: myAddr},
+		},
+		"pre-funded base account": {
+			rsp:        &types.QueryCheckInstantiate2AddressResponse{Address: myAddr, AccountExists: true, HasBalance: true, Balance: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))},
+			expWarning: "address " + myAddr + " holds 1stake already: the funds are kept or burned on instantiation depending on the account type",
+		},
+		"account without balance": {
+			rsp:        &types.QueryCheckInstantiate2AddressResponse{Address: myAddr, AccountExists: true},
+			expWarning: "address " + myAddr + " has an account already: the instantiation fails when the account was used to sign txs",
+		},
+		"existing contract": {
+			rsp:    &types.QueryCheckInstantiate2AddressResponse{Address: myAddr, AccountExists: true, IsContract: true},
+			expErr: true,
+		},
+		"query not supported": {
+			err: status.Error(codes.Unimplemented, "unknown method"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockCheckInstantiate2AddressQueryClient{rsp: spec.rsp, err: spec.err}
+			gotWarning, gotErr := instantiate2AddressPreflight(context.Background(), queryClient, msg)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, "--"+flagSkipPreflight)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expWarning, gotWarning)
+			exp := &types.QueryCheckInstantiate2AddressRequest{Creator: msg.Sender, CodeId: 1, Salt: msg.Salt, FixMsg: true, Msg: msg.Msg}
+			assert.Equal(t, exp, queryClient.gotReq)
+		})
+	}
+}
+
+type mockCheckInstantiate2AddressQueryClient struct {
+	types.QueryClient
+	rsp    *types.QueryCheckInstantiate2AddressResponse
+	err    error
+	gotReq *types.QueryCheckInstantiate2AddressRequest
+}
+
+func (m *mockCheckInstantiate2AddressQueryClient) CheckInstantiate2Address(_ context.Context, req *types.QueryCheckInstantiate2AddressRequest, _ ...grpc.CallOption) (*types.QueryCheckInstantiate2AddressResponse, error) {
+	m.gotReq = req
+	return m.rsp, m.err
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagWarnLockedFunds = "warn-locked-funds"
+
+func addWarnLockedFundsFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagWarnLockedFunds, false, "Warn when funds are sent to a contract whose code has no execute entry point to send them out again")
+}
+
+// checkLockedFunds warns and asks for confirmation when funds are sent to a contract whose code has no execute entry point.
+// The check is opt-in and a warning only: when the code can not be analyzed, the tx is not blocked.
+// The returned context skips the tx confirmation when the warning was confirmed already. With --dry-run the
+// warning is printed without confirmation as nothing is broadcast.
+func checkLockedFunds(cmd *cobra.Command, clientCtx client.Context, codeID uint64, funds sdk.Coins) (client.Context, error) {
+	warn, err := cmd.Flags().GetBool(flagWarnLockedFunds)
+	if err != nil {
+		return clientCtx, fmt.Errorf("warn locked funds: %s", err)
+	}
+	switch {
+	case funds.IsZero():
+		return clientCtx, nil
+	case !warn:
+		recordPreflight(cmd.Context(), preflightLockedFunds, preflightSkipped, "--"+flagWarnLockedFunds+" not set")
+		return clientCtx, nil
+	case clientCtx.GenerateOnly || clientCtx.Offline:
+		recordPreflight(cmd.Context(), preflightLockedFunds, preflightSkipped, "offline or generate only")
+		return clientCtx, nil
+	}
+	warning, analyzed := lockedFundsWarning(cmd.Context(), types.NewQueryClient(clientCtx), codeID, funds)
+	if !analyzed {
+		recordPreflight(cmd.Context(), preflightLockedFunds, preflightSkipped, warning)
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "note: %s\n", warning)
+		return clientCtx, nil
+	}
+	recordPreflightResult(cmd.Context(), preflightLockedFunds, warning, nil)
+	if warning == "" {
+		return clientCtx, nil
+	}
+	confirmed, err := confirmLockedFunds(warning, bufio.NewReader(clientCtx.Input), cmd.ErrOrStderr(), clientCtx.SkipConfirm || clientCtx.Simulate)
+	if err != nil || !confirmed {
+		return clientCtx, err
+	}
+	return clientCtx.WithSkipConfirmation(true), nil
+}
+
+// lockedFundsWarning returns a warning when the exports of the code do not include an execute entry point.
+// When the code can not be analyzed, the reason is returned with analyzed set to false.
+func lockedFundsWarning(ctx context.Context, queryClient types.QueryClient, codeID uint64, funds sdk.Coins) (warning string, analyzed bool) {
+	res, err := queryClient.Code(ctx, &types.QueryCodeRequest{CodeId: codeID})
+	if err != nil {
+		return fmt.Sprintf("code id %d can not be analyzed for locked funds: %s", codeID, err), false
+	}
+	exports, err := wasmExports(res.Data)
+	if err != nil {
+		return fmt.Sprintf("code id %d can not be analyzed for locked funds: %s", codeID, err), false
+	}
+	if _, ok := exports["execute"]; ok {
+		return "", true
+	}
+	return fmt.Sprintf("code id %d has no execute entry point: the funds %s may be unrecoverable", codeID, funds), true
+}
+
+// confirmLockedFunds prints the warning and asks for confirmation unless skipConfirm is set
+func confirmLockedFunds(warning string, in *bufio.Reader, out io.Writer, skipConfirm bool) (bool, error) {
+	if _, err := fmt.Fprintf(out, "warning: %s\n", warning); err != nil {
+		return false, err
+	}
+	if skipConfirm {
+		return false, nil
+	}
+	ok, err := input.GetConfirmation("send the funds anyway?", in, out)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, errors.New("instantiate canceled")
+	}
+	return true, nil
+}
+
+// wasmExports returns the names of the functions exported by the wasm code
+func wasmExports(code []byte) (map[string]struct{}, error) {
+	if ioutils.IsGzip(code) {
+		var err error
+		if code, err = ioutils.Uncompress(code, int64(types.MaxWasmSize)); err != nil {
+			return nil, err
+		}
+	}
+	if !ioutils.IsWasm(code) || len(code) < 8 {
+		return nil, errors.New("not a wasm binary")
+	}
+	r := wasmReader(code[8:])
+	exports := make(map[string]struct{})
+	for len(r) != 0 {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		section, err := r.bytes()
+		if err != nil {
+			return nil, err
+		}
+		const exportSection = 7
+		if id != exportSection {
+			continue
+		}
+		n, err := section.uint()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < n; i++ {
+			name, err := section.bytes()
+			if err != nil {
+				return nil, err
+			}
+			kind, err := section.byte()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := section.uint(); err != nil {
+				return nil, err
+			}
+			const funcExport = 0
+			if kind == funcExport {
+				exports[string(name)] = struct{}{}
+			}
+		}
+	}
+	return exports, nil
+}
+
+// wasmReader reads the encoded values of a wasm binary
+type wasmReader []byte
+
+func (r *wasmReader) byte() (byte, error) {
+	if len(*r) == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := (*r)[0]
+	*r = (*r)[1:]
+	return b, nil
+}
+
+func (r *wasmReader) uint() (uint64, error) {
+	v, n := binary.Uvarint(*r)
+	if n <= 0 {
+		return 0, errors.New("invalid integer")
+	}
+	*r = (*r)[n:]
+	return v, nil
+}
+
+// bytes reads a length prefixed byte vector
+func (r *wasmReader) bytes() (wasmReader, error) {
+	n, err := r.uint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(*r)) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := (*r)[:n]
+	*r = (*r)[n:]
+	return b, nil
+}
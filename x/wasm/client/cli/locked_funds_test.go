@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestLockedFundsWarning(t *testing.T) {
+	hackatomWasm, err := os.ReadFile("../../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	gzippedWasm, err := os.ReadFile("../../keeper/testdata/hackatom.wasm.gzip")
+	require.NoError(t, err)
+	funds := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+
+	specs := map[string]struct {
+		rsp         *types.QueryCodeResponse
+		err         error
+		expWarning  string
+		expAnalyzed bool
+	}{
+		"with execute entry point": {
+			rsp:         &types.QueryCodeResponse{Data: hackatomWasm},
+			expAnalyzed: true,
+		},
+		"gzipped with execute entry point": {
+			rsp:         &types.QueryCodeResponse{Data: gzippedWasm},
+			expAnalyzed: true,
+		},
+		"without execute entry point": {
+			rsp:         &types.QueryCodeResponse{Data: wasmWithExports("instantiate", "query")},
+			expWarning:  "code id 1 has no execute entry point: the funds 100stake may be unrecoverable",
+			expAnalyzed: true,
+		},
+		"execute exported as global": {
+			rsp:         &types.QueryCodeResponse{Data: wasmWithExports("instantiate", "\x03execute")},
+			expWarning:  "code id 1 has no execute entry point: the funds 100stake may be unrecoverable",
+			expAnalyzed: true,
+		},
+		"no analysis data - query error": {
+			err:        errors.New("testing"),
+			expWarning: "code id 1 can not be analyzed for locked funds: testing",
+		},
+		"no analysis data - invalid code": {
+			rsp:        &types.QueryCodeResponse{Data: []byte("not wasm")},
+			expWarning: "code id 1 can not be analyzed for locked funds: not a wasm binary",
+		},
+		"no analysis data - truncated code": {
+			rsp:        &types.QueryCodeResponse{Data: wasmWithExports("execute")[:12]},
+			expWarning: "code id 1 can not be analyzed for locked funds: unexpected EOF",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockCodeQueryClient{rsp: spec.rsp, err: spec.err}
+			gotWarning, gotAnalyzed := lockedFundsWarning(context.Background(), queryClient, 1, funds)
+			assert.Equal(t, spec.expWarning, gotWarning)
+			assert.Equal(t, spec.expAnalyzed, gotAnalyzed)
+			assert.Equal(t, uint64(1), queryClient.gotCodeID)
+		})
+	}
+}
+
+func TestConfirmLockedFunds(t *testing.T) {
+	specs := map[string]struct {
+		input        string
+		skipConfirm  bool
+		expConfirmed bool
+		expErr       bool
+	}{
+		"confirmed":         {input: "y\n", expConfirmed: true},
+		"declined":          {input: "n\n", expErr: true},
+		"skip confirmation": {skipConfirm: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			gotConfirmed, gotErr := confirmLockedFunds("my warning", bufio.NewReader(strings.NewReader(spec.input)), &out, spec.skipConfirm)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expConfirmed, gotConfirmed)
+			assert.Equal(t, "warning: my warning\n", out.String())
+		})
+	}
+}
+
+// wasmWithExports returns a wasm binary with an export section only. Names are exported as functions unless
+// prefixed with another export kind byte.
+func wasmWithExports(names ...string) []byte {
+	section := []byte{byte(len(names))}
+	for _, n := range names {
+		kind := byte(0)
+		if n[0] < 4 {
+			kind, n = n[0], n[1:]
+		}
+		section = append(section, byte(len(n)))
+		section = append(section, n...)
+		section = append(section, kind, 0)
+	}
+	code := []byte("\x00asm\x01\x00\x00\x00")
+	code = append(code, 7, byte(len(section)))
+	return append(code, section...)
+}
+
+type mockCodeQueryClient struct {
+	types.QueryClient
+	rsp       *types.QueryCodeResponse
+	err       error
+	gotCodeID uint64
+}
+
+func (m *mockCodeQueryClient) Code(_ context.Context, req *types.QueryCodeRequest, _ ...grpc.CallOption) (*types.QueryCodeResponse, error) {
+	m.gotCodeID = req.CodeId
+	return m.rsp, m.err
+}
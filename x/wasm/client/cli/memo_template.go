@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+const flagMemoTemplate = "memo-template"
+
+// memo template placeholders
+const (
+	memoPlaceholderFile     = "file"
+	memoPlaceholderChecksum = "checksum"
+	memoPlaceholderCodeID   = "code_id"
+	memoPlaceholderLabel    = "label"
+	memoPlaceholderContract = "contract"
+)
+
+var memoPlaceholders = []string{
+	memoPlaceholderFile,
+	memoPlaceholderChecksum,
+	memoPlaceholderCodeID,
+	memoPlaceholderLabel,
+	memoPlaceholderContract,
+}
+
+// memoValues returns the placeholder values of a command. They are only computed when a memo template is set.
+type memoValues func() (map[string]string, error)
+
+func addMemoTemplateFlag(cmd *cobra.Command) {
+	cmd.Flags().String(flagMemoTemplate, "", "Set the tx memo from a template, like \"deploy {file} {checksum}\". "+
+		"Supported placeholders: {file}, {checksum}, {code_id}, {label}, {contract}, when known to the command")
+}
+
+// applyMemoTemplate renders the memo template, when set, into the note flag that the tx factory reads the memo from
+func applyMemoTemplate(flagSet *flag.FlagSet, values memoValues) error {
+	f := flagSet.Lookup(flagMemoTemplate)
+	if f == nil || f.Value.String() == "" {
+		return nil
+	}
+	if flagSet.Changed(flags.FlagNote) {
+		return fmt.Errorf("--%s can not be combined with --%s", flagMemoTemplate, flags.FlagNote)
+	}
+	v, err := values()
+	if err != nil {
+		return err
+	}
+	memo, err := renderMemoTemplate(f.Value.String(), v)
+	if err != nil {
+		return fmt.Errorf("memo template: %w", err)
+	}
+	return flagSet.Set(flags.FlagNote, memo)
+}
+
+// renderMemoTemplate replaces the {name} placeholders with the values. Unknown placeholders, placeholders without
+// a value and unclosed braces are rejected.
+func renderMemoTemplate(tmpl string, values map[string]string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			b.WriteString(tmpl)
+			return b.String(), nil
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unclosed placeholder %q", tmpl[start:])
+		}
+		name := tmpl[start+1 : start+end]
+		if !slices.Contains(memoPlaceholders, name) {
+			return "", fmt.Errorf("unknown placeholder {%s}", name)
+		}
+		v, ok := values[name]
+		if !ok {
+			return "", fmt.Errorf("placeholder {%s} is not available for this command", name)
+		}
+		b.WriteString(tmpl[:start])
+		b.WriteString(v)
+		tmpl = tmpl[start+end+1:]
+	}
+}
+
+// storeCodeMemoValues returns the file name and the checksum of the wasm code
+func storeCodeMemoValues(file string, wasmCode []byte) memoValues {
+	return func() (map[string]string, error) {
+		checksum, err := wasmChecksum(wasmCode)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{
+			memoPlaceholderFile:     filepath.Base(file),
+			memoPlaceholderChecksum: hex.EncodeToString(checksum),
+		}, nil
+	}
+}
+
+// instantiateMemoValues returns the code id and the label
+func instantiateMemoValues(codeID uint64, label string) memoValues {
+	return func() (map[string]string, error) {
+		return map[string]string{
+			memoPlaceholderCodeID: strconv.FormatUint(codeID, 10),
+			memoPlaceholderLabel:  label,
+		}, nil
+	}
+}
+
+// contractMemoValues returns the contract address and, when not zero, the code id
+func contractMemoValues(contract string, codeID uint64) memoValues {
+	return func() (map[string]string, error) {
+		r := map[string]string{memoPlaceholderContract: contract}
+		if codeID != 0 {
+			r[memoPlaceholderCodeID] = strconv.FormatUint(codeID, 10)
+		}
+		return r, nil
+	}
+}
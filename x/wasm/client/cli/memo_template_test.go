@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+)
+
+func TestRenderMemoTemplate(t *testing.T) {
+	values := map[string]string{"file": "router.wasm", "label": "router v1.4.2"}
+	specs := map[string]struct {
+		src    string
+		exp    string
+		expErr bool
+	}{
+		"placeholders": {
+			src: "deploy {label} from {file}",
+			exp: "deploy router v1.4.2 from router.wasm",
+		},
+		"no placeholders": {
+			src: "deploy",
+			exp: "deploy",
+		},
+		"unknown placeholder": {
+			src:    "deploy {version}",
+			expErr: true,
+		},
+		"placeholder not available": {
+			src:    "deploy {contract}",
+			expErr: true,
+		},
+		"empty placeholder": {
+			src:    "deploy {}",
+			expErr: true,
+		},
+		"unclosed placeholder": {
+			src:    "deploy {label",
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := renderMemoTemplate(spec.src, values)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestMemoTemplateInGeneratedTx(t *testing.T) {
+	const (
+		mySender   = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+		myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	)
+	encodingConfig := keeper.MakeEncodingConfig(t)
+	specs := map[string]struct {
+		cmd    func() *cobra.Command
+		args   []string
+		exp    string
+		expErr bool
+	}{
+		"store": {
+			cmd:  StoreCodeCmd,
+			args: []string{"../../keeper/testdata/hackatom.wasm", "--memo-template=deploy {file} {checksum}"},
+			exp:  "deploy hackatom.wasm " + testdata.ChecksumHackatom,
+		},
+		"instantiate": {
+			cmd:  InstantiateContractCmd,
+			args: []string{"1", "{}", "--label=router v1.4.2", "--no-admin", "--memo-template=instantiate {label} from code {code_id}"},
+			exp:  "instantiate router v1.4.2 from code 1",
+		},
+		"execute": {
+			cmd:  ExecuteContractCmd,
+			args: []string{myContract, "{}", "--memo-template=execute {contract}"},
+			exp:  "execute " + myContract,
+		},
+		"without template": {
+			cmd:  ExecuteContractCmd,
+			args: []string{myContract, "{}", "--note=my note"},
+			exp:  "my note",
+		},
+		"placeholder not available for command": {
+			cmd:    ExecuteContractCmd,
+			args:   []string{myContract, "{}", "--memo-template=execute {label}"},
+			expErr: true,
+		},
+		"unknown placeholder": {
+			cmd:    ExecuteContractCmd,
+			args:   []string{myContract, "{}", "--memo-template=execute {version}"},
+			expErr: true,
+		},
+		"template with note": {
+			cmd:    ExecuteContractCmd,
+			args:   []string{myContract, "{}", "--memo-template=execute {contract}", "--note=my note"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			clientCtx := client.Context{}.
+				WithCodec(encodingConfig.Codec).
+				WithInterfaceRegistry(encodingConfig.InterfaceRegistry).
+				WithTxConfig(encodingConfig.TxConfig).
+				WithLegacyAmino(encodingConfig.Amino).
+				WithKeyring(keyring.NewInMemory(encodingConfig.Codec)).
+				WithOutput(&out)
+			cmd := spec.cmd()
+			cmd.SetContext(context.WithValue(context.Background(), client.ClientContextKey, &clientCtx))
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append(spec.args, "--from="+mySender, "--generate-only", "--chain-id=testing"))
+
+			// when
+			gotErr := cmd.Execute()
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			gotTx, err := encodingConfig.TxConfig.TxJSONDecoder()(out.Bytes())
+			require.NoError(t, err)
+			memoTx, ok := gotTx.(sdk.TxWithMemo)
+			require.True(t, ok)
+			assert.Equal(t, spec.exp, memoTx.GetMemo())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagLast = "last"
+
+// GetCmdQueryModuleActivity gets the wasm activity of the last blocks
+func GetCmdQueryModuleActivity() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activity",
+		Short: "Query a summary of the wasm activity of the last blocks",
+		Long: fmt.Sprintf(`Query a summary of the stores, instantiations, executions, migrations and the wasm gas of the last blocks.
+Blocks are recorded for a rolling window of %d blocks when enabled with record_module_activity in the params.`, types.ModuleActivityWindow),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			last, err := cmd.Flags().GetUint64(flagLast)
+			if err != nil {
+				return err
+			}
+			if last == 0 || last > types.ModuleActivityWindow {
+				return fmt.Errorf("--%s must be between 1 and %d", flagLast, types.ModuleActivityWindow)
+			}
+			height := clientCtx.Height
+			if height == 0 {
+				if height, err = latestHeight(cmd.Context(), clientCtx); err != nil {
+					return err
+				}
+			}
+			toHeight := uint64(height)
+			fromHeight := uint64(1)
+			if toHeight > last {
+				fromHeight = toHeight - last + 1
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ModuleActivity(cmd.Context(), &types.QueryModuleActivityRequest{
+				FromHeight: fromHeight,
+				ToHeight:   toHeight,
+			})
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatJSON {
+				return clientCtx.PrintProto(res)
+			}
+			return renderModuleActivity(cmd.OutOrStdout(), fromHeight, toHeight, res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagLast, 1000, "Number of blocks up to the latest height to summarize")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// renderModuleActivity writes the totals of the height range as table with the busiest block below
+func renderModuleActivity(out io.Writer, fromHeight, toHeight uint64, res *types.QueryModuleActivityResponse) error {
+	if _, err := fmt.Fprintf(out, "blocks %d-%d: %d with wasm activity\n", fromHeight, toHeight, len(res.Blocks)); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	rows := [][]any{
+		{"ACTIVITY", "TOTAL"},
+		{"stores", res.Total.Stores},
+		{"instantiations", res.Total.Instantiations},
+		{"executions", res.Total.Executions},
+		{"migrations", res.Total.Migrations},
+		{"gas used", res.Total.GasUsed},
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "%v\t%v\n", row...); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(res.Blocks) == 0 {
+		return nil
+	}
+	busiest := res.Blocks[0]
+	for _, b := range res.Blocks[1:] {
+		if b.GasUsed > busiest.GasUsed {
+			busiest = b
+		}
+	}
+	_, err := fmt.Fprintf(out, "most gas used at height %d: %d\n", busiest.Height, busiest.GasUsed)
+	return err
+}
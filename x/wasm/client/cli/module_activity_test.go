@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRenderModuleActivity(t *testing.T) {
+	specs := map[string]struct {
+		src types.QueryModuleActivityResponse
+		exp string
+	}{
+		"with blocks": {
+			src: types.QueryModuleActivityResponse{
+				Blocks: []types.ModuleActivity{
+					{Height: 10, Stores: 1, Executions: 2, GasUsed: 300},
+					{Height: 12, Instantiations: 1, Executions: 1, Migrations: 1, GasUsed: 500},
+					{Height: 15, Executions: 1, GasUsed: 100},
+				},
+				Total: types.ModuleActivity{Stores: 1, Instantiations: 1, Executions: 4, Migrations: 1, GasUsed: 900},
+			},
+			exp: "blocks 1-20: 3 with wasm activity\n" +
+				"ACTIVITY        TOTAL\n" +
+				"stores          1\n" +
+				"instantiations  1\n" +
+				"executions      4\n" +
+				"migrations      1\n" +
+				"gas used        900\n" +
+				"most gas used at height 12: 500\n",
+		},
+		"no blocks": {
+			exp: "blocks 1-20: 0 with wasm activity\n" +
+				"ACTIVITY        TOTAL\n" +
+				"stores          0\n" +
+				"instantiations  0\n" +
+				"executions      0\n" +
+				"migrations      0\n" +
+				"gas used        0\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, renderModuleActivity(&out, 1, 20, &spec.src))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagMultisig = "multisig"
+
+// wasmTypeURLPrefix is the type url prefix of all wasm messages
+const wasmTypeURLPrefix = "/cosmwasm.wasm.v1."
+
+func addMultisigFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool(flagMultisig, false, "Generate canonical json with base64 encoded contract messages for offline multisig signing, to be decoded with verify-unsigned before signing, requires --generate-only")
+}
+
+func isMultisig(flagSet *flag.FlagSet) bool {
+	f := flagSet.Lookup(flagMultisig)
+	return f != nil && f.Value.String() == "true"
+}
+
+// printMultisigUnsignedTx prints the unsigned tx like tx.Factory.PrintUnsignedTx but encoded with encodeMultisigTxJSON
+func printMultisigUnsignedTx(clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) error {
+	txf, err := tx.NewFactoryCLI(clientCtx, flagSet)
+	if err != nil {
+		return err
+	}
+	if txf.SimulateAndExecute() {
+		if clientCtx.Offline {
+			return errors.New("cannot estimate gas in offline mode")
+		}
+		preparedTxf, err := txf.Prepare(clientCtx)
+		if err != nil {
+			return err
+		}
+		_, adjusted, err := tx.CalculateGas(clientCtx, preparedTxf, msgs...)
+		if err != nil {
+			return err
+		}
+		txf = txf.WithGas(adjusted)
+		_, _ = fmt.Fprintf(os.Stderr, "%s\n", tx.GasEstimateResponse{GasEstimate: txf.Gas()})
+	}
+	unsignedTx, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return err
+	}
+	bz, err := encodeMultisigTxJSON(clientCtx.TxConfig, unsignedTx.GetTx())
+	if err != nil {
+		return err
+	}
+	return clientCtx.PrintString(fmt.Sprintf("%s\n", bz))
+}
+
+// encodeMultisigTxJSON encodes the tx as proto json with sorted keys and without whitespace. The contract messages
+// are base64 encoded so that signers can not re-encode them differently. They are decoded by verify-unsigned only,
+// see decodeMultisigTx.
+func encodeMultisigTxJSON(txCfg client.TxConfig, tx sdk.Tx) ([]byte, error) {
+	bz, err := txCfg.TxJSONEncoder()(tx)
+	if err != nil {
+		return nil, err
+	}
+	return canonicalTxJSON(bz)
+}
+
+// canonicalTxJSON sorts all object keys and encodes the contract messages of wasm types with base64
+func canonicalTxJSON(bz json.RawMessage) (json.RawMessage, error) {
+	bz = bytes.TrimSpace(bz)
+	if len(bz) == 0 {
+		return bz, nil
+	}
+	switch bz[0] {
+	case '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(bz, &obj); err != nil {
+			return nil, err
+		}
+		var typeURL string
+		if v, ok := obj["@type"]; ok {
+			if err := json.Unmarshal(v, &typeURL); err != nil {
+				return nil, err
+			}
+		}
+		isWasmType := strings.HasPrefix(typeURL, wasmTypeURLPrefix)
+		for k, v := range obj {
+			var err error
+			switch {
+			case isWasmType && k == "msg":
+				obj[k], err = base64ContractMessage(v)
+			case isWasmType && k == "messages":
+				obj[k], err = base64ContractMessages(v)
+			default:
+				obj[k], err = canonicalTxJSON(v)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+		}
+		return json.Marshal(obj)
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(bz, &elems); err != nil {
+			return nil, err
+		}
+		for i, v := range elems {
+			var err error
+			if elems[i], err = canonicalTxJSON(v); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(elems)
+	default:
+		return bz, nil
+	}
+}
+
+// base64ContractMessage encodes a json object or array contract message with base64. Other values are kept
+// as they are not decoded by types.RawContractMessage.
+func base64ContractMessage(bz json.RawMessage) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, bz); err != nil {
+		return nil, err
+	}
+	if buf.Len() == 0 || (buf.Bytes()[0] != '{' && buf.Bytes()[0] != '[') {
+		return buf.Bytes(), nil
+	}
+	return json.Marshal(base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+func base64ContractMessages(bz json.RawMessage) (json.RawMessage, error) {
+	return mapContractMessages(bz, base64ContractMessage)
+}
+
+func mapContractMessages(bz json.RawMessage, f func(json.RawMessage) (json.RawMessage, error)) (json.RawMessage, error) {
+	var msgs []json.RawMessage
+	if err := json.Unmarshal(bz, &msgs); err != nil {
+		return nil, err
+	}
+	for i, v := range msgs {
+		var err error
+		if msgs[i], err = f(v); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(msgs)
+}
+
+// decodeMultisigTx decodes the tx json generated with encodeMultisigTxJSON. The base64 encoded contract messages
+// are restored before the tx json decoder is called. Txs without base64 encoded contract messages are decoded
+// as they are.
+func decodeMultisigTx(txCfg client.TxConfig, bz []byte) (sdk.Tx, error) {
+	plain, err := plainTxJSON(bz)
+	if err != nil {
+		return nil, err
+	}
+	return txCfg.TxJSONDecoder()(plain)
+}
+
+// plainTxJSON reverts the base64 encoding of the contract messages of wasm types by canonicalTxJSON
+func plainTxJSON(bz json.RawMessage) (json.RawMessage, error) {
+	bz = bytes.TrimSpace(bz)
+	if len(bz) == 0 {
+		return bz, nil
+	}
+	switch bz[0] {
+	case '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(bz, &obj); err != nil {
+			return nil, err
+		}
+		var typeURL string
+		if v, ok := obj["@type"]; ok {
+			if err := json.Unmarshal(v, &typeURL); err != nil {
+				return nil, err
+			}
+		}
+		isWasmType := strings.HasPrefix(typeURL, wasmTypeURLPrefix)
+		for k, v := range obj {
+			var err error
+			switch {
+			case isWasmType && k == "msg":
+				obj[k], err = plainContractMessage(v)
+			case isWasmType && k == "messages":
+				obj[k], err = mapContractMessages(v, plainContractMessage)
+			default:
+				obj[k], err = plainTxJSON(v)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+		}
+		return json.Marshal(obj)
+	case '[':
+		var elems []json.RawMessage
+		if err := json.Unmarshal(bz, &elems); err != nil {
+			return nil, err
+		}
+		for i, v := range elems {
+			var err error
+			if elems[i], err = plainTxJSON(v); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(elems)
+	default:
+		return bz, nil
+	}
+}
+
+// plainContractMessage returns the json object or array when the message is a json string with the base64
+// encoded message as generated by base64ContractMessage. Other values are kept.
+func plainContractMessage(bz json.RawMessage) (json.RawMessage, error) {
+	var s string
+	if len(bz) == 0 || bz[0] != '"' || json.Unmarshal(bz, &s) != nil {
+		return bz, nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(decoded) == 0 || (decoded[0] != '{' && decoded[0] != '[') || !json.Valid(decoded) {
+		return bz, nil
+	}
+	return decoded, nil
+}
+
+// VerifyUnsignedTxCmd validates the messages of an unsigned tx before signing it offline
+func VerifyUnsignedTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-unsigned [tx.json]",
+		Short: "Validate the messages of an unsigned tx before signing it",
+		Long: fmt.Sprintf(`Validate the messages of an unsigned tx before signing it.
+All messages, including the messages of an authz exec or gov proposal, must pass the stateless validation,
+contract messages must be valid json and the checksum of embedded wasm code is printed to compare it with
+the expected code. The checksum must match the code hash when set.
+The base64 encoded contract messages of a tx generated with --multisig are decoded. Use --output-document
+to write the decoded tx that can be signed with the tx sign command.
+
+Example:
+$ %s tx wasm execute [contract] [json] --from [multisig] --generate-only --multisig > tx.json
+$ %s tx wasm verify-unsigned tx.json --output-document unsigned.json
+$ %s tx sign unsigned.json --from [key] --multisig [multisig]
+`, version.AppName, version.AppName, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			unsignedTx, msgs, err := verifyUnsignedTx(clientCtx.TxConfig, bz)
+			if err != nil {
+				return err
+			}
+			if outputDoc, _ := cmd.Flags().GetString(flags.FlagOutputDocument); outputDoc != "" {
+				plain, err := clientCtx.TxConfig.TxJSONEncoder()(unsignedTx)
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(outputDoc, plain, 0o600); err != nil {
+					return err
+				}
+			}
+			return printVerifiedMsgs(cmd.OutOrStdout(), msgs)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flags.FlagOutputDocument, "", "Write the decoded tx that can be signed to the given file")
+	return cmd
+}
+
+// verifyUnsignedTx decodes the tx json, see decodeMultisigTx, and validates all messages
+func verifyUnsignedTx(txCfg client.TxConfig, bz []byte) (sdk.Tx, []verifiedMsg, error) {
+	decodedTx, err := decodeMultisigTx(txCfg, bz)
+	if err != nil {
+		return nil, nil, err
+	}
+	msgs, err := verifyMsgs("", decodedTx.GetMsgs())
+	if err != nil {
+		return nil, nil, err
+	}
+	return decodedTx, msgs, nil
+}
+
+// verifiedMsg is a message of an unsigned tx that passed the validation
+type verifiedMsg struct {
+	Index    string
+	TypeURL  string
+	Checksum []byte
+}
+
+func verifyMsgs(indexPrefix string, msgs []sdk.Msg) ([]verifiedMsg, error) {
+	var r []verifiedMsg
+	for i, msg := range msgs {
+		index := fmt.Sprintf("%s%d", indexPrefix, i)
+		if m, ok := msg.(sdk.HasValidateBasic); ok {
+			if err := m.ValidateBasic(); err != nil {
+				return nil, fmt.Errorf("msg %s: %w", index, err)
+			}
+		}
+		checksum, err := verifyWasmCode(msg)
+		if err != nil {
+			return nil, fmt.Errorf("msg %s: %w", index, err)
+		}
+		r = append(r, verifiedMsg{Index: index, TypeURL: sdk.MsgTypeURL(msg), Checksum: checksum})
+
+		var nested []sdk.Msg
+		switch m := msg.(type) {
+		case *authz.MsgExec:
+			nested, err = m.GetMessages()
+		case *v1.MsgSubmitProposal:
+			nested, err = m.GetMsgs()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("msg %s: %w", index, err)
+		}
+		nestedMsgs, err := verifyMsgs(index+".", nested)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, nestedMsgs...)
+	}
+	return r, nil
+}
+
+// verifyWasmCode returns the checksum of the wasm code embedded in the message, if any
+func verifyWasmCode(msg sdk.Msg) ([]byte, error) {
+	switch m := msg.(type) {
+	case *types.MsgStoreCode:
+		return wasmChecksum(m.WASMByteCode)
+	case *types.MsgStoreAndMigrateContract:
+		return wasmChecksum(m.WASMByteCode)
+	case *types.MsgStoreAndInstantiateContract:
+		checksum, err := wasmChecksum(m.WASMByteCode)
+		if err != nil {
+			return nil, err
+		}
+		if len(m.CodeHash) != 0 && !bytes.Equal(checksum, m.CodeHash) {
+			return nil, fmt.Errorf("code hash mismatch: %X, checksum: %X", m.CodeHash, checksum)
+		}
+		return checksum, nil
+	default:
+		return nil, nil
+	}
+}
+
+func wasmChecksum(code []byte) ([]byte, error) {
+	if ioutils.IsGzip(code) {
+		var err error
+		if code, err = ioutils.Uncompress(code, int64(types.MaxWasmSize)); err != nil {
+			return nil, fmt.Errorf("invalid zip: %w", err)
+		}
+	}
+	checksum, err := wasmvm.CreateChecksum(code)
+	if err != nil {
+		return nil, fmt.Errorf("checksum: %s", err)
+	}
+	return checksum[:], nil
+}
+
+func printVerifiedMsgs(out io.Writer, msgs []verifiedMsg) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "MSG\tTYPE\tCHECKSUM"); err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		checksum := "-"
+		if len(m.Checksum) != 0 {
+			checksum = hex.EncodeToString(m.Checksum)
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", m.Index, m.TypeURL, checksum); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "%d messages verified\n", len(msgs))
+	return err
+}
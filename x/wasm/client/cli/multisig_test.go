@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMultisigTxRoundTrip(t *testing.T) {
+	const mySender = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	txConfig := keeper.MakeEncodingConfig(t).TxConfig
+	storeMsg := &types.MsgStoreCode{Sender: mySender, WASMByteCode: testdata.HackatomContractWasm()}
+	instantiateMsg := &types.MsgInstantiateContract{
+		Sender: mySender,
+		CodeID: 1,
+		Label:  "treasury",
+		Msg:    []byte(`{"verifier": "foo",  "beneficiary":"bar"}`),
+		Funds:  sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+	}
+	builder := txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(storeMsg, instantiateMsg))
+	builder.SetGasLimit(200_000)
+	builder.SetMemo("a < b")
+
+	// generate
+	gotJSON, err := encodeMultisigTxJSON(txConfig, builder.GetTx())
+	require.NoError(t, err)
+	expMsg := base64.StdEncoding.EncodeToString([]byte(`{"verifier":"foo","beneficiary":"bar"}`))
+	assert.Contains(t, string(gotJSON), `"msg":"`+expMsg+`"`)
+	assert.True(t, json.Valid(gotJSON))
+	// canonical encoding is stable
+	reencoded, err := canonicalTxJSON(gotJSON)
+	require.NoError(t, err)
+	assert.Equal(t, string(gotJSON), string(reencoded))
+
+	// verify
+	unsignedTx, gotMsgs, err := verifyUnsignedTx(txConfig, gotJSON)
+	require.NoError(t, err)
+	expChecksum, err := hex.DecodeString(testdata.ChecksumHackatom)
+	require.NoError(t, err)
+	exp := []verifiedMsg{
+		{Index: "0", TypeURL: "/cosmwasm.wasm.v1.MsgStoreCode", Checksum: expChecksum},
+		{Index: "1", TypeURL: "/cosmwasm.wasm.v1.MsgInstantiateContract"},
+	}
+	assert.Equal(t, exp, gotMsgs)
+
+	// sign stub with the decoded tx as written by verify-unsigned
+	plainJSON, err := txConfig.TxJSONEncoder()(unsignedTx)
+	require.NoError(t, err)
+	unsignedTx, err = txConfig.TxJSONDecoder()(plainJSON)
+	require.NoError(t, err)
+	signBuilder, err := txConfig.WrapTxBuilder(unsignedTx)
+	require.NoError(t, err)
+	require.NoError(t, signBuilder.SetSignatures(signing.SignatureV2{
+		PubKey: secp256k1.GenPrivKey().PubKey(),
+		Data:   &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, Signature: []byte("stub")},
+	}))
+	signedJSON, err := txConfig.TxJSONEncoder()(signBuilder.GetTx())
+	require.NoError(t, err)
+	signedTx, err := txConfig.TxJSONDecoder()(signedJSON)
+	require.NoError(t, err)
+
+	// then the signed messages are byte equal to the generated ones
+	gotSigned := signedTx.GetMsgs()
+	require.Len(t, gotSigned, 2)
+	assert.Equal(t, storeMsg.WASMByteCode, gotSigned[0].(*types.MsgStoreCode).WASMByteCode)
+	assert.Equal(t, `{"verifier":"foo","beneficiary":"bar"}`, string(gotSigned[1].(*types.MsgInstantiateContract).Msg))
+	assert.Equal(t, instantiateMsg.Funds, gotSigned[1].(*types.MsgInstantiateContract).Funds)
+}
+
+func TestCanonicalTxJSON(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	specs := map[string]struct {
+		src    string
+		exp    string
+		expErr bool
+	}{
+		"keys sorted": {
+			src: `{"b": 1, "a": {"d": [2, 1], "c": null}}`,
+			exp: `{"a":{"c":null,"d":[2,1]},"b":1}`,
+		},
+		"wasm msg": {
+			src: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":{"b": 1, "a": 2}}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"` + b64(`{"b":1,"a":2}`) + `"}`,
+		},
+		"wasm msg nested": {
+			src: `{"msgs":[{"@type":"/cosmwasm.wasm.v1.MsgSudoContract","msg":[1]}]}`,
+			exp: `{"msgs":[{"@type":"/cosmwasm.wasm.v1.MsgSudoContract","msg":"` + b64(`[1]`) + `"}]}`,
+		},
+		"wasm string msg": {
+			src: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"release"}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"release"}`,
+		},
+		"wasm messages filter": {
+			src: `{"@type":"/cosmwasm.wasm.v1.AcceptedMessagesFilter","messages":[{"b":1,"a":2},"foo"]}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.AcceptedMessagesFilter","messages":["` + b64(`{"b":1,"a":2}`) + `","foo"]}`,
+		},
+		"non wasm msg": {
+			src: `{"@type":"/other.v1.Msg","msg":{"b":1,"a":2}}`,
+			exp: `{"@type":"/other.v1.Msg","msg":{"a":2,"b":1}}`,
+		},
+		"invalid json": {
+			src:    `{"a":`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := canonicalTxJSON([]byte(spec.src))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, string(got))
+		})
+	}
+}
+
+func TestPlainTxJSON(t *testing.T) {
+	b64 := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+	specs := map[string]struct {
+		src    string
+		exp    string
+		expErr bool
+	}{
+		"wasm msg": {
+			src: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"` + b64(`{"b":1,"a":2}`) + `"}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":{"b":1,"a":2}}`,
+		},
+		"wasm msg nested": {
+			src: `{"msgs":[{"@type":"/cosmwasm.wasm.v1.MsgSudoContract","msg":"` + b64(`[1]`) + `"}]}`,
+			exp: `{"msgs":[{"@type":"/cosmwasm.wasm.v1.MsgSudoContract","msg":[1]}]}`,
+		},
+		"wasm string msg": {
+			src: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"release"}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"release"}`,
+		},
+		"wasm base64 encoded number": {
+			src: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"` + b64(`123`) + `"}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","msg":"` + b64(`123`) + `"}`,
+		},
+		"wasm messages filter": {
+			src: `{"@type":"/cosmwasm.wasm.v1.AcceptedMessagesFilter","messages":["` + b64(`{"a":2}`) + `","foo"]}`,
+			exp: `{"@type":"/cosmwasm.wasm.v1.AcceptedMessagesFilter","messages":[{"a":2},"foo"]}`,
+		},
+		"non wasm msg": {
+			src: `{"@type":"/other.v1.Msg","msg":"` + b64(`{"a":2}`) + `"}`,
+			exp: `{"@type":"/other.v1.Msg","msg":"` + b64(`{"a":2}`) + `"}`,
+		},
+		"invalid json": {
+			src:    `{"a":`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := plainTxJSON([]byte(spec.src))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, string(got))
+		})
+	}
+}
+
+func TestVerifyMsgs(t *testing.T) {
+	const mySender = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	expChecksum, err := hex.DecodeString(testdata.ChecksumHackatom)
+	require.NoError(t, err)
+	executeMsg := &types.MsgExecuteContract{Sender: mySender, Contract: mySender, Msg: []byte(`{}`)}
+	execMsg := authz.NewMsgExec(sdk.MustAccAddressFromBech32(mySender), []sdk.Msg{executeMsg})
+
+	specs := map[string]struct {
+		src    []sdk.Msg
+		exp    []verifiedMsg
+		expErr bool
+	}{
+		"store and instantiate with code hash": {
+			src: []sdk.Msg{&types.MsgStoreAndInstantiateContract{
+				Authority: mySender, WASMByteCode: testdata.HackatomContractWasm(), Label: "foo", Msg: []byte(`{}`),
+				Source: "https://example.com/hackatom.wasm", Builder: "cosmwasm/workspace-optimizer:0.12.9", CodeHash: expChecksum,
+			}},
+			exp: []verifiedMsg{{Index: "0", TypeURL: "/cosmwasm.wasm.v1.MsgStoreAndInstantiateContract", Checksum: expChecksum}},
+		},
+		"authz exec": {
+			src: []sdk.Msg{&execMsg},
+			exp: []verifiedMsg{
+				{Index: "0", TypeURL: "/cosmos.authz.v1beta1.MsgExec"},
+				{Index: "0.0", TypeURL: "/cosmwasm.wasm.v1.MsgExecuteContract"},
+			},
+		},
+		"code hash mismatch": {
+			src: []sdk.Msg{&types.MsgStoreAndInstantiateContract{
+				Authority: mySender, WASMByteCode: testdata.HackatomContractWasm(), Label: "foo", Msg: []byte(`{}`),
+				Source: "https://example.com/hackatom.wasm", Builder: "cosmwasm/workspace-optimizer:0.12.9", CodeHash: bytes.Repeat([]byte{1}, 32),
+			}},
+			expErr: true,
+		},
+		"invalid wasm code": {
+			src:    []sdk.Msg{&types.MsgStoreCode{Sender: mySender, WASMByteCode: []byte("not wasm")}},
+			expErr: true,
+		},
+		"invalid contract msg": {
+			src:    []sdk.Msg{&types.MsgExecuteContract{Sender: mySender, Contract: mySender, Msg: []byte(`{"foo"`)}},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := verifyMsgs("", spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestPrintVerifiedMsgs(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printVerifiedMsgs(&out, []verifiedMsg{
+		{Index: "0", TypeURL: "/cosmwasm.wasm.v1.MsgStoreCode", Checksum: []byte{0xab}},
+		{Index: "1", TypeURL: "/cosmwasm.wasm.v1.MsgInstantiateContract"},
+	}))
+	exp := "MSG  TYPE                                      CHECKSUM\n" +
+		"0    /cosmwasm.wasm.v1.MsgStoreCode            ab\n" +
+		"1    /cosmwasm.wasm.v1.MsgInstantiateContract  -\n" +
+		"2 messages verified\n"
+	assert.Equal(t, exp, out.String())
+}
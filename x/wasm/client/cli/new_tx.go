@@ -1,15 +1,22 @@
 package cli
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
 
 	errorsmod "cosmossdk.io/errors"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/client/input"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -31,10 +38,20 @@ func MigrateContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			if err := checkCW2FromFlags(cmd, clientCtx, msg.Contract); err != nil {
+				return err
+			}
+			if err := applyMemoTemplate(cmd.Flags(), contractMemoValues(msg.Contract, msg.CodeID)); err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
+	addCW2CheckFlags(cmd)
+	addGasPreviewFlag(cmd)
+	addMultisigFlag(cmd)
+	addMemoTemplateFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -74,10 +91,14 @@ func UpdateContractAdminCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			if err := checkAdminExists(cmd, clientCtx, msg.NewAdmin); err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
+	cmd.Flags().Bool(flagVerifyAdminExists, false, "Query the chain to ensure the new admin is an existing account or contract")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -111,7 +132,7 @@ func ClearContractAdminCmd() *cobra.Command {
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
@@ -131,45 +152,102 @@ func UpdateInstantiateConfigCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			msg, err := parseUpdateInstantiateConfigArgs(args[0], clientCtx.GetFromAddress().String(), cmd.Flags())
 			if err != nil {
 				return err
 			}
-			perm, err := parseAccessConfigFlags(cmd.Flags())
-			if err != nil {
+			if err = msg.ValidateBasic(); err != nil {
 				return err
 			}
-
-			msg := types.MsgUpdateInstantiateConfig{
-				Sender:                   clientCtx.GetFromAddress().String(),
-				CodeID:                   codeID,
-				NewInstantiatePermission: perm,
+			if err := checkInstantiatePermissionAddresses(cmd, clientCtx, msg.NewInstantiatePermission); err != nil {
+				return err
 			}
-			if err = msg.ValidateBasic(); err != nil {
+			showDiff, err := cmd.Flags().GetBool(flagShowDiff)
+			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			if showDiff {
+				current, proposed, err := proposedInstantiateConfig(cmd.Context(), types.NewQueryClient(clientCtx), msg)
+				if err != nil {
+					return err
+				}
+				changed, err := renderAccessConfigDiff(cmd.ErrOrStderr(), current, proposed)
+				if err != nil || !changed {
+					return err
+				}
+				if !clientCtx.SkipConfirm && !clientCtx.GenerateOnly && !clientCtx.Simulate {
+					ok, err := input.GetConfirmation("update the instantiate config?", bufio.NewReader(clientCtx.Input), cmd.ErrOrStderr())
+					if err != nil {
+						return err
+					}
+					if !ok {
+						return errors.New("update canceled")
+					}
+					// the update was confirmed with the diff already
+					clientCtx = clientCtx.WithSkipConfirmation(true)
+				}
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
 
 	addInstantiatePermissionFlags(cmd)
+	addSkipAddressCheckFlag(cmd)
+	cmd.Flags().Bool(flagShowDiff, false, "Show the current and the proposed instantiate config and ask for confirmation unless --yes, nothing is sent without a change")
+	cmd.Flags().Bool(flagResetToDefault, false, "Reset the instantiate permission to the chain default, can not be combined with other instantiate permission flags")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+func parseUpdateInstantiateConfigArgs(rawCodeID, sender string, flags *flag.FlagSet) (types.MsgUpdateInstantiateConfig, error) {
+	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
+	if err != nil {
+		return types.MsgUpdateInstantiateConfig{}, err
+	}
+	reset, err := flags.GetBool(flagResetToDefault)
+	if err != nil {
+		return types.MsgUpdateInstantiateConfig{}, fmt.Errorf("reset to default: %s", err)
+	}
+	if reset {
+		for _, f := range []string{flagInstantiateByEverybody, flagInstantiateNobody, flagInstantiateByAddress, flagInstantiateByAnyOfAddress} {
+			if flags.Changed(f) {
+				return types.MsgUpdateInstantiateConfig{}, fmt.Errorf("flag %s can not be combined with %s", flagResetToDefault, f)
+			}
+		}
+		return types.MsgUpdateInstantiateConfig{
+			Sender:         sender,
+			CodeID:         codeID,
+			ResetToDefault: true,
+		}, nil
+	}
+	perm, err := parseAccessConfigFlags(flags)
+	if err != nil {
+		return types.MsgUpdateInstantiateConfig{}, err
+	}
+	return types.MsgUpdateInstantiateConfig{
+		Sender:                   sender,
+		CodeID:                   codeID,
+		NewInstantiatePermission: perm,
+	}, nil
+}
+
 // UpdateContractLabelCmd sets an new label for a contract
 func UpdateContractLabelCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "set-contract-label [contract_addr_bech32] [new_label]",
 		Short: "Set new label for a contract",
-		Args:  cobra.ExactArgs(2),
+		Long: `Set new label for a contract. The label must meet the same rules as on instantiate.
+The current label is queried and shown before broadcasting. The update must be confirmed unless --yes is set.`,
+		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-
+			if err := validateLabel(args[1]); err != nil {
+				return err
+			}
 			msg := types.MsgUpdateContractLabel{
 				Sender:   clientCtx.GetFromAddress().String(),
 				Contract: args[0],
@@ -178,10 +256,169 @@ func UpdateContractLabelCmd() *cobra.Command {
 			if err = msg.ValidateBasic(); err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			if !clientCtx.GenerateOnly && !clientCtx.Offline {
+				err := confirmContractLabelUpdate(cmd.Context(), types.NewQueryClient(clientCtx), msg, bufio.NewReader(clientCtx.Input), cmd.ErrOrStderr(), clientCtx.SkipConfirm || clientCtx.Simulate)
+				if err != nil {
+					return err
+				}
+				// the update was confirmed with the labels already
+				clientCtx = clientCtx.WithSkipConfirmation(true)
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// SetContractStateCmd writes raw key/value pairs to the store of a contract
+func SetContractStateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-state [contract_addr_bech32] [json_file]",
+		Short: "Write raw key/value pairs to the store of a contract",
+		Long: `Write raw key/value pairs to the store of a contract. This requires raw state writes to be enabled at genesis
+and must be signed by the module authority. The json file contains a list of base64 encoded key/value pairs:
+[{"key":"Y29uZmln","value":"eyJjb3VudCI6MX0="}]`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			msg, err := parseSetContractStateArgs(args[0], args[1], clientCtx.GetFromAddress().String())
+			if err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
 		},
 		SilenceUsage: true,
 	}
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
+
+// SetContractStateAccessCmd enables or disables the raw state queries of a contract
+func SetContractStateAccessCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-state-access [contract_addr_bech32] [raw_query_enabled]",
+		Short: "Enable or disable the raw state queries of a contract",
+		Long: `Enable or disable the raw state queries of a contract. This requires contract state access control to be
+enabled in the params and must be signed by the contract admin. Smart queries are not affected.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			rawQueryEnabled, err := strconv.ParseBool(args[1])
+			if err != nil {
+				return fmt.Errorf("raw query enabled: %s", err)
+			}
+
+			msg := types.MsgSetContractStateAccess{
+				Sender:          clientCtx.GetFromAddress().String(),
+				Contract:        args[0],
+				RawQueryEnabled: rawQueryEnabled,
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+func parseSetContractStateArgs(contract, file, authority string) (types.MsgSetContractState, error) {
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return types.MsgSetContractState{}, err
+	}
+	var pairs []struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	}
+	if err := json.Unmarshal(bz, &pairs); err != nil {
+		return types.MsgSetContractState{}, errorsmod.Wrap(err, "key/value pairs")
+	}
+	models := make([]types.Model, len(pairs))
+	for i, p := range pairs {
+		models[i] = types.Model{Key: p.Key, Value: p.Value}
+	}
+	msg := types.MsgSetContractState{
+		Authority: authority,
+		Contract:  contract,
+		Models:    models,
+	}
+	return msg, msg.ValidateBasic()
+}
+
+// SudoContractCmd calls the sudo entry point of a contract with a message signed by the module authority
+func SudoContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sudo [contract_addr_bech32] [json_encoded_sudo_msg|@file] --authority [address]",
+		Short: "Call the sudo entry point of a contract as the module authority",
+		Long: `Call the sudo entry point of a contract as the module authority. This is intended for chains with a
+non gov authority, like a multisig, and is usually combined with --generate-only to feed a multisig signing flow.
+The message can be read from a file with the @ prefix. With the gov module account as authority, the
+tx is not broadcasted unless --force is set. Use "tx wasm submit-proposal sudo-contract" instead.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			authority, err := cmd.Flags().GetString(flagAuthority)
+			if err != nil {
+				return fmt.Errorf("authority: %s", err)
+			}
+			force, err := cmd.Flags().GetBool(flagForce)
+			if err != nil {
+				return fmt.Errorf("force: %s", err)
+			}
+			if err := checkSudoAuthority(authority, clientCtx.GenerateOnly, force); err != nil {
+				return err
+			}
+			msg, err := parseSudoContractArgs(args[0], args[1], authority)
+			if err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagAuthority, DefaultGovAuthority.String(), "The address of the module authority. Default is the sdk gov module account")
+	cmd.Flags().Bool(flagForce, false, "Broadcast the tx even when the authority is the gov module account")
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// checkSudoAuthority rejects a direct broadcast with the gov module account as authority as the tx can not
+// be signed by it
+func checkSudoAuthority(authority string, generateOnly, force bool) error {
+	if authority == DefaultGovAuthority.String() && !generateOnly && !force {
+		return errors.New("authority is the gov module account: use \"tx wasm submit-proposal sudo-contract\" instead or set --force")
+	}
+	return nil
+}
+
+func parseSudoContractArgs(contract, rawMsg, authority string) (types.MsgSudoContract, error) {
+	sudoMsg := []byte(rawMsg)
+	if file, ok := strings.CutPrefix(rawMsg, "@"); ok {
+		bz, err := os.ReadFile(file)
+		if err != nil {
+			return types.MsgSudoContract{}, errorsmod.Wrap(err, "sudo msg")
+		}
+		sudoMsg = bz
+	}
+	msg := types.MsgSudoContract{
+		Authority: authority,
+		Contract:  contract,
+		Msg:       sudoMsg,
+	}
+	return msg, msg.ValidateBasic()
+}
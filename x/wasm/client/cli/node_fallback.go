@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+const (
+	flagNodeFallback = "node-fallback"
+
+	// nodeStatusTimeout is the max time to wait for the status of a node
+	nodeStatusTimeout = 5 * time.Second
+)
+
+// nodeStatusFn returns an error when the node is not reachable
+type nodeStatusFn func(ctx context.Context, node string) error
+
+// addNodeFallback adds the node fallback flag to all commands with a node flag and runs them with the first
+// reachable node of the list
+func addNodeFallback(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		addNodeFallback(c)
+	}
+	if cmd.RunE == nil || cmd.Flags().Lookup(flags.FlagNode) == nil {
+		return
+	}
+	cmd.Flags().StringSlice(flagNodeFallback, nil, "Comma separated RPC endpoints to use when the node is not reachable. Can be given multiple times")
+	withNodeFallback(cmd, queryNodeStatus)
+}
+
+// withNodeFallback wraps the run function of the command. Without fallback nodes, the command runs as before.
+// Otherwise the first node that returns its status is used. When the command fails with a connection error, it is
+// run once more with the next reachable node. Queries at a fixed height are not retried so that all data is read from
+// the same node.
+func withNodeFallback(cmd *cobra.Command, nodeStatus nodeStatusFn) {
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		nodes, err := nodeEndpoints(cmd)
+		if err != nil {
+			return err
+		}
+		if len(nodes) < 2 {
+			return runE(cmd, args)
+		}
+		heightPinned, err := isHeightPinned(cmd.Flags())
+		if err != nil {
+			return err
+		}
+		var (
+			lastErr error
+			retried bool
+		)
+		for _, node := range nodes {
+			ctx, cancel := context.WithTimeout(cmd.Context(), nodeStatusTimeout)
+			err := nodeStatus(ctx, node)
+			cancel()
+			if err != nil {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "node %s: not reachable: %s\n", node, err)
+				lastErr = err
+				continue
+			}
+			if err := cmd.Flags().Set(flags.FlagNode, node); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "using node %s\n", node)
+			err = runE(cmd, args)
+			if err == nil || retried || heightPinned || !isConnectionError(err) {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "node %s: %s: retrying with the next node\n", node, err)
+			lastErr = err
+			retried = true
+		}
+		return fmt.Errorf("no reachable node: %w", lastErr)
+	}
+}
+
+// nodeEndpoints returns the comma separated nodes of the node flag, or the node of the client config, and
+// the fallback nodes
+func nodeEndpoints(cmd *cobra.Command) ([]string, error) {
+	fs := cmd.Flags()
+	node, err := fs.GetString(flags.FlagNode)
+	if err != nil {
+		return nil, fmt.Errorf("node: %s", err)
+	}
+	if !fs.Changed(flags.FlagNode) {
+		if clientCtx := client.GetClientContextFromCmd(cmd); clientCtx.NodeURI != "" {
+			node = clientCtx.NodeURI
+		}
+	}
+	fallback, err := fs.GetStringSlice(flagNodeFallback)
+	if err != nil {
+		return nil, fmt.Errorf("node fallback: %s", err)
+	}
+	var nodes []string
+	for _, n := range append(strings.Split(node, ","), fallback...) {
+		if n = strings.TrimSpace(n); n != "" {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes, nil
+}
+
+// isHeightPinned returns true when the command queries at a fixed height
+func isHeightPinned(fs *flag.FlagSet) (bool, error) {
+	if fs.Lookup(flags.FlagHeight) == nil {
+		return false, nil
+	}
+	height, err := fs.GetInt64(flags.FlagHeight)
+	if err != nil {
+		return false, fmt.Errorf("height: %s", err)
+	}
+	return height > 0, nil
+}
+
+// isConnectionError returns true when the node could not be reached
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+	if s, ok := status.FromError(err); ok && s.Code() == codes.Unavailable {
+		return true
+	}
+	// errors of the rpc client are not always wrapped
+	msg := err.Error()
+	for _, s := range []string{"connection refused", "connection reset", "no such host", "i/o timeout", "dial tcp"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryNodeStatus queries the status of the node
+func queryNodeStatus(ctx context.Context, node string) error {
+	rpcClient, err := client.NewClientFromNode(node)
+	if err != nil {
+		return err
+	}
+	_, err = rpcClient.Status(ctx)
+	return err
+}
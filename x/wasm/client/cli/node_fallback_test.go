@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+func TestWithNodeFallback(t *testing.T) {
+	const (
+		nodeA = "tcp://a:26657"
+		nodeB = "tcp://b:26657"
+		nodeC = "tcp://c:26657"
+	)
+	errConnRefused := &testNetError{error: syscall.ECONNREFUSED}
+	specs := map[string]struct {
+		args         []string
+		unreachable  map[string]bool
+		runErrs      []error
+		expNodes     []string
+		expErr       error
+		expErrString string
+	}{
+		"single node": {
+			args:     []string{"--node", nodeA},
+			expNodes: []string{nodeA},
+		},
+		"first node reachable": {
+			args:     []string{"--node", nodeA + "," + nodeB},
+			expNodes: []string{nodeA},
+		},
+		"first node unreachable": {
+			args:        []string{"--node", nodeA + "," + nodeB},
+			unreachable: map[string]bool{nodeA: true},
+			expNodes:    []string{nodeB},
+		},
+		"fallback flag": {
+			args:        []string{"--node", nodeA, "--node-fallback", nodeB, "--node-fallback", nodeC},
+			unreachable: map[string]bool{nodeA: true, nodeB: true},
+			expNodes:    []string{nodeC},
+		},
+		"retry on connection error": {
+			args:     []string{"--node", nodeA, "--node-fallback", nodeB + "," + nodeC},
+			runErrs:  []error{errConnRefused},
+			expNodes: []string{nodeA, nodeB},
+		},
+		"retry once only": {
+			args:     []string{"--node", nodeA, "--node-fallback", nodeB + "," + nodeC},
+			runErrs:  []error{errConnRefused, errConnRefused},
+			expNodes: []string{nodeA, nodeB},
+			expErr:   errConnRefused,
+		},
+		"no retry on other errors": {
+			args:     []string{"--node", nodeA + "," + nodeB},
+			runErrs:  []error{errors.New("testing")},
+			expNodes: []string{nodeA},
+			expErr:   errors.New("testing"),
+		},
+		"no retry at fixed height": {
+			args:     []string{"--node", nodeA + "," + nodeB, "--height", "7"},
+			runErrs:  []error{errConnRefused},
+			expNodes: []string{nodeA},
+			expErr:   errConnRefused,
+		},
+		"no node reachable": {
+			args:         []string{"--node", nodeA + "," + nodeB},
+			unreachable:  map[string]bool{nodeA: true, nodeB: true},
+			expErrString: "no reachable node",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotNodes []string
+			cmd := newNodeFallbackTestCmd(func(cmd *cobra.Command, _ []string) error {
+				node, err := cmd.Flags().GetString(flags.FlagNode)
+				require.NoError(t, err)
+				gotNodes = append(gotNodes, node)
+				if len(gotNodes) <= len(spec.runErrs) {
+					return spec.runErrs[len(gotNodes)-1]
+				}
+				return nil
+			})
+			withNodeFallback(cmd, func(_ context.Context, node string) error {
+				if spec.unreachable[node] {
+					return errConnRefused
+				}
+				return nil
+			})
+			cmd.SetArgs(spec.args)
+
+			gotErr := cmd.Execute()
+			switch {
+			case spec.expErr != nil:
+				assert.Equal(t, spec.expErr, gotErr)
+			case spec.expErrString != "":
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErrString)
+			default:
+				require.NoError(t, gotErr)
+			}
+			assert.Equal(t, spec.expNodes, gotNodes)
+		})
+	}
+}
+
+func TestNodeFallbackWithUnreachableNode(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "status" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(rpctypes.NewRPCSuccessResponse(req.ID, &coretypes.ResultStatus{}))
+	}))
+	t.Cleanup(up.Close)
+
+	var gotNode string
+	cmd := newNodeFallbackTestCmd(func(cmd *cobra.Command, _ []string) error {
+		var err error
+		gotNode, err = cmd.Flags().GetString(flags.FlagNode)
+		return err
+	})
+	withNodeFallback(cmd, queryNodeStatus)
+	var errOut bytes.Buffer
+	cmd.SetErr(&errOut)
+	cmd.SetArgs([]string{"--node", down.URL + "," + up.URL})
+
+	// when
+	require.NoError(t, cmd.Execute())
+
+	// then
+	assert.Equal(t, up.URL, gotNode)
+	assert.Contains(t, errOut.String(), "node "+down.URL+": not reachable")
+	assert.Contains(t, errOut.String(), "using node "+up.URL)
+}
+
+func TestIsConnectionError(t *testing.T) {
+	specs := map[string]struct {
+		err error
+		exp bool
+	}{
+		"refused":         {err: syscall.ECONNREFUSED, exp: true},
+		"net error":       {err: &testNetError{error: errors.New("testing")}, exp: true},
+		"unwrapped dial":  {err: errors.New("post failed: dial tcp 127.0.0.1:26657: connect: connection refused"), exp: true},
+		"unknown host":    {err: errors.New("lookup node: no such host"), exp: true},
+		"not found":       {err: errors.New("contract: not found")},
+		"invalid request": {err: errors.New("invalid request")},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, isConnectionError(spec.err))
+		})
+	}
+}
+
+func newNodeFallbackTestCmd(runE func(cmd *cobra.Command, args []string) error) *cobra.Command {
+	cmd := &cobra.Command{Use: "test", RunE: runE, SilenceUsage: true, SilenceErrors: true}
+	flags.AddQueryFlagsToCmd(cmd)
+	cmd.Flags().StringSlice(flagNodeFallback, nil, "")
+	cmd.SetContext(context.Background())
+	cmd.SetErr(io.Discard)
+	return cmd
+}
+
+type testNetError struct{ error }
+
+func (e *testNetError) Timeout() bool   { return false }
+func (e *testNetError) Temporary() bool { return false }
+func (e *testNetError) Unwrap() error   { return e.error }
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+)
+
+const flagPreflightReport = "preflight-report"
+
+// preflightReportVersion is the version of the report schema. Fields and check names are never renamed or removed
+// within a version so that pipelines can gate on them.
+const preflightReportVersion = 1
+
+// preflightStatus is the outcome of a preflight check
+type preflightStatus string
+
+const (
+	preflightPass    preflightStatus = "pass"
+	preflightWarn    preflightStatus = "warn"
+	preflightFail    preflightStatus = "fail"
+	preflightSkipped preflightStatus = "skipped"
+)
+
+// names of the preflight checks in the report
+const (
+	preflightAdminExists                    = "admin_exists"
+	preflightDuplicateCode                  = "duplicate_code"
+	preflightFundsConsistency               = "funds_consistency"
+	preflightInstantiate2Address            = "instantiate2_address"
+	preflightInstantiateCode                = "instantiate_code"
+	preflightInstantiatePermissionAddresses = "instantiate_permission_addresses"
+	preflightLockedFunds                    = "locked_funds"
+	preflightStoreCodeGrant                 = "store_code_grant"
+	preflightTxSize                         = "tx_size"
+	preflightUploadPermission               = "upload_permission"
+	preflightUploadQuota                    = "upload_quota"
+)
+
+// preflightCheck is the result of a single preflight check
+type preflightCheck struct {
+	Name    string          `json:"name"`
+	Status  preflightStatus `json:"status"`
+	Details string          `json:"details,omitempty"`
+}
+
+// preflightReport contains the preflight checks performed by a command in the order they were run. The error is set
+// when the command failed, by a check or otherwise.
+type preflightReport struct {
+	Version int              `json:"version"`
+	Command string           `json:"command"`
+	Checks  []preflightCheck `json:"checks"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type preflightReportKey struct{}
+
+// addPreflightReport adds the preflight report flag to all tx commands. The report file is written when the command
+// returns, independent of whether the tx was broadcast.
+func addPreflightReport(cmd *cobra.Command) {
+	for _, c := range cmd.Commands() {
+		addPreflightReport(c)
+	}
+	if cmd.RunE == nil || cmd.Flags().Lookup(flags.FlagFrom) == nil {
+		return
+	}
+	cmd.Flags().String(flagPreflightReport, "", "Write the results of all preflight checks as json to the file, also when the command fails")
+	runE := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		file, err := cmd.Flags().GetString(flagPreflightReport)
+		if err != nil {
+			return fmt.Errorf("preflight report: %s", err)
+		}
+		if file == "" {
+			return runE(cmd, args)
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		report := &preflightReport{Version: preflightReportVersion, Command: cmd.CommandPath(), Checks: []preflightCheck{}}
+		cmd.SetContext(context.WithValue(ctx, preflightReportKey{}, report))
+		runErr := runE(cmd, args)
+		if runErr != nil {
+			report.Error = runErr.Error()
+		}
+		return errors.Join(runErr, writePreflightReport(file, report))
+	}
+}
+
+// recordPreflight adds the check to the report of the context. A check that was run already, like on a retry with
+// a fallback node, is replaced. Nothing is recorded without a report.
+func recordPreflight(ctx context.Context, name string, status preflightStatus, details string) {
+	if ctx == nil {
+		return
+	}
+	report, ok := ctx.Value(preflightReportKey{}).(*preflightReport)
+	if !ok {
+		return
+	}
+	check := preflightCheck{Name: name, Status: status, Details: details}
+	for i, c := range report.Checks {
+		if c.Name == name {
+			report.Checks[i] = check
+			return
+		}
+	}
+	report.Checks = append(report.Checks, check)
+}
+
+// recordPreflightResult records a failed check for an error, a warning or a pass otherwise
+func recordPreflightResult(ctx context.Context, name, warning string, err error) {
+	switch {
+	case err != nil:
+		recordPreflight(ctx, name, preflightFail, err.Error())
+	case warning != "":
+		recordPreflight(ctx, name, preflightWarn, warning)
+	default:
+		recordPreflight(ctx, name, preflightPass, "")
+	}
+}
+
+func writePreflightReport(file string, report *preflightReport) error {
+	bz, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(file, append(bz, '\n'), 0o644); err != nil {
+		return fmt.Errorf("preflight report: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestPreflightReport(t *testing.T) {
+	const myAddr = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	txConfig := keeper.MakeEncodingConfig(t).TxConfig
+	msg := &types.MsgExecuteContract{Sender: myAddr, Contract: myAddr, Msg: []byte(`{"deposit":{"denom":"ustake","amount":"100"}}`)}
+	size, err := estimateTxSize(txConfig, msg)
+	require.NoError(t, err)
+	perm := types.AccessTypeAnyOfAddresses.With(sdk.MustAccAddressFromBech32(myAddr))
+
+	// a command with mixed check outcomes
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{
+			Use: "mycmd",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				clientCtx := client.Context{}.WithTxConfig(txConfig).WithOffline(true)
+				if err := checkInstantiatePermissionAddresses(cmd, clientCtx, &perm); err != nil {
+					return err
+				}
+				if err := checkAdminExists(cmd, clientCtx, myAddr); err != nil {
+					return err
+				}
+				if err := checkFundsConsistency(cmd.Context(), cmd.ErrOrStderr(), cmd.Flags(), msg.Msg, nil); err != nil {
+					return err
+				}
+				return checkTxSize(cmd.Context(), clientCtx, cmd.Flags(), "split it", msg)
+			},
+		}
+		addSkipAddressCheckFlag(cmd)
+		cmd.Flags().Bool(flagVerifyAdminExists, false, "")
+		addFundsConsistencyFlags(cmd)
+		addMaxTxBytesFlag(cmd)
+		flags.AddTxFlagsToCmd(cmd)
+		parent := &cobra.Command{Use: "tx"}
+		parent.AddCommand(cmd)
+		addPreflightReport(parent)
+		return parent
+	}
+
+	specs := map[string]struct {
+		maxTxBytes int64
+		exp        string
+		expErr     bool
+	}{
+		"command fails": {
+			maxTxBytes: size - 1,
+			exp: `{
+  "version": 1,
+  "command": "tx mycmd",
+  "checks": [
+    {
+      "name": "instantiate_permission_addresses",
+      "status": "skipped",
+      "details": "offline"
+    },
+    {
+      "name": "admin_exists",
+      "status": "skipped",
+      "details": "--verify-admin-exists not set"
+    },
+    {
+      "name": "funds_consistency",
+      "status": "warn",
+      "details": "--amount does not cover the coins in the msg:\n  $.deposit: 100ustake, --amount has 0ustake"
+    },
+    {
+      "name": "tx_size",
+      "status": "fail",
+      "details": "` + txTooLargeError{Size: size, MaxBytes: size - 1, Hint: "split it"}.Error() + `"
+    }
+  ],
+  "error": "` + txTooLargeError{Size: size, MaxBytes: size - 1, Hint: "split it"}.Error() + `"
+}
+`,
+			expErr: true,
+		},
+		"command proceeds": {
+			maxTxBytes: size,
+			exp: `{
+  "version": 1,
+  "command": "tx mycmd",
+  "checks": [
+    {
+      "name": "instantiate_permission_addresses",
+      "status": "skipped",
+      "details": "offline"
+    },
+    {
+      "name": "admin_exists",
+      "status": "skipped",
+      "details": "--verify-admin-exists not set"
+    },
+    {
+      "name": "funds_consistency",
+      "status": "warn",
+      "details": "--amount does not cover the coins in the msg:\n  $.deposit: 100ustake, --amount has 0ustake"
+    },
+    {
+      "name": "tx_size",
+      "status": "pass",
+      "details": "~` + strconv.FormatInt(size, 10) + ` of ` + strconv.FormatInt(size, 10) + ` bytes"
+    }
+  ]
+}
+`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			reportFile := filepath.Join(t.TempDir(), "report.json")
+			cmd := newCmd()
+			cmd.SetArgs([]string{"mycmd", "--check-funds-consistency", "--max-tx-bytes", strconv.FormatInt(spec.maxTxBytes, 10), "--preflight-report", reportFile})
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			gotErr := cmd.Execute()
+			if spec.expErr {
+				require.Error(t, gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			got, err := os.ReadFile(reportFile)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, string(got))
+		})
+	}
+}
+
+func TestRecordPreflight(t *testing.T) {
+	// nothing is recorded without a report
+	recordPreflight(context.Background(), preflightTxSize, preflightPass, "")
+
+	report := &preflightReport{}
+	ctx := context.WithValue(context.Background(), preflightReportKey{}, report)
+	recordPreflight(ctx, preflightTxSize, preflightFail, "first attempt")
+	recordPreflight(ctx, preflightAdminExists, preflightPass, "")
+	// a retried check is replaced
+	recordPreflight(ctx, preflightTxSize, preflightPass, "")
+
+	exp := []preflightCheck{
+		{Name: preflightTxSize, Status: preflightPass},
+		{Name: preflightAdminExists, Status: preflightPass},
+	}
+	assert.Equal(t, exp, report.Checks)
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// proposalSummaryMaxMsgLen is the max length of a contract msg in the proposal summary
+const proposalSummaryMaxMsgLen = 256
+
+// generateOrBroadcastProposal writes a summary of the proposal to stderr when the tx is generated only.
+// The generated tx on stdout is not modified.
+func generateOrBroadcastProposal(cmd *cobra.Command, clientCtx client.Context, proposal *v1.MsgSubmitProposal) error {
+	if clientCtx.GenerateOnly {
+		if err := renderProposalSummary(cmd.ErrOrStderr(), clientCtx.Codec, proposal); err != nil {
+			return err
+		}
+	}
+	return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), proposal)
+}
+
+// renderProposalSummary writes the title and the messages of the proposal in a human-readable form
+func renderProposalSummary(out io.Writer, cdc codec.JSONCodec, proposal *v1.MsgSubmitProposal) error {
+	msgs, err := proposal.GetMsgs()
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "proposal: %s\n", proposal.Title)
+	fmt.Fprintf(&b, "deposit: %s\n", proposal.InitialDeposit)
+	for i, msg := range msgs {
+		fmt.Fprintf(&b, "msg %d: %s\n", i, sdk.MsgTypeURL(msg))
+		lines, err := proposalMsgSummary(cdc, msg)
+		if err != nil {
+			return err
+		}
+		for _, l := range lines {
+			fmt.Fprintf(&b, "  %s: %s\n", l[0], l[1])
+		}
+	}
+	_, err = io.WriteString(out, b.String())
+	return err
+}
+
+// proposalMsgSummary returns the labeled fields of a proposal message. Messages without a summary are
+// shown as JSON.
+func proposalMsgSummary(cdc codec.JSONCodec, msg sdk.Msg) ([][2]string, error) {
+	codeID := func(id uint64) string { return fmt.Sprintf("%d", id) }
+	switch m := msg.(type) {
+	case *types.MsgStoreCode:
+		return append([][2]string{{"authority", m.Sender}}, wasmCodeSummary(m.WASMByteCode)...), nil
+	case *types.MsgInstantiateContract:
+		return [][2]string{{"authority", m.Sender}, {"code id", codeID(m.CodeID)}, {"label", m.Label}, {"admin", m.Admin}, {"funds", m.Funds.String()}, {"msg", contractMsgSummary(m.Msg)}}, nil
+	case *types.MsgInstantiateContract2:
+		return [][2]string{{"authority", m.Sender}, {"code id", codeID(m.CodeID)}, {"label", m.Label}, {"admin", m.Admin}, {"salt", hex.EncodeToString(m.Salt)}, {"funds", m.Funds.String()}, {"msg", contractMsgSummary(m.Msg)}}, nil
+	case *types.MsgStoreAndInstantiateContract:
+		r := append([][2]string{{"authority", m.Authority}}, wasmCodeSummary(m.WASMByteCode)...)
+		return append(r, [][2]string{{"label", m.Label}, {"admin", m.Admin}, {"funds", m.Funds.String()}, {"msg", contractMsgSummary(m.Msg)}}...), nil
+	case *types.MsgMigrateContract:
+		return [][2]string{{"authority", m.Sender}, {"contract", m.Contract}, {"code id", codeID(m.CodeID)}, {"msg", contractMsgSummary(m.Msg)}}, nil
+	case *types.MsgStoreAndMigrateContract:
+		r := append([][2]string{{"authority", m.Authority}, {"contract", m.Contract}}, wasmCodeSummary(m.WASMByteCode)...)
+		return append(r, [2]string{"msg", contractMsgSummary(m.Msg)}), nil
+	case *types.MsgForceMigrateWithoutAdminCheck:
+		return [][2]string{{"authority", m.Authority}, {"contracts", strings.Join(m.Contracts, ", ")}, {"code id", codeID(m.CodeID)}, {"atomic", fmt.Sprintf("%t", m.Atomic)}, {"msg", contractMsgSummary(m.Msg)}}, nil
+	case *types.MsgExecuteContract:
+		return [][2]string{{"authority", m.Sender}, {"contract", m.Contract}, {"funds", m.Funds.String()}, {"msg", contractMsgSummary(m.Msg)}}, nil
+	case *types.MsgSudoContract:
+		return [][2]string{{"authority", m.Authority}, {"contract", m.Contract}, {"msg", contractMsgSummary(m.Msg)}}, nil
+	case *types.MsgUpdateAdmin:
+		return [][2]string{{"authority", m.Sender}, {"contract", m.Contract}, {"new admin", m.NewAdmin}}, nil
+	case *types.MsgClearAdmin:
+		return [][2]string{{"authority", m.Sender}, {"contract", m.Contract}}, nil
+	}
+	bz, err := cdc.MarshalJSON(msg)
+	if err != nil {
+		return nil, err
+	}
+	return [][2]string{{"json", truncateSummary(string(bz))}}, nil
+}
+
+// wasmCodeSummary returns the checksum of the uncompressed wasm code and the size of the code in the message
+func wasmCodeSummary(code []byte) [][2]string {
+	size := fmt.Sprintf("%d bytes", len(code))
+	wasmCode := code
+	if ioutils.IsGzip(code) {
+		size += " (gzip)"
+		var err error
+		if wasmCode, err = ioutils.Uncompress(code, int64(types.MaxProposalWasmSize)); err != nil {
+			return [][2]string{{"checksum", fmt.Sprintf("invalid gzip: %s", err)}, {"size", size}}
+		}
+	}
+	checksum := sha256.Sum256(wasmCode)
+	return [][2]string{{"checksum", hex.EncodeToString(checksum[:])}, {"size", size}}
+}
+
+// contractMsgSummary returns the compact JSON of the contract msg, truncated
+func contractMsgSummary(msg types.RawContractMessage) string {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, msg); err != nil {
+		return truncateSummary(string(msg))
+	}
+	return truncateSummary(buf.String())
+}
+
+func truncateSummary(s string) string {
+	if len(s) <= proposalSummaryMaxMsgLen {
+		return s
+	}
+	return s[:proposalSummaryMaxMsgLen] + "..."
+}
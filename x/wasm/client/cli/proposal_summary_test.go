@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	v1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRenderProposalSummary(t *testing.T) {
+	const (
+		myAuthority = "cosmos10d07y265gmmuvt4z0w9aw880jnsr700j6zn9kn"
+		myContract  = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	)
+	gzippedWasm, err := os.ReadFile("../../keeper/testdata/hackatom.wasm.gzip")
+	require.NoError(t, err)
+	longMsg := `{"data":"` + strings.Repeat("a", 300) + `"}`
+
+	specs := map[string]struct {
+		msgs []sdk.Msg
+		exp  string
+	}{
+		"store": {
+			msgs: []sdk.Msg{
+				&types.MsgStoreCode{Sender: myAuthority, WASMByteCode: gzippedWasm},
+				&types.MsgPinCodes{Authority: myAuthority, CodeIDs: []uint64{1}},
+			},
+			exp: "proposal: my title\n" +
+				"deposit: 100stake\n" +
+				"msg 0: /cosmwasm.wasm.v1.MsgStoreCode\n" +
+				"  authority: " + myAuthority + "\n" +
+				"  checksum: " + testdata.ChecksumHackatom + "\n" +
+				fmt.Sprintf("  size: %d bytes (gzip)\n", len(gzippedWasm)) +
+				"msg 1: /cosmwasm.wasm.v1.MsgPinCodes\n" +
+				`  json: {"authority":"` + myAuthority + `","code_ids":["1"]}` + "\n",
+		},
+		"migrate": {
+			msgs: []sdk.Msg{
+				&types.MsgMigrateContract{Sender: myAuthority, Contract: myContract, CodeID: 7, Msg: []byte(`{ "verifier": "foo" }`)},
+			},
+			exp: "proposal: my title\n" +
+				"deposit: 100stake\n" +
+				"msg 0: /cosmwasm.wasm.v1.MsgMigrateContract\n" +
+				"  authority: " + myAuthority + "\n" +
+				"  contract: " + myContract + "\n" +
+				"  code id: 7\n" +
+				`  msg: {"verifier":"foo"}` + "\n",
+		},
+		"force migrate": {
+			msgs: []sdk.Msg{
+				&types.MsgForceMigrateWithoutAdminCheck{Authority: myAuthority, Contracts: []string{myContract, myAuthority}, CodeID: 7, Msg: []byte(`{}`), Atomic: true},
+			},
+			exp: "proposal: my title\n" +
+				"deposit: 100stake\n" +
+				"msg 0: /cosmwasm.wasm.v1.MsgForceMigrateWithoutAdminCheck\n" +
+				"  authority: " + myAuthority + "\n" +
+				"  contracts: " + myContract + ", " + myAuthority + "\n" +
+				"  code id: 7\n" +
+				"  atomic: true\n" +
+				"  msg: {}\n",
+		},
+		"sudo with truncated msg": {
+			msgs: []sdk.Msg{
+				&types.MsgSudoContract{Authority: myAuthority, Contract: myContract, Msg: []byte(longMsg)},
+			},
+			exp: "proposal: my title\n" +
+				"deposit: 100stake\n" +
+				"msg 0: /cosmwasm.wasm.v1.MsgSudoContract\n" +
+				"  authority: " + myAuthority + "\n" +
+				"  contract: " + myContract + "\n" +
+				"  msg: " + longMsg[:proposalSummaryMaxMsgLen] + "...\n",
+		},
+	}
+	cdc := keeper.MakeEncodingConfig(t).Codec
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			proposal, err := v1.NewMsgSubmitProposal(spec.msgs, sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), myAuthority, "", "my title", "my summary", false)
+			require.NoError(t, err)
+			var out bytes.Buffer
+
+			// when
+			require.NoError(t, renderProposalSummary(&out, cdc, proposal))
+
+			// then
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	"github.com/spf13/cobra"
@@ -17,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -36,15 +38,37 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdListContractByCode(),
 		GetCmdQueryCode(),
 		GetCmdQueryCodeInfo(),
+		GetCmdQueryCodeInfos(),
+		GetCmdQueryCodeIDByChecksum(),
+		GetCmdCodeManifest(),
+		GetCmdListContractsByChecksum(),
+		GetCmdListContractsByLabel(),
 		GetCmdGetContractInfo(),
+		GetCmdGetContractInfoAt(),
 		GetCmdGetContractHistory(),
 		GetCmdGetContractState(),
 		GetCmdListPinnedCode(),
 		GetCmdLibVersion(),
 		GetCmdQueryParams(),
+		GetCmdQueryUploadQuota(),
+		GetCmdQueryCanUpload(),
+		GetCmdQueryFeelessExecutions(),
+		GetCmdQueryContractGasBudgets(),
+		GetCmdQueryFootprint(),
+		GetCmdQueryRecentExecutions(),
+		GetCmdQueryModuleActivity(),
+		GetCmdQueryTopGasConsumers(),
+		GetCmdQueryDelegations(),
 		GetCmdBuildAddress(),
+		GetCmdCheckInstantiate2Address(),
+		GetCmdMakeSalt(),
 		GetCmdListContractsByCreator(),
+		GetCmdDump(),
+		GetCmdContractTxs(),
+		GetCmdCallGraph(),
+		GetCmdSubscribe(),
 	)
+	addNodeFallback(queryCmd)
 	return queryCmd
 }
 
@@ -71,13 +95,24 @@ func GetCmdLibVersion() *cobra.Command {
 
 // GetCmdBuildAddress build a contract address
 func GetCmdBuildAddress() *cobra.Command {
-	decoder := newArgDecoder(hex.DecodeString)
+	decoder := newArgDecoder(hexDecodeString)
 	cmd := &cobra.Command{
-		Use:     "build-address [code-hash] [creator-address] [salt-hex-encoded] [json_encoded_init_args (required when set as fixed)]",
-		Short:   "build contract address",
+		Use:   "build-address [code-hash] [creator-address] [salt-hex-encoded] [json_encoded_init_args (required when set as fixed)]",
+		Short: "build contract address",
+		Long:  "Build the predictable address of a contract. The code hash and the salt are hex encoded with an optional 0x prefix.",
+		Example: fmt.Sprintf("$ %s query wasm build-address 0x13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5 [creator-address] 0x0102\n"+
+			"$ %s query wasm build-address 13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5 [creator-address] testing --ascii", version.AppName, version.AppName),
 		Aliases: []string{"address"},
 		Args:    cobra.RangeArgs(3, 4),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			codeHash, err := hexDecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid code hash: %w", err)
+			}
+			salt, err := decoder.DecodeString(args[2])
+			if err != nil {
+				return err
+			}
 			var initArgs []byte
 			if len(args) == 4 {
 				initArgs = types.RawContractMessage(args[3])
@@ -85,17 +120,17 @@ func GetCmdBuildAddress() *cobra.Command {
 
 			res, err := keeper.BuildAddressPredictable(
 				&types.QueryBuildAddressRequest{
-					CodeHash:       args[0],
+					CodeHash:       hex.EncodeToString(codeHash),
 					CreatorAddress: args[1],
-					Salt:           args[2],
+					Salt:           hex.EncodeToString(salt),
 					InitArgs:       initArgs,
 				},
 			)
 			if err != nil {
 				return err
 			}
-			fmt.Println(res.Address)
-			return nil
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), res.Address)
+			return err
 		},
 		SilenceUsage: true,
 	}
@@ -103,6 +138,70 @@ func GetCmdBuildAddress() *cobra.Command {
 	return cmd
 }
 
+// GetCmdCheckInstantiate2Address queries the predictable address of an instantiate2 call and how it is used already
+func GetCmdCheckInstantiate2Address() *cobra.Command {
+	decoder := newArgDecoder(hexDecodeString)
+	cmd := &cobra.Command{
+		Use:   "check-address2 [code_id_int64] [creator-address] [salt] [json_encoded_init_args,optional with --fix-msg]",
+		Short: "Query the predictable address of an instantiate2 call and whether it is in use",
+		Long: `Query the predictable address of an instantiate2 call and whether an account or a contract exists with this
+address or it holds funds already. An existing contract makes the instantiation fail. Existing accounts and balances
+are kept or pruned depending on the account type. The salt is hex encoded by default.`,
+		Example: fmt.Sprintf("$ %s query wasm check-address2 1 [creator-address] 0x0102\n"+
+			"$ %s query wasm check-address2 1 [creator-address] testing '{\"foo\":\"bar\"}' --ascii --fix-msg", version.AppName, version.AppName),
+		Aliases: []string{"check-address"},
+		Args:    cobra.RangeArgs(3, 4),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("code id: %s", err)
+			}
+			if _, err := sdk.AccAddressFromBech32(args[1]); err != nil {
+				return fmt.Errorf("creator: %s", err)
+			}
+			salt, err := decoder.DecodeString(args[2])
+			if err != nil {
+				return err
+			}
+			fixMsg, err := cmd.Flags().GetBool(flagFixMsg)
+			if err != nil {
+				return fmt.Errorf("fix msg: %s", err)
+			}
+			var initMsg types.RawContractMessage
+			switch {
+			case len(args) == 4 && !fixMsg:
+				return fmt.Errorf("init args are used with --%s only", flagFixMsg)
+			case len(args) == 4:
+				initMsg = types.RawContractMessage(args[3])
+			case fixMsg:
+				return fmt.Errorf("init args required with --%s", flagFixMsg)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CheckInstantiate2Address(cmd.Context(), &types.QueryCheckInstantiate2AddressRequest{
+				Creator: args[1],
+				CodeId:  codeID,
+				Salt:    salt,
+				FixMsg:  fixMsg,
+				Msg:     initMsg,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagFixMsg, false, "Include the json_encoded_init_args in the predictable address generation")
+	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdListCode lists all wasm code uploaded
 func GetCmdListCode() *cobra.Command {
 	cmd := &cobra.Command{
@@ -121,11 +220,37 @@ func GetCmdListCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			sortByUsage, err := cmd.Flags().GetBool(flagSortByUsage)
+			if err != nil {
+				return err
+			}
+			includeDeprecated, err := cmd.Flags().GetBool(flagIncludeDeprecated)
+			if err != nil {
+				return err
+			}
+			if sortByUsage && !includeDeprecated {
+				return fmt.Errorf("--%s can not be combined with --%s=false", flagSortByUsage, flagIncludeDeprecated)
+			}
 			queryClient := types.NewQueryClient(clientCtx)
+			if sortByUsage {
+				// most used codes first, unless the order is reversed
+				pageReq.Reverse = !pageReq.Reverse
+				res, err := queryClient.CodesByUsage(
+					context.Background(),
+					&types.QueryCodesByUsageRequest{
+						Pagination: pageReq,
+					},
+				)
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintProto(res)
+			}
 			res, err := queryClient.Codes(
 				context.Background(),
 				&types.QueryCodesRequest{
-					Pagination: pageReq,
+					Pagination:        pageReq,
+					ExcludeDeprecated: !includeDeprecated,
 				},
 			)
 			if err != nil {
@@ -137,6 +262,8 @@ func GetCmdListCode() *cobra.Command {
 	}
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "list codes")
+	cmd.Flags().Bool(flagSortByUsage, false, "Sort codes by number of instantiations, most used first")
+	cmd.Flags().Bool(flagIncludeDeprecated, true, "List deprecated codes that can not be instantiated anymore")
 	return cmd
 }
 
@@ -166,21 +293,53 @@ func GetCmdListContractByCode() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			admin, err := cmd.Flags().GetString(flagAdmin)
+			if err != nil {
+				return err
+			}
+			creator, err := cmd.Flags().GetString(flagCreator)
+			if err != nil {
+				return err
+			}
+			withCodeInfo, err := cmd.Flags().GetBool(flagWithCodeInfo)
+			if err != nil {
+				return err
+			}
+			inactiveSince, err := cmd.Flags().GetUint64(flagInactiveSinceHeight)
+			if err != nil {
+				return err
+			}
+			_, details, err := readContractListFilter(cmd, false)
+			if err != nil {
+				return err
+			}
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.ContractsByCode(
 				context.Background(),
 				&types.QueryContractsByCodeRequest{
-					CodeId:     codeID,
-					Pagination: pageReq,
+					CodeId:              codeID,
+					Pagination:          pageReq,
+					Admin:               admin,
+					Creator:             creator,
+					IncludeCodeInfo:     withCodeInfo,
+					InactiveSinceHeight: inactiveSince,
 				},
 			)
 			if err != nil {
 				return err
 			}
+			if details {
+				return printContractListing(cmd, clientCtx, res.Contracts, res.Pagination, contractListFilter{})
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
+	addContractListingFlags(cmd, false)
+	cmd.Flags().String(flagAdmin, "", "Only list contracts with this admin address")
+	cmd.Flags().String(flagCreator, "", "Only list contracts with this creator address")
+	cmd.Flags().Bool(flagWithCodeInfo, false, "Include the code's instantiate permission, creator and checksum")
+	cmd.Flags().Uint64(flagInactiveSinceHeight, 0, "Only list contracts without a recorded execution at or after this height. Requires contract activity tracking. Contracts without any recorded execution are listed, too")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "list contracts by code")
 	return cmd
@@ -265,6 +424,48 @@ func GetCmdQueryCodeInfo() *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryCodeInfos gets the metadata of multiple code ids in one call
+func GetCmdQueryCodeInfos() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "code-infos [code_id,...]",
+		Short:   "Prints out metadata of multiple code ids",
+		Long:    "Prints out metadata of multiple code ids in the given order. Unknown code ids are marked as not found.",
+		Example: fmt.Sprintf("$ %s query wasm code-infos 1,2,7,9", version.AppName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			var codeIDs []uint64
+			for _, v := range strings.Split(args[0], ",") {
+				codeID, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+				if err != nil {
+					return fmt.Errorf("code id %q: %w", v, err)
+				}
+				codeIDs = append(codeIDs, codeID)
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeInfos(
+				context.Background(),
+				&types.QueryCodeInfosRequest{
+					CodeIDs: codeIDs,
+				},
+			)
+			if err != nil {
+				return err
+			}
+
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetContractInfo gets details about a given contract
 func GetCmdGetContractInfo() *cobra.Command {
 	cmd := &cobra.Command{
@@ -301,6 +502,47 @@ func GetCmdGetContractInfo() *cobra.Command {
 	return cmd
 }
 
+// GetCmdGetContractInfoAt gets details about a given contract at a block height
+func GetCmdGetContractInfoAt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-info-at [bech32_address] [height]",
+		Short: "Prints out metadata of a contract at the given block height",
+		Long: "Prints out metadata of a contract at the given block height. The info is reconstructed from the contract history, " +
+			"admin and label changes are only included when recorded by the chain",
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			height, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("height: %s", err)
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractInfoAt(
+				context.Background(),
+				&types.QueryContractInfoAtRequest{
+					Address: args[0],
+					Height:  height,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetContractState dumps full internal state of a given contract
 func GetCmdGetContractState() *cobra.Command {
 	cmd := &cobra.Command{
@@ -314,8 +556,10 @@ func GetCmdGetContractState() *cobra.Command {
 	}
 	cmd.AddCommand(
 		GetCmdGetContractStateAll(),
+		GetCmdGetContractStateKeys(),
 		GetCmdGetContractStateRaw(),
 		GetCmdGetContractStateSmart(),
+		GetCmdGetContractStateAccess(),
 	)
 	return cmd
 }
@@ -324,8 +568,11 @@ func GetCmdGetContractStateAll() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "all [bech32_address]",
 		Short: "Prints out all internal state of a contract given its address",
-		Long:  "Prints out all internal state of a contract given its address",
-		Args:  cobra.ExactArgs(1),
+		Long: `Prints out all internal state of a contract given its address.
+With --out, --progress or --resume-from all pages are streamed as NDJSON at the same height. --out records
+the height and the next pagination key in a checkpoint file after each page so that an interrupted dump
+continues with --resume-from. Without --out, resume with the printed --progress key and --height.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
@@ -336,6 +583,9 @@ func GetCmdGetContractStateAll() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if isStateDump(cmd) {
+				return runContractStateDump(cmd, clientCtx, args[0])
+			}
 
 			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
 			if err != nil {
@@ -358,16 +608,80 @@ func GetCmdGetContractStateAll() *cobra.Command {
 	}
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "contract state")
+	addStateDumpFlags(cmd)
+	return cmd
+}
+
+func GetCmdGetContractStateKeys() *cobra.Command {
+	decoder := newArgDecoder(hexDecodeString)
+	cmd := &cobra.Command{
+		Use:   "keys [bech32_address]",
+		Short: "Prints out all internal state keys of a contract given its address",
+		Long:  "Prints out all internal state keys of a contract given its address. The values are not returned.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			rawPrefix, err := cmd.Flags().GetString(flagPrefix)
+			if err != nil {
+				return err
+			}
+			var keyPrefix []byte
+			if rawPrefix != "" {
+				if keyPrefix, err = decoder.DecodeString(rawPrefix); err != nil {
+					return err
+				}
+			}
+
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractStateKeys(
+				context.Background(),
+				&types.QueryContractStateKeysRequest{
+					Address:    args[0],
+					Prefix:     keyPrefix,
+					Pagination: pageReq,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagPrefix, "", "Only list keys starting with this prefix")
+	decoder.RegisterFlags(cmd.PersistentFlags(), "prefix")
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "contract state keys")
 	return cmd
 }
 
 func GetCmdGetContractStateRaw() *cobra.Command {
-	decoder := newArgDecoder(hex.DecodeString)
+	decoder := newArgDecoder(hexDecodeString)
 	cmd := &cobra.Command{
-		Use:   "raw [bech32_address] [key]",
+		Use:   "raw [bech32_address] [key,optional with --namespace or --key-segments]",
 		Short: "Prints out internal state for key of a contract given its address",
-		Long:  "Prints out internal state for of a contract given its address",
-		Args:  cobra.ExactArgs(2),
+		Long: fmt.Sprintf(`Prints out internal state for of a contract given its address.
+The key can be built from cw-storage-plus namespaces and key segments instead of the key argument.
+Segments can have an encoding hint: str:, addr:, u64:, u32: or hex:. The built key is printed to stderr.
+
+Item:
+$ %s query wasm contract-state raw [address] --namespace config
+Map<(Addr,u64), T>:
+$ %s query wasm contract-state raw [address] --namespace allowances --key-segments addr:[address],u64:5
+`, version.AppName, version.AppName),
+		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
 			if err != nil {
@@ -378,10 +692,13 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			queryData, err := decoder.DecodeString(args[1])
+			queryData, err := parseRawStateKey(args[1:], decoder, cmd.Flags())
 			if err != nil {
 				return err
 			}
+			if len(args) == 1 {
+				_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "key: %s\n", hex.EncodeToString(queryData))
+			}
 
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.RawContractState(
@@ -399,10 +716,109 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "key argument")
+	cmd.Flags().String(flagNamespace, "", "Namespace of a cw-storage-plus Item or Map")
+	cmd.Flags().StringSlice(flagKeySegments, nil, "Comma separated cw-storage-plus Map key segments")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdGetContractStateAccess prints whether the raw state of a contract can be queried
+func GetCmdGetContractStateAccess() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "access [bech32_address]",
+		Short: "Prints out whether the raw state of a contract can be queried",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			_, err = sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractStateAccess(
+				context.Background(),
+				&types.QueryContractStateAccessRequest{
+					Address: args[0],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// GetCmdQueryDelegations prints the wasm authorizations and the fee allowance a granter has given to a grantee
+func GetCmdQueryDelegations() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegations [granter] [grantee]",
+		Short: "Prints out the wasm authorizations and the fee allowance a granter has given to a grantee",
+		Long: `Prints out the unexpired authz grants for wasm messages of the granter for the grantee with their
+contracts and codes, and the fee allowance of the granter for the grantee.
+The fee allowance is not supported when the chain has no feegrant module.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return fmt.Errorf("granter: %s", err)
+			}
+			if _, err := sdk.AccAddressFromBech32(args[1]); err != nil {
+				return fmt.Errorf("grantee: %s", err)
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.DelegatedCapabilities(
+				context.Background(),
+				&types.QueryDelegatedCapabilitiesRequest{
+					Granter: args[0],
+					Grantee: args[1],
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseRawStateKey returns the key from the optional argument or builds it from the namespace and key segments
+func parseRawStateKey(args []string, decoder *argumentDecoder, flags *flag.FlagSet) ([]byte, error) {
+	namespace, err := flags.GetString(flagNamespace)
+	if err != nil {
+		return nil, err
+	}
+	segments, err := flags.GetStringSlice(flagKeySegments)
+	if err != nil {
+		return nil, err
+	}
+	built := namespace != "" || len(segments) != 0
+	switch {
+	case built && len(args) != 0:
+		return nil, errors.New("key argument can not be used with --namespace or --key-segments")
+	case built:
+		return buildStorageKey(namespace, segments)
+	case len(args) == 0:
+		return nil, errors.New("key argument or --namespace required")
+	default:
+		return decoder.DecodeString(args[0])
+	}
+}
+
 func GetCmdGetContractStateSmart() *cobra.Command {
 	decoder := newArgDecoder(asciiDecodeString)
 	cmd := &cobra.Command{
@@ -426,7 +842,7 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 
 			queryData, err := decoder.DecodeString(args[1])
 			if err != nil {
-				return fmt.Errorf("decode query: %s", err)
+				return err
 			}
 			if !json.Valid(queryData) {
 				return errors.New("query data must be json")
@@ -471,32 +887,89 @@ func GetCmdGetContractHistory() *cobra.Command {
 				return err
 			}
 
+			rawOperation, err := cmd.Flags().GetString(flagOperation)
+			if err != nil {
+				return err
+			}
+			operation, err := parseHistoryOperation(rawOperation)
+			if err != nil {
+				return err
+			}
+			sinceHeight, err := cmd.Flags().GetUint64(flagSince)
+			if err != nil {
+				return err
+			}
+
+			countOnly, err := cmd.Flags().GetBool(flagCountOnly)
+			if err != nil {
+				return err
+			}
+
 			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
 			if err != nil {
 				return err
 			}
+			if countOnly {
+				if len(pageReq.Key) != 0 {
+					return errors.New("--count-only can not be combined with --page-key")
+				}
+				// the total is only returned for offset based pagination
+				pageReq.Offset, pageReq.Limit, pageReq.CountTotal = 0, 1, true
+			}
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.ContractHistory(
 				context.Background(),
 				&types.QueryContractHistoryRequest{
-					Address:    args[0],
-					Pagination: pageReq,
+					Address:     args[0],
+					Pagination:  pageReq,
+					Operation:   operation,
+					SinceHeight: sinceHeight,
 				},
 			)
 			if err != nil {
 				return err
 			}
+			if countOnly {
+				var total uint64
+				if res.Pagination != nil {
+					total = res.Pagination.Total
+				}
+				return clientCtx.PrintString(fmt.Sprintf("%d\n", total))
+			}
 
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
 
+	cmd.Flags().String(flagOperation, "", "Only list entries of this operation type: init, migrate, genesis, admin-changed or label-changed")
+	cmd.Flags().Uint64(flagSince, 0, "Only list entries updated at or after this block height")
+	cmd.Flags().Bool(flagCountOnly, false, "Print the number of matching entries only, to find contracts with a long history")
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "contract history")
 	return cmd
 }
 
+// parseHistoryOperation maps the operation flag value to the history operation type
+func parseHistoryOperation(s string) (types.ContractCodeHistoryOperationType, error) {
+	switch s {
+	case "":
+		return types.ContractCodeHistoryOperationTypeUnspecified, nil
+	case "init":
+		return types.ContractCodeHistoryOperationTypeInit, nil
+	case "migrate":
+		return types.ContractCodeHistoryOperationTypeMigrate, nil
+	case "genesis":
+		return types.ContractCodeHistoryOperationTypeGenesis, nil
+	case "admin-changed":
+		return types.ContractCodeHistoryOperationTypeAdminChanged, nil
+	case "label-changed":
+		return types.ContractCodeHistoryOperationTypeLabelChanged, nil
+	default:
+		return types.ContractCodeHistoryOperationTypeUnspecified, fmt.Errorf("unsupported operation %q: use init, migrate, genesis, admin-changed or label-changed", s)
+	}
+}
+
 // GetCmdListPinnedCode lists all wasm code ids that are pinned
 func GetCmdListPinnedCode() *cobra.Command {
 	cmd := &cobra.Command{
@@ -554,6 +1027,11 @@ func GetCmdListContractsByCreator() *cobra.Command {
 				return err
 			}
 
+			filter, details, err := readContractListFilter(cmd, true)
+			if err != nil {
+				return err
+			}
+
 			queryClient := types.NewQueryClient(clientCtx)
 			res, err := queryClient.ContractsByCreator(
 				context.Background(),
@@ -565,10 +1043,14 @@ func GetCmdListContractsByCreator() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if details {
+				return printContractListing(cmd, clientCtx, res.ContractAddresses, res.Pagination, filter)
+			}
 			return clientCtx.PrintProto(res)
 		},
 		SilenceUsage: true,
 	}
+	addContractListingFlags(cmd, true)
 	flags.AddQueryFlagsToCmd(cmd)
 	addPaginationFlags(cmd, "list contracts by creator")
 	return cmd
@@ -578,6 +1060,8 @@ type argumentDecoder struct {
 	// dec is the default decoder
 	dec                func(string) ([]byte, error)
 	asciiF, hexF, b64F bool
+	// argName is used in decoding errors
+	argName string
 }
 
 func newArgDecoder(def func(string) ([]byte, error)) *argumentDecoder {
@@ -585,12 +1069,22 @@ func newArgDecoder(def func(string) ([]byte, error)) *argumentDecoder {
 }
 
 func (a *argumentDecoder) RegisterFlags(f *flag.FlagSet, argName string) {
+	a.argName = argName
 	f.BoolVar(&a.asciiF, "ascii", false, "ascii encoded "+argName)
 	f.BoolVar(&a.hexF, "hex", false, "hex encoded "+argName)
 	f.BoolVar(&a.b64F, "b64", false, "base64 encoded "+argName)
 }
 
+// DecodeString decodes the argument with the encoding of the flag set or the default decoder. Errors name the argument.
 func (a *argumentDecoder) DecodeString(s string) ([]byte, error) {
+	bz, err := a.decodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", a.argName, err)
+	}
+	return bz, nil
+}
+
+func (a *argumentDecoder) decodeString(s string) ([]byte, error) {
 	found := -1
 	for i, v := range []*bool{&a.asciiF, &a.hexF, &a.b64F} {
 		if !*v {
@@ -605,7 +1099,7 @@ func (a *argumentDecoder) DecodeString(s string) ([]byte, error) {
 	case 0:
 		return asciiDecodeString(s)
 	case 1:
-		return hex.DecodeString(s)
+		return hexDecodeString(s)
 	case 2:
 		return base64.StdEncoding.DecodeString(s)
 	default:
@@ -617,6 +1111,21 @@ func asciiDecodeString(s string) ([]byte, error) {
 	return []byte(s), nil
 }
 
+// hexDecodeString decodes a hex string with an optional 0x prefix
+func hexDecodeString(s string) ([]byte, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = s[2:]
+	}
+	if len(s)%2 != 0 {
+		return nil, fmt.Errorf("odd length hex string with %d characters", len(s))
+	}
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("not a hex string: %s", err)
+	}
+	return bz, nil
+}
+
 // sdk ReadPageRequest expects binary but we encoded to base64 in our marshaller
 func withPageKeyDecoded(flagSet *flag.FlagSet) *flag.FlagSet {
 	encoded, err := flagSet.GetString(flags.FlagPageKey)
@@ -664,6 +1173,267 @@ func GetCmdQueryParams() *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryUploadQuota gets the code upload deposit and quota for an account
+func GetCmdQueryUploadQuota() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upload-quota [address]",
+		Short: "Query the code upload deposit and quota for an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.UploadQuota(cmd.Context(), &types.QueryUploadQuotaRequest{Address: args[0]})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryCodeIDByChecksum resolves the code ids stored with a checksum
+func GetCmdQueryCodeIDByChecksum() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "code-id-by-checksum [checksum]",
+		Short:   "Query the code ids for a hex encoded checksum",
+		Long:    "Query the code ids for a hex encoded checksum. The lowest code id is returned as code_id.",
+		Example: fmt.Sprintf("$ %s query wasm code-id-by-checksum 0x13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5", version.AppName),
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			checksum, err := hexDecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid checksum: %w", err)
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeIdByChecksum(cmd.Context(), &types.QueryCodeIdByChecksumRequest{Checksum: hex.EncodeToString(checksum)})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdListContractsByChecksum lists the contracts of all code ids stored with a checksum
+func GetCmdListContractsByChecksum() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contracts-by-checksum [checksum]",
+		Short: "List the contracts of all code ids stored with a hex encoded checksum",
+		Long: "List the contracts of all code ids stored with a hex encoded checksum. " +
+			"The contracts are ordered by code id and then by their creation.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			checksum, err := hexDecodeString(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid checksum: %w", err)
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			filter, details, err := readContractListFilter(cmd, true)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByChecksum(cmd.Context(), &types.QueryContractsByChecksumRequest{
+				Checksum:   hex.EncodeToString(checksum),
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			if details {
+				return printContractListing(cmd, clientCtx, res.Contracts, res.Pagination, filter)
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	addContractListingFlags(cmd, true)
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by checksum")
+	return cmd
+}
+
+// GetCmdListContractsByLabel lists the contracts with exactly the given label
+func GetCmdListContractsByLabel() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "by-label [label]",
+		Short:   "List all contracts with exactly the given label",
+		Long:    "List all contracts with exactly the given label. The label is case sensitive and not matched as a prefix. The chain must enable the index_contract_labels param.",
+		Aliases: []string{"contracts-by-label"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if args[0] == "" {
+				return errors.New("empty label")
+			}
+			pageReq, err := client.ReadPageRequest(withPageKeyDecoded(cmd.Flags()))
+			if err != nil {
+				return err
+			}
+			filter, details, err := readContractListFilter(cmd, true)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractsByLabel(cmd.Context(), &types.QueryContractsByLabelRequest{
+				Label:      args[0],
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+			if details {
+				return printContractListing(cmd, clientCtx, res.Contracts, res.Pagination, filter)
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	addContractListingFlags(cmd, true)
+	flags.AddQueryFlagsToCmd(cmd)
+	addPaginationFlags(cmd, "list contracts by label")
+	return cmd
+}
+
+// GetCmdQueryCanUpload gets the upload permission and default instantiate config for an account
+func GetCmdQueryCanUpload() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "can-upload [address]",
+		Short: "Query if an account can upload code and the instantiate config applied without permission flags",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.EffectiveInstantiatePermission(cmd.Context(), &types.QueryEffectiveInstantiatePermissionRequest{Sender: args[0]})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryFeelessExecutions gets the allow-list of contract executions that can be sent without fees
+func GetCmdQueryFeelessExecutions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feeless-executions",
+		Short: "Query the contract executions that can be sent without fees and the max gas of a fee-less tx",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.FeelessExecutions(cmd.Context(), &types.QueryFeelessExecutionsRequest{})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(&res.FeelessExecutions)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryContractGasBudgets gets the per block execution gas budgets of contracts
+func GetCmdQueryContractGasBudgets() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "contract-gas-budgets",
+		Short: "Query the per block execution gas budgets of contracts",
+		Long:  "Query the per block execution gas budgets of contracts. Contracts without a budget are unlimited.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.ContractGasBudgets(cmd.Context(), &types.QueryContractGasBudgetsRequest{})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// GetCmdQueryRecentExecutions gets the last executions of a contract recorded by the node
+func GetCmdQueryRecentExecutions() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "recent-exec [bech32_address]",
+		Short:   "Query the last executions of a contract recorded by the node",
+		Long:    "Query the last executions of a contract recorded by the node, newest first. The receipts are node local and only available when enabled with execution_receipts_limit in the node's app.toml.",
+		Aliases: []string{"recent-executions"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetUint32(flags.FlagLimit)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.RecentExecutions(cmd.Context(), &types.QueryRecentExecutionsRequest{
+				Address: args[0],
+				Limit:   limit,
+			})
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint32(flags.FlagLimit, 0, "Max number of executions to return. 0 returns all executions kept by the node")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // supports a subset of the SDK pagination params for better resource utilization
 func addPaginationFlags(cmd *cobra.Command, query string) {
 	cmd.Flags().String(flags.FlagPageKey, "", fmt.Sprintf("pagination page-key of %s to query for", query))
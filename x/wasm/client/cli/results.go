@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagWait = "wait"
+
+	// attributeKeyMsgIndex is the event attribute with the tx message index set by the sdk
+	attributeKeyMsgIndex = "msg_index"
+
+	// waitTxTimeout is the max duration to wait for a tx to be included in a block
+	waitTxTimeout = time.Minute
+	// waitTxPollInterval is the duration between two tx queries
+	waitTxPollInterval = time.Second
+)
+
+// ExecuteResult is the response of a MsgExecuteContract within a tx
+type ExecuteResult struct {
+	// MsgIndex is the position of the execute message in the tx
+	MsgIndex int `json:"msg_index"`
+	// Contract is the address of the executed contract
+	Contract string `json:"contract"`
+	// Data is the binary data returned by the contract
+	Data []byte `json:"data"`
+}
+
+// ParseExecuteResults returns the results of all execute messages in a tx ordered by message index.
+// The msg responses share the index with the tx messages so that each execute message is matched
+// with its own response data.
+func ParseExecuteResults(res *sdk.TxResponse) ([]ExecuteResult, error) {
+	bz, err := hex.DecodeString(res.Data)
+	if err != nil {
+		return nil, fmt.Errorf("decode tx data: %w", err)
+	}
+	var msgData sdk.TxMsgData
+	if err := msgData.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("decode tx msg data: %w", err)
+	}
+	contracts := executedContracts(res.Events)
+	executeResponseTypeURL := sdk.MsgTypeURL(&types.MsgExecuteContractResponse{})
+	executeCompatResponseTypeURL := sdk.MsgTypeURL(&types.MsgExecuteContractCompatResponse{})
+	var results []ExecuteResult
+	for i, r := range msgData.MsgResponses {
+		if r == nil {
+			continue
+		}
+		var data []byte
+		switch r.TypeUrl {
+		case executeResponseTypeURL:
+			var rsp types.MsgExecuteContractResponse
+			if err := rsp.Unmarshal(r.Value); err != nil {
+				return nil, fmt.Errorf("decode execute response %d: %w", i, err)
+			}
+			data = rsp.Data
+		case executeCompatResponseTypeURL:
+			var rsp types.MsgExecuteContractCompatResponse
+			if err := rsp.Unmarshal(r.Value); err != nil {
+				return nil, fmt.Errorf("decode execute response %d: %w", i, err)
+			}
+			data = rsp.Data
+		default:
+			continue
+		}
+		results = append(results, ExecuteResult{MsgIndex: i, Contract: contracts[i], Data: data})
+	}
+	return results, nil
+}
+
+// executedContracts returns the contract address of the first execute event for each msg index.
+// Executions of sub-messages are emitted after the top level one and are ignored.
+func executedContracts(events []abci.Event) map[int]string {
+	r := make(map[int]string)
+	for _, e := range events {
+		if e.Type != types.EventTypeExecute {
+			continue
+		}
+		var contract string
+		msgIndex := -1
+		for _, a := range e.Attributes {
+			switch a.Key {
+			case types.AttributeKeyContractAddr:
+				contract = a.Value
+			case attributeKeyMsgIndex:
+				if i, err := strconv.Atoi(a.Value); err == nil {
+					msgIndex = i
+				}
+			}
+		}
+		if _, exists := r[msgIndex]; msgIndex >= 0 && !exists {
+			r[msgIndex] = contract
+		}
+	}
+	return r
+}
+
+// printExecuteResults writes the data of each result as base64 and additionally pretty prints it
+// when the data is valid JSON
+func printExecuteResults(w io.Writer, results []ExecuteResult) error {
+	for _, r := range results {
+		if _, err := fmt.Fprintf(w, "execute result of msg %d for contract %s\ndata: %s\n",
+			r.MsgIndex, r.Contract, base64.StdEncoding.EncodeToString(r.Data)); err != nil {
+			return err
+		}
+		if len(r.Data) == 0 || !json.Valid(r.Data) {
+			continue
+		}
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, r.Data, "", "  "); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", pretty.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// broadcastAndPrintExecuteResults broadcasts the tx like GenerateOrBroadcastTxCLI, waits for the
+// tx to be included in a block and prints the execute results. The output format is JSON.
+func broadcastAndPrintExecuteResults(clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) error {
+	if clientCtx.GenerateOnly || clientCtx.Simulate || clientCtx.Offline {
+		return errors.New("wait can not be combined with generate only, dry run or offline mode")
+	}
+	if isGasPreview(flagSet) {
+		return errors.New("wait can not be combined with gas preview")
+	}
+	var out io.Writer = os.Stdout
+	if clientCtx.Output != nil {
+		out = clientCtx.Output
+	}
+	var captured bytes.Buffer
+	broadcastCtx := clientCtx.WithOutputFormat(flags.OutputFormatJSON).WithOutput(io.MultiWriter(out, &captured))
+	if err := generateOrBroadcastTxCLI(broadcastCtx, flagSet, msgs...); err != nil {
+		return err
+	}
+	// the broadcast response is the last JSON document. It may follow the tx printed for confirmation.
+	var last json.RawMessage
+	for dec := json.NewDecoder(&captured); ; {
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("decode broadcast response: %w", err)
+		}
+		last = v
+	}
+	var broadcastRsp struct {
+		TxHash    string `json:"txhash"`
+		Codespace string `json:"codespace"`
+		Code      uint32 `json:"code"`
+		RawLog    string `json:"raw_log"`
+	}
+	if len(last) != 0 {
+		if err := json.Unmarshal(last, &broadcastRsp); err != nil {
+			return fmt.Errorf("decode broadcast response: %w", err)
+		}
+	}
+	switch {
+	case broadcastRsp.TxHash == "": // canceled
+		return nil
+	case broadcastRsp.Code != 0:
+		return txResultError(broadcastRsp.Codespace, broadcastRsp.Code, broadcastRsp.RawLog)
+	}
+
+	res, err := waitForTx(clientCtx, broadcastRsp.TxHash)
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return txResultError(res.Codespace, res.Code, res.RawLog)
+	}
+	results, err := ParseExecuteResults(res)
+	if err != nil {
+		return err
+	}
+	return printExecuteResults(out, results)
+}
+
+// waitForTx polls for the tx until it is included in a block or the timeout is reached
+func waitForTx(clientCtx client.Context, txHash string) (*sdk.TxResponse, error) {
+	deadline := time.Now().Add(waitTxTimeout)
+	for {
+		res, err := authtx.QueryTx(clientCtx, txHash)
+		if err == nil {
+			return res, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("tx %s not included within %s: %w", txHash, waitTxTimeout, err)
+		}
+		time.Sleep(waitTxPollInterval)
+	}
+}
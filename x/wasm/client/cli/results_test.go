@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestParseExecuteResults(t *testing.T) {
+	contractA := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	contractB := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+	subContract := sdk.AccAddress(bytes.Repeat([]byte{3}, 32)).String()
+
+	specs := map[string]struct {
+		src        *sdk.TxResponse
+		expResults []ExecuteResult
+		expErr     bool
+	}{
+		"two execute results": {
+			src: txResponseFixture(t,
+				[]*codectypes.Any{
+					mustAny(t, &types.MsgExecuteContractResponse{Data: []byte(`{"count":1}`)}),
+					mustAny(t, &banktypes.MsgSendResponse{}),
+					mustAny(t, &types.MsgExecuteContractResponse{Data: []byte{0x1, 0x2}}),
+				},
+				executeEvent(contractA, 0),
+				executeEvent(subContract, 0),
+				executeEvent(contractB, 2),
+			),
+			expResults: []ExecuteResult{
+				{MsgIndex: 0, Contract: contractA, Data: []byte(`{"count":1}`)},
+				{MsgIndex: 2, Contract: contractB, Data: []byte{0x1, 0x2}},
+			},
+		},
+		"compat execute result": {
+			src: txResponseFixture(t,
+				[]*codectypes.Any{mustAny(t, &types.MsgExecuteContractCompatResponse{Data: []byte(`{"count":1}`)})},
+				executeEvent(contractA, 0),
+			),
+			expResults: []ExecuteResult{{MsgIndex: 0, Contract: contractA, Data: []byte(`{"count":1}`)}},
+		},
+		"no execute results": {
+			src: txResponseFixture(t, []*codectypes.Any{mustAny(t, &banktypes.MsgSendResponse{})}),
+		},
+		"invalid data": {
+			src:    &sdk.TxResponse{Data: "not hex"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseExecuteResults(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expResults, got)
+		})
+	}
+}
+
+func TestPrintExecuteResults(t *testing.T) {
+	var out bytes.Buffer
+	err := printExecuteResults(&out, []ExecuteResult{
+		{MsgIndex: 0, Contract: "contractA", Data: []byte(`{"count":1}`)},
+		{MsgIndex: 2, Contract: "contractB", Data: []byte{0x1, 0x2}},
+		{MsgIndex: 3, Contract: "contractC"},
+	})
+	require.NoError(t, err)
+	exp := "execute result of msg 0 for contract contractA\n" +
+		"data: eyJjb3VudCI6MX0=\n" +
+		"{\n  \"count\": 1\n}\n" +
+		"execute result of msg 2 for contract contractB\n" +
+		"data: AQI=\n" +
+		"execute result of msg 3 for contract contractC\n" +
+		"data: \n"
+	assert.Equal(t, exp, out.String())
+}
+
+func txResponseFixture(t *testing.T, msgResponses []*codectypes.Any, events ...abci.Event) *sdk.TxResponse {
+	t.Helper()
+	bz, err := (&sdk.TxMsgData{MsgResponses: msgResponses}).Marshal()
+	require.NoError(t, err)
+	return &sdk.TxResponse{Data: strings.ToUpper(hex.EncodeToString(bz)), Events: events}
+}
+
+func mustAny(t *testing.T, msg sdk.Msg) *codectypes.Any {
+	t.Helper()
+	a, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+	return a
+}
+
+func executeEvent(contract string, msgIndex int) abci.Event {
+	return abci.Event{
+		Type: types.EventTypeExecute,
+		Attributes: []abci.EventAttribute{
+			{Key: types.AttributeKeyContractAddr, Value: contract},
+			{Key: attributeKeyMsgIndex, Value: strconv.Itoa(msgIndex)},
+		},
+	}
+}
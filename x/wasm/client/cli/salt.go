@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/version"
+)
+
+const (
+	flagRandom   = "random"
+	flagSaltFrom = "salt-from"
+)
+
+// GetCmdMakeSalt derives a salt for instantiate2 from a namespace and name
+func GetCmdMakeSalt() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "make-salt [namespace] [name]",
+		Short: "Derive a 32 byte salt for instantiate2",
+		Long: fmt.Sprintf(`Derive a 32 byte salt for instantiate2 as sha256(namespace || 0x00 || name).
+The same salt is derived by the '--%s namespace/name' flag of the instantiate2 command.
+Use '--%s' for a random salt instead.
+
+Example:
+$ %s query wasm make-salt myapp pool
+`, flagSaltFrom, flagRandom, version.AppName),
+		Args: cobra.RangeArgs(0, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			random, err := cmd.Flags().GetBool(flagRandom)
+			if err != nil {
+				return err
+			}
+			var salt []byte
+			switch {
+			case random && len(args) != 0:
+				return errors.New("namespace and name can not be used with --random")
+			case random:
+				if salt, err = randomSalt(); err != nil {
+					return err
+				}
+			case len(args) != 2:
+				return errors.New("namespace and name required")
+			default:
+				salt = deriveSalt(args[0], args[1])
+			}
+			return printSalt(cmd.OutOrStdout(), salt)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Bool(flagRandom, false, "Generate a random salt")
+	return cmd
+}
+
+// deriveSalt returns sha256(namespace || 0x00 || name). The derivation must never change
+// as deployments rely on it to rebuild contract addresses.
+func deriveSalt(namespace, name string) []byte {
+	h := sha256.New()
+	h.Write([]byte(namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(name))
+	return h.Sum(nil)
+}
+
+// parseSaltFrom derives the salt from a "namespace/name" reference. The name is taken after the last slash
+// so that namespaces can be nested.
+func parseSaltFrom(s string) ([]byte, error) {
+	i := strings.LastIndex(s, "/")
+	if i <= 0 || i == len(s)-1 {
+		return nil, fmt.Errorf("invalid salt reference %q: use namespace/name", s)
+	}
+	return deriveSalt(s[:i], s[i+1:]), nil
+}
+
+// randomSalt returns a 32 byte salt from a cryptographically secure source
+func randomSalt() ([]byte, error) {
+	salt := make([]byte, sha256.Size)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return salt, nil
+}
+
+func printSalt(out io.Writer, salt []byte) error {
+	_, err := fmt.Fprintf(out, "hex: %s\nbase64: %s\n", hex.EncodeToString(salt), base64.StdEncoding.EncodeToString(salt))
+	return err
+}
@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDeriveSalt(t *testing.T) {
+	// pinned values, deployments rely on them to rebuild contract addresses
+	specs := map[string]struct {
+		namespace, name string
+		expHex          string
+	}{
+		"namespace and name": {
+			namespace: "myapp", name: "pool",
+			expHex: "f083b7d16788b554e0a63e9130110df66337dcde887458eea0bbc6461d7cef32",
+		},
+		"nested namespace": {
+			namespace: "my/app", name: "pool",
+			expHex: "468d9937cce32d775ecee614780f2a0c5a23ca32f02e50a7048848be6375e7c1",
+		},
+		"empty": {
+			expHex: "6e340b9cffb37a989ca544e6bb780a2c78901d3fb33738768511a30617afa01d",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.expHex, hex.EncodeToString(deriveSalt(spec.namespace, spec.name)))
+		})
+	}
+}
+
+func TestParseInstantiate2Salt(t *testing.T) {
+	specs := map[string]struct {
+		args      []string
+		flags     []string
+		expHex    string
+		expErr    bool
+		expErrMsg string
+	}{
+		"salt argument": {
+			args:   []string{"0102"},
+			expHex: "0102",
+		},
+		"salt argument with 0x prefix": {
+			args:   []string{"0x0102"},
+			expHex: "0102",
+		},
+		"salt argument with odd length": {
+			args:      []string{"010"},
+			expErr:    true,
+			expErrMsg: "invalid salt: odd length hex string with 3 characters",
+		},
+		"salt argument not hex": {
+			args:      []string{"zz"},
+			expErr:    true,
+			expErrMsg: "invalid salt: not a hex string",
+		},
+		"salt from reference": {
+			flags:  []string{"--salt-from=myapp/pool"},
+			expHex: "f083b7d16788b554e0a63e9130110df66337dcde887458eea0bbc6461d7cef32",
+		},
+		"salt from nested reference": {
+			flags:  []string{"--salt-from=my/app/pool"},
+			expHex: "468d9937cce32d775ecee614780f2a0c5a23ca32f02e50a7048848be6375e7c1",
+		},
+		"salt from reference without name": {
+			flags:  []string{"--salt-from=myapp/"},
+			expErr: true,
+		},
+		"salt from reference without namespace": {
+			flags:  []string{"--salt-from=pool"},
+			expErr: true,
+		},
+		"both set": {
+			args:   []string{"0102"},
+			flags:  []string{"--salt-from=myapp/pool"},
+			expErr: true,
+		},
+		"none set": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := InstantiateContract2Cmd()
+			require.NoError(t, cmd.ParseFlags(spec.flags))
+			decoder := newArgDecoder(hexDecodeString)
+			decoder.RegisterFlags(flag.NewFlagSet("test", flag.ContinueOnError), "salt")
+			gotSalt, gotErr := parseInstantiate2Salt(spec.args, decoder, cmd.Flags())
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, spec.expErrMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expHex, hex.EncodeToString(gotSalt))
+		})
+	}
+}
+
+func TestMakeSaltCmd(t *testing.T) {
+	specs := map[string]struct {
+		args   []string
+		expOut string
+		expErr bool
+	}{
+		"namespace and name": {
+			args:   []string{"myapp", "pool"},
+			expOut: "hex: f083b7d16788b554e0a63e9130110df66337dcde887458eea0bbc6461d7cef32\nbase64: 8IO30WeItVTgpj6RMBEN9mM33N6IdFjuoLvGRh187zI=\n",
+		},
+		"name missing": {
+			args:   []string{"myapp"},
+			expErr: true,
+		},
+		"random with args": {
+			args:   []string{"myapp", "pool", "--random"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := GetCmdMakeSalt()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(spec.args)
+			gotErr := cmd.Execute()
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expOut, out.String())
+		})
+	}
+}
+
+func TestBuildAddressCmd(t *testing.T) {
+	const (
+		myCodeHash = "13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5"
+		myCreator  = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	exp, err := keeper.BuildAddressPredictable(&types.QueryBuildAddressRequest{CodeHash: myCodeHash, CreatorAddress: myCreator, Salt: "0102"})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		args      []string
+		expErrMsg string
+	}{
+		"hex": {
+			args: []string{myCodeHash, myCreator, "0102"},
+		},
+		"0x prefixed": {
+			args: []string{"0x" + myCodeHash, myCreator, "0x0102"},
+		},
+		"code hash with odd length": {
+			args:      []string{myCodeHash[1:], myCreator, "0102"},
+			expErrMsg: "invalid code hash: odd length hex string with 63 characters",
+		},
+		"code hash not hex": {
+			args:      []string{"zz" + myCodeHash[2:], myCreator, "0102"},
+			expErrMsg: "invalid code hash: not a hex string",
+		},
+		"salt with odd length": {
+			args:      []string{myCodeHash, myCreator, "010"},
+			expErrMsg: "invalid salt: odd length hex string with 3 characters",
+		},
+		"salt not hex": {
+			args:      []string{myCodeHash, myCreator, "salt"},
+			expErrMsg: "invalid salt: not a hex string",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := GetCmdBuildAddress()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(spec.args)
+			gotErr := cmd.Execute()
+			if spec.expErrMsg != "" {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, spec.expErrMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, exp.Address+"\n", out.String())
+		})
+	}
+}
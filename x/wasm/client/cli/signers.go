@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/proto"
+	flag "github.com/spf13/pflag"
+	protov2 "google.golang.org/protobuf/proto"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// msgSignersGetter returns the signers of a message. This is implemented by the codec.
+type msgSignersGetter interface {
+	GetMsgV1Signers(msg proto.Message) ([][]byte, protov2.Message, error)
+}
+
+// generateOrBroadcastTxCLI audits the message signers before the tx is generated or broadcasted
+// with tx.GenerateOrBroadcastTxCLI. See auditSigners. With --dry-run or --gas-preview the tx is only simulated.
+// With --multisig the unsigned tx is printed for offline signing, see encodeMultisigTxJSON.
+// Known errors are explained, see explainTxError.
+func generateOrBroadcastTxCLI(clientCtx client.Context, flagSet *flag.FlagSet, msgs ...sdk.Msg) error {
+	var granter sdk.AccAddress
+	if f := flagSet.Lookup(flagGranter); f != nil && f.Value.String() != "" {
+		var err error
+		if granter, err = sdk.AccAddressFromBech32(f.Value.String()); err != nil {
+			return fmt.Errorf("granter: %s", err)
+		}
+	}
+	var forced bool
+	if f := flagSet.Lookup(flagForce); f != nil {
+		forced = f.Value.String() == "true"
+	}
+	msgs, err := auditSigners(clientCtx.Codec, clientCtx.GetFromAddress(), granter, clientCtx.GenerateOnly || forced, msgs)
+	if err != nil {
+		return err
+	}
+	if clientCtx.Simulate {
+		return explainTxError(dryRun(clientCtx, flagSet, msgs...))
+	}
+	if isGasPreview(flagSet) {
+		return explainTxError(previewGas(clientCtx, flagSet, msgs...))
+	}
+	if isMultisig(flagSet) {
+		if !clientCtx.GenerateOnly {
+			return errors.New("--multisig requires --generate-only")
+		}
+		return printMultisigUnsignedTx(clientCtx, flagSet, msgs...)
+	}
+	return explainTxError(tx.GenerateOrBroadcastTxCLI(clientCtx, flagSet, msgs...))
+}
+
+// auditSigners compares the signers of all messages with the --from address, the only signer of the tx when broadcasted.
+// Messages signed by the granter are wrapped into an authz MsgExec with the --from address as grantee.
+// Other mismatches are rejected with an explanation which flag to use, unless the tx is not broadcasted
+// directly and signatures are collected offline.
+func auditSigners(cdc msgSignersGetter, from, granter sdk.AccAddress, allowMismatch bool, msgs []sdk.Msg) ([]sdk.Msg, error) {
+	r := make([]sdk.Msg, len(msgs))
+	for i, msg := range msgs {
+		signers, _, err := cdc.GetMsgV1Signers(msg)
+		if err != nil {
+			return nil, fmt.Errorf("signers of %s: %w", sdk.MsgTypeURL(msg), err)
+		}
+		switch {
+		case onlySignedBy(signers, from):
+			r[i] = msg
+		case len(granter) != 0 && onlySignedBy(signers, granter):
+			execMsg := authz.NewMsgExec(from, []sdk.Msg{msg})
+			r[i] = &execMsg
+		case allowMismatch:
+			r[i] = msg
+		default:
+			return nil, signerMismatchError(msg, signers, from)
+		}
+	}
+	return r, nil
+}
+
+func onlySignedBy(signers [][]byte, addr sdk.AccAddress) bool {
+	if len(signers) == 0 {
+		return false
+	}
+	for _, s := range signers {
+		if !bytes.Equal(s, addr) {
+			return false
+		}
+	}
+	return true
+}
+
+func signerMismatchError(msg sdk.Msg, signers [][]byte, from sdk.AccAddress) error {
+	for _, s := range signers {
+		if bytes.Equal(s, from) {
+			continue
+		}
+		signer := sdk.AccAddress(s)
+		if signer.Equals(DefaultGovAuthority) {
+			return fmt.Errorf("signer %s of %s is the gov module account: submit it with \"tx wasm submit-proposal\" instead", signer, sdk.MsgTypeURL(msg))
+		}
+		return fmt.Errorf("signer %s of %s does not match --from %s: use --from with the signer key, --granter %s for an authz grant or --generate-only to collect the signatures offline",
+			signer, sdk.MsgTypeURL(msg), from, signer)
+	}
+	return fmt.Errorf("no signers for %s", sdk.MsgTypeURL(msg))
+}
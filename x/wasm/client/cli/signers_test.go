@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestAuditSigners(t *testing.T) {
+	cdc := keeper.MakeEncodingConfig(t).Codec
+	myFrom := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	myGranter := sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{3}, 32)).String()
+
+	executeMsg := func(sender sdk.AccAddress) *types.MsgExecuteContract {
+		return &types.MsgExecuteContract{Sender: sender.String(), Contract: myContract, Msg: []byte(`{}`)}
+	}
+	sudoMsg := func(authority sdk.AccAddress) *types.MsgSudoContract {
+		return &types.MsgSudoContract{Authority: authority.String(), Contract: myContract, Msg: []byte(`{}`)}
+	}
+	execMsg := authz.NewMsgExec(myFrom, []sdk.Msg{executeMsg(myGranter)})
+
+	specs := map[string]struct {
+		msgs          []sdk.Msg
+		granter       sdk.AccAddress
+		allowMismatch bool
+		expMsgs       []sdk.Msg
+		expErr        string
+	}{
+		"plain msg signed by from": {
+			msgs:    []sdk.Msg{executeMsg(myFrom)},
+			expMsgs: []sdk.Msg{executeMsg(myFrom)},
+		},
+		"plain msg with mismatched from": {
+			msgs:   []sdk.Msg{executeMsg(myFrom), executeMsg(myGranter)},
+			expErr: "--granter",
+		},
+		"plain msg with mismatched from and generate only": {
+			msgs:          []sdk.Msg{executeMsg(myGranter)},
+			allowMismatch: true,
+			expMsgs:       []sdk.Msg{executeMsg(myGranter)},
+		},
+		"authority msg signed by from": {
+			msgs:    []sdk.Msg{sudoMsg(myFrom)},
+			expMsgs: []sdk.Msg{sudoMsg(myFrom)},
+		},
+		"authority msg with gov authority": {
+			msgs:   []sdk.Msg{sudoMsg(DefaultGovAuthority)},
+			expErr: "submit-proposal",
+		},
+		"authority msg with mismatched authority": {
+			msgs:   []sdk.Msg{sudoMsg(myGranter)},
+			expErr: "--generate-only",
+		},
+		"authority msg with gov authority and generate only": {
+			msgs:          []sdk.Msg{sudoMsg(DefaultGovAuthority)},
+			allowMismatch: true,
+			expMsgs:       []sdk.Msg{sudoMsg(DefaultGovAuthority)},
+		},
+		"granter msg wrapped in authz exec": {
+			msgs:    []sdk.Msg{executeMsg(myFrom), executeMsg(myGranter)},
+			granter: myGranter,
+			expMsgs: []sdk.Msg{executeMsg(myFrom), &execMsg},
+		},
+		"authz exec signed by from": {
+			msgs:    []sdk.Msg{&execMsg},
+			expMsgs: []sdk.Msg{&execMsg},
+		},
+		"granter does not match signer": {
+			msgs:    []sdk.Msg{sudoMsg(DefaultGovAuthority)},
+			granter: myGranter,
+			expErr:  "submit-proposal",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotMsgs, gotErr := auditSigners(cdc, myFrom, spec.granter, spec.allowMismatch, spec.msgs)
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMsgs, gotMsgs)
+		})
+	}
+}
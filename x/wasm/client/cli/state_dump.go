@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagResumeFrom        = "resume-from"
+	flagProgress          = "progress"
+	flagOut               = "out"
+	flagAllowHeightChange = "allow-height-change"
+
+	// stateDumpCheckpointSuffix is appended to the --out file name for the checkpoint file
+	stateDumpCheckpointSuffix = ".checkpoint"
+)
+
+func addStateDumpFlags(cmd *cobra.Command) {
+	cmd.Flags().String(flagResumeFrom, "", "Continue a dump from this base64 encoded pagination key")
+	cmd.Flags().Bool(flagProgress, false, "Print the height and the next pagination key to stderr after each page")
+	cmd.Flags().String(flagOut, "", "Stream the state as NDJSON into this file and record a checkpoint after each page")
+	cmd.Flags().Bool(flagAllowHeightChange, false, "Allow to resume a dump at a different height than it was started")
+}
+
+// isStateDump returns true when any of the state dump flags is set
+func isStateDump(cmd *cobra.Command) bool {
+	for _, name := range []string{flagResumeFrom, flagProgress, flagOut} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// stateDumpCheckpoint is the progress of a state dump, written after each page
+type stateDumpCheckpoint struct {
+	Height  int64  `json:"height"`
+	NextKey []byte `json:"next_key"`
+}
+
+// stateDumpEntry is a NDJSON record of a contract state dump
+type stateDumpEntry struct {
+	Key   string `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// runContractStateDump streams all state entries of the contract. All pages are queried at the same height.
+func runContractStateDump(cmd *cobra.Command, clientCtx client.Context, contractAddr string) error {
+	if cmd.Flags().Changed(flags.FlagPageKey) {
+		return fmt.Errorf("--%s can not be combined with --%s", flags.FlagPageKey, flagResumeFrom)
+	}
+	resumeFrom, err := cmd.Flags().GetString(flagResumeFrom)
+	if err != nil {
+		return err
+	}
+	pageKey, err := base64.StdEncoding.DecodeString(resumeFrom)
+	if err != nil {
+		return fmt.Errorf("resume from: %s", err)
+	}
+	outFile, err := cmd.Flags().GetString(flagOut)
+	if err != nil {
+		return err
+	}
+	progress, err := cmd.Flags().GetBool(flagProgress)
+	if err != nil {
+		return err
+	}
+	allowHeightChange, err := cmd.Flags().GetBool(flagAllowHeightChange)
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint64(flags.FlagLimit)
+	if err != nil {
+		return err
+	}
+
+	var recordedHeight int64
+	if resumeFrom != "" && outFile != "" {
+		checkpoint, err := readStateDumpCheckpoint(outFile + stateDumpCheckpointSuffix)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			recordedHeight = checkpoint.Height
+		}
+	}
+	height := clientCtx.Height
+	if height == 0 && !allowHeightChange {
+		height = recordedHeight
+	}
+	if height == 0 {
+		if height, err = latestHeight(cmd.Context(), clientCtx); err != nil {
+			return err
+		}
+	}
+	if err := checkStateDumpHeight(recordedHeight, height, allowHeightChange); err != nil {
+		return err
+	}
+	clientCtx = clientCtx.WithHeight(height)
+
+	out := cmd.OutOrStdout()
+	if outFile != "" {
+		f, _, err := openDumpFile(outFile, resumeFrom != "")
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	onPage := func(nextKey []byte) error {
+		if outFile != "" {
+			checkpoint := stateDumpCheckpoint{Height: height, NextKey: nextKey}
+			if err := writeStateDumpCheckpoint(outFile+stateDumpCheckpointSuffix, checkpoint); err != nil {
+				return err
+			}
+		}
+		if progress {
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "height: %d next key: %s\n", height, base64.StdEncoding.EncodeToString(nextKey))
+		}
+		return nil
+	}
+	return dumpContractState(cmd.Context(), types.NewQueryClient(clientCtx), contractAddr, pageKey, limit, out, onPage)
+}
+
+// checkStateDumpHeight fails when a dump is resumed at a different height than it was started
+func checkStateDumpHeight(recorded, height int64, allowHeightChange bool) error {
+	if recorded == 0 || recorded == height || allowHeightChange {
+		return nil
+	}
+	return fmt.Errorf("dump was started at height %d, not %d: set --%s to resume anyway", recorded, height, flagAllowHeightChange)
+}
+
+// dumpContractState writes the state entries page by page as NDJSON starting with the page key. The output is
+// flushed before onPage is called with the key of the next page.
+func dumpContractState(ctx context.Context, queryClient types.QueryClient, contractAddr string, pageKey []byte, limit uint64, out io.Writer, onPage func(nextKey []byte) error) error {
+	enc := json.NewEncoder(out)
+	for {
+		res, err := queryClient.AllContractState(ctx, &types.QueryAllContractStateRequest{
+			Address:    contractAddr,
+			Pagination: &query.PageRequest{Key: pageKey, Limit: limit},
+		})
+		if err != nil {
+			return err
+		}
+		for _, m := range res.Models {
+			if err := enc.Encode(stateDumpEntry{Key: m.Key.String(), Value: m.Value}); err != nil {
+				return err
+			}
+		}
+		if f, ok := out.(interface{ Sync() error }); ok {
+			if err := f.Sync(); err != nil {
+				return err
+			}
+		}
+		if res.Pagination != nil {
+			pageKey = res.Pagination.NextKey
+		} else {
+			pageKey = nil
+		}
+		if err := onPage(pageKey); err != nil {
+			return err
+		}
+		if len(pageKey) == 0 {
+			return nil
+		}
+	}
+}
+
+func readStateDumpCheckpoint(name string) (*stateDumpCheckpoint, error) {
+	bz, err := os.ReadFile(name)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	var checkpoint stateDumpCheckpoint
+	if err := json.Unmarshal(bz, &checkpoint); err != nil {
+		return nil, fmt.Errorf("checkpoint %s: %s", name, err)
+	}
+	return &checkpoint, nil
+}
+
+// writeStateDumpCheckpoint replaces the checkpoint file so that it is never partially written
+func writeStateDumpCheckpoint(name string, checkpoint stateDumpCheckpoint) error {
+	bz, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmpName := name + ".tmp"
+	f, err := os.Create(tmpName)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(bz); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, name)
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestDumpContractStateResume(t *testing.T) {
+	queryClient := &stateDumpQueryClientMock{models: []types.Model{
+		{Key: []byte("a"), Value: []byte(`{"count":1}`)},
+		{Key: []byte("b"), Value: []byte(`{"count":2}`)},
+		{Key: []byte("c"), Value: []byte(`{"count":3}`)},
+		{Key: []byte("d"), Value: []byte(`{"count":4}`)},
+		{Key: []byte("e"), Value: []byte(`{"count":5}`)},
+	}}
+	const myHeight = 100
+
+	var expOut bytes.Buffer
+	require.NoError(t, dumpContractState(context.Background(), queryClient, "contract", nil, 2, &expOut, func([]byte) error { return nil }))
+	require.Equal(t, 5, bytes.Count(expOut.Bytes(), []byte("\n")))
+
+	for interruptAfter := 1; interruptAfter <= 2; interruptAfter++ {
+		outFile := filepath.Join(t.TempDir(), "state.ndjson")
+		checkpointFile := outFile + stateDumpCheckpointSuffix
+		onPage := func(nextKey []byte) error {
+			return writeStateDumpCheckpoint(checkpointFile, stateDumpCheckpoint{Height: myHeight, NextKey: nextKey})
+		}
+
+		// interrupted dump
+		f, _, err := openDumpFile(outFile, false)
+		require.NoError(t, err)
+		queryClient.failAfter, queryClient.calls = interruptAfter, 0
+		gotErr := dumpContractState(context.Background(), queryClient, "contract", nil, 2, f, onPage)
+		require.Error(t, gotErr)
+		require.NoError(t, f.Close())
+
+		// resumed from checkpoint
+		checkpoint, err := readStateDumpCheckpoint(checkpointFile)
+		require.NoError(t, err)
+		require.NotNil(t, checkpoint)
+		assert.Equal(t, int64(myHeight), checkpoint.Height)
+		require.NoError(t, checkStateDumpHeight(checkpoint.Height, myHeight, false))
+		f, _, err = openDumpFile(outFile, true)
+		require.NoError(t, err)
+		queryClient.failAfter = 0
+		require.NoError(t, dumpContractState(context.Background(), queryClient, "contract", checkpoint.NextKey, 2, f, onPage))
+		require.NoError(t, f.Close())
+
+		// then
+		got, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		assert.Equal(t, expOut.String(), string(got))
+		checkpoint, err = readStateDumpCheckpoint(checkpointFile)
+		require.NoError(t, err)
+		assert.Empty(t, checkpoint.NextKey)
+	}
+}
+
+func TestCheckStateDumpHeight(t *testing.T) {
+	specs := map[string]struct {
+		recorded          int64
+		height            int64
+		allowHeightChange bool
+		expErr            bool
+	}{
+		"new dump": {
+			height: 10,
+		},
+		"same height": {
+			recorded: 10,
+			height:   10,
+		},
+		"different height": {
+			recorded: 10,
+			height:   11,
+			expErr:   true,
+		},
+		"different height allowed": {
+			recorded:          10,
+			height:            11,
+			allowHeightChange: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := checkStateDumpHeight(spec.recorded, spec.height, spec.allowHeightChange)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestReadStateDumpCheckpointNotExists(t *testing.T) {
+	got, err := readStateDumpCheckpoint(filepath.Join(t.TempDir(), "unknown"))
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+// stateDumpQueryClientMock returns the models by page with the model index as page key. Queries fail after
+// failAfter pages when set.
+type stateDumpQueryClientMock struct {
+	types.QueryClient
+	models    []types.Model
+	failAfter int
+	calls     int
+}
+
+func (m *stateDumpQueryClientMock) AllContractState(_ context.Context, req *types.QueryAllContractStateRequest, _ ...grpc.CallOption) (*types.QueryAllContractStateResponse, error) {
+	m.calls++
+	if m.failAfter != 0 && m.calls > m.failAfter {
+		return nil, errors.New("connection lost")
+	}
+	var start int
+	if len(req.Pagination.Key) != 0 {
+		start = int(req.Pagination.Key[0])
+	}
+	end := min(start+int(req.Pagination.Limit), len(m.models))
+	res := &types.QueryAllContractStateResponse{Models: m.models[start:end], Pagination: &query.PageResponse{}}
+	if end < len(m.models) {
+		res.Pagination.NextKey = []byte{byte(end)}
+	}
+	return res, nil
+}
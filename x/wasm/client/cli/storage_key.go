@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	flagKeySegments = "key-segments"
+	flagNamespace   = "namespace"
+)
+
+// buildStorageKey builds a raw contract state key the same way as cw-storage-plus. Without segments the key is
+// the namespace of an Item. Otherwise the namespace and all segments but the last are length prefixed as for Map
+// keys and the last segment is appended as is.
+func buildStorageKey(namespace string, segments []string) ([]byte, error) {
+	parts := make([][]byte, 0, len(segments)+1)
+	if namespace != "" {
+		parts = append(parts, []byte(namespace))
+	}
+	for _, s := range segments {
+		bz, err := parseKeySegment(s)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, bz)
+	}
+	if len(parts) == 0 {
+		return nil, errors.New("empty key")
+	}
+	var key []byte
+	for _, p := range parts[:len(parts)-1] {
+		if len(p) > math.MaxUint16 {
+			return nil, fmt.Errorf("key segment exceeds max length: %d", len(p))
+		}
+		key = binary.BigEndian.AppendUint16(key, uint16(len(p)))
+		key = append(key, p...)
+	}
+	return append(key, parts[len(parts)-1]...), nil
+}
+
+// parseKeySegment decodes a key segment with an optional encoding hint: `str:`, `addr:`, `u64:`, `u32:` or `hex:`.
+// Segments without a known hint are strings.
+func parseKeySegment(s string) ([]byte, error) {
+	hint, v, ok := strings.Cut(s, ":")
+	if !ok {
+		return []byte(s), nil
+	}
+	switch hint {
+	case "str":
+		return []byte(v), nil
+	case "addr":
+		if _, err := sdk.AccAddressFromBech32(v); err != nil {
+			return nil, fmt.Errorf("key segment %q: %s", s, err)
+		}
+		return []byte(v), nil
+	case "u64":
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("key segment %q: %s", s, err)
+		}
+		return binary.BigEndian.AppendUint64(nil, n), nil
+	case "u32":
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("key segment %q: %s", s, err)
+		}
+		return binary.BigEndian.AppendUint32(nil, uint32(n)), nil
+	case "hex":
+		bz, err := hex.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("key segment %q: %s", s, err)
+		}
+		return bz, nil
+	default:
+		return []byte(s), nil
+	}
+}
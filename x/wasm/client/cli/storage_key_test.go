@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/hex"
+	"testing"
+
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildStorageKey(t *testing.T) {
+	const myAddr = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	specs := map[string]struct {
+		namespace string
+		segments  []string
+		expHex    string
+		expErr    bool
+	}{
+		"Item": {
+			namespace: "config",
+			expHex:    "636f6e666967",
+		},
+		"Map<String, T>": {
+			namespace: "balances",
+			segments:  []string{"alice"},
+			expHex:    "0008" + "62616c616e636573" + "616c696365",
+		},
+		"Map<String, T> with str hint": {
+			namespace: "balances",
+			segments:  []string{"str:alice"},
+			expHex:    "0008" + "62616c616e636573" + "616c696365",
+		},
+		"Map<(Addr,u64), T>": {
+			namespace: "allowances",
+			segments:  []string{"addr:" + myAddr, "u64:5"},
+			expHex:    "000a" + "616c6c6f77616e636573" + "002d" + hex.EncodeToString([]byte(myAddr)) + "0000000000000005",
+		},
+		"Map<u32, T>": {
+			namespace: "ids",
+			segments:  []string{"u32:258"},
+			expHex:    "0003" + "696473" + "00000102",
+		},
+		"hex segment": {
+			namespace: "raw",
+			segments:  []string{"hex:0102"},
+			expHex:    "0003" + "726177" + "0102",
+		},
+		"unknown hint is a string": {
+			namespace: "urls",
+			segments:  []string{"http://x"},
+			expHex:    "0004" + "75726c73" + hex.EncodeToString([]byte("http://x")),
+		},
+		"invalid address": {
+			namespace: "allowances",
+			segments:  []string{"addr:foo"},
+			expErr:    true,
+		},
+		"invalid number": {
+			namespace: "ids",
+			segments:  []string{"u64:-1"},
+			expErr:    true,
+		},
+		"empty": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotKey, gotErr := buildStorageKey(spec.namespace, spec.segments)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expHex, hex.EncodeToString(gotKey))
+		})
+	}
+}
+
+func TestParseRawStateKey(t *testing.T) {
+	specs := map[string]struct {
+		args      []string
+		flags     []string
+		expHex    string
+		expErr    bool
+		expErrMsg string
+	}{
+		"key argument": {
+			args:   []string{"0102"},
+			expHex: "0102",
+		},
+		"key argument with 0x prefix": {
+			args:   []string{"0X0102"},
+			expHex: "0102",
+		},
+		"key argument with odd length": {
+			args:      []string{"0x010"},
+			expErr:    true,
+			expErrMsg: "invalid key argument: odd length hex string with 3 characters",
+		},
+		"key argument not hex": {
+			args:      []string{"key"},
+			expErr:    true,
+			expErrMsg: "invalid key argument: odd length hex string",
+		},
+		"key argument with non hex characters": {
+			args:      []string{"keys"},
+			expErr:    true,
+			expErrMsg: "invalid key argument: not a hex string",
+		},
+		"namespace": {
+			flags:  []string{"--namespace=config"},
+			expHex: "636f6e666967",
+		},
+		"namespace and segments": {
+			flags:  []string{"--namespace=balances", "--key-segments=alice"},
+			expHex: "000862616c616e636573616c696365",
+		},
+		"key argument and namespace": {
+			args:   []string{"0102"},
+			flags:  []string{"--namespace=config"},
+			expErr: true,
+		},
+		"none set": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := GetCmdGetContractStateRaw()
+			require.NoError(t, cmd.ParseFlags(spec.flags))
+			decoder := newArgDecoder(hexDecodeString)
+			decoder.RegisterFlags(flag.NewFlagSet("test", flag.ContinueOnError), "key argument")
+			gotKey, gotErr := parseRawStateKey(spec.args, decoder, cmd.Flags())
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.ErrorContains(t, gotErr, spec.expErrMsg)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expHex, hex.EncodeToString(gotKey))
+		})
+	}
+}
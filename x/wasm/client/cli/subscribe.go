@@ -0,0 +1,357 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagFromHeight = "from-height"
+
+	// subscribeMinBackoff is the wait time before the first reconnect to the node
+	subscribeMinBackoff = time.Second
+	// subscribeMaxBackoff is the max wait time before a reconnect to the node
+	subscribeMaxBackoff = 30 * time.Second
+)
+
+// SubscribedEvent is a NDJSON record of a wasm event of the subscribed contract
+type SubscribedEvent struct {
+	Height     int64                      `json:"height"`
+	TxHash     string                     `json:"txhash"`
+	Type       string                     `json:"type"`
+	Attributes []SubscribedEventAttribute `json:"attributes"`
+}
+
+// SubscribedEventAttribute is an attribute of a wasm event
+type SubscribedEventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// txEvents are the events of a tx that matched a subscription query
+type txEvents struct {
+	Height int64
+	TxHash string
+	Events []abci.Event
+}
+
+// txSubscribeFn subscribes to the txs that match any of the queries. The channel is closed when the connection to
+// the node is lost or the context is done.
+type txSubscribeFn func(ctx context.Context, queries []string) (<-chan txEvents, error)
+
+// GetCmdSubscribe streams the wasm events of a contract
+func GetCmdSubscribe() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscribe [contract_addr]",
+		Short: "Stream the wasm events of a contract as NDJSON",
+		Long: `Stream the wasm events of a contract and the hash of the triggering tx as NDJSON until interrupted.
+The command subscribes to the txs of the contract via the websocket RPC of the node. The --action flag
+filters for execute, instantiate or migrate messages on the contract. With "all", txs with any of these
+actions or custom wasm events of the contract are streamed.
+With --from-height, the indexed txs since this height are streamed before going live. This requires a node
+with tx indexing enabled. When the connection is lost, the command reconnects with an increasing backoff
+and streams the txs that were missed in between from the tx index.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			action, err := cmd.Flags().GetString(flagAction)
+			if err != nil {
+				return err
+			}
+			fromHeight, err := cmd.Flags().GetInt64(flagFromHeight)
+			if err != nil {
+				return err
+			}
+			if fromHeight < 0 {
+				return errors.New("from height must not be negative")
+			}
+			queries, err := contractTxsQueries(args[0], action)
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			s := contractSubscription{
+				contract: args[0],
+				queries:  queries,
+				search: func(query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error) {
+					return authtx.QueryTxsByEvents(clientCtx, page, limit, query, orderBy)
+				},
+				subscribe: func(ctx context.Context, queries []string) (<-chan txEvents, error) {
+					return subscribeTxs(ctx, clientCtx.NodeURI, queries)
+				},
+				latestHeight: func(ctx context.Context) (int64, error) {
+					return latestHeight(ctx, clientCtx)
+				},
+				out:        cmd.OutOrStdout(),
+				errOut:     cmd.ErrOrStderr(),
+				minBackoff: subscribeMinBackoff,
+				maxBackoff: subscribeMaxBackoff,
+			}
+			return s.run(ctx, fromHeight)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagAction, txsActionAll, "Filter by action: execute, instantiate, migrate or all")
+	cmd.Flags().Int64(flagFromHeight, 0, "Stream the indexed txs since this height before going live")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// contractSubscription streams the wasm events of a contract
+type contractSubscription struct {
+	contract     string
+	queries      []string
+	search       txSearchFn
+	subscribe    txSubscribeFn
+	latestHeight func(ctx context.Context) (int64, error)
+	out          io.Writer
+	errOut       io.Writer
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+}
+
+// run streams the events until the context is done. The txs since the cursor height are backfilled from the tx index
+// after each subscription so that no tx is missed while the connection was lost. Txs are written once only and
+// in height order.
+func (s contractSubscription) run(ctx context.Context, fromHeight int64) error {
+	enc := json.NewEncoder(s.out)
+	// the hashes of the written txs at the last written height
+	seen := make(map[string]struct{})
+	var lastHeight int64
+	cursor := fromHeight
+	emit := func(tx txEvents) error {
+		if tx.Height < lastHeight {
+			return nil
+		}
+		if tx.Height > lastHeight {
+			lastHeight = tx.Height
+			cursor = max(cursor, lastHeight)
+			clear(seen)
+		}
+		if _, ok := seen[tx.TxHash]; ok {
+			return nil
+		}
+		seen[tx.TxHash] = struct{}{}
+		for _, e := range contractEvents(tx, s.contract) {
+			if err := enc.Encode(e); err != nil {
+				return writeError{err}
+			}
+		}
+		return nil
+	}
+
+	backoff := s.minBackoff
+	for {
+		err := s.stream(ctx, &cursor, emit, func() { backoff = s.minBackoff })
+		var writeErr writeError
+		if errors.As(err, &writeErr) {
+			return writeErr.error
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		_, _ = fmt.Fprintf(s.errOut, "subscription: %s: reconnecting in %s\n", err, backoff)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, s.maxBackoff)
+	}
+}
+
+// writeError is returned when the output can not be written
+type writeError struct{ error }
+
+// stream subscribes, backfills since the cursor and then emits the live txs until the connection is lost.
+// The connected callback is called when the subscription is live.
+func (s contractSubscription) stream(ctx context.Context, cursor *int64, emit func(txEvents) error, connected func()) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	txs, err := s.subscribe(ctx, s.queries)
+	if err != nil {
+		return err
+	}
+	if *cursor > 0 {
+		if err := s.backfill(*cursor, emit); err != nil {
+			return err
+		}
+	} else {
+		// txs after this height are backfilled on reconnect
+		height, err := s.latestHeight(ctx)
+		if err != nil {
+			return err
+		}
+		*cursor = height + 1
+	}
+	connected()
+	for tx := range txs {
+		if err := emit(tx); err != nil {
+			return err
+		}
+	}
+	return errors.New("connection closed")
+}
+
+// backfill writes the indexed txs of all queries since the height in height order
+func (s contractSubscription) backfill(height int64, emit func(txEvents) error) error {
+	var txs []*sdk.TxResponse
+	for _, q := range s.queries {
+		query := fmt.Sprintf("%s AND tx.height>=%d", q, height)
+		for page, collected := 1, 0; ; page++ {
+			res, err := searchTxs(s.search, query, page, txSearchMaxPageSize, "asc")
+			if err != nil {
+				return err
+			}
+			txs = append(txs, res.Txs...)
+			collected += len(res.Txs)
+			if len(res.Txs) == 0 || uint64(collected) >= res.TotalCount {
+				break
+			}
+		}
+	}
+	sort.SliceStable(txs, func(i, j int) bool { return txs[i].Height < txs[j].Height })
+	for _, tx := range txs {
+		if err := emit(txEvents{Height: tx.Height, TxHash: tx.TxHash, Events: tx.Events}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contractEvents returns the wasm events that the contract emitted in the tx
+func contractEvents(tx txEvents, contract string) []SubscribedEvent {
+	var r []SubscribedEvent
+	for _, e := range tx.Events {
+		switch {
+		case e.Type == types.WasmModuleEventType, strings.HasPrefix(e.Type, types.CustomContractEventPrefix):
+		case callGraphActions[e.Type] != "":
+		default:
+			continue
+		}
+		if eventAttribute(e, types.AttributeKeyContractAddr) != contract {
+			continue
+		}
+		attrs := make([]SubscribedEventAttribute, len(e.Attributes))
+		for i, a := range e.Attributes {
+			attrs[i] = SubscribedEventAttribute{Key: a.Key, Value: a.Value}
+		}
+		r = append(r, SubscribedEvent{Height: tx.Height, TxHash: tx.TxHash, Type: e.Type, Attributes: attrs})
+	}
+	return r
+}
+
+// subscribeTxs opens a websocket connection to the node and subscribes to the txs that match any of the queries
+func subscribeTxs(ctx context.Context, nodeURI string, queries []string) (<-chan txEvents, error) {
+	endpoint, err := websocketEndpoint(nodeURI)
+	if err != nil {
+		return nil, err
+	}
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, q := range queries {
+		req, err := rpctypes.MapToRequest(rpctypes.JSONRPCIntID(i), "subscribe", map[string]interface{}{
+			"query": fmt.Sprintf("%s='%s' AND %s", cmttypes.EventTypeKey, cmttypes.EventTx, q),
+		})
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		if err := conn.WriteJSON(req); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+	}
+
+	txs := make(chan txEvents)
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+	go func() {
+		defer close(txs)
+		defer conn.Close()
+		for {
+			var rsp rpctypes.RPCResponse
+			if err := conn.ReadJSON(&rsp); err != nil {
+				return
+			}
+			if rsp.Error != nil {
+				return
+			}
+			var event coretypes.ResultEvent
+			if err := cmtjson.Unmarshal(rsp.Result, &event); err != nil {
+				continue
+			}
+			// the subscription is confirmed with an empty result
+			data, ok := event.Data.(cmttypes.EventDataTx)
+			if !ok {
+				continue
+			}
+			tx := txEvents{
+				Height: data.Height,
+				TxHash: fmt.Sprintf("%X", cmttypes.Tx(data.Tx).Hash()),
+				Events: data.Result.Events,
+			}
+			select {
+			case txs <- tx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return txs, nil
+}
+
+// websocketEndpoint returns the websocket URL of the node RPC
+func websocketEndpoint(nodeURI string) (string, error) {
+	u, err := url.Parse(nodeURI)
+	if err != nil {
+		return "", fmt.Errorf("node: %s", err)
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	case "tcp", "http", "ws":
+		u.Scheme = "ws"
+	default:
+		return "", fmt.Errorf("unsupported node scheme %q", u.Scheme)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/websocket"
+	return u.String(), nil
+}
@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractSubscriptionRun(t *testing.T) {
+	const (
+		myContract    = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+		otherContract = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	wasmEvents := func(action string) []abci.Event {
+		return []abci.Event{
+			{Type: sdk.EventTypeMessage, Attributes: []abci.EventAttribute{{Key: "module", Value: "wasm"}}},
+			{Type: types.EventTypeExecute, Attributes: []abci.EventAttribute{{Key: types.AttributeKeyContractAddr, Value: myContract}}},
+			{Type: types.WasmModuleEventType, Attributes: []abci.EventAttribute{{Key: types.AttributeKeyContractAddr, Value: myContract}, {Key: "action", Value: action}}},
+			{Type: types.WasmModuleEventType, Attributes: []abci.EventAttribute{{Key: types.AttributeKeyContractAddr, Value: otherContract}, {Key: "action", Value: "other"}}},
+		}
+	}
+	txA, txB, txC := []byte("tx a"), []byte("tx b"), []byte("tx c")
+	hash := func(tx []byte) string { return fmt.Sprintf("%X", cmttypes.Tx(tx).Hash()) }
+
+	// the node sends tx a and drops the connection, then sends tx a again and tx b after the reconnect
+	var connections atomic.Int32
+	node := newMockWebsocketNode(t, func(conn *websocket.Conn, queries []string) {
+		assert.Equal(t, []string{"tm.event='Tx' AND execute._contract_address='" + myContract + "'"}, queries)
+		switch connections.Add(1) {
+		case 1:
+			sendTxEvent(t, conn, 5, txA, wasmEvents("a"))
+		default:
+			sendTxEvent(t, conn, 5, txA, wasmEvents("a"))
+			sendTxEvent(t, conn, 7, txB, wasmEvents("b"))
+			// keep the connection open until the client closes it
+			_, _, _ = conn.ReadMessage()
+		}
+	})
+	// tx c was missed while the connection was lost
+	var searchedQueries []string
+	search := func(query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error) {
+		searchedQueries = append(searchedQueries, query)
+		return &sdk.SearchTxsResult{TotalCount: 2, Txs: []*sdk.TxResponse{
+			{Height: 6, TxHash: hash(txC), Events: wasmEvents("c")},
+			{Height: 5, TxHash: hash(txA), Events: wasmEvents("a")},
+		}}, nil
+	}
+	queries, err := contractTxsQueries(myContract, txsActionExecute)
+	require.NoError(t, err)
+	var out, errOut syncBuffer
+	s := contractSubscription{
+		contract: myContract,
+		queries:  queries,
+		search:   search,
+		subscribe: func(ctx context.Context, queries []string) (<-chan txEvents, error) {
+			return subscribeTxs(ctx, node, queries)
+		},
+		latestHeight: func(ctx context.Context) (int64, error) { return 4, nil },
+		out:          &out,
+		errOut:       &errOut,
+		minBackoff:   time.Millisecond,
+		maxBackoff:   time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	// when
+	go func() { done <- s.run(ctx, 0) }()
+
+	// then
+	require.Eventually(t, func() bool { return strings.Count(out.String(), "\n") >= 6 }, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	var gotActions []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var e SubscribedEvent
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		if e.Type != types.WasmModuleEventType {
+			assert.Equal(t, types.EventTypeExecute, e.Type)
+			continue
+		}
+		assert.Equal(t, myContract, e.Attributes[0].Value)
+		gotActions = append(gotActions, fmt.Sprintf("%d %s %s", e.Height, e.TxHash, e.Attributes[1].Value))
+	}
+	exp := []string{
+		fmt.Sprintf("5 %s a", hash(txA)),
+		fmt.Sprintf("6 %s c", hash(txC)),
+		fmt.Sprintf("7 %s b", hash(txB)),
+	}
+	assert.Equal(t, exp, gotActions)
+	assert.Equal(t, []string{"execute._contract_address='" + myContract + "' AND tx.height>=5"}, searchedQueries)
+	assert.Contains(t, errOut.String(), "connection closed: reconnecting")
+}
+
+func TestContractSubscriptionRunBackfill(t *testing.T) {
+	const myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	event := abci.Event{Type: types.WasmModuleEventType, Attributes: []abci.EventAttribute{{Key: types.AttributeKeyContractAddr, Value: myContract}}}
+	specs := map[string]struct {
+		fromHeight  int64
+		searchErr   error
+		expTxs      []string
+		expSearched bool
+		expErrOut   string
+	}{
+		"from height": {
+			fromHeight:  3,
+			expTxs:      []string{"3", "4", "5"},
+			expSearched: true,
+		},
+		"live only": {
+			expTxs: []string{"5"},
+		},
+		"search fails": {
+			fromHeight:  3,
+			searchErr:   fmt.Errorf("testing"),
+			expSearched: true,
+			expErrOut:   "subscription: testing: reconnecting",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var searched bool
+			search := func(query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error) {
+				searched = true
+				assert.Equal(t, "asc", orderBy)
+				if spec.searchErr != nil {
+					return nil, spec.searchErr
+				}
+				return &sdk.SearchTxsResult{TotalCount: 3, Txs: []*sdk.TxResponse{
+					{Height: 4, TxHash: "4", Events: []abci.Event{event}},
+					{Height: 3, TxHash: "3", Events: []abci.Event{event}},
+					{Height: 5, TxHash: "5", Events: []abci.Event{event}},
+				}}, nil
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var out, errOut syncBuffer
+			s := contractSubscription{
+				contract: myContract,
+				queries:  []string{"myQuery"},
+				search:   search,
+				subscribe: func(ctx context.Context, queries []string) (<-chan txEvents, error) {
+					txs := make(chan txEvents)
+					go func() {
+						defer close(txs)
+						select {
+						case txs <- txEvents{Height: 5, TxHash: "5", Events: []abci.Event{event}}:
+						case <-ctx.Done():
+						}
+						<-ctx.Done()
+					}()
+					return txs, nil
+				},
+				latestHeight: func(ctx context.Context) (int64, error) { return 4, nil },
+				out:          &out,
+				errOut:       &errOut,
+				minBackoff:   time.Hour,
+				maxBackoff:   time.Hour,
+			}
+			done := make(chan error)
+
+			// when
+			go func() { done <- s.run(ctx, spec.fromHeight) }()
+
+			// then
+			if spec.expErrOut != "" {
+				require.Eventually(t, func() bool { return strings.Contains(errOut.String(), spec.expErrOut) }, time.Second, time.Millisecond)
+			} else {
+				require.Eventually(t, func() bool { return strings.Count(out.String(), "\n") == len(spec.expTxs) }, time.Second, time.Millisecond)
+			}
+			cancel()
+			require.NoError(t, <-done)
+			var gotTxs []string
+			for _, line := range strings.Fields(out.String()) {
+				var e SubscribedEvent
+				require.NoError(t, json.Unmarshal([]byte(line), &e))
+				gotTxs = append(gotTxs, e.TxHash)
+			}
+			assert.Equal(t, spec.expTxs, gotTxs)
+			assert.Equal(t, spec.expSearched, searched)
+		})
+	}
+}
+
+func TestWebsocketEndpoint(t *testing.T) {
+	specs := map[string]struct {
+		node   string
+		exp    string
+		expErr bool
+	}{
+		"tcp":         {node: "tcp://localhost:26657", exp: "ws://localhost:26657/websocket"},
+		"http":        {node: "http://localhost:26657", exp: "ws://localhost:26657/websocket"},
+		"https":       {node: "https://rpc.example.com/", exp: "wss://rpc.example.com/websocket"},
+		"with path":   {node: "https://example.com/rpc", exp: "wss://example.com/rpc/websocket"},
+		"unsupported": {node: "unix:///tmp/node.sock", expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := websocketEndpoint(spec.node)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+// newMockWebsocketNode starts a websocket RPC server that confirms the subscriptions and then hands over the connection.
+// The connection is closed when the handler returns.
+func newMockWebsocketNode(t *testing.T, handle func(conn *websocket.Conn, queries []string)) string {
+	t.Helper()
+	var upgrader websocket.Upgrader
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/websocket" {
+			http.NotFound(w, r)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var req rpctypes.RPCRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		var params struct {
+			Query string `json:"query"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil || req.Method != "subscribe" {
+			return
+		}
+		if err := conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, &coretypes.ResultSubscribe{})); err != nil {
+			return
+		}
+		handle(conn, []string{params.Query})
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func sendTxEvent(t *testing.T, conn *websocket.Conn, height int64, tx []byte, events []abci.Event) {
+	t.Helper()
+	event := &coretypes.ResultEvent{Data: cmttypes.EventDataTx{TxResult: abci.TxResult{
+		Height: height,
+		Tx:     tx,
+		Result: abci.ExecTxResult{Events: events},
+	}}}
+	assert.NoError(t, conn.WriteJSON(rpctypes.NewRPCSuccessResponse(rpctypes.JSONRPCIntID(0), event)))
+}
+
+// syncBuffer is a buffer that is safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const flagWindow = "window"
+
+// GetCmdQueryTopGasConsumers gets the code ids with the most execution gas used in the last blocks
+func GetCmdQueryTopGasConsumers() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "top-gas",
+		Short: "Query the code ids with the most execution gas used in the last blocks",
+		Long: fmt.Sprintf(`Query the code ids with the most execution gas used in the last blocks.
+The gas is summed up in buckets of %d blocks. The window is rounded down to the start of its first
+bucket so that up to %d blocks before the window are included and it must be at least one bucket long.
+It is recorded when enabled with record_code_gas_usage in the params.`, types.CodeGasUsageBucketLength, types.CodeGasUsageBucketLength-1),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			window, err := cmd.Flags().GetUint64(flagWindow)
+			if err != nil {
+				return err
+			}
+			if window < types.CodeGasUsageBucketLength || window > types.CodeGasUsageWindow {
+				return fmt.Errorf("--%s must be between %d and %d", flagWindow, types.CodeGasUsageBucketLength, types.CodeGasUsageWindow)
+			}
+			limit, err := cmd.Flags().GetUint32(flags.FlagLimit)
+			if err != nil {
+				return err
+			}
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.TopGasConsumers(cmd.Context(), &types.QueryTopGasConsumersRequest{
+				WindowBlocks: window,
+				Limit:        limit,
+			})
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatJSON {
+				return clientCtx.PrintProto(res)
+			}
+			return renderTopGasConsumers(cmd.OutOrStdout(), res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagWindow, types.CodeGasUsageWindow, "Number of blocks up to the latest height to include")
+	cmd.Flags().Uint32(flags.FlagLimit, 20, "Max number of code ids to show")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// renderTopGasConsumers writes the ranking of the code ids as table
+func renderTopGasConsumers(out io.Writer, res *types.QueryTopGasConsumersResponse) error {
+	if _, err := fmt.Fprintf(out, "blocks %d-%d\n", res.FromHeight, res.ToHeight); err != nil {
+		return err
+	}
+	if len(res.Consumers) == 0 {
+		_, err := fmt.Fprintln(out, "no execution gas recorded")
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "RANK\tCODE ID\tGAS USED"); err != nil {
+		return err
+	}
+	for i, c := range res.Consumers {
+		if _, err := fmt.Fprintf(w, "%d\t%d\t%d\n", i+1, c.CodeID, c.GasUsed); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRenderTopGasConsumers(t *testing.T) {
+	specs := map[string]struct {
+		src types.QueryTopGasConsumersResponse
+		exp string
+	}{
+		"with consumers": {
+			src: types.QueryTopGasConsumersResponse{
+				Consumers: []types.CodeGasUsage{
+					{CodeID: 12, GasUsed: 1_500_000},
+					{CodeID: 3, GasUsed: 200},
+				},
+				FromHeight: 1000,
+				ToHeight:   10500,
+			},
+			exp: "blocks 1000-10500\n" +
+				"RANK  CODE ID  GAS USED\n" +
+				"1     12       1500000\n" +
+				"2     3        200\n",
+		},
+		"no consumers": {
+			src: types.QueryTopGasConsumersResponse{FromHeight: 1, ToHeight: 20},
+			exp: "blocks 1-20\n" +
+				"no execution gas recorded\n",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, renderTopGasConsumers(&out, &spec.src))
+			assert.Equal(t, spec.exp, out.String())
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -11,16 +16,20 @@ import (
 
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/cosmos/cosmos-sdk/version"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 
 	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -48,6 +57,29 @@ const (
 	flagNoTokenTransfer           = "no-token-transfer"
 	flagAuthority                 = "authority"
 	flagExpedite                  = "expedite"
+	flagResetToDefault            = "reset-to-default"
+	flagSortByUsage               = "sort-by-usage"
+	flagCreator                   = "creator"
+	flagWithCodeInfo              = "with-code-info"
+	flagPrefix                    = "prefix"
+	flagVerifyAdminExists         = "verify-admin-exists"
+	flagOperation                 = "operation"
+	flagSince                     = "since"
+	flagCountOnly                 = "count-only"
+	flagForce                     = "force"
+	flagExpirationFromContract    = "expiration-from-contract"
+	flagExpirationQuery           = "expiration-query"
+	flagExpirationField           = "expiration-field"
+	flagExpirationRelative        = "expiration-relative"
+	flagMaxExpiration             = "max-expiration"
+	flagAllowExisting             = "allow-existing"
+	flagGranter                   = "granter"
+	flagPin                       = "pin"
+	flagMaxGas                    = "max-gas"
+	flagIncludeDeprecated         = "include-deprecated"
+	flagAtomic                    = "atomic"
+	flagCompatFunds               = "compat-funds"
+	flagInactiveSinceHeight       = "inactive-since-height"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -62,6 +94,7 @@ func GetTxCmd() *cobra.Command {
 	}
 	txCmd.AddCommand(
 		StoreCodeCmd(),
+		StoreManyCodeCmd(),
 		InstantiateContractCmd(),
 		InstantiateContract2Cmd(),
 		ExecuteContractCmd(),
@@ -72,7 +105,13 @@ func GetTxCmd() *cobra.Command {
 		UpdateInstantiateConfigCmd(),
 		SubmitProposalCmd(),
 		UpdateContractLabelCmd(),
+		SetContractStateCmd(),
+		SetContractStateAccessCmd(),
+		SudoContractCmd(),
+		VerifyUnsignedTxCmd(),
 	)
+	addNodeFallback(txCmd)
+	addPreflightReport(txCmd)
 	return txCmd
 }
 
@@ -88,20 +127,131 @@ func StoreCodeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			msg, err := parseStoreCodeArgs(args[0], clientCtx.GetFromAddress().String(), cmd.Flags())
+			granter, err := cmd.Flags().GetString(flagGranter)
 			if err != nil {
+				return fmt.Errorf("granter: %s", err)
+			}
+			sender := clientCtx.GetFromAddress().String()
+			if granter != "" {
+				sender = granter
+			}
+			msg, err := parseStoreCodeArgs(args[0], sender, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			if err := checkInstantiatePermissionAddresses(cmd, clientCtx, msg.InstantiatePermission); err != nil {
+				return err
+			}
+			switch {
+			case granter == "":
+			case clientCtx.Offline:
+				recordPreflight(cmd.Context(), preflightStoreCodeGrant, preflightSkipped, "offline")
+			default:
+				warning, err := checkStoreCodeGrant(cmd.Context(), authz.NewQueryClient(clientCtx), granter, clientCtx.GetFromAddress().String(), &msg)
+				recordPreflightResult(cmd.Context(), preflightStoreCodeGrant, warning, err)
+				if err != nil {
+					return err
+				}
+				if warning != "" {
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", warning)
+				}
+			}
+			pinMsg, err := parsePinFlag(cmd.Flags(), sender)
+			if err != nil {
+				return err
+			}
+			if err := printUploadQuota(cmd.Context(), clientCtx, cmd.ErrOrStderr(), sender, 1); err != nil {
+				return err
+			}
+			if err := applyMemoTemplate(cmd.Flags(), storeCodeMemoValues(args[0], msg.WASMByteCode)); err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			msgs := []sdk.Msg{&msg}
+			if pinMsg != nil {
+				msgs = append(msgs, pinMsg)
+			}
+			// the msgs are wrapped into an authz MsgExec for the granter
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
 		},
 		SilenceUsage: true,
 	}
 
 	addInstantiatePermissionFlags(cmd)
+	addSkipAddressCheckFlag(cmd)
+	cmd.Flags().String(flagGranter, "", "Upload the code on behalf of this granter with an authz store code grant")
+	cmd.Flags().Bool(flagPin, false, "Pin the code in the same tx, only allowed for the authority")
+	cmd.Flags().String(flagAuthority, DefaultGovAuthority.String(), "The address of the authority that can pin codes. Default is the sdk gov module account")
+	addGasPreviewFlag(cmd)
+	addMultisigFlag(cmd)
+	addMemoTemplateFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// parsePinFlag returns a msg to pin the code stored in the same tx when the pin flag is set.
+// Pinning codes requires the authority as sender.
+func parsePinFlag(flags *flag.FlagSet, sender string) (*types.MsgPinCodes, error) {
+	pin, err := flags.GetBool(flagPin)
+	if err != nil {
+		return nil, fmt.Errorf("pin: %s", err)
+	}
+	if !pin {
+		return nil, nil
+	}
+	authority, err := flags.GetString(flagAuthority)
+	if err != nil {
+		return nil, fmt.Errorf("authority: %s", err)
+	}
+	if sender != authority {
+		return nil, fmt.Errorf("pinning code requires the authority %s: submit a governance proposal with `pin-codes` after the code is stored instead", authority)
+	}
+	return &types.MsgPinCodes{Authority: authority, CodeIDs: []uint64{types.LastStoredCodeID}}, nil
+}
+
+// checkStoreCodeGrant returns a warning when none of the store code grants of the granter to the grantee
+// accepts the code hash and instantiate permission of the message
+func checkStoreCodeGrant(ctx context.Context, queryClient authz.QueryClient, granter, grantee string, msg *types.MsgStoreCode) (string, error) {
+	wasm := msg.WASMByteCode
+	if ioutils.IsGzip(wasm) {
+		var err error
+		if wasm, err = ioutils.Uncompress(wasm, int64(types.MaxWasmSize)); err != nil {
+			return "", err
+		}
+	}
+	checksum := sha256.Sum256(wasm)
+	res, err := queryClient.Grants(ctx, &authz.QueryGrantsRequest{
+		Granter:    granter,
+		Grantee:    grantee,
+		MsgTypeUrl: sdk.MsgTypeURL(&types.MsgStoreCode{}),
+	})
+	if err != nil {
+		return "", fmt.Errorf("query grants: %s", err)
+	}
+	for _, g := range res.Grants {
+		if g.Authorization == nil {
+			continue
+		}
+		switch g.Authorization.TypeUrl {
+		case sdk.MsgTypeURL(&authz.GenericAuthorization{}):
+			return "", nil
+		case sdk.MsgTypeURL(&types.StoreCodeAuthorization{}):
+			var a types.StoreCodeAuthorization
+			if err := a.Unmarshal(g.Authorization.Value); err != nil {
+				return "", fmt.Errorf("store code authorization: %s", err)
+			}
+			for _, codeGrant := range a.Grants {
+				if codeGrant.Accept(checksum[:], msg.InstantiatePermission) {
+					return "", nil
+				}
+			}
+		}
+	}
+	if len(res.Grants) == 0 {
+		return "no store code grant found", nil
+	}
+	return fmt.Sprintf("code hash %X or instantiate permission is not granted", checksum), nil
+}
+
 // Prepares MsgStoreCode object from flags with gzipped wasm byte code field
 func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStoreCode, error) {
 	wasm, err := os.ReadFile(file)
@@ -109,13 +259,19 @@ func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStor
 		return types.MsgStoreCode{}, err
 	}
 
-	// gzip the wasm file
-	if ioutils.IsWasm(wasm) {
+	// gzip the wasm file or compress a gzip file again as single member stream without header fields
+	switch {
+	case ioutils.IsWasm(wasm):
 		wasm, err = ioutils.GzipIt(wasm)
 		if err != nil {
 			return types.MsgStoreCode{}, err
 		}
-	} else if !ioutils.IsGzip(wasm) {
+	case ioutils.IsGzip(wasm):
+		wasm, err = ioutils.NormalizeGzip(wasm, int64(types.MaxWasmSize))
+		if err != nil {
+			return types.MsgStoreCode{}, fmt.Errorf("invalid gzip: %w", err)
+		}
+	default:
 		return types.MsgStoreCode{}, errors.New("invalid input file. Use wasm binary or gzip")
 	}
 
@@ -132,7 +288,107 @@ func parseStoreCodeArgs(file, sender string, flags *flag.FlagSet) (types.MsgStor
 	return msg, msg.ValidateBasic()
 }
 
+// StoreManyCodeCmd will upload multiple wasm binaries in a single tx.
+func StoreManyCodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store-many [wasm file]...",
+		Short: "Upload multiple wasm binaries in a single tx",
+		Long:  "Upload multiple wasm binaries in a single tx. Files with the same wasm code are uploaded only once.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			msgs, skipped, err := parseStoreManyCodeArgs(args, clientCtx.GetFromAddress().String(), cmd.Flags())
+			if err != nil {
+				return err
+			}
+			for _, file := range skipped {
+				cmd.PrintErrf("skipping %s: duplicate wasm code\n", file)
+			}
+			if len(skipped) != 0 {
+				recordPreflight(cmd.Context(), preflightDuplicateCode, preflightWarn, "skipped: "+strings.Join(skipped, ", "))
+			} else {
+				recordPreflight(cmd.Context(), preflightDuplicateCode, preflightPass, "")
+			}
+			if err := checkTxSize(cmd.Context(), clientCtx, cmd.Flags(), "upload the files with multiple txs", msgs...); err != nil {
+				return err
+			}
+			if err := printUploadQuota(cmd.Context(), clientCtx, cmd.ErrOrStderr(), clientCtx.GetFromAddress().String(), len(msgs)); err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+		},
+		SilenceUsage: true,
+	}
+
+	addInstantiatePermissionFlags(cmd)
+	addMaxTxBytesFlag(cmd)
+	addGasPreviewFlag(cmd)
+	addMultisigFlag(cmd)
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// Prepares a MsgStoreCode object for each distinct wasm code in the given files.
+// Files with wasm code that is already contained in a previous file are returned as skipped.
+func parseStoreManyCodeArgs(files []string, sender string, flags *flag.FlagSet) ([]sdk.Msg, []string, error) {
+	var (
+		msgs    = make([]sdk.Msg, 0, len(files))
+		skipped []string
+		seen    = make(map[string]struct{}, len(files))
+	)
+	for _, file := range files {
+		msg, err := parseStoreCodeArgs(file, sender, flags)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", file, err)
+		}
+		wasm, err := ioutils.Uncompress(msg.WASMByteCode, int64(types.MaxWasmSize))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", file, err)
+		}
+		checksum := sha256.Sum256(wasm)
+		key := hex.EncodeToString(checksum[:])
+		if _, exists := seen[key]; exists {
+			skipped = append(skipped, file)
+			continue
+		}
+		seen[key] = struct{}{}
+		msgs = append(msgs, &msg)
+	}
+	return msgs, skipped, nil
+}
+
+// accessConfigFlagsConflictError is returned when more than one instantiate permission flag is set
+type accessConfigFlagsConflictError struct {
+	Flags []string
+}
+
+func (e accessConfigFlagsConflictError) Error() string {
+	return fmt.Sprintf("instantiate permission flags can not be combined: %s", strings.Join(e.Flags, ", "))
+}
+
+// parseAccessConfigFlags returns the instantiate permission of the flags. Nil is returned for the chain default,
+// either when no flag is set or when a boolean flag is set to false.
 func parseAccessConfigFlags(flags *flag.FlagSet) (*types.AccessConfig, error) {
+	onlyAddrStr, err := flags.GetString(flagInstantiateByAddress)
+	if err != nil {
+		return nil, fmt.Errorf("instantiate by address: %s", err)
+	}
+	if onlyAddrStr != "" {
+		return nil, fmt.Errorf("not supported anymore. Use: %s", flagInstantiateByAnyOfAddress)
+	}
+	var conflicting []string
+	for _, f := range []string{flagInstantiateByEverybody, flagInstantiateNobody, flagInstantiateByAnyOfAddress} {
+		if flags.Changed(f) {
+			conflicting = append(conflicting, "--"+f)
+		}
+	}
+	if len(conflicting) > 1 {
+		return nil, accessConfigFlagsConflictError{Flags: conflicting}
+	}
+
 	addrs, err := flags.GetStringSlice(flagInstantiateByAnyOfAddress)
 	if err != nil {
 		return nil, fmt.Errorf("flag any of: %s", err)
@@ -149,46 +405,27 @@ func parseAccessConfigFlags(flags *flag.FlagSet) (*types.AccessConfig, error) {
 		return &x, nil
 	}
 
-	onlyAddrStr, err := flags.GetString(flagInstantiateByAddress)
-	if err != nil {
-		return nil, fmt.Errorf("instantiate by address: %s", err)
-	}
-	if onlyAddrStr != "" {
-		return nil, fmt.Errorf("not supported anymore. Use: %s", flagInstantiateByAnyOfAddress)
-	}
-	everybodyStr, err := flags.GetString(flagInstantiateByEverybody)
+	everybody, err := flags.GetBool(flagInstantiateByEverybody)
 	if err != nil {
 		return nil, fmt.Errorf("instantiate by everybody: %s", err)
 	}
-	if everybodyStr != "" {
-		ok, err := strconv.ParseBool(everybodyStr)
-		if err != nil {
-			return nil, fmt.Errorf("boolean value expected for instantiate by everybody: %s", err)
-		}
-		if ok {
-			return &types.AllowEverybody, nil
-		}
+	if everybody {
+		return &types.AllowEverybody, nil
 	}
 
-	nobodyStr, err := flags.GetString(flagInstantiateNobody)
+	nobody, err := flags.GetBool(flagInstantiateNobody)
 	if err != nil {
 		return nil, fmt.Errorf("instantiate by nobody: %s", err)
 	}
-	if nobodyStr != "" {
-		ok, err := strconv.ParseBool(nobodyStr)
-		if err != nil {
-			return nil, fmt.Errorf("boolean value expected for instantiate by nobody: %s", err)
-		}
-		if ok {
-			return &types.AllowNobody, nil
-		}
+	if nobody {
+		return &types.AllowNobody, nil
 	}
 	return nil, nil
 }
 
 func addInstantiatePermissionFlags(cmd *cobra.Command) {
-	cmd.Flags().String(flagInstantiateByEverybody, "", "Everybody can instantiate a contract from the code, optional")
-	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
+	cmd.Flags().Bool(flagInstantiateByEverybody, false, "Everybody can instantiate a contract from the code, set to false for the chain default, optional")
+	cmd.Flags().Bool(flagInstantiateNobody, false, "Nobody except the governance process can instantiate a contract from the code, set to false for the chain default, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", fmt.Sprintf("Removed: use %s instead", flagInstantiateByAnyOfAddress))
 	cmd.Flags().StringSlice(flagInstantiateByAnyOfAddress, []string{}, "Any of the addresses can instantiate a contract from the code, optional")
 }
@@ -205,34 +442,64 @@ $ %s tx wasm instantiate 1 '{"foo":"bar"}' --admin="$(%s keys show mykey -a)" \
   --from mykey --amount="100ustake" --label "local0.1.0"
 `, version.AppName, version.AppName),
 		Aliases: []string{"start", "init", "inst", "i"},
-		Args:    cobra.ExactArgs(2),
+		Args:    instantiateArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			msg, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), cmd.Flags())
+			msg, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), queryDefaultAdminPolicy(cmd.Context(), clientCtx), cmd.Flags())
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+			if err := checkFundsConsistency(cmd.Context(), cmd.ErrOrStderr(), cmd.Flags(), msg.Msg, msg.Funds); err != nil {
+				return err
+			}
+			if err := checkAdminExists(cmd, clientCtx, msg.Admin); err != nil {
+				return err
+			}
+			if err := checkInstantiatePreflight(cmd, clientCtx, msg.CodeID, msg.Sender); err != nil {
+				return err
+			}
+			if clientCtx, err = checkLockedFunds(cmd, clientCtx, msg.CodeID, msg.Funds); err != nil {
+				return err
+			}
+			if err := applyMemoTemplate(cmd.Flags(), instantiateMemoValues(msg.CodeID, msg.Label)); err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	addAmountFlag(cmd, "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
-	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin, unless the chain default admin policy allows an empty admin")
+	cmd.Flags().Bool(flagVerifyAdminExists, false, "Query the chain to ensure the admin is an existing account or contract")
+	addInstantiatePreflightFlag(cmd)
+	addWarnLockedFundsFlag(cmd)
+	addFundsConsistencyFlags(cmd)
+	addGasPreviewFlag(cmd)
+	addMultisigFlag(cmd)
+	addMemoTemplateFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
+// instantiateArgs rejects a salt argument that was meant for instantiate2
+func instantiateArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 3 {
+		return fmt.Errorf("unexpected third argument %q: use instantiate2 to instantiate with a salt", args[2])
+	}
+	return cobra.ExactArgs(2)(cmd, args)
+}
+
 // InstantiateContract2Cmd will instantiate a contract from previously uploaded code with predictable address generated
 func InstantiateContract2Cmd() *cobra.Command {
-	decoder := newArgDecoder(hex.DecodeString)
+	decoder := newArgDecoder(hexDecodeString)
 	cmd := &cobra.Command{
-		Use: "instantiate2 [code_id_int64] [json_encoded_init_args] [salt] --label [text] --admin [address,optional] --amount [coins,optional] " +
+		Use: "instantiate2 [code_id_int64] [json_encoded_init_args] [salt,optional with --salt-from] --label [text] --admin [address,optional] --amount [coins,optional] " +
 			"--fix-msg [bool,optional]",
 		Short: "Instantiate a wasm contract with predictable address",
 		Long: fmt.Sprintf(`Creates a new instance of an uploaded wasm code with the given 'constructor' message.
@@ -243,63 +510,204 @@ Predictable address example (also see '%s query wasm build-address -h'):
 $ %s tx wasm instantiate2 1 '{"foo":"bar"}' $(echo -n "testing" | xxd -ps) --admin="$(%s keys show mykey -a)" \
   --from mykey --amount="100ustake" --label "local0.1.0" \
    --fix-msg
-`, version.AppName, version.AppName, version.AppName),
-		Aliases: []string{"start", "init", "inst", "i"},
-		Args:    cobra.ExactArgs(3),
+
+Salt derived from a reference (also see '%s query wasm make-salt -h'):
+$ %s tx wasm instantiate2 1 '{"foo":"bar"}' --salt-from "myapp/pool" --from mykey --label "local0.1.0" --no-admin
+`, version.AppName, version.AppName, version.AppName, version.AppName, version.AppName),
+		Aliases: []string{"start2", "init2", "i2"},
+		Args:    cobra.RangeArgs(2, 3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
-			salt, err := decoder.DecodeString(args[2])
+			salt, err := parseInstantiate2Salt(args[2:], decoder, cmd.Flags())
 			if err != nil {
-				return fmt.Errorf("salt: %w", err)
+				return err
 			}
 			fixMsg, err := cmd.Flags().GetBool(flagFixMsg)
 			if err != nil {
 				return fmt.Errorf("fix msg: %w", err)
 			}
-			data, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), cmd.Flags())
+			data, err := parseInstantiateArgs(args[0], args[1], clientCtx.Keyring, clientCtx.GetFromAddress().String(), queryDefaultAdminPolicy(cmd.Context(), clientCtx), cmd.Flags())
 			if err != nil {
 				return err
 			}
+			if err := checkFundsConsistency(cmd.Context(), cmd.ErrOrStderr(), cmd.Flags(), data.Msg, data.Funds); err != nil {
+				return err
+			}
+			if err := checkAdminExists(cmd, clientCtx, data.Admin); err != nil {
+				return err
+			}
+			if err := checkInstantiatePreflight(cmd, clientCtx, data.CodeID, data.Sender); err != nil {
+				return err
+			}
+			if clientCtx, err = checkLockedFunds(cmd, clientCtx, data.CodeID, data.Funds); err != nil {
+				return err
+			}
+			if err := applyMemoTemplate(cmd.Flags(), instantiateMemoValues(data.CodeID, data.Label)); err != nil {
+				return err
+			}
 			msg := &types.MsgInstantiateContract2{
-				Sender: data.Sender,
-				Admin:  data.Admin,
-				CodeID: data.CodeID,
-				Label:  data.Label,
-				Msg:    data.Msg,
-				Funds:  data.Funds,
-				Salt:   salt,
-				FixMsg: fixMsg,
-			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+				Sender:  data.Sender,
+				Admin:   data.Admin,
+				CodeID:  data.CodeID,
+				Label:   data.Label,
+				Msg:     data.Msg,
+				Funds:   data.Funds,
+				Salt:    salt,
+				FixMsg:  fixMsg,
+				NoAdmin: data.NoAdmin,
+			}
+			allowExisting, err := cmd.Flags().GetBool(flagAllowExisting)
+			if err != nil {
+				return fmt.Errorf("allow existing: %w", err)
+			}
+			if allowExisting {
+				existing, err := findExistingInstance(context.Background(), types.NewQueryClient(clientCtx), msg)
+				if err != nil {
+					return err
+				}
+				if existing != "" {
+					_, err := fmt.Fprintln(cmd.OutOrStdout(), existing)
+					return err
+				}
+			}
+			if err := checkInstantiate2AddressPreflight(cmd, clientCtx, msg); err != nil {
+				return err
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
 		},
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
+	addAmountFlag(cmd, "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
 	cmd.Flags().String(flagAdmin, "", "Address or key name of an admin")
-	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin")
+	cmd.Flags().Bool(flagNoAdmin, false, "You must set this explicitly if you don't want an admin, unless the chain default admin policy allows an empty admin")
+	cmd.Flags().Bool(flagVerifyAdminExists, false, "Query the chain to ensure the admin is an existing account or contract")
+	addInstantiatePreflightFlag(cmd)
+	addWarnLockedFundsFlag(cmd)
 	cmd.Flags().Bool(flagFixMsg, false, "An optional flag to include the json_encoded_init_args for the predictable address generation mode")
+	cmd.Flags().Bool(flagAllowExisting, false, "Print the address and skip the tx when a contract with the same code id exists at the predictable address already")
+	cmd.Flags().String(flagSaltFrom, "", "Derive the salt from a namespace/name reference instead of the salt argument")
 	decoder.RegisterFlags(cmd.PersistentFlags(), "salt")
+	addFundsConsistencyFlags(cmd)
+	addGasPreviewFlag(cmd)
+	addMultisigFlag(cmd)
+	addMemoTemplateFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
-func parseInstantiateArgs(rawCodeID, initMsg string, kr keyring.Keyring, sender string, flags *flag.FlagSet) (*types.MsgInstantiateContract, error) {
-	// get the id of the code to instantiate
-	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
+// parseInstantiate2Salt returns the salt from the optional argument or the --salt-from reference. Exactly one must be set.
+func parseInstantiate2Salt(args []string, decoder *argumentDecoder, flags *flag.FlagSet) ([]byte, error) {
+	saltFrom, err := flags.GetString(flagSaltFrom)
 	if err != nil {
 		return nil, err
 	}
+	switch {
+	case saltFrom != "" && len(args) != 0:
+		return nil, errors.New("salt argument can not be used with --salt-from")
+	case saltFrom != "":
+		return parseSaltFrom(saltFrom)
+	case len(args) == 0:
+		return nil, errors.New("salt argument or --salt-from required")
+	default:
+		return decoder.DecodeString(args[0])
+	}
+}
 
-	amountStr, err := flags.GetString(flagAmount)
+// findExistingInstance returns the predictable address of the contract when it was instantiated with the same code id
+// before. An empty string is returned when no contract exists at this address. A contract with a different code id
+// is an error.
+func findExistingInstance(ctx context.Context, queryClient types.QueryClient, msg *types.MsgInstantiateContract2) (string, error) {
+	creator, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
-		return nil, fmt.Errorf("amount: %s", err)
+		return "", fmt.Errorf("sender: %s", err)
+	}
+	codeInfo, err := queryClient.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: msg.CodeID})
+	if err != nil {
+		return "", fmt.Errorf("query code info: %s", err)
+	}
+	initMsg := []byte{}
+	if msg.FixMsg {
+		initMsg = msg.Msg
+	}
+	contractAddr := keeper.BuildContractAddressPredictable(codeInfo.Checksum, creator, msg.Salt, initMsg).String()
+	// the history returns no entries instead of an error for unknown contracts. The last entry has the current code id.
+	history, err := queryClient.ContractHistory(ctx, &types.QueryContractHistoryRequest{
+		Address:    contractAddr,
+		Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+	})
+	switch {
+	case err != nil:
+		return "", fmt.Errorf("query contract history: %s", err)
+	case len(history.Entries) == 0:
+		return "", nil
+	case history.Entries[0].CodeID != msg.CodeID:
+		return "", fmt.Errorf("contract %s exists with code id %d instead of %d", contractAddr, history.Entries[0].CodeID, msg.CodeID)
+	}
+	return contractAddr, nil
+}
+
+// checkAdminExists verifies the admin when requested by flag
+func checkAdminExists(cmd *cobra.Command, clientCtx client.Context, admin string) error {
+	verify, err := cmd.Flags().GetBool(flagVerifyAdminExists)
+	if err != nil {
+		return fmt.Errorf("verify admin exists: %s", err)
+	}
+	switch {
+	case admin == "":
+		return nil
+	case !verify:
+		recordPreflight(cmd.Context(), preflightAdminExists, preflightSkipped, "--"+flagVerifyAdminExists+" not set")
+		return nil
+	}
+	err = verifyAdminExists(cmd.Context(), authtypes.NewQueryClient(clientCtx), types.NewQueryClient(clientCtx), admin)
+	recordPreflightResult(cmd.Context(), preflightAdminExists, "", err)
+	return err
+}
+
+// verifyAdminExists ensures that the admin address is an existing account or contract on chain.
+// This works independent of the strict admin validation param.
+func verifyAdminExists(ctx context.Context, authQuery authtypes.QueryClient, wasmQuery types.QueryClient, admin string) error {
+	_, err := authQuery.Account(ctx, &authtypes.QueryAccountRequest{Address: admin})
+	switch {
+	case err == nil:
+		return nil
+	case status.Code(err) != codes.NotFound:
+		return fmt.Errorf("query admin account: %s", err)
+	}
+	if _, err := wasmQuery.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: admin}); err != nil {
+		return fmt.Errorf("admin %s is neither an existing account nor a contract", admin)
+	}
+	return nil
+}
+
+// queryDefaultAdminPolicy returns the default admin policy of the chain. Unspecified is returned in offline mode or
+// when the query fails so that an explicit admin choice is required.
+func queryDefaultAdminPolicy(ctx context.Context, clientCtx client.Context) types.DefaultAdminPolicy {
+	if clientCtx.Offline {
+		return types.DefaultAdminPolicyUnspecified
+	}
+	res, err := types.NewQueryClient(clientCtx).Params(ctx, &types.QueryParamsRequest{})
+	if err != nil {
+		return types.DefaultAdminPolicyUnspecified
+	}
+	return res.Params.DefaultAdminPolicy
+}
+
+// parseInstantiateArgs builds the instantiate message from the args and flags. An admin or --no-admin is required
+// unless the default admin policy of the chain handles an empty admin.
+func parseInstantiateArgs(rawCodeID, initMsg string, kr keyring.Keyring, sender string, adminPolicy types.DefaultAdminPolicy, flags *flag.FlagSet) (*types.MsgInstantiateContract, error) {
+	// get the id of the code to instantiate
+	codeID, err := strconv.ParseUint(rawCodeID, 10, 64)
+	if err != nil {
+		return nil, err
 	}
-	amount, err := sdk.ParseCoinsNormalized(amountStr)
+
+	amount, err := parseAmountFlag(flags)
 	if err != nil {
 		return nil, fmt.Errorf("amount: %s", err)
 	}
@@ -307,8 +715,8 @@ func parseInstantiateArgs(rawCodeID, initMsg string, kr keyring.Keyring, sender
 	if err != nil {
 		return nil, fmt.Errorf("label: %s", err)
 	}
-	if label == "" {
-		return nil, errors.New("label is required on all contracts")
+	if err := validateLabel(label); err != nil {
+		return nil, err
 	}
 	adminStr, err := flags.GetString(flagAdmin)
 	if err != nil {
@@ -321,7 +729,7 @@ func parseInstantiateArgs(rawCodeID, initMsg string, kr keyring.Keyring, sender
 	}
 
 	// ensure sensible admin is set (or explicitly immutable)
-	if adminStr == "" && !noAdmin {
+	if adminStr == "" && !noAdmin && !adminPolicy.EmptyAdminAllowed() {
 		return nil, errors.New("you must set an admin or explicitly pass --no-admin to make it immutable (wasmd issue #719)")
 	}
 	if adminStr != "" && noAdmin {
@@ -353,6 +761,8 @@ func parseInstantiateArgs(rawCodeID, initMsg string, kr keyring.Keyring, sender
 		Funds:  amount,
 		Msg:    []byte(initMsg),
 		Admin:  adminStr,
+		// only set when it makes a difference as chains without the policy reject the unknown field
+		NoAdmin: noAdmin && adminPolicy == types.DefaultAdminPolicyCreator,
 	}
 	return &msg, msg.ValidateBasic()
 }
@@ -374,27 +784,45 @@ func ExecuteContractCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+			if err := checkFundsConsistency(cmd.Context(), cmd.ErrOrStderr(), cmd.Flags(), msg.Msg, msg.Funds); err != nil {
+				return err
+			}
+			if err := applyMemoTemplate(cmd.Flags(), contractMemoValues(msg.Contract, 0)); err != nil {
+				return err
+			}
+			txMsg, err := executeTxMsg(cmd.Flags(), msg)
+			if err != nil {
+				return err
+			}
+			wait, err := cmd.Flags().GetBool(flagWait)
+			if err != nil {
+				return err
+			}
+			if wait {
+				return broadcastAndPrintExecuteResults(clientCtx, cmd.Flags(), txMsg)
+			}
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), txMsg)
 		},
 		SilenceUsage: true,
 	}
 
-	cmd.Flags().String(flagAmount, "", "Coins to send to the contract along with command")
+	addAmountFlag(cmd, "Coins to send to the contract along with command")
+	cmd.Flags().Bool(flagWait, false, "Wait for the tx to be included in a block and print the data returned by the contract")
+	cmd.Flags().Bool(flagCompatFunds, false, "Send a MsgExecuteContractCompat with the funds encoded as a single string")
+	addFundsConsistencyFlags(cmd)
+	addGasPreviewFlag(cmd)
+	addMultisigFlag(cmd)
+	addMemoTemplateFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
 
 func parseExecuteArgs(contractAddr, execMsg string, sender sdk.AccAddress, flags *flag.FlagSet) (types.MsgExecuteContract, error) {
-	amountStr, err := flags.GetString(flagAmount)
+	amount, err := parseAmountFlag(flags)
 	if err != nil {
 		return types.MsgExecuteContract{}, fmt.Errorf("amount: %s", err)
 	}
 
-	amount, err := sdk.ParseCoinsNormalized(amountStr)
-	if err != nil {
-		return types.MsgExecuteContract{}, err
-	}
-
 	return types.MsgExecuteContract{
 		Sender:   sender.String(),
 		Contract: contractAddr,
@@ -403,16 +831,64 @@ func parseExecuteArgs(contractAddr, execMsg string, sender sdk.AccAddress, flags
 	}, nil
 }
 
+// executeTxMsg returns the message to send for the execute command. With --compat-funds, it is the equivalent
+// MsgExecuteContractCompat.
+func executeTxMsg(flags *flag.FlagSet, msg types.MsgExecuteContract) (sdk.Msg, error) {
+	compat, err := flags.GetBool(flagCompatFunds)
+	if err != nil {
+		return nil, err
+	}
+	if !compat {
+		return &msg, nil
+	}
+	compatMsg := types.NewMsgExecuteContractCompat(msg)
+	return &compatMsg, nil
+}
+
+func addAmountFlag(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringArray(flagAmount, nil, usage+". Can be given multiple times")
+}
+
+// parseAmountFlag merges the coins of all --amount values. Display denoms are normalized to their base denom.
+// Coins of the same denom are added up and the result is sorted so that the funds pass the strict validation
+// on chain. Zero amounts are rejected.
+func parseAmountFlag(flags *flag.FlagSet) (sdk.Coins, error) {
+	values, err := flags.GetStringArray(flagAmount)
+	if err != nil {
+		return nil, err
+	}
+	var amount sdk.Coins
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		for _, s := range strings.Split(v, ",") {
+			decCoin, err := sdk.ParseDecCoin(s)
+			if err != nil {
+				return nil, err
+			}
+			coin, _ := sdk.NormalizeDecCoin(decCoin).TruncateDecimal()
+			if !coin.IsPositive() {
+				return nil, fmt.Errorf("amount %q: must be positive", strings.TrimSpace(s))
+			}
+			amount = amount.Add(coin)
+		}
+	}
+	return amount, nil
+}
+
 func GrantCmd() *cobra.Command {
 	txCmd := &cobra.Command{
-		Use:                "grant",
-		Short:              "Grant a authz permission",
-		DisableFlagParsing: true,
-		SilenceUsage:       true,
+		Use:                        "grant",
+		Short:                      "Grant a authz permission",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+		SilenceUsage:               true,
 	}
 	txCmd.AddCommand(
 		GrantAuthorizationCmd(),
 		GrantStoreCodeAuthorizationCmd(),
+		GrantApplyPolicyCmd(),
 	)
 	return txCmd
 }
@@ -428,125 +904,36 @@ $ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-mess
 $ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-messages --max-funds 100000uwasm --expiration 1667979596
 
 $ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-messages --max-calls 5 --max-funds 100000uwasm --expiration 1667979596
-`, version.AppName, version.AppName, version.AppName),
+
+$ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-messages --max-calls 1 --no-token-transfer --expiration-from-contract <contract_addr> --expiration-query '{"config":{}}' --expiration-field unbonding_period --expiration-relative --max-expiration 720h
+`, version.AppName, version.AppName, version.AppName, version.AppName),
 		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			clientCtx, err := client.GetClientTxContext(cmd)
-			if err != nil {
-				return err
-			}
-
-			grantee, err := sdk.AccAddressFromBech32(args[0])
-			if err != nil {
-				return err
-			}
-
-			contract, err := sdk.AccAddressFromBech32(args[2])
-			if err != nil {
-				return err
-			}
-
-			msgKeys, err := cmd.Flags().GetStringSlice(flagAllowedMsgKeys)
-			if err != nil {
-				return err
-			}
-
-			rawMsgs, err := cmd.Flags().GetStringSlice(flagAllowedRawMsgs)
-			if err != nil {
-				return err
-			}
-
-			maxFundsStr, err := cmd.Flags().GetString(flagMaxFunds)
-			if err != nil {
-				return fmt.Errorf("max funds: %s", err)
-			}
-
-			maxCalls, err := cmd.Flags().GetUint64(flagMaxCalls)
-			if err != nil {
-				return err
-			}
-
-			exp, err := cmd.Flags().GetInt64(flagExpiration)
+			// validate before the keyring is accessed
+			grant, err := parseContractGrantArgs(args, cmd.Flags())
 			if err != nil {
 				return err
 			}
-			if exp == 0 {
-				return errors.New("expiration must be set")
-			}
-
-			allowAllMsgs, err := cmd.Flags().GetBool(flagAllowAllMsgs)
+			clientCtx, err := client.GetClientTxContext(cmd)
 			if err != nil {
 				return err
 			}
 
-			noTokenTransfer, err := cmd.Flags().GetBool(flagNoTokenTransfer)
+			expire, err := getExpireTime(cmd)
 			if err != nil {
 				return err
 			}
-
-			var limit types.ContractAuthzLimitX
-			switch {
-			case maxFundsStr != "" && maxCalls != 0 && !noTokenTransfer:
-				maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
-				if err != nil {
-					return fmt.Errorf("max funds: %s", err)
-				}
-				limit = types.NewCombinedLimit(maxCalls, maxFunds...)
-			case maxFundsStr != "" && maxCalls == 0 && !noTokenTransfer:
-				maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
-				if err != nil {
-					return fmt.Errorf("max funds: %s", err)
-				}
-				limit = types.NewMaxFundsLimit(maxFunds...)
-			case maxCalls != 0 && noTokenTransfer && maxFundsStr == "":
-				limit = types.NewMaxCallsLimit(maxCalls)
-			default:
-				return errors.New("invalid limit setup")
-			}
-
-			var filter types.ContractAuthzFilterX
-			switch {
-			case allowAllMsgs && len(msgKeys) != 0 || allowAllMsgs && len(rawMsgs) != 0 || len(msgKeys) != 0 && len(rawMsgs) != 0:
-				return errors.New("cannot set more than one filter within one grant")
-			case allowAllMsgs:
-				filter = types.NewAllowAllMessagesFilter()
-			case len(msgKeys) != 0:
-				filter = types.NewAcceptedMessageKeysFilter(msgKeys...)
-			case len(rawMsgs) != 0:
-				msgs := make([]types.RawContractMessage, len(rawMsgs))
-				for i, msg := range rawMsgs {
-					msgs[i] = types.RawContractMessage(msg)
+			if grant.expirationContract != "" {
+				if expire, err = getExpireTimeFromContract(cmd, clientCtx, grant.expirationContract); err != nil {
+					return err
 				}
-				filter = types.NewAcceptedMessagesFilter(msgs...)
-			default:
-				return errors.New("invalid filter setup")
-			}
-
-			grant, err := types.NewContractGrant(contract, limit, filter)
-			if err != nil {
-				return err
-			}
-
-			var authorization authz.Authorization
-			switch args[1] {
-			case "execution":
-				authorization = types.NewContractExecutionAuthorization(*grant)
-			case "migration":
-				authorization = types.NewContractMigrationAuthorization(*grant)
-			default:
-				return fmt.Errorf("%s authorization type not supported", args[1])
-			}
-
-			expire, err := getExpireTime(cmd)
-			if err != nil {
-				return err
 			}
 
-			grantMsg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grantee, authorization, expire)
+			grantMsg, err := authz.NewMsgGrant(clientCtx.GetFromAddress(), grant.grantee, grant.authorization, expire)
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), grantMsg)
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), grantMsg)
 		},
 	}
 	flags.AddTxFlagsToCmd(cmd)
@@ -555,21 +942,166 @@ $ %s tx grant contract <grantee_addr> execution <contract_addr> --allow-all-mess
 	cmd.Flags().Uint64(flagMaxCalls, 0, "Maximal number of calls to the contract")
 	cmd.Flags().String(flagMaxFunds, "", "Maximal amount of tokens transferable to the contract.")
 	cmd.Flags().Int64(flagExpiration, 0, "The Unix timestamp.")
+	cmd.Flags().String(flagExpirationFromContract, "", "Contract address to query the expiration from, instead of setting it with --expiration")
+	cmd.Flags().String(flagExpirationQuery, "", "JSON encoded smart query for the expiration")
+	cmd.Flags().String(flagExpirationField, "", "Dot separated path of the expiration field in the query result, e.g. config.unbonding_period")
+	cmd.Flags().Bool(flagExpirationRelative, false, "Read the field as duration in seconds from now instead of a Unix timestamp")
+	cmd.Flags().Duration(flagMaxExpiration, 0, "Clamp the expiration from contract to this duration from now, e.g. 720h")
 	cmd.Flags().Bool(flagAllowAllMsgs, false, "Allow all messages")
 	cmd.Flags().Bool(flagNoTokenTransfer, false, "Don't allow token transfer")
+	cmd.MarkFlagsMutuallyExclusive(flagAllowAllMsgs, flagAllowedMsgKeys, flagAllowedRawMsgs)
+	cmd.MarkFlagsOneRequired(flagAllowAllMsgs, flagAllowedMsgKeys, flagAllowedRawMsgs)
+	cmd.MarkFlagsMutuallyExclusive(flagExpiration, flagExpirationFromContract)
+	cmd.MarkFlagsOneRequired(flagExpiration, flagExpirationFromContract)
+	cmd.MarkFlagsRequiredTogether(flagExpirationFromContract, flagExpirationQuery, flagExpirationField)
+	cmd.MarkFlagsMutuallyExclusive(flagMaxFunds, flagNoTokenTransfer)
 	return cmd
 }
 
+// contractGrant is the parsed input of the grant contract command
+type contractGrant struct {
+	grantee            sdk.AccAddress
+	authorization      authz.Authorization
+	expirationContract string
+}
+
+// parseContractGrantArgs parses and validates the args and flags of the grant contract command
+func parseContractGrantArgs(args []string, flags *flag.FlagSet) (*contractGrant, error) {
+	grantee, err := sdk.AccAddressFromBech32(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := sdk.AccAddressFromBech32(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	msgKeys, err := flags.GetStringSlice(flagAllowedMsgKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	rawMsgs, err := flags.GetStringSlice(flagAllowedRawMsgs)
+	if err != nil {
+		return nil, err
+	}
+
+	maxFundsStr, err := flags.GetString(flagMaxFunds)
+	if err != nil {
+		return nil, fmt.Errorf("max funds: %s", err)
+	}
+
+	maxCalls, err := flags.GetUint64(flagMaxCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	exp, err := flags.GetInt64(flagExpiration)
+	if err != nil {
+		return nil, err
+	}
+	expirationContract, err := flags.GetString(flagExpirationFromContract)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case exp == 0 && expirationContract == "":
+		return nil, errors.New("expiration must be set")
+	case exp != 0 && expirationContract != "":
+		return nil, errors.New("cannot set expiration and expiration from contract within one grant")
+	}
+
+	allowAllMsgs, err := flags.GetBool(flagAllowAllMsgs)
+	if err != nil {
+		return nil, err
+	}
+
+	noTokenTransfer, err := flags.GetBool(flagNoTokenTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := newContractAuthzLimit(maxCalls, maxFundsStr, noTokenTransfer)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newContractAuthzFilter(allowAllMsgs, msgKeys, rawMsgs)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := types.NewContractGrant(contract, limit, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var authorization authz.Authorization
+	switch args[1] {
+	case "execution":
+		authorization = types.NewContractExecutionAuthorization(*g)
+	case "migration":
+		authorization = types.NewContractMigrationAuthorization(*g)
+	default:
+		return nil, fmt.Errorf("%s authorization type not supported", args[1])
+	}
+
+	return &contractGrant{grantee: grantee, authorization: authorization, expirationContract: expirationContract}, nil
+}
+
+// newContractAuthzLimit returns the limit of a contract grant for the max calls, max funds and no token transfer settings
+func newContractAuthzLimit(maxCalls uint64, maxFundsStr string, noTokenTransfer bool) (types.ContractAuthzLimitX, error) {
+	switch {
+	case maxFundsStr != "" && maxCalls != 0 && !noTokenTransfer:
+		maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
+		if err != nil {
+			return nil, fmt.Errorf("max funds: %s", err)
+		}
+		return types.NewCombinedLimit(maxCalls, maxFunds...), nil
+	case maxFundsStr != "" && maxCalls == 0 && !noTokenTransfer:
+		maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
+		if err != nil {
+			return nil, fmt.Errorf("max funds: %s", err)
+		}
+		return types.NewMaxFundsLimit(maxFunds...), nil
+	case maxCalls != 0 && noTokenTransfer && maxFundsStr == "":
+		return types.NewMaxCallsLimit(maxCalls), nil
+	default:
+		return nil, errors.New("invalid limit setup")
+	}
+}
+
+// newContractAuthzFilter returns the filter of a contract grant. Only one of the filter settings must be set.
+func newContractAuthzFilter(allowAllMsgs bool, msgKeys, rawMsgs []string) (types.ContractAuthzFilterX, error) {
+	switch {
+	case allowAllMsgs && len(msgKeys) != 0 || allowAllMsgs && len(rawMsgs) != 0 || len(msgKeys) != 0 && len(rawMsgs) != 0:
+		return nil, errors.New("cannot set more than one filter within one grant")
+	case allowAllMsgs:
+		return types.NewAllowAllMessagesFilter(), nil
+	case len(msgKeys) != 0:
+		return types.NewAcceptedMessageKeysFilter(msgKeys...), nil
+	case len(rawMsgs) != 0:
+		msgs := make([]types.RawContractMessage, len(rawMsgs))
+		for i, msg := range rawMsgs {
+			msgs[i] = types.RawContractMessage(msg)
+		}
+		return types.NewAcceptedMessagesFilter(msgs...), nil
+	default:
+		return nil, errors.New("invalid filter setup")
+	}
+}
+
 func GrantStoreCodeAuthorizationCmd() *cobra.Command {
+	bech32Prefix := sdk.GetConfig().GetBech32AccountAddrPrefix()
 	cmd := &cobra.Command{
 		Use:   "store-code [grantee] [code_hash:permission]",
 		Short: "Grant authorization to upload contract code on behalf of you",
 		Long: fmt.Sprintf(`Grant authorization to an address.
 Examples:
-$ %s tx grant store-code <grantee_addr> 13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5:everybody  1wqrtry681b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5:nobody --expiration 1667979596
+$ %s tx grant store-code <grantee_addr> 13a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5:everybody 0x5a1fc994cc6d1c81b746ee0c0ff6f90043875e0bf1d9be6b7d779fc978dc2a5:nobody --expiration 1667979596
 
 $ %s tx grant store-code <grantee_addr> *:%s1l2rsakp388kuv9k8qzq6lrm9taddae7fpx59wm,%s1vx8knpllrj7n963p9ttd80w47kpacrhuts497x
-`, version.AppName, version.AppName, version.AppName, version.AppName),
+`, version.AppName, version.AppName, bech32Prefix, bech32Prefix),
 		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -598,7 +1130,7 @@ $ %s tx grant store-code <grantee_addr> *:%s1l2rsakp388kuv9k8qzq6lrm9taddae7fpx5
 			if err != nil {
 				return err
 			}
-			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), grantMsg)
+			return generateOrBroadcastTxCLI(clientCtx, cmd.Flags(), grantMsg)
 		},
 	}
 	flags.AddTxFlagsToCmd(cmd)
@@ -618,6 +1150,121 @@ func getExpireTime(cmd *cobra.Command) (*time.Time, error) {
 	return &e, nil
 }
 
+var (
+	errExpirationQuery        = errors.New("expiration query failed")
+	errExpirationFieldMissing = errors.New("expiration field not found")
+	errExpirationValue        = errors.New("unparsable expiration value")
+)
+
+func getExpireTimeFromContract(cmd *cobra.Command, clientCtx client.Context, contract string) (*time.Time, error) {
+	rawQuery, err := cmd.Flags().GetString(flagExpirationQuery)
+	if err != nil {
+		return nil, err
+	}
+	field, err := cmd.Flags().GetString(flagExpirationField)
+	if err != nil {
+		return nil, err
+	}
+	relative, err := cmd.Flags().GetBool(flagExpirationRelative)
+	if err != nil {
+		return nil, err
+	}
+	maxExpiration, err := cmd.Flags().GetDuration(flagMaxExpiration)
+	if err != nil {
+		return nil, err
+	}
+	exp, err := expirationFromContract(cmd.Context(), types.NewQueryClient(clientCtx), contract, rawQuery, field, relative, maxExpiration, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &exp, nil
+}
+
+// expirationFromContract runs the smart query and reads a Unix timestamp or, when relative, a duration in seconds
+// from the result field. A max expiration > 0 clamps the result to this duration from now.
+func expirationFromContract(
+	ctx context.Context,
+	queryClient types.QueryClient,
+	contract, rawQuery, field string,
+	relative bool,
+	maxExpiration time.Duration,
+	now time.Time,
+) (time.Time, error) {
+	if _, err := sdk.AccAddressFromBech32(contract); err != nil {
+		return time.Time{}, fmt.Errorf("expiration contract: %s", err)
+	}
+	if !json.Valid([]byte(rawQuery)) {
+		return time.Time{}, errors.New("expiration query must be valid json")
+	}
+	if field == "" {
+		return time.Time{}, errors.New("expiration field must be set")
+	}
+	res, err := queryClient.SmartContractState(ctx, &types.QuerySmartContractStateRequest{
+		Address:   contract,
+		QueryData: []byte(rawQuery),
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", errExpirationQuery, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(res.Data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s", errExpirationQuery, err)
+	}
+	for _, key := range strings.Split(field, ".") {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return time.Time{}, fmt.Errorf("%w: %q", errExpirationFieldMissing, field)
+		}
+		if value, ok = obj[key]; !ok {
+			return time.Time{}, fmt.Errorf("%w: %q", errExpirationFieldMissing, field)
+		}
+	}
+	// numbers may be encoded as json string, like Uint64 in CosmWasm
+	var rawValue string
+	switch v := value.(type) {
+	case json.Number:
+		rawValue = v.String()
+	case string:
+		rawValue = v
+	default:
+		return time.Time{}, fmt.Errorf("%w: %v in field %q", errExpirationValue, value, field)
+	}
+	seconds, err := strconv.ParseInt(rawValue, 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Time{}, fmt.Errorf("%w: %q in field %q", errExpirationValue, rawValue, field)
+	}
+
+	exp := time.Unix(seconds, 0)
+	if relative {
+		if seconds > int64(math.MaxInt64/time.Second) {
+			return time.Time{}, fmt.Errorf("%w: %q in field %q", errExpirationValue, rawValue, field)
+		}
+		exp = now.Add(time.Duration(seconds) * time.Second)
+	}
+	if maxExp := now.Add(maxExpiration); maxExpiration > 0 && exp.After(maxExp) {
+		exp = maxExp
+	}
+	return exp, nil
+}
+
+// parseGrantCodeHash returns the wildcard or the decoded hex checksum of a store code grant
+func parseGrantCodeHash(s string) ([]byte, error) {
+	switch s {
+	case types.CodehashWildcard:
+		return []byte(s), nil
+	case "":
+		return nil, errors.New("empty code hash")
+	}
+	codeHash, err := hexDecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid code hash %q: %w", s, err)
+	}
+	return codeHash, nil
+}
+
 func parseStoreCodeGrants(args []string) ([]types.CodeGrant, error) {
 	grants := make([]types.CodeGrant, len(args))
 	for i, c := range args {
@@ -628,9 +1275,13 @@ func parseStoreCodeGrants(args []string) ([]types.CodeGrant, error) {
 			return nil, errors.New("invalid format")
 		}
 
+		codeHash, err := parseGrantCodeHash(parts[0])
+		if err != nil {
+			return nil, err
+		}
 		if parts[1] == "*" {
 			grants[i] = types.CodeGrant{
-				CodeHash: []byte(parts[0]),
+				CodeHash: codeHash,
 			}
 			continue
 		}
@@ -640,7 +1291,7 @@ func parseStoreCodeGrants(args []string) ([]types.CodeGrant, error) {
 			return nil, err
 		}
 		grants[i] = types.CodeGrant{
-			CodeHash:              []byte(parts[0]),
+			CodeHash:              codeHash,
 			InstantiatePermission: &accessConfig,
 		}
 	}
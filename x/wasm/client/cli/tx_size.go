@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	consensustypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
+)
+
+const flagMaxTxBytes = "max-tx-bytes"
+
+// txSizeOverhead is reserved for the memo, the fee and the signature that are not part of the estimated tx
+const txSizeOverhead = 512
+
+func addMaxTxBytesFlag(cmd *cobra.Command) {
+	cmd.Flags().Int64(flagMaxTxBytes, cmttypes.DefaultBlockParams().MaxBytes, "The max tx bytes used in offline mode or when the consensus params can not be queried")
+}
+
+// txTooLargeError is returned when the estimated tx size exceeds the max tx bytes of the chain
+type txTooLargeError struct {
+	Size     int64
+	MaxBytes int64
+	Hint     string
+}
+
+func (e txTooLargeError) Error() string {
+	return fmt.Sprintf("tx of ~%d bytes exceeds the max tx bytes %d by %d bytes: %s", e.Size, e.MaxBytes, e.Size-e.MaxBytes, e.Hint)
+}
+
+// checkTxSize estimates the size of the tx with the messages and fails before broadcast when it exceeds the max tx
+// bytes of the chain. The hint tells how to split the messages.
+func checkTxSize(ctx context.Context, clientCtx client.Context, flagSet *flag.FlagSet, hint string, msgs ...sdk.Msg) error {
+	size, err := estimateTxSize(clientCtx.TxConfig, msgs...)
+	if err != nil {
+		return err
+	}
+	maxBytes, err := queryMaxTxBytes(ctx, clientCtx, flagSet)
+	if err != nil {
+		return err
+	}
+	err = validateTxSize(size, maxBytes, hint)
+	if err == nil {
+		recordPreflight(ctx, preflightTxSize, preflightPass, fmt.Sprintf("~%d of %d bytes", size, maxBytes))
+		return nil
+	}
+	recordPreflightResult(ctx, preflightTxSize, "", err)
+	return err
+}
+
+// estimateTxSize returns the size of the encoded unsigned tx with the messages plus the txSizeOverhead
+func estimateTxSize(txCfg client.TxConfig, msgs ...sdk.Msg) (int64, error) {
+	txBuilder := txCfg.NewTxBuilder()
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return 0, err
+	}
+	bz, err := txCfg.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return 0, fmt.Errorf("encode tx: %w", err)
+	}
+	return int64(len(bz)) + txSizeOverhead, nil
+}
+
+// queryMaxTxBytes returns the max block bytes of the consensus params as no tx can be larger. The flag value is
+// used in offline mode or when the query fails.
+func queryMaxTxBytes(ctx context.Context, clientCtx client.Context, flagSet *flag.FlagSet) (int64, error) {
+	if !clientCtx.Offline {
+		res, err := consensustypes.NewQueryClient(clientCtx).Params(ctx, &consensustypes.QueryParamsRequest{})
+		if err == nil && res.Params != nil && res.Params.Block != nil {
+			if res.Params.Block.MaxBytes == -1 {
+				return cmttypes.MaxBlockSizeBytes, nil
+			}
+			return res.Params.Block.MaxBytes, nil
+		}
+	}
+	maxBytes, err := flagSet.GetInt64(flagMaxTxBytes)
+	if err != nil {
+		return 0, fmt.Errorf("max tx bytes: %s", err)
+	}
+	return maxBytes, nil
+}
+
+// validateTxSize returns a txTooLargeError when the size exceeds the max bytes. Zero or less disables the check.
+func validateTxSize(size, maxBytes int64, hint string) error {
+	if maxBytes <= 0 || size <= maxBytes {
+		return nil
+	}
+	return txTooLargeError{Size: size, MaxBytes: maxBytes, Hint: hint}
+}
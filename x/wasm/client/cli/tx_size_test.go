@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCheckTxSize(t *testing.T) {
+	const mySender = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	txConfig := keeper.MakeEncodingConfig(t).TxConfig
+	msgs := []sdk.Msg{
+		&types.MsgStoreCode{Sender: mySender, WASMByteCode: testdata.HackatomContractWasm()},
+		&types.MsgStoreCode{Sender: mySender, WASMByteCode: testdata.ReflectContractWasm()},
+	}
+	size, err := estimateTxSize(txConfig, msgs...)
+	require.NoError(t, err)
+	require.Greater(t, size, int64(len(testdata.HackatomContractWasm())+len(testdata.ReflectContractWasm())))
+
+	specs := map[string]struct {
+		maxBytes int64
+		expErr   error
+	}{
+		"just under the limit": {
+			maxBytes: size + 1,
+		},
+		"at the limit": {
+			maxBytes: size,
+		},
+		"just over the limit": {
+			maxBytes: size - 1,
+			expErr:   txTooLargeError{Size: size, MaxBytes: size - 1, Hint: "split it"},
+		},
+		"no limit": {
+			maxBytes: 0,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := StoreManyCodeCmd()
+			require.NoError(t, cmd.Flags().Set(flagMaxTxBytes, strconv.FormatInt(spec.maxBytes, 10)))
+			clientCtx := client.Context{}.WithTxConfig(txConfig).WithOffline(true)
+
+			gotErr := checkTxSize(context.Background(), clientCtx, cmd.Flags(), "split it", msgs...)
+			assert.Equal(t, spec.expErr, gotErr)
+		})
+	}
+}
+
+func TestTxTooLargeError(t *testing.T) {
+	err := txTooLargeError{Size: 1100, MaxBytes: 1000, Hint: "upload the files with multiple txs"}
+	assert.Equal(t, "tx of ~1100 bytes exceeds the max tx bytes 1000 by 100 bytes: upload the files with multiple txs", err.Error())
+}
@@ -1,15 +1,34 @@
 package cli
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	sdkmath "cosmossdk.io/math"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 
 	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -76,6 +95,143 @@ func TestParseVerificationFlags(t *testing.T) {
 	}
 }
 
+func TestParseStoreCodeArgsGzipVariants(t *testing.T) {
+	const mySender = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	wasmRaw, err := os.ReadFile("../../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	expCode, err := ioutils.GzipIt(wasmRaw)
+	require.NoError(t, err)
+	gzipWithHeader := func(src []byte, header gzip.Header) []byte {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Header = header
+		_, err := w.Write(src)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+
+	specs := map[string]struct {
+		src    []byte
+		expErr bool
+	}{
+		"raw wasm": {
+			src: wasmRaw,
+		},
+		"plain gzip": {
+			src: expCode,
+		},
+		"gzip with file name": {
+			src: gzipWithHeader(wasmRaw, gzip.Header{Name: "hackatom.wasm"}),
+		},
+		"gzip with extra field": {
+			src: gzipWithHeader(wasmRaw, gzip.Header{Extra: []byte{'C', 'I', 2, 0, 1, 2}, Comment: "ci"}),
+		},
+		"multi-member gzip": {
+			src: append(gzipWithHeader(wasmRaw[:1000], gzip.Header{Name: "hackatom.wasm"}), gzipWithHeader(wasmRaw[1000:], gzip.Header{})...),
+		},
+		"broken gzip": {
+			src:    expCode[:len(expCode)-5],
+			expErr: true,
+		},
+		"other content": {
+			src:    []byte("not wasm"),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "code")
+			require.NoError(t, os.WriteFile(file, spec.src, 0o600))
+
+			gotMsg, gotErr := parseStoreCodeArgs(file, mySender, StoreCodeCmd().Flags())
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, expCode, gotMsg.WASMByteCode)
+		})
+	}
+}
+
+func TestParseAmountFlag(t *testing.T) {
+	// display denom normalized to its base denom
+	if _, ok := sdk.GetDenomUnit("wasmtoken"); !ok {
+		require.NoError(t, sdk.RegisterDenom("wasmtoken", sdkmath.LegacyOneDec()))
+		require.NoError(t, sdk.RegisterDenom("uwasmtoken", sdkmath.LegacyNewDecWithPrec(1, 6)))
+	}
+	specs := map[string]struct {
+		args   []string
+		exp    sdk.Coins
+		expErr bool
+	}{
+		"not set": {},
+		"single value": {
+			args: []string{"--amount=1stake,2atom"},
+			exp:  sdk.NewCoins(sdk.NewInt64Coin("stake", 1), sdk.NewInt64Coin("atom", 2)),
+		},
+		"multiple values merged": {
+			args: []string{"--amount=1stake", "--amount=2atom,3foo"},
+			exp:  sdk.NewCoins(sdk.NewInt64Coin("stake", 1), sdk.NewInt64Coin("atom", 2), sdk.NewInt64Coin("foo", 3)),
+		},
+		"empty value": {
+			args: []string{"--amount=1stake", "--amount="},
+			exp:  sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+		},
+		"display denom normalized": {
+			args: []string{"--amount=2wasmtoken", "--amount=1stake"},
+			exp:  sdk.NewCoins(sdk.NewInt64Coin("uwasmtoken", 2_000_000), sdk.NewInt64Coin("stake", 1)),
+		},
+		"unsorted": {
+			args: []string{"--amount=3uosmo,5uatom"},
+			exp:  sdk.Coins{sdk.NewInt64Coin("uatom", 5), sdk.NewInt64Coin("uosmo", 3)},
+		},
+		"duplicate denom in single value merged": {
+			args: []string{"--amount=5uatom,3uosmo,2uatom"},
+			exp:  sdk.Coins{sdk.NewInt64Coin("uatom", 7), sdk.NewInt64Coin("uosmo", 3)},
+		},
+		"duplicate denom in multiple values merged": {
+			args: []string{"--amount=1stake", "--amount=2stake"},
+			exp:  sdk.NewCoins(sdk.NewInt64Coin("stake", 3)),
+		},
+		"duplicate denom after normalization merged": {
+			args: []string{"--amount=1wasmtoken", "--amount=1uwasmtoken"},
+			exp:  sdk.NewCoins(sdk.NewInt64Coin("uwasmtoken", 1_000_001)),
+		},
+		"zero amount": {
+			args:   []string{"--amount=0stake"},
+			expErr: true,
+		},
+		"zero amount with other coins": {
+			args:   []string{"--amount=1atom,0stake"},
+			expErr: true,
+		},
+		"zero amount after normalization": {
+			args:   []string{"--amount=0.0000001wasmtoken"},
+			expErr: true,
+		},
+		"invalid coins": {
+			args:   []string{"--amount=1stake", "--amount=foo"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := ExecuteContractCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+
+			got, gotErr := parseAmountFlag(flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
 func TestParseAccessConfigFlags(t *testing.T) {
 	specs := map[string]struct {
 		args   []string
@@ -109,11 +265,32 @@ func TestParseAccessConfigFlags(t *testing.T) {
 		"not set": {
 			args: []string{},
 		},
+		"everybody without value": {
+			args:   []string{"--instantiate-everybody"},
+			expCfg: &types.AccessConfig{Permission: types.AccessTypeEverybody},
+		},
+		"nobody without value": {
+			args:   []string{"--instantiate-nobody"},
+			expCfg: &types.AccessConfig{Permission: types.AccessTypeNobody},
+		},
+		"everybody false for chain default": {
+			args: []string{"--instantiate-everybody=false"},
+		},
+		"everybody false in capitals": {
+			args: []string{"--instantiate-everybody=FALSE"},
+		},
+		"nobody not a boolean": {
+			args:   []string{"--instantiate-nobody=foo"},
+			expErr: true,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
 			flags := StoreCodeCmd().Flags()
-			require.NoError(t, flags.Parse(spec.args))
+			if err := flags.Parse(spec.args); err != nil {
+				require.True(t, spec.expErr, err)
+				return
+			}
 			gotCfg, gotErr := parseAccessConfigFlags(flags)
 			if spec.expErr {
 				require.Error(t, gotErr)
@@ -125,7 +302,317 @@ func TestParseAccessConfigFlags(t *testing.T) {
 	}
 }
 
+func TestParseAccessConfigFlagsCombinations(t *testing.T) {
+	const myAddr = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	anyOfCfg := types.AccessTypeAnyOfAddresses.With(sdk.MustAccAddressFromBech32(myAddr))
+	type flagValue struct {
+		arg string
+		cfg *types.AccessConfig
+	}
+	everybodyValues := []*flagValue{nil, {"--instantiate-everybody=true", &types.AllowEverybody}, {"--instantiate-everybody=false", nil}}
+	nobodyValues := []*flagValue{nil, {"--instantiate-nobody=true", &types.AllowNobody}, {"--instantiate-nobody=false", nil}}
+	anyOfValues := []*flagValue{nil, {"--instantiate-anyof-addresses=" + myAddr, &anyOfCfg}}
+
+	for _, everybody := range everybodyValues {
+		for _, nobody := range nobodyValues {
+			for _, anyOf := range anyOfValues {
+				var (
+					args   []string
+					expCfg *types.AccessConfig
+				)
+				for _, v := range []*flagValue{everybody, nobody, anyOf} {
+					if v != nil {
+						args = append(args, v.arg)
+						expCfg = v.cfg
+					}
+				}
+				t.Run(strings.Join(args, " "), func(t *testing.T) {
+					flags := StoreCodeCmd().Flags()
+					require.NoError(t, flags.Parse(args))
+
+					gotCfg, gotErr := parseAccessConfigFlags(flags)
+
+					if len(args) > 1 {
+						var conflictErr accessConfigFlagsConflictError
+						require.ErrorAs(t, gotErr, &conflictErr)
+						expFlags := make([]string, len(args))
+						for i, a := range args {
+							expFlags[i], _, _ = strings.Cut(a, "=")
+						}
+						assert.Equal(t, expFlags, conflictErr.Flags)
+						return
+					}
+					require.NoError(t, gotErr)
+					assert.Equal(t, expCfg, gotCfg)
+				})
+			}
+		}
+	}
+}
+
+func TestParsePinFlag(t *testing.T) {
+	myAuthority := DefaultGovAuthority.String()
+	specs := map[string]struct {
+		args   []string
+		sender string
+		expMsg *types.MsgPinCodes
+		expErr bool
+	}{
+		"pin by authority": {
+			args:   []string{"--pin"},
+			sender: myAuthority,
+			expMsg: &types.MsgPinCodes{Authority: myAuthority, CodeIDs: []uint64{types.LastStoredCodeID}},
+		},
+		"pin by custom authority": {
+			args:   []string{"--pin", "--authority=cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"},
+			sender: "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x",
+			expMsg: &types.MsgPinCodes{Authority: "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x", CodeIDs: []uint64{types.LastStoredCodeID}},
+		},
+		"pin by other sender": {
+			args:   []string{"--pin"},
+			sender: "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x",
+			expErr: true,
+		},
+		"not set": {
+			sender: "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := StoreCodeCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotMsg, gotErr := parsePinFlag(flags, spec.sender)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), "governance proposal")
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMsg, gotMsg)
+		})
+	}
+}
+
+func TestGrantContractCmdFlags(t *testing.T) {
+	const (
+		myGrantee  = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+		myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+	)
+	myGrant, err := types.NewContractGrant(sdk.MustAccAddressFromBech32(myContract), types.NewMaxCallsLimit(1), types.NewAllowAllMessagesFilter())
+	require.NoError(t, err)
+	specs := map[string]struct {
+		args   []string
+		expErr bool
+	}{
+		"flags after positionals": {
+			args: []string{"contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-calls=1", "--no-token-transfer", "--expiration=1667979596", "--fees=1stake"},
+		},
+		"flags before positionals": {
+			args: []string{"contract", "--allow-all-messages", "--max-calls", "1", "--no-token-transfer", "--fees", "1stake", "--expiration=1667979596", myGrantee, "execution", myContract},
+		},
+		"flags before sub command": {
+			args: []string{"--fees=1stake", "contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-calls=1", "--no-token-transfer", "--expiration=1667979596"},
+		},
+		"flags between positionals": {
+			args: []string{"contract", myGrantee, "--allow-all-messages", "execution", "--max-calls=1", "--no-token-transfer", myContract, "--expiration=1667979596"},
+		},
+		"multiple filters": {
+			args:   []string{"contract", myGrantee, "execution", myContract, "--allow-all-messages", "--allow-msg-keys=foo", "--max-calls=1", "--no-token-transfer", "--expiration=1667979596"},
+			expErr: true,
+		},
+		"no filter": {
+			args:   []string{"contract", myGrantee, "execution", myContract, "--max-calls=1", "--no-token-transfer", "--expiration=1667979596"},
+			expErr: true,
+		},
+		"no expiration": {
+			args:   []string{"contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-calls=1", "--no-token-transfer"},
+			expErr: true,
+		},
+		"expiration and expiration from contract": {
+			args: []string{
+				"contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-calls=1", "--no-token-transfer", "--expiration=1667979596",
+				"--expiration-from-contract=" + myContract, "--expiration-query={}", "--expiration-field=foo",
+			},
+			expErr: true,
+		},
+		"expiration from contract without query": {
+			args:   []string{"contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-calls=1", "--no-token-transfer", "--expiration-from-contract=" + myContract},
+			expErr: true,
+		},
+		"max funds and no token transfer": {
+			args:   []string{"contract", myGrantee, "execution", myContract, "--allow-all-messages", "--max-funds=1stake", "--no-token-transfer", "--expiration=1667979596"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			cmd := GrantCmd()
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			contractCmd, _, err := cmd.Find([]string{"contract"})
+			require.NoError(t, err)
+			var gotGrant *contractGrant
+			contractCmd.RunE = func(cmd *cobra.Command, args []string) (err error) {
+				gotGrant, err = parseContractGrantArgs(args, cmd.Flags())
+				return err
+			}
+			cmd.SetArgs(spec.args)
+
+			gotErr := cmd.Execute()
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, myGrantee, gotGrant.grantee.String())
+			assert.Equal(t, types.NewContractExecutionAuthorization(*myGrant), gotGrant.authorization)
+		})
+	}
+}
+
+func TestParsersWith32ByteAddresses(t *testing.T) {
+	cfg := sdk.GetConfig()
+	prevVerifier := cfg.GetAddressVerifier()
+	t.Cleanup(func() { cfg.SetAddressVerifier(prevVerifier) })
+	cfg.SetAddressVerifier(types.VerifyAddressLen())
+
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	otherAddr := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+	tooLongAddr := sdk.AccAddress(bytes.Repeat([]byte{3}, 33)).String()
+
+	t.Run("access config", func(t *testing.T) {
+		gotCfg, err := parseAccessConfig(myAddr + "," + otherAddr)
+		require.NoError(t, err)
+		assert.Equal(t, []string{myAddr, otherAddr}, gotCfg.Addresses)
+		_, err = parseAccessConfig(tooLongAddr)
+		require.Error(t, err)
+	})
+	t.Run("store code grants", func(t *testing.T) {
+		gotGrants, err := parseStoreCodeGrants([]string{"*:" + myAddr})
+		require.NoError(t, err)
+		require.Len(t, gotGrants, 1)
+		assert.Equal(t, []string{myAddr}, gotGrants[0].InstantiatePermission.Addresses)
+	})
+	t.Run("contract grant", func(t *testing.T) {
+		cmd := GrantAuthorizationCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--allow-all-messages", "--max-calls=1", "--no-token-transfer", "--expiration=1667979596"}))
+		gotGrant, err := parseContractGrantArgs([]string{myAddr, "execution", otherAddr}, cmd.Flags())
+		require.NoError(t, err)
+		assert.Equal(t, myAddr, gotGrant.grantee.String())
+		_, err = parseContractGrantArgs([]string{tooLongAddr, "execution", otherAddr}, cmd.Flags())
+		require.Error(t, err)
+	})
+	t.Run("build address", func(t *testing.T) {
+		gotRsp, err := keeper.BuildAddressPredictable(&types.QueryBuildAddressRequest{
+			CodeHash:       hex.EncodeToString(bytes.Repeat([]byte{4}, 32)),
+			CreatorAddress: myAddr,
+			Salt:           "01",
+		})
+		require.NoError(t, err)
+		gotAddr, err := sdk.AccAddressFromBech32(gotRsp.Address)
+		require.NoError(t, err)
+		assert.Len(t, gotAddr, types.ContractAddrLen)
+	})
+}
+
+func TestParseStoreManyCodeArgs(t *testing.T) {
+	const (
+		mySender = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+		hackatom = "../../keeper/testdata/hackatom.wasm"
+		zipped   = "../../keeper/testdata/hackatom.wasm.gzip"
+		reflect  = "../../keeper/testdata/reflect_2_0.wasm"
+	)
+	specs := map[string]struct {
+		files      []string
+		expMsgs    int
+		expSkipped []string
+		expErr     bool
+	}{
+		"different codes": {
+			files:   []string{hackatom, reflect},
+			expMsgs: 2,
+		},
+		"same file twice": {
+			files:      []string{hackatom, reflect, hackatom},
+			expMsgs:    2,
+			expSkipped: []string{hackatom},
+		},
+		"same code zipped": {
+			files:      []string{hackatom, zipped},
+			expMsgs:    1,
+			expSkipped: []string{zipped},
+		},
+		"missing file": {
+			files:  []string{hackatom, "non-existing.wasm"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := StoreManyCodeCmd().Flags()
+			gotMsgs, gotSkipped, gotErr := parseStoreManyCodeArgs(spec.files, mySender, flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Len(t, gotMsgs, spec.expMsgs)
+			assert.Equal(t, spec.expSkipped, gotSkipped)
+		})
+	}
+}
+
+func TestParseUpdateInstantiateConfigArgs(t *testing.T) {
+	const mySender = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	specs := map[string]struct {
+		args   []string
+		expMsg types.MsgUpdateInstantiateConfig
+		expErr bool
+	}{
+		"permission flag": {
+			args:   []string{"--instantiate-nobody=true"},
+			expMsg: types.MsgUpdateInstantiateConfig{Sender: mySender, CodeID: 1, NewInstantiatePermission: &types.AllowNobody},
+		},
+		"reset to default": {
+			args:   []string{"--reset-to-default"},
+			expMsg: types.MsgUpdateInstantiateConfig{Sender: mySender, CodeID: 1, ResetToDefault: true},
+		},
+		"reset to default with everybody": {
+			args:   []string{"--reset-to-default", "--instantiate-everybody=true"},
+			expErr: true,
+		},
+		"reset to default with nobody": {
+			args:   []string{"--reset-to-default", "--instantiate-nobody=false"},
+			expErr: true,
+		},
+		"reset to default with any of addresses": {
+			args:   []string{"--reset-to-default", "--instantiate-anyof-addresses=" + mySender},
+			expErr: true,
+		},
+		"reset to default disabled": {
+			args:   []string{"--reset-to-default=false", "--instantiate-everybody=true"},
+			expMsg: types.MsgUpdateInstantiateConfig{Sender: mySender, CodeID: 1, NewInstantiatePermission: &types.AllowEverybody},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := UpdateInstantiateConfigCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotMsg, gotErr := parseUpdateInstantiateConfigArgs("1", mySender, flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expMsg, gotMsg)
+		})
+	}
+}
+
 func TestParseStoreCodeGrants(t *testing.T) {
+	checksum1, err := hex.DecodeString(testdata.ChecksumHackatom)
+	require.NoError(t, err)
+	checksum2 := bytes.Repeat([]byte{0xab}, 32)
 	specs := map[string]struct {
 		src    []string
 		exp    []types.CodeGrant
@@ -176,16 +663,16 @@ func TestParseStoreCodeGrants(t *testing.T) {
 			},
 		},
 		"multiple code hashes with different permissions": {
-			src: []string{"any_checksum_1:cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x,cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", "any_checksum_2:nobody"},
+			src: []string{testdata.ChecksumHackatom + ":cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x,cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", hex.EncodeToString(checksum2) + ":nobody"},
 			exp: []types.CodeGrant{
 				{
-					CodeHash: []byte("any_checksum_1"),
+					CodeHash: checksum1,
 					InstantiatePermission: &types.AccessConfig{
 						Permission: types.AccessTypeAnyOfAddresses,
 						Addresses:  []string{"cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x", "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"},
 					},
 				}, {
-					CodeHash: []byte("any_checksum_2"),
+					CodeHash: checksum2,
 					InstantiatePermission: &types.AccessConfig{
 						Permission: types.AccessTypeNobody,
 					},
@@ -193,21 +680,35 @@ func TestParseStoreCodeGrants(t *testing.T) {
 			},
 		},
 		"code hash : wildcard": {
-			src: []string{"any_checksum_1:*"},
+			src: []string{testdata.ChecksumHackatom + ":*"},
 			exp: []types.CodeGrant{{
-				CodeHash: []byte("any_checksum_1"),
+				CodeHash: checksum1,
 			}},
 		},
 		"code hash : any of addresses - empty list": {
-			src:    []string{"any_checksum_1:"},
+			src:    []string{testdata.ChecksumHackatom + ":"},
 			expErr: true,
 		},
 		"code hash : any of addresses - invalid address": {
-			src:    []string{"any_checksum_1:foo"},
+			src:    []string{testdata.ChecksumHackatom + ":foo"},
 			expErr: true,
 		},
 		"code hash : any of addresses - duplicate address": {
-			src:    []string{"any_checksum_1:cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x,cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"},
+			src:    []string{testdata.ChecksumHackatom + ":cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x,cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"},
+			expErr: true,
+		},
+		"code hash with 0x prefix": {
+			src: []string{"0x" + testdata.ChecksumHackatom + ":*"},
+			exp: []types.CodeGrant{{
+				CodeHash: checksum1,
+			}},
+		},
+		"code hash with odd length": {
+			src:    []string{testdata.ChecksumHackatom[1:] + ":*"},
+			expErr: true,
+		},
+		"code hash not hex": {
+			src:    []string{"any_checksum_1:*"},
 			expErr: true,
 		},
 		"empty code hash": {
@@ -227,3 +728,652 @@ func TestParseStoreCodeGrants(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyAdminExists(t *testing.T) {
+	const myAdmin = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	specs := map[string]struct {
+		accountErr  error
+		contractErr error
+		expErr      bool
+	}{
+		"existing account": {},
+		"existing contract": {
+			accountErr: status.Error(codes.NotFound, "not found"),
+		},
+		"unknown address": {
+			accountErr:  status.Error(codes.NotFound, "not found"),
+			contractErr: types.ErrNoSuchContractFn(myAdmin),
+			expErr:      true,
+		},
+		"account query failed": {
+			accountErr: status.Error(codes.Unavailable, "connection refused"),
+			expErr:     true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			authQuery := &mockAuthQueryClient{err: spec.accountErr}
+			wasmQuery := &mockWasmQueryClient{err: spec.contractErr}
+			gotErr := verifyAdminExists(context.Background(), authQuery, wasmQuery, myAdmin)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+type mockAuthQueryClient struct {
+	authtypes.QueryClient
+	err error
+}
+
+func (m mockAuthQueryClient) Account(_ context.Context, _ *authtypes.QueryAccountRequest, _ ...grpc.CallOption) (*authtypes.QueryAccountResponse, error) {
+	return &authtypes.QueryAccountResponse{}, m.err
+}
+
+type mockWasmQueryClient struct {
+	types.QueryClient
+	err      error
+	smartRsp []byte
+}
+
+func (m mockWasmQueryClient) ContractInfo(_ context.Context, _ *types.QueryContractInfoRequest, _ ...grpc.CallOption) (*types.QueryContractInfoResponse, error) {
+	return &types.QueryContractInfoResponse{}, m.err
+}
+
+func (m mockWasmQueryClient) SmartContractState(_ context.Context, _ *types.QuerySmartContractStateRequest, _ ...grpc.CallOption) (*types.QuerySmartContractStateResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &types.QuerySmartContractStateResponse{Data: m.smartRsp}, nil
+}
+
+func TestParseSetContractStateArgs(t *testing.T) {
+	const myAuthority = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	specs := map[string]struct {
+		content   string
+		expModels []types.Model
+		expErr    bool
+	}{
+		"base64 pairs": {
+			content:   `[{"key":"Zm9v","value":"ImJhciI="},{"key":"AAE=","value":""}]`,
+			expModels: []types.Model{{Key: []byte("foo"), Value: []byte(`"bar"`)}, {Key: []byte{0x0, 0x1}, Value: []byte{}}},
+		},
+		"invalid base64": {
+			content: `[{"key":"foo","value":"bar"}]`,
+			expErr:  true,
+		},
+		"empty list": {
+			content: `[]`,
+			expErr:  true,
+		},
+		"invalid json": {
+			content: `{`,
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "state.json")
+			require.NoError(t, os.WriteFile(file, []byte(spec.content), 0o600))
+			gotMsg, gotErr := parseSetContractStateArgs(myContract, file, myAuthority)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, myAuthority, gotMsg.Authority)
+			assert.Equal(t, myContract, gotMsg.Contract)
+			assert.Equal(t, spec.expModels, gotMsg.Models)
+		})
+	}
+}
+
+func TestParseSudoContractArgs(t *testing.T) {
+	const myAuthority = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	msgFile := filepath.Join(t.TempDir(), "sudo.json")
+	require.NoError(t, os.WriteFile(msgFile, []byte(`{"from_file":{}}`), 0o600))
+
+	specs := map[string]struct {
+		rawMsg string
+		expMsg []byte
+		expErr bool
+	}{
+		"inline json": {
+			rawMsg: `{"inline":{}}`,
+			expMsg: []byte(`{"inline":{}}`),
+		},
+		"msg from file": {
+			rawMsg: "@" + msgFile,
+			expMsg: []byte(`{"from_file":{}}`),
+		},
+		"unknown file": {
+			rawMsg: "@" + filepath.Join(t.TempDir(), "unknown.json"),
+			expErr: true,
+		},
+		"invalid json": {
+			rawMsg: `not json`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotMsg, gotErr := parseSudoContractArgs(myContract, spec.rawMsg, myAuthority)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, myAuthority, gotMsg.Authority)
+			assert.Equal(t, myContract, gotMsg.Contract)
+			assert.Equal(t, types.RawContractMessage(spec.expMsg), gotMsg.Msg)
+		})
+	}
+}
+
+func TestCheckSudoAuthority(t *testing.T) {
+	const councilAuthority = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	govAuthority := DefaultGovAuthority.String()
+	specs := map[string]struct {
+		authority    string
+		generateOnly bool
+		force        bool
+		expErr       bool
+	}{
+		"gov authority broadcast": {
+			authority: govAuthority,
+			expErr:    true,
+		},
+		"gov authority generate only": {
+			authority:    govAuthority,
+			generateOnly: true,
+		},
+		"gov authority forced": {
+			authority: govAuthority,
+			force:     true,
+		},
+		"non gov authority broadcast": {
+			authority: councilAuthority,
+		},
+		"non gov authority generate only": {
+			authority:    councilAuthority,
+			generateOnly: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := checkSudoAuthority(spec.authority, spec.generateOnly, spec.force)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestExpirationFromContract(t *testing.T) {
+	myContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	now := time.Unix(1_700_000_000, 0)
+	specs := map[string]struct {
+		smartRsp      string
+		queryErr      error
+		field         string
+		relative      bool
+		maxExpiration time.Duration
+		exp           time.Time
+		expErr        error
+	}{
+		"timestamp": {
+			smartRsp: `{"expires":1700086400}`,
+			field:    "expires",
+			exp:      time.Unix(1_700_086_400, 0),
+		},
+		"timestamp as string": {
+			smartRsp: `{"expires":"1700086400"}`,
+			field:    "expires",
+			exp:      time.Unix(1_700_086_400, 0),
+		},
+		"duration": {
+			smartRsp: `{"config":{"unbonding_period":3600}}`,
+			field:    "config.unbonding_period",
+			relative: true,
+			exp:      now.Add(time.Hour),
+		},
+		"duration clamped": {
+			smartRsp:      `{"config":{"unbonding_period":1814400}}`,
+			field:         "config.unbonding_period",
+			relative:      true,
+			maxExpiration: 24 * time.Hour,
+			exp:           now.Add(24 * time.Hour),
+		},
+		"timestamp clamped": {
+			smartRsp:      `{"expires":1800000000}`,
+			field:         "expires",
+			maxExpiration: time.Hour,
+			exp:           now.Add(time.Hour),
+		},
+		"timestamp below max": {
+			smartRsp:      `{"expires":1700000060}`,
+			field:         "expires",
+			maxExpiration: time.Hour,
+			exp:           time.Unix(1_700_000_060, 0),
+		},
+		"query failed": {
+			queryErr: errors.New("testing"),
+			field:    "expires",
+			expErr:   errExpirationQuery,
+		},
+		"field missing": {
+			smartRsp: `{"expiration":1700086400}`,
+			field:    "expires",
+			expErr:   errExpirationFieldMissing,
+		},
+		"nested field missing": {
+			smartRsp: `{"config":1}`,
+			field:    "config.unbonding_period",
+			expErr:   errExpirationFieldMissing,
+		},
+		"unparsable value": {
+			smartRsp: `{"expires":"tomorrow"}`,
+			field:    "expires",
+			expErr:   errExpirationValue,
+		},
+		"decimal value": {
+			smartRsp: `{"expires":1.5}`,
+			field:    "expires",
+			expErr:   errExpirationValue,
+		},
+		"negative value": {
+			smartRsp: `{"expires":-1}`,
+			field:    "expires",
+			expErr:   errExpirationValue,
+		},
+		"object value": {
+			smartRsp: `{"expires":{"at_time":"1"}}`,
+			field:    "expires",
+			expErr:   errExpirationValue,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockWasmQueryClient{err: spec.queryErr, smartRsp: []byte(spec.smartRsp)}
+			got, gotErr := expirationFromContract(context.Background(), queryClient, myContract, `{"config":{}}`, spec.field, spec.relative, spec.maxExpiration, now)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestFindExistingInstance(t *testing.T) {
+	mySender := sdk.AccAddress(bytes.Repeat([]byte{1}, 32))
+	myChecksum := bytes.Repeat([]byte{2}, 32)
+	mySalt := []byte("my salt")
+	myContractAddr := keeper.BuildContractAddressPredictable(myChecksum, mySender, mySalt, []byte{}).String()
+	myFixedMsgContractAddr := keeper.BuildContractAddressPredictable(myChecksum, mySender, mySalt, []byte(`{}`)).String()
+
+	specs := map[string]struct {
+		fixMsg  bool
+		history map[string][]types.ContractCodeHistoryEntry
+		expAddr string
+		expErr  bool
+	}{
+		"no contract": {
+			history: map[string][]types.ContractCodeHistoryEntry{},
+		},
+		"contract with same code id": {
+			history: map[string][]types.ContractCodeHistoryEntry{
+				myContractAddr: {{CodeID: 1}},
+			},
+			expAddr: myContractAddr,
+		},
+		"contract with same code id and fixed msg": {
+			fixMsg: true,
+			history: map[string][]types.ContractCodeHistoryEntry{
+				myFixedMsgContractAddr: {{CodeID: 1}},
+			},
+			expAddr: myFixedMsgContractAddr,
+		},
+		"contract with different code id": {
+			history: map[string][]types.ContractCodeHistoryEntry{
+				myContractAddr: {{CodeID: 2}},
+			},
+			expErr: true,
+		},
+		"contract migrated from same code id": {
+			history: map[string][]types.ContractCodeHistoryEntry{
+				myContractAddr: {{CodeID: 1}, {CodeID: 2}},
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &instantiate2QueryClientMock{checksum: myChecksum, history: spec.history}
+			msg := &types.MsgInstantiateContract2{
+				Sender: mySender.String(),
+				CodeID: 1,
+				Msg:    []byte(`{}`),
+				Salt:   mySalt,
+				FixMsg: spec.fixMsg,
+			}
+			gotAddr, gotErr := findExistingInstance(context.Background(), queryClient, msg)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAddr, gotAddr)
+		})
+	}
+}
+
+type instantiate2QueryClientMock struct {
+	types.QueryClient
+	checksum []byte
+	history  map[string][]types.ContractCodeHistoryEntry
+}
+
+func (m instantiate2QueryClientMock) CodeInfo(_ context.Context, req *types.QueryCodeInfoRequest, _ ...grpc.CallOption) (*types.QueryCodeInfoResponse, error) {
+	return &types.QueryCodeInfoResponse{CodeID: req.CodeId, Checksum: m.checksum}, nil
+}
+
+// ContractHistory returns the last entry only, like a reverse query with limit 1
+func (m instantiate2QueryClientMock) ContractHistory(_ context.Context, req *types.QueryContractHistoryRequest, _ ...grpc.CallOption) (*types.QueryContractHistoryResponse, error) {
+	entries := m.history[req.Address]
+	if len(entries) == 0 {
+		return &types.QueryContractHistoryResponse{Entries: []types.ContractCodeHistoryEntry{}}, nil
+	}
+	return &types.QueryContractHistoryResponse{Entries: entries[len(entries)-1:]}, nil
+}
+
+func TestCheckStoreCodeGrant(t *testing.T) {
+	const (
+		myGranter = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+		myGrantee = "cosmos1wyqh3n50ecatjg4vww5crmtd0nmyzusnwckw4at4gluc0m5m477q4arfek"
+	)
+	hackatomChecksum, err := hex.DecodeString(testdata.ChecksumHackatom)
+	require.NoError(t, err)
+	otherChecksum := bytes.Repeat([]byte{1}, 32)
+	everybody := types.AllowEverybody
+
+	specs := map[string]struct {
+		authorizations []proto.Message
+		permission     *types.AccessConfig
+		expWarning     bool
+	}{
+		"hash match": {
+			authorizations: []proto.Message{types.NewStoreCodeAuthorization(
+				types.CodeGrant{CodeHash: otherChecksum},
+				types.CodeGrant{CodeHash: hackatomChecksum},
+			)},
+		},
+		"hash mismatch": {
+			authorizations: []proto.Message{types.NewStoreCodeAuthorization(types.CodeGrant{CodeHash: otherChecksum})},
+			expWarning:     true,
+		},
+		"wildcard": {
+			authorizations: []proto.Message{types.NewStoreCodeAuthorization(types.CodeGrant{CodeHash: []byte(types.CodehashWildcard)})},
+		},
+		"wildcard with permission mismatch": {
+			authorizations: []proto.Message{types.NewStoreCodeAuthorization(types.CodeGrant{
+				CodeHash:              []byte(types.CodehashWildcard),
+				InstantiatePermission: &types.AllowNobody,
+			})},
+			permission: &everybody,
+			expWarning: true,
+		},
+		"generic authorization": {
+			authorizations: []proto.Message{authz.NewGenericAuthorization(sdk.MsgTypeURL(&types.MsgStoreCode{}))},
+		},
+		"no grant": {
+			expWarning: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockAuthzQueryClient{}
+			for _, a := range spec.authorizations {
+				anyAuthorization, err := codectypes.NewAnyWithValue(a)
+				require.NoError(t, err)
+				queryClient.grants = append(queryClient.grants, &authz.Grant{Authorization: anyAuthorization})
+			}
+			msg := &types.MsgStoreCode{
+				Sender:                myGranter,
+				WASMByteCode:          testdata.HackatomContractWasm(),
+				InstantiatePermission: spec.permission,
+			}
+			gotWarning, gotErr := checkStoreCodeGrant(context.Background(), queryClient, myGranter, myGrantee, msg)
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expWarning, gotWarning != "", gotWarning)
+		})
+	}
+}
+
+type mockAuthzQueryClient struct {
+	authz.QueryClient
+	grants []*authz.Grant
+}
+
+func (m mockAuthzQueryClient) Grants(_ context.Context, _ *authz.QueryGrantsRequest, _ ...grpc.CallOption) (*authz.QueryGrantsResponse, error) {
+	return &authz.QueryGrantsResponse{Grants: m.grants}, nil
+}
+
+func TestTxCmdAliases(t *testing.T) {
+	specs := map[string]string{
+		"instantiate":  "instantiate",
+		"start":        "instantiate",
+		"init":         "instantiate",
+		"inst":         "instantiate",
+		"i":            "instantiate",
+		"instantiate2": "instantiate2",
+		"start2":       "instantiate2",
+		"init2":        "instantiate2",
+		"i2":           "instantiate2",
+		"upload":       "store",
+		"st":           "store",
+		"s":            "store",
+		"run":          "execute",
+		"call":         "execute",
+		"exec":         "execute",
+		"ex":           "execute",
+		"e":            "execute",
+		"update":       "migrate",
+		"mig":          "migrate",
+		"m":            "migrate",
+		"new-admin":    "set-contract-admin",
+		"admin":        "set-contract-admin",
+		"set-adm":      "set-contract-admin",
+		"sa":           "set-contract-admin",
+		"clear-admin":  "clear-contract-admin",
+		"clr-adm":      "clear-contract-admin",
+	}
+	for alias, expCmd := range specs {
+		t.Run(alias, func(t *testing.T) {
+			gotCmd, _, err := GetTxCmd().Find([]string{alias})
+			require.NoError(t, err)
+			assert.Equal(t, expCmd, gotCmd.Name())
+		})
+	}
+}
+
+func TestCmdAliasesUnique(t *testing.T) {
+	var assertUnique func(t *testing.T, parent *cobra.Command)
+	assertUnique = func(t *testing.T, parent *cobra.Command) {
+		owners := make(map[string]string)
+		for _, c := range parent.Commands() {
+			for _, n := range append([]string{c.Name()}, c.Aliases...) {
+				if other, exists := owners[n]; exists && other != c.Name() {
+					t.Errorf("%q is used by %q and %q in %q", n, other, c.Name(), parent.CommandPath())
+				}
+				owners[n] = c.Name()
+			}
+			assertUnique(t, c)
+		}
+	}
+	assertUnique(t, GetTxCmd())
+	assertUnique(t, GetQueryCmd())
+}
+
+func TestInstantiateArgs(t *testing.T) {
+	specs := map[string]struct {
+		src    []string
+		expErr string
+	}{
+		"two args": {
+			src: []string{"1", "{}"},
+		},
+		"salt given": {
+			src:    []string{"1", "{}", "6d7973616c74"},
+			expErr: "use instantiate2",
+		},
+		"missing msg": {
+			src:    []string{"1"},
+			expErr: "accepts 2 arg(s), received 1",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotErr := instantiateArgs(InstantiateContractCmd(), spec.src)
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+		})
+	}
+}
+
+func TestParseInstantiateArgsAdminPolicy(t *testing.T) {
+	const (
+		mySender = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+		myAdmin  = "cosmos1vx8knpllrj7n963p9ttd80w47kpacrhuts497x"
+	)
+	specs := map[string]struct {
+		policy     types.DefaultAdminPolicy
+		args       []string
+		expAdmin   string
+		expNoAdmin bool
+		expErr     string
+	}{
+		"unspecified - admin": {
+			policy:   types.DefaultAdminPolicyUnspecified,
+			args:     []string{"--admin=" + myAdmin},
+			expAdmin: myAdmin,
+		},
+		"unspecified - no admin": {
+			policy: types.DefaultAdminPolicyUnspecified,
+			args:   []string{"--no-admin"},
+		},
+		"unspecified - empty": {
+			policy: types.DefaultAdminPolicyUnspecified,
+			expErr: "you must set an admin or explicitly pass --no-admin",
+		},
+		"error - admin": {
+			policy:   types.DefaultAdminPolicyError,
+			args:     []string{"--admin=" + myAdmin},
+			expAdmin: myAdmin,
+		},
+		"error - no admin": {
+			policy: types.DefaultAdminPolicyError,
+			args:   []string{"--no-admin"},
+		},
+		"error - empty": {
+			policy: types.DefaultAdminPolicyError,
+			expErr: "you must set an admin or explicitly pass --no-admin",
+		},
+		"creator - admin": {
+			policy:   types.DefaultAdminPolicyCreator,
+			args:     []string{"--admin=" + myAdmin},
+			expAdmin: myAdmin,
+		},
+		"creator - no admin": {
+			policy:     types.DefaultAdminPolicyCreator,
+			args:       []string{"--no-admin"},
+			expNoAdmin: true,
+		},
+		"creator - empty": {
+			policy: types.DefaultAdminPolicyCreator,
+		},
+		"none - admin": {
+			policy:   types.DefaultAdminPolicyNone,
+			args:     []string{"--admin=" + myAdmin},
+			expAdmin: myAdmin,
+		},
+		"none - no admin": {
+			policy: types.DefaultAdminPolicyNone,
+			args:   []string{"--no-admin"},
+		},
+		"none - empty": {
+			policy: types.DefaultAdminPolicyNone,
+		},
+		"admin and no admin": {
+			policy: types.DefaultAdminPolicyCreator,
+			args:   []string{"--admin=" + myAdmin, "--no-admin"},
+			expErr: "you set an admin and passed --no-admin",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := InstantiateContractCmd().Flags()
+			require.NoError(t, flags.Parse(append([]string{"--label=testing"}, spec.args...)))
+
+			// when
+			gotMsg, gotErr := parseInstantiateArgs("1", "{}", nil, mySender, spec.policy, flags)
+
+			// then
+			if spec.expErr != "" {
+				require.ErrorContains(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAdmin, gotMsg.Admin)
+			assert.Equal(t, spec.expNoAdmin, gotMsg.NoAdmin)
+		})
+	}
+}
+
+func TestExecuteTxMsg(t *testing.T) {
+	msg := types.MsgExecuteContract{
+		Sender:   sdk.AccAddress(make([]byte, 20)).String(),
+		Contract: sdk.AccAddress(make([]byte, 20)).String(),
+		Msg:      []byte(`{}`),
+		Funds:    sdk.NewCoins(sdk.NewInt64Coin("uosmo", 5), sdk.NewInt64Coin("uatom", 100)),
+	}
+	specs := map[string]struct {
+		args []string
+		exp  sdk.Msg
+	}{
+		"canonical": {
+			exp: &msg,
+		},
+		"compat funds": {
+			args: []string{"--compat-funds"},
+			exp: &types.MsgExecuteContractCompat{
+				Sender:   msg.Sender,
+				Contract: msg.Contract,
+				Msg:      msg.Msg,
+				Funds:    "100uatom,5uosmo",
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := ExecuteContractCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+
+			got, err := executeTxMsg(flags, msg)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
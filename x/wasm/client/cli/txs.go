@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	flagAction  = "action"
+	flagOrderBy = "order-by"
+
+	txsActionExecute     = "execute"
+	txsActionInstantiate = "instantiate"
+	txsActionMigrate     = "migrate"
+	txsActionAll         = "all"
+
+	// txSearchMaxPageSize is the max number of txs a node returns per page
+	txSearchMaxPageSize = 100
+)
+
+// errTxIndexingDisabled is returned by the node when the tx indexer is turned off
+const errTxIndexingDisabled = "transaction indexing is disabled"
+
+// txSearchFn searches the indexed txs for the given event query
+type txSearchFn func(query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error)
+
+// ContractTxs is a page of txs that interacted with a contract
+type ContractTxs struct {
+	// TotalCount is the number of matching txs. It is not set for action "all" where the
+	// results of multiple searches are merged.
+	TotalCount uint64       `json:"total_count,omitempty"`
+	Page       int          `json:"page"`
+	Limit      int          `json:"limit"`
+	Txs        []ContractTx `json:"txs"`
+}
+
+// ContractTx is a tx that interacted with a contract
+type ContractTx struct {
+	Height    int64  `json:"height"`
+	TxHash    string `json:"txhash"`
+	Code      uint32 `json:"code"`
+	Timestamp string `json:"timestamp,omitempty"`
+	// Actions are the wasm actions on the contract within the tx
+	Actions []string `json:"actions"`
+	// Checksum is the hex encoded code checksum of the last instantiate or migrate action
+	Checksum string `json:"checksum,omitempty"`
+	// Msgs are the decoded wasm messages of the tx
+	Msgs []DecodedWasmMsg `json:"msgs"`
+}
+
+// DecodedWasmMsg is a wasm tx message with the contract message as JSON
+type DecodedWasmMsg struct {
+	TypeURL  string          `json:"@type"`
+	Sender   string          `json:"sender"`
+	Contract string          `json:"contract,omitempty"`
+	CodeID   uint64          `json:"code_id,omitempty"`
+	Msg      json.RawMessage `json:"msg,omitempty"`
+	Funds    sdk.Coins       `json:"funds,omitempty"`
+}
+
+// GetCmdContractTxs lists the txs that interacted with a contract
+func GetCmdContractTxs() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "txs [contract_addr]",
+		Short: "List the txs that interacted with a contract",
+		Long: `List the txs that interacted with a contract by searching the tx index of the node.
+The --action flag filters for execute, instantiate or migrate messages on the contract. With "all",
+txs with any of these actions or custom wasm events of the contract are returned.
+The JSON output contains the decoded wasm messages of each tx.
+Requires a node with tx indexing enabled.`,
+		Aliases: []string{"contract-txs"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			if _, err := sdk.AccAddressFromBech32(args[0]); err != nil {
+				return err
+			}
+			action, err := cmd.Flags().GetString(flagAction)
+			if err != nil {
+				return err
+			}
+			page, err := cmd.Flags().GetInt(flags.FlagPage)
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetInt(flags.FlagLimit)
+			if err != nil {
+				return err
+			}
+			orderBy, err := cmd.Flags().GetString(flagOrderBy)
+			if err != nil {
+				return err
+			}
+			search := func(query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error) {
+				return authtx.QueryTxsByEvents(clientCtx, page, limit, query, orderBy)
+			}
+			res, err := searchContractTxs(search, args[0], action, page, limit, orderBy)
+			if err != nil {
+				return err
+			}
+			if clientCtx.OutputFormat == flags.OutputFormatJSON {
+				bz, err := json.Marshal(res)
+				if err != nil {
+					return err
+				}
+				return clientCtx.PrintRaw(bz)
+			}
+			return printContractTxs(cmd.OutOrStdout(), res)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagAction, txsActionAll, "Filter by action: execute, instantiate, migrate or all")
+	cmd.Flags().Int(flags.FlagPage, query.DefaultPage, "Query a specific page of paginated results")
+	cmd.Flags().Int(flags.FlagLimit, query.DefaultLimit, "Query number of transactions results per page returned")
+	cmd.Flags().String(flagOrderBy, "", "Order of the results by height: asc or desc")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// contractTxsQueries returns the tx search queries for the contract action
+func contractTxsQueries(contract, action string) ([]string, error) {
+	eventQuery := func(eventType string) string {
+		return fmt.Sprintf("%s.%s='%s'", eventType, types.AttributeKeyContractAddr, contract)
+	}
+	switch action {
+	case txsActionExecute:
+		return []string{eventQuery(types.EventTypeExecute)}, nil
+	case txsActionInstantiate:
+		return []string{eventQuery(types.EventTypeInstantiate)}, nil
+	case txsActionMigrate:
+		return []string{eventQuery(types.EventTypeMigrate)}, nil
+	case txsActionAll:
+		// the tx search does not support OR conditions so that each event type is searched on its own
+		return []string{
+			eventQuery(types.EventTypeExecute),
+			eventQuery(types.EventTypeInstantiate),
+			eventQuery(types.EventTypeMigrate),
+			eventQuery(types.WasmModuleEventType),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported action %q: use %s, %s, %s or %s", action,
+			txsActionExecute, txsActionInstantiate, txsActionMigrate, txsActionAll)
+	}
+}
+
+// searchContractTxs returns a page of txs that interacted with the contract
+func searchContractTxs(search txSearchFn, contract, action string, page, limit int, orderBy string) (*ContractTxs, error) {
+	if page <= 0 {
+		return nil, errors.New("page must be greater than 0")
+	}
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	queries, err := contractTxsQueries(contract, action)
+	if err != nil {
+		return nil, err
+	}
+	result := &ContractTxs{Page: page, Limit: limit, Txs: []ContractTx{}}
+	var txs []*sdk.TxResponse
+	if len(queries) == 1 {
+		res, err := searchTxs(search, queries[0], page, limit, orderBy)
+		if err != nil {
+			return nil, err
+		}
+		result.TotalCount, txs = res.TotalCount, res.Txs
+	} else {
+		if txs, err = searchMergedTxs(search, queries, page, limit, orderBy); err != nil {
+			return nil, err
+		}
+	}
+	for _, tx := range txs {
+		actions, checksum := contractActions(tx, contract)
+		result.Txs = append(result.Txs, ContractTx{
+			Height:    tx.Height,
+			TxHash:    tx.TxHash,
+			Code:      tx.Code,
+			Timestamp: tx.Timestamp,
+			Actions:   actions,
+			Checksum:  checksum,
+			Msgs:      decodeWasmMsgs(tx),
+		})
+	}
+	return result, nil
+}
+
+// searchMergedTxs runs all queries and returns the requested page of the merged and deduplicated txs
+func searchMergedTxs(search txSearchFn, queries []string, page, limit int, orderBy string) ([]*sdk.TxResponse, error) {
+	end := page * limit
+	seen := make(map[string]struct{})
+	var merged []*sdk.TxResponse
+	for _, q := range queries {
+		// the first results of each query are enough to fill the requested page
+		for p, collected := 1, 0; collected < end; p++ {
+			res, err := searchTxs(search, q, p, txSearchMaxPageSize, orderBy)
+			if err != nil {
+				return nil, err
+			}
+			for _, tx := range res.Txs {
+				if _, ok := seen[tx.TxHash]; !ok {
+					seen[tx.TxHash] = struct{}{}
+					merged = append(merged, tx)
+				}
+			}
+			collected += len(res.Txs)
+			if len(res.Txs) == 0 || uint64(collected) >= res.TotalCount {
+				break
+			}
+		}
+	}
+	desc := strings.EqualFold(orderBy, "desc")
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].Height != merged[j].Height {
+			return (merged[i].Height < merged[j].Height) != desc
+		}
+		return merged[i].TxHash < merged[j].TxHash
+	})
+	start := (page - 1) * limit
+	if start >= len(merged) {
+		return nil, nil
+	}
+	return merged[start:min(end, len(merged))], nil
+}
+
+func searchTxs(search txSearchFn, query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error) {
+	res, err := search(query, page, limit, orderBy)
+	if err != nil {
+		if strings.Contains(err.Error(), errTxIndexingDisabled) {
+			return nil, errors.New("tx indexing is disabled on the node: enable the tx indexer in the node's config.toml or use a node with indexing enabled")
+		}
+		return nil, err
+	}
+	return res, nil
+}
+
+// contractActions returns the execute, instantiate and migrate events of the contract in the tx
+// and the code checksum of the last instantiate or migrate event
+func contractActions(tx *sdk.TxResponse, contract string) (actions []string, checksum string) {
+	for _, e := range tx.Events {
+		switch e.Type {
+		case types.EventTypeExecute, types.EventTypeInstantiate, types.EventTypeMigrate:
+		default:
+			continue
+		}
+		var isContract bool
+		var eventChecksum string
+		for _, a := range e.Attributes {
+			switch a.Key {
+			case types.AttributeKeyContractAddr:
+				isContract = a.Value == contract
+			case types.AttributeKeyChecksum:
+				eventChecksum = a.Value
+			}
+		}
+		if !isContract {
+			continue
+		}
+		actions = append(actions, e.Type)
+		if eventChecksum != "" {
+			checksum = eventChecksum
+		}
+	}
+	return actions, checksum
+}
+
+// decodeWasmMsgs returns the wasm messages of the tx with the contract messages as JSON
+func decodeWasmMsgs(tx *sdk.TxResponse) []DecodedWasmMsg {
+	r := []DecodedWasmMsg{}
+	if tx.Tx == nil || tx.GetTx() == nil {
+		return r
+	}
+	for _, msg := range tx.GetTx().GetMsgs() {
+		var m DecodedWasmMsg
+		switch msg := msg.(type) {
+		case *types.MsgExecuteContract:
+			m = DecodedWasmMsg{Sender: msg.Sender, Contract: msg.Contract, Msg: json.RawMessage(msg.Msg), Funds: msg.Funds}
+		case *types.MsgExecuteContractCompat:
+			funds, _ := types.ParseCompatFunds(msg.Funds)
+			m = DecodedWasmMsg{Sender: msg.Sender, Contract: msg.Contract, Msg: json.RawMessage(msg.Msg), Funds: funds}
+		case *types.MsgInstantiateContract:
+			m = DecodedWasmMsg{Sender: msg.Sender, CodeID: msg.CodeID, Msg: json.RawMessage(msg.Msg), Funds: msg.Funds}
+		case *types.MsgInstantiateContract2:
+			m = DecodedWasmMsg{Sender: msg.Sender, CodeID: msg.CodeID, Msg: json.RawMessage(msg.Msg), Funds: msg.Funds}
+		case *types.MsgMigrateContract:
+			m = DecodedWasmMsg{Sender: msg.Sender, Contract: msg.Contract, CodeID: msg.CodeID, Msg: json.RawMessage(msg.Msg)}
+		default:
+			continue
+		}
+		m.TypeURL = sdk.MsgTypeURL(msg)
+		r = append(r, m)
+	}
+	return r
+}
+
+func printContractTxs(out io.Writer, res *ContractTxs) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "HEIGHT\tTXHASH\tCODE\tACTIONS"); err != nil {
+		return err
+	}
+	for _, tx := range res.Txs {
+		actions := strings.Join(tx.Actions, ",")
+		if actions == "" {
+			actions = "-"
+		}
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%d\t%s\n", tx.Height, tx.TxHash, tx.Code, actions); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
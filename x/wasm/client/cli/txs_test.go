@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestSearchContractTxs(t *testing.T) {
+	const (
+		myContract = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+		mySender   = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du"
+	)
+	txConfig := keeper.MakeEncodingConfig(t).TxConfig
+	newTx := func(height int64, hash string, eventTypes []string, msgs ...sdk.Msg) *sdk.TxResponse {
+		builder := txConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(msgs...))
+		var events []abci.Event
+		for _, et := range eventTypes {
+			events = append(events, abci.Event(sdk.NewEvent(et, sdk.NewAttribute(types.AttributeKeyContractAddr, myContract))))
+		}
+		return &sdk.TxResponse{
+			Height: height,
+			TxHash: hash,
+			Tx:     builder.GetTx().(interface{ AsAny() *codectypes.Any }).AsAny(),
+			Events: events,
+		}
+	}
+	instantiateTx := newTx(1, "A1", []string{types.EventTypeInstantiate, types.WasmModuleEventType},
+		&types.MsgInstantiateContract{Sender: mySender, CodeID: 1, Label: "foo", Msg: []byte(`{"init":{}}`)})
+	executeTx := newTx(2, "B2", []string{types.EventTypeExecute, types.WasmModuleEventType},
+		&types.MsgExecuteContract{Sender: mySender, Contract: myContract, Msg: []byte(`{"release":{}}`)})
+	migrateTx := newTx(3, "C3", []string{types.EventTypeMigrate},
+		&types.MsgMigrateContract{Sender: mySender, Contract: myContract, CodeID: 2, Msg: []byte(`{}`)})
+	replyTx := newTx(4, "D4", []string{types.WasmModuleEventType})
+
+	index := map[string][]*sdk.TxResponse{
+		"execute._contract_address='" + myContract + "'":     {executeTx},
+		"instantiate._contract_address='" + myContract + "'": {instantiateTx},
+		"migrate._contract_address='" + myContract + "'":     {migrateTx},
+		"wasm._contract_address='" + myContract + "'":        {instantiateTx, executeTx, replyTx},
+	}
+	search := func(query string, page, limit int, orderBy string) (*sdk.SearchTxsResult, error) {
+		all := index[query]
+		if orderBy == "desc" {
+			all = make([]*sdk.TxResponse, len(index[query]))
+			for i, tx := range index[query] {
+				all[len(all)-1-i] = tx
+			}
+		}
+		start, end := min((page-1)*limit, len(all)), min(page*limit, len(all))
+		return sdk.NewSearchTxsResult(uint64(len(all)), uint64(end-start), uint64(page), uint64(limit), all[start:end]), nil
+	}
+
+	specs := map[string]struct {
+		action      string
+		page, limit int
+		orderBy     string
+		search      txSearchFn
+		expHashes   []string
+		expActions  [][]string
+		expTotal    uint64
+		expErr      string
+	}{
+		"execute": {
+			action: txsActionExecute, page: 1, limit: 10,
+			expHashes:  []string{"B2"},
+			expActions: [][]string{{"execute"}},
+			expTotal:   1,
+		},
+		"instantiate": {
+			action: txsActionInstantiate, page: 1, limit: 10,
+			expHashes:  []string{"A1"},
+			expActions: [][]string{{"instantiate"}},
+			expTotal:   1,
+		},
+		"migrate": {
+			action: txsActionMigrate, page: 1, limit: 10,
+			expHashes:  []string{"C3"},
+			expActions: [][]string{{"migrate"}},
+			expTotal:   1,
+		},
+		"all merged and deduplicated": {
+			action: txsActionAll, page: 1, limit: 10,
+			expHashes:  []string{"A1", "B2", "C3", "D4"},
+			expActions: [][]string{{"instantiate"}, {"execute"}, {"migrate"}, nil},
+		},
+		"all descending": {
+			action: txsActionAll, page: 1, limit: 10, orderBy: "desc",
+			expHashes:  []string{"D4", "C3", "B2", "A1"},
+			expActions: [][]string{nil, {"migrate"}, {"execute"}, {"instantiate"}},
+		},
+		"all second page": {
+			action: txsActionAll, page: 2, limit: 3,
+			expHashes:  []string{"D4"},
+			expActions: [][]string{nil},
+		},
+		"all page out of range": {
+			action: txsActionAll, page: 3, limit: 3,
+		},
+		"unsupported action": {
+			action: "sudo", page: 1, limit: 10,
+			expErr: "unsupported action",
+		},
+		"invalid page": {
+			action: txsActionAll, page: 0, limit: 10,
+			expErr: "page",
+		},
+		"tx indexing disabled": {
+			action: txsActionExecute, page: 1, limit: 10,
+			search: func(string, int, int, string) (*sdk.SearchTxsResult, error) {
+				return nil, errors.New("failed to search for txs: RPC error -32603 - Internal error: transaction indexing is disabled")
+			},
+			expErr: "tx indexing is disabled on the node",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			searchFn := search
+			if spec.search != nil {
+				searchFn = spec.search
+			}
+			got, gotErr := searchContractTxs(searchFn, myContract, spec.action, spec.page, spec.limit, spec.orderBy)
+			if spec.expErr != "" {
+				require.Error(t, gotErr)
+				assert.Contains(t, gotErr.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expTotal, got.TotalCount)
+			var gotHashes []string
+			var gotActions [][]string
+			for _, tx := range got.Txs {
+				gotHashes = append(gotHashes, tx.TxHash)
+				gotActions = append(gotActions, tx.Actions)
+			}
+			assert.Equal(t, spec.expHashes, gotHashes)
+			assert.Equal(t, spec.expActions, gotActions)
+		})
+	}
+}
+
+func TestContractActions(t *testing.T) {
+	const myContract = "contract"
+	tx := &sdk.TxResponse{Events: []abci.Event{
+		abci.Event(sdk.NewEvent(types.EventTypeInstantiate,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, myContract),
+			sdk.NewAttribute(types.AttributeKeyCodeID, "1"),
+			sdk.NewAttribute(types.AttributeKeyChecksum, "aa"))),
+		abci.Event(sdk.NewEvent(types.EventTypeExecute,
+			sdk.NewAttribute(types.AttributeKeyContractAddr, myContract))),
+		abci.Event(sdk.NewEvent(types.EventTypeMigrate,
+			sdk.NewAttribute(types.AttributeKeyCodeID, "3"),
+			sdk.NewAttribute(types.AttributeKeyContractAddr, "other"),
+			sdk.NewAttribute(types.AttributeKeyChecksum, "cc"))),
+		abci.Event(sdk.NewEvent(types.EventTypeMigrate,
+			sdk.NewAttribute(types.AttributeKeyCodeID, "2"),
+			sdk.NewAttribute(types.AttributeKeyContractAddr, myContract),
+			sdk.NewAttribute(types.AttributeKeyChecksum, "bb"))),
+	}}
+	gotActions, gotChecksum := contractActions(tx, myContract)
+	assert.Equal(t, []string{"instantiate", "execute", "migrate"}, gotActions)
+	assert.Equal(t, "bb", gotChecksum)
+}
+
+func TestDecodeWasmMsgs(t *testing.T) {
+	txConfig := keeper.MakeEncodingConfig(t).TxConfig
+	builder := txConfig.NewTxBuilder()
+	require.NoError(t, builder.SetMsgs(
+		&types.MsgExecuteContract{Sender: "sender", Contract: "contract", Msg: []byte(`{"release":{}}`), Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 1))},
+		&types.MsgClearAdmin{Sender: "sender", Contract: "contract"},
+		&types.MsgMigrateContract{Sender: "sender", Contract: "contract", CodeID: 2, Msg: []byte(`{"migrate":{}}`)},
+	))
+	tx := &sdk.TxResponse{Tx: builder.GetTx().(interface{ AsAny() *codectypes.Any }).AsAny()}
+
+	got, err := json.Marshal(decodeWasmMsgs(tx))
+	require.NoError(t, err)
+	exp := `[{"@type":"/cosmwasm.wasm.v1.MsgExecuteContract","sender":"sender","contract":"contract","msg":{"release":{}},"funds":[{"denom":"denom","amount":"1"}]},` +
+		`{"@type":"/cosmwasm.wasm.v1.MsgMigrateContract","sender":"sender","contract":"contract","code_id":2,"msg":{"migrate":{}}}]`
+	assert.JSONEq(t, exp, string(got))
+}
+
+func TestPrintContractTxs(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printContractTxs(&out, &ContractTxs{Txs: []ContractTx{
+		{Height: 1, TxHash: "A1", Actions: []string{"instantiate"}},
+		{Height: 12, TxHash: "B2", Code: 5},
+	}}))
+	exp := "HEIGHT  TXHASH  CODE  ACTIONS\n" +
+		"1       A1      0     instantiate\n" +
+		"12      B2      5     -\n"
+	assert.Equal(t, exp, out.String())
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// printUploadQuota prints the upload deposit and quota that apply to the uploader and warns when the uploader
+// is not allowed to upload. An error is returned when the uploads would exceed the quota. Nothing is checked
+// when the node does not support the queries.
+func printUploadQuota(ctx context.Context, clientCtx client.Context, out io.Writer, uploader string, uploads int) error {
+	if clientCtx.Offline || clientCtx.GenerateOnly {
+		recordPreflight(ctx, preflightUploadPermission, preflightSkipped, "offline or generate only")
+		recordPreflight(ctx, preflightUploadQuota, preflightSkipped, "offline or generate only")
+		return nil
+	}
+	queryClient := types.NewQueryClient(clientCtx)
+	warning := checkCanUpload(ctx, queryClient, uploader)
+	recordPreflightResult(ctx, preflightUploadPermission, warning, nil)
+	if warning != "" {
+		_, _ = fmt.Fprintf(out, "warning: %s\n", warning)
+	}
+	notice, err := checkUploadQuota(ctx, queryClient, uploader, uploads)
+	if err != nil {
+		recordPreflightResult(ctx, preflightUploadQuota, "", err)
+		return err
+	}
+	recordPreflight(ctx, preflightUploadQuota, preflightPass, notice)
+	if notice != "" {
+		_, _ = fmt.Fprintln(out, notice)
+	}
+	return nil
+}
+
+// checkUploadQuota returns a notice with the upload deposit and quota for the uploader
+func checkUploadQuota(ctx context.Context, queryClient types.QueryClient, uploader string, uploads int) (string, error) {
+	res, err := queryClient.UploadQuota(ctx, &types.QueryUploadQuotaRequest{Address: uploader})
+	if err != nil || res.Exempt {
+		// the query is not available on older nodes
+		return "", nil
+	}
+	var notice string
+	if !res.Deposit.IsZero() {
+		notice = fmt.Sprintf("upload deposit: %s per code is burned", res.Deposit)
+	}
+	if res.MaxUploadsPerEpoch != 0 {
+		if res.Uploads+uint64(uploads) > res.MaxUploadsPerEpoch {
+			return "", fmt.Errorf("upload quota exceeded: %d of %d uploads used until height %d", res.Uploads, res.MaxUploadsPerEpoch, res.EpochEndHeight)
+		}
+		if notice != "" {
+			notice += "\n"
+		}
+		notice += fmt.Sprintf("upload quota: %d of %d uploads used until height %d", res.Uploads, res.MaxUploadsPerEpoch, res.EpochEndHeight)
+	}
+	return notice, nil
+}
+
+// checkCanUpload returns a warning when the uploader is not allowed to upload by the chain params
+func checkCanUpload(ctx context.Context, queryClient types.QueryClient, uploader string) string {
+	res, err := queryClient.EffectiveInstantiatePermission(ctx, &types.QueryEffectiveInstantiatePermissionRequest{Sender: uploader})
+	if err != nil || res.CanUpload {
+		// the query is not available on older nodes
+		return ""
+	}
+	return fmt.Sprintf("%s is not allowed to upload code by the chain's code upload access, the tx will fail", uploader)
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCheckUploadQuota(t *testing.T) {
+	specs := map[string]struct {
+		rsp       *types.QueryUploadQuotaResponse
+		err       error
+		uploads   int
+		expNotice string
+		expErr    bool
+	}{
+		"deposit and quota": {
+			rsp: &types.QueryUploadQuotaResponse{
+				Deposit:            sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+				MaxUploadsPerEpoch: 3,
+				Uploads:            1,
+				EpochEndHeight:     99,
+			},
+			uploads:   2,
+			expNotice: "upload deposit: 100stake per code is burned\nupload quota: 1 of 3 uploads used until height 99",
+		},
+		"quota exceeded": {
+			rsp:     &types.QueryUploadQuotaResponse{MaxUploadsPerEpoch: 3, Uploads: 2, EpochEndHeight: 99},
+			uploads: 2,
+			expErr:  true,
+		},
+		"exempt": {
+			rsp:     &types.QueryUploadQuotaResponse{Exempt: true},
+			uploads: 1,
+		},
+		"query not supported": {
+			err:     errors.New("unknown query path"),
+			uploads: 1,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockUploadQuotaQueryClient{rsp: spec.rsp, err: spec.err}
+			gotNotice, gotErr := checkUploadQuota(context.Background(), queryClient, "uploader", spec.uploads)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expNotice, gotNotice)
+		})
+	}
+}
+
+type mockUploadQuotaQueryClient struct {
+	types.QueryClient
+	rsp           *types.QueryUploadQuotaResponse
+	permissionRsp *types.QueryEffectiveInstantiatePermissionResponse
+	err           error
+}
+
+func (m mockUploadQuotaQueryClient) UploadQuota(_ context.Context, _ *types.QueryUploadQuotaRequest, _ ...grpc.CallOption) (*types.QueryUploadQuotaResponse, error) {
+	return m.rsp, m.err
+}
+
+func (m mockUploadQuotaQueryClient) EffectiveInstantiatePermission(_ context.Context, _ *types.QueryEffectiveInstantiatePermissionRequest, _ ...grpc.CallOption) (*types.QueryEffectiveInstantiatePermissionResponse, error) {
+	return m.permissionRsp, m.err
+}
+
+func TestCheckCanUpload(t *testing.T) {
+	specs := map[string]struct {
+		rsp        *types.QueryEffectiveInstantiatePermissionResponse
+		err        error
+		expWarning bool
+	}{
+		"can upload": {
+			rsp: &types.QueryEffectiveInstantiatePermissionResponse{CanUpload: true, InstantiatePermission: types.AllowEverybody},
+		},
+		"can not upload": {
+			rsp:        &types.QueryEffectiveInstantiatePermissionResponse{InstantiatePermission: types.AllowEverybody},
+			expWarning: true,
+		},
+		"query not supported": {
+			err: errors.New("unknown query path"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			queryClient := &mockUploadQuotaQueryClient{permissionRsp: spec.rsp, err: spec.err}
+			gotWarning := checkCanUpload(context.Background(), queryClient, "uploader")
+			if spec.expWarning {
+				assert.Contains(t, gotWarning, "uploader is not allowed to upload")
+				return
+			}
+			assert.Empty(t, gotWarning)
+		})
+	}
+}
@@ -9,12 +9,13 @@ import (
 	errorsmod "cosmossdk.io/errors"
 )
 
-var errLimit = errors.New("exceeds limit")
+// ErrLimit is returned when the uncompressed content exceeds the limit
+var ErrLimit = errors.New("exceeds limit")
 
 // Uncompress expects a valid gzip source to unpack or fails. See IsGzip
 func Uncompress(gzipSrc []byte, limit int64) ([]byte, error) {
 	if int64(len(gzipSrc)) > limit {
-		return nil, errorsmod.Wrapf(errLimit, "max %d bytes", limit)
+		return nil, errorsmod.Wrapf(ErrLimit, "max %d bytes", limit)
 	}
 	zr, err := gzip.NewReader(bytes.NewReader(gzipSrc))
 	if err != nil {
@@ -23,12 +24,34 @@ func Uncompress(gzipSrc []byte, limit int64) ([]byte, error) {
 	zr.Multistream(false)
 	defer zr.Close()
 	bz, err := io.ReadAll(LimitReader(zr, limit))
-	if errors.Is(err, errLimit) {
-		return nil, errorsmod.Wrapf(errLimit, "max %d bytes", limit)
+	if errors.Is(err, ErrLimit) {
+		return nil, errorsmod.Wrapf(ErrLimit, "max %d bytes", limit)
 	}
 	return bz, err
 }
 
+// NormalizeGzip unpacks all members of a gzip stream and compresses the content again as a single member without
+// the optional header fields. Uncompress reads the first member only, so that multi-member streams must be normalized
+// before they are stored.
+func NormalizeGzip(gzipSrc []byte, limit int64) ([]byte, error) {
+	if int64(len(gzipSrc)) > limit {
+		return nil, errorsmod.Wrapf(ErrLimit, "max %d bytes", limit)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(gzipSrc))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	bz, err := io.ReadAll(LimitReader(zr, limit))
+	if errors.Is(err, ErrLimit) {
+		return nil, errorsmod.Wrapf(ErrLimit, "max %d bytes", limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return GzipIt(bz)
+}
+
 // LimitReader returns a Reader that reads from r
 // but stops with "limit error" after n bytes.
 // The underlying implementation is a *io.LimitedReader.
@@ -42,7 +65,7 @@ type LimitedReader struct {
 
 func (l *LimitedReader) Read(p []byte) (n int, err error) {
 	if l.r.N <= 0 {
-		return 0, errLimit
+		return 0, ErrLimit
 	}
 	return l.r.Read(p)
 }
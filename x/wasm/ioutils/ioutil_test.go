@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cometbft/cometbft/libs/rand"
 	"github.com/stretchr/testify/assert"
@@ -49,11 +50,11 @@ func TestUncompress(t *testing.T) {
 		},
 		"handle big gzip output": {
 			src:      asGzip(bytes.Repeat([]byte{0x1}, maxSize+1)),
-			expError: errLimit,
+			expError: ErrLimit,
 		},
 		"handle big gzip archive": {
 			src:      asGzip(rand.Bytes(2 * maxSize)),
-			expError: errLimit,
+			expError: ErrLimit,
 		},
 	}
 	for msg, spec := range specs {
@@ -68,6 +69,75 @@ func TestUncompress(t *testing.T) {
 	}
 }
 
+func TestNormalizeGzip(t *testing.T) {
+	wasmRaw, err := os.ReadFile("../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	wasmGzipped, err := os.ReadFile("../keeper/testdata/hackatom.wasm.gzip")
+	require.NoError(t, err)
+	expResult, err := GzipIt(wasmRaw)
+	require.NoError(t, err)
+
+	const maxSize = 400_000
+
+	specs := map[string]struct {
+		src      []byte
+		expError error
+	}{
+		"plain gzip": {
+			src: wasmGzipped,
+		},
+		"with file name": {
+			src: asGzipWithHeader(wasmRaw, gzip.Header{Name: "hackatom.wasm"}),
+		},
+		"with extra field, comment and mod time": {
+			src: asGzipWithHeader(wasmRaw, gzip.Header{
+				Extra:   []byte{'C', 'I', 4, 0, 1, 2, 3, 4},
+				Comment: "built by ci",
+				ModTime: time.Unix(1700000000, 0),
+				OS:      3,
+			}),
+		},
+		"multi-member stream": {
+			src: append(asGzipWithHeader(wasmRaw[:1000], gzip.Header{Name: "part1"}), asGzip(wasmRaw[1000:])...),
+		},
+		"big gzip output": {
+			src:      asGzip(bytes.Repeat([]byte{0x1}, maxSize+1)),
+			expError: ErrLimit,
+		},
+		"broken gzip": {
+			src:      append(gzipIdent, byte(0x1)),
+			expError: io.ErrUnexpectedEOF,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			r, err := NormalizeGzip(spec.src, maxSize)
+			require.True(t, errors.Is(err, spec.expError), "exp %v got %+v", spec.expError, err)
+			if spec.expError != nil {
+				return
+			}
+			assert.Equal(t, expResult, r)
+			// and the single member can be uncompressed
+			got, err := Uncompress(r, maxSize)
+			require.NoError(t, err)
+			assert.Equal(t, wasmRaw, got)
+		})
+	}
+}
+
+func asGzipWithHeader(src []byte, header gzip.Header) []byte {
+	var buf bytes.Buffer
+	zipper := gzip.NewWriter(&buf)
+	zipper.Header = header
+	if _, err := io.Copy(zipper, bytes.NewReader(src)); err != nil {
+		panic(err)
+	}
+	if err := zipper.Close(); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
 func asGzip(src []byte) []byte {
 	var buf bytes.Buffer
 	zipper := gzip.NewWriter(&buf)
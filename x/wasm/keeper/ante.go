@@ -1,13 +1,16 @@
 package keeper
 
 import (
+	"context"
 	"encoding/binary"
 
 	corestoretypes "cosmossdk.io/core/store"
 	errorsmod "cosmossdk.io/errors"
 	storetypes "cosmossdk.io/store/types"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -134,3 +137,175 @@ func (d TxContractsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bo
 	txContracts := types.NewTxContracts()
 	return next(types.WithTxContracts(ctx, txContracts), tx, simulate)
 }
+
+// ParamsReader reads the wasm params
+type ParamsReader interface {
+	GetParams(ctx context.Context) types.Params
+}
+
+// FeelessExecutionDecorator ante decorator that lets txs pass the min fee check when they consist solely of
+// contract executions in the fee-less allow-list. This decorator is optional and must be placed before the
+// fee decorator. It works with tx fee checkers that read the min gas prices from the context only.
+type FeelessExecutionDecorator struct {
+	params ParamsReader
+}
+
+// NewFeelessExecutionDecorator constructor
+func NewFeelessExecutionDecorator(p ParamsReader) *FeelessExecutionDecorator {
+	return &FeelessExecutionDecorator{params: p}
+}
+
+// AnteHandle sets zero min gas prices in the context for txs without fees when all messages are allowed
+// executions and the tx gas limit does not exceed the max gas of fee-less txs. All other txs are passed on
+// unchanged.
+func (d FeelessExecutionDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok || !feeTx.GetFee().IsZero() {
+		return next(ctx, tx, simulate)
+	}
+	config := d.params.GetParams(ctx).FeelessExecutions
+	if !config.Enabled() || feeTx.GetGas() > config.MaxGas || !allFeelessExecutions(config, tx.GetMsgs()) {
+		return next(ctx, tx, simulate)
+	}
+	return next(ctx.WithMinGasPrices(sdk.NewDecCoins()), tx, simulate)
+}
+
+func allFeelessExecutions(config types.FeelessExecutions, msgs []sdk.Msg) bool {
+	if len(msgs) == 0 {
+		return false
+	}
+	for _, msg := range msgs {
+		execMsg, ok := executeContractMsg(msg)
+		if !ok || !config.Allows(execMsg) {
+			return false
+		}
+	}
+	return true
+}
+
+// executeContractMsg returns the MsgExecuteContract of an execute message or of its compat form
+func executeContractMsg(msg sdk.Msg) (*types.MsgExecuteContract, bool) {
+	switch msg := msg.(type) {
+	case *types.MsgExecuteContract:
+		return msg, true
+	case *types.MsgExecuteContractCompat:
+		execMsg, err := msg.ToExecuteContract()
+		return &execMsg, err == nil
+	}
+	return nil, false
+}
+
+// GasBreakdownDecorator ante decorator that opts a simulation into gas breakdown events when the tx
+// has a types.GasBreakdownExtensionOption as non-critical extension option. The option has no effect
+// outside of simulations.
+type GasBreakdownDecorator struct{}
+
+// NewGasBreakdownDecorator constructor
+func NewGasBreakdownDecorator() *GasBreakdownDecorator {
+	return &GasBreakdownDecorator{}
+}
+
+// AnteHandle stores the opt-in for gas breakdown events in the context of a simulation
+func (d GasBreakdownDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if !simulate {
+		return next(ctx, tx, simulate)
+	}
+	extTx, ok := tx.(interface {
+		GetNonCriticalExtensionOptions() []*codectypes.Any
+	})
+	if !ok {
+		return next(ctx, tx, simulate)
+	}
+	typeURL := sdk.MsgTypeURL(&types.GasBreakdownExtensionOption{})
+	for _, o := range extTx.GetNonCriticalExtensionOptions() {
+		if o.TypeUrl == typeURL {
+			return next(types.WithGasBreakdown(ctx, true), tx, simulate)
+		}
+	}
+	return next(ctx, tx, simulate)
+}
+
+// ContractGasBudgetReserver reserves the per block execution gas budget of a contract
+type ContractGasBudgetReserver interface {
+	ReserveContractGasBudget(ctx context.Context, contractAddr sdk.AccAddress, gasLimit uint64) (bool, error)
+}
+
+// ContractGasBudgetDecorator ante decorator that rejects txs executing a contract with a used up gas budget
+// and charges the gas limit of accepted txs to the budget. The charge is stored before the messages are executed
+// so that failed and out of gas executions count, too, and CheckTx and block execution see the same counter.
+// Without this decorator, the budget is enforced on execution with the gas of committed executions only.
+type ContractGasBudgetDecorator struct {
+	reserver ContractGasBudgetReserver
+}
+
+// NewContractGasBudgetDecorator constructor
+func NewContractGasBudgetDecorator(r ContractGasBudgetReserver) *ContractGasBudgetDecorator {
+	return &ContractGasBudgetDecorator{reserver: r}
+}
+
+// AnteHandle returns an ErrRateLimited error when a MsgExecuteContract of the tx targets a contract that exceeded
+// its gas budget in the current block. Otherwise, the gas limit of the tx is charged once to each budget.
+func (d ContractGasBudgetDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	var reserved []sdk.AccAddress
+	seen := make(map[string]struct{})
+	for _, msg := range tx.GetMsgs() {
+		execMsg, ok := executeContractMsg(msg)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[execMsg.Contract]; ok {
+			continue
+		}
+		seen[execMsg.Contract] = struct{}{}
+		contractAddr, err := sdk.AccAddressFromBech32(execMsg.Contract)
+		if err != nil {
+			return ctx, errorsmod.Wrap(err, "contract")
+		}
+		feeTx, ok := tx.(sdk.FeeTx)
+		if !ok {
+			return ctx, errorsmod.Wrap(sdkerrors.ErrTxDecode, "tx must be a FeeTx")
+		}
+		budgeted, err := d.reserver.ReserveContractGasBudget(ctx, contractAddr, feeTx.GetGas())
+		if err != nil {
+			return ctx, err
+		}
+		if budgeted {
+			reserved = append(reserved, contractAddr)
+		}
+	}
+	if len(reserved) != 0 {
+		ctx = types.WithGasBudgetReservations(ctx, reserved)
+	}
+	return next(ctx, tx, simulate)
+}
+
+// ExecutionReceiptsRecorder records the execution receipts collected for a tx
+type ExecutionReceiptsRecorder interface {
+	RecordExecutionReceipts(ctx context.Context, success bool)
+}
+
+// ExecutionReceiptsDecorator collects the receipts of the top level contract executions of a tx in block execution
+// and records them with the tx result. It must be set as ante and as post decorator. It should be the last post
+// decorator as a failing post handler reverts the tx. Without this decorator, no execution receipts are recorded.
+type ExecutionReceiptsDecorator struct {
+	recorder ExecutionReceiptsRecorder
+}
+
+// NewExecutionReceiptsDecorator constructor
+func NewExecutionReceiptsDecorator(r ExecutionReceiptsRecorder) *ExecutionReceiptsDecorator {
+	return &ExecutionReceiptsDecorator{recorder: r}
+}
+
+// AnteHandle adds a new receipts collection to the context in block execution
+func (d ExecutionReceiptsDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	if ctx.ExecMode() != sdk.ExecModeFinalize {
+		return next(ctx, tx, simulate)
+	}
+	return next(types.WithPendingExecutionReceipts(ctx, &types.PendingExecutionReceipts{}), tx, simulate)
+}
+
+// PostHandle records the collected receipts with the tx result
+func (d ExecutionReceiptsDecorator) PostHandle(ctx sdk.Context, tx sdk.Tx, simulate, success bool, next sdk.PostHandler) (sdk.Context, error) {
+	d.recorder.RecordExecutionReceipts(ctx, success)
+	return next(ctx, tx, simulate, success)
+}
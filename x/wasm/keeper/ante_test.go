@@ -1,6 +1,7 @@
 package keeper_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -10,12 +11,15 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
+	sdkmath "cosmossdk.io/math"
 	"cosmossdk.io/store"
 	storemetrics "cosmossdk.io/store/metrics"
 	storetypes "cosmossdk.io/store/types"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
@@ -311,3 +315,325 @@ func TestTxContractsDecorator(t *testing.T) {
 		})
 	}
 }
+
+func TestFeelessExecutionDecorator(t *testing.T) {
+	oracle := keeper.RandomBech32AccountAddress(t)
+	other := keeper.RandomBech32AccountAddress(t)
+	sender := keeper.RandomBech32AccountAddress(t)
+	config := types.FeelessExecutions{
+		Allowed: []types.FeelessExecution{
+			{Contract: oracle, MsgKey: "push_price"},
+			{Contract: oracle, MsgKey: "push_prices"},
+		},
+		MaxGas: 100_000,
+	}
+	execMsg := func(contract, msg string) *types.MsgExecuteContract {
+		return &types.MsgExecuteContract{Sender: sender, Contract: contract, Msg: []byte(msg)}
+	}
+	minGasPrices := sdk.NewDecCoins(sdk.NewDecCoinFromDec("stake", sdkmath.LegacyNewDecWithPrec(1, 2)))
+
+	specs := map[string]struct {
+		config     types.FeelessExecutions
+		msgs       []sdk.Msg
+		fee        sdk.Coins
+		gas        uint64
+		expFeeless bool
+	}{
+		"allowed execution": {
+			config:     config,
+			msgs:       []sdk.Msg{execMsg(oracle, `{"push_price":{"price":"1.2"}}`)},
+			gas:        100_000,
+			expFeeless: true,
+		},
+		"multiple allowed executions": {
+			config:     config,
+			msgs:       []sdk.Msg{execMsg(oracle, `{"push_price":{}}`), execMsg(oracle, `{"push_prices":[]}`)},
+			gas:        50_000,
+			expFeeless: true,
+		},
+		"gas above max": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{}}`)},
+			gas:    100_001,
+		},
+		"fee set": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{}}`)},
+			fee:    sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+			gas:    50_000,
+		},
+		"mixed with other msg type": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{}}`), &types.MsgClearAdmin{Sender: sender, Contract: other}},
+			gas:    50_000,
+		},
+		"mixed with not allowed execution": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{}}`), execMsg(other, `{"push_price":{}}`)},
+			gas:    50_000,
+		},
+		"non matching msg key": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `{"withdraw":{}}`)},
+			gas:    50_000,
+		},
+		"non matching contract": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(other, `{"push_price":{}}`)},
+			gas:    50_000,
+		},
+		"multiple top-level keys": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{},"withdraw":{}}`)},
+			gas:    50_000,
+		},
+		"not a json object": {
+			config: config,
+			msgs:   []sdk.Msg{execMsg(oracle, `["push_price"]`)},
+			gas:    50_000,
+		},
+		"no msgs": {
+			config: config,
+			gas:    50_000,
+		},
+		"max gas not set": {
+			config: types.FeelessExecutions{Allowed: config.Allowed},
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{}}`)},
+			gas:    50_000,
+		},
+		"empty allow-list": {
+			config: types.FeelessExecutions{MaxGas: 100_000},
+			msgs:   []sdk.Msg{execMsg(oracle, `{"push_price":{}}`)},
+			gas:    50_000,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			txBuilder := keeper.MakeEncodingConfig(t).TxConfig.NewTxBuilder()
+			require.NoError(t, txBuilder.SetMsgs(spec.msgs...))
+			txBuilder.SetFeeAmount(spec.fee)
+			txBuilder.SetGasLimit(spec.gas)
+			params := types.DefaultParams()
+			params.FeelessExecutions = spec.config
+			ctx := sdk.Context{}.WithMinGasPrices(minGasPrices).WithIsCheckTx(true)
+
+			var gotMinGasPrices sdk.DecCoins
+			nextAnte := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				gotMinGasPrices = ctx.MinGasPrices()
+				return ctx, nil
+			}
+			// when
+			ante := keeper.NewFeelessExecutionDecorator(staticParams(params))
+			_, gotErr := ante.AnteHandle(ctx, txBuilder.GetTx(), false, nextAnte)
+			// then
+			require.NoError(t, gotErr)
+			if spec.expFeeless {
+				assert.True(t, gotMinGasPrices.IsZero())
+				return
+			}
+			assert.Equal(t, minGasPrices, gotMinGasPrices)
+		})
+	}
+}
+
+type staticParams types.Params
+
+func (p staticParams) GetParams(context.Context) types.Params {
+	return types.Params(p)
+}
+
+func TestGasBreakdownDecorator(t *testing.T) {
+	myOption, err := codectypes.NewAnyWithValue(&types.GasBreakdownExtensionOption{})
+	require.NoError(t, err)
+	otherOption, err := codectypes.NewAnyWithValue(&types.EventGasBreakdown{})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		options  []*codectypes.Any
+		simulate bool
+		exp      bool
+	}{
+		"simulation with option": {
+			options:  []*codectypes.Any{otherOption, myOption},
+			simulate: true,
+			exp:      true,
+		},
+		"simulation without option": {
+			simulate: true,
+		},
+		"simulation with other option": {
+			options:  []*codectypes.Any{otherOption},
+			simulate: true,
+		},
+		"no simulation with option": {
+			options: []*codectypes.Any{myOption},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			txBuilder := keeper.MakeEncodingConfig(t).TxConfig.NewTxBuilder()
+			txBuilder.(authtx.ExtensionOptionsTxBuilder).SetNonCriticalExtensionOptions(spec.options...)
+			var got bool
+			nextAnte := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				got = types.IsGasBreakdownEnabled(ctx)
+				return ctx, nil
+			}
+			// when
+			ante := keeper.NewGasBreakdownDecorator()
+			_, gotErr := ante.AnteHandle(sdk.Context{}.WithContext(context.Background()), txBuilder.GetTx(), spec.simulate, nextAnte)
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestContractGasBudgetDecorator(t *testing.T) {
+	limited := keeper.RandomAccountAddress(t)
+	budgeted := keeper.RandomAccountAddress(t)
+	other := keeper.RandomAccountAddress(t)
+	sender := keeper.RandomBech32AccountAddress(t)
+	const myGasLimit = 123_456
+
+	specs := map[string]struct {
+		msgs        []sdk.Msg
+		expReserved []sdk.AccAddress
+		expErr      error
+	}{
+		"execution of unlimited contract": {
+			msgs:        []sdk.Msg{&types.MsgExecuteContract{Sender: sender, Contract: other.String(), Msg: []byte(`{}`)}},
+			expReserved: []sdk.AccAddress{},
+		},
+		"execution of budgeted contract": {
+			msgs:        []sdk.Msg{&types.MsgExecuteContract{Sender: sender, Contract: budgeted.String(), Msg: []byte(`{}`)}},
+			expReserved: []sdk.AccAddress{budgeted},
+		},
+		"multiple executions of budgeted contract": {
+			msgs: []sdk.Msg{
+				&types.MsgExecuteContract{Sender: sender, Contract: budgeted.String(), Msg: []byte(`{}`)},
+				&types.MsgExecuteContract{Sender: sender, Contract: other.String(), Msg: []byte(`{}`)},
+				&types.MsgExecuteContract{Sender: sender, Contract: budgeted.String(), Msg: []byte(`{}`)},
+			},
+			expReserved: []sdk.AccAddress{budgeted},
+		},
+		"execution of rate limited contract": {
+			msgs:   []sdk.Msg{&types.MsgExecuteContract{Sender: sender, Contract: limited.String(), Msg: []byte(`{}`)}},
+			expErr: types.ErrRateLimited,
+		},
+		"mixed executions": {
+			msgs: []sdk.Msg{
+				&types.MsgExecuteContract{Sender: sender, Contract: other.String(), Msg: []byte(`{}`)},
+				&types.MsgExecuteContract{Sender: sender, Contract: limited.String(), Msg: []byte(`{}`)},
+			},
+			expErr: types.ErrRateLimited,
+		},
+		"other msg type for rate limited contract": {
+			msgs:        []sdk.Msg{&types.MsgClearAdmin{Sender: sender, Contract: limited.String()}},
+			expReserved: []sdk.AccAddress{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			txBuilder := keeper.MakeEncodingConfig(t).TxConfig.NewTxBuilder()
+			require.NoError(t, txBuilder.SetMsgs(spec.msgs...))
+			txBuilder.SetGasLimit(myGasLimit)
+			gotReserved := []sdk.AccAddress{}
+			reserver := contractGasBudgetReserverFn(func(_ context.Context, contractAddr sdk.AccAddress, gasLimit uint64) (bool, error) {
+				assert.Equal(t, uint64(myGasLimit), gasLimit)
+				switch {
+				case contractAddr.Equals(limited):
+					return false, types.ErrRateLimited
+				case contractAddr.Equals(budgeted):
+					gotReserved = append(gotReserved, contractAddr)
+					return true, nil
+				}
+				return false, nil
+			})
+			var nextCalled bool
+			nextAnte := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				nextCalled = true
+				assert.Equal(t, len(spec.expReserved) != 0, types.HasGasBudgetReservation(ctx, budgeted))
+				assert.False(t, types.HasGasBudgetReservation(ctx, other))
+				return ctx, nil
+			}
+			// when
+			ante := keeper.NewContractGasBudgetDecorator(reserver)
+			_, gotErr := ante.AnteHandle(sdk.Context{}.WithContext(context.Background()), txBuilder.GetTx(), false, nextAnte)
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.False(t, nextCalled)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.True(t, nextCalled)
+			assert.Equal(t, spec.expReserved, gotReserved)
+		})
+	}
+}
+
+type contractGasBudgetReserverFn func(ctx context.Context, contractAddr sdk.AccAddress, gasLimit uint64) (bool, error)
+
+func (f contractGasBudgetReserverFn) ReserveContractGasBudget(ctx context.Context, contractAddr sdk.AccAddress, gasLimit uint64) (bool, error) {
+	return f(ctx, contractAddr, gasLimit)
+}
+
+func TestExecutionReceiptsDecorator(t *testing.T) {
+	specs := map[string]struct {
+		execMode   sdk.ExecMode
+		expPending bool
+	}{
+		"block execution": {
+			execMode:   sdk.ExecModeFinalize,
+			expPending: true,
+		},
+		"check tx": {
+			execMode: sdk.ExecModeCheck,
+		},
+		"simulation": {
+			execMode: sdk.ExecModeSimulate,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithContext(context.Background()).WithExecMode(spec.execMode)
+			var gotPending bool
+			nextAnte := func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				_, gotPending = types.PendingExecutionReceiptsFromContext(ctx)
+				return ctx, nil
+			}
+			decorator := keeper.NewExecutionReceiptsDecorator(nil)
+			// when
+			_, gotErr := decorator.AnteHandle(ctx, nil, false, nextAnte)
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expPending, gotPending)
+		})
+	}
+}
+
+func TestExecutionReceiptsDecoratorPostHandle(t *testing.T) {
+	for _, success := range []bool{true, false} {
+		var gotSuccess, nextCalled bool
+		recorder := executionReceiptsRecorderFn(func(ctx context.Context, success bool) {
+			gotSuccess = success
+		})
+		nextPost := func(ctx sdk.Context, tx sdk.Tx, simulate, success bool) (sdk.Context, error) {
+			nextCalled = true
+			return ctx, nil
+		}
+		decorator := keeper.NewExecutionReceiptsDecorator(recorder)
+		// when
+		_, gotErr := decorator.PostHandle(sdk.Context{}.WithContext(context.Background()), nil, false, success, nextPost)
+		// then
+		require.NoError(t, gotErr)
+		assert.Equal(t, success, gotSuccess)
+		assert.True(t, nextCalled)
+	}
+}
+
+type executionReceiptsRecorderFn func(ctx context.Context, success bool)
+
+func (f executionReceiptsRecorderFn) RecordExecutionReceipts(ctx context.Context, success bool) {
+	f(ctx, success)
+}
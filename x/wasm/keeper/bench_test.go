@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"testing"
 
@@ -8,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/query"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -101,3 +104,47 @@ func BenchmarkCompilation(b *testing.B) {
 		})
 	}
 }
+
+// Compare the time it takes to page through the state of a contract with large values
+// when only the keys are returned.
+func BenchmarkContractStateKeys(b *testing.B) {
+	ctx, keepers := createTestInput(b, false, AvailableCapabilities, types.DefaultNodeConfig(), types.VMConfig{}, dbm.NewMemDB())
+	example := InstantiateHackatomExampleContract(b, ctx, keepers)
+	models := make([]types.Model, 1000)
+	for i := range models {
+		models[i] = types.Model{Key: []byte(fmt.Sprintf("key%04d", i)), Value: bytes.Repeat([]byte{'x'}, 4096)}
+	}
+	require.NoError(b, keepers.WasmKeeper.importContractState(ctx, example.Contract, models))
+	q := Querier(keepers.WasmKeeper)
+
+	b.Run("all contract state", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var pageKey []byte
+			for {
+				rsp, err := q.AllContractState(ctx, &types.QueryAllContractStateRequest{
+					Address:    example.Contract.String(),
+					Pagination: &query.PageRequest{Key: pageKey},
+				})
+				require.NoError(b, err)
+				if pageKey = rsp.Pagination.NextKey; pageKey == nil {
+					break
+				}
+			}
+		}
+	})
+	b.Run("contract state keys", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var pageKey []byte
+			for {
+				rsp, err := q.ContractStateKeys(ctx, &types.QueryContractStateKeysRequest{
+					Address:    example.Contract.String(),
+					Pagination: &query.PageRequest{Key: pageKey},
+				})
+				require.NoError(b, err)
+				if pageKey = rsp.Pagination.NextKey; pageKey == nil {
+					break
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"context"
+	"math"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// codeGasUsagePruneLimit is the max number of expired bucket entries deleted per block
+const codeGasUsagePruneLimit = 100
+
+// codeGasUsageBucket returns the bucket of the execution gas per code for the block height
+func codeGasUsageBucket(height int64) uint64 {
+	if height < 0 {
+		return 0
+	}
+	return uint64(height) / types.CodeGasUsageBucketLength
+}
+
+// addCodeGasUsed adds the execution gas to the counter of the code in the current block when enabled by the
+// RecordCodeGasUsage param. Counting is not charged. Nothing is counted without a transient store.
+func (k Keeper) addCodeGasUsed(ctx sdk.Context, codeID, gas uint64) error {
+	if k.transientStoreService == nil {
+		return nil
+	}
+	if !k.cachedParams(ctx).RecordCodeGasUsage {
+		return nil
+	}
+	gasFreeCtx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	store := k.transientStoreService.OpenTransientStore(gasFreeCtx)
+	key := types.GetCodeGasUsedKey(codeID)
+	bz, err := store.Get(key)
+	if err != nil {
+		return err
+	}
+	var used uint64
+	if len(bz) == 8 {
+		used = sdk.BigEndianToUint64(bz)
+	}
+	return store.Set(key, sdk.Uint64ToBigEndian(addGas(used, gas)))
+}
+
+// endBlockCodeGasUsage adds the execution gas per code of the block to the current bucket. Buckets that left the
+// window are deleted with a bounded number of entries per block.
+func (k Keeper) endBlockCodeGasUsage(ctx context.Context) error {
+	bucket := codeGasUsageBucket(sdk.UnwrapSDKContext(ctx).BlockHeight())
+	if minBucket := uint64(types.CodeGasUsageWindow / types.CodeGasUsageBucketLength); bucket > minBucket {
+		if err := k.pruneCodeGasUsage(ctx, bucket-minBucket); err != nil {
+			return err
+		}
+	}
+	if k.transientStoreService == nil {
+		return nil
+	}
+	blockStore := prefix.NewStore(runtime.KVStoreAdapter(k.transientStoreService.OpenTransientStore(ctx)), types.CodeGasUsedPrefix)
+	iter := blockStore.Iterator(nil, nil)
+	var blockUsage []types.CodeGasUsage
+	for ; iter.Valid(); iter.Next() {
+		blockUsage = append(blockUsage, types.CodeGasUsage{CodeID: sdk.BigEndianToUint64(iter.Key()), GasUsed: sdk.BigEndianToUint64(iter.Value())})
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	for _, u := range blockUsage {
+		key := types.GetCodeGasUsageKey(bucket, u.CodeID)
+		bz, err := store.Get(key)
+		if err != nil {
+			return err
+		}
+		var total uint64
+		if len(bz) == 8 {
+			total = sdk.BigEndianToUint64(bz)
+		}
+		if err := store.Set(key, sdk.Uint64ToBigEndian(addGas(total, u.GasUsed))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneCodeGasUsage deletes up to codeGasUsagePruneLimit entries of the buckets before the given bucket
+func (k Keeper) pruneCodeGasUsage(ctx context.Context, beforeBucket uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	iter, err := store.Iterator(types.CodeGasUsagePrefix, types.GetCodeGasUsageBucketPrefix(beforeBucket))
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; iter.Valid() && len(keys) < codeGasUsagePruneLimit; iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IterateCodeGasUsage iterates over the execution gas per code of the buckets in the given range in ascending
+// order. The callback returns true to stop the iteration.
+func (k Keeper) IterateCodeGasUsage(ctx context.Context, fromBucket, toBucket uint64, cb func(bucket, codeID, gasUsed uint64) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.CodeGasUsagePrefix)
+	var end []byte
+	if toBucket < math.MaxUint64 {
+		end = sdk.Uint64ToBigEndian(toBucket + 1)
+	}
+	iter := prefixStore.Iterator(sdk.Uint64ToBigEndian(fromBucket), end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if cb(sdk.BigEndianToUint64(key[:8]), sdk.BigEndianToUint64(key[8:]), sdk.BigEndianToUint64(iter.Value())) {
+			return
+		}
+	}
+}
+
+// addGas returns the sum capped at max uint64
+func addGas(a, b uint64) uint64 {
+	if a > math.MaxUint64-b {
+		return math.MaxUint64
+	}
+	return a + b
+}
@@ -0,0 +1,189 @@
+package keeper
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCodeGasUsageRecording(t *testing.T) {
+	specs := map[string]struct {
+		record bool
+	}{
+		"enabled":  {record: true},
+		"disabled": {},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			example := InstantiateHackatomExampleContract(t, ctx, keepers)
+			k := keepers.WasmKeeper
+			params := k.GetParams(ctx)
+			params.RecordCodeGasUsage = spec.record
+			require.NoError(t, k.SetParams(ctx, params))
+
+			// when
+			gasBefore := ctx.GasMeter().GasConsumed()
+			_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+			require.NoError(t, err)
+			gasUsed := ctx.GasMeter().GasConsumed() - gasBefore
+
+			// then
+			bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(types.GetCodeGasUsedKey(example.CodeID))
+			require.NoError(t, err)
+			if !spec.record {
+				assert.Nil(t, bz)
+				return
+			}
+			require.Len(t, bz, 8)
+			got := sdk.BigEndianToUint64(bz)
+			assert.NotZero(t, got)
+			assert.LessOrEqual(t, got, gasUsed)
+		})
+	}
+}
+
+func TestCodeGasUsageBuckets(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	q := Querier(k)
+	params := k.GetParams(ctx)
+	params.RecordCodeGasUsage = true
+	require.NoError(t, k.SetParams(ctx, params))
+
+	endBlock := func(height int64, gasUsed map[uint64]uint64) {
+		ctx = ctx.WithBlockHeight(height)
+		for codeID, gas := range gasUsed {
+			require.NoError(t, k.addCodeGasUsed(ctx, codeID, gas))
+		}
+		require.NoError(t, k.EndBlocker(ctx))
+		for codeID := range gasUsed {
+			require.NoError(t, k.transientStoreService.OpenTransientStore(ctx).Delete(types.GetCodeGasUsedKey(codeID)))
+		}
+	}
+	topGas := func(t *testing.T, window uint64) *types.QueryTopGasConsumersResponse {
+		t.Helper()
+		res, err := q.TopGasConsumers(ctx, &types.QueryTopGasConsumersRequest{WindowBlocks: window})
+		require.NoError(t, err)
+		return res
+	}
+	// executions across the bucket boundaries
+	endBlock(999, map[uint64]uint64{1: 100, 2: 50})
+	endBlock(1000, map[uint64]uint64{2: 100})
+	endBlock(1001, map[uint64]uint64{2: 10, 3: 300})
+
+	res := topGas(t, 0)
+	assert.Equal(t, []types.CodeGasUsage{{CodeID: 3, GasUsed: 300}, {CodeID: 2, GasUsed: 160}, {CodeID: 1, GasUsed: 100}}, res.Consumers)
+	assert.Equal(t, int64(1), res.FromHeight)
+	assert.Equal(t, int64(1001), res.ToHeight)
+
+	// the window is rounded down to the start of its first bucket
+	res, err := q.TopGasConsumers(ctx.WithBlockHeight(2000), &types.QueryTopGasConsumersRequest{WindowBlocks: types.CodeGasUsageBucketLength})
+	require.NoError(t, err)
+	assert.Equal(t, []types.CodeGasUsage{{CodeID: 3, GasUsed: 300}, {CodeID: 2, GasUsed: 110}}, res.Consumers)
+	assert.Equal(t, int64(1000), res.FromHeight)
+	assert.Equal(t, int64(2000), res.ToHeight)
+
+	// when the first bucket leaves the window
+	endBlock(types.CodeGasUsageWindow+1000, map[uint64]uint64{1: 1})
+
+	// then it is evicted
+	res = topGas(t, 0)
+	assert.Equal(t, []types.CodeGasUsage{{CodeID: 3, GasUsed: 300}, {CodeID: 2, GasUsed: 110}, {CodeID: 1, GasUsed: 1}}, res.Consumers)
+	var buckets []uint64
+	k.IterateCodeGasUsage(ctx, 0, math.MaxUint64, func(bucket, _, _ uint64) bool {
+		buckets = append(buckets, bucket)
+		return false
+	})
+	assert.Equal(t, []uint64{1, 1, 11}, buckets)
+}
+
+func TestCodeGasUsagePruningIsBounded(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	store := k.storeService.OpenKVStore(ctx)
+	for codeID := uint64(1); codeID <= codeGasUsagePruneLimit+50; codeID++ {
+		require.NoError(t, store.Set(types.GetCodeGasUsageKey(0, codeID), sdk.Uint64ToBigEndian(1)))
+	}
+	countEntries := func() int {
+		var r int
+		k.IterateCodeGasUsage(ctx, 0, math.MaxUint64, func(_, _, _ uint64) bool {
+			r++
+			return false
+		})
+		return r
+	}
+	ctx = ctx.WithBlockHeight(types.CodeGasUsageWindow + types.CodeGasUsageBucketLength)
+
+	require.NoError(t, k.EndBlocker(ctx))
+	assert.Equal(t, 50, countEntries())
+
+	require.NoError(t, k.EndBlocker(ctx))
+	assert.Equal(t, 0, countEntries())
+}
+
+func TestQueryTopGasConsumers(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	q := Querier(k)
+	store := k.storeService.OpenKVStore(ctx)
+	for codeID := uint64(1); codeID <= 30; codeID++ {
+		require.NoError(t, store.Set(types.GetCodeGasUsageKey(0, codeID), sdk.Uint64ToBigEndian(codeID%3)))
+	}
+	ctx = ctx.WithBlockHeight(10)
+
+	specs := map[string]struct {
+		src      types.QueryTopGasConsumersRequest
+		expLen   int
+		expFirst []types.CodeGasUsage
+		expCode  codes.Code
+	}{
+		"default limit": {
+			expLen:   20,
+			expFirst: []types.CodeGasUsage{{CodeID: 2, GasUsed: 2}, {CodeID: 5, GasUsed: 2}},
+		},
+		"custom limit": {
+			src:      types.QueryTopGasConsumersRequest{Limit: 1},
+			expLen:   1,
+			expFirst: []types.CodeGasUsage{{CodeID: 2, GasUsed: 2}},
+		},
+		"limit above max": {
+			src:     types.QueryTopGasConsumersRequest{Limit: maxTopGasConsumersLimit + 1},
+			expCode: codes.InvalidArgument,
+		},
+		"window below bucket length": {
+			src:     types.QueryTopGasConsumersRequest{WindowBlocks: types.CodeGasUsageBucketLength - 1},
+			expCode: codes.InvalidArgument,
+		},
+		"window of one bucket": {
+			src:      types.QueryTopGasConsumersRequest{WindowBlocks: types.CodeGasUsageBucketLength},
+			expLen:   20,
+			expFirst: []types.CodeGasUsage{{CodeID: 2, GasUsed: 2}, {CodeID: 5, GasUsed: 2}},
+		},
+		"window above max": {
+			src:     types.QueryTopGasConsumersRequest{WindowBlocks: types.CodeGasUsageWindow + 1},
+			expCode: codes.InvalidArgument,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.TopGasConsumers(ctx, &spec.src)
+			if spec.expCode != codes.OK {
+				require.Error(t, gotErr)
+				assert.Equal(t, spec.expCode, status.Code(gotErr))
+				return
+			}
+			require.NoError(t, gotErr)
+			require.Len(t, got.Consumers, spec.expLen)
+			assert.Equal(t, spec.expFirst, got.Consumers[:len(spec.expFirst)])
+		})
+	}
+}
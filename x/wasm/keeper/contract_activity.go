@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"context"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetContractLastActivityHeight returns the block height of the last recorded execute, sudo or ibc call of the
+// contract. Zero is returned when no call was recorded.
+func (k Keeper) GetContractLastActivityHeight(ctx context.Context, contractAddr sdk.AccAddress) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetContractLastActivityKey(contractAddr))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) != 8 {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// recordContractActivity stores the current block height as the last activity of the contract when enabled by
+// the TrackContractActivity param. The gas is charged via the gas register only, so that nothing is charged when
+// disabled and the costs are the same for any address length.
+func (k Keeper) recordContractActivity(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	if !k.cachedParams(ctx).TrackContractActivity {
+		return nil
+	}
+	ctx.GasMeter().ConsumeGas(k.gasRegister.ContractActivityCosts(), "wasm contract activity")
+	return k.setContractLastActivityHeight(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()), contractAddr, uint64(ctx.BlockHeight()))
+}
+
+func (k Keeper) setContractLastActivityHeight(ctx context.Context, contractAddr sdk.AccAddress, height uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractLastActivityKey(contractAddr), sdk.Uint64ToBigEndian(height))
+}
@@ -0,0 +1,141 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestRecordContractActivity(t *testing.T) {
+	var m wasmtesting.MockWasmEngine
+	wasmtesting.MakeIBCInstantiable(&m)
+	okResult := &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}
+	m.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		if string(executeMsg) == `{"fail":{}}` {
+			return &wasmvmtypes.ContractResult{Err: "testing"}, 0, nil
+		}
+		return okResult, 0, nil
+	}
+	m.SudoFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return okResult, 0, nil
+	}
+	m.IBCChannelConnectFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCChannelConnectMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+		return &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}}, 0, nil
+	}
+	m.IBCPacketReceiveFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCPacketReceiveMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCReceiveResult, uint64, error) {
+		return &wasmvmtypes.IBCReceiveResult{Ok: &wasmvmtypes.IBCReceiveResponse{Acknowledgement: []byte("ok")}}, 0, nil
+	}
+	m.IBCSourceCallbackFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, msg wasmvmtypes.IBCSourceCallbackMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.IBCBasicResult, uint64, error) {
+		return &wasmvmtypes.IBCBasicResult{Ok: &wasmvmtypes.IBCBasicResponse{}}, 0, nil
+	}
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	k := keepers.WasmKeeper
+
+	specs := map[string]struct {
+		exec        func(ctx sdk.Context) error
+		expErr      bool
+		expRecorded bool
+	}{
+		"execute": {
+			exec: func(ctx sdk.Context) error {
+				_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{}`), nil)
+				return err
+			},
+			expRecorded: true,
+		},
+		"failed execute": {
+			exec: func(ctx sdk.Context) error {
+				_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.CreatorAddr, []byte(`{"fail":{}}`), nil)
+				return err
+			},
+			expErr: true,
+		},
+		"sudo": {
+			exec: func(ctx sdk.Context) error {
+				_, err := k.Sudo(ctx, example.Contract, []byte(`{}`))
+				return err
+			},
+			expRecorded: true,
+		},
+		"ibc channel connect": {
+			exec: func(ctx sdk.Context) error {
+				return k.OnConnectChannel(ctx, example.Contract, wasmvmtypes.IBCChannelConnectMsg{})
+			},
+			expRecorded: true,
+		},
+		"ibc packet receive": {
+			exec: func(ctx sdk.Context) error {
+				_, err := k.OnRecvPacket(ctx, example.Contract, wasmvmtypes.IBCPacketReceiveMsg{})
+				return err
+			},
+			expRecorded: true,
+		},
+		"ibc source callback": {
+			exec: func(ctx sdk.Context) error {
+				return k.IBCSourceCallback(ctx, example.Contract, wasmvmtypes.IBCSourceCallbackMsg{})
+			},
+			expRecorded: true,
+		},
+	}
+	for name, spec := range specs {
+		for _, enabled := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s - tracking enabled: %v", name, enabled), func(t *testing.T) {
+				ctx, _ := parentCtx.WithBlockHeight(100).CacheContext()
+				params := k.GetParams(ctx)
+				params.TrackContractActivity = enabled
+				require.NoError(t, k.SetParams(ctx, params))
+
+				// when
+				gotErr := spec.exec(ctx)
+
+				// then
+				if spec.expErr {
+					require.Error(t, gotErr)
+				} else {
+					require.NoError(t, gotErr)
+				}
+				var expHeight uint64
+				if enabled && spec.expRecorded {
+					expHeight = 100
+				}
+				assert.Equal(t, expHeight, k.GetContractLastActivityHeight(ctx, example.Contract))
+			})
+		}
+	}
+}
+
+func TestRecordContractActivityGas(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	contractAddr := RandomAccountAddress(t)
+
+	for _, enabled := range []bool{true, false} {
+		ctx, _ := parentCtx.WithBlockHeight(100).CacheContext()
+		params := k.GetParams(ctx)
+		params.TrackContractActivity = enabled
+		require.NoError(t, k.SetParams(ctx, params))
+
+		// when
+		ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		require.NoError(t, k.recordContractActivity(ctx, contractAddr))
+
+		// then
+		var expGas storetypes.Gas
+		if enabled {
+			expGas = types.DefaultContractActivityCost
+		}
+		assert.Equal(t, expGas, ctx.GasMeter().GasConsumed())
+	}
+}
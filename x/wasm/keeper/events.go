@@ -1,8 +1,10 @@
 package keeper
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
@@ -13,10 +15,113 @@ import (
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
+// EventAttributeViolationMode defines how contract attributes that violate the EventAttributePolicy are handled
+type EventAttributeViolationMode int
+
+const (
+	// EventAttributeViolationError fails the contract execution
+	EventAttributeViolationError EventAttributeViolationMode = iota
+	// EventAttributeViolationTruncate drops or truncates the violating attributes
+	EventAttributeViolationTruncate
+)
+
+// EventAttributePolicy defines the rules for attributes that contracts can emit with an execution
+type EventAttributePolicy struct {
+	// ReservedPrefixes that contract attribute keys must not start with. The `_` prefix is always reserved.
+	ReservedPrefixes []string
+	// MaxAttributes is the max number of attributes over all events of an execution. 0 for no limit
+	MaxAttributes int
+	// MaxAttributeSize is the max length of key and value of an attribute. 0 for no limit
+	MaxAttributeSize int
+	// ViolationMode defines how violations are handled
+	ViolationMode EventAttributeViolationMode
+}
+
+// DefaultEventAttributePolicy reserves the `_` prefix and has no limits
+func DefaultEventAttributePolicy() EventAttributePolicy {
+	return EventAttributePolicy{ReservedPrefixes: []string{types.AttributeReservedPrefix}}
+}
+
+// apply enforces the attribute limits. In truncate mode, attributes with reserved prefixes and attributes over the
+// max count are dropped and values are truncated to the max attribute size.
+// Reserved prefixes in error mode are checked on conversion to sdk events.
+func (p EventAttributePolicy) apply(
+	attrs []wasmvmtypes.EventAttribute,
+	evts wasmvmtypes.Array[wasmvmtypes.Event],
+) ([]wasmvmtypes.EventAttribute, wasmvmtypes.Array[wasmvmtypes.Event], error) {
+	if p.ViolationMode == EventAttributeViolationError && p.MaxAttributes == 0 && p.MaxAttributeSize == 0 {
+		return attrs, evts, nil
+	}
+	var count int
+	filter := func(src []wasmvmtypes.EventAttribute) ([]wasmvmtypes.EventAttribute, error) {
+		r := make([]wasmvmtypes.EventAttribute, 0, len(src))
+		for _, a := range src {
+			if p.ViolationMode == EventAttributeViolationTruncate && p.isReserved(a.Key) != "" {
+				continue
+			}
+			if p.MaxAttributes != 0 && count >= p.MaxAttributes {
+				if p.ViolationMode == EventAttributeViolationTruncate {
+					return r, nil
+				}
+				return nil, errorsmod.Wrapf(types.ErrInvalidEvent, "more than %d attributes", p.MaxAttributes)
+			}
+			if p.MaxAttributeSize != 0 && len(a.Key)+len(a.Value) > p.MaxAttributeSize {
+				if p.ViolationMode != EventAttributeViolationTruncate {
+					return nil, errorsmod.Wrapf(types.ErrInvalidEvent, "attribute %q exceeds max size %d", a.Key, p.MaxAttributeSize)
+				}
+				if len(a.Key) >= p.MaxAttributeSize {
+					continue
+				}
+				a.Value = truncateUTF8(a.Value, p.MaxAttributeSize-len(a.Key))
+			}
+			r = append(r, a)
+			count++
+		}
+		return r, nil
+	}
+	resultAttrs, err := filter(attrs)
+	if err != nil {
+		return nil, nil, err
+	}
+	resultEvts := make(wasmvmtypes.Array[wasmvmtypes.Event], len(evts))
+	for i, e := range evts {
+		if e.Attributes, err = filter(e.Attributes); err != nil {
+			return nil, nil, err
+		}
+		resultEvts[i] = e
+	}
+	return resultAttrs, resultEvts, nil
+}
+
+// isReserved returns the reserved prefix of the key or empty string when not reserved
+func (p EventAttributePolicy) isReserved(key string) string {
+	key = strings.TrimSpace(key)
+	if strings.HasPrefix(key, types.AttributeReservedPrefix) {
+		return types.AttributeReservedPrefix
+	}
+	for _, prefix := range p.ReservedPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
+
+// truncateUTF8 truncates s to max n bytes without splitting a multi byte character
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
 // newWasmModuleEvent creates with wasm module event for interacting with the given contract. Adds custom attributes
 // to this event.
-func newWasmModuleEvent(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress) (sdk.Events, error) {
-	attrs, err := contractSDKEventAttributes(customAttributes, contractAddr)
+func newWasmModuleEvent(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress, policy EventAttributePolicy) (sdk.Events, error) {
+	attrs, err := contractSDKEventAttributes(customAttributes, contractAddr, policy)
 	if err != nil {
 		return nil, err
 	}
@@ -28,14 +133,14 @@ func newWasmModuleEvent(customAttributes []wasmvmtypes.EventAttribute, contractA
 const eventTypeMinLength = 2
 
 // newCustomEvents converts wasmvm events from a contract response to sdk type events
-func newCustomEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk.AccAddress) (sdk.Events, error) {
+func newCustomEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk.AccAddress, policy EventAttributePolicy) (sdk.Events, error) {
 	events := make(sdk.Events, 0, len(evts))
 	for _, e := range evts {
 		errType := strings.TrimSpace(e.Type)
 		if len(errType) <= eventTypeMinLength {
 			return nil, errorsmod.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Event type too short: '%s'", errType))
 		}
-		attributes, err := contractSDKEventAttributes(e.Attributes, contractAddr)
+		attributes, err := contractSDKEventAttributes(e.Attributes, contractAddr, policy)
 		if err != nil {
 			return nil, err
 		}
@@ -45,7 +150,7 @@ func newCustomEvents(evts wasmvmtypes.Array[wasmvmtypes.Event], contractAddr sdk
 }
 
 // convert and add contract address issuing this event
-func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress) ([]sdk.Attribute, error) {
+func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, contractAddr sdk.AccAddress, policy EventAttributePolicy) ([]sdk.Attribute, error) {
 	attrs := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String())}
 	// append attributes from wasm to the sdk.Event
 	for _, l := range customAttributes {
@@ -56,10 +161,59 @@ func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, c
 		}
 		value := strings.TrimSpace(l.Value)
 		// and reserve all _* keys for our use (not contract)
-		if strings.HasPrefix(key, types.AttributeReservedPrefix) {
-			return nil, errorsmod.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Attribute key starts with reserved prefix %s: '%s'", types.AttributeReservedPrefix, key))
+		if prefix := policy.isReserved(key); prefix != "" {
+			return nil, errorsmod.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Attribute key starts with reserved prefix %s: '%s'", prefix, key))
 		}
 		attrs = append(attrs, sdk.NewAttribute(key, value))
 	}
 	return attrs, nil
 }
+
+// emitContractManagementChanged emits the typed event for a change that affects the management of the contract.
+// The contract address is empty for changes of the code when contractAddr is nil.
+func emitContractManagementChanged(ctx context.Context, contractAddr sdk.AccAddress, codeID uint64, changeType types.ContractManagementChangeType, oldValue, newValue string) error {
+	var addr string
+	if len(contractAddr) != 0 {
+		addr = contractAddr.String()
+	}
+	return sdk.UnwrapSDKContext(ctx).EventManager().EmitTypedEvent(&types.EventContractManagementChanged{
+		ContractAddress: addr,
+		CodeID:          codeID,
+		ChangeType:      changeType,
+		OldValue:        oldValue,
+		NewValue:        newValue,
+	})
+}
+
+// maxCodeManagementEventContracts is the max number of contracts of a code that a change of the code is emitted for
+const maxCodeManagementEventContracts = 100
+
+// emitCodeManagementChanged emits the typed event for a change of the code once without contract address and
+// for each of the first maxCodeManagementEventContracts contracts of the code. Subscribers match the other
+// contracts by the code id. The contracts are read from the gas metered store.
+func (k Keeper) emitCodeManagementChanged(ctx context.Context, codeID uint64, changeType types.ContractManagementChangeType, oldValue, newValue string) error {
+	if err := emitContractManagementChanged(ctx, nil, codeID, changeType, oldValue, newValue); err != nil {
+		return err
+	}
+	var (
+		n   int
+		err error
+	)
+	k.IterateContractsByCode(ctx, codeID, func(contractAddr sdk.AccAddress) bool {
+		if n == maxCodeManagementEventContracts {
+			return true
+		}
+		n++
+		err = emitContractManagementChanged(ctx, contractAddr, codeID, changeType, oldValue, newValue)
+		return err != nil
+	})
+	return err
+}
+
+// accessConfigValue returns the permission followed by the authorized addresses, if any
+func accessConfigValue(c types.AccessConfig) string {
+	if addrs := c.AllAuthorizedAddresses(); len(addrs) != 0 {
+		return c.Permission.String() + ":" + strings.Join(addrs, ",")
+	}
+	return c.Permission.String()
+}
@@ -2,13 +2,22 @@ package keeper
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"testing"
 
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
@@ -194,7 +203,7 @@ func TestNewCustomEvents(t *testing.T) {
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			gotEvent, err := newCustomEvents(spec.src, myContract)
+			gotEvent, err := newCustomEvents(spec.src, myContract, DefaultEventAttributePolicy())
 			if spec.isError {
 				assert.Error(t, err)
 			} else {
@@ -270,7 +279,7 @@ func TestNewWasmModuleEvent(t *testing.T) {
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			gotEvent, err := newWasmModuleEvent(spec.src, myContract)
+			gotEvent, err := newWasmModuleEvent(spec.src, myContract, DefaultEventAttributePolicy())
 			if spec.isError {
 				assert.Error(t, err)
 			} else {
@@ -294,3 +303,294 @@ func hasWasmModuleEvent(ctx sdk.Context, contractAddr sdk.AccAddress) bool {
 	}
 	return false
 }
+
+func TestEventAttributePolicy(t *testing.T) {
+	attr := func(k, v string) wasmvmtypes.EventAttribute { return wasmvmtypes.EventAttribute{Key: k, Value: v} }
+	specs := map[string]struct {
+		policy   EventAttributePolicy
+		attrs    []wasmvmtypes.EventAttribute
+		evts     wasmvmtypes.Array[wasmvmtypes.Event]
+		expAttrs []wasmvmtypes.EventAttribute
+		expEvts  wasmvmtypes.Array[wasmvmtypes.Event]
+		expErr   bool
+	}{
+		"default unchanged": {
+			policy:   DefaultEventAttributePolicy(),
+			attrs:    []wasmvmtypes.EventAttribute{attr("a", "1"), attr("_b", "2")},
+			evts:     wasmvmtypes.Array[wasmvmtypes.Event]{{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{attr("c", "3")}}},
+			expAttrs: []wasmvmtypes.EventAttribute{attr("a", "1"), attr("_b", "2")},
+			expEvts:  wasmvmtypes.Array[wasmvmtypes.Event]{{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{attr("c", "3")}}},
+		},
+		"max attributes - error": {
+			policy: EventAttributePolicy{MaxAttributes: 2},
+			attrs:  []wasmvmtypes.EventAttribute{attr("a", "1"), attr("b", "2")},
+			evts:   wasmvmtypes.Array[wasmvmtypes.Event]{{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{attr("c", "3")}}},
+			expErr: true,
+		},
+		"max attributes - truncate": {
+			policy:   EventAttributePolicy{MaxAttributes: 2, ViolationMode: EventAttributeViolationTruncate},
+			attrs:    []wasmvmtypes.EventAttribute{attr("a", "1")},
+			evts:     wasmvmtypes.Array[wasmvmtypes.Event]{{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{attr("b", "2"), attr("c", "3")}}, {Type: "bar", Attributes: []wasmvmtypes.EventAttribute{attr("d", "4")}}},
+			expAttrs: []wasmvmtypes.EventAttribute{attr("a", "1")},
+			expEvts:  wasmvmtypes.Array[wasmvmtypes.Event]{{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{attr("b", "2")}}, {Type: "bar", Attributes: []wasmvmtypes.EventAttribute{}}},
+		},
+		"max size - error": {
+			policy: EventAttributePolicy{MaxAttributeSize: 4},
+			attrs:  []wasmvmtypes.EventAttribute{attr("a", "1"), attr("b", "2345")},
+			expErr: true,
+		},
+		"max size - truncate": {
+			policy:   EventAttributePolicy{MaxAttributeSize: 4, ViolationMode: EventAttributeViolationTruncate},
+			attrs:    []wasmvmtypes.EventAttribute{attr("a", "1"), attr("b", "2345"), attr("c", "ab€"), attr("long", "1")},
+			evts:     wasmvmtypes.Array[wasmvmtypes.Event]{},
+			expAttrs: []wasmvmtypes.EventAttribute{attr("a", "1"), attr("b", "234"), attr("c", "ab")},
+			expEvts:  wasmvmtypes.Array[wasmvmtypes.Event]{},
+		},
+		"reserved prefix - truncate": {
+			policy:   EventAttributePolicy{ReservedPrefixes: []string{"_", "x-"}, ViolationMode: EventAttributeViolationTruncate},
+			attrs:    []wasmvmtypes.EventAttribute{attr("a", "1"), attr(" _b", "2"), attr("x-c", "3")},
+			evts:     wasmvmtypes.Array[wasmvmtypes.Event]{},
+			expAttrs: []wasmvmtypes.EventAttribute{attr("a", "1")},
+			expEvts:  wasmvmtypes.Array[wasmvmtypes.Event]{},
+		},
+		"default prefix always reserved - truncate": {
+			policy:   EventAttributePolicy{ViolationMode: EventAttributeViolationTruncate},
+			attrs:    []wasmvmtypes.EventAttribute{attr("a", "1"), attr("_b", "2")},
+			evts:     wasmvmtypes.Array[wasmvmtypes.Event]{},
+			expAttrs: []wasmvmtypes.EventAttribute{attr("a", "1")},
+			expEvts:  wasmvmtypes.Array[wasmvmtypes.Event]{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotAttrs, gotEvts, gotErr := spec.policy.apply(spec.attrs, spec.evts)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				require.ErrorIs(t, gotErr, types.ErrInvalidEvent)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAttrs, gotAttrs)
+			assert.Equal(t, spec.expEvts, gotEvts)
+		})
+	}
+}
+
+func TestEventAttributePolicyReservedPrefixes(t *testing.T) {
+	myContract := RandomAccountAddress(t)
+	policy := EventAttributePolicy{ReservedPrefixes: []string{"x-"}}
+	// custom prefixes are rejected
+	_, err := newWasmModuleEvent([]wasmvmtypes.EventAttribute{{Key: "x-foo", Value: "bar"}}, myContract, policy)
+	require.ErrorIs(t, err, types.ErrInvalidEvent)
+	// and the default prefix is still reserved
+	_, err = newWasmModuleEvent([]wasmvmtypes.EventAttribute{{Key: "_foo", Value: "bar"}}, myContract, policy)
+	require.ErrorIs(t, err, types.ErrInvalidEvent)
+	// also without custom prefixes
+	_, err = newCustomEvents(wasmvmtypes.Array[wasmvmtypes.Event]{{Type: "foo", Attributes: []wasmvmtypes.EventAttribute{{Key: "_foo", Value: "bar"}}}}, myContract, EventAttributePolicy{})
+	require.ErrorIs(t, err, types.ErrInvalidEvent)
+	// while other keys are accepted
+	gotEvts, err := newWasmModuleEvent([]wasmvmtypes.EventAttribute{{Key: "foo", Value: "bar"}}, myContract, policy)
+	require.NoError(t, err)
+	assert.Equal(t, sdk.Events{sdk.NewEvent("wasm",
+		sdk.NewAttribute("_contract_address", myContract.String()),
+		sdk.NewAttribute("foo", "bar"))}, gotEvts)
+}
+
+func TestHandleContractResponseAttributeLimits(t *testing.T) {
+	myContract := RandomAccountAddress(t)
+	bigAttrs := make([]wasmvmtypes.EventAttribute, 10)
+	for i := range bigAttrs {
+		bigAttrs[i] = wasmvmtypes.EventAttribute{Key: "key" + strconv.Itoa(i), Value: strings.Repeat("x", 1000)}
+	}
+	specs := map[string]struct {
+		policy      EventAttributePolicy
+		expGasAttrs []wasmvmtypes.EventAttribute
+		expErr      bool
+	}{
+		"default": {
+			policy:      DefaultEventAttributePolicy(),
+			expGasAttrs: bigAttrs,
+		},
+		"truncate": {
+			policy:      EventAttributePolicy{MaxAttributes: 2, MaxAttributeSize: 100, ViolationMode: EventAttributeViolationTruncate},
+			expGasAttrs: []wasmvmtypes.EventAttribute{{Key: "key0", Value: strings.Repeat("x", 96)}, {Key: "key1", Value: strings.Repeat("x", 96)}},
+		},
+		"error": {
+			policy: EventAttributePolicy{MaxAttributes: 2},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithEventAttributePolicy(spec.policy))
+			k := keepers.WasmKeeper
+			ctx := parentCtx.WithGasMeter(storetypes.NewInfiniteGasMeter()).WithEventManager(sdk.NewEventManager())
+
+			// when
+			_, gotErr := k.handleContractResponse(ctx, myContract, "", nil, bigAttrs, nil, nil)
+
+			// then
+			if spec.expErr {
+				require.ErrorIs(t, gotErr, types.ErrInvalidEvent)
+				assert.Empty(t, ctx.EventManager().Events())
+				assert.Zero(t, ctx.GasMeter().GasConsumed())
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, k.gasRegister.EventCosts(spec.expGasAttrs, nil), ctx.GasMeter().GasConsumed())
+			require.Len(t, ctx.EventManager().Events(), 1)
+			assert.Len(t, ctx.EventManager().Events()[0].Attributes, len(spec.expGasAttrs)+1)
+		})
+	}
+}
+
+func TestContractManagementChangedEvents(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	burnerCodeID := StoreBurnerExampleContract(t, parentCtx, keepers).CodeID
+	myAdmin := RandomAccountAddress(t)
+
+	specs := map[string]struct {
+		exec      func(ctx sdk.Context) error
+		expType   types.ContractManagementChangeType
+		expCode   bool
+		expCodeID uint64
+		expOld    string
+		expNew    string
+	}{
+		"update admin": {
+			exec: func(ctx sdk.Context) error {
+				return k.setContractAdmin(ctx, example.Contract, example.CreatorAddr, myAdmin, DefaultAuthorizationPolicy{})
+			},
+			expType: types.ContractManagementChangeTypeAdmin,
+			expOld:  example.CreatorAddr.String(),
+			expNew:  myAdmin.String(),
+		},
+		"clear admin": {
+			exec: func(ctx sdk.Context) error {
+				return k.setContractAdmin(ctx, example.Contract, example.CreatorAddr, nil, DefaultAuthorizationPolicy{})
+			},
+			expType: types.ContractManagementChangeTypeAdmin,
+			expOld:  example.CreatorAddr.String(),
+		},
+		"update label": {
+			exec: func(ctx sdk.Context) error {
+				return k.setContractLabel(ctx, example.Contract, example.CreatorAddr, "new label", DefaultAuthorizationPolicy{})
+			},
+			expType: types.ContractManagementChangeTypeLabel,
+			expOld:  "hackatom contract",
+			expNew:  "new label",
+		},
+		"update instantiate config": {
+			exec: func(ctx sdk.Context) error {
+				return k.setAccessConfig(ctx, example.CodeID, example.CreatorAddr, types.AccessTypeAnyOfAddresses.With(myAdmin), DefaultAuthorizationPolicy{})
+			},
+			expType: types.ContractManagementChangeTypeInstantiateConfig,
+			expCode: true,
+			expOld:  "Everybody",
+			expNew:  "AnyOfAddresses:" + myAdmin.String(),
+		},
+		"pin code": {
+			exec: func(ctx sdk.Context) error {
+				return k.pinCode(ctx, example.CodeID)
+			},
+			expType: types.ContractManagementChangeTypePin,
+			expCode: true,
+			expOld:  "false",
+			expNew:  "true",
+		},
+		"unpin code": {
+			exec: func(ctx sdk.Context) error {
+				require.NoError(t, k.pinCode(ctx.WithEventManager(sdk.NewEventManager()), example.CodeID))
+				return k.unpinCode(ctx, example.CodeID)
+			},
+			expType: types.ContractManagementChangeTypePin,
+			expCode: true,
+			expOld:  "true",
+			expNew:  "false",
+		},
+		"migrate": {
+			exec: func(ctx sdk.Context) error {
+				migMsg := BurnerExampleInitMsg{Payout: example.CreatorAddr}.GetBytes(t)
+				_, err := k.migrate(ctx, example.Contract, example.CreatorAddr, burnerCodeID, migMsg, DefaultAuthorizationPolicy{})
+				return err
+			},
+			expType:   types.ContractManagementChangeTypeMigrate,
+			expCodeID: burnerCodeID,
+			expOld:    strconv.FormatUint(example.CodeID, 10),
+			expNew:    strconv.FormatUint(burnerCodeID, 10),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+
+			// when
+			require.NoError(t, spec.exec(ctx.WithEventManager(em)))
+
+			// then
+			var got []*types.EventContractManagementChanged
+			for _, e := range em.Events() {
+				if e.Type != proto.MessageName(&types.EventContractManagementChanged{}) {
+					continue
+				}
+				evt, err := sdk.ParseTypedEvent(abci.Event(e))
+				require.NoError(t, err)
+				got = append(got, evt.(*types.EventContractManagementChanged))
+			}
+			expCodeID := spec.expCodeID
+			if expCodeID == 0 {
+				expCodeID = example.CodeID
+			}
+			exp := []*types.EventContractManagementChanged{{
+				ContractAddress: example.Contract.String(),
+				CodeID:          expCodeID,
+				ChangeType:      spec.expType,
+				OldValue:        spec.expOld,
+				NewValue:        spec.expNew,
+			}}
+			// code changes are emitted once per code and for the contracts of the code
+			if spec.expCode {
+				codeEvt := *exp[0]
+				codeEvt.ContractAddress = ""
+				exp = append([]*types.EventContractManagementChanged{&codeEvt}, exp...)
+			}
+			assert.Equal(t, exp, got)
+		})
+	}
+}
+
+func TestCodeManagementChangedEventsLimit(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	m := wasmtesting.MockWasmEngine{PinFn: func(checksum wasmvm.Checksum) error { return nil }}
+	wasmtesting.MakeInstantiable(&m)
+	example := SeedNewContractInstance(t, parentCtx, keepers, &m)
+	contracts := []string{example.Contract.String()}
+	for i := 1; i <= maxCodeManagementEventContracts; i++ {
+		// instantiated at increasing heights for a deterministic order in the code index
+		addr, _, err := keepers.ContractKeeper.Instantiate(parentCtx.WithBlockHeight(parentCtx.BlockHeight()+int64(i)), example.CodeID, example.CreatorAddr, nil, []byte(`{}`), "testing", nil)
+		require.NoError(t, err)
+		contracts = append(contracts, addr.String())
+	}
+	ctx, _ := parentCtx.CacheContext()
+	em := sdk.NewEventManager()
+
+	// when
+	require.NoError(t, k.pinCode(ctx.WithEventManager(em), example.CodeID))
+
+	// then the event per code and the events for the first contracts are emitted
+	var got []string
+	for _, e := range em.Events() {
+		if e.Type != proto.MessageName(&types.EventContractManagementChanged{}) {
+			continue
+		}
+		evt, err := sdk.ParseTypedEvent(abci.Event(e))
+		require.NoError(t, err)
+		got = append(got, evt.(*types.EventContractManagementChanged).ContractAddress)
+	}
+	exp := append([]string{""}, contracts[:maxCodeManagementEventContracts]...)
+	assert.Equal(t, exp, got)
+}
@@ -0,0 +1,157 @@
+package keeper
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// maxExecutionReceiptContracts is the max number of contracts that receipts are kept for. The receipts of the
+// least recently executed contract are dropped when a new contract exceeds it.
+const maxExecutionReceiptContracts = 1_000
+
+// executionReceiptLog keeps the last executions per contract in memory. It is node local and must never be read
+// by state machine code so that it can not affect the consensus state or gas.
+type executionReceiptLog struct {
+	mu           sync.Mutex
+	limit        int
+	maxContracts int
+	// lru holds the contract rings, most recently executed first
+	lru   *list.List
+	rings map[string]*list.Element
+}
+
+func newExecutionReceiptLog(limit uint32) *executionReceiptLog {
+	return &executionReceiptLog{
+		limit:        int(limit),
+		maxContracts: maxExecutionReceiptContracts,
+		lru:          list.New(),
+		rings:        make(map[string]*list.Element),
+	}
+}
+
+// contractRing is the lru list value
+type contractRing struct {
+	contractAddr string
+	ring         *receiptRing
+}
+
+// add stores the receipt and evicts the oldest receipt of the contract when the limit is reached.
+// The least recently executed contract is evicted when the max number of contracts is exceeded.
+func (l *executionReceiptLog) add(contractAddr sdk.AccAddress, r types.ExecutionReceipt) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem, ok := l.rings[string(contractAddr)]; ok {
+		l.lru.MoveToFront(elem)
+		elem.Value.(*contractRing).ring.add(r)
+		return
+	}
+	ring := &receiptRing{entries: make([]types.ExecutionReceipt, 0, l.limit)}
+	ring.add(r)
+	l.rings[string(contractAddr)] = l.lru.PushFront(&contractRing{contractAddr: string(contractAddr), ring: ring})
+	for l.lru.Len() > l.maxContracts {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.rings, oldest.Value.(*contractRing).contractAddr)
+	}
+}
+
+// addAll stores the receipts in the given order and marks them as reverted when the tx failed
+func (l *executionReceiptLog) addAll(entries []types.PendingExecutionReceipt, reverted bool) {
+	for _, e := range entries {
+		e.Receipt.Reverted = reverted
+		l.add(e.Contract, e.Receipt)
+	}
+}
+
+// recent returns up to limit receipts of the contract, newest first. Zero returns all.
+func (l *executionReceiptLog) recent(contractAddr sdk.AccAddress, limit int) []types.ExecutionReceipt {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elem, ok := l.rings[string(contractAddr)]
+	if !ok {
+		return []types.ExecutionReceipt{}
+	}
+	return elem.Value.(*contractRing).ring.newestFirst(limit)
+}
+
+// receiptRing is a fixed size ring buffer. Once full, next is the position of the oldest entry.
+type receiptRing struct {
+	entries []types.ExecutionReceipt
+	next    int
+}
+
+func (r *receiptRing) add(e types.ExecutionReceipt) {
+	if len(r.entries) < cap(r.entries) {
+		r.entries = append(r.entries, e)
+		return
+	}
+	r.entries[r.next] = e
+	r.next = (r.next + 1) % len(r.entries)
+}
+
+func (r *receiptRing) newestFirst(limit int) []types.ExecutionReceipt {
+	n := len(r.entries)
+	if limit <= 0 || limit > n {
+		limit = n
+	}
+	result := make([]types.ExecutionReceipt, limit)
+	for i := range result {
+		result[i] = r.entries[(r.next-1-i+2*n)%n]
+	}
+	return result
+}
+
+// newExecutionReceipt builds the receipt of an execution that returned the given error or panicked with the
+// recovered value.
+func newExecutionReceipt(ctx sdk.Context, caller sdk.AccAddress, msg []byte, gasUsed uint64, err error, recovered any) types.ExecutionReceipt {
+	msgHash := sha256.Sum256(msg)
+	r := types.ExecutionReceipt{
+		Height:  uint64(ctx.BlockHeight()),
+		Sender:  caller.String(),
+		MsgHash: msgHash[:],
+		GasUsed: gasUsed,
+	}
+	switch oog, isOutOfGas := recovered.(storetypes.ErrorOutOfGas); {
+	case isOutOfGas:
+		r.Error = fmt.Sprintf("out of gas in location: %s", oog.Descriptor)
+	case recovered != nil:
+		r.Error = fmt.Sprintf("panic: %v", recovered)
+	case err != nil:
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// isTopLevelExecution returns true for executions that were not dispatched as sub-message by another contract
+func isTopLevelExecution(ctx sdk.Context) bool {
+	depth, _ := types.CallDepth(ctx)
+	return depth == 0
+}
+
+// RecordExecutionReceipts stores the receipts collected for the current tx once the tx result is known. The receipts
+// are marked as reverted when the tx failed.
+func (k Keeper) RecordExecutionReceipts(ctx context.Context, success bool) {
+	pending, ok := types.PendingExecutionReceiptsFromContext(ctx)
+	if !ok || k.executionReceipts == nil {
+		return
+	}
+	k.executionReceipts.addAll(pending.Take(), !success)
+}
+
+// GetRecentExecutions returns up to limit receipts of the last executions of the contract that were recorded by
+// this node, newest first. Zero returns all. The returned bool is false when receipts are disabled in the node config.
+func (k Keeper) GetRecentExecutions(contractAddr sdk.AccAddress, limit uint32) ([]types.ExecutionReceipt, bool) {
+	if k.executionReceipts == nil {
+		return nil, false
+	}
+	return k.executionReceipts.recent(contractAddr, int(limit)), true
+}
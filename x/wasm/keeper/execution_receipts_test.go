@@ -0,0 +1,239 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestExecutionReceiptLog(t *testing.T) {
+	myContract, otherContract := RandomAccountAddress(t), RandomAccountAddress(t)
+	receipt := func(height uint64) types.ExecutionReceipt {
+		return types.ExecutionReceipt{Height: height}
+	}
+	specs := map[string]struct {
+		heights []uint64
+		limit   int
+		exp     []types.ExecutionReceipt
+	}{
+		"empty": {
+			exp: []types.ExecutionReceipt{},
+		},
+		"below capacity": {
+			heights: []uint64{1, 2},
+			exp:     []types.ExecutionReceipt{receipt(2), receipt(1)},
+		},
+		"at capacity": {
+			heights: []uint64{1, 2, 3},
+			exp:     []types.ExecutionReceipt{receipt(3), receipt(2), receipt(1)},
+		},
+		"oldest evicted": {
+			heights: []uint64{1, 2, 3, 4, 5},
+			exp:     []types.ExecutionReceipt{receipt(5), receipt(4), receipt(3)},
+		},
+		"wrapped around multiple times": {
+			heights: []uint64{1, 2, 3, 4, 5, 6, 7},
+			exp:     []types.ExecutionReceipt{receipt(7), receipt(6), receipt(5)},
+		},
+		"with limit": {
+			heights: []uint64{1, 2, 3, 4},
+			limit:   2,
+			exp:     []types.ExecutionReceipt{receipt(4), receipt(3)},
+		},
+		"limit above size": {
+			heights: []uint64{1, 2},
+			limit:   10,
+			exp:     []types.ExecutionReceipt{receipt(2), receipt(1)},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			log := newExecutionReceiptLog(3)
+			for _, h := range spec.heights {
+				log.add(myContract, receipt(h))
+			}
+			log.add(otherContract, receipt(100))
+
+			// when
+			got := log.recent(myContract, spec.limit)
+
+			// then
+			assert.Equal(t, spec.exp, got)
+			assert.Equal(t, []types.ExecutionReceipt{receipt(100)}, log.recent(otherContract, 0))
+		})
+	}
+}
+
+func TestExecutionReceiptLogEvictsLeastRecentContract(t *testing.T) {
+	contractA, contractB, contractC := RandomAccountAddress(t), RandomAccountAddress(t), RandomAccountAddress(t)
+	log := newExecutionReceiptLog(3)
+	log.maxContracts = 2
+	log.add(contractA, types.ExecutionReceipt{Height: 1})
+	log.add(contractB, types.ExecutionReceipt{Height: 2})
+	// touch A so that B becomes the least recently executed contract
+	log.add(contractA, types.ExecutionReceipt{Height: 3})
+
+	// when
+	log.add(contractC, types.ExecutionReceipt{Height: 4})
+
+	// then
+	assert.Equal(t, 2, log.lru.Len())
+	assert.Len(t, log.rings, 2)
+	assert.Equal(t, []types.ExecutionReceipt{{Height: 3}, {Height: 1}}, log.recent(contractA, 0))
+	assert.Empty(t, log.recent(contractB, 0))
+	assert.Equal(t, []types.ExecutionReceipt{{Height: 4}}, log.recent(contractC, 0))
+}
+
+func TestRecordExecutionReceipts(t *testing.T) {
+	nodeConfig := types.DefaultNodeConfig()
+	nodeConfig.ExecutionReceiptsLimit = 3
+	parentCtx, keepers := createTestInput(t, false, AvailableCapabilities, nodeConfig, types.VMConfig{}, dbm.NewMemDB())
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	k := keepers.WasmKeeper
+	releaseMsg := []byte(`{"release":{}}`)
+	releaseMsgHash := sha256.Sum256(releaseMsg)
+
+	ctx, _ := parentCtx.WithExecMode(sdk.ExecModeFinalize).WithBlockHeight(100).CacheContext()
+	ctx = types.WithPendingExecutionReceipts(ctx, &types.PendingExecutionReceipts{})
+	// when executed successfully
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, releaseMsg, nil)
+	require.NoError(t, err)
+	// then not recorded before the tx result is known
+	got, enabled := k.GetRecentExecutions(example.Contract, 0)
+	require.True(t, enabled)
+	assert.Empty(t, got)
+	// and recorded with the tx result
+	k.RecordExecutionReceipts(ctx, true)
+	got, _ = k.GetRecentExecutions(example.Contract, 0)
+	require.Len(t, got, 1)
+	assert.Equal(t, uint64(100), got[0].Height)
+	assert.Equal(t, example.VerifierAddr.String(), got[0].Sender)
+	assert.Equal(t, releaseMsgHash[:], []byte(got[0].MsgHash))
+	assert.NotZero(t, got[0].GasUsed)
+	assert.Empty(t, got[0].Error)
+	assert.False(t, got[0].Reverted)
+
+	// and when failed
+	_, err = keepers.ContractKeeper.Execute(ctx.WithBlockHeight(101), example.Contract, example.BeneficiaryAddr, releaseMsg, nil)
+	require.Error(t, err)
+	k.RecordExecutionReceipts(ctx, false)
+	got, _ = k.GetRecentExecutions(example.Contract, 1)
+	require.Len(t, got, 1)
+	assert.Equal(t, uint64(101), got[0].Height)
+	assert.Contains(t, got[0].Error, "Unauthorized")
+	assert.True(t, got[0].Reverted)
+
+	// and when executed successfully in a failed tx
+	_, err = keepers.ContractKeeper.Execute(ctx.WithBlockHeight(102), example.Contract, example.VerifierAddr, releaseMsg, nil)
+	require.NoError(t, err)
+	k.RecordExecutionReceipts(ctx, false)
+	got, _ = k.GetRecentExecutions(example.Contract, 1)
+	require.Len(t, got, 1)
+	assert.Equal(t, uint64(102), got[0].Height)
+	assert.Empty(t, got[0].Error)
+	assert.True(t, got[0].Reverted)
+
+	// and when out of gas, recorded without post handler and the oldest receipt is evicted
+	oogCtx := ctx.WithBlockHeight(103).WithGasMeter(storetypes.NewGasMeter(20_000))
+	assert.Panics(t, func() {
+		_, _ = keepers.ContractKeeper.Execute(oogCtx, example.Contract, example.VerifierAddr, releaseMsg, nil)
+	})
+	got, _ = k.GetRecentExecutions(example.Contract, 0)
+	require.Len(t, got, 3)
+	assert.Equal(t, uint64(103), got[0].Height)
+	assert.Contains(t, got[0].Error, "out of gas")
+	assert.True(t, got[0].Reverted)
+	assert.Equal(t, uint64(101), got[2].Height)
+
+	// and not recorded when dispatched by another contract, in check tx, simulation or without a tx
+	specs := map[string]sdk.Context{
+		"sub-message": types.WithCallDepth(ctx, 1),
+		"check tx":    ctx.WithExecMode(sdk.ExecModeCheck),
+		"simulation":  ctx.WithExecMode(sdk.ExecModeSimulate),
+		"no tx":       parentCtx.WithExecMode(sdk.ExecModeFinalize),
+	}
+	for name, execCtx := range specs {
+		t.Run(name, func(t *testing.T) {
+			execCtx, _ := execCtx.WithBlockHeight(104).CacheContext()
+			// when
+			_, err = keepers.ContractKeeper.Execute(execCtx, example.Contract, example.BeneficiaryAddr, releaseMsg, nil)
+			require.Error(t, err)
+			k.RecordExecutionReceipts(execCtx, true)
+			// then
+			got, _ := k.GetRecentExecutions(example.Contract, 1)
+			require.Len(t, got, 1)
+			assert.Equal(t, uint64(103), got[0].Height)
+		})
+	}
+
+	// and queryable
+	got, _ = k.GetRecentExecutions(example.Contract, 1)
+	q := Querier(k)
+	rsp, err := q.RecentExecutions(ctx, &types.QueryRecentExecutionsRequest{Address: example.Contract.String(), Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, got, rsp.Receipts)
+}
+
+func TestExecutionReceiptsDoNotAffectGasOrState(t *testing.T) {
+	nodeConfig := types.DefaultNodeConfig()
+	nodeConfig.ExecutionReceiptsLimit = 2
+	parentCtx, keepers := createTestInput(t, false, AvailableCapabilities, nodeConfig, types.VMConfig{}, dbm.NewMemDB())
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	k := keepers.WasmKeeper
+	receipts := k.executionReceipts
+
+	execute := func(t *testing.T) (storetypes.Gas, map[string][]byte) {
+		ctx, _ := parentCtx.WithExecMode(sdk.ExecModeFinalize).CacheContext()
+		ctx = types.WithPendingExecutionReceipts(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()), &types.PendingExecutionReceipts{})
+		_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+		require.NoError(t, err)
+		k.RecordExecutionReceipts(ctx, true)
+		state := make(map[string][]byte)
+		it := ctx.KVStore(keepers.WasmStoreKey).Iterator(nil, nil)
+		defer it.Close()
+		for ; it.Valid(); it.Next() {
+			state[string(it.Key())] = it.Value()
+		}
+		return ctx.GasMeter().GasConsumed(), state
+	}
+	// when executed without and with receipts
+	k.executionReceipts = nil
+	gasWithout, stateWithout := execute(t)
+	k.executionReceipts = receipts
+	gasWith, stateWith := execute(t)
+
+	// then
+	assert.Equal(t, gasWithout, gasWith)
+	assert.Equal(t, stateWithout, stateWith)
+	got, _ := k.GetRecentExecutions(example.Contract, 0)
+	assert.Len(t, got, 1)
+}
+
+func TestExecutionReceiptsDisabledByDefault(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	ctx = types.WithPendingExecutionReceipts(ctx.WithExecMode(sdk.ExecModeFinalize), &types.PendingExecutionReceipts{})
+	_, err := keepers.ContractKeeper.Execute(ctx, example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+	keepers.WasmKeeper.RecordExecutionReceipts(ctx, true)
+
+	// when
+	got, enabled := keepers.WasmKeeper.GetRecentExecutions(example.Contract, 0)
+
+	// then
+	assert.False(t, enabled)
+	assert.Nil(t, got)
+	_, err = Querier(keepers.WasmKeeper).RecentExecutions(ctx, &types.QueryRecentExecutionsRequest{Address: example.Contract.String()})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
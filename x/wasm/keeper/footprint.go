@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// GetContractFootprint returns the bytes the contract adds to the state. Store entries are counted with the full
+// store key. At most maxStateEntries state entries are counted, the footprint is truncated otherwise.
+func (k Keeper) GetContractFootprint(ctx context.Context, contractAddr sdk.AccAddress, maxStateEntries uint64) (*types.ContractFootprint, error) {
+	store := k.storeService.OpenKVStore(ctx)
+	contractKey := types.GetContractAddressKey(contractAddr)
+	infoBz, err := store.Get(contractKey)
+	if err != nil {
+		return nil, err
+	}
+	if infoBz == nil {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	var info types.ContractInfo
+	k.cdc.MustUnmarshal(infoBz, &info)
+	r := types.ContractFootprint{
+		Address:           contractAddr.String(),
+		ContractInfoBytes: uint64(len(contractKey) + len(infoBz)),
+	}
+
+	historyPrefix := types.GetContractCodeHistoryElementPrefix(contractAddr)
+	historyStore := prefix.NewStore(runtime.KVStoreAdapter(store), historyPrefix)
+	historyIter := historyStore.Iterator(nil, nil)
+	for ; historyIter.Valid(); historyIter.Next() {
+		r.HistoryEntries++
+		r.HistoryBytes += uint64(len(historyPrefix) + len(historyIter.Key()) + len(historyIter.Value()))
+	}
+	historyIter.Close()
+
+	statePrefix := types.GetContractStorePrefix(contractAddr)
+	stateStore := prefix.NewStore(runtime.KVStoreAdapter(store), statePrefix)
+	stateIter := stateStore.Iterator(nil, nil)
+	for ; stateIter.Valid(); stateIter.Next() {
+		if r.StateEntries == maxStateEntries {
+			r.Truncated = true
+			break
+		}
+		r.StateEntries++
+		r.StateBytes += uint64(len(statePrefix) + len(stateIter.Key()) + len(stateIter.Value()))
+	}
+	stateIter.Close()
+
+	code, err := k.GetByteCode(ctx, info.CodeID)
+	if err != nil {
+		return nil, err
+	}
+	r.CodeShareBytes = uint64(len(code)) / max(k.GetCodeInstantiationCount(ctx, info.CodeID), 1)
+	r.TotalBytes = r.ContractInfoBytes + r.HistoryBytes + r.StateBytes + r.CodeShareBytes
+	return &r, nil
+}
@@ -0,0 +1,164 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestGetContractFootprint(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	codeSize := uint64(len(testdata.HackatomContractWasm()))
+
+	contractA, contractB := BuildContractAddressClassic(example.CodeID, 1), BuildContractAddressClassic(example.CodeID, 2)
+	infoA := types.ContractInfoFixture(func(info *types.ContractInfo) { info.CodeID = example.CodeID })
+	infoB := types.ContractInfoFixture(func(info *types.ContractInfo) { info.CodeID = example.CodeID; info.Label = "other" })
+	history := []types.ContractCodeHistoryEntry{{
+		Operation: types.ContractCodeHistoryOperationTypeInit,
+		CodeID:    example.CodeID,
+		Updated:   &types.AbsoluteTxPosition{BlockHeight: 1},
+		Msg:       []byte(`{}`),
+	}}
+	state := []types.Model{
+		{Key: []byte("a"), Value: make([]byte, 10)},
+		{Key: []byte("bb"), Value: make([]byte, 20)},
+		{Key: []byte("ccc"), Value: make([]byte, 30)},
+	}
+	require.NoError(t, k.importContract(ctx, contractA, &infoA, state, history))
+	require.NoError(t, k.importContract(ctx, contractB, &infoB, nil, history))
+
+	statePrefixLen := uint64(len(types.GetContractStorePrefix(contractA)))
+	historyBytes := uint64(len(types.GetContractCodeHistoryElementKey(contractA, 1)) + len(keepers.EncodingConfig.Codec.MustMarshal(&history[0])))
+	infoBytes := func(addr sdk.AccAddress, info types.ContractInfo) uint64 {
+		return uint64(len(types.GetContractAddressKey(addr)) + len(keepers.EncodingConfig.Codec.MustMarshal(&info)))
+	}
+	specs := map[string]struct {
+		contract        sdk.AccAddress
+		maxStateEntries uint64
+		exp             types.ContractFootprint
+		expErr          bool
+	}{
+		"with state": {
+			contract:        contractA,
+			maxStateEntries: 10,
+			exp: types.ContractFootprint{
+				Address:           contractA.String(),
+				ContractInfoBytes: infoBytes(contractA, infoA),
+				HistoryEntries:    1,
+				HistoryBytes:      historyBytes,
+				StateEntries:      3,
+				StateBytes:        3*statePrefixLen + 1 + 10 + 2 + 20 + 3 + 30,
+				CodeShareBytes:    codeSize / 2,
+				TotalBytes:        infoBytes(contractA, infoA) + historyBytes + 3*statePrefixLen + 66 + codeSize/2,
+			},
+		},
+		"state capped": {
+			contract:        contractA,
+			maxStateEntries: 2,
+			exp: types.ContractFootprint{
+				Address:           contractA.String(),
+				ContractInfoBytes: infoBytes(contractA, infoA),
+				HistoryEntries:    1,
+				HistoryBytes:      historyBytes,
+				StateEntries:      2,
+				StateBytes:        2*statePrefixLen + 1 + 10 + 2 + 20,
+				CodeShareBytes:    codeSize / 2,
+				TotalBytes:        infoBytes(contractA, infoA) + historyBytes + 2*statePrefixLen + 33 + codeSize/2,
+				Truncated:         true,
+			},
+		},
+		"without state": {
+			contract:        contractB,
+			maxStateEntries: 10,
+			exp: types.ContractFootprint{
+				Address:           contractB.String(),
+				ContractInfoBytes: infoBytes(contractB, infoB),
+				HistoryEntries:    1,
+				HistoryBytes:      historyBytes,
+				CodeShareBytes:    codeSize / 2,
+				TotalBytes:        infoBytes(contractB, infoB) + historyBytes + codeSize/2,
+			},
+		},
+		"unknown contract": {
+			contract:        RandomAccountAddress(t),
+			maxStateEntries: 10,
+			expErr:          true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := k.GetContractFootprint(ctx, spec.contract, spec.maxStateEntries)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, *got)
+		})
+	}
+}
+
+func TestQueryCodesFootprint(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	history := []types.ContractCodeHistoryEntry{{
+		Operation: types.ContractCodeHistoryOperationTypeInit,
+		CodeID:    example.CodeID,
+		Updated:   &types.AbsoluteTxPosition{BlockHeight: 1},
+		Msg:       []byte(`{}`),
+	}}
+	var exp []types.ContractFootprint
+	for i := uint64(1); i <= 3; i++ {
+		addr := BuildContractAddressClassic(example.CodeID, i)
+		info := types.ContractInfoFixture(func(info *types.ContractInfo) { info.CodeID = example.CodeID })
+		state := []types.Model{{Key: []byte("key"), Value: make([]byte, i*100)}}
+		require.NoError(t, k.importContract(ctx, addr, &info, state, history))
+	}
+	for i := uint64(1); i <= 3; i++ {
+		footprint, err := k.GetContractFootprint(ctx, BuildContractAddressClassic(example.CodeID, i), footprintMaxStateEntries)
+		require.NoError(t, err)
+		exp = append(exp, *footprint)
+	}
+	q := Querier(k)
+
+	// when
+	gotPage1, err := q.CodesFootprint(ctx, &types.QueryCodesFootprintRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Limit: 2}})
+	require.NoError(t, err)
+	gotPage2, err := q.CodesFootprint(ctx, &types.QueryCodesFootprintRequest{CodeId: example.CodeID, Pagination: &query.PageRequest{Key: gotPage1.Pagination.NextKey}})
+	require.NoError(t, err)
+
+	// then
+	assert.ElementsMatch(t, exp, append(gotPage1.Contracts, gotPage2.Contracts...))
+	for _, page := range []*types.QueryCodesFootprintResponse{gotPage1, gotPage2} {
+		var expSum types.ContractFootprint
+		for _, f := range page.Contracts {
+			expSum.ContractInfoBytes += f.ContractInfoBytes
+			expSum.HistoryEntries += f.HistoryEntries
+			expSum.HistoryBytes += f.HistoryBytes
+			expSum.StateEntries += f.StateEntries
+			expSum.StateBytes += f.StateBytes
+			expSum.CodeShareBytes += f.CodeShareBytes
+			expSum.TotalBytes += f.TotalBytes
+		}
+		assert.Equal(t, expSum, page.Sum)
+	}
+	assert.Equal(t, uint64(len(testdata.HackatomContractWasm()))/3*3, gotPage1.Sum.CodeShareBytes+gotPage2.Sum.CodeShareBytes)
+
+	// and unknown code
+	_, err = q.CodesFootprint(ctx, &types.QueryCodesFootprintRequest{CodeId: 99})
+	require.Error(t, err)
+	// and single contract query
+	gotContract, err := q.ContractFootprint(ctx, &types.QueryContractFootprintRequest{Address: exp[0].Address})
+	require.NoError(t, err)
+	assert.Equal(t, exp[0], gotContract.Footprint)
+}
@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	"context"
+	"strings"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+const (
+	gasDescriptorSetupPrefix = "Loading CosmWasm module: "
+	gasDescriptorCompile     = "Compiling wasm bytecode"
+	gasDescriptorUncompress  = "Uncompress gzip bytecode"
+	gasDescriptorChecksum    = "Checksum wasm bytecode"
+	gasDescriptorRuntime     = "wasm contract"
+	gasDescriptorEvents      = "Custom contract event attributes"
+	gasDescriptorSubMsg      = "From limited Sub-Message"
+	gasDescriptorSubMsgOOG   = "Sub-Message OutOfGas panic"
+	gasDescriptorAnyMsg      = "unpacking AnyMsg"
+)
+
+var _ storetypes.GasMeter = &gasBreakdownMeter{}
+
+// gasBreakdownMeter is a gas meter decorator that assigns the consumed gas to categories by descriptor
+type gasBreakdownMeter struct {
+	storetypes.GasMeter
+	start     storetypes.Gas
+	breakdown types.EventGasBreakdown
+}
+
+func newGasBreakdownMeter(parent storetypes.GasMeter) *gasBreakdownMeter {
+	return &gasBreakdownMeter{GasMeter: parent, start: parent.GasConsumed()}
+}
+
+// ConsumeGas consumes the gas on the parent meter and assigns it to a category
+func (m *gasBreakdownMeter) ConsumeGas(amount storetypes.Gas, descriptor string) {
+	m.GasMeter.ConsumeGas(amount, descriptor)
+	switch {
+	case strings.HasPrefix(descriptor, gasDescriptorSetupPrefix),
+		descriptor == gasDescriptorCompile,
+		descriptor == gasDescriptorUncompress,
+		descriptor == gasDescriptorChecksum:
+		m.breakdown.Setup += amount
+	case descriptor == gasDescriptorRuntime:
+		m.breakdown.Execution += amount
+	case descriptor == storetypes.GasReadCostFlatDesc,
+		descriptor == storetypes.GasReadPerByteDesc,
+		descriptor == storetypes.GasWriteCostFlatDesc,
+		descriptor == storetypes.GasWritePerByteDesc,
+		descriptor == storetypes.GasHasDesc,
+		descriptor == storetypes.GasDeleteDesc,
+		descriptor == storetypes.GasIterNextCostFlatDesc,
+		descriptor == storetypes.GasValuePerByteDesc:
+		m.breakdown.Storage += amount
+	case descriptor == gasDescriptorEvents:
+		m.breakdown.Events += amount
+	case descriptor == gasDescriptorSubMsg,
+		descriptor == gasDescriptorSubMsgOOG,
+		descriptor == gasDescriptorAnyMsg:
+		m.breakdown.Submessages += amount
+	}
+}
+
+// Breakdown returns the gas per category. Gas that is not assigned to a category is accounted for in
+// Other so that the categories sum up to the total. Refunds are not assigned to categories: when more gas
+// was refunded than consumed without a category, Other is 0 and the categories exceed the total.
+func (m *gasBreakdownMeter) Breakdown() types.EventGasBreakdown {
+	r := m.breakdown
+	r.Total = m.GasConsumed() - m.start
+	if assigned := r.Setup + r.Execution + r.Storage + r.Events + r.Submessages; assigned < r.Total {
+		r.Other = r.Total - assigned
+	}
+	return r
+}
+
+// startGasBreakdown collects the gas breakdown of a top level wasm message in simulations that
+// opted in with types.WithGasBreakdown. The returned function emits the breakdown as typed event.
+// Nothing is collected for other execution modes or messages dispatched by contracts.
+func startGasBreakdown(ctx context.Context) (context.Context, func()) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if sdkCtx.ExecMode() != sdk.ExecModeSimulate || !types.IsGasBreakdownEnabled(sdkCtx) {
+		return ctx, func() {}
+	}
+	if depth, _ := types.CallDepth(sdkCtx); depth != 0 {
+		return ctx, func() {}
+	}
+	if _, ok := sdkCtx.GasMeter().(*gasBreakdownMeter); ok {
+		return ctx, func() {}
+	}
+	meter := newGasBreakdownMeter(sdkCtx.GasMeter())
+	return sdkCtx.WithGasMeter(meter), func() {
+		breakdown := meter.Breakdown()
+		if err := sdkCtx.EventManager().EmitTypedEvent(&breakdown); err != nil {
+			moduleLogger(sdkCtx).Error("emit gas breakdown", "error", err)
+		}
+	}
+}
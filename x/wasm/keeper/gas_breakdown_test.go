@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestGasBreakdown(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	msgServer := NewMsgServerImpl(keepers.WasmKeeper)
+
+	specs := map[string]struct {
+		mode         sdk.ExecMode
+		optIn        bool
+		expBreakdown bool
+	}{
+		"simulate": {
+			mode:         sdk.ExecModeSimulate,
+			optIn:        true,
+			expBreakdown: true,
+		},
+		"simulate without opt-in": {
+			mode: sdk.ExecModeSimulate,
+		},
+		"finalize": {
+			mode:  sdk.ExecModeFinalize,
+			optIn: true,
+		},
+		"check tx": {
+			mode:  sdk.ExecModeCheck,
+			optIn: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithExecMode(spec.mode).WithEventManager(em).WithGasMeter(storetypes.NewInfiniteGasMeter())
+			if spec.optIn {
+				ctx = types.WithGasBreakdown(ctx, true)
+			}
+
+			// when
+			_, err := msgServer.ExecuteContract(ctx, &types.MsgExecuteContract{
+				Sender:   example.VerifierAddr.String(),
+				Contract: example.Contract.String(),
+				Msg:      []byte(`{"release":{}}`),
+			})
+
+			// then
+			require.NoError(t, err)
+			var breakdowns []*types.EventGasBreakdown
+			for _, e := range em.Events() {
+				if e.Type != "cosmwasm.wasm.v1.EventGasBreakdown" {
+					continue
+				}
+				msg, err := sdk.ParseTypedEvent(abci.Event(e))
+				require.NoError(t, err)
+				breakdowns = append(breakdowns, msg.(*types.EventGasBreakdown))
+			}
+			if !spec.expBreakdown {
+				assert.Empty(t, breakdowns)
+				return
+			}
+			require.Len(t, breakdowns, 1)
+			got := breakdowns[0]
+			assert.Equal(t, ctx.GasMeter().GasConsumed(), got.Total)
+			assert.Equal(t, got.Total, got.Setup+got.Execution+got.Storage+got.Events+got.Submessages+got.Other)
+			assert.NotZero(t, got.Setup)
+			assert.NotZero(t, got.Execution)
+			assert.NotZero(t, got.Storage)
+			assert.NotZero(t, got.Events)
+		})
+	}
+}
+
+func TestGasBreakdownMeter(t *testing.T) {
+	parent := storetypes.NewGasMeter(1_000)
+	parent.ConsumeGas(100, "before")
+	meter := newGasBreakdownMeter(parent)
+
+	meter.ConsumeGas(1, "Loading CosmWasm module: execute")
+	meter.ConsumeGas(2, gasDescriptorCompile)
+	meter.ConsumeGas(4, gasDescriptorRuntime)
+	meter.ConsumeGas(8, storetypes.GasWriteCostFlatDesc)
+	meter.ConsumeGas(16, storetypes.GasReadPerByteDesc)
+	meter.ConsumeGas(32, gasDescriptorEvents)
+	meter.ConsumeGas(64, gasDescriptorSubMsg)
+	meter.ConsumeGas(128, "contract sub-query")
+	meter.RefundGas(28, "refund")
+
+	assert.Equal(t, types.EventGasBreakdown{
+		Setup:       3,
+		Execution:   4,
+		Storage:     24,
+		Events:      32,
+		Submessages: 64,
+		Other:       100,
+		Total:       227,
+	}, meter.Breakdown())
+	assert.Equal(t, storetypes.Gas(327), parent.GasConsumed())
+}
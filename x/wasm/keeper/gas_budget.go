@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// CheckContractGasBudget returns an ErrRateLimited error when the execution gas used by the contract in the
+// current block exceeds its budget. Contracts without a budget are unlimited. Nothing is tracked without a
+// transient store. The check is not charged so that the gas of contract calls is not modified.
+func (k Keeper) CheckContractGasBudget(ctx context.Context, contractAddr sdk.AccAddress) error {
+	if k.transientStoreService == nil {
+		return nil
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	gasFreeCtx := sdkCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	budget, ok := k.cachedParams(sdkCtx).ContractGasBudget(contractAddr)
+	if !ok {
+		return nil
+	}
+	return k.checkContractGasUsed(gasFreeCtx, contractAddr, budget)
+}
+
+// ReserveContractGasBudget checks the budget of the contract and charges the gas limit of the tx to the contract's
+// counter of the current block. The gas limit is charged up front in the ante handler so that the counter does not
+// depend on the outcome of the execution and is the same in CheckTx and in block execution. Returns false for
+// contracts without a budget. Nothing is charged to the gas meter.
+func (k Keeper) ReserveContractGasBudget(ctx context.Context, contractAddr sdk.AccAddress, gasLimit uint64) (bool, error) {
+	if k.transientStoreService == nil {
+		return false, nil
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	gasFreeCtx := sdkCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	budget, ok := k.cachedParams(sdkCtx).ContractGasBudget(contractAddr)
+	if !ok {
+		return false, nil
+	}
+	if err := k.checkContractGasUsed(gasFreeCtx, contractAddr, budget); err != nil {
+		return false, err
+	}
+	used := addGas(k.GetContractGasUsed(gasFreeCtx, contractAddr), gasLimit)
+	return true, k.transientStoreService.OpenTransientStore(gasFreeCtx).Set(types.GetContractGasUsedKey(contractAddr), sdk.Uint64ToBigEndian(used))
+}
+
+func (k Keeper) checkContractGasUsed(ctx context.Context, contractAddr sdk.AccAddress, budget uint64) error {
+	if used := k.GetContractGasUsed(ctx, contractAddr); used > budget {
+		return errorsmod.Wrapf(types.ErrRateLimited, "contract %s used %d of %d gas in this block", contractAddr, used, budget)
+	}
+	return nil
+}
+
+// GetContractGasUsed returns the execution gas used by the contract in the current block
+func (k Keeper) GetContractGasUsed(ctx context.Context, contractAddr sdk.AccAddress) uint64 {
+	if k.transientStoreService == nil {
+		return 0
+	}
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(types.GetContractGasUsedKey(contractAddr))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) != 8 {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// addContractGasUsed adds the execution gas to the contract's counter of the current block when it has a budget.
+// Counting is not charged. Without a reservation by the ante handler, for example for calls from other contracts,
+// the gas is only counted when the execution is committed.
+func (k Keeper) addContractGasUsed(ctx context.Context, contractAddr sdk.AccAddress, gas uint64) error {
+	if k.transientStoreService == nil {
+		return nil
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if _, ok := k.cachedParams(sdkCtx).ContractGasBudget(contractAddr); !ok {
+		return nil
+	}
+	gasFreeCtx := sdkCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	used := addGas(k.GetContractGasUsed(gasFreeCtx, contractAddr), gas)
+	return k.transientStoreService.OpenTransientStore(gasFreeCtx).Set(types.GetContractGasUsedKey(contractAddr), sdk.Uint64ToBigEndian(used))
+}
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm/v2"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestContractGasBudget(t *testing.T) {
+	mock := wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1_000 * types.DefaultGasMultiplier, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	limited := SeedNewContractInstance(t, ctx, keepers, &mock)
+	unlimited := SeedNewContractInstance(t, ctx, keepers, &mock)
+	caller := RandomAccountAddress(t)
+
+	params := k.GetParams(ctx)
+	params.ContractGasBudgets = []types.ContractGasBudget{{Contract: limited.Contract.String(), MaxGasPerBlock: 100_000}}
+	require.NoError(t, k.SetParams(ctx, params))
+
+	// each tx is executed in a cache context that is committed on success only
+	execTx := func(ctx sdk.Context, contract sdk.AccAddress) error {
+		txCtx, commit := ctx.CacheContext()
+		if _, err := k.execute(txCtx, contract, caller, []byte(`{}`), nil); err != nil {
+			return err
+		}
+		commit()
+		return nil
+	}
+
+	// when the budget is not exceeded
+	require.NoError(t, execTx(ctx, limited.Contract))
+	gasUsedFirstTx := k.GetContractGasUsed(ctx, limited.Contract)
+	require.Greater(t, gasUsedFirstTx, uint64(1_000))
+	require.Less(t, gasUsedFirstTx, uint64(100_000))
+	// then further txs in the same block are accepted
+	require.NoError(t, execTx(ctx, limited.Contract))
+	assert.Equal(t, 2*gasUsedFirstTx, k.GetContractGasUsed(ctx, limited.Contract))
+
+	// when the budget is exceeded
+	require.Greater(t, k.GetContractGasUsed(ctx, limited.Contract), uint64(100_000))
+	// then further executions in the same block fail
+	err := execTx(ctx, limited.Contract)
+	require.ErrorIs(t, err, types.ErrRateLimited)
+	require.ErrorIs(t, k.CheckContractGasBudget(ctx, limited.Contract), types.ErrRateLimited)
+	assert.Equal(t, 2*gasUsedFirstTx, k.GetContractGasUsed(ctx, limited.Contract))
+	// and contracts without a budget are not limited nor tracked
+	require.NoError(t, execTx(ctx, unlimited.Contract))
+	assert.Equal(t, uint64(0), k.GetContractGasUsed(ctx, unlimited.Contract))
+
+	// when the next block starts with a new transient store
+	require.NoError(t, k.transientStoreService.OpenTransientStore(ctx).Delete(types.GetContractGasUsedKey(limited.Contract)))
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	// then the counter is reset
+	assert.Equal(t, uint64(0), k.GetContractGasUsed(ctx, limited.Contract))
+	require.NoError(t, execTx(ctx, limited.Contract))
+	assert.Equal(t, gasUsedFirstTx, k.GetContractGasUsed(ctx, limited.Contract))
+}
+
+func TestReserveContractGasBudget(t *testing.T) {
+	mock := wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Err: "failed"}, 1_000 * types.DefaultGasMultiplier, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	limited := SeedNewContractInstance(t, ctx, keepers, &mock)
+	unlimited := SeedNewContractInstance(t, ctx, keepers, &mock)
+
+	params := k.GetParams(ctx)
+	params.ContractGasBudgets = []types.ContractGasBudget{{Contract: limited.Contract.String(), MaxGasPerBlock: 300_000}}
+	require.NoError(t, k.SetParams(ctx, params))
+
+	// when the gas limit of a tx is reserved
+	ok, err := k.ReserveContractGasBudget(ctx, limited.Contract, 200_000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	// then it is counted before execution
+	assert.Equal(t, uint64(200_000), k.GetContractGasUsed(ctx, limited.Contract))
+	// and the failed execution of the tx is neither rejected nor counted twice
+	txCtx, _ := types.WithGasBudgetReservations(ctx, []sdk.AccAddress{limited.Contract}).CacheContext()
+	_, err = k.execute(txCtx, limited.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+	require.ErrorIs(t, err, types.ErrExecuteFailed)
+	assert.Equal(t, uint64(200_000), k.GetContractGasUsed(ctx, limited.Contract))
+
+	// when the budget is exceeded by the next reservation
+	ok, err = k.ReserveContractGasBudget(ctx, limited.Contract, 200_000)
+	require.NoError(t, err)
+	require.True(t, ok)
+	// then further reservations fail
+	_, err = k.ReserveContractGasBudget(ctx, limited.Contract, 1)
+	require.ErrorIs(t, err, types.ErrRateLimited)
+	assert.Equal(t, uint64(400_000), k.GetContractGasUsed(ctx, limited.Contract))
+
+	// and contracts without a budget are not reserved
+	ok, err = k.ReserveContractGasBudget(ctx, unlimited.Contract, 200_000)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), k.GetContractGasUsed(ctx, unlimited.Contract))
+}
+
+func TestContractGasBudgetDefaultUnlimited(t *testing.T) {
+	mock := wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(&mock)
+	mock.ExecuteFn = func(codeID wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.ContractResult, uint64, error) {
+		return &wasmvmtypes.ContractResult{Ok: &wasmvmtypes.Response{}}, 1_000_000 * types.DefaultGasMultiplier, nil
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithWasmEngine(&mock))
+	k := keepers.WasmKeeper
+	example := SeedNewContractInstance(t, ctx, keepers, &mock)
+	require.Empty(t, k.GetParams(ctx).ContractGasBudgets)
+
+	for i := 0; i < 3; i++ {
+		_, err := k.execute(ctx, example.Contract, RandomAccountAddress(t), []byte(`{}`), nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, uint64(0), k.GetContractGasUsed(ctx, example.Contract))
+}
@@ -33,6 +33,11 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "code %d with id: %d", i, code.CodeID)
 		}
+		if code.Provenance != nil {
+			if err := keeper.setCodeProvenance(ctx, code.CodeID, *code.Provenance); err != nil {
+				return nil, errorsmod.Wrapf(err, "provenance of code %d with id: %d", i, code.CodeID)
+			}
+		}
 		if code.CodeID > maxCodeID {
 			maxCodeID = code.CodeID
 		}
@@ -52,6 +57,16 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if err != nil {
 			return nil, errorsmod.Wrapf(err, "contract number %d", i)
 		}
+		if contract.HistoryPrunedHeight != 0 {
+			if err := keeper.setContractHistoryPrunedHeight(ctx, contractAddr, contract.HistoryPrunedHeight); err != nil {
+				return nil, errorsmod.Wrapf(err, "history pruned height of contract number %d", i)
+			}
+		}
+		if contract.LastActivityHeight != 0 {
+			if err := keeper.setContractLastActivityHeight(ctx, contractAddr, contract.LastActivityHeight); err != nil {
+				return nil, errorsmod.Wrapf(err, "last activity height of contract number %d", i)
+			}
+		}
 	}
 
 	for i, seq := range data.Sequences {
@@ -94,10 +109,11 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			panic(err)
 		}
 		genState.Codes = append(genState.Codes, types.Code{
-			CodeID:    codeID,
-			CodeInfo:  info,
-			CodeBytes: bytecode,
-			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			CodeID:     codeID,
+			CodeInfo:   info,
+			CodeBytes:  bytecode,
+			Pinned:     keeper.IsPinnedCode(ctx, codeID),
+			Provenance: keeper.GetCodeProvenance(ctx, codeID),
 		})
 		return false
 	})
@@ -116,6 +132,8 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			ContractInfo:        contract,
 			ContractState:       state,
 			ContractCodeHistory: contractCodeHistory,
+			HistoryPrunedHeight: keeper.GetContractHistoryPrunedHeight(ctx, addr),
+			LastActivityHeight:  keeper.GetContractLastActivityHeight(ctx, addr),
 		})
 		return false
 	})
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
@@ -57,6 +58,7 @@ func TestGenesisExportImport(t *testing.T) {
 			history           []types.ContractCodeHistoryEntry
 			pinned            bool
 			contractExtension bool
+			txBytes           []byte
 		)
 		f.Fuzz(&codeInfo)
 		f.Fuzz(&contract)
@@ -64,10 +66,11 @@ func TestGenesisExportImport(t *testing.T) {
 		f.NilChance(0).Fuzz(&history)
 		f.Fuzz(&pinned)
 		f.Fuzz(&contractExtension)
+		f.Fuzz(&txBytes)
 
 		creatorAddr, err := sdk.AccAddressFromBech32(codeInfo.Creator)
 		require.NoError(t, err)
-		codeID, _, err := contractKeeper.Create(srcCtx, creatorAddr, wasmCode, &codeInfo.InstantiateConfig)
+		codeID, _, err := contractKeeper.Create(srcCtx.WithTxBytes(txBytes), creatorAddr, wasmCode, &codeInfo.InstantiateConfig)
 		require.NoError(t, err)
 		if pinned {
 			err = contractKeeper.PinCode(srcCtx, codeID)
@@ -92,6 +95,8 @@ func TestGenesisExportImport(t *testing.T) {
 	}
 	var wasmParams types.Params
 	f.NilChance(0).Fuzz(&wasmParams)
+	// the label index is not built for the contracts stored above
+	wasmParams.IndexContractLabels = false
 	err = wasmKeeper.SetParams(srcCtx, wasmParams)
 	require.NoError(t, err)
 
@@ -122,6 +127,8 @@ func TestGenesisExportImport(t *testing.T) {
 		require.NoError(t, err)
 		err = wasmKeeper.addToContractCreatorSecondaryIndex(srcCtx, creatorAddress, history[0].Updated, address)
 		require.NoError(t, err)
+		err = wasmKeeper.incrementCodeInstantiationCount(srcCtx, info.CodeID)
+		require.NoError(t, err)
 		return false
 	})
 
@@ -174,6 +181,99 @@ func TestGenesisExportImportWithPredictableAddress(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGenesisExportImportWithContractInfoChanges(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.RecordContractInfoChanges = true
+	params.IndexContractLabels = true
+	require.NoError(t, k.SetParams(ctx, params))
+	eCtx, _ := ctx.CacheContext()
+	example := InstantiateReflectExampleContract(t, eCtx, keepers)
+	require.NoError(t, k.setContractLabel(eCtx, example.Contract, example.CreatorAddr, "new label", DefaultAuthorizationPolicy{}))
+	genesisState := ExportGenesis(eCtx, k)
+	require.Len(t, genesisState.Contracts, 1)
+	exportedHistory := genesisState.Contracts[0].ContractCodeHistory
+	require.Len(t, exportedHistory, 2)
+	assert.Equal(t, types.ContractCodeHistoryOperationTypeLabelChanged, exportedHistory[1].Operation)
+
+	// when imported
+	_, err := InitGenesis(ctx, k, *genesisState)
+	require.NoError(t, err)
+
+	// then the history, code and label indexes are restored
+	assert.Equal(t, exportedHistory, k.GetContractHistory(ctx, example.Contract))
+	var gotContracts []sdk.AccAddress
+	k.IterateContractsByCode(ctx, example.CodeID, func(addr sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, addr)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{example.Contract}, gotContracts)
+	gotContracts = nil
+	k.IterateContractsByLabel(ctx, "new label", func(addr sdk.AccAddress) bool {
+		gotContracts = append(gotContracts, addr)
+		return false
+	})
+	assert.Equal(t, []sdk.AccAddress{example.Contract}, gotContracts)
+	k.IterateContractsByLabel(ctx, example.Label, func(addr sdk.AccAddress) bool {
+		t.Fatalf("unexpected contract %s for old label", addr)
+		return true
+	})
+}
+
+func TestGenesisExportImportWithPrunedContractHistory(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k, q := keepers.WasmKeeper, Querier(keepers.WasmKeeper)
+	params := types.DefaultParams()
+	params.RecordContractInfoChanges = true
+	require.NoError(t, k.SetParams(ctx, params))
+	eCtx, _ := ctx.CacheContext()
+	example := InstantiateReflectExampleContract(t, eCtx.WithBlockHeight(100), keepers)
+	otherAdmin := RandomAccountAddress(t)
+	require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(eCtx.WithBlockHeight(101), example.Contract, example.CreatorAddr, otherAdmin))
+	require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(eCtx.WithBlockHeight(102), example.Contract, otherAdmin, example.CreatorAddr))
+	_, err := k.pruneContractHistory(eCtx, example.Contract, 1)
+	require.NoError(t, err)
+	genesisState := ExportGenesis(eCtx, k)
+	require.Len(t, genesisState.Contracts, 1)
+	assert.Equal(t, uint64(101), genesisState.Contracts[0].HistoryPrunedHeight)
+
+	// when imported
+	_, err = InitGenesis(ctx, k, *genesisState)
+	require.NoError(t, err)
+
+	// then heights before the pruned entries are not found
+	assert.Equal(t, uint64(101), k.GetContractHistoryPrunedHeight(ctx, example.Contract))
+	_, err = q.ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: example.Contract.String(), Height: 100})
+	require.ErrorIs(t, err, types.ErrNotFound)
+	rsp, err := q.ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: example.Contract.String(), Height: 102})
+	require.NoError(t, err)
+	assert.Equal(t, example.CreatorAddr.String(), rsp.Admin)
+}
+
+func TestGenesisExportImportWithContractActivity(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k, q := keepers.WasmKeeper, Querier(keepers.WasmKeeper)
+	params := types.DefaultParams()
+	params.TrackContractActivity = true
+	require.NoError(t, k.SetParams(ctx, params))
+	eCtx, _ := ctx.CacheContext()
+	example := InstantiateReflectExampleContract(t, eCtx, keepers)
+	require.NoError(t, k.recordContractActivity(eCtx.WithBlockHeight(123), example.Contract))
+	genesisState := ExportGenesis(eCtx, k)
+	require.Len(t, genesisState.Contracts, 1)
+	assert.Equal(t, uint64(123), genesisState.Contracts[0].LastActivityHeight)
+
+	// when imported
+	_, err := InitGenesis(ctx, k, *genesisState)
+	require.NoError(t, err)
+
+	// then the last activity is restored
+	rsp, err := q.ContractInfo(ctx, &types.QueryContractInfoRequest{Address: example.Contract.String()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(123), rsp.LastActivityHeight)
+}
+
 func TestGenesisInit(t *testing.T) {
 	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -198,6 +298,22 @@ func TestGenesisInit(t *testing.T) {
 			},
 			expSuccess: true,
 		},
+		"happy path: code with provenance": {
+			src: types.GenesisState{
+				Codes: []types.Code{{
+					CodeID:     1,
+					CodeInfo:   myCodeInfo,
+					CodeBytes:  wasmCode,
+					Provenance: &types.CodeProvenance{TxHash: bytes.Repeat([]byte{1}, 32), Source: "https://example.com", Builder: "cosmwasm/workspace-optimizer:0.12.9"},
+				}},
+				Sequences: []types.Sequence{
+					{IDKey: types.KeySequenceCodeID, Value: 2},
+					{IDKey: types.KeySequenceInstanceID, Value: 1},
+				},
+				Params: types.DefaultParams(),
+			},
+			expSuccess: true,
+		},
 		"happy path: code ids can contain gaps": {
 			src: types.GenesisState{
 				Codes: []types.Code{{
@@ -501,6 +617,7 @@ func TestGenesisInit(t *testing.T) {
 
 			for _, c := range spec.src.Codes {
 				assert.Equal(t, c.Pinned, keeper.IsPinnedCode(ctx, c.CodeID))
+				assert.Equal(t, c.Provenance, keeper.GetCodeProvenance(ctx, c.CodeID))
 			}
 		})
 	}
@@ -646,6 +763,8 @@ func TestImportContractWithCodeHistoryPreserved(t *testing.T) {
 		},
 	}
 	assert.Equal(t, expHistory, keeper.GetContractHistory(ctx, contractAddr))
+	// verify instantiation count
+	assert.Equal(t, uint64(1), keeper.GetCodeInstantiationCount(ctx, 1))
 	id, err := keeper.PeekAutoIncrementID(ctx, types.KeySequenceCodeID)
 	require.NoError(t, err)
 	assert.Equal(t, uint64(2), id)
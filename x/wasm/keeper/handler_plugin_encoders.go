@@ -216,7 +216,7 @@ func EncodeAnyMsg(unpacker codectypes.AnyUnpacker) AnyEncoder {
 		}
 		var sdkMsg sdk.Msg
 
-		ctx.GasMeter().ConsumeGas(anyMsgGasCost/types.DefaultGasMultiplier, "unpacking AnyMsg")
+		ctx.GasMeter().ConsumeGas(anyMsgGasCost/types.DefaultGasMultiplier, gasDescriptorAnyMsg)
 		if err := unpacker.UnpackAny(&codecAny, &sdkMsg); err != nil {
 			return nil, errorsmod.Wrap(types.ErrInvalidMsg, fmt.Sprintf("Cannot unpack proto message with type URL: %s", msg.TypeURL))
 		}
@@ -255,6 +255,8 @@ func EncodeWasmMsg(sender sdk.AccAddress, msg *wasmvmtypes.WasmMsg) ([]sdk.Msg,
 			Msg:    msg.Instantiate.Msg,
 			Admin:  msg.Instantiate.Admin,
 			Funds:  coins,
+			// contracts choose the admin explicitly, the chain default admin policy does not apply
+			NoAdmin: msg.Instantiate.Admin == "",
 		}
 		return []sdk.Msg{&sdkMsg}, nil
 	case msg.Instantiate2 != nil:
@@ -272,7 +274,8 @@ func EncodeWasmMsg(sender sdk.AccAddress, msg *wasmvmtypes.WasmMsg) ([]sdk.Msg,
 			Funds:  coins,
 			Salt:   msg.Instantiate2.Salt,
 			// FixMsg is discouraged, see: https://medium.com/cosmwasm/dev-note-3-limitations-of-instantiate2-and-how-to-deal-with-them-a3f946874230
-			FixMsg: false,
+			FixMsg:  false,
+			NoAdmin: msg.Instantiate2.Admin == "",
 		}
 		return []sdk.Msg{&sdkMsg}, nil
 	case msg.Migrate != nil:
@@ -185,6 +185,27 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"wasm instantiate without admin": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Wasm: &wasmvmtypes.WasmMsg{
+					Instantiate: &wasmvmtypes.InstantiateMsg{
+						CodeID: 7,
+						Msg:    jsonMsg,
+						Label:  "myLabel",
+					},
+				},
+			},
+			output: []sdk.Msg{
+				&types.MsgInstantiateContract{
+					Sender:  addr1.String(),
+					CodeID:  7,
+					Label:   "myLabel",
+					Msg:     jsonMsg,
+					NoAdmin: true,
+				},
+			},
+		},
 		"wasm instantiate2": {
 			sender: addr1,
 			srcMsg: wasmvmtypes.CosmosMsg{
@@ -0,0 +1,42 @@
+package keeper
+
+import (
+	"context"
+
+	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// CheckInstantiate2Address returns the predictable address of an instantiate2 call and whether the address is
+// used by an account, a contract or holds funds already. An existing account or balance is kept or pruned on
+// instantiation depending on the account type, see Keeper.instantiate.
+func (k Keeper) CheckInstantiate2Address(ctx context.Context, creator sdk.AccAddress, codeID uint64, salt []byte, initMsg types.RawContractMessage, fixMsg bool) (*types.QueryCheckInstantiate2AddressResponse, error) {
+	if err := sdk.VerifyAddressFormat(creator); err != nil {
+		return nil, errorsmod.Wrap(err, "creator")
+	}
+	if err := types.ValidateSalt(salt); err != nil {
+		return nil, errorsmod.Wrap(err, "salt")
+	}
+	if fixMsg {
+		if err := initMsg.ValidateBasic(); err != nil {
+			return nil, errorsmod.Wrap(err, "msg")
+		}
+	}
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return nil, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	contractAddr := PredictableAddressGenerator(creator, salt, initMsg, fixMsg)(ctx, codeID, codeInfo.CodeHash)
+	r := &types.QueryCheckInstantiate2AddressResponse{
+		Address:       contractAddr.String(),
+		AccountExists: k.accountKeeper.GetAccount(ctx, contractAddr) != nil,
+		IsContract:    k.HasContractInfo(ctx, contractAddr),
+	}
+	if balance := k.balances.GetAllBalances(ctx, contractAddr); !balance.IsZero() {
+		r.HasBalance, r.Balance = true, balance
+	}
+	return r, nil
+}
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestCheckInstantiate2Address(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	mock := &wasmtesting.MockWasmEngine{}
+	wasmtesting.MakeInstantiable(mock)
+	keepers.WasmKeeper.wasmVM = mock // set mock to not fail on contract init message
+	q := Querier(keepers.WasmKeeper)
+
+	mySalt := []byte("my salt")
+	myMsg := []byte(`{"foo":"bar"}`)
+	myAddr := BuildContractAddressPredictable(example.Checksum, example.CreatorAddr, mySalt, []byte{})
+	myFixedMsgAddr := BuildContractAddressPredictable(example.Checksum, example.CreatorAddr, mySalt, myMsg)
+	myFunds := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+
+	specs := map[string]struct {
+		setup  func(t *testing.T, ctx sdk.Context)
+		fixMsg bool
+		exp    types.QueryCheckInstantiate2AddressResponse
+	}{
+		"fresh address": {
+			exp: types.QueryCheckInstantiate2AddressResponse{Address: myAddr.String()},
+		},
+		"fresh address with fix msg": {
+			fixMsg: true,
+			exp:    types.QueryCheckInstantiate2AddressResponse{Address: myFixedMsgAddr.String()},
+		},
+		"pre-funded base account": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				keepers.Faucet.Fund(ctx, myAddr, myFunds...)
+			},
+			exp: types.QueryCheckInstantiate2AddressResponse{Address: myAddr.String(), AccountExists: true, HasBalance: true, Balance: myFunds},
+		},
+		"base account without balance": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				keepers.AccountKeeper.SetAccount(ctx, keepers.AccountKeeper.NewAccountWithAddress(ctx, myAddr))
+			},
+			exp: types.QueryCheckInstantiate2AddressResponse{Address: myAddr.String(), AccountExists: true},
+		},
+		"existing contract": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				_, _, err := keepers.ContractKeeper.Instantiate2(ctx, example.CodeID, example.CreatorAddr, nil, myMsg, "testing", nil, mySalt, false)
+				require.NoError(t, err)
+			},
+			exp: types.QueryCheckInstantiate2AddressResponse{Address: myAddr.String(), AccountExists: true, IsContract: true},
+		},
+		"existing contract with other fix msg setting": {
+			setup: func(t *testing.T, ctx sdk.Context) {
+				_, _, err := keepers.ContractKeeper.Instantiate2(ctx, example.CodeID, example.CreatorAddr, nil, myMsg, "testing", nil, mySalt, false)
+				require.NoError(t, err)
+			},
+			fixMsg: true,
+			exp:    types.QueryCheckInstantiate2AddressResponse{Address: myFixedMsgAddr.String()},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			if spec.setup != nil {
+				spec.setup(t, ctx)
+			}
+
+			// when
+			got, gotErr := q.CheckInstantiate2Address(ctx, &types.QueryCheckInstantiate2AddressRequest{
+				Creator: example.CreatorAddr.String(),
+				CodeId:  example.CodeID,
+				Salt:    mySalt,
+				FixMsg:  spec.fixMsg,
+				Msg:     myMsg,
+			})
+
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, *got)
+		})
+	}
+}
+
+func TestCheckInstantiate2AddressValidation(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	k := keepers.WasmKeeper
+
+	specs := map[string]struct {
+		creator sdk.AccAddress
+		codeID  uint64
+		salt    []byte
+		msg     types.RawContractMessage
+		fixMsg  bool
+		expErr  error
+	}{
+		"unknown code": {
+			creator: example.CreatorAddr,
+			codeID:  example.CodeID + 1,
+			salt:    []byte("my salt"),
+			expErr:  types.ErrNoSuchCodeFn(example.CodeID + 1).Unwrap(),
+		},
+		"empty salt": {
+			creator: example.CreatorAddr,
+			codeID:  example.CodeID,
+			expErr:  types.ErrEmpty,
+		},
+		"salt too long": {
+			creator: example.CreatorAddr,
+			codeID:  example.CodeID,
+			salt:    make([]byte, types.MaxSaltSize+1),
+			expErr:  types.ErrLimit,
+		},
+		"invalid msg with fix msg": {
+			creator: example.CreatorAddr,
+			codeID:  example.CodeID,
+			salt:    []byte("my salt"),
+			msg:     []byte("not json"),
+			fixMsg:  true,
+			expErr:  types.ErrInvalid,
+		},
+		"empty creator": {
+			codeID: example.CodeID,
+			salt:   []byte("my salt"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, gotErr := k.CheckInstantiate2Address(ctx, spec.creator, spec.codeID, spec.salt, spec.msg, spec.fixMsg)
+			require.Error(t, gotErr)
+			if spec.expErr != nil {
+				assert.ErrorIs(t, gotErr, spec.expErr)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 
 	"cosmossdk.io/collections"
@@ -85,7 +87,9 @@ var defaultAcceptedAccountTypes = map[reflect.Type]struct{}{
 // Keeper will have a reference to Wasm Engine with it's own data directory.
 type Keeper struct {
 	// The (unexposed) keys used to access the stores from the Context.
-	storeService          corestoretypes.KVStoreService
+	storeService corestoretypes.KVStoreService
+	// transientStoreService is optional and tracks the code stored last in a tx
+	transientStoreService corestoretypes.TransientStoreService
 	cdc                   codec.Codec
 	accountKeeper         types.AccountKeeper
 	bank                  CoinTransferrer
@@ -94,13 +98,24 @@ type Keeper struct {
 	wasmVMResponseHandler WasmVMResponseHandler
 	messenger             Messenger
 	// queryGasLimit is the max wasmvm gas that can be spent on executing a query with a contract
-	queryGasLimit        uint64
-	gasRegister          types.GasRegister
-	maxQueryStackSize    uint32
-	maxCallDepth         uint32
-	acceptedAccountTypes map[reflect.Type]struct{}
+	queryGasLimit     uint64
+	gasRegister       types.GasRegister
+	maxQueryStackSize uint32
+	maxCallDepth      uint32
+	// maxCodeInfosBatchSize is the max number of code ids in a CodeInfos query
+	maxCodeInfosBatchSize int
+	acceptedAccountTypes  map[reflect.Type]struct{}
+	// eventAttributePolicy defines the rules for attributes emitted by contracts
+	eventAttributePolicy EventAttributePolicy
 	accountPruner        AccountPruner
-	params               collections.Item[types.Params]
+	// burner burns the code upload deposits
+	burner types.Burner
+	// balances are read to check predictable contract addresses for funds
+	balances types.BankViewKeeper
+	// authzKeeper and feeGrantKeeper are optional and used for the delegated capabilities query only
+	authzKeeper    types.AuthzKeeper
+	feeGrantKeeper types.FeeGrantKeeper
+	params         collections.Item[types.Params]
 	// propagate gov authZ to sub-messages
 	propagateGovAuthorization map[types.AuthorizationPolicyAction]struct{}
 
@@ -110,6 +125,9 @@ type Keeper struct {
 
 	// wasmLimits contains the limits sent to wasmvm on init
 	wasmLimits wasmvmtypes.WasmLimits
+
+	// executionReceipts is optional and keeps the recent executions per contract in memory
+	executionReceipts *executionReceiptLog
 }
 
 func (k Keeper) getUploadAccessConfig(ctx context.Context) types.AccessConfig {
@@ -133,6 +151,24 @@ func (k Keeper) GetParams(ctx context.Context) types.Params {
 	return p
 }
 
+// withCachedParams stores the params in the context returned unless set already. They are loaded once per contract
+// call without gas and reused by the gas free reads of the call and its nested calls. Param changes take effect
+// with the next message.
+func (k Keeper) withCachedParams(ctx sdk.Context) sdk.Context {
+	if _, ok := types.ParamsFromContext(ctx); ok {
+		return ctx
+	}
+	return types.WithParams(ctx, k.GetParams(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())))
+}
+
+// cachedParams returns the params stored in the context or reads them without gas
+func (k Keeper) cachedParams(ctx sdk.Context) types.Params {
+	if p, ok := types.ParamsFromContext(ctx); ok {
+		return p
+	}
+	return k.GetParams(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()))
+}
+
 // SetParams sets all wasm parameters.
 func (k Keeper) SetParams(ctx context.Context, ps types.Params) error {
 	return k.params.Set(ctx, ps)
@@ -149,6 +185,11 @@ func (k Keeper) GetGasRegister() types.GasRegister {
 }
 
 func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy) (codeID uint64, checksum []byte, err error) {
+	return k.createWithSource(ctx, creator, wasmCode, instantiateAccess, authZ, "", "")
+}
+
+// createWithSource stores the code like create. The optional source and builder are recorded in the upload provenance.
+func (k Keeper) createWithSource(ctx context.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ types.AuthorizationPolicy, source, builder string) (codeID uint64, checksum []byte, err error) {
 	if creator == nil {
 		return 0, checksum, errorsmod.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
 	}
@@ -168,13 +209,41 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	}
 
 	if ioutils.IsGzip(wasmCode) {
-		sdkCtx.GasMeter().ConsumeGas(k.gasRegister.UncompressCosts(len(wasmCode)), "Uncompress gzip bytecode")
+		sdkCtx.GasMeter().ConsumeGas(k.gasRegister.UncompressCosts(len(wasmCode)), gasDescriptorUncompress)
 		wasmCode, err = ioutils.Uncompress(wasmCode, int64(types.MaxWasmSize))
 		if err != nil {
 			return 0, checksum, types.ErrCreateFailed.Wrap(errorsmod.Wrap(err, "uncompress wasm archive").Error())
 		}
 	}
 
+	_, hasTxContracts := types.TxContractsFromContext(sdkCtx)
+	if hasTxContracts && k.transientStoreService != nil {
+		sdkCtx.GasMeter().ConsumeGas(k.gasRegister.ChecksumCosts(len(wasmCode)), gasDescriptorChecksum)
+		if existingID, existingChecksum, found := k.findStoredInTx(sdkCtx, wasmCode); found {
+			// the same code id is only returned for an identical code info
+			existing := k.GetCodeInfo(sdkCtx, existingID)
+			if existing.Creator != creator.String() || !existing.InstantiateConfig.Equals(*instantiateAccess) {
+				return 0, checksum, errorsmod.Wrapf(types.ErrDuplicateStoreCode, "already stored with code id %d in this tx by another creator or with another instantiate permission", existingID)
+			}
+			if !k.GetParams(sdkCtx).DeduplicateStoreCode {
+				return 0, checksum, errorsmod.Wrapf(types.ErrDuplicateStoreCode, "already stored with code id %d in this tx", existingID)
+			}
+			sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+				types.EventTypeStoreCodeDuplicate,
+				sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(existingChecksum)),
+				sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(existingID, 10)),
+			))
+			if err := k.setLastStoredCode(sdkCtx, existingID); err != nil {
+				return 0, checksum, err
+			}
+			return existingID, existingChecksum, nil
+		}
+	}
+
+	if err := k.chargeUpload(sdkCtx, creator); err != nil {
+		return 0, checksum, err
+	}
+
 	gasLeft := k.runtimeGasForContract(sdkCtx)
 	var gasUsed uint64
 	isSimulation := sdkCtx.ExecMode() == sdk.ExecModeSimulate
@@ -184,7 +253,7 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	} else {
 		checksum, gasUsed, err = k.wasmVM.StoreCode(wasmCode, gasLeft)
 	}
-	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	k.consumeVMGas(sdkCtx, gasUsed, gasDescriptorCompile)
 	if err != nil {
 		return 0, checksum, errorsmod.Wrap(types.ErrCreateFailed, err.Error())
 	}
@@ -201,12 +270,40 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	k.Logger(sdkCtx).Debug("storing new contract", "capabilities", requiredCapabilities, "code_id", codeID)
 	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
 	k.mustStoreCodeInfo(sdkCtx, codeID, codeInfo)
+	if err := k.setCodeInstantiationCount(sdkCtx, codeID, 0); err != nil {
+		return 0, checksum, err
+	}
+	if err := k.addToCodeIDsByChecksumIndex(sdkCtx, checksum, codeID); err != nil {
+		return 0, checksum, err
+	}
+	if hasTxContracts {
+		if err := k.setStoredCodeInTx(sdkCtx, checksum, codeID); err != nil {
+			return 0, checksum, err
+		}
+	}
+	if err := k.setLastStoredCode(sdkCtx, codeID); err != nil {
+		return 0, checksum, err
+	}
+	provenance := types.CodeProvenance{Source: source, Builder: builder}
+	if txBytes := sdkCtx.TxBytes(); len(txBytes) != 0 {
+		provenance.TxHash = tmhash.Sum(txBytes)
+	}
+	if err := k.setCodeProvenance(sdkCtx, codeID, provenance); err != nil {
+		return 0, checksum, err
+	}
 
-	evt := sdk.NewEvent(
-		types.EventTypeStoreCode,
-		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum)),
-		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)), // last element to be compatible with scripts
-	)
+	attrs := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(checksum))}
+	if len(provenance.TxHash) != 0 {
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyTxHash, provenance.TxHash.String()))
+	}
+	if source != "" {
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyCodeSource, source))
+	}
+	if builder != "" {
+		attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyBuilder, builder))
+	}
+	attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10))) // last element to be compatible with scripts
+	evt := sdk.NewEvent(types.EventTypeStoreCode, attrs...)
 	for _, f := range strings.Split(requiredCapabilities, ",") {
 		evt.AppendAttributes(sdk.NewAttribute(types.AttributeKeyRequiredCapability, strings.TrimSpace(f)))
 	}
@@ -215,6 +312,36 @@ func (k Keeper) create(ctx context.Context, creator sdk.AccAddress, wasmCode []b
 	return codeID, checksum, nil
 }
 
+// findStoredInTx returns the code id and checksum when the same wasm code was already stored in the current transaction.
+// Invalid wasm code is never reported as found so that it fails in the VM as before. The checksum is not charged,
+// callers have to do this. A transient store is required.
+func (k Keeper) findStoredInTx(ctx sdk.Context, wasmCode []byte) (uint64, []byte, bool) {
+	checksum, err := wasmvm.CreateChecksum(wasmCode)
+	if err != nil {
+		return 0, nil, false
+	}
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(types.GetStoredCodeInTxKey(checksum))
+	if err != nil {
+		panic(err)
+	}
+	txHash := sha256.Sum256(ctx.TxBytes())
+	if len(bz) != len(txHash)+8 || !bytes.Equal(bz[:len(txHash)], txHash[:]) {
+		return 0, nil, false
+	}
+	return sdk.BigEndianToUint64(bz[len(txHash):]), checksum, true
+}
+
+// setStoredCodeInTx records the code id of the wasm code stored in the current tx. The transient store is used
+// so that the record is reverted with the state of a failed message.
+// Nothing is recorded without a transient store.
+func (k Keeper) setStoredCodeInTx(ctx sdk.Context, checksum []byte, codeID uint64) error {
+	if k.transientStoreService == nil {
+		return nil
+	}
+	txHash := sha256.Sum256(ctx.TxBytes())
+	return k.transientStoreService.OpenTransientStore(ctx).Set(types.GetStoredCodeInTxKey(checksum), append(txHash[:], sdk.Uint64ToBigEndian(codeID)...))
+}
+
 func (k Keeper) mustStoreCodeInfo(ctx context.Context, codeID uint64, codeInfo types.CodeInfo) {
 	store := k.storeService.OpenKVStore(ctx)
 	// 0x01 | codeID (uint64) -> ContractInfo
@@ -250,7 +377,13 @@ func (k Keeper) importCode(ctx context.Context, codeID uint64, codeInfo types.Co
 		return errorsmod.Wrapf(types.ErrDuplicate, "duplicate code: %d", codeID)
 	}
 	// 0x01 | codeID (uint64) -> ContractInfo
-	return store.Set(key, k.cdc.MustMarshal(&codeInfo))
+	if err := store.Set(key, k.cdc.MustMarshal(&codeInfo)); err != nil {
+		return err
+	}
+	if err := k.addToCodeIDsByChecksumIndex(ctx, codeInfo.CodeHash, codeID); err != nil {
+		return err
+	}
+	return k.setCodeInstantiationCount(ctx, codeID, 0)
 }
 
 func (k Keeper) instantiate(
@@ -268,12 +401,15 @@ func (k Keeper) instantiate(
 	if creator == nil {
 		return nil, nil, types.ErrEmpty.Wrap("creator")
 	}
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx := k.withCachedParams(sdk.UnwrapSDKContext(ctx))
 
 	codeInfo := k.GetCodeInfo(ctx, codeID)
 	if codeInfo == nil {
 		return nil, nil, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
 	}
+	if codeInfo.Deprecated {
+		return nil, nil, types.ErrCodeDeprecated.Wrapf("code id %d", codeID)
+	}
 
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(sdkCtx, codeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(initMsg))
@@ -281,7 +417,7 @@ func (k Keeper) instantiate(
 	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: instantiate")
 
 	if !authPolicy.CanInstantiateContract(codeInfo.InstantiateConfig, creator) {
-		return nil, nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not instantiate")
+		return nil, nil, types.NewInstantiateNotPermittedError(codeID, codeInfo.InstantiateConfig)
 	}
 	contractAddress := addressGenerator(ctx, codeID, codeInfo.CodeHash)
 	if k.HasContractInfo(ctx, contractAddress) {
@@ -290,6 +426,12 @@ func (k Keeper) instantiate(
 		// is used for both cases.
 		return nil, nil, types.ErrDuplicate.Wrap("contract address already exists, try a different combination of creator, checksum and salt")
 	}
+	// the new contract can be its own admin
+	if !admin.Equals(contractAddress) {
+		if err := k.verifyAdminExists(sdkCtx, admin); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// check account
 	// every cosmos module can define custom account types when needed. The cosmos-sdk comes with extension points
@@ -382,10 +524,20 @@ func (k Keeper) instantiate(
 	if err != nil {
 		return nil, nil, err
 	}
+	if k.isContractLabelIndexEnabled(sdkCtx) {
+		err = k.addToContractLabelIndex(sdkCtx, label, contractAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	err = k.appendToContractHistory(sdkCtx, contractAddress, historyEntry)
 	if err != nil {
 		return nil, nil, err
 	}
+	err = k.incrementCodeInstantiationCount(sdkCtx, codeID)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	k.mustStoreContractInfo(sdkCtx, contractAddress, &contractInfo)
 
@@ -393,6 +545,8 @@ func (k Keeper) instantiate(
 		types.EventTypeInstantiate,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(codeInfo.CodeHash)),
+		sdk.NewAttribute(types.AttributeKeyCreator, creator.String()),
 	))
 
 	sdkCtx = types.WithSubMsgAuthzPolicy(sdkCtx, authPolicy.SubMessageAuthorizationPolicy(types.AuthZActionInstantiate))
@@ -405,13 +559,36 @@ func (k Keeper) instantiate(
 }
 
 // Execute executes the contract instance
-func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) ([]byte, error) {
+func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddress, msg []byte, coins sdk.Coins) (data []byte, err error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx := k.withCachedParams(sdk.UnwrapSDKContext(ctx))
+	// receipts of top level executions in block execution are collected until the tx result is known.
+	// They do not touch the state or gas meter.
+	if pending, ok := types.PendingExecutionReceiptsFromContext(sdkCtx); ok && k.executionReceipts != nil &&
+		sdkCtx.ExecMode() == sdk.ExecModeFinalize && isTopLevelExecution(sdkCtx) {
+		gasMeter, gasBefore := sdkCtx.GasMeter(), sdkCtx.GasMeter().GasConsumed()
+		defer func() {
+			recovered := recover()
+			pending.Add(contractAddress, newExecutionReceipt(sdkCtx, caller, msg, gasMeter.GasConsumed()-gasBefore, err, recovered))
+			if recovered != nil {
+				// the panic fails the tx before the post handler can record the receipts
+				k.executionReceipts.addAll(pending.Take(), true)
+				panic(recovered)
+			}
+		}()
+	}
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
 	}
+	// the gas limit of the tx was charged to the budget already when reserved by the ante handler
+	budgetReserved := types.HasGasBudgetReservation(sdkCtx, contractAddress)
+	if !budgetReserved {
+		if err := k.CheckContractGasBudget(sdkCtx, contractAddress); err != nil {
+			return nil, err
+		}
+	}
+	gasBefore := sdkCtx.GasMeter().GasConsumed()
 
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
@@ -433,6 +610,15 @@ func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddr
 	gasLeft := k.runtimeGasForContract(sdkCtx)
 	res, gasUsed, execErr := k.wasmVM.Execute(codeInfo.CodeHash, env, info, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(sdkCtx), gasLeft, costJSONDeserialization)
 	k.consumeRuntimeGas(sdkCtx, gasUsed)
+	execGas := sdkCtx.GasMeter().GasConsumed() - gasBefore
+	if !budgetReserved {
+		if err := k.addContractGasUsed(sdkCtx, contractAddress, execGas); err != nil {
+			return nil, err
+		}
+	}
+	if err := k.addCodeGasUsed(sdkCtx, contractInfo.CodeID, execGas); err != nil {
+		return nil, err
+	}
 	if execErr != nil {
 		return nil, errorsmod.Wrap(types.ErrVMError, execErr.Error())
 	}
@@ -443,13 +629,16 @@ func (k Keeper) execute(ctx context.Context, contractAddress, caller sdk.AccAddr
 	if res.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
+	if err := k.recordContractActivity(sdkCtx, contractAddress); err != nil {
+		return nil, err
+	}
 
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeExecute,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 	))
 
-	data, err := k.handleContractResponse(sdkCtx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
+	data, err = k.handleContractResponse(sdkCtx, contractAddress, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Data, res.Ok.Events)
 	if err != nil {
 		return nil, err
 	}
@@ -481,9 +670,12 @@ func (k Keeper) migrate(
 	if newCodeInfo == nil {
 		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown code")
 	}
+	if newCodeInfo.Deprecated {
+		return nil, types.ErrCodeDeprecated.Wrapf("code id %d", newCodeID)
+	}
 
 	if !authZ.CanInstantiateContract(newCodeInfo.InstantiateConfig, caller) {
-		return nil, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "to use new code")
+		return nil, types.NewInstantiateNotPermittedError(newCodeID, newCodeInfo.InstantiateConfig)
 	}
 
 	// check for IBC flag
@@ -520,11 +712,12 @@ func (k Keeper) migrate(
 	}
 
 	// delete old secondary index entry
-	err = k.removeFromContractCodeSecondaryIndex(ctx, contractAddress, k.mustGetLastContractHistoryEntry(sdkCtx, contractAddress))
+	err = k.removeFromContractCodeSecondaryIndex(ctx, contractAddress, k.mustGetLastContractCodeHistoryEntry(sdkCtx, contractAddress))
 	if err != nil {
 		return nil, err
 	}
 	// persist migration updates
+	oldCodeID := contractInfo.CodeID
 	historyEntry := contractInfo.AddMigration(sdkCtx, newCodeID, msg)
 	err = k.appendToContractHistory(ctx, contractAddress, historyEntry)
 	if err != nil {
@@ -534,13 +727,25 @@ func (k Keeper) migrate(
 	if err != nil {
 		return nil, err
 	}
+	// instantiation counts follow the code a contract currently runs
+	if err := k.decrementCodeInstantiationCount(ctx, oldCodeID); err != nil {
+		return nil, err
+	}
+	if err := k.incrementCodeInstantiationCount(ctx, newCodeID); err != nil {
+		return nil, err
+	}
 	k.mustStoreContractInfo(ctx, contractAddress, contractInfo)
 
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeMigrate,
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyChecksum, hex.EncodeToString(newCodeInfo.CodeHash)),
 	))
+	err = emitContractManagementChanged(sdkCtx, contractAddress, newCodeID, types.ContractManagementChangeTypeMigrate, strconv.FormatUint(oldCodeID, 10), strconv.FormatUint(newCodeID, 10))
+	if err != nil {
+		return nil, err
+	}
 
 	var data []byte
 
@@ -565,6 +770,43 @@ func (k Keeper) migrate(
 	return data, nil
 }
 
+// forceMigrateContracts migrates the contracts to the new code id. The authorization policy is expected to skip the
+// admin checks. In atomic mode, the first failed migration is returned as error. Otherwise a failed migration is
+// reverted and its redacted error is reported in the results only.
+func (k Keeper) forceMigrateContracts(
+	ctx context.Context,
+	contracts []sdk.AccAddress,
+	caller sdk.AccAddress,
+	newCodeID uint64,
+	msg []byte,
+	atomic bool,
+	authZ types.AuthorizationPolicy,
+) ([]types.ForceMigrateResult, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	results := make([]types.ForceMigrateResult, len(contracts))
+	for i, contractAddr := range contracts {
+		cacheCtx, commit := sdkCtx.CacheContext()
+		_, err := k.migrate(cacheCtx, contractAddr, caller, newCodeID, msg, authZ)
+		if err != nil && atomic {
+			return nil, errorsmod.Wrapf(err, "contract %s", contractAddr)
+		}
+		results[i] = types.ForceMigrateResult{Contract: contractAddr.String(), Success: err == nil}
+		attrs := []sdk.Attribute{
+			sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(newCodeID, 10)),
+			sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(err == nil)),
+		}
+		if err != nil {
+			results[i].Error = redactError(err).Error()
+			attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyError, results[i].Error))
+		} else {
+			commit()
+		}
+		sdkCtx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeForceMigrate, attrs...))
+	}
+	return results, nil
+}
+
 func (k Keeper) callMigrateEntrypoint(
 	sdkCtx sdk.Context,
 	contractAddress sdk.AccAddress,
@@ -574,6 +816,7 @@ func (k Keeper) callMigrateEntrypoint(
 	senderAddress sdk.AccAddress,
 	oldMigrateVersion *uint64,
 ) (*wasmvmtypes.Response, error) {
+	sdkCtx = k.withCachedParams(sdkCtx)
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, newChecksum, k.IsPinnedCode(sdkCtx, newCodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
 	sdkCtx.GasMeter().ConsumeGas(setupCost, "Loading CosmWasm module: migrate")
@@ -622,7 +865,7 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 	if err != nil {
 		return nil, err
 	}
-	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	sdkCtx := k.withCachedParams(sdk.UnwrapSDKContext(ctx))
 	sdkCtx, discount := k.checkDiscountEligibility(sdkCtx, codeInfo.CodeHash, k.IsPinnedCode(ctx, contractInfo.CodeID))
 	setupCost := k.gasRegister.SetupContractCost(discount, len(msg))
 
@@ -645,6 +888,9 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 	if res.Err != "" {
 		return nil, types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
+	if err := k.recordContractActivity(sdkCtx, contractAddress); err != nil {
+		return nil, err
+	}
 
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeSudo,
@@ -662,6 +908,7 @@ func (k Keeper) Sudo(ctx context.Context, contractAddress sdk.AccAddress, msg []
 
 // reply is only called from keeper internal functions (dispatchSubmessages) after processing the submessage
 func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+	ctx = k.withCachedParams(ctx)
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
@@ -719,6 +966,34 @@ func (k Keeper) addToContractCreatorSecondaryIndex(ctx context.Context, creatorA
 	return store.Set(types.GetContractByCreatorSecondaryIndexKey(creatorAddress, position.Bytes(), contractAddress), []byte{})
 }
 
+// addToContractLabelIndex adds element to the index for contracts-by-label queries
+func (k Keeper) addToContractLabelIndex(ctx context.Context, label string, contractAddress sdk.AccAddress) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractByLabelKey(label, contractAddress), []byte{})
+}
+
+// removeFromContractLabelIndex removes element from the index for contracts-by-label queries
+func (k Keeper) removeFromContractLabelIndex(ctx context.Context, label string, contractAddress sdk.AccAddress) error {
+	return k.storeService.OpenKVStore(ctx).Delete(types.GetContractByLabelKey(label, contractAddress))
+}
+
+// isContractLabelIndexEnabled returns the IndexContractLabels param. It is read without gas so that
+// nothing is charged when the index is disabled.
+func (k Keeper) isContractLabelIndexEnabled(ctx sdk.Context) bool {
+	return k.cachedParams(ctx).IndexContractLabels
+}
+
+// IterateContractsByLabel iterates over all contracts with exactly the given label in order of their address bytes.
+func (k Keeper) IterateContractsByLabel(ctx context.Context, label string, cb func(address sdk.AccAddress) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractsByLabelPrefix(label))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			return
+		}
+	}
+}
+
 // IterateContractsByCreator iterates over all contracts with given creator address in order of creation time asc.
 func (k Keeper) IterateContractsByCreator(ctx context.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool) {
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractsByCreatorPrefix(creator))
@@ -744,6 +1019,71 @@ func (k Keeper) IterateContractsByCode(ctx context.Context, codeID uint64, cb fu
 	}
 }
 
+// GetCodeIDsByChecksum returns all code ids stored with the checksum in ascending order
+func (k Keeper) GetCodeIDsByChecksum(ctx context.Context, checksum []byte) []uint64 {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetCodeIDsByChecksumPrefix(checksum))
+	iter := prefixStore.Iterator(nil, nil)
+	defer iter.Close()
+
+	var r []uint64
+	for ; iter.Valid(); iter.Next() {
+		r = append(r, binary.BigEndian.Uint64(iter.Key()))
+	}
+	return r
+}
+
+// addToCodeIDsByChecksumIndex adds the code id to the secondary index of code ids by checksum
+func (k Keeper) addToCodeIDsByChecksumIndex(ctx context.Context, checksum []byte, codeID uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetCodeIDByChecksumKey(checksum, codeID), []byte{})
+}
+
+// GetCodeInstantiationCount returns the number of contracts currently running the given code id
+func (k Keeper) GetCodeInstantiationCount(ctx context.Context, codeID uint64) uint64 {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(types.GetCodeInstantiationCountKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+// setCodeInstantiationCount stores the number of instantiations for a code id and updates the
+// codes-by-usage secondary index
+func (k Keeper) setCodeInstantiationCount(ctx context.Context, codeID, count uint64) error {
+	store := k.storeService.OpenKVStore(ctx)
+	key := types.GetCodeInstantiationCountKey(codeID)
+	bz, err := store.Get(key)
+	if err != nil {
+		return err
+	}
+	if bz != nil {
+		if err := store.Delete(types.GetCodesByInstantiationCountKey(sdk.BigEndianToUint64(bz), codeID)); err != nil {
+			return err
+		}
+	}
+	if err := store.Set(key, sdk.Uint64ToBigEndian(count)); err != nil {
+		return err
+	}
+	return store.Set(types.GetCodesByInstantiationCountKey(count, codeID), []byte{})
+}
+
+// incrementCodeInstantiationCount increases the number of instantiations for a code id by one
+func (k Keeper) incrementCodeInstantiationCount(ctx context.Context, codeID uint64) error {
+	return k.setCodeInstantiationCount(ctx, codeID, k.GetCodeInstantiationCount(ctx, codeID)+1)
+}
+
+// decrementCodeInstantiationCount decreases the number of instantiations for a code id by one
+func (k Keeper) decrementCodeInstantiationCount(ctx context.Context, codeID uint64) error {
+	count := k.GetCodeInstantiationCount(ctx, codeID)
+	if count == 0 {
+		return nil
+	}
+	return k.setCodeInstantiationCount(ctx, codeID, count-1)
+}
+
 func (k Keeper) setContractAdmin(ctx context.Context, contractAddress, caller, newAdmin sdk.AccAddress, authZ types.AuthorizationPolicy) error {
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
@@ -753,19 +1093,53 @@ func (k Keeper) setContractAdmin(ctx context.Context, contractAddress, caller, n
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
 	}
+	if !newAdmin.Equals(contractAddress) {
+		if err := k.verifyAdminExists(sdkCtx, newAdmin); err != nil {
+			return err
+		}
+	}
 	newAdminStr := newAdmin.String()
+	oldAdminStr := contractInfo.Admin
 	contractInfo.Admin = newAdminStr
 	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	if k.isRecordContractInfoChangesEnabled(sdkCtx) {
+		entry := types.NewContractInfoChangeEntry(sdkCtx, types.ContractCodeHistoryOperationTypeAdminChanged, contractInfo.CodeID, oldAdminStr, newAdminStr)
+		if err := k.appendToContractHistory(sdkCtx, contractAddress, entry); err != nil {
+			return err
+		}
+	}
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeUpdateContractAdmin,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 		sdk.NewAttribute(types.AttributeKeyNewAdmin, newAdminStr),
 	))
 
-	return nil
+	return emitContractManagementChanged(sdkCtx, contractAddress, contractInfo.CodeID, types.ContractManagementChangeTypeAdmin, oldAdminStr, newAdminStr)
+}
+
+// isRecordContractInfoChangesEnabled returns the RecordContractInfoChanges param. It is read without gas so that
+// nothing is charged when the recording is disabled.
+func (k Keeper) isRecordContractInfoChangesEnabled(ctx sdk.Context) bool {
+	return k.cachedParams(ctx).RecordContractInfoChanges
+}
+
+// verifyAdminExists returns an error when strict admin validation is enabled and the admin is
+// neither an existing account nor a contract. An empty admin is always valid. The param is read without gas so
+// that nothing is charged when the validation is disabled.
+func (k Keeper) verifyAdminExists(ctx sdk.Context, admin sdk.AccAddress) error {
+	if len(admin) == 0 || !k.cachedParams(ctx).StrictAdminValidation {
+		return nil
+	}
+	if k.accountKeeper.GetAccount(ctx, admin) != nil || k.HasContractInfo(ctx, admin) {
+		return nil
+	}
+	return errorsmod.Wrapf(types.ErrUnknownAdmin, "address %s", admin)
 }
 
 func (k Keeper) setContractLabel(ctx context.Context, contractAddress, caller sdk.AccAddress, newLabel string, authZ types.AuthorizationPolicy) error {
+	if err := types.ValidateLabel(newLabel); err != nil {
+		return errorsmod.Wrap(err, "label")
+	}
 	sdkCtx := sdk.UnwrapSDKContext(ctx)
 	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
 	if contractInfo == nil {
@@ -774,17 +1148,63 @@ func (k Keeper) setContractLabel(ctx context.Context, contractAddress, caller sd
 	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
 	}
+	oldLabel := contractInfo.Label
 	contractInfo.Label = newLabel
 	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	if k.isContractLabelIndexEnabled(sdkCtx) {
+		if err := k.removeFromContractLabelIndex(sdkCtx, oldLabel, contractAddress); err != nil {
+			return err
+		}
+		if err := k.addToContractLabelIndex(sdkCtx, newLabel, contractAddress); err != nil {
+			return err
+		}
+	}
+	if k.isRecordContractInfoChangesEnabled(sdkCtx) {
+		entry := types.NewContractInfoChangeEntry(sdkCtx, types.ContractCodeHistoryOperationTypeLabelChanged, contractInfo.CodeID, oldLabel, newLabel)
+		if err := k.appendToContractHistory(sdkCtx, contractAddress, entry); err != nil {
+			return err
+		}
+	}
 	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
 		types.EventTypeUpdateContractLabel,
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyOldLabel, oldLabel),
 		sdk.NewAttribute(types.AttributeKeyNewLabel, newLabel),
 	))
 
+	return emitContractManagementChanged(sdkCtx, contractAddress, contractInfo.CodeID, types.ContractManagementChangeTypeLabel, oldLabel, newLabel)
+}
+
+// setContractStateAccess enables or disables the raw state queries of a contract.
+// This requires contract state access control to be enabled in the params.
+func (k Keeper) setContractStateAccess(ctx context.Context, contractAddress, caller sdk.AccAddress, rawQueryEnabled bool, authZ types.AuthorizationPolicy) error {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if !k.GetParams(sdkCtx).ContractStateAccessControl {
+		return types.ErrStateAccessControlDisabled
+	}
+	contractInfo := k.GetContractInfo(sdkCtx, contractAddress)
+	if contractInfo == nil {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
+	}
+	if !authZ.CanModifyContract(contractInfo.AdminAddr(), caller) {
+		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify contract")
+	}
+	contractInfo.RawQueryDisabled = !rawQueryEnabled
+	k.mustStoreContractInfo(sdkCtx, contractAddress, contractInfo)
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetContractStateAccess,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyRawQueryEnabled, strconv.FormatBool(rawQueryEnabled)),
+	))
 	return nil
 }
 
+// IsRawQueryEnabled returns false when the raw state queries of the contract are disabled by its admin and the
+// contract state access control is enabled in the params. Contract to contract raw queries are not affected.
+func (k Keeper) IsRawQueryEnabled(ctx context.Context, contractInfo types.ContractInfo) bool {
+	return !contractInfo.RawQueryDisabled || !k.GetParams(ctx).ContractStateAccessControl
+}
+
 func (k Keeper) appendToContractHistory(ctx context.Context, contractAddr sdk.AccAddress, newEntries ...types.ContractCodeHistoryEntry) error {
 	store := k.storeService.OpenKVStore(ctx)
 	// find last element position
@@ -828,8 +1248,9 @@ func (k Keeper) GetContractHistory(ctx context.Context, contractAddr sdk.AccAddr
 	return r
 }
 
-// mustGetLastContractHistoryEntry returns the last element from history. To be used internally only as it panics when none exists
-func (k Keeper) mustGetLastContractHistoryEntry(ctx context.Context, contractAddr sdk.AccAddress) types.ContractCodeHistoryEntry {
+// mustGetLastContractCodeHistoryEntry returns the last element from history that set the contract code.
+// Admin and label changes are skipped. To be used internally only as it panics when none exists
+func (k Keeper) mustGetLastContractCodeHistoryEntry(ctx context.Context, contractAddr sdk.AccAddress) types.ContractCodeHistoryEntry {
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.GetContractCodeHistoryElementPrefix(contractAddr))
 	iter := prefixStore.ReverseIterator(nil, nil)
 	defer iter.Close()
@@ -838,13 +1259,103 @@ func (k Keeper) mustGetLastContractHistoryEntry(ctx context.Context, contractAdd
 	for ; iter.Valid(); iter.Next() {
 		if len(iter.Key()) == 8 { // add extra safety in a mixed contract length environment
 			k.cdc.MustUnmarshal(iter.Value(), &r)
-			return r
+			if r.Operation.IsCodeChange() {
+				return r
+			}
 		}
 	}
 	// all contracts have a history
 	panic(fmt.Sprintf("no history for %s", contractAddr.String()))
 }
 
+// pruneContractHistory deletes all but the newest keepLastN history entries of a contract. The init entry and the
+// latest code change entry are always kept as the contract info queries and the code index depend on them.
+// The height before which the contract info can not be reconstructed from the history anymore is stored.
+// Returns the number of deleted entries.
+func (k Keeper) pruneContractHistory(ctx context.Context, contractAddr sdk.AccAddress, keepLastN uint64) (uint64, error) {
+	if !k.HasContractInfo(ctx, contractAddr) {
+		return 0, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(store), types.GetContractCodeHistoryElementPrefix(contractAddr))
+	var (
+		positions      []uint64
+		entries        []types.ContractCodeHistoryEntry
+		lastCodeChange int
+	)
+	iter := prefixStore.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		if len(iter.Key()) != 8 { // add extra safety in a mixed contract length environment
+			continue
+		}
+		var e types.ContractCodeHistoryEntry
+		k.cdc.MustUnmarshal(iter.Value(), &e)
+		if e.Operation.IsCodeChange() {
+			lastCodeChange = len(entries)
+		}
+		positions = append(positions, sdk.BigEndianToUint64(iter.Key()))
+		entries = append(entries, e)
+	}
+	iter.Close()
+
+	var (
+		pruned           uint64
+		prunedHeight     uint64
+		prunedCodeChange bool
+	)
+	for i, pos := range positions {
+		if i == 0 || i == lastCodeChange || uint64(len(positions)-i) <= keepLastN {
+			continue
+		}
+		if err := store.Delete(types.GetContractCodeHistoryElementKey(contractAddr, pos)); err != nil {
+			return 0, err
+		}
+		pruned++
+		prunedHeight = max(prunedHeight, entryHeight(entries[i]))
+		prunedCodeChange = prunedCodeChange || entries[i].Operation.IsCodeChange()
+	}
+	if prunedCodeChange {
+		// the code id is reconstructed from the latest code change up to the height
+		prunedHeight = max(prunedHeight, entryHeight(entries[lastCodeChange]))
+	}
+	if prunedHeight > k.GetContractHistoryPrunedHeight(ctx, contractAddr) {
+		if err := k.setContractHistoryPrunedHeight(ctx, contractAddr, prunedHeight); err != nil {
+			return 0, err
+		}
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypePruneContractHistory,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyPrunedCount, strconv.FormatUint(pruned, 10)),
+	))
+	return pruned, nil
+}
+
+// GetContractHistoryPrunedHeight returns the height before which entries of the contract history were pruned so
+// that the contract info at these heights is unknown. Zero is returned when nothing was pruned.
+func (k Keeper) GetContractHistoryPrunedHeight(ctx context.Context, contractAddr sdk.AccAddress) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetContractHistoryPrunedKey(contractAddr))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) != 8 {
+		return 0
+	}
+	return sdk.BigEndianToUint64(bz)
+}
+
+func (k Keeper) setContractHistoryPrunedHeight(ctx context.Context, contractAddr sdk.AccAddress, height uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.GetContractHistoryPrunedKey(contractAddr), sdk.Uint64ToBigEndian(height))
+}
+
+// entryHeight returns the block height of the history entry or zero when not set
+func entryHeight(e types.ContractCodeHistoryEntry) uint64 {
+	if e.Updated == nil {
+		return 0
+	}
+	return e.Updated.BlockHeight
+}
+
 // QuerySmart queries the smart contract itself.
 func (k Keeper) QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-smart")
@@ -854,6 +1365,7 @@ func (k Keeper) QuerySmart(ctx context.Context, contractAddr sdk.AccAddress, req
 	if err != nil {
 		return nil, err
 	}
+	sdkCtx = k.withCachedParams(sdkCtx)
 
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(sdkCtx, contractAddr)
 	if err != nil {
@@ -1061,6 +1573,34 @@ func (k Keeper) IterateContractState(ctx context.Context, contractAddress sdk.Ac
 	}
 }
 
+// IterateContractStateKeys iterates over the contract store keys with the given prefix, beginning at the start key
+// when set. The values are never loaded. The keys are relative to the contract store and must not be modified.
+func (k Keeper) IterateContractStateKeys(ctx context.Context, contractAddress sdk.AccAddress, keyPrefix, start []byte, reverse bool, cb func(key []byte) bool) {
+	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
+	lower, upper := keyPrefix, storetypes.PrefixEndBytes(keyPrefix)
+	var iter storetypes.Iterator
+	if reverse {
+		if start != nil {
+			// the end is exclusive so that the next possible key is used to include start
+			upper = append(bytes.Clone(start), 0)
+		}
+		iter = prefixStore.ReverseIterator(lower, upper)
+	} else {
+		if start != nil {
+			lower = start
+		}
+		iter = prefixStore.Iterator(lower, upper)
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if cb(iter.Key()) {
+			break
+		}
+	}
+}
+
 func (k Keeper) importContractState(ctx context.Context, contractAddress sdk.AccAddress, models []types.Model) error {
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
@@ -1076,6 +1616,31 @@ func (k Keeper) importContractState(ctx context.Context, contractAddress sdk.Acc
 	return nil
 }
 
+// setContractState writes the models to the contract store. Existing keys are overwritten.
+// This requires raw state writes to be enabled in the params.
+func (k Keeper) setContractState(ctx context.Context, contractAddress sdk.AccAddress, models []types.Model) error {
+	if !k.GetParams(ctx).AllowRawStateWrites {
+		return types.ErrRawStateWritesDisabled
+	}
+	if !k.HasContractInfo(ctx, contractAddress) {
+		return types.ErrNoSuchContractFn(contractAddress.String()).Wrapf("address %s", contractAddress.String())
+	}
+	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), prefixStoreKey)
+	for _, model := range models {
+		if model.Value == nil {
+			model.Value = []byte{}
+		}
+		prefixStore.Set(model.Key, model.Value)
+	}
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSetContractState,
+		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
+		sdk.NewAttribute(types.AttributeKeyKeyCount, strconv.Itoa(len(models))),
+	))
+	return nil
+}
+
 func (k Keeper) GetCodeInfo(ctx context.Context, codeID uint64) *types.CodeInfo {
 	store := k.storeService.OpenKVStore(ctx)
 	var codeInfo types.CodeInfo
@@ -1090,6 +1655,30 @@ func (k Keeper) GetCodeInfo(ctx context.Context, codeID uint64) *types.CodeInfo
 	return &codeInfo
 }
 
+// GetCodeProvenance returns the upload provenance of the code or nil when not recorded
+func (k Keeper) GetCodeProvenance(ctx context.Context, codeID uint64) *types.CodeProvenance {
+	store := k.storeService.OpenKVStore(ctx)
+	bz, err := store.Get(types.GetCodeProvenanceKey(codeID))
+	if err != nil {
+		panic(err)
+	}
+	if bz == nil {
+		return nil
+	}
+	var provenance types.CodeProvenance
+	k.cdc.MustUnmarshal(bz, &provenance)
+	return &provenance
+}
+
+// setCodeProvenance stores the upload provenance of the code. Empty records are not stored.
+func (k Keeper) setCodeProvenance(ctx context.Context, codeID uint64, provenance types.CodeProvenance) error {
+	if provenance.Equal(types.CodeProvenance{}) {
+		return nil
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	return store.Set(types.GetCodeProvenanceKey(codeID), k.cdc.MustMarshal(&provenance))
+}
+
 func (k Keeper) containsCodeInfo(ctx context.Context, codeID uint64) bool {
 	store := k.storeService.OpenKVStore(ctx)
 	ok, err := store.Has(types.GetCodeKey(codeID))
@@ -1138,6 +1727,7 @@ func (k Keeper) pinCode(ctx context.Context, codeID uint64) error {
 	if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
 		return errorsmod.Wrap(types.ErrPinContractFailed, err.Error())
 	}
+	wasPinned := k.IsPinnedCode(ctx, codeID)
 	store := k.storeService.OpenKVStore(ctx)
 	// store 1 byte to not run into `nil` debugging issues
 	err := store.Set(types.GetPinnedCodeIndexPrefix(codeID), []byte{1})
@@ -1149,7 +1739,33 @@ func (k Keeper) pinCode(ctx context.Context, codeID uint64) error {
 		types.EventTypePinCode,
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
 	))
-	return nil
+	return k.emitCodeManagementChanged(ctx, codeID, types.ContractManagementChangeTypePin, strconv.FormatBool(wasPinned), "true")
+}
+
+// setLastStoredCode records the code id as stored last in the current tx.
+// Nothing is recorded without a transient store or outside of a tx, like for proposals.
+func (k Keeper) setLastStoredCode(ctx sdk.Context, codeID uint64) error {
+	if k.transientStoreService == nil || len(ctx.TxBytes()) == 0 {
+		return nil
+	}
+	txHash := sha256.Sum256(ctx.TxBytes())
+	return k.transientStoreService.OpenTransientStore(ctx).Set(types.LastStoredCodeKey, append(txHash[:], sdk.Uint64ToBigEndian(codeID)...))
+}
+
+// lastStoredCode returns the code id that was stored last in the current tx.
+func (k Keeper) lastStoredCode(ctx sdk.Context) (uint64, error) {
+	if k.transientStoreService == nil {
+		return 0, errorsmod.Wrap(types.ErrInvalid, "last stored code id not supported")
+	}
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(types.LastStoredCodeKey)
+	if err != nil {
+		return 0, err
+	}
+	txHash := sha256.Sum256(ctx.TxBytes())
+	if len(bz) != len(txHash)+8 || !bytes.Equal(bz[:len(txHash)], txHash[:]) {
+		return 0, errorsmod.Wrap(types.ErrNotFound, "no code stored in this tx")
+	}
+	return sdk.BigEndianToUint64(bz[len(txHash):]), nil
 }
 
 // UnpinCode removes the wasm contract from wasmvm cache
@@ -1162,6 +1778,7 @@ func (k Keeper) unpinCode(ctx context.Context, codeID uint64) error {
 		return errorsmod.Wrap(types.ErrUnpinContractFailed, err.Error())
 	}
 
+	wasPinned := k.IsPinnedCode(ctx, codeID)
 	store := k.storeService.OpenKVStore(ctx)
 	err := store.Delete(types.GetPinnedCodeIndexPrefix(codeID))
 	if err != nil {
@@ -1172,7 +1789,7 @@ func (k Keeper) unpinCode(ctx context.Context, codeID uint64) error {
 		types.EventTypeUnpinCode,
 		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
 	))
-	return nil
+	return k.emitCodeManagementChanged(ctx, codeID, types.ContractManagementChangeTypePin, strconv.FormatBool(wasPinned), "false")
 }
 
 // IsPinnedCode returns true when codeID is pinned in wasmvm cache
@@ -1245,6 +1862,7 @@ func (k Keeper) setAccessConfig(ctx context.Context, codeID uint64, caller sdk.A
 		return errorsmod.Wrap(sdkerrors.ErrUnauthorized, "can not modify code access config")
 	}
 
+	oldConfig := info.InstantiateConfig
 	info.InstantiateConfig = newConfig
 	k.mustStoreCodeInfo(ctx, codeID, *info)
 	evt := sdk.NewEvent(
@@ -1257,9 +1875,36 @@ func (k Keeper) setAccessConfig(ctx context.Context, codeID uint64, caller sdk.A
 		evt.Attributes = append(evt.Attributes, attr.ToKVPair())
 	}
 	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(evt)
+	return k.emitCodeManagementChanged(ctx, codeID, types.ContractManagementChangeTypeInstantiateConfig, accessConfigValue(oldConfig), accessConfigValue(newConfig))
+}
+
+// deprecateCode marks a code id so that no new contracts can be instantiated from it or migrated to it.
+// Existing contracts are not affected.
+func (k Keeper) deprecateCode(ctx context.Context, codeID uint64) error {
+	info := k.GetCodeInfo(ctx, codeID)
+	if info == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	info.Deprecated = true
+	k.mustStoreCodeInfo(ctx, codeID, *info)
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeDeprecateCode,
+		sdk.NewAttribute(types.AttributeKeyCodeID, strconv.FormatUint(codeID, 10)),
+	))
 	return nil
 }
 
+// resetAccessConfig replaces the access config of a code id with the current chain default instantiate permission.
+// Same as on upload, the code creator is the authorized address when the default is `AnyOfAddresses`.
+func (k Keeper) resetAccessConfig(ctx context.Context, codeID uint64, caller sdk.AccAddress, authz types.AuthorizationPolicy) error {
+	info := k.GetCodeInfo(ctx, codeID)
+	if info == nil {
+		return types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+	}
+	defaultConfig := k.getInstantiateAccessConfig(ctx).With(sdk.MustAccAddressFromBech32(info.Creator))
+	return k.setAccessConfig(ctx, codeID, caller, defaultConfig, authz)
+}
+
 // handleContractResponse processes the contract response data by emitting events and sending sub-/messages.
 func (k *Keeper) handleContractResponse(
 	ctx sdk.Context,
@@ -1270,18 +1915,22 @@ func (k *Keeper) handleContractResponse(
 	data []byte,
 	evts wasmvmtypes.Array[wasmvmtypes.Event],
 ) ([]byte, error) {
+	attrs, evts, err := k.eventAttributePolicy.apply(attrs, evts)
+	if err != nil {
+		return nil, err
+	}
 	attributeGasCost := k.gasRegister.EventCosts(attrs, evts)
-	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
+	ctx.GasMeter().ConsumeGas(attributeGasCost, gasDescriptorEvents)
 	// emit all events from this contract itself
 	if len(attrs) != 0 {
-		wasmEvents, err := newWasmModuleEvent(attrs, contractAddr)
+		wasmEvents, err := newWasmModuleEvent(attrs, contractAddr, k.eventAttributePolicy)
 		if err != nil {
 			return nil, err
 		}
 		ctx.EventManager().EmitEvents(wasmEvents)
 	}
 	if len(evts) > 0 {
-		customEvents, err := newCustomEvents(evts, contractAddr)
+		customEvents, err := newCustomEvents(evts, contractAddr, k.eventAttributePolicy)
 		if err != nil {
 			return nil, err
 		}
@@ -1302,8 +1951,12 @@ func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
 }
 
 func (k Keeper) consumeRuntimeGas(ctx sdk.Context, gas uint64) {
+	k.consumeVMGas(ctx, gas, gasDescriptorRuntime)
+}
+
+func (k Keeper) consumeVMGas(ctx sdk.Context, gas uint64, descriptor string) {
 	consumed := k.gasRegister.FromWasmVMGas(gas)
-	ctx.GasMeter().ConsumeGas(consumed, "wasm contract")
+	ctx.GasMeter().ConsumeGas(consumed, descriptor)
 	// throw OutOfGas error if we ran out (got exactly to zero due to better limit enforcing)
 	if ctx.GasMeter().IsOutOfGas() {
 		panic(storetypes.ErrorOutOfGas{Descriptor: "Wasm engine function execution"})
@@ -1376,7 +2029,14 @@ func (k Keeper) importContract(ctx context.Context, contractAddr sdk.AccAddress,
 		return err
 	}
 	k.mustStoreContractInfo(ctx, contractAddr, c)
-	err = k.addToContractCodeSecondaryIndex(ctx, contractAddr, historyEntries[len(historyEntries)-1])
+	// the secondary index is based on the last code change, admin and label changes are skipped
+	lastCodeEntry := historyEntries[0]
+	for _, e := range historyEntries {
+		if e.Operation.IsCodeChange() {
+			lastCodeEntry = e
+		}
+	}
+	err = k.addToContractCodeSecondaryIndex(ctx, contractAddr, lastCodeEntry)
 	if err != nil {
 		return err
 	}
@@ -1384,10 +2044,21 @@ func (k Keeper) importContract(ctx context.Context, contractAddr sdk.AccAddress,
 	if err != nil {
 		return err
 	}
+	if k.isContractLabelIndexEnabled(sdk.UnwrapSDKContext(ctx)) {
+		err = k.addToContractLabelIndex(ctx, c.Label, contractAddr)
+		if err != nil {
+			return err
+		}
+	}
+	err = k.incrementCodeInstantiationCount(ctx, c.CodeID)
+	if err != nil {
+		return err
+	}
 	return k.importContractState(ctx, contractAddr, state)
 }
 
 func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
+	ctx = types.WithQueryJSONEncoding(ctx, k.queryJSONEncoding(ctx))
 	return NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
 }
 
@@ -1422,7 +2093,11 @@ func moduleLogger(ctx sdk.Context) log.Logger {
 
 // Querier creates a new grpc querier instance
 func Querier(k *Keeper) *GrpcQuerier {
-	return NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+	q := NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+	q.maxCodeInfosBatchSize = k.maxCodeInfosBatchSize
+	q.authzKeeper = k.authzKeeper
+	q.feeGrantKeeper = k.feeGrantKeeper
+	return q
 }
 
 // QueryGasLimit returns the gas limit for smart queries.
@@ -39,24 +39,31 @@ func NewKeeper(
 ) Keeper {
 	sb := collections.NewSchemaBuilder(storeService)
 	keeper := &Keeper{
-		storeService:         storeService,
-		cdc:                  cdc,
-		wasmVM:               nil,
-		accountKeeper:        accountKeeper,
-		bank:                 NewBankCoinTransferrer(bankKeeper),
-		accountPruner:        NewVestingCoinBurner(bankKeeper),
-		queryGasLimit:        nodeConfig.SmartQueryGasLimit,
-		gasRegister:          types.NewDefaultWasmGasRegister(),
-		maxQueryStackSize:    types.DefaultMaxQueryStackSize,
-		maxCallDepth:         types.DefaultMaxCallDepth,
-		acceptedAccountTypes: defaultAcceptedAccountTypes,
-		params:               collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
+		storeService:          storeService,
+		cdc:                   cdc,
+		wasmVM:                nil,
+		accountKeeper:         accountKeeper,
+		bank:                  NewBankCoinTransferrer(bankKeeper),
+		accountPruner:         NewVestingCoinBurner(bankKeeper),
+		burner:                bankKeeper,
+		balances:              bankKeeper,
+		queryGasLimit:         nodeConfig.SmartQueryGasLimit,
+		gasRegister:           types.NewDefaultWasmGasRegister(),
+		maxQueryStackSize:     types.DefaultMaxQueryStackSize,
+		maxCallDepth:          types.DefaultMaxCallDepth,
+		maxCodeInfosBatchSize: DefaultMaxCodeInfosBatchSize,
+		acceptedAccountTypes:  defaultAcceptedAccountTypes,
+		eventAttributePolicy:  DefaultEventAttributePolicy(),
+		params:                collections.NewItem(sb, types.ParamsKey, "params", codec.CollValue[types.Params](cdc)),
 		propagateGovAuthorization: map[types.AuthorizationPolicyAction]struct{}{
 			types.AuthZActionInstantiate: {},
 		},
 		authority:  authority,
 		wasmLimits: vmConfig.WasmLimits,
 	}
+	if nodeConfig.ExecutionReceiptsLimit != 0 {
+		keeper.executionReceipts = newExecutionReceiptLog(nodeConfig.ExecutionReceiptsLimit)
+	}
 	keeper.messenger = NewDefaultMessageHandler(keeper, router, ics4Wrapper, channelKeeper, bankKeeper, cdc, portSource)
 	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distrKeeper, channelKeeper, keeper)
 	preOpts, postOpts := splitOpts(opts)
@@ -3,11 +3,13 @@ package keeper
 import (
 	"bytes"
 	_ "embed"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	stdrand "math/rand"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	dbm "github.com/cosmos/cosmos-db"
+	"github.com/cosmos/gogoproto/proto"
 	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -332,6 +335,114 @@ func TestCreateDuplicate(t *testing.T) {
 	require.Equal(t, hackatomWasm, storedCode)
 }
 
+func TestCreateDuplicateInTx(t *testing.T) {
+	gzippedHackatom, err := os.ReadFile("./testdata/hackatom.wasm.gzip")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		dedupe       bool
+		wasmCodes    [][]byte
+		accesses     []*types.AccessConfig
+		otherCreator bool
+		expCodeIDs   []uint64
+		expErr       *errorsmod.Error
+		expDupEvents int
+	}{
+		"duplicate rejected": {
+			wasmCodes:  [][]byte{hackatomWasm, hackatomWasm},
+			expCodeIDs: []uint64{1},
+			expErr:     types.ErrDuplicateStoreCode,
+		},
+		"gzipped duplicate rejected": {
+			wasmCodes:  [][]byte{hackatomWasm, gzippedHackatom},
+			expCodeIDs: []uint64{1},
+			expErr:     types.ErrDuplicateStoreCode,
+		},
+		"duplicate deduplicated": {
+			dedupe:       true,
+			wasmCodes:    [][]byte{hackatomWasm, hackatomWasm},
+			expCodeIDs:   []uint64{1, 1},
+			expDupEvents: 1,
+		},
+		"duplicate with other instantiate permission rejected": {
+			dedupe:     true,
+			wasmCodes:  [][]byte{hackatomWasm, hackatomWasm},
+			accesses:   []*types.AccessConfig{nil, &types.AllowNobody},
+			expCodeIDs: []uint64{1},
+			expErr:     types.ErrDuplicateStoreCode,
+		},
+		"duplicate by other creator rejected": {
+			dedupe:       true,
+			wasmCodes:    [][]byte{hackatomWasm, hackatomWasm},
+			otherCreator: true,
+			expCodeIDs:   []uint64{1},
+			expErr:       types.ErrDuplicateStoreCode,
+		},
+		"different codes": {
+			wasmCodes:  [][]byte{hackatomWasm, replierWasm},
+			expCodeIDs: []uint64{1, 2},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			params := types.DefaultParams()
+			params.DeduplicateStoreCode = spec.dedupe
+			require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+			creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+
+			em := sdk.NewEventManager()
+			ctx = types.WithTxContracts(ctx.WithEventManager(em), types.NewTxContracts())
+			otherCreator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+			var gotCodeIDs []uint64
+			for i, wasmCode := range spec.wasmCodes {
+				var access *types.AccessConfig
+				if i < len(spec.accesses) {
+					access = spec.accesses[i]
+				}
+				sender := creator
+				if i != 0 && spec.otherCreator {
+					sender = otherCreator
+				}
+				codeID, _, err := keepers.ContractKeeper.Create(ctx, sender, wasmCode, access)
+				if err != nil {
+					require.True(t, spec.expErr.Is(err), err)
+					break
+				}
+				gotCodeIDs = append(gotCodeIDs, codeID)
+			}
+			require.Equal(t, spec.expCodeIDs, gotCodeIDs)
+			var gotDupEvents int
+			for _, e := range em.Events() {
+				if e.Type == types.EventTypeStoreCodeDuplicate {
+					gotDupEvents++
+				}
+			}
+			assert.Equal(t, spec.expDupEvents, gotDupEvents)
+			// no additional code stored
+			assert.False(t, keepers.WasmKeeper.containsCodeInfo(ctx, gotCodeIDs[len(gotCodeIDs)-1]+1))
+		})
+	}
+}
+
+func TestCreateDuplicateInTxAfterDiscardedStore(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	ctx = types.WithTxContracts(ctx, types.NewTxContracts())
+
+	// when stored in a cache context that is discarded, like a failed sub message
+	cacheCtx, _ := ctx.CacheContext()
+	_, _, err := keepers.ContractKeeper.Create(cacheCtx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+
+	// then the same code can be stored again
+	codeID, _, err := keepers.ContractKeeper.Create(ctx, creator, hackatomWasm, nil)
+	require.NoError(t, err)
+	assert.True(t, keepers.WasmKeeper.containsCodeInfo(ctx, codeID))
+	_, _, err = keepers.ContractKeeper.Create(ctx, creator, hackatomWasm, nil)
+	assert.ErrorIs(t, err, types.ErrDuplicateStoreCode)
+}
+
 func TestCreateWithSimulation(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 
@@ -423,7 +534,7 @@ func TestInstantiate(t *testing.T) {
 
 	gasAfter := ctx.GasMeter().GasConsumed()
 	if types.EnableGasVerification {
-		require.Equal(t, uint64(0x1bcb0), gasAfter-gasBefore)
+		require.Equal(t, uint64(0x1dc6a), gasAfter-gasBefore)
 	}
 
 	// ensure it is stored properly
@@ -444,13 +555,54 @@ func TestInstantiate(t *testing.T) {
 	// and events emitted
 	expEvt := sdk.Events{
 		sdk.NewEvent("instantiate",
-			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1")),
+			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("code_id", "1"),
+			sdk.NewAttribute("code_checksum", hex.EncodeToString(example.Checksum)), sdk.NewAttribute("creator", creator.String())),
 		sdk.NewEvent("wasm",
 			sdk.NewAttribute("_contract_address", gotContractAddr.String()), sdk.NewAttribute("Let the", "hacking begin")),
 	}
 	assert.Equal(t, expEvt, em.Events())
 }
 
+func TestCodeInstantiationCount(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	otherExample := StoreHackatomExampleContract(t, ctx, keepers)
+	initMsg := mustMarshal(t, HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)})
+
+	assert.Equal(t, uint64(0), k.GetCodeInstantiationCount(ctx, example.CodeID))
+	assert.Equal(t, uint64(0), k.GetCodeInstantiationCount(ctx, otherExample.CodeID))
+
+	// when instantiated
+	_, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsg, "first", nil)
+	require.NoError(t, err)
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsg, "second", nil)
+	require.NoError(t, err)
+	// and instantiated with predictable address
+	_, _, err = keepers.ContractKeeper.Instantiate2(ctx, example.CodeID, creator, nil, initMsg, "third", nil, []byte("salt"), false)
+	require.NoError(t, err)
+
+	// then
+	assert.Equal(t, uint64(3), k.GetCodeInstantiationCount(ctx, example.CodeID))
+	assert.Equal(t, uint64(0), k.GetCodeInstantiationCount(ctx, otherExample.CodeID))
+
+	// and failed instantiations are not counted
+	_, _, err = keepers.ContractKeeper.Instantiate2(ctx, example.CodeID, creator, nil, initMsg, "duplicate", nil, []byte("salt"), false)
+	require.Error(t, err)
+	assert.Equal(t, uint64(3), k.GetCodeInstantiationCount(ctx, example.CodeID))
+
+	// and migrations move the count to the new code
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, initMsg, "migrated", nil)
+	require.NoError(t, err)
+	migMsg := []byte(fmt.Sprintf(`{"verifier":%q}`, RandomAccountAddress(t).String()))
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, creator, otherExample.CodeID, migMsg)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), k.GetCodeInstantiationCount(ctx, example.CodeID))
+	assert.Equal(t, uint64(1), k.GetCodeInstantiationCount(ctx, otherExample.CodeID))
+}
+
 func TestInstantiateWithDeposit(t *testing.T) {
 	var (
 		bob  = bytes.Repeat([]byte{1}, types.SDKAddrLen)
@@ -535,7 +687,7 @@ func TestInstantiateWithPermissions(t *testing.T) {
 		"nobody": {
 			srcPermission: types.AllowNobody,
 			srcActor:      myAddr,
-			expError:      sdkerrors.ErrUnauthorized,
+			expError:      types.ErrInstantiateNotPermitted,
 		},
 		"anyAddress with matching address": {
 			srcPermission: types.AccessTypeAnyOfAddresses.With(myAddr),
@@ -544,7 +696,7 @@ func TestInstantiateWithPermissions(t *testing.T) {
 		"anyAddress with non matching address": {
 			srcActor:      myAddr,
 			srcPermission: types.AccessTypeAnyOfAddresses.With(otherAddr),
-			expError:      sdkerrors.ErrUnauthorized,
+			expError:      types.ErrInstantiateNotPermitted,
 		},
 	}
 	for msg, spec := range specs {
@@ -558,6 +710,15 @@ func TestInstantiateWithPermissions(t *testing.T) {
 
 			_, _, err = keepers.ContractKeeper.Instantiate(ctx, contractID, spec.srcActor, nil, initMsgBz, "demo contract 1", nil)
 			assert.True(t, spec.expError.Is(err), "got %+v", err)
+			if spec.expError == nil {
+				return
+			}
+			// and the error contains the instantiate config
+			var gotErr types.InstantiateNotPermittedError
+			require.True(t, errors.As(err, &gotErr))
+			assert.Equal(t, contractID, gotErr.CodeID)
+			assert.Equal(t, spec.srcPermission.Permission, gotErr.Permission)
+			assert.Equal(t, spec.srcPermission.AllAuthorizedAddresses(), gotErr.Addresses)
 		})
 	}
 }
@@ -1312,7 +1473,7 @@ func TestMigrate(t *testing.T) {
 			fromCodeID: originalCodeID,
 			toCodeID:   restrictedCodeExample.CodeID,
 			migrateMsg: migMsgBz,
-			expErr:     sdkerrors.ErrUnauthorized,
+			expErr:     types.ErrInstantiateNotPermitted,
 		},
 		"fail with non existing code id": {
 			admin:      creator,
@@ -1489,6 +1650,146 @@ func TestMigrateReplacesTheSecondIndex(t *testing.T) {
 	require.False(t, exists)
 }
 
+func TestForceMigrateContracts(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100000))
+	creator := DeterministicAccountAddress(t, 1)
+	keepers.Faucet.Fund(parentCtx, creator, deposit...)
+	authority := DeterministicAccountAddress(t, 2)
+
+	originalCodeID := StoreHackatomExampleContract(t, parentCtx, keepers).CodeID
+	// migrating from hackatom 420 to 42 fails in the migrate entry point of the contract
+	hackatom42 := StoreExampleContract(t, parentCtx, keepers, "./testdata/hackatom_42.wasm")
+	hackatom420 := StoreExampleContract(t, parentCtx, keepers, "./testdata/hackatom_420.wasm")
+
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	withAdmin, _, err := keepers.ContractKeeper.Instantiate(parentCtx, originalCodeID, creator, creator, initMsgBz, "with admin", nil)
+	require.NoError(t, err)
+	withoutAdmin, _, err := keepers.ContractKeeper.Instantiate(parentCtx, originalCodeID, creator, nil, initMsgBz, "without admin", nil)
+	require.NoError(t, err)
+	failing, _, err := keepers.ContractKeeper.Instantiate(parentCtx, hackatom420.CodeID, creator, nil, initMsgBz, "failing", nil)
+	require.NoError(t, err)
+
+	newVerifierAddr := RandomAccountAddress(t)
+	migMsgBz, err := json.Marshal(map[string]any{"verifier": newVerifierAddr})
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		contracts     []sdk.AccAddress
+		toCodeID      uint64
+		atomic        bool
+		expErr        bool
+		expSuccess    []bool
+		expErrContain string
+	}{
+		"admin-less contract": {
+			contracts:  []sdk.AccAddress{withoutAdmin},
+			toCodeID:   hackatom42.CodeID,
+			expSuccess: []bool{true},
+		},
+		"contracts with and without admin": {
+			contracts:  []sdk.AccAddress{withAdmin, withoutAdmin},
+			toCodeID:   hackatom42.CodeID,
+			expSuccess: []bool{true, true},
+		},
+		"contracts with and without admin - atomic": {
+			contracts:  []sdk.AccAddress{withAdmin, withoutAdmin},
+			toCodeID:   hackatom42.CodeID,
+			atomic:     true,
+			expSuccess: []bool{true, true},
+		},
+		"failing migrate entry point - non atomic": {
+			contracts:     []sdk.AccAddress{withAdmin, failing, withoutAdmin},
+			toCodeID:      hackatom42.CodeID,
+			expSuccess:    []bool{true, false, true},
+			expErrContain: types.ErrMigrationFailed.Error(),
+		},
+		"failing migrate entry point - atomic": {
+			contracts: []sdk.AccAddress{withAdmin, failing, withoutAdmin},
+			toCodeID:  hackatom42.CodeID,
+			atomic:    true,
+			expErr:    true,
+		},
+		"unknown code id - non atomic": {
+			contracts:  []sdk.AccAddress{withAdmin},
+			toCodeID:   99999,
+			expSuccess: []bool{false},
+		},
+		"unknown contract - atomic": {
+			contracts: []sdk.AccAddress{withAdmin, RandomAccountAddress(t)},
+			toCodeID:  hackatom42.CodeID,
+			atomic:    true,
+			expErr:    true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			codeIDsBefore := make([]uint64, len(spec.contracts))
+			for i, c := range spec.contracts {
+				if info := k.GetContractInfo(ctx, c); info != nil {
+					codeIDsBefore[i] = info.CodeID
+				}
+			}
+
+			// when
+			gotResults, gotErr := k.forceMigrateContracts(ctx, spec.contracts, authority, spec.toCodeID, migMsgBz, spec.atomic, GovAuthorizationPolicy{})
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.Len(t, gotResults, len(spec.contracts))
+			var gotEvents []sdk.Event
+			for _, e := range em.Events() {
+				if e.Type == types.EventTypeForceMigrate {
+					gotEvents = append(gotEvents, e)
+				}
+			}
+			require.Len(t, gotEvents, len(spec.contracts))
+			for i, c := range spec.contracts {
+				res := gotResults[i]
+				assert.Equal(t, c.String(), res.Contract)
+				assert.Equal(t, spec.expSuccess[i], res.Success)
+				expAttrs := map[string]string{
+					"_contract_address": c.String(),
+					"code_id":           strconv.FormatUint(spec.toCodeID, 10),
+					"success":           strconv.FormatBool(spec.expSuccess[i]),
+				}
+				if !res.Success {
+					require.NotEmpty(t, res.Error)
+					if spec.expErrContain != "" {
+						assert.Contains(t, res.Error, spec.expErrContain)
+					}
+					expAttrs["error"] = res.Error
+				}
+				assert.Equal(t, expAttrs, attrsToStringMap(gotEvents[i].Attributes))
+
+				info := k.GetContractInfo(ctx, c)
+				if !res.Success {
+					// failed migrations are reverted
+					assert.Equal(t, codeIDsBefore[i], info.CodeID)
+					continue
+				}
+				assert.Equal(t, spec.toCodeID, info.CodeID)
+				raw := k.QueryRaw(ctx, c, []byte("config"))
+				var stored map[string]string
+				require.NoError(t, json.Unmarshal(raw, &stored))
+				assert.Equal(t, newVerifierAddr.String(), stored["verifier"])
+			}
+		})
+	}
+}
+
 func TestMigrateWithDispatchedMessage(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.ContractKeeper
@@ -1503,7 +1804,7 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 
 	originalContractID, _, err := keeper.Create(ctx, creator, hackatomWasm, nil)
 	require.NoError(t, err)
-	burnerContractID, _, err := keeper.Create(ctx, creator, burnerCode, nil)
+	burnerContractID, burnerChecksum, err := keeper.Create(ctx, creator, burnerCode, nil)
 	require.NoError(t, err)
 	require.NotEqual(t, originalContractID, burnerContractID)
 
@@ -1529,6 +1830,17 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 			"Attr": []dict{
 				{"code_id": "2"},
 				{"_contract_address": contractAddr},
+				{"code_checksum": hex.EncodeToString(burnerChecksum)},
+			},
+		},
+		{
+			"Type": "cosmwasm.wasm.v1.EventContractManagementChanged",
+			"Attr": []dict{
+				{"change_type": `"CONTRACT_MANAGEMENT_CHANGE_TYPE_MIGRATE"`},
+				{"code_id": `"2"`},
+				{"contract_address": `"` + contractAddr.String() + `"`},
+				{"new_value": `"2"`},
+				{"old_value": `"1"`},
 			},
 		},
 		{
@@ -1545,6 +1857,8 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 			"Attr": []dict{
 				{"spender": contractAddr},
 				{"amount": "100000denom"},
+				{"_msg_index": "0"},
+				{"_call_depth": "1"},
 			},
 		},
 		{
@@ -1552,6 +1866,8 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 			"Attr": []dict{
 				{"receiver": myPayoutAddr},
 				{"amount": "100000denom"},
+				{"_msg_index": "0"},
+				{"_call_depth": "1"},
 			},
 		},
 		{
@@ -1560,6 +1876,8 @@ func TestMigrateWithDispatchedMessage(t *testing.T) {
 				{"recipient": myPayoutAddr},
 				{"sender": contractAddr},
 				{"amount": "100000denom"},
+				{"_msg_index": "0"},
+				{"_call_depth": "1"},
 			},
 		},
 	}
@@ -1907,7 +2225,11 @@ func TestPinCode(t *testing.T) {
 	assert.True(t, k.IsPinnedCode(ctx, myCodeID))
 
 	// and events
-	exp := sdk.Events{sdk.NewEvent("pin_code", sdk.NewAttribute("code_id", "1"))}
+	typedEvt, err := sdk.TypedEventToEvent(&types.EventContractManagementChanged{
+		CodeID: myCodeID, ChangeType: types.ContractManagementChangeTypePin, OldValue: "false", NewValue: "true",
+	})
+	require.NoError(t, err)
+	exp := sdk.Events{sdk.NewEvent("pin_code", sdk.NewAttribute("code_id", "1")), typedEvt}
 	assert.Equal(t, exp, em.Events())
 }
 
@@ -1941,7 +2263,11 @@ func TestUnpinCode(t *testing.T) {
 	assert.False(t, k.IsPinnedCode(ctx, myCodeID))
 
 	// and events
-	exp := sdk.Events{sdk.NewEvent("unpin_code", sdk.NewAttribute("code_id", "1"))}
+	typedEvt, err := sdk.TypedEventToEvent(&types.EventContractManagementChanged{
+		CodeID: myCodeID, ChangeType: types.ContractManagementChangeTypePin, OldValue: "true", NewValue: "false",
+	})
+	require.NoError(t, err)
+	exp := sdk.Events{sdk.NewEvent("unpin_code", sdk.NewAttribute("code_id", "1")), typedEvt}
 	assert.Equal(t, exp, em.Events())
 }
 
@@ -2489,14 +2815,129 @@ func TestSetAccessConfig(t *testing.T) {
 				return
 			}
 			require.NoError(t, gotErr)
-			// and event emitted
-			require.Len(t, em.Events(), 1)
+			// and events emitted
+			require.Len(t, em.Events(), 2)
 			assert.Equal(t, "update_code_access_config", em.Events()[0].Type)
 			assert.Equal(t, spec.expEvts, attrsToStringMap(em.Events()[0].Attributes))
+			assert.Equal(t, proto.MessageName(&types.EventContractManagementChanged{}), em.Events()[1].Type)
 		})
 	}
 }
 
+func TestResetAccessConfig(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creatorAddr := RandomAccountAddress(t)
+	nonCreatorAddr := RandomAccountAddress(t)
+	const codeID = 1
+
+	specs := map[string]struct {
+		authz           types.AuthorizationPolicy
+		chainPermission types.AccessType
+		caller          sdk.AccAddress
+		expConfig       types.AccessConfig
+		expErr          bool
+		expEvts         map[string]string
+	}{
+		"chain default everybody": {
+			authz:           DefaultAuthorizationPolicy{},
+			chainPermission: types.AccessTypeEverybody,
+			caller:          creatorAddr,
+			expConfig:       types.AllowEverybody,
+			expEvts: map[string]string{
+				"code_id":         "1",
+				"code_permission": "Everybody",
+			},
+		},
+		"chain default nobody": {
+			authz:           DefaultAuthorizationPolicy{},
+			chainPermission: types.AccessTypeNobody,
+			caller:          creatorAddr,
+			expConfig:       types.AllowNobody,
+			expEvts: map[string]string{
+				"code_id":         "1",
+				"code_permission": "Nobody",
+			},
+		},
+		"chain default any of addresses": {
+			authz:           DefaultAuthorizationPolicy{},
+			chainPermission: types.AccessTypeAnyOfAddresses,
+			caller:          creatorAddr,
+			expConfig:       types.AccessTypeAnyOfAddresses.With(creatorAddr),
+			expEvts: map[string]string{
+				"code_id":              "1",
+				"code_permission":      "AnyOfAddresses",
+				"authorized_addresses": creatorAddr.String(),
+			},
+		},
+		"different actor": {
+			authz:           DefaultAuthorizationPolicy{},
+			chainPermission: types.AccessTypeEverybody,
+			caller:          nonCreatorAddr,
+			expErr:          true,
+		},
+		"gov without actor": {
+			authz:           GovAuthorizationPolicy{},
+			chainPermission: types.AccessTypeEverybody,
+			expConfig:       types.AllowEverybody,
+			expEvts: map[string]string{
+				"code_id":         "1",
+				"code_permission": "Everybody",
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			newParams := types.DefaultParams()
+			newParams.InstantiateDefaultPermission = spec.chainPermission
+			require.NoError(t, k.SetParams(ctx, newParams))
+
+			k.mustStoreCodeInfo(ctx, codeID, types.NewCodeInfo(nil, creatorAddr, types.AccessTypeAnyOfAddresses.With(nonCreatorAddr)))
+			// when
+			gotErr := k.resetAccessConfig(ctx, codeID, spec.caller, spec.authz)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expConfig, k.GetCodeInfo(ctx, codeID).InstantiateConfig)
+			// and events emitted
+			require.Len(t, em.Events(), 2)
+			assert.Equal(t, "update_code_access_config", em.Events()[0].Type)
+			assert.Equal(t, spec.expEvts, attrsToStringMap(em.Events()[0].Attributes))
+			assert.Equal(t, proto.MessageName(&types.EventContractManagementChanged{}), em.Events()[1].Type)
+		})
+	}
+}
+
+func TestResetAccessConfigFollowsParamChanges(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creatorAddr := RandomAccountAddress(t)
+	const codeID = 1
+
+	params := types.DefaultParams()
+	params.InstantiateDefaultPermission = types.AccessTypeEverybody
+	require.NoError(t, k.SetParams(ctx, params))
+	k.mustStoreCodeInfo(ctx, codeID, types.NewCodeInfo(nil, creatorAddr, types.AllowNobody))
+
+	require.NoError(t, k.resetAccessConfig(ctx, codeID, creatorAddr, DefaultAuthorizationPolicy{}))
+	assert.Equal(t, types.AllowEverybody, k.GetCodeInfo(ctx, codeID).InstantiateConfig)
+
+	// when the chain default changes afterwards
+	params.InstantiateDefaultPermission = types.AccessTypeNobody
+	require.NoError(t, k.SetParams(ctx, params))
+	// then the stored config is not modified
+	assert.Equal(t, types.AllowEverybody, k.GetCodeInfo(ctx, codeID).InstantiateConfig)
+	// but a new reset picks up the new default
+	require.NoError(t, k.resetAccessConfig(ctx, codeID, creatorAddr, DefaultAuthorizationPolicy{}))
+	assert.Equal(t, types.AllowNobody, k.GetCodeInfo(ctx, codeID).InstantiateConfig)
+}
+
 func TestAppendToContractHistory(t *testing.T) {
 	f := fuzz.New().Funcs(ModelFuzzers...)
 	pCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
@@ -2536,10 +2977,122 @@ func TestAppendToContractHistory(t *testing.T) {
 			for j, addr := range variableLengthAddresses {
 				gotHistory := k.GetContractHistory(ctx, addr)
 				assert.Equal(t, orderedEntries[j], gotHistory, "%d: %X", j, addr)
-				assert.Equal(t, orderedEntries[j][len(orderedEntries[j])-1], k.mustGetLastContractHistoryEntry(ctx, addr))
+				assert.Equal(t, orderedEntries[j][len(orderedEntries[j])-1], k.mustGetLastContractCodeHistoryEntry(ctx, addr))
+			}
+		})
+	}
+}
+
+func TestPruneContractHistory(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k, q := keepers.WasmKeeper, Querier(keepers.WasmKeeper)
+
+	example := StoreHackatomExampleContract(t, parentCtx, keepers)
+	newCodeID := StoreHackatomExampleContract(t, parentCtx, keepers).CodeID
+	creator := example.CreatorAddr
+	initMsgBz := HackatomExampleInitMsg{
+		Verifier:    RandomAccountAddress(t),
+		Beneficiary: RandomAccountAddress(t),
+	}.GetBytes(t)
+	migMsgBz := []byte(fmt.Sprintf(`{"verifier":%q}`, RandomAccountAddress(t).String()))
+	params := k.GetParams(parentCtx)
+	params.RecordContractInfoChanges = true
+	require.NoError(t, k.SetParams(parentCtx, params))
+
+	// history: init, migrate and 3 admin changes at increasing heights
+	ctx := parentCtx.WithBlockHeight(100)
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, creator, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(101)
+	_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, creator, newCodeID, migMsgBz)
+	require.NoError(t, err)
+	for i := int64(0); i < 3; i++ {
+		ctx = ctx.WithBlockHeight(102 + i)
+		require.NoError(t, keepers.ContractKeeper.UpdateContractAdmin(ctx, contractAddr, creator, creator))
+	}
+	fullHistory := k.GetContractHistory(ctx, contractAddr)
+	require.Len(t, fullHistory, 5)
+
+	specs := map[string]struct {
+		keepLastN       uint64
+		expHistory      []types.ContractCodeHistoryEntry
+		expPrunedHeight uint64
+	}{
+		"keep none": {
+			keepLastN:       0,
+			expHistory:      fullHistory[0:2],
+			expPrunedHeight: 104,
+		},
+		"keep last": {
+			keepLastN:       1,
+			expHistory:      []types.ContractCodeHistoryEntry{fullHistory[0], fullHistory[1], fullHistory[4]},
+			expPrunedHeight: 103,
+		},
+		"keep last 2": {
+			keepLastN:       2,
+			expHistory:      []types.ContractCodeHistoryEntry{fullHistory[0], fullHistory[1], fullHistory[3], fullHistory[4]},
+			expPrunedHeight: 102,
+		},
+		"keep more than exist": {
+			keepLastN:  10,
+			expHistory: fullHistory,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			em := sdk.NewEventManager()
+
+			// when
+			gotPruned, gotErr := k.pruneContractHistory(ctx.WithEventManager(em), contractAddr, spec.keepLastN)
+
+			// then
+			require.NoError(t, gotErr)
+			expPruned := uint64(len(fullHistory) - len(spec.expHistory))
+			assert.Equal(t, expPruned, gotPruned)
+			assert.Equal(t, spec.expHistory, k.GetContractHistory(ctx, contractAddr))
+			expEvt := sdk.NewEvent(types.EventTypePruneContractHistory,
+				sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyPrunedCount, strconv.FormatUint(expPruned, 10)),
+			)
+			assert.Equal(t, sdk.Events{expEvt}, em.Events())
+
+			// and the queries still work
+			historyRsp, err := q.ContractHistory(ctx, &types.QueryContractHistoryRequest{Address: contractAddr.String()})
+			require.NoError(t, err)
+			assert.Equal(t, spec.expHistory, historyRsp.Entries)
+			// and the contract info is not reconstructed for heights before pruned entries
+			assert.Equal(t, spec.expPrunedHeight, k.GetContractHistoryPrunedHeight(ctx, contractAddr))
+			infoRsp, err := q.ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: contractAddr.String(), Height: 100})
+			if spec.expPrunedHeight > 100 {
+				require.ErrorIs(t, err, types.ErrNotFound)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, example.CodeID, infoRsp.CodeID)
 			}
+			infoRsp, err = q.ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: contractAddr.String(), Height: 104})
+			require.NoError(t, err)
+			assert.Equal(t, newCodeID, infoRsp.CodeID)
+
+			// and a later migration updates the code index
+			_, err = keepers.ContractKeeper.Migrate(ctx, contractAddr, creator, example.CodeID, migMsgBz)
+			require.NoError(t, err)
+			byCodeRsp, err := q.ContractsByCode(ctx, &types.QueryContractsByCodeRequest{CodeId: newCodeID})
+			require.NoError(t, err)
+			assert.Empty(t, byCodeRsp.Contracts)
+			byCodeRsp, err = q.ContractsByCode(ctx, &types.QueryContractsByCodeRequest{CodeId: example.CodeID})
+			require.NoError(t, err)
+			assert.Equal(t, []string{contractAddr.String()}, byCodeRsp.Contracts)
+			gotHistory := k.GetContractHistory(ctx, contractAddr)
+			assert.Equal(t, fullHistory[0], gotHistory[0])
+			assert.Equal(t, types.ContractCodeHistoryOperationTypeMigrate, gotHistory[len(gotHistory)-1].Operation)
 		})
 	}
+	t.Run("unknown contract", func(t *testing.T) {
+		addr := RandomAccountAddress(t)
+		_, gotErr := k.pruneContractHistory(ctx, addr, 1)
+		assert.ErrorIs(t, gotErr, types.ErrNoSuchContractFn(addr.String()).Unwrap())
+	})
 }
 
 func TestCoinBurnerPruneBalances(t *testing.T) {
@@ -2751,7 +3304,7 @@ func TestSetContractAdmin(t *testing.T) {
 			require.NoError(t, gotErr)
 			assert.Equal(t, spec.expAdmin, k.GetContractInfo(ctx, example.Contract).Admin)
 			// and event emitted
-			require.Len(t, em.Events(), 1)
+			require.Len(t, em.Events(), 2)
 			assert.Equal(t, "update_contract_admin", em.Events()[0].Type)
 			exp := map[string]string{
 				"_contract_address": example.Contract.String(),
@@ -2762,6 +3315,91 @@ func TestSetContractAdmin(t *testing.T) {
 	}
 }
 
+func TestStrictAdminValidation(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	existingAccount := keepers.Faucet.NewFundedRandomAccount(parentCtx, sdk.NewInt64Coin("denom", 1))
+	moduleAccount := keepers.AccountKeeper.GetModuleAccount(parentCtx, authtypes.FeeCollectorName).GetAddress()
+	initMsgBz := HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)}.GetBytes(t)
+
+	specs := map[string]struct {
+		strict bool
+		admin  sdk.AccAddress
+		expErr *errorsmod.Error
+	}{
+		"fresh address": {
+			strict: true,
+			admin:  RandomAccountAddress(t),
+			expErr: types.ErrUnknownAdmin,
+		},
+		"existing account": {
+			strict: true,
+			admin:  existingAccount,
+		},
+		"module account": {
+			strict: true,
+			admin:  moduleAccount,
+		},
+		"existing contract": {
+			strict: true,
+			admin:  example.Contract,
+		},
+		"no admin": {
+			strict: true,
+		},
+		"fresh address - param disabled": {
+			admin: RandomAccountAddress(t),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := types.DefaultParams()
+			params.StrictAdminValidation = spec.strict
+			require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+
+			// on instantiate
+			_, _, gotErr := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, spec.admin, initMsgBz, "label", nil)
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+			} else {
+				require.NoError(t, gotErr)
+			}
+			// and on update admin
+			gotErr = keepers.WasmKeeper.setContractAdmin(ctx, example.Contract, example.CreatorAddr, spec.admin, DefaultAuthorizationPolicy{})
+			if spec.expErr != nil {
+				require.True(t, spec.expErr.Is(gotErr), "got %+v", gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.admin, keepers.WasmKeeper.GetContractInfo(ctx, example.Contract).AdminAddr())
+		})
+	}
+	t.Run("no gas when disabled", func(t *testing.T) {
+		ctx := parentCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		require.NoError(t, keepers.WasmKeeper.verifyAdminExists(ctx, RandomAccountAddress(t)))
+		assert.Equal(t, storetypes.Gas(0), ctx.GasMeter().GasConsumed())
+	})
+}
+
+func TestIsRecordContractInfoChangesEnabled(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	for _, enabled := range []bool{true, false} {
+		ctx, _ := parentCtx.CacheContext()
+		params := types.DefaultParams()
+		params.RecordContractInfoChanges = enabled
+		require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+
+		// when
+		ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		got := keepers.WasmKeeper.isRecordContractInfoChangesEnabled(ctx)
+
+		// then
+		assert.Equal(t, enabled, got)
+		assert.Equal(t, storetypes.Gas(0), ctx.GasMeter().GasConsumed())
+	}
+}
+
 func TestGasConsumed(t *testing.T) {
 	specs := map[string]struct {
 		originalMeter            storetypes.GasMeter
@@ -2821,6 +3459,7 @@ func TestSetContractLabel(t *testing.T) {
 	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	k := keepers.WasmKeeper
 	example := InstantiateReflectExampleContract(t, parentCtx, keepers)
+	oldLabel := k.GetContractInfo(parentCtx, example.Contract).Label
 
 	specs := map[string]struct {
 		newLabel string
@@ -2855,6 +3494,20 @@ func TestSetContractLabel(t *testing.T) {
 			contract: RandomAccountAddress(t),
 			expErr:   true,
 		},
+		"update label - invalid label": {
+			newLabel: " new label",
+			caller:   example.CreatorAddr,
+			policy:   DefaultAuthorizationPolicy{},
+			contract: example.Contract,
+			expErr:   true,
+		},
+		"update label - empty label": {
+			newLabel: "",
+			caller:   example.CreatorAddr,
+			policy:   DefaultAuthorizationPolicy{},
+			contract: example.Contract,
+			expErr:   true,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -2869,10 +3522,11 @@ func TestSetContractLabel(t *testing.T) {
 			require.NoError(t, gotErr)
 			assert.Equal(t, spec.newLabel, k.GetContractInfo(ctx, spec.contract).Label)
 			// and event emitted
-			require.Len(t, em.Events(), 1)
+			require.Len(t, em.Events(), 2)
 			assert.Equal(t, "update_contract_label", em.Events()[0].Type)
 			exp := map[string]string{
 				"_contract_address": spec.contract.String(),
+				"old_label":         oldLabel,
 				"new_label":         spec.newLabel,
 			}
 			assert.Equal(t, exp, attrsToStringMap(em.Events()[0].Attributes))
@@ -2880,6 +3534,90 @@ func TestSetContractLabel(t *testing.T) {
 	}
 }
 
+func TestSetContractStateAccess(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	example := InstantiateReflectExampleContract(t, parentCtx, keepers)
+
+	specs := map[string]struct {
+		accessControl   bool
+		rawQueryEnabled bool
+		caller          sdk.AccAddress
+		policy          types.AuthorizationPolicy
+		contract        sdk.AccAddress
+		expErr          error
+	}{
+		"disable - default policy": {
+			accessControl: true,
+			caller:        example.CreatorAddr,
+			policy:        DefaultAuthorizationPolicy{},
+			contract:      example.Contract,
+		},
+		"enable - default policy": {
+			accessControl:   true,
+			rawQueryEnabled: true,
+			caller:          example.CreatorAddr,
+			policy:          DefaultAuthorizationPolicy{},
+			contract:        example.Contract,
+		},
+		"disable - gov policy": {
+			accessControl: true,
+			caller:        RandomAccountAddress(t),
+			policy:        GovAuthorizationPolicy{},
+			contract:      example.Contract,
+		},
+		"unauthorized": {
+			accessControl: true,
+			caller:        RandomAccountAddress(t),
+			policy:        DefaultAuthorizationPolicy{},
+			contract:      example.Contract,
+			expErr:        sdkerrors.ErrUnauthorized,
+		},
+		"unknown contract": {
+			accessControl: true,
+			caller:        example.CreatorAddr,
+			policy:        DefaultAuthorizationPolicy{},
+			contract:      RandomAccountAddress(t),
+			expErr:        sdkerrors.ErrInvalidRequest,
+		},
+		"access control disabled in params": {
+			caller:   example.CreatorAddr,
+			policy:   DefaultAuthorizationPolicy{},
+			contract: example.Contract,
+			expErr:   types.ErrStateAccessControlDisabled,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := k.GetParams(ctx)
+			params.ContractStateAccessControl = spec.accessControl
+			require.NoError(t, k.SetParams(ctx, params))
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			gotErr := k.setContractStateAccess(ctx, spec.contract, spec.caller, spec.rawQueryEnabled, spec.policy)
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				assert.False(t, k.GetContractInfo(ctx, example.Contract).RawQueryDisabled)
+				return
+			}
+			require.NoError(t, gotErr)
+			info := k.GetContractInfo(ctx, spec.contract)
+			assert.Equal(t, !spec.rawQueryEnabled, info.RawQueryDisabled)
+			assert.Equal(t, spec.rawQueryEnabled, k.IsRawQueryEnabled(ctx, *info))
+			// and event emitted
+			require.Len(t, em.Events(), 1)
+			assert.Equal(t, "set_contract_state_access", em.Events()[0].Type)
+			exp := map[string]string{
+				"_contract_address": spec.contract.String(),
+				"raw_query_enabled": strconv.FormatBool(spec.rawQueryEnabled),
+			}
+			assert.Equal(t, exp, attrsToStringMap(em.Events()[0].Attributes))
+		})
+	}
+}
+
 func attrsToStringMap(attrs []abci.EventAttribute) map[string]string {
 	r := make(map[string]string, len(attrs))
 	for _, v := range attrs {
@@ -2984,3 +3722,31 @@ func TestCheckDiscountEligibility(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedParams(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	k := keepers.WasmKeeper
+	ctx, _ := parentCtx.CacheContext()
+
+	// when loaded
+	gasBefore := ctx.GasMeter().GasConsumed()
+	cachedCtx := k.withCachedParams(ctx)
+	// then not charged
+	assert.Equal(t, gasBefore, ctx.GasMeter().GasConsumed())
+
+	// and when the stored params are changed afterwards
+	params := k.GetParams(ctx)
+	require.False(t, params.TrackContractActivity)
+	params.TrackContractActivity = true
+	require.NoError(t, k.SetParams(ctx, params))
+	// then the loaded params are kept
+	assert.False(t, k.cachedParams(cachedCtx).TrackContractActivity)
+	assert.False(t, k.cachedParams(k.withCachedParams(cachedCtx)).TrackContractActivity)
+	assert.True(t, k.cachedParams(ctx).TrackContractActivity)
+
+	// and passed down to the execution
+	_, err := keepers.ContractKeeper.Execute(cachedCtx.WithBlockHeight(100), example.Contract, example.VerifierAddr, []byte(`{"release":{}}`), nil)
+	require.NoError(t, err)
+	assert.Zero(t, k.GetContractLastActivityHeight(ctx, example.Contract))
+}
@@ -7,6 +7,10 @@ import (
 	v1 "github.com/CosmWasm/wasmd/x/wasm/migrations/v1"
 	v2 "github.com/CosmWasm/wasmd/x/wasm/migrations/v2"
 	v3 "github.com/CosmWasm/wasmd/x/wasm/migrations/v3"
+	v4 "github.com/CosmWasm/wasmd/x/wasm/migrations/v4"
+	v5 "github.com/CosmWasm/wasmd/x/wasm/migrations/v5"
+	v6 "github.com/CosmWasm/wasmd/x/wasm/migrations/v6"
+	v7 "github.com/CosmWasm/wasmd/x/wasm/migrations/v7"
 )
 
 // Migrator is a struct for handling in-place store migrations.
@@ -36,3 +40,27 @@ func (m Migrator) Migrate2to3(ctx sdk.Context) error {
 func (m Migrator) Migrate3to4(ctx sdk.Context) error {
 	return v3.NewMigrator(m.keeper, m.keeper.mustStoreCodeInfo).Migrate3to4(ctx, m.keeper.storeService, m.keeper.cdc)
 }
+
+// Migrate4to5 migrates the x/wasm module state from the consensus
+// version 4 to version 5.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	return v4.NewMigrator(m.keeper, m.keeper.setCodeInstantiationCount).Migrate4to5(ctx)
+}
+
+// Migrate5to6 migrates the x/wasm module state from the consensus
+// version 5 to version 6.
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	return v5.NewMigrator(m.keeper, m.keeper.addToCodeIDsByChecksumIndex).Migrate5to6(ctx)
+}
+
+// Migrate6to7 migrates the x/wasm module state from the consensus
+// version 6 to version 7.
+func (m Migrator) Migrate6to7(ctx sdk.Context) error {
+	return v6.NewMigrator(m.keeper, m.keeper.addToContractLabelIndex).Migrate6to7(ctx)
+}
+
+// Migrate7to8 migrates the x/wasm module state from the consensus
+// version 7 to version 8.
+func (m Migrator) Migrate7to8(ctx sdk.Context) error {
+	return v7.NewMigrator(m.keeper).Migrate7to8(ctx)
+}
@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"context"
+	"math"
+
+	"cosmossdk.io/store/prefix"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// startActivityCount returns a function that sets the message counts on success and a function that adds them,
+// together with the gas consumed on the message gas meter since the start, to the wasm activity of the current
+// block. The latter must be deferred before any other deferred work of the message so that it runs last and the gas
+// charged by that work is included. Gas is counted for top level messages only as the gas of messages dispatched by
+// contracts is part of their parent message. Counting is not charged. Nothing is counted without a transient store
+// or when no counts were set.
+func (k Keeper) startActivityCount(ctx context.Context) (func(counts types.ModuleActivity), func()) {
+	if k.transientStoreService == nil {
+		return func(types.ModuleActivity) {}, func() {}
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	depth, _ := types.CallDepth(sdkCtx)
+	start := sdkCtx.GasMeter().GasConsumed()
+	var counts *types.ModuleActivity
+	setCounts := func(c types.ModuleActivity) {
+		counts = &c
+	}
+	flush := func() {
+		if counts == nil {
+			return
+		}
+		if depth == 0 {
+			counts.GasUsed = sdkCtx.GasMeter().GasConsumed() - start
+		}
+		gasFreeCtx := sdkCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		if err := k.addBlockActivity(gasFreeCtx, *counts); err != nil {
+			moduleLogger(sdkCtx).Error("count module activity", "error", err)
+		}
+	}
+	return setCounts, flush
+}
+
+// GetBlockActivity returns the wasm activity of the current block
+func (k Keeper) GetBlockActivity(ctx context.Context) types.ModuleActivity {
+	r := types.ModuleActivity{Height: sdk.UnwrapSDKContext(ctx).BlockHeight()}
+	if k.transientStoreService == nil {
+		return r
+	}
+	bz, err := k.transientStoreService.OpenTransientStore(ctx).Get(types.BlockActivityKey)
+	if err != nil {
+		panic(err)
+	}
+	if bz != nil {
+		k.cdc.MustUnmarshal(bz, &r)
+	}
+	return r
+}
+
+func (k Keeper) addBlockActivity(ctx context.Context, counts types.ModuleActivity) error {
+	r := k.GetBlockActivity(ctx)
+	r.Stores += counts.Stores
+	r.Instantiations += counts.Instantiations
+	r.Executions += counts.Executions
+	r.Migrations += counts.Migrations
+	if r.GasUsed > math.MaxUint64-counts.GasUsed {
+		r.GasUsed = math.MaxUint64
+	} else {
+		r.GasUsed += counts.GasUsed
+	}
+	return k.transientStoreService.OpenTransientStore(ctx).Set(types.BlockActivityKey, k.cdc.MustMarshal(&r))
+}
+
+// EndBlocker emits the wasm activity of the block as typed event. The activity is persisted for a rolling window
+// of blocks when enabled in the params. The execution gas per code is added to its bucket.
+func (k Keeper) EndBlocker(ctx context.Context) error {
+	if err := k.endBlockCodeGasUsage(ctx); err != nil {
+		return err
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	if err := k.pruneModuleActivity(ctx, sdkCtx.BlockHeight()-types.ModuleActivityWindow); err != nil {
+		return err
+	}
+	activity := k.GetBlockActivity(ctx)
+	if activity.Stores+activity.Instantiations+activity.Executions+activity.Migrations == 0 {
+		return nil
+	}
+	if err := sdkCtx.EventManager().EmitTypedEvent(&activity); err != nil {
+		return err
+	}
+	if !k.GetParams(ctx).RecordModuleActivity {
+		return nil
+	}
+	return k.storeService.OpenKVStore(ctx).Set(types.GetModuleActivityKey(activity.Height), k.cdc.MustMarshal(&activity))
+}
+
+// pruneModuleActivity deletes the recorded wasm activity up to and including the given height
+func (k Keeper) pruneModuleActivity(ctx context.Context, maxHeight int64) error {
+	if maxHeight < 0 {
+		return nil
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	iter, err := store.Iterator(types.ModuleActivityPrefix, types.GetModuleActivityKey(maxHeight+1))
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	if err := iter.Close(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := store.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IterateModuleActivity iterates over the recorded wasm activity from the given block heights in ascending order.
+// The callback returns true to stop the iteration.
+func (k Keeper) IterateModuleActivity(ctx context.Context, fromHeight, toHeight int64, cb func(types.ModuleActivity) bool) {
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx)), types.ModuleActivityPrefix)
+	iter := prefixStore.Iterator(sdk.Uint64ToBigEndian(uint64(fromHeight)), sdk.Uint64ToBigEndian(uint64(toHeight)+1))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var a types.ModuleActivity
+		k.cdc.MustUnmarshal(iter.Value(), &a)
+		if cb(a) {
+			return
+		}
+	}
+}
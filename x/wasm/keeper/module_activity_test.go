@@ -0,0 +1,239 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestModuleActivityCounters(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	ctx, _ := parentCtx.CacheContext()
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	// read without gas so that the meter holds the gas of the messages only
+	gasFreeCtx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+	require.Equal(t, types.ModuleActivity{Height: ctx.BlockHeight()}, k.GetBlockActivity(gasFreeCtx))
+
+	// when multiple messages are executed within the block
+	_, err := msgServer.StoreCode(ctx, &types.MsgStoreCode{
+		Sender:       example.CreatorAddr.String(),
+		WASMByteCode: testdata.HackatomContractWasm(),
+	})
+	require.NoError(t, err)
+	_, err = msgServer.InstantiateContract(ctx, &types.MsgInstantiateContract{
+		Sender:  example.CreatorAddr.String(),
+		CodeID:  example.CodeID,
+		Label:   "other",
+		Msg:     []byte(fmt.Sprintf(`{"verifier":%q,"beneficiary":%q}`, example.VerifierAddr.String(), example.BeneficiaryAddr.String())),
+		NoAdmin: true,
+	})
+	require.NoError(t, err)
+	_, err = msgServer.MigrateContract(ctx, &types.MsgMigrateContract{
+		Sender:   example.CreatorAddr.String(),
+		Contract: example.Contract.String(),
+		CodeID:   example.CodeID,
+		Msg:      []byte(fmt.Sprintf(`{"verifier":%q}`, example.VerifierAddr.String())),
+	})
+	require.NoError(t, err)
+	_, err = msgServer.ExecuteContract(ctx, &types.MsgExecuteContract{
+		Sender:   example.VerifierAddr.String(),
+		Contract: example.Contract.String(),
+		Msg:      []byte(`{"release":{}}`),
+	})
+	require.NoError(t, err)
+
+	// then
+	exp := types.ModuleActivity{
+		Height:         ctx.BlockHeight(),
+		Stores:         1,
+		Instantiations: 1,
+		Executions:     1,
+		Migrations:     1,
+		GasUsed:        ctx.GasMeter().GasConsumed(),
+	}
+	assert.Equal(t, exp, k.GetBlockActivity(ctx))
+
+	// and failed messages are not counted
+	_, err = msgServer.ExecuteContract(ctx, &types.MsgExecuteContract{
+		Sender:   example.VerifierAddr.String(),
+		Contract: example.Contract.String(),
+		Msg:      []byte(`{"panic":{}}`),
+	})
+	require.Error(t, err)
+	assert.Equal(t, exp, k.GetBlockActivity(ctx))
+}
+
+func TestModuleActivityEndBlocker(t *testing.T) {
+	specs := map[string]struct {
+		record    bool
+		activity  types.ModuleActivity
+		expEvent  bool
+		expStored bool
+	}{
+		"recorded": {
+			record:    true,
+			activity:  types.ModuleActivity{Executions: 2, GasUsed: 100},
+			expEvent:  true,
+			expStored: true,
+		},
+		"not recorded": {
+			activity: types.ModuleActivity{Executions: 2, GasUsed: 100},
+			expEvent: true,
+		},
+		"no activity": {
+			record: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+			k := keepers.WasmKeeper
+			params := k.GetParams(ctx)
+			params.RecordModuleActivity = spec.record
+			require.NoError(t, k.SetParams(ctx, params))
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			require.NoError(t, k.addBlockActivity(ctx, spec.activity))
+
+			// when
+			require.NoError(t, k.EndBlocker(ctx))
+
+			// then
+			var events []string
+			for _, e := range em.Events() {
+				events = append(events, e.Type)
+			}
+			if spec.expEvent {
+				assert.Equal(t, []string{"cosmwasm.wasm.v1.ModuleActivity"}, events)
+			} else {
+				assert.Empty(t, events)
+			}
+			var stored []types.ModuleActivity
+			k.IterateModuleActivity(ctx, 0, ctx.BlockHeight(), func(a types.ModuleActivity) bool {
+				stored = append(stored, a)
+				return false
+			})
+			if !spec.expStored {
+				assert.Empty(t, stored)
+				return
+			}
+			exp := spec.activity
+			exp.Height = ctx.BlockHeight()
+			assert.Equal(t, []types.ModuleActivity{exp}, stored)
+		})
+	}
+}
+
+func TestModuleActivityWindowPruning(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := k.GetParams(ctx)
+	params.RecordModuleActivity = true
+	require.NoError(t, k.SetParams(ctx, params))
+
+	endBlock := func(height int64) {
+		ctx = ctx.WithBlockHeight(height)
+		require.NoError(t, k.addBlockActivity(ctx, types.ModuleActivity{Executions: 1}))
+		require.NoError(t, k.EndBlocker(ctx))
+		require.NoError(t, k.transientStoreService.OpenTransientStore(ctx).Delete(types.BlockActivityKey))
+	}
+	recordedHeights := func() []int64 {
+		var r []int64
+		k.IterateModuleActivity(ctx, 0, ctx.BlockHeight(), func(a types.ModuleActivity) bool {
+			r = append(r, a.Height)
+			return false
+		})
+		return r
+	}
+	endBlock(1)
+	endBlock(2)
+	endBlock(3)
+	endBlock(types.ModuleActivityWindow)
+	assert.Equal(t, []int64{1, 2, 3, types.ModuleActivityWindow}, recordedHeights())
+
+	// when the window moves
+	endBlock(types.ModuleActivityWindow + 2)
+
+	// then the blocks before the window are pruned
+	assert.Equal(t, []int64{3, types.ModuleActivityWindow, types.ModuleActivityWindow + 2}, recordedHeights())
+}
+
+func TestQueryModuleActivity(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	q := Querier(k)
+	store := k.storeService.OpenKVStore(ctx)
+	for _, a := range []types.ModuleActivity{
+		{Height: 5, Stores: 1, GasUsed: 10},
+		{Height: 7, Executions: 2, GasUsed: 20},
+		{Height: 9, Instantiations: 1, Migrations: 1, GasUsed: 30},
+	} {
+		require.NoError(t, store.Set(types.GetModuleActivityKey(a.Height), k.cdc.MustMarshal(&a)))
+	}
+	ctx = ctx.WithBlockHeight(10)
+
+	specs := map[string]struct {
+		src     types.QueryModuleActivityRequest
+		exp     []int64
+		expSum  types.ModuleActivity
+		expCode codes.Code
+	}{
+		"all": {
+			exp:    []int64{5, 7, 9},
+			expSum: types.ModuleActivity{Stores: 1, Instantiations: 1, Executions: 2, Migrations: 1, GasUsed: 60},
+		},
+		"range": {
+			src:    types.QueryModuleActivityRequest{FromHeight: 6, ToHeight: 7},
+			exp:    []int64{7},
+			expSum: types.ModuleActivity{Executions: 2, GasUsed: 20},
+		},
+		"from only": {
+			src:    types.QueryModuleActivityRequest{FromHeight: 8},
+			exp:    []int64{9},
+			expSum: types.ModuleActivity{Instantiations: 1, Migrations: 1, GasUsed: 30},
+		},
+		"empty range": {
+			src: types.QueryModuleActivityRequest{FromHeight: 1, ToHeight: 4},
+			exp: []int64{},
+		},
+		"from above to": {
+			src:     types.QueryModuleActivityRequest{FromHeight: 8, ToHeight: 7},
+			expCode: codes.InvalidArgument,
+		},
+		"range exceeds window": {
+			src:     types.QueryModuleActivityRequest{FromHeight: 1, ToHeight: types.ModuleActivityWindow + 1},
+			expCode: codes.InvalidArgument,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ModuleActivity(ctx, &spec.src)
+			if spec.expCode != codes.OK {
+				require.Error(t, gotErr)
+				assert.Equal(t, spec.expCode, status.Code(gotErr))
+				return
+			}
+			require.NoError(t, gotErr)
+			heights := make([]int64, 0, len(got.Blocks))
+			for _, b := range got.Blocks {
+				heights = append(heights, b.Height)
+			}
+			assert.Equal(t, spec.exp, heights)
+			assert.Equal(t, spec.expSum, got.Total)
+		})
+	}
+}
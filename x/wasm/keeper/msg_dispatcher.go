@@ -3,6 +3,7 @@ package keeper
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
@@ -74,7 +75,7 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 				moduleLogger(ctx).Info("SubMsg rethrowing panic: %#v", r)
 				panic(r)
 			}
-			ctx.GasMeter().ConsumeGas(gasLimit, "Sub-Message OutOfGas panic")
+			ctx.GasMeter().ConsumeGas(gasLimit, gasDescriptorSubMsgOOG)
 			err = errorsmod.Wrap(sdkerrors.ErrOutOfGas, "SubMsg hit gas limit")
 		}
 	}()
@@ -82,14 +83,25 @@ func (d MessageDispatcher) dispatchMsgWithGasLimit(ctx sdk.Context, contractAddr
 
 	// make sure we charge the parent what was spent
 	spent := subCtx.GasMeter().GasConsumed()
-	ctx.GasMeter().ConsumeGas(spent, "From limited Sub-Message")
+	ctx.GasMeter().ConsumeGas(spent, gasDescriptorSubMsg)
 
 	return events, data, msgResponses, err
 }
 
 // DispatchSubmessages builds a sandbox to execute these messages and returns the execution result to the contract
-// that dispatched them, both on success as well as failure
+// that dispatched them, both on success as well as failure.
+// The events of a successful submessage are emitted in dispatch order and are followed by the events of the reply.
+// Both are tagged with the submessage index in the dispatch order of the tx message and the call depth, so that
+// consumers can reconstruct the call tree. A failed submessage with reply emits an EventSubmessageFailed.
+// Errors that abort the dispatch carry the submessage index and reply id into the tx log.
 func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk.AccAddress, ibcPort string, msgs []wasmvmtypes.SubMsg) ([]byte, error) {
+	counter, ok := types.DispatchCounterFromContext(ctx)
+	if !ok {
+		counter = &types.DispatchCounter{}
+		ctx = types.WithDispatchCounter(ctx, counter)
+	}
+	callDepth, _ := types.CallDepth(ctx)
+
 	var rsp []byte
 	for _, msg := range msgs {
 		switch msg.ReplyOn {
@@ -97,6 +109,7 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		default:
 			return nil, errorsmod.Wrap(types.ErrInvalid, "replyOn value")
 		}
+		msgIndex := counter.Next()
 		// first, we build a sub-context which we can use inside the submessages
 		subCtx, commit := ctx.CacheContext()
 		em := sdk.NewEventManager()
@@ -120,11 +133,13 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 		var filteredEvents []sdk.Event
 		if err == nil {
 			commit()
-			filteredEvents = filterEvents(append(em.Events(), events...))
+			filteredEvents = tagEvents(filterEvents(append(em.Events(), events...)), msgIndex, callDepth+1)
 			ctx.EventManager().EmitEvents(filteredEvents)
 			if msg.Msg.Wasm == nil {
 				filteredEvents = []sdk.Event{}
 			} else {
+				// the contract receives the events as before without the call tree attributes
+				filteredEvents = untagEvents(filteredEvents)
 				for _, e := range filteredEvents {
 					attributes := e.Attributes
 					sort.SliceStable(attributes, func(i, j int) bool {
@@ -136,7 +151,7 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 
 		// we only callback if requested. Short-circuit here the cases we don't want to
 		if (msg.ReplyOn == wasmvmtypes.ReplySuccess || msg.ReplyOn == wasmvmtypes.ReplyNever) && err != nil {
-			return nil, err
+			return nil, submsgError{err: err, msgIndex: msgIndex, replyID: msg.ID}
 		}
 		if msg.ReplyOn == wasmvmtypes.ReplyNever || (msg.ReplyOn == wasmvmtypes.ReplyError && err == nil) {
 			continue
@@ -179,7 +194,21 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 			result = wasmvmtypes.SubMsgResult{
 				Err: redactError(err).Error(),
 			}
+			codespace, code, _ := errorsmod.ABCIInfo(err, false)
+			event, evtErr := sdk.TypedEventToEvent(&types.EventSubmessageFailed{
+				ContractAddress: contractAddr.String(),
+				MsgIndex:        msgIndex,
+				ReplyID:         msg.ID,
+				Codespace:       codespace,
+				Code:            code,
+				Error:           result.Err,
+			})
+			if evtErr != nil {
+				return nil, evtErr
+			}
+			ctx.EventManager().EmitEvents(tagEvents([]sdk.Event{event}, msgIndex, callDepth))
 		}
+		submsgErr := err
 
 		// now handle the reply, we use the parent context, and abort on error
 		reply := wasmvmtypes.Reply{
@@ -188,21 +217,65 @@ func (d MessageDispatcher) DispatchSubmessages(ctx sdk.Context, contractAddr sdk
 			Payload: msg.Payload,
 		}
 
-		// we can ignore any result returned as there is nothing to do with the data
-		// and the events are already in the ctx.EventManager()
-		rspData, err := d.keeper.reply(ctx, contractAddr, reply)
+		// the reply events are collected to be tagged with the originating submessage
+		replyEm := sdk.NewEventManager()
+		rspData, err := d.keeper.reply(ctx.WithEventManager(replyEm), contractAddr, reply)
 		switch {
 		case err != nil:
-			return nil, errorsmod.Wrap(err, "reply")
+			return nil, replyError(err, msgIndex, msg.ID, submsgErr)
 		case rspData != nil:
 			rsp = rspData
 		}
+		ctx.EventManager().EmitEvents(tagEvents(replyEm.Events(), msgIndex, callDepth))
 	}
 	return rsp, nil
 }
 
+// submsgError is the error of a failed submessage without reply on error. It adds the position of the submessage
+// in the call tree to the tx log. The breadcrumb is stripped before redaction so that the results passed to
+// contracts are not modified.
+type submsgError struct {
+	err      error
+	msgIndex uint32
+	replyID  uint64
+}
+
+func (e submsgError) Error() string {
+	return fmt.Sprintf("submessage %d (reply id %d): %s", e.msgIndex, e.replyID, e.err)
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e submsgError) Unwrap() error {
+	return e.err
+}
+
+// Cause is the same as unwrap but used by ABCIInfo
+func (e submsgError) Cause() error {
+	return e.err
+}
+
+// replyError adds the position of the submessage in the call tree to the error of the reply. When the submessage
+// failed before, its error is added, as the reply got the redacted error only.
+func replyError(err error, msgIndex uint32, replyID uint64, submsgErr error) error {
+	if submsgErr == nil {
+		return errorsmod.Wrapf(err, "reply to submessage %d (reply id %d)", msgIndex, replyID)
+	}
+	codespace, code, _ := errorsmod.ABCIInfo(submsgErr, false)
+	return errorsmod.Wrapf(err, "reply to submessage %d (reply id %d) after submessage error [codespace: %s, code: %d, log: %q]",
+		msgIndex, replyID, codespace, code, submsgErr.Error())
+}
+
 // Issue #759 - we don't return error string for worries of non-determinism
 func redactError(err error) error {
+	// The submessage breadcrumbs are for the tx log only
+	for {
+		e, ok := err.(submsgError)
+		if !ok {
+			break
+		}
+		err = e.err
+	}
+
 	// Do not redact system errors
 	// SystemErrors must be created in x/wasm and we can ensure determinism
 	if wasmvmtypes.ToSystemError(err) != nil {
@@ -236,6 +309,48 @@ func filterEvents(events []sdk.Event) []sdk.Event {
 	return res
 }
 
+// tagEvents adds the msg index and call depth attributes to all events that were not tagged by a nested dispatch before
+func tagEvents(events []sdk.Event, msgIndex, callDepth uint32) []sdk.Event {
+	res := make([]sdk.Event, len(events))
+	for i, ev := range events {
+		res[i] = ev
+		if hasEventAttribute(ev, types.AttributeKeyCallDepth) {
+			continue
+		}
+		attrs := make([]abci.EventAttribute, len(ev.Attributes), len(ev.Attributes)+2)
+		copy(attrs, ev.Attributes)
+		res[i].Attributes = append(attrs,
+			abci.EventAttribute{Key: types.AttributeKeyMsgIndex, Value: strconv.FormatUint(uint64(msgIndex), 10)},
+			abci.EventAttribute{Key: types.AttributeKeyCallDepth, Value: strconv.FormatUint(uint64(callDepth), 10)},
+		)
+	}
+	return res
+}
+
+// untagEvents returns copies of the events without the msg index and call depth attributes
+func untagEvents(events []sdk.Event) []sdk.Event {
+	res := make([]sdk.Event, len(events))
+	for i, ev := range events {
+		res[i] = ev
+		res[i].Attributes = make([]abci.EventAttribute, 0, len(ev.Attributes))
+		for _, a := range ev.Attributes {
+			if a.Key != types.AttributeKeyMsgIndex && a.Key != types.AttributeKeyCallDepth {
+				res[i].Attributes = append(res[i].Attributes, a)
+			}
+		}
+	}
+	return res
+}
+
+func hasEventAttribute(ev sdk.Event, key string) bool {
+	for _, a := range ev.Attributes {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
 func sdkEventsToWasmVMEvents(events []sdk.Event) []wasmvmtypes.Event {
 	res := make([]wasmvmtypes.Event, len(events))
 	for i, ev := range events {
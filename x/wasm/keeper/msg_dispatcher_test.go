@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
 
@@ -18,11 +20,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
 
 func TestDispatchSubmessages(t *testing.T) {
 	noReplyCalled := &mockReplyer{}
 	var anyGasLimit uint64 = 1
+	myContractAddr := RandomAccountAddress(t)
 	specs := map[string]struct {
 		msgs       []wasmvmtypes.SubMsg
 		replyer    *mockReplyer
@@ -86,6 +90,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    []byte("myReplyData"),
 			expCommits: []bool{false},
+			expEvents:  []sdk.Event{submsgFailedEvent(t, myContractAddr, 0, 0, "undefined", 1, "codespace: undefined, code: 1")},
 		},
 		"with reply events": {
 			msgs: []wasmvmtypes.SubMsg{{
@@ -106,11 +111,11 @@ func TestDispatchSubmessages(t *testing.T) {
 			expData:    []byte("myReplyData"),
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{
-				{
+				withCallTree(sdk.Event{
 					Type:       "myEvent",
 					Attributes: []abci.EventAttribute{{Key: "foo", Value: "bar"}},
-				},
-				sdk.NewEvent("wasm-reply"),
+				}, 0, 1),
+				withCallTree(sdk.NewEvent("wasm-reply"), 0, 0),
 			},
 		},
 		"with context events - released on commit": {
@@ -126,10 +131,10 @@ func TestDispatchSubmessages(t *testing.T) {
 				},
 			},
 			expCommits: []bool{true},
-			expEvents: []sdk.Event{{
+			expEvents: []sdk.Event{withCallTree(sdk.Event{
 				Type:       "myEvent",
 				Attributes: []abci.EventAttribute{{Key: "foo", Value: "bar"}},
-			}},
+			}, 0, 1)},
 		},
 		"with context events - discarded on failure": {
 			msgs: []wasmvmtypes.SubMsg{{
@@ -181,6 +186,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    []byte("myReplyData"),
 			expCommits: []bool{false},
+			expEvents:  []sdk.Event{submsgFailedEvent(t, myContractAddr, 0, 0, "sdk", 11, "codespace: sdk, code: 11")},
 		},
 		"with gas limit - within limit no error": {
 			msgs: []wasmvmtypes.SubMsg{{
@@ -241,6 +247,10 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    []byte("myReplyData:2"),
 			expCommits: []bool{false, false},
+			expEvents: []sdk.Event{
+				submsgFailedEvent(t, myContractAddr, 0, 1, "undefined", 1, "codespace: undefined, code: 1"),
+				submsgFailedEvent(t, myContractAddr, 1, 2, "undefined", 1, "codespace: undefined, code: 1"),
+			},
 		},
 		"multiple msg - last non nil reply returned": {
 			msgs: []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyError}, {ID: 2, ReplyOn: wasmvmtypes.ReplyError}},
@@ -259,6 +269,10 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    []byte("myReplyData:1"),
 			expCommits: []bool{false, false},
+			expEvents: []sdk.Event{
+				submsgFailedEvent(t, myContractAddr, 0, 1, "undefined", 1, "codespace: undefined, code: 1"),
+				submsgFailedEvent(t, myContractAddr, 1, 2, "undefined", 1, "codespace: undefined, code: 1"),
+			},
 		},
 		"multiple msg - empty reply can overwrite result": {
 			msgs: []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyError}, {ID: 2, ReplyOn: wasmvmtypes.ReplyError}},
@@ -277,6 +291,10 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    []byte{},
 			expCommits: []bool{false, false},
+			expEvents: []sdk.Event{
+				submsgFailedEvent(t, myContractAddr, 0, 1, "undefined", 1, "codespace: undefined, code: 1"),
+				submsgFailedEvent(t, myContractAddr, 1, 2, "undefined", 1, "codespace: undefined, code: 1"),
+			},
 		},
 		"message event filtered without reply": {
 			msgs: []wasmvmtypes.SubMsg{{
@@ -298,7 +316,7 @@ func TestDispatchSubmessages(t *testing.T) {
 			},
 			expData:    nil,
 			expCommits: []bool{true},
-			expEvents:  []sdk.Event{sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar"))},
+			expEvents:  []sdk.Event{withCallTree(sdk.NewEvent("execute", sdk.NewAttribute("foo", "bar")), 0, 1)},
 		},
 		"wasm reply gets proper events": {
 			// put fake wasmmsg in here to show where it comes from
@@ -343,9 +361,9 @@ func TestDispatchSubmessages(t *testing.T) {
 			expData:    []byte("subData"),
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{
-				sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "placeholder-random-addr")),
-				sdk.NewEvent("wasm", sdk.NewAttribute("random", "data")),
-				sdk.NewEvent("wasm-reply"),
+				withCallTree(sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "placeholder-random-addr")), 0, 1),
+				withCallTree(sdk.NewEvent("wasm", sdk.NewAttribute("random", "data")), 0, 1),
+				withCallTree(sdk.NewEvent("wasm-reply"), 0, 0),
 			},
 		},
 		"wasm reply gets payload": {
@@ -410,9 +428,9 @@ func TestDispatchSubmessages(t *testing.T) {
 			expData:    []byte("subData"),
 			expCommits: []bool{true},
 			expEvents: []sdk.Event{
-				sdk.NewEvent("non-deterministic"),
+				withCallTree(sdk.NewEvent("non-deterministic"), 0, 1),
 				// the event from reply is also exposed
-				sdk.NewEvent("stargate-reply"),
+				withCallTree(sdk.NewEvent("stargate-reply"), 0, 0),
 			},
 		},
 	}
@@ -420,13 +438,13 @@ func TestDispatchSubmessages(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			var mockStore wasmtesting.MockCommitMultiStore
 			em := sdk.NewEventManager()
-			ctx := sdk.Context{}.WithMultiStore(&mockStore).
+			ctx := sdk.Context{}.WithContext(context.Background()).WithMultiStore(&mockStore).
 				WithGasMeter(storetypes.NewGasMeter(100)).
 				WithEventManager(em).WithLogger(log.NewTestLogger(t))
 			d := NewMessageDispatcher(spec.msgHandler, spec.replyer)
 
 			// run the test
-			gotData, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "any_port", spec.msgs)
+			gotData, gotErr := d.DispatchSubmessages(ctx, myContractAddr, "any_port", spec.msgs)
 			if spec.expErr {
 				require.Error(t, gotErr)
 				assert.Empty(t, em.Events())
@@ -448,6 +466,194 @@ func TestDispatchSubmessages(t *testing.T) {
 	}
 }
 
+func TestDispatchSubmessagesEventOrder(t *testing.T) {
+	parent := RandomAccountAddress(t)
+	executeSubMsg := func(id uint64, contract string) wasmvmtypes.SubMsg {
+		return wasmvmtypes.SubMsg{
+			ID:      id,
+			ReplyOn: wasmvmtypes.ReplyAlways,
+			Msg:     wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{ContractAddr: contract, Msg: []byte(`{}`)}}},
+		}
+	}
+	bankSubMsg := wasmvmtypes.SubMsg{
+		ReplyOn: wasmvmtypes.ReplyNever,
+		Msg:     wasmvmtypes.CosmosMsg{Bank: &wasmvmtypes.BankMsg{Send: &wasmvmtypes.SendMsg{ToAddress: "receiver"}}},
+	}
+
+	var d *MessageDispatcher
+	msgHandler := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+			if msg.Bank != nil {
+				return []sdk.Event{sdk.NewEvent("transfer", sdk.NewAttribute("recipient", msg.Bank.Send.ToAddress))}, nil, nil, nil
+			}
+			contract := msg.Wasm.Execute.ContractAddr
+			ctx.EventManager().EmitEvent(sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", contract)))
+			ctx.EventManager().EmitEvent(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", contract), sdk.NewAttribute("action", "execute")))
+			if contract == "first" {
+				// the first contract dispatches a submessage on its own
+				nestedCtx := types.WithCallDepth(ctx, 1)
+				if _, err := d.DispatchSubmessages(nestedCtx, RandomAccountAddress(t), "", []wasmvmtypes.SubMsg{bankSubMsg}); err != nil {
+					return nil, nil, nil, err
+				}
+			}
+			return nil, nil, nil, nil
+		},
+	}
+	replyer := &mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			// the contract receives the events without the call tree attributes
+			for _, e := range reply.Result.Ok.Events {
+				for _, a := range e.Attributes {
+					if a.Key == types.AttributeKeyMsgIndex || a.Key == types.AttributeKeyCallDepth {
+						return nil, fmt.Errorf("unexpected attribute %q in event %q", a.Key, e.Type)
+					}
+				}
+			}
+			ctx.EventManager().EmitEvent(sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", contractAddress.String())))
+			ctx.EventManager().EmitEvent(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", contractAddress.String()), sdk.NewAttribute("reply_id", fmt.Sprint(reply.ID))))
+			return nil, nil
+		},
+	}
+	d = NewMessageDispatcher(msgHandler, replyer)
+
+	var mockStore wasmtesting.MockCommitMultiStore
+	em := sdk.NewEventManager()
+	ctx := sdk.Context{}.WithContext(context.Background()).WithMultiStore(&mockStore).
+		WithGasMeter(storetypes.NewInfiniteGasMeter()).
+		WithEventManager(em).WithLogger(log.NewTestLogger(t))
+	// the parent contract's own event is emitted before the submessages are dispatched
+	ctx.EventManager().EmitEvent(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", parent.String())))
+
+	// when
+	_, gotErr := d.DispatchSubmessages(ctx, parent, "", []wasmvmtypes.SubMsg{executeSubMsg(1, "first"), executeSubMsg(2, "second")})
+
+	// then
+	require.NoError(t, gotErr)
+	expEvents := sdk.Events{
+		sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", parent.String())),
+		// first submessage
+		withCallTree(sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "first")), 0, 1),
+		withCallTree(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", "first"), sdk.NewAttribute("action", "execute")), 0, 1),
+		withCallTree(sdk.NewEvent("transfer", sdk.NewAttribute("recipient", "receiver")), 1, 2),
+		withCallTree(sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", parent.String())), 0, 0),
+		withCallTree(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", parent.String()), sdk.NewAttribute("reply_id", "1")), 0, 0),
+		// second submessage
+		withCallTree(sdk.NewEvent("execute", sdk.NewAttribute("_contract_address", "second")), 2, 1),
+		withCallTree(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", "second"), sdk.NewAttribute("action", "execute")), 2, 1),
+		withCallTree(sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", parent.String())), 2, 0),
+		withCallTree(sdk.NewEvent("wasm", sdk.NewAttribute("_contract_address", parent.String()), sdk.NewAttribute("reply_id", "2")), 2, 0),
+	}
+	assert.Equal(t, expEvents, em.Events())
+}
+
+func TestDispatchSubmessagesErrorBreadcrumbs(t *testing.T) {
+	myErr := types.ErrInvalid.Wrap("my error")
+	failingHandler := &wasmtesting.MockMessageHandler{
+		DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+			return nil, nil, nil, myErr
+		},
+	}
+	failingReplyer := &mockReplyer{
+		replyFn: func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error) {
+			return nil, errors.New("reply failed")
+		},
+	}
+	var nestedDispatcher *MessageDispatcher
+	specs := map[string]struct {
+		msgs       []wasmvmtypes.SubMsg
+		replyer    *mockReplyer
+		msgHandler *wasmtesting.MockMessageHandler
+		expErr     string
+		expCode    uint32
+	}{
+		"dispatch failed": {
+			msgs:       []wasmvmtypes.SubMsg{{ID: 7, ReplyOn: wasmvmtypes.ReplyNever}},
+			replyer:    &mockReplyer{},
+			msgHandler: failingHandler,
+			expErr:     "submessage 0 (reply id 7): my error: invalid",
+			expCode:    types.ErrInvalid.ABCICode(),
+		},
+		"reply failed": {
+			msgs:    []wasmvmtypes.SubMsg{{ID: 7, ReplyOn: wasmvmtypes.ReplySuccess}},
+			replyer: failingReplyer,
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					return nil, nil, nil, nil
+				},
+			},
+			expErr:  "reply to submessage 0 (reply id 7): reply failed",
+			expCode: 1,
+		},
+		"reply failed after submessage error": {
+			msgs:       []wasmvmtypes.SubMsg{{ID: 7, ReplyOn: wasmvmtypes.ReplyError}},
+			replyer:    failingReplyer,
+			msgHandler: failingHandler,
+			expErr:     fmt.Sprintf("reply to submessage 0 (reply id 7) after submessage error [codespace: wasm, code: %d, log: \"my error: invalid\"]: reply failed", types.ErrInvalid.ABCICode()),
+			expCode:    1,
+		},
+		"nested dispatch failed": {
+			msgs:    []wasmvmtypes.SubMsg{{ID: 1, ReplyOn: wasmvmtypes.ReplyNever}},
+			replyer: &mockReplyer{},
+			msgHandler: &wasmtesting.MockMessageHandler{
+				DispatchMsgFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, contractIBCPortID string, msg wasmvmtypes.CosmosMsg) (events []sdk.Event, data [][]byte, msgResponses [][]*codectypes.Any, err error) {
+					_, err = nestedDispatcher.DispatchSubmessages(types.WithCallDepth(ctx, 1), contractAddr, "", []wasmvmtypes.SubMsg{{ID: 2, ReplyOn: wasmvmtypes.ReplySuccess}})
+					return nil, nil, nil, err
+				},
+			},
+			expErr:  "submessage 0 (reply id 1): submessage 1 (reply id 2): my error: invalid",
+			expCode: types.ErrInvalid.ABCICode(),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			nestedDispatcher = NewMessageDispatcher(failingHandler, &mockReplyer{})
+			ctx := sdk.Context{}.WithContext(context.Background()).WithMultiStore(&wasmtesting.MockCommitMultiStore{}).
+				WithGasMeter(storetypes.NewInfiniteGasMeter()).
+				WithEventManager(sdk.NewEventManager()).WithLogger(log.NewTestLogger(t))
+			d := NewMessageDispatcher(spec.msgHandler, spec.replyer)
+
+			// when
+			_, gotErr := d.DispatchSubmessages(ctx, RandomAccountAddress(t), "", spec.msgs)
+
+			// then
+			require.Error(t, gotErr)
+			assert.Equal(t, spec.expErr, gotErr.Error())
+			_, gotCode, _ := errorsmod.ABCIInfo(gotErr, false)
+			assert.Equal(t, spec.expCode, gotCode)
+		})
+	}
+}
+
+func TestRedactErrorStripsBreadcrumbs(t *testing.T) {
+	myErr := types.MarkErrorDeterministic(errors.New("my error"))
+	nested := submsgError{err: submsgError{err: myErr, msgIndex: 1, replyID: 2}, msgIndex: 0, replyID: 1}
+	assert.Equal(t, myErr, redactError(nested))
+	assert.Equal(t, fmt.Sprintf("codespace: wasm, code: %d", types.ErrInvalid.ABCICode()), redactError(submsgError{err: types.ErrInvalid.Wrap("my error")}).Error())
+}
+
+// submsgFailedEvent builds the event of a failed submessage with reply that is emitted at call depth 0
+func submsgFailedEvent(t *testing.T, contractAddr sdk.AccAddress, msgIndex int, replyID uint64, codespace string, code uint32, errMsg string) sdk.Event {
+	t.Helper()
+	e, err := sdk.TypedEventToEvent(&types.EventSubmessageFailed{
+		ContractAddress: contractAddr.String(),
+		MsgIndex:        uint32(msgIndex),
+		ReplyID:         replyID,
+		Codespace:       codespace,
+		Code:            code,
+		Error:           errMsg,
+	})
+	require.NoError(t, err)
+	return withCallTree(e, msgIndex, 0)
+}
+
+// withCallTree adds the call tree attributes that are set when events are relayed
+func withCallTree(e sdk.Event, msgIndex, callDepth int) sdk.Event {
+	return e.AppendAttributes(
+		sdk.NewAttribute(types.AttributeKeyMsgIndex, fmt.Sprint(msgIndex)),
+		sdk.NewAttribute(types.AttributeKeyCallDepth, fmt.Sprint(callDepth)),
+	)
+}
+
 type mockReplyer struct {
 	replyFn func(ctx sdk.Context, contractAddress sdk.AccAddress, reply wasmvmtypes.Reply) ([]byte, error)
 }
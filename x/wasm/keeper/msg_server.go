@@ -5,6 +5,7 @@ import (
 	"slices"
 
 	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -28,6 +29,12 @@ func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*typ
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
+
+	countActivity, flushActivity := m.keeper.startActivityCount(ctx)
+	defer flushActivity()
+	ctx, emitGasBreakdown := startGasBreakdown(ctx)
+	defer emitGasBreakdown()
+
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "sender")
@@ -39,6 +46,7 @@ func (m msgServer) StoreCode(ctx context.Context, msg *types.MsgStoreCode) (*typ
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Stores: 1})
 
 	return &types.MsgStoreCodeResponse{
 		CodeID:   codeID,
@@ -52,15 +60,18 @@ func (m msgServer) InstantiateContract(ctx context.Context, msg *types.MsgInstan
 		return nil, err
 	}
 
+	countActivity, flushActivity := m.keeper.startActivityCount(ctx)
+	defer flushActivity()
+	ctx, emitGasBreakdown := startGasBreakdown(ctx)
+	defer emitGasBreakdown()
+
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "sender")
 	}
-	var adminAddr sdk.AccAddress
-	if msg.Admin != "" {
-		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
-			return nil, errorsmod.Wrap(err, "admin")
-		}
+	adminAddr, err := m.selectAdmin(ctx, senderAddr, msg.Admin, msg.NoAdmin)
+	if err != nil {
+		return nil, err
 	}
 
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
@@ -69,6 +80,7 @@ func (m msgServer) InstantiateContract(ctx context.Context, msg *types.MsgInstan
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Instantiations: 1})
 
 	return &types.MsgInstantiateContractResponse{
 		Address: contractAddr.String(),
@@ -82,15 +94,18 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 		return nil, err
 	}
 
+	countActivity, flushActivity := m.keeper.startActivityCount(ctx)
+	defer flushActivity()
+	ctx, emitGasBreakdown := startGasBreakdown(ctx)
+	defer emitGasBreakdown()
+
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "sender")
 	}
-	var adminAddr sdk.AccAddress
-	if msg.Admin != "" {
-		if adminAddr, err = sdk.AccAddressFromBech32(msg.Admin); err != nil {
-			return nil, errorsmod.Wrap(err, "admin")
-		}
+	adminAddr, err := m.selectAdmin(ctx, senderAddr, msg.Admin, msg.NoAdmin)
+	if err != nil {
+		return nil, err
 	}
 
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
@@ -101,6 +116,7 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Instantiations: 1})
 
 	return &types.MsgInstantiateContract2Response{
 		Address: contractAddr.String(),
@@ -108,11 +124,36 @@ func (m msgServer) InstantiateContract2(ctx context.Context, msg *types.MsgInsta
 	}, nil
 }
 
+// selectAdmin returns the contract admin. An empty admin is replaced by the sender when the chain default admin
+// policy is creator, unless no admin is requested explicitly. The policy is read without gas.
+func (m msgServer) selectAdmin(ctx context.Context, sender sdk.AccAddress, admin string, noAdmin bool) (sdk.AccAddress, error) {
+	if admin != "" {
+		adminAddr, err := sdk.AccAddressFromBech32(admin)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "admin")
+		}
+		return adminAddr, nil
+	}
+	if noAdmin {
+		return nil, nil
+	}
+	gasFreeCtx := sdk.UnwrapSDKContext(ctx).WithGasMeter(storetypes.NewInfiniteGasMeter())
+	if m.keeper.GetParams(gasFreeCtx).DefaultAdminPolicy == types.DefaultAdminPolicyCreator {
+		return sender, nil
+	}
+	return nil, nil
+}
+
 func (m msgServer) ExecuteContract(ctx context.Context, msg *types.MsgExecuteContract) (*types.MsgExecuteContractResponse, error) {
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
 	}
 
+	countActivity, flushActivity := m.keeper.startActivityCount(ctx)
+	defer flushActivity()
+	ctx, emitGasBreakdown := startGasBreakdown(ctx)
+	defer emitGasBreakdown()
+
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "sender")
@@ -126,6 +167,7 @@ func (m msgServer) ExecuteContract(ctx context.Context, msg *types.MsgExecuteCon
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Executions: 1})
 
 	return &types.MsgExecuteContractResponse{
 		Data: data,
@@ -137,6 +179,11 @@ func (m msgServer) MigrateContract(ctx context.Context, msg *types.MsgMigrateCon
 		return nil, err
 	}
 
+	countActivity, flushActivity := m.keeper.startActivityCount(ctx)
+	defer flushActivity()
+	ctx, emitGasBreakdown := startGasBreakdown(ctx)
+	defer emitGasBreakdown()
+
 	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "sender")
@@ -152,6 +199,7 @@ func (m msgServer) MigrateContract(ctx context.Context, msg *types.MsgMigrateCon
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Migrations: 1})
 
 	return &types.MsgMigrateContractResponse{
 		Data: data,
@@ -219,6 +267,13 @@ func (m msgServer) UpdateInstantiateConfig(ctx context.Context, msg *types.MsgUp
 	}
 	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
 
+	if msg.ResetToDefault {
+		if err := m.keeper.resetAccessConfig(ctx, msg.CodeID, senderAddr, policy); err != nil {
+			return nil, err
+		}
+		return &types.MsgUpdateInstantiateConfigResponse{}, nil
+	}
+
 	if err := m.keeper.setAccessConfig(ctx, msg.CodeID, senderAddr, *msg.NewInstantiatePermission, policy); err != nil {
 		return nil, err
 	}
@@ -236,6 +291,13 @@ func (m msgServer) UpdateParams(ctx context.Context, req *types.MsgUpdateParams)
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
 	}
 
+	if req.Params.AllowRawStateWrites != m.keeper.GetParams(ctx).AllowRawStateWrites {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "allow raw state writes can only be set at genesis")
+	}
+	if req.Params.IndexContractLabels != m.keeper.GetParams(ctx).IndexContractLabels {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "index contract labels can only be set at genesis or by migration")
+	}
+
 	if err := m.keeper.SetParams(ctx, req.Params); err != nil {
 		return nil, err
 	}
@@ -254,7 +316,16 @@ func (m msgServer) PinCodes(ctx context.Context, req *types.MsgPinCodes) (*types
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
 	}
 
+	// the last stored code id is resolved within a tx only, proposals are executed without tx bytes
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	inTx := len(sdkCtx.TxBytes()) != 0
 	for _, codeID := range req.CodeIDs {
+		if codeID == types.LastStoredCodeID && inTx {
+			var err error
+			if codeID, err = m.keeper.lastStoredCode(sdkCtx); err != nil {
+				return nil, err
+			}
+		}
 		if err := m.keeper.pinCode(ctx, codeID); err != nil {
 			return nil, err
 		}
@@ -293,6 +364,9 @@ func (m msgServer) SudoContract(ctx context.Context, req *types.MsgSudoContract)
 		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
 	}
 
+	ctx, emitGasBreakdown := startGasBreakdown(ctx)
+	defer emitGasBreakdown()
+
 	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "contract")
@@ -324,10 +398,15 @@ func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, req *types
 		}
 	}
 
+	countActivity, flushActivity := m.keeper.startActivityCount(goCtx)
+	defer flushActivity()
+	goCtx, emitGasBreakdown := startGasBreakdown(goCtx)
+	defer emitGasBreakdown()
+
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
 
-	codeID, _, err := m.keeper.create(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, policy)
+	codeID, _, err := m.keeper.createWithSource(ctx, authorityAddr, req.WASMByteCode, req.InstantiatePermission, policy, req.Source, req.Builder)
 	if err != nil {
 		return nil, err
 	}
@@ -336,6 +415,7 @@ func (m msgServer) StoreAndInstantiateContract(goCtx context.Context, req *types
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Stores: 1, Instantiations: 1})
 
 	return &types.MsgStoreAndInstantiateContractResponse{
 		Address: contractAddr.String(),
@@ -409,6 +489,111 @@ func (m msgServer) RemoveCodeUploadParamsAddresses(goCtx context.Context, req *t
 	return &types.MsgRemoveCodeUploadParamsAddressesResponse{}, nil
 }
 
+// SetFeelessExecutions replaces the allow-list of contract executions that can be sent without fees
+func (m msgServer) SetFeelessExecutions(goCtx context.Context, req *types.MsgSetFeelessExecutions) (*types.MsgSetFeelessExecutionsResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := m.keeper.GetParams(ctx)
+	params.FeelessExecutions = req.FeelessExecutions
+	if err := m.keeper.SetParams(ctx, params); err != nil {
+		return nil, err
+	}
+	return &types.MsgSetFeelessExecutionsResponse{}, nil
+}
+
+// SetContractGasBudgets replaces the per block execution gas budgets of contracts
+func (m msgServer) SetContractGasBudgets(goCtx context.Context, req *types.MsgSetContractGasBudgets) (*types.MsgSetContractGasBudgetsResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	params := m.keeper.GetParams(ctx)
+	params.ContractGasBudgets = req.Budgets
+	if err := m.keeper.SetParams(ctx, params); err != nil {
+		return nil, err
+	}
+	return &types.MsgSetContractGasBudgetsResponse{}, nil
+}
+
+// FreezeCodeByChecksum sets the instantiate config of all code ids with the checksum to nobody
+func (m msgServer) FreezeCodeByChecksum(goCtx context.Context, req *types.MsgFreezeCodeByChecksum) (*types.MsgFreezeCodeByChecksumResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "authority")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	codeIDs := m.keeper.GetCodeIDsByChecksum(ctx, req.Checksum)
+	if len(codeIDs) == 0 {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "checksum %X", req.Checksum)
+	}
+	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
+	for _, codeID := range codeIDs {
+		if err := m.keeper.setAccessConfig(ctx, codeID, authorityAddr, types.AllowNobody, policy); err != nil {
+			return nil, errorsmod.Wrapf(err, "code id %d", codeID)
+		}
+	}
+	return &types.MsgFreezeCodeByChecksumResponse{CodeIDs: codeIDs}, nil
+}
+
+// DeprecateCode marks a code id so that it can not be instantiated or used as migration target anymore
+func (m msgServer) DeprecateCode(goCtx context.Context, req *types.MsgDeprecateCode) (*types.MsgDeprecateCodeResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if err := m.keeper.deprecateCode(ctx, req.CodeID); err != nil {
+		return nil, err
+	}
+	return &types.MsgDeprecateCodeResponse{}, nil
+}
+
+// PruneContractHistory deletes old code history entries of a contract
+func (m msgServer) PruneContractHistory(goCtx context.Context, req *types.MsgPruneContractHistory) (*types.MsgPruneContractHistoryResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	pruned, err := m.keeper.pruneContractHistory(ctx, contractAddr, req.KeepLastN)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgPruneContractHistoryResponse{Pruned: pruned}, nil
+}
+
 func (m msgServer) selectAuthorizationPolicy(ctx context.Context, actor string) types.AuthorizationPolicy {
 	if actor == m.keeper.GetAuthority() {
 		return newGovAuthorizationPolicy(m.keeper.propagateGovAuthorization)
@@ -430,6 +615,11 @@ func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.Msg
 		return nil, err
 	}
 
+	countActivity, flushActivity := m.keeper.startActivityCount(goCtx)
+	defer flushActivity()
+	goCtx, emitGasBreakdown := startGasBreakdown(goCtx)
+	defer emitGasBreakdown()
+
 	ctx := sdk.UnwrapSDKContext(goCtx)
 	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
 
@@ -447,6 +637,7 @@ func (m msgServer) StoreAndMigrateContract(goCtx context.Context, req *types.Msg
 	if err != nil {
 		return nil, err
 	}
+	countActivity(types.ModuleActivity{Stores: 1, Migrations: 1})
 
 	return &types.MsgStoreAndMigrateContractResponse{
 		CodeID:   codeID,
@@ -477,3 +668,100 @@ func (m msgServer) UpdateContractLabel(ctx context.Context, msg *types.MsgUpdate
 
 	return &types.MsgUpdateContractLabelResponse{}, nil
 }
+
+// SetContractStateAccess enables or disables the raw state queries of a contract
+func (m msgServer) SetContractStateAccess(ctx context.Context, msg *types.MsgSetContractStateAccess) (*types.MsgSetContractStateAccessResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "sender")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(msg.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, msg.Sender)
+
+	if err := m.keeper.setContractStateAccess(ctx, contractAddr, senderAddr, msg.RawQueryEnabled, policy); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractStateAccessResponse{}, nil
+}
+
+// SetContractState writes raw key/value pairs to the store of a contract.
+func (m msgServer) SetContractState(ctx context.Context, req *types.MsgSetContractState) (*types.MsgSetContractStateResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Contract)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "contract")
+	}
+
+	if err := m.keeper.setContractState(ctx, contractAddr, req.Models); err != nil {
+		return nil, err
+	}
+
+	return &types.MsgSetContractStateResponse{}, nil
+}
+
+// ForceMigrateWithoutAdminCheck migrates a list of contracts to a new code id without checking the contract admins
+func (m msgServer) ForceMigrateWithoutAdminCheck(ctx context.Context, req *types.MsgForceMigrateWithoutAdminCheck) (*types.MsgForceMigrateWithoutAdminCheckResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	authority := m.keeper.GetAuthority()
+	if authority != req.Authority {
+		return nil, errorsmod.Wrapf(types.ErrInvalid, "invalid authority; expected %s, got %s", authority, req.Authority)
+	}
+	authorityAddr, err := sdk.AccAddressFromBech32(req.Authority)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "authority")
+	}
+	contracts := make([]sdk.AccAddress, len(req.Contracts))
+	for i, c := range req.Contracts {
+		if contracts[i], err = sdk.AccAddressFromBech32(c); err != nil {
+			return nil, errorsmod.Wrap(err, "contract")
+		}
+	}
+
+	policy := m.selectAuthorizationPolicy(ctx, req.Authority)
+
+	countActivity, flushActivity := m.keeper.startActivityCount(ctx)
+	defer flushActivity()
+	results, err := m.keeper.forceMigrateContracts(ctx, contracts, authorityAddr, req.CodeID, req.Msg, req.Atomic, policy)
+	if err != nil {
+		return nil, err
+	}
+	var migrated uint64
+	for _, r := range results {
+		if r.Success {
+			migrated++
+		}
+	}
+	countActivity(types.ModuleActivity{Migrations: migrated})
+	return &types.MsgForceMigrateWithoutAdminCheckResponse{Results: results}, nil
+}
+
+// ExecuteContractCompat executes the contract like ExecuteContract with the funds parsed from their string form
+func (m msgServer) ExecuteContractCompat(ctx context.Context, msg *types.MsgExecuteContractCompat) (*types.MsgExecuteContractCompatResponse, error) {
+	execMsg, err := msg.ToExecuteContract()
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := m.ExecuteContract(ctx, &execMsg)
+	if err != nil {
+		return nil, err
+	}
+	return &types.MsgExecuteContractCompatResponse{Data: rsp.Data}, nil
+}
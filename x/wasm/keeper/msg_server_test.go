@@ -6,6 +6,7 @@ import (
 	tmproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/log"
 	"cosmossdk.io/store"
@@ -57,3 +58,60 @@ func TestSelectAuthorizationPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestPinLastStoredCode(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	msgServer := NewMsgServerImpl(k)
+	pinMsg := &types.MsgPinCodes{Authority: k.GetAuthority(), CodeIDs: []uint64{types.LastStoredCodeID}}
+
+	specs := map[string]struct {
+		storeTx []byte
+		pinTx   []byte
+		expErr  error
+	}{
+		"code stored in the same tx": {
+			storeTx: []byte("myTx"),
+			pinTx:   []byte("myTx"),
+		},
+		"code stored in another tx": {
+			storeTx: []byte("myTx"),
+			pinTx:   []byte("otherTx"),
+			expErr:  types.ErrNotFound,
+		},
+		"no code stored": {
+			pinTx:  []byte("myTx"),
+			expErr: types.ErrNotFound,
+		},
+		"code stored by proposal": {
+			storeTx: []byte{},
+			pinTx:   []byte{},
+			expErr:  types.ErrNoSuchCodeFn(types.LastStoredCodeID),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			var codeID uint64
+			if spec.storeTx != nil {
+				rsp, err := msgServer.StoreCode(ctx.WithTxBytes(spec.storeTx), &types.MsgStoreCode{Sender: k.GetAuthority(), WASMByteCode: hackatomWasm})
+				require.NoError(t, err)
+				codeID = rsp.CodeID
+			}
+
+			// when
+			_, gotErr := msgServer.PinCodes(ctx.WithTxBytes(spec.pinTx), pinMsg)
+
+			// then
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				if codeID != 0 {
+					assert.False(t, k.IsPinnedCode(ctx, codeID))
+				}
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.True(t, k.IsPinnedCode(ctx, codeID))
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	corestoretypes "cosmossdk.io/core/store"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
@@ -164,6 +166,42 @@ func WithMaxCallDepth(m uint32) Option {
 	})
 }
 
+// WithMaxCodeInfosBatchSize overwrites the default limit for the number of code ids in a CodeInfos query
+func WithMaxCodeInfosBatchSize(m int) Option {
+	return optsFn(func(k *Keeper) {
+		k.maxCodeInfosBatchSize = m
+	})
+}
+
+// WithAuthzKeeper sets the authz keeper to query the wasm grants of an account
+func WithAuthzKeeper(x types.AuthzKeeper) Option {
+	return optsFn(func(k *Keeper) {
+		k.authzKeeper = x
+	})
+}
+
+// WithFeeGrantKeeper sets the feegrant keeper to query the fee allowances of an account
+func WithFeeGrantKeeper(x types.FeeGrantKeeper) Option {
+	return optsFn(func(k *Keeper) {
+		k.feeGrantKeeper = x
+	})
+}
+
+// WithEventAttributePolicy overwrites the default rules for attributes emitted by contracts
+func WithEventAttributePolicy(p EventAttributePolicy) Option {
+	return optsFn(func(k *Keeper) {
+		k.eventAttributePolicy = p
+	})
+}
+
+// WithTransientStoreService sets the transient store to track the code stored last in a tx.
+// This is required to pin the code of a store code msg with LastStoredCodeID in the same tx.
+func WithTransientStoreService(s corestoretypes.TransientStoreService) Option {
+	return optsFn(func(k *Keeper) {
+		k.transientStoreService = s
+	})
+}
+
 // WithAcceptedAccountTypesOnContractInstantiation sets the accepted account types. Account types of this list won't be overwritten or cause a failure
 // when they exist for an address on contract instantiation.
 //
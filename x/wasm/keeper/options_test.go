@@ -122,6 +122,24 @@ func TestConstructorOptions(t *testing.T) {
 				assert.Equal(t, uint32(1), k.maxCallDepth)
 			},
 		},
+		"max code infos batch size": {
+			srcOpt: WithMaxCodeInfosBatchSize(1),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, 1, k.maxCodeInfosBatchSize)
+			},
+		},
+		"event attribute policy": {
+			srcOpt: WithEventAttributePolicy(EventAttributePolicy{MaxAttributes: 1, ViolationMode: EventAttributeViolationTruncate}),
+			verify: func(t *testing.T, k Keeper) {
+				assert.Equal(t, EventAttributePolicy{MaxAttributes: 1, ViolationMode: EventAttributeViolationTruncate}, k.eventAttributePolicy)
+			},
+		},
+		"transient store service": {
+			srcOpt: WithTransientStoreService(runtime.NewTransientStoreService(storetypes.NewTransientStoreKey(types.TStoreKey))),
+			verify: func(t *testing.T, k Keeper) {
+				assert.NotNil(t, k.transientStoreService)
+			},
+		},
 		"accepted account types": {
 			srcOpt: WithAcceptedAccountTypesOnContractInstantiation(&authtypes.BaseAccount{}, &vestingtypes.ContinuousVestingAccount{}),
 			verify: func(t *testing.T, k Keeper) {
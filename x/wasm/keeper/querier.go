@@ -1,13 +1,19 @@
 package keeper
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"runtime/debug"
+	"sort"
+	"strings"
 
+	"github.com/cosmos/gogoproto/proto"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
@@ -17,10 +23,12 @@ import (
 	storetypes "cosmossdk.io/store/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/runtime"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -28,6 +36,22 @@ import (
 // DefaultGasCostBuildAddress is the SDK gas cost to build a contract address
 const DefaultGasCostBuildAddress = 10
 
+// DefaultMaxCodeInfosBatchSize is the max number of code ids in a single CodeInfos query
+const DefaultMaxCodeInfosBatchSize = 100
+
+// wasmMsgTypeURLPrefix is the type url prefix of the wasm messages
+const wasmMsgTypeURLPrefix = "/cosmwasm.wasm.v1."
+
+// footprintMaxStateEntries is the max number of state entries counted for a contract footprint
+const footprintMaxStateEntries = 100_000
+
+const (
+	// defaultTopGasConsumersLimit is the number of code ids returned by the TopGasConsumers query when not set
+	defaultTopGasConsumersLimit = 20
+	// maxTopGasConsumersLimit is the max number of code ids returned by the TopGasConsumers query
+	maxTopGasConsumersLimit = 100
+)
+
 var _ types.QueryServer = &GrpcQuerier{}
 
 type GrpcQuerier struct {
@@ -35,11 +59,17 @@ type GrpcQuerier struct {
 	storeService  corestoretypes.KVStoreService
 	keeper        types.ViewKeeper
 	queryGasLimit storetypes.Gas
+	// maxCodeInfosBatchSize is the max number of code ids in a CodeInfos query
+	maxCodeInfosBatchSize int
+	// authzKeeper is required for the DelegatedCapabilities query
+	authzKeeper types.AuthzKeeper
+	// feeGrantKeeper is optional for the DelegatedCapabilities query
+	feeGrantKeeper types.FeeGrantKeeper
 }
 
 // NewGrpcQuerier constructor
 func NewGrpcQuerier(cdc codec.Codec, storeService corestoretypes.KVStoreService, keeper types.ViewKeeper, queryGasLimit storetypes.Gas) *GrpcQuerier {
-	return &GrpcQuerier{cdc: cdc, storeService: storeService, keeper: keeper, queryGasLimit: queryGasLimit}
+	return &GrpcQuerier{cdc: cdc, storeService: storeService, keeper: keeper, queryGasLimit: queryGasLimit, maxCodeInfosBatchSize: DefaultMaxCodeInfosBatchSize}
 }
 
 func (q GrpcQuerier) ContractInfo(c context.Context, req *types.QueryContractInfoRequest) (*types.QueryContractInfoResponse, error) {
@@ -61,6 +91,24 @@ func (q GrpcQuerier) ContractInfo(c context.Context, req *types.QueryContractInf
 	return rsp, nil
 }
 
+func (q GrpcQuerier) ContractInfoAt(c context.Context, req *types.QueryContractInfoAtRequest) (*types.QueryContractInfoAtResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	info, err := contractInfoAt(sdk.UnwrapSDKContext(c), contractAddr, req.Height, q.keeper)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractInfoAtResponse{
+		Address:      contractAddr.String(),
+		ContractInfo: *info,
+	}, nil
+}
+
 func (q GrpcQuerier) ContractHistory(c context.Context, req *types.QueryContractHistoryRequest) (*types.QueryContractHistoryResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -78,12 +126,29 @@ func (q GrpcQuerier) ContractHistory(c context.Context, req *types.QueryContract
 	r := make([]types.ContractCodeHistoryEntry, 0)
 
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractCodeHistoryElementPrefix(contractAddr))
+	if req.SinceHeight != 0 && !paginationParams.Reverse {
+		seekKey, err := q.seekContractHistory(prefixStore, req.SinceHeight)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Compare(seekKey, paginationParams.Key) > 0 {
+			paginationParams.Key = seekKey
+		}
+	}
+	filtered := req.Operation != types.ContractCodeHistoryOperationTypeUnspecified || req.SinceHeight != 0
 	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		if !accumulate && !filtered {
+			return true, nil
+		}
+		var e types.ContractCodeHistoryEntry
+		if err := q.cdc.Unmarshal(value, &e); err != nil {
+			return false, err
+		}
+		if (req.Operation != types.ContractCodeHistoryOperationTypeUnspecified && e.Operation != req.Operation) ||
+			(req.SinceHeight != 0 && (e.Updated == nil || e.Updated.BlockHeight < req.SinceHeight)) {
+			return false, nil
+		}
 		if accumulate {
-			var e types.ContractCodeHistoryEntry
-			if err := q.cdc.Unmarshal(value, &e); err != nil {
-				return false, err
-			}
 			r = append(r, e)
 		}
 		return true, nil
@@ -97,7 +162,47 @@ func (q GrpcQuerier) ContractHistory(c context.Context, req *types.QueryContract
 	}, nil
 }
 
-// ContractsByCode lists all smart contracts for a code id
+// seekContractHistory returns the key of the first history entry updated at or after the given height.
+// Entries are appended with increasing positions and therefore ordered by height, so that a binary
+// search can be used instead of a scan from the start. Nil is returned when the positions have gaps.
+func (q GrpcQuerier) seekContractHistory(historyStore prefix.Store, height uint64) ([]byte, error) {
+	var last uint64
+	iter := historyStore.ReverseIterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+		if len(iter.Key()) == 8 {
+			last = sdk.BigEndianToUint64(iter.Key())
+			break
+		}
+	}
+	iter.Close()
+
+	var seekErr error
+	var gap bool
+	n := sort.Search(int(last), func(i int) bool {
+		bz := historyStore.Get(sdk.Uint64ToBigEndian(uint64(i) + 1))
+		if bz == nil {
+			gap = true
+			return true
+		}
+		var e types.ContractCodeHistoryEntry
+		if err := q.cdc.Unmarshal(bz, &e); err != nil {
+			seekErr = err
+			return true
+		}
+		return e.Updated != nil && e.Updated.BlockHeight >= height
+	})
+	switch {
+	case seekErr != nil:
+		return nil, seekErr
+	case gap:
+		return nil, nil
+	}
+	return sdk.Uint64ToBigEndian(uint64(n) + 1), nil
+}
+
+// ContractsByCode lists all smart contracts for a code id. The inactive since height filter requires the
+// TrackContractActivity param. Contracts without recorded activity are considered inactive, which includes
+// contracts that were called before the tracking was enabled only.
 func (q GrpcQuerier) ContractsByCode(c context.Context, req *types.QueryContractsByCodeRequest) (*types.QueryContractsByCodeResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -110,13 +215,53 @@ func (q GrpcQuerier) ContractsByCode(c context.Context, req *types.QueryContract
 		return nil, err
 	}
 
+	var admin, creator string
+	if req.Admin != "" {
+		addr, err := sdk.AccAddressFromBech32(req.Admin)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "admin")
+		}
+		admin = addr.String()
+	}
+	if req.Creator != "" {
+		addr, err := sdk.AccAddressFromBech32(req.Creator)
+		if err != nil {
+			return nil, errorsmod.Wrap(err, "creator")
+		}
+		creator = addr.String()
+	}
+
 	ctx := sdk.UnwrapSDKContext(c)
-	r := make([]string, 0)
+	if req.InactiveSinceHeight != 0 && !q.keeper.GetParams(ctx).TrackContractActivity {
+		return nil, status.Error(codes.FailedPrecondition, "contract activity tracking is disabled")
+	}
+	var codeInfo *types.QueryCodeInfoResponse
+	if req.IncludeCodeInfo {
+		result := queryCodeInfos(ctx, []uint64{req.CodeId}, q.keeper)[0]
+		if result.NotFound {
+			return nil, types.ErrNoSuchCodeFn(req.CodeId).Wrapf("code id %d", req.CodeId)
+		}
+		codeInfo = result.CodeInfo
+	}
 
+	r := make([]string, 0)
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractByCodeIDSecondaryIndexPrefix(req.CodeId))
 	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+		// contract info is loaded only when filters are set
+		if admin != "" || creator != "" {
+			info := q.keeper.GetContractInfo(ctx, contractAddr)
+			if info == nil {
+				return false, types.ErrNoSuchContractFn(contractAddr.String()).Wrapf("address %s", contractAddr.String())
+			}
+			if (admin != "" && info.Admin != admin) || (creator != "" && info.Creator != creator) {
+				return false, nil
+			}
+		}
+		if req.InactiveSinceHeight != 0 && q.keeper.GetContractLastActivityHeight(ctx, contractAddr) >= req.InactiveSinceHeight {
+			return false, nil
+		}
 		if accumulate {
-			var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
 			r = append(r, contractAddr.String())
 		}
 		return true, nil
@@ -127,6 +272,7 @@ func (q GrpcQuerier) ContractsByCode(c context.Context, req *types.QueryContract
 	return &types.QueryContractsByCodeResponse{
 		Contracts:  r,
 		Pagination: pageRes,
+		CodeInfo:   codeInfo,
 	}, nil
 }
 
@@ -145,9 +291,8 @@ func (q GrpcQuerier) AllContractState(c context.Context, req *types.QueryAllCont
 	}
 
 	ctx := sdk.UnwrapSDKContext(c)
-	if !q.keeper.HasContractInfo(ctx, contractAddr) {
-		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
-			Wrapf("address %s", contractAddr.String())
+	if err := q.checkRawQueryAccess(ctx, contractAddr); err != nil {
+		return nil, err
 	}
 
 	r := make([]types.Model, 0)
@@ -170,6 +315,44 @@ func (q GrpcQuerier) AllContractState(c context.Context, req *types.QueryAllCont
 	}, nil
 }
 
+func (q GrpcQuerier) ContractStateKeys(c context.Context, req *types.QueryContractStateKeysRequest) (*types.QueryContractStateKeysResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	if paginationParams.Key != nil && !bytes.HasPrefix(paginationParams.Key, req.Prefix) {
+		return nil, status.Error(codes.InvalidArgument, "pagination key does not match prefix")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	if err := q.checkRawQueryAccess(ctx, contractAddr); err != nil {
+		return nil, err
+	}
+
+	r := make([][]byte, 0)
+	var nextKey []byte
+	q.keeper.IterateContractStateKeys(ctx, contractAddr, req.Prefix, paginationParams.Key, paginationParams.Reverse, func(key []byte) bool {
+		if uint64(len(r)) == paginationParams.Limit {
+			nextKey = bytes.Clone(key)
+			return true
+		}
+		r = append(r, bytes.Clone(key))
+		return false
+	})
+	return &types.QueryContractStateKeysResponse{
+		Keys:       r,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+	}, nil
+}
+
 func (q GrpcQuerier) RawContractState(c context.Context, req *types.QueryRawContractStateRequest) (*types.QueryRawContractStateResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -181,14 +364,44 @@ func (q GrpcQuerier) RawContractState(c context.Context, req *types.QueryRawCont
 		return nil, err
 	}
 
-	if !q.keeper.HasContractInfo(ctx, contractAddr) {
-		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
-			Wrapf("address %s", contractAddr.String())
+	if err := q.checkRawQueryAccess(ctx, contractAddr); err != nil {
+		return nil, err
 	}
 	rsp := q.keeper.QueryRaw(ctx, contractAddr, req.QueryData)
 	return &types.QueryRawContractStateResponse{Data: rsp}, nil
 }
 
+// checkRawQueryAccess returns an error when the contract does not exist or its raw state queries are disabled
+func (q GrpcQuerier) checkRawQueryAccess(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	info := q.keeper.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	if !q.keeper.IsRawQueryEnabled(ctx, *info) {
+		return status.Errorf(codes.PermissionDenied, "raw state queries disabled for contract %s", contractAddr.String())
+	}
+	return nil
+}
+
+// ContractStateAccess returns whether the raw state of the contract can be queried
+func (q GrpcQuerier) ContractStateAccess(c context.Context, req *types.QueryContractStateAccessRequest) (*types.QueryContractStateAccessResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	info := q.keeper.GetContractInfo(ctx, contractAddr)
+	if info == nil {
+		return nil, types.ErrNoSuchContractFn(contractAddr.String()).
+			Wrapf("address %s", contractAddr.String())
+	}
+	return &types.QueryContractStateAccessResponse{RawQueryEnabled: q.keeper.IsRawQueryEnabled(ctx, *info)}, nil
+}
+
 func (q GrpcQuerier) SmartContractState(c context.Context, req *types.QuerySmartContractStateRequest) (rsp *types.QuerySmartContractStateResponse, err error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -270,16 +483,22 @@ func (q GrpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*ty
 	r := make([]types.CodeInfoResponse, 0)
 	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.CodeKeyPrefix)
 	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, value []byte, accumulate bool) (bool, error) {
+		var c types.CodeInfo
+		if err := q.cdc.Unmarshal(value, &c); err != nil {
+			return false, err
+		}
+		if req.ExcludeDeprecated && c.Deprecated {
+			return false, nil
+		}
 		if accumulate {
-			var c types.CodeInfo
-			if err := q.cdc.Unmarshal(value, &c); err != nil {
-				return false, err
-			}
+			codeID := binary.BigEndian.Uint64(key)
 			r = append(r, types.CodeInfoResponse{
-				CodeID:                binary.BigEndian.Uint64(key),
+				CodeID:                codeID,
 				Creator:               c.Creator,
 				DataHash:              c.CodeHash,
 				InstantiatePermission: c.InstantiateConfig,
+				InstantiationCount:    q.keeper.GetCodeInstantiationCount(ctx, codeID),
+				Deprecated:            c.Deprecated,
 			})
 		}
 		return true, nil
@@ -306,9 +525,51 @@ func (q GrpcQuerier) CodeInfo(c context.Context, req *types.QueryCodeInfoRequest
 		Creator:               info.Creator,
 		Checksum:              info.DataHash,
 		InstantiatePermission: info.InstantiatePermission,
+		InstantiationCount:    info.InstantiationCount,
+		Provenance:            q.keeper.GetCodeProvenance(c, req.CodeId),
+		Deprecated:            info.Deprecated,
 	}, nil
 }
 
+// CodeInfos returns the code infos in the order of the requested code ids. Unknown code ids are marked as not found.
+func (q GrpcQuerier) CodeInfos(c context.Context, req *types.QueryCodeInfosRequest) (*types.QueryCodeInfosResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if len(req.CodeIDs) == 0 {
+		return nil, errorsmod.Wrap(types.ErrEmpty, "code ids")
+	}
+	if len(req.CodeIDs) > q.maxCodeInfosBatchSize {
+		return nil, errorsmod.Wrapf(types.ErrLimit, "%d code ids, max %d", len(req.CodeIDs), q.maxCodeInfosBatchSize)
+	}
+	return &types.QueryCodeInfosResponse{
+		Results: queryCodeInfos(sdk.UnwrapSDKContext(c), req.CodeIDs, q.keeper),
+	}, nil
+}
+
+// queryCodeInfos returns a result for each code id with the code info or the not found marker
+func queryCodeInfos(ctx sdk.Context, codeIDs []uint64, keeper types.ViewKeeper) []types.CodeInfosResult {
+	r := make([]types.CodeInfosResult, len(codeIDs))
+	for i, codeID := range codeIDs {
+		r[i].CodeID = codeID
+		info := queryCodeInfo(ctx, codeID, keeper)
+		if info == nil {
+			r[i].NotFound = true
+			continue
+		}
+		r[i].CodeInfo = &types.QueryCodeInfoResponse{
+			CodeID:                info.CodeID,
+			Creator:               info.Creator,
+			Checksum:              info.DataHash,
+			InstantiatePermission: info.InstantiatePermission,
+			InstantiationCount:    info.InstantiationCount,
+			Provenance:            keeper.GetCodeProvenance(ctx, codeID),
+			Deprecated:            info.Deprecated,
+		}
+	}
+	return r
+}
+
 func queryContractInfo(ctx sdk.Context, addr sdk.AccAddress, keeper types.ViewKeeper) (*types.QueryContractInfoResponse, error) {
 	info := keeper.GetContractInfo(ctx, addr)
 	if info == nil {
@@ -316,11 +577,58 @@ func queryContractInfo(ctx sdk.Context, addr sdk.AccAddress, keeper types.ViewKe
 			Wrapf("address %s", addr.String())
 	}
 	return &types.QueryContractInfoResponse{
-		Address:      addr.String(),
-		ContractInfo: *info,
+		Address:            addr.String(),
+		ContractInfo:       *info,
+		LastActivityHeight: keeper.GetContractLastActivityHeight(ctx, addr),
 	}, nil
 }
 
+// contractInfoAt reconstructs the contract info at the given height by folding the contract history.
+// Admin and label are only reverted for changes that were recorded in the history. Heights before pruned history
+// entries are not found.
+func contractInfoAt(ctx sdk.Context, addr sdk.AccAddress, height uint64, keeper types.ViewKeeper) (*types.ContractInfo, error) {
+	info := keeper.GetContractInfo(ctx, addr)
+	if info == nil {
+		return nil, types.ErrNoSuchContractFn(addr.String()).
+			Wrapf("address %s", addr.String())
+	}
+	history := keeper.GetContractHistory(ctx, addr)
+	if len(history) == 0 || history[0].Updated == nil || history[0].Updated.BlockHeight > height {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "contract not created at height %d", height)
+	}
+	if prunedHeight := keeper.GetContractHistoryPrunedHeight(ctx, addr); height < prunedHeight {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "contract history pruned before height %d", prunedHeight)
+	}
+	for i := len(history) - 1; i >= 0; i-- {
+		e := history[i]
+		if e.Updated != nil && e.Updated.BlockHeight <= height {
+			continue
+		}
+		if e.Operation.IsCodeChange() {
+			continue
+		}
+		var change types.ContractInfoChange
+		if err := json.Unmarshal(e.Msg, &change); err != nil {
+			return nil, errorsmod.Wrap(types.ErrInvalid, "contract info change")
+		}
+		switch e.Operation {
+		case types.ContractCodeHistoryOperationTypeAdminChanged:
+			info.Admin = change.Old
+		case types.ContractCodeHistoryOperationTypeLabelChanged:
+			info.Label = change.Old
+		}
+	}
+	for _, e := range history {
+		if e.Updated != nil && e.Updated.BlockHeight > height {
+			break
+		}
+		if e.Operation.IsCodeChange() {
+			info.CodeID = e.CodeID
+		}
+	}
+	return info, nil
+}
+
 func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.QueryCodeResponse, error) {
 	info := queryCodeInfo(ctx, codeID, keeper)
 	if info == nil {
@@ -349,10 +657,48 @@ func queryCodeInfo(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) *typ
 		Creator:               res.Creator,
 		DataHash:              res.CodeHash,
 		InstantiatePermission: res.InstantiateConfig,
+		InstantiationCount:    keeper.GetCodeInstantiationCount(ctx, codeID),
+		Deprecated:            res.Deprecated,
 	}
 	return &info
 }
 
+func (q GrpcQuerier) CodesByUsage(c context.Context, req *types.QueryCodesByUsageRequest) (*types.QueryCodesByUsageResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	r := make([]types.CodeInfoResponse, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.CodesByInstantiationCountPrefix)
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			count, codeID := types.ParseCodesByInstantiationCountIndex(key)
+			c := q.keeper.GetCodeInfo(ctx, codeID)
+			if c == nil {
+				return false, types.ErrNoSuchCodeFn(codeID).Wrapf("code id %d", codeID)
+			}
+			r = append(r, types.CodeInfoResponse{
+				CodeID:                codeID,
+				Creator:               c.Creator,
+				DataHash:              c.CodeHash,
+				InstantiatePermission: c.InstantiateConfig,
+				InstantiationCount:    count,
+				Deprecated:            c.Deprecated,
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodesByUsageResponse{CodeInfos: r, Pagination: pageRes}, nil
+}
+
 func (q GrpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesRequest) (*types.QueryPinnedCodesResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
@@ -381,6 +727,169 @@ func (q GrpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesR
 	}, nil
 }
 
+func (q GrpcQuerier) UploadQuota(c context.Context, req *types.QueryUploadQuotaRequest) (*types.QueryUploadQuotaResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	uploader, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.IsUploadSpamProtected(ctx, uploader) {
+		return &types.QueryUploadQuotaResponse{Exempt: true}, nil
+	}
+	p := q.keeper.GetParams(ctx).UploadSpamProtection
+	r := &types.QueryUploadQuotaResponse{
+		Deposit:            p.Deposit,
+		MaxUploadsPerEpoch: p.MaxUploadsPerEpoch,
+	}
+	if p.MaxUploadsPerEpoch != 0 {
+		epoch := p.Epoch(ctx.BlockHeight())
+		r.Uploads = q.keeper.GetUploadCount(ctx, uploader, epoch)
+		r.EpochEndHeight = p.EpochEndHeight(epoch)
+	}
+	return r, nil
+}
+
+func (q GrpcQuerier) CodeIdByChecksum(c context.Context, req *types.QueryCodeIdByChecksumRequest) (*types.QueryCodeIdByChecksumResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	checksum, err := hex.DecodeString(req.Checksum)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "checksum: %s", err)
+	}
+	if len(checksum) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "checksum must be 32 bytes")
+	}
+	codeIDs := q.keeper.GetCodeIDsByChecksum(sdk.UnwrapSDKContext(c), checksum)
+	if len(codeIDs) == 0 {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "checksum %s", req.Checksum)
+	}
+	return &types.QueryCodeIdByChecksumResponse{CodeID: codeIDs[0], CodeIDs: codeIDs}, nil
+}
+
+// ContractsByChecksum lists the contracts of all code ids with the checksum, ordered by code id and then by creation.
+// The page key is the code id of the cursor followed by the key in the contracts by code index.
+func (q GrpcQuerier) ContractsByChecksum(c context.Context, req *types.QueryContractsByChecksumRequest) (*types.QueryContractsByChecksumResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	checksum, err := hex.DecodeString(req.Checksum)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "checksum: %s", err)
+	}
+	if len(checksum) != 32 {
+		return nil, status.Errorf(codes.InvalidArgument, "checksum must be 32 bytes")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	if paginationParams.Reverse {
+		return nil, status.Error(codes.InvalidArgument, "reverse pagination not supported")
+	}
+	startCodeID, startKey, err := decodeContractsByChecksumPageKey(paginationParams.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	codeIDs := q.keeper.GetCodeIDsByChecksum(ctx, checksum)
+	if len(codeIDs) == 0 {
+		return nil, errorsmod.Wrapf(types.ErrNotFound, "checksum %s", req.Checksum)
+	}
+	r := make([]string, 0)
+	var nextKey []byte
+	store := runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx))
+	for _, codeID := range codeIDs {
+		if codeID < startCodeID {
+			continue
+		}
+		var start []byte
+		if codeID == startCodeID {
+			start = startKey
+		}
+		nextKey = func() []byte {
+			iter := prefix.NewStore(store, types.GetContractByCodeIDSecondaryIndexPrefix(codeID)).Iterator(start, nil)
+			defer iter.Close()
+			for ; iter.Valid(); iter.Next() {
+				if uint64(len(r)) == paginationParams.Limit {
+					return contractsByChecksumPageKey(codeID, iter.Key())
+				}
+				r = append(r, sdk.AccAddress(iter.Key()[types.AbsoluteTxPositionLen:]).String())
+			}
+			return nil
+		}()
+		if nextKey != nil {
+			break
+		}
+	}
+	return &types.QueryContractsByChecksumResponse{
+		Contracts:  r,
+		Pagination: &query.PageResponse{NextKey: nextKey},
+		CodeIDs:    codeIDs,
+	}, nil
+}
+
+// contractsByChecksumPageKey returns the code id followed by the key in the contracts by code index
+func contractsByChecksumPageKey(codeID uint64, key []byte) []byte {
+	r := make([]byte, 8+len(key))
+	binary.BigEndian.PutUint64(r, codeID)
+	copy(r[8:], key)
+	return r
+}
+
+func decodeContractsByChecksumPageKey(pageKey []byte) (uint64, []byte, error) {
+	if len(pageKey) == 0 {
+		return 0, nil, nil
+	}
+	if len(pageKey) <= 8 {
+		return 0, nil, status.Error(codes.InvalidArgument, "invalid page key")
+	}
+	return binary.BigEndian.Uint64(pageKey[:8]), pageKey[8:], nil
+}
+
+// EffectiveInstantiatePermission evaluates the chain params for an upload by the sender without instantiate permission
+func (q GrpcQuerier) EffectiveInstantiatePermission(c context.Context, req *types.QueryEffectiveInstantiatePermissionRequest) (*types.QueryEffectiveInstantiatePermissionResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	sender, err := sdk.AccAddressFromBech32(req.Sender)
+	if err != nil {
+		return nil, err
+	}
+	params := q.keeper.GetParams(sdk.UnwrapSDKContext(c))
+	instantiateConfig := params.InstantiateDefaultPermission.With(sender)
+	chainConfigs := types.ChainAccessConfigs{
+		Instantiate: instantiateConfig,
+		Upload:      params.CodeUploadAccess,
+	}
+	return &types.QueryEffectiveInstantiatePermissionResponse{
+		CanUpload:             DefaultAuthorizationPolicy{}.CanCreateCode(chainConfigs, sender, instantiateConfig),
+		InstantiatePermission: instantiateConfig,
+	}, nil
+}
+
+// FeelessExecutions returns the allow-list of contract executions that can be sent without fees
+func (q GrpcQuerier) FeelessExecutions(c context.Context, req *types.QueryFeelessExecutionsRequest) (*types.QueryFeelessExecutionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	params := q.keeper.GetParams(sdk.UnwrapSDKContext(c))
+	return &types.QueryFeelessExecutionsResponse{FeelessExecutions: params.FeelessExecutions}, nil
+}
+
+// ContractGasBudgets returns the per block execution gas budgets of contracts
+func (q GrpcQuerier) ContractGasBudgets(c context.Context, req *types.QueryContractGasBudgetsRequest) (*types.QueryContractGasBudgetsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	params := q.keeper.GetParams(sdk.UnwrapSDKContext(c))
+	return &types.QueryContractGasBudgetsResponse{Budgets: params.ContractGasBudgets}, nil
+}
+
 // Params returns params of the module.
 func (q GrpcQuerier) Params(c context.Context, _ *types.QueryParamsRequest) (*types.QueryParamsResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
@@ -494,3 +1003,285 @@ func BuildAddressPredictable(req *types.QueryBuildAddressRequest) (*types.QueryB
 		Address: BuildContractAddressPredictable(codeHash, creator, salt, initMsg).String(),
 	}, nil
 }
+
+// ContractFootprint returns the bytes a contract adds to the state
+func (q GrpcQuerier) ContractFootprint(c context.Context, req *types.QueryContractFootprintRequest) (*types.QueryContractFootprintResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	footprint, err := q.keeper.GetContractFootprint(sdk.UnwrapSDKContext(c), contractAddr, footprintMaxStateEntries)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractFootprintResponse{Footprint: *footprint}, nil
+}
+
+// CodesFootprint returns the bytes the contracts of a code id add to the state with the sum of the page
+func (q GrpcQuerier) CodesFootprint(c context.Context, req *types.QueryCodesFootprintRequest) (*types.QueryCodesFootprintResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, errorsmod.Wrap(types.ErrInvalid, "code id")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	if q.keeper.GetCodeInfo(ctx, req.CodeId) == nil {
+		return nil, types.ErrNoSuchCodeFn(req.CodeId).Wrapf("code id %d", req.CodeId)
+	}
+
+	r := &types.QueryCodesFootprintResponse{Contracts: make([]types.ContractFootprint, 0)}
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractByCodeIDSecondaryIndexPrefix(req.CodeId))
+	r.Pagination, err = query.Paginate(prefixStore, paginationParams, func(key, _ []byte) error {
+		var contractAddr sdk.AccAddress = key[types.AbsoluteTxPositionLen:]
+		footprint, err := q.keeper.GetContractFootprint(ctx, contractAddr, footprintMaxStateEntries)
+		if err != nil {
+			return err
+		}
+		r.Contracts = append(r.Contracts, *footprint)
+		r.Sum.ContractInfoBytes += footprint.ContractInfoBytes
+		r.Sum.HistoryEntries += footprint.HistoryEntries
+		r.Sum.HistoryBytes += footprint.HistoryBytes
+		r.Sum.StateEntries += footprint.StateEntries
+		r.Sum.StateBytes += footprint.StateBytes
+		r.Sum.CodeShareBytes += footprint.CodeShareBytes
+		r.Sum.TotalBytes += footprint.TotalBytes
+		r.Sum.Truncated = r.Sum.Truncated || footprint.Truncated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DelegatedCapabilities returns the unexpired authz grants for wasm messages and the fee allowance that the granter has
+// given to the grantee. Without a feegrant keeper, the fee allowance is not supported.
+func (q GrpcQuerier) DelegatedCapabilities(c context.Context, req *types.QueryDelegatedCapabilitiesRequest) (*types.QueryDelegatedCapabilitiesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if q.authzKeeper == nil {
+		return nil, status.Error(codes.Unimplemented, "authz keeper not set")
+	}
+	granter, err := sdk.AccAddressFromBech32(req.Granter)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "granter")
+	}
+	grantee, err := sdk.AccAddressFromBech32(req.Grantee)
+	if err != nil {
+		return nil, errorsmod.Wrap(err, "grantee")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	authorizations, err := q.authzKeeper.GetAuthorizations(ctx, grantee, granter)
+	if err != nil {
+		return nil, err
+	}
+	rsp := &types.QueryDelegatedCapabilitiesResponse{
+		Authorizations:    make([]types.DelegatedAuthorization, 0),
+		FeeGrantSupported: q.feeGrantKeeper != nil,
+	}
+	for _, a := range authorizations {
+		msgType := a.MsgTypeURL()
+		if !strings.HasPrefix(msgType, wasmMsgTypeURLPrefix) {
+			continue
+		}
+		// expired grants are not returned
+		a, expiration := q.authzKeeper.GetAuthorization(ctx, grantee, granter, msgType)
+		if a == nil {
+			continue
+		}
+		d := types.DelegatedAuthorization{MsgTypeURL: msgType, Expiration: expiration}
+		switch v := a.(type) {
+		case *types.ContractExecutionAuthorization:
+			d.Contracts = v.Grants
+		case *types.ContractMigrationAuthorization:
+			d.Contracts = v.Grants
+		case *types.StoreCodeAuthorization:
+			d.Codes = v.Grants
+		case *authz.GenericAuthorization:
+			d.Generic = true
+		}
+		rsp.Authorizations = append(rsp.Authorizations, d)
+	}
+	if q.feeGrantKeeper == nil {
+		return rsp, nil
+	}
+	allowance, err := q.feeGrantKeeper.GetAllowance(ctx, granter, grantee)
+	switch {
+	case errors.Is(err, sdkerrors.ErrNotFound):
+		return rsp, nil
+	case err != nil:
+		return nil, err
+	}
+	msg, ok := allowance.(proto.Message)
+	if !ok {
+		return nil, status.Errorf(codes.Internal, "unsupported fee allowance %T", allowance)
+	}
+	if rsp.FeeAllowance, err = codectypes.NewAnyWithValue(msg); err != nil {
+		return nil, err
+	}
+	return rsp, nil
+}
+
+// ContractsByLabel lists the contracts with exactly the given label. The label index must be enabled by the
+// IndexContractLabels param.
+func (q GrpcQuerier) ContractsByLabel(c context.Context, req *types.QueryContractsByLabelRequest) (*types.QueryContractsByLabelResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.Label == "" {
+		return nil, status.Error(codes.InvalidArgument, "empty label")
+	}
+	paginationParams, err := ensurePaginationParams(req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	if !q.keeper.GetParams(ctx).IndexContractLabels {
+		return nil, status.Error(codes.FailedPrecondition, "contract label index is disabled")
+	}
+	contracts := make([]string, 0)
+	prefixStore := prefix.NewStore(runtime.KVStoreAdapter(q.storeService.OpenKVStore(ctx)), types.GetContractsByLabelPrefix(req.Label))
+	pageRes, err := query.FilteredPaginate(prefixStore, paginationParams, func(key, _ []byte, accumulate bool) (bool, error) {
+		if accumulate {
+			contracts = append(contracts, sdk.AccAddress(key).String())
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryContractsByLabelResponse{
+		Contracts:  contracts,
+		Pagination: pageRes,
+	}, nil
+}
+
+// RecentExecutions returns the last executions of a contract recorded by this node
+func (q GrpcQuerier) RecentExecutions(_ context.Context, req *types.QueryRecentExecutionsRequest) (*types.QueryRecentExecutionsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(req.Address)
+	if err != nil {
+		return nil, err
+	}
+	receipts, enabled := q.keeper.GetRecentExecutions(contractAddr, req.Limit)
+	if !enabled {
+		return nil, status.Error(codes.Unavailable, "execution receipts are disabled in the node config")
+	}
+	return &types.QueryRecentExecutionsResponse{Receipts: receipts}, nil
+}
+
+// CheckInstantiate2Address returns the predictable address of an instantiate2 call and how the address is used already
+func (q GrpcQuerier) CheckInstantiate2Address(c context.Context, req *types.QueryCheckInstantiate2AddressRequest) (*types.QueryCheckInstantiate2AddressResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	creator, err := sdk.AccAddressFromBech32(req.Creator)
+	if err != nil {
+		return nil, err
+	}
+	return q.keeper.CheckInstantiate2Address(sdk.UnwrapSDKContext(c), creator, req.CodeId, req.Salt, req.Msg, req.FixMsg)
+}
+
+// ModuleActivity returns the recorded wasm activity per block within the rolling window and the sum of it
+func (q GrpcQuerier) ModuleActivity(c context.Context, req *types.QueryModuleActivityRequest) (*types.QueryModuleActivityResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	toHeight := req.ToHeight
+	if toHeight == 0 {
+		toHeight = uint64(ctx.BlockHeight())
+	}
+	fromHeight := req.FromHeight
+	if fromHeight == 0 {
+		fromHeight = 1
+		if toHeight > types.ModuleActivityWindow {
+			fromHeight = toHeight - types.ModuleActivityWindow + 1
+		}
+	}
+	switch {
+	case fromHeight > toHeight:
+		return nil, status.Errorf(codes.InvalidArgument, "from height %d above to height %d", fromHeight, toHeight)
+	case toHeight-fromHeight >= types.ModuleActivityWindow:
+		return nil, status.Errorf(codes.InvalidArgument, "height range exceeds the window of %d blocks", types.ModuleActivityWindow)
+	case toHeight > math.MaxInt64:
+		return nil, status.Error(codes.InvalidArgument, "to height out of range")
+	}
+	rsp := types.QueryModuleActivityResponse{Blocks: make([]types.ModuleActivity, 0)}
+	q.keeper.IterateModuleActivity(ctx, int64(fromHeight), int64(toHeight), func(a types.ModuleActivity) bool {
+		rsp.Blocks = append(rsp.Blocks, a)
+		rsp.Total.Stores += a.Stores
+		rsp.Total.Instantiations += a.Instantiations
+		rsp.Total.Executions += a.Executions
+		rsp.Total.Migrations += a.Migrations
+		rsp.Total.GasUsed += a.GasUsed
+		return false
+	})
+	return &rsp, nil
+}
+
+// TopGasConsumers sums up the recorded execution gas per code id within the last blocks and returns the code ids
+// that used the most gas first. The window is rounded down to the start of its first bucket and must be at least
+// one bucket long.
+func (q GrpcQuerier) TopGasConsumers(c context.Context, req *types.QueryTopGasConsumersRequest) (*types.QueryTopGasConsumersResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	window := req.WindowBlocks
+	if window == 0 {
+		window = types.CodeGasUsageWindow
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTopGasConsumersLimit
+	}
+	switch {
+	case window < types.CodeGasUsageBucketLength:
+		return nil, status.Errorf(codes.InvalidArgument, "window must be at least %d blocks", types.CodeGasUsageBucketLength)
+	case window > types.CodeGasUsageWindow:
+		return nil, status.Errorf(codes.InvalidArgument, "window exceeds %d blocks", types.CodeGasUsageWindow)
+	case limit > maxTopGasConsumersLimit:
+		return nil, status.Errorf(codes.InvalidArgument, "limit exceeds %d", maxTopGasConsumersLimit)
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	toHeight := ctx.BlockHeight()
+	fromBucket := uint64(0)
+	if toHeight > int64(window) {
+		fromBucket = codeGasUsageBucket(toHeight - int64(window) + 1)
+	}
+	totals := make(map[uint64]uint64)
+	q.keeper.IterateCodeGasUsage(ctx, fromBucket, codeGasUsageBucket(toHeight), func(_, codeID, gasUsed uint64) bool {
+		totals[codeID] = addGas(totals[codeID], gasUsed)
+		return false
+	})
+	consumers := make([]types.CodeGasUsage, 0, len(totals))
+	for codeID, gasUsed := range totals {
+		consumers = append(consumers, types.CodeGasUsage{CodeID: codeID, GasUsed: gasUsed})
+	}
+	sort.Slice(consumers, func(i, j int) bool {
+		if consumers[i].GasUsed != consumers[j].GasUsed {
+			return consumers[i].GasUsed > consumers[j].GasUsed
+		}
+		return consumers[i].CodeID < consumers[j].CodeID
+	})
+	if len(consumers) > int(limit) {
+		consumers = consumers[:limit]
+	}
+	return &types.QueryTopGasConsumersResponse{
+		Consumers:  consumers,
+		FromHeight: max(1, int64(fromBucket)*types.CodeGasUsageBucketLength),
+		ToHeight:   toHeight,
+	}, nil
+}
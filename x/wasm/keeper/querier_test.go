@@ -1,11 +1,17 @@
 package keeper
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
@@ -20,12 +26,16 @@ import (
 	errorsmod "cosmossdk.io/errors"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/feegrant"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkErrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 
+	"github.com/CosmWasm/wasmd/x/wasm/keeper/testdata"
 	"github.com/CosmWasm/wasmd/x/wasm/keeper/wasmtesting"
 	"github.com/CosmWasm/wasmd/x/wasm/types"
 )
@@ -125,6 +135,120 @@ func TestQueryAllContractState(t *testing.T) {
 	}
 }
 
+func TestQueryContractStateKeys(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	exampleContract := InstantiateHackatomExampleContract(t, ctx, keepers)
+	contractAddr := exampleContract.Contract
+	contractModel := []types.Model{
+		{Key: []byte{0x0, 0x1}, Value: []byte(`{"count":8}`)},
+		{Key: []byte("foo"), Value: []byte(`"bar"`)},
+	}
+	require.NoError(t, keeper.importContractState(ctx, contractAddr, contractModel))
+
+	randomAddr := RandomBech32AccountAddress(t)
+
+	q := Querier(keeper)
+	specs := map[string]struct {
+		srcQuery   *types.QueryContractStateKeysRequest
+		expKeys    [][]byte
+		expNextKey []byte
+		expErr     error
+	}{
+		"query all": {
+			srcQuery: &types.QueryContractStateKeysRequest{Address: contractAddr.String()},
+			expKeys:  [][]byte{{0x0, 0x1}, []byte("config"), []byte("foo")},
+		},
+		"with prefix": {
+			srcQuery: &types.QueryContractStateKeysRequest{Address: contractAddr.String(), Prefix: []byte("f")},
+			expKeys:  [][]byte{[]byte("foo")},
+		},
+		"with prefix not matching": {
+			srcQuery: &types.QueryContractStateKeysRequest{Address: contractAddr.String(), Prefix: []byte("x")},
+			expKeys:  [][]byte{},
+		},
+		"with unknown address": {
+			srcQuery: &types.QueryContractStateKeysRequest{Address: randomAddr},
+			expErr:   types.ErrNoSuchContractFn(randomAddr).Wrapf("address %s", randomAddr),
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryContractStateKeysRequest{
+				Address:    contractAddr.String(),
+				Pagination: &query.PageRequest{Offset: 1},
+			},
+			expErr: errLegacyPaginationUnsupported,
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryContractStateKeysRequest{
+				Address:    contractAddr.String(),
+				Pagination: &query.PageRequest{Limit: 1},
+			},
+			expKeys:    [][]byte{{0x0, 0x1}},
+			expNextKey: []byte("config"),
+		},
+		"with pagination next key": {
+			srcQuery: &types.QueryContractStateKeysRequest{
+				Address:    contractAddr.String(),
+				Pagination: &query.PageRequest{Key: []byte("config")},
+			},
+			expKeys: [][]byte{[]byte("config"), []byte("foo")},
+		},
+		"with pagination reverse": {
+			srcQuery: &types.QueryContractStateKeysRequest{
+				Address:    contractAddr.String(),
+				Pagination: &query.PageRequest{Reverse: true},
+			},
+			expKeys: [][]byte{[]byte("foo"), []byte("config"), {0x0, 0x1}},
+		},
+		"with pagination reverse next key": {
+			srcQuery: &types.QueryContractStateKeysRequest{
+				Address:    contractAddr.String(),
+				Pagination: &query.PageRequest{Key: []byte("config"), Limit: 1, Reverse: true},
+			},
+			expKeys:    [][]byte{[]byte("config")},
+			expNextKey: []byte{0x0, 0x1},
+		},
+		"with pagination key not matching prefix": {
+			srcQuery: &types.QueryContractStateKeysRequest{
+				Address:    contractAddr.String(),
+				Prefix:     []byte("f"),
+				Pagination: &query.PageRequest{Key: []byte("config")},
+			},
+			expErr: status.Error(codes.InvalidArgument, "pagination key does not match prefix"),
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, err := q.ContractStateKeys(ctx, spec.srcQuery)
+
+			if spec.expErr != nil {
+				require.Equal(t, spec.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expKeys, got.Keys)
+			assert.Equal(t, spec.expNextKey, got.Pagination.NextKey)
+		})
+	}
+
+	t.Run("next key continues all contract state", func(t *testing.T) {
+		keysRsp, err := q.ContractStateKeys(ctx, &types.QueryContractStateKeysRequest{
+			Address:    contractAddr.String(),
+			Pagination: &query.PageRequest{Limit: 1},
+		})
+		require.NoError(t, err)
+		allRsp, err := q.AllContractState(ctx, &types.QueryAllContractStateRequest{
+			Address:    contractAddr.String(),
+			Pagination: &query.PageRequest{Key: keysRsp.Pagination.NextKey},
+		})
+		require.NoError(t, err)
+		require.Len(t, allRsp.Models, 2)
+		assert.Equal(t, []byte("config"), []byte(allRsp.Models[0].Key))
+		assert.Equal(t, contractModel[1], allRsp.Models[1])
+	})
+}
+
 func TestQuerySmartContractState(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -275,6 +399,71 @@ func TestQueryRawContractState(t *testing.T) {
 	}
 }
 
+func TestQueryRawContractStateAccess(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	exampleContract := InstantiateHackatomExampleContract(t, parentCtx, keepers)
+	contractAddr := exampleContract.Contract.String()
+	require.NoError(t, keeper.importContractState(parentCtx, exampleContract.Contract, []types.Model{{Key: []byte("foo"), Value: []byte(`"bar"`)}}))
+
+	q := Querier(keeper)
+	specs := map[string]struct {
+		accessControl    bool
+		rawQueryDisabled bool
+		expEnabled       bool
+	}{
+		"default": {
+			expEnabled: true,
+		},
+		"access control enabled": {
+			accessControl: true,
+			expEnabled:    true,
+		},
+		"access control enabled - raw query disabled": {
+			accessControl:    true,
+			rawQueryDisabled: true,
+		},
+		"access control disabled - raw query disabled": {
+			rawQueryDisabled: true,
+			expEnabled:       true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			params := keeper.GetParams(ctx)
+			params.ContractStateAccessControl = spec.accessControl
+			require.NoError(t, keeper.SetParams(ctx, params))
+			info := keeper.GetContractInfo(ctx, exampleContract.Contract)
+			info.RawQueryDisabled = spec.rawQueryDisabled
+			keeper.mustStoreContractInfo(ctx, exampleContract.Contract, info)
+
+			// when
+			gotAccess, err := q.ContractStateAccess(ctx, &types.QueryContractStateAccessRequest{Address: contractAddr})
+			require.NoError(t, err)
+			_, rawErr := q.RawContractState(ctx, &types.QueryRawContractStateRequest{Address: contractAddr, QueryData: []byte("foo")})
+			_, allErr := q.AllContractState(ctx, &types.QueryAllContractStateRequest{Address: contractAddr})
+			_, keysErr := q.ContractStateKeys(ctx, &types.QueryContractStateKeysRequest{Address: contractAddr})
+			_, smartErr := q.SmartContractState(ctx, &types.QuerySmartContractStateRequest{Address: contractAddr, QueryData: []byte(`{"verifier":{}}`)})
+
+			// then
+			assert.Equal(t, spec.expEnabled, gotAccess.RawQueryEnabled)
+			require.NoError(t, smartErr)
+			if spec.expEnabled {
+				require.NoError(t, rawErr)
+				require.NoError(t, allErr)
+				require.NoError(t, keysErr)
+				return
+			}
+			for _, err := range []error{rawErr, allErr, keysErr} {
+				require.Error(t, err)
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			}
+		})
+	}
+}
+
 func TestQueryContractsByCode(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -398,6 +587,313 @@ func TestQueryContractsByCode(t *testing.T) {
 	}
 }
 
+func TestQueryContractsByCodeFilters(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000000))
+	alice := keepers.Faucet.NewFundedRandomAccount(ctx, deposit...)
+	bob := keepers.Faucet.NewFundedRandomAccount(ctx, deposit...)
+	anyAdmin := RandomAccountAddress(t)
+
+	example := StoreHackatomExampleContract(t, ctx, keepers)
+	// instantiate at increasing heights so that the index returns the contracts in creation order
+	height := ctx.BlockHeight()
+	instantiate := func(creator, admin sdk.AccAddress) string {
+		height++
+		initMsgBz := HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)}.GetBytes(t)
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx.WithBlockHeight(height), example.CodeID, creator, admin, initMsgBz, "label", nil)
+		require.NoError(t, err)
+		return addr.String()
+	}
+	aliceAdminAny1 := instantiate(alice, anyAdmin)
+	aliceNoAdmin := instantiate(alice, nil)
+	aliceAdminAny2 := instantiate(alice, anyAdmin)
+	bobAdminAny := instantiate(bob, anyAdmin)
+	bobAdminBob := instantiate(bob, bob)
+
+	// record executions for the inactive filter
+	params := keepers.WasmKeeper.GetParams(ctx)
+	params.TrackContractActivity = true
+	require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+	for height, addr := range map[int64]string{10: aliceAdminAny1, 20: bobAdminAny} {
+		require.NoError(t, keepers.WasmKeeper.recordContractActivity(ctx.WithBlockHeight(height), sdk.MustAccAddressFromBech32(addr)))
+	}
+
+	q := Querier(keepers.WasmKeeper)
+	specs := map[string]struct {
+		req         *types.QueryContractsByCodeRequest
+		expAddr     []string
+		expCodeInfo bool
+		expErr      bool
+	}{
+		"no filters": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID},
+			expAddr: []string{aliceAdminAny1, aliceNoAdmin, aliceAdminAny2, bobAdminAny, bobAdminBob},
+		},
+		"by admin": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Admin: anyAdmin.String()},
+			expAddr: []string{aliceAdminAny1, aliceAdminAny2, bobAdminAny},
+		},
+		"by creator": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Creator: bob.String()},
+			expAddr: []string{bobAdminAny, bobAdminBob},
+		},
+		"by admin and creator": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Admin: anyAdmin.String(), Creator: alice.String()},
+			expAddr: []string{aliceAdminAny1, aliceAdminAny2},
+		},
+		"by admin with pagination limit": {
+			req: &types.QueryContractsByCodeRequest{
+				CodeId:     example.CodeID,
+				Admin:      anyAdmin.String(),
+				Pagination: &query.PageRequest{Limit: 2},
+			},
+			expAddr: []string{aliceAdminAny1, aliceAdminAny2},
+		},
+		"no match": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Admin: alice.String()},
+			expAddr: []string{},
+		},
+		"with code info": {
+			req:         &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Creator: alice.String(), IncludeCodeInfo: true},
+			expAddr:     []string{aliceAdminAny1, aliceNoAdmin, aliceAdminAny2},
+			expCodeInfo: true,
+		},
+		"inactive since height": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, InactiveSinceHeight: 20},
+			expAddr: []string{aliceAdminAny1, aliceNoAdmin, aliceAdminAny2, bobAdminBob},
+		},
+		"inactive since height of first execution": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, InactiveSinceHeight: 10},
+			expAddr: []string{aliceNoAdmin, aliceAdminAny2, bobAdminBob},
+		},
+		"inactive since height and creator": {
+			req:     &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Creator: bob.String(), InactiveSinceHeight: 11},
+			expAddr: []string{bobAdminBob},
+		},
+		"with code info for unknown code": {
+			req:    &types.QueryContractsByCodeRequest{CodeId: example.CodeID + 1, IncludeCodeInfo: true},
+			expErr: true,
+		},
+		"invalid admin": {
+			req:    &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Admin: "invalid"},
+			expErr: true,
+		},
+		"invalid creator": {
+			req:    &types.QueryContractsByCodeRequest{CodeId: example.CodeID, Creator: "invalid"},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := q.ContractsByCode(ctx, spec.req)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expAddr, got.Contracts)
+			if !spec.expCodeInfo {
+				assert.Nil(t, got.CodeInfo)
+				return
+			}
+			require.NotNil(t, got.CodeInfo)
+			assert.Equal(t, example.CodeID, got.CodeInfo.CodeID)
+			assert.Equal(t, example.CreatorAddr.String(), got.CodeInfo.Creator)
+			assert.Equal(t, example.Checksum, []byte(got.CodeInfo.Checksum))
+			assert.Equal(t, types.AllowEverybody, got.CodeInfo.InstantiatePermission)
+			assert.Equal(t, uint64(5), got.CodeInfo.InstantiationCount)
+		})
+	}
+
+	// and when activity tracking is disabled
+	params.TrackContractActivity = false
+	require.NoError(t, keepers.WasmKeeper.SetParams(ctx, params))
+	_, err := q.ContractsByCode(ctx, &types.QueryContractsByCodeRequest{CodeId: example.CodeID, InactiveSinceHeight: 20})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestQueryContractsByChecksum(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000000))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, deposit...)
+
+	// two code ids share the checksum
+	codeID1, checksum, err := keepers.ContractKeeper.Create(ctx, creator, testdata.HackatomContractWasm(), nil)
+	require.NoError(t, err)
+	codeID2, _, err := keepers.ContractKeeper.Create(ctx, creator, testdata.HackatomContractWasm(), nil)
+	require.NoError(t, err)
+	otherCode := StoreReflectContract(t, ctx, keepers)
+
+	var h int64 = 10
+	instantiate := func(codeID uint64) string {
+		ctx = ctx.WithBlockHeight(h)
+		h++
+		initMsgBz := HackatomExampleInitMsg{Verifier: RandomAccountAddress(t), Beneficiary: RandomAccountAddress(t)}.GetBytes(t)
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsgBz, "label", nil)
+		require.NoError(t, err)
+		return addr.String()
+	}
+	// interleaved contracts
+	code1Contract1 := instantiate(codeID1)
+	code2Contract1 := instantiate(codeID2)
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, otherCode.CodeID, creator, nil, []byte("{}"), "other", nil)
+	require.NoError(t, err)
+	code1Contract2 := instantiate(codeID1)
+	code2Contract2 := instantiate(codeID2)
+	allContracts := []string{code1Contract1, code1Contract2, code2Contract1, code2Contract2}
+
+	q := Querier(keepers.WasmKeeper)
+	specs := map[string]struct {
+		req     *types.QueryContractsByChecksumRequest
+		expAddr []string
+		expErr  bool
+	}{
+		"all contracts": {
+			req:     &types.QueryContractsByChecksumRequest{Checksum: hex.EncodeToString(checksum)},
+			expAddr: allContracts,
+		},
+		"unknown checksum": {
+			req:    &types.QueryContractsByChecksumRequest{Checksum: hex.EncodeToString(bytes.Repeat([]byte{1}, 32))},
+			expErr: true,
+		},
+		"invalid checksum": {
+			req:    &types.QueryContractsByChecksumRequest{Checksum: "foo"},
+			expErr: true,
+		},
+		"invalid page key": {
+			req:    &types.QueryContractsByChecksumRequest{Checksum: hex.EncodeToString(checksum), Pagination: &query.PageRequest{Key: []byte{1}}},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractsByChecksum(ctx, spec.req)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAddr, got.Contracts)
+			assert.Equal(t, []uint64{codeID1, codeID2}, got.CodeIDs)
+			assert.Nil(t, got.Pagination.NextKey)
+		})
+	}
+
+	for _, limit := range []uint64{1, 2, 3} {
+		t.Run(fmt.Sprintf("paginated with limit %d", limit), func(t *testing.T) {
+			var (
+				gotAddrs []string
+				nextKey  []byte
+			)
+			for {
+				got, err := q.ContractsByChecksum(ctx, &types.QueryContractsByChecksumRequest{
+					Checksum:   hex.EncodeToString(checksum),
+					Pagination: &query.PageRequest{Key: nextKey, Limit: limit},
+				})
+				require.NoError(t, err)
+				require.LessOrEqual(t, uint64(len(got.Contracts)), limit)
+				gotAddrs = append(gotAddrs, got.Contracts...)
+				if nextKey = got.Pagination.NextKey; nextKey == nil {
+					break
+				}
+			}
+			assert.Equal(t, allContracts, gotAddrs)
+		})
+	}
+	t.Run("page key encodes code id", func(t *testing.T) {
+		got, err := q.ContractsByChecksum(ctx, &types.QueryContractsByChecksumRequest{
+			Checksum:   hex.EncodeToString(checksum),
+			Pagination: &query.PageRequest{Limit: 2},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, codeID2, binary.BigEndian.Uint64(got.Pagination.NextKey[:8]))
+	})
+}
+
+func TestQueryContractsByLabel(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.IndexContractLabels = true
+	require.NoError(t, k.SetParams(ctx, params))
+	example := StoreReflectContract(t, ctx, keepers)
+	instantiate := func(label string) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, example.CreatorAddr, example.CreatorAddr, []byte("{}"), label, nil)
+		require.NoError(t, err)
+		return addr
+	}
+	// duplicate labels
+	router1 := instantiate("router-v2")
+	router2 := instantiate("router-v2")
+	usdc := instantiate("cw20:USDC")
+	moved := instantiate("cw20:USDC")
+	// label update moves the index entry
+	require.NoError(t, k.setContractLabel(ctx, moved, example.CreatorAddr, "cw20:USDC-old", DefaultAuthorizationPolicy{}))
+
+	q := Querier(k)
+	specs := map[string]struct {
+		req     *types.QueryContractsByLabelRequest
+		expAddr []string
+		expErr  bool
+	}{
+		"duplicate label": {
+			req:     &types.QueryContractsByLabelRequest{Label: "router-v2"},
+			expAddr: []string{router1.String(), router2.String()},
+		},
+		"old label": {
+			req:     &types.QueryContractsByLabelRequest{Label: "cw20:USDC"},
+			expAddr: []string{usdc.String()},
+		},
+		"new label": {
+			req:     &types.QueryContractsByLabelRequest{Label: "cw20:USDC-old"},
+			expAddr: []string{moved.String()},
+		},
+		"no prefix match": {
+			req:     &types.QueryContractsByLabelRequest{Label: "cw20"},
+			expAddr: []string{},
+		},
+		"case sensitive": {
+			req:     &types.QueryContractsByLabelRequest{Label: "Router-v2"},
+			expAddr: []string{},
+		},
+		"with pagination limit": {
+			req:     &types.QueryContractsByLabelRequest{Label: "cw20:USDC-old", Pagination: &query.PageRequest{Limit: 1}},
+			expAddr: []string{moved.String()},
+		},
+		"with pagination offset": {
+			req:    &types.QueryContractsByLabelRequest{Label: "router-v2", Pagination: &query.PageRequest{Offset: 1}},
+			expErr: true,
+		},
+		"empty label": {
+			req:    &types.QueryContractsByLabelRequest{},
+			expErr: true,
+		},
+		"nil request": {
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := q.ContractsByLabel(ctx, spec.req)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.ElementsMatch(t, spec.expAddr, got.Contracts)
+		})
+	}
+
+	// and when disabled, the index is not queryable
+	params.IndexContractLabels = false
+	require.NoError(t, k.SetParams(ctx, params))
+	_, err := q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: "router-v2"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
 func TestQueryContractHistory(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -542,6 +1038,199 @@ func TestQueryContractHistory(t *testing.T) {
 	}
 }
 
+func TestQueryContractHistoryFilters(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	myContractAddr := RandomAccountAddress(t)
+	// 50 entries with heights 10, 20, ... 500: an init, followed by migrations and a genesis entry for every 10th
+	history := make([]types.ContractCodeHistoryEntry, 50)
+	for i := range history {
+		op := types.ContractCodeHistoryOperationTypeMigrate
+		switch {
+		case i == 0:
+			op = types.ContractCodeHistoryOperationTypeInit
+		case i%10 == 0:
+			op = types.ContractCodeHistoryOperationTypeGenesis
+		}
+		history[i] = types.ContractCodeHistoryEntry{
+			Operation: op,
+			CodeID:    uint64(i + 1),
+			Updated:   &types.AbsoluteTxPosition{BlockHeight: uint64(10 * (i + 1))},
+			Msg:       []byte(`{}`),
+		}
+	}
+	require.NoError(t, keeper.appendToContractHistory(ctx, myContractAddr, history...))
+
+	specs := map[string]struct {
+		operation   types.ContractCodeHistoryOperationType
+		sinceHeight uint64
+		reverse     bool
+		expCodeIDs  []uint64
+	}{
+		"no filter": {
+			expCodeIDs: codeIDRange(1, 50),
+		},
+		"init only": {
+			operation:  types.ContractCodeHistoryOperationTypeInit,
+			expCodeIDs: []uint64{1},
+		},
+		"genesis only": {
+			operation:  types.ContractCodeHistoryOperationTypeGenesis,
+			expCodeIDs: []uint64{11, 21, 31, 41},
+		},
+		"since height": {
+			sinceHeight: 455,
+			expCodeIDs:  codeIDRange(46, 50),
+		},
+		"since exact height": {
+			sinceHeight: 460,
+			expCodeIDs:  codeIDRange(46, 50),
+		},
+		"since first height": {
+			sinceHeight: 10,
+			expCodeIDs:  codeIDRange(1, 50),
+		},
+		"since after last height": {
+			sinceHeight: 501,
+		},
+		"migrations since height": {
+			operation:   types.ContractCodeHistoryOperationTypeMigrate,
+			sinceHeight: 300,
+			expCodeIDs:  append(codeIDRange(30, 30), append(codeIDRange(32, 40), codeIDRange(42, 50)...)...),
+		},
+		"init since height": {
+			operation:   types.ContractCodeHistoryOperationTypeInit,
+			sinceHeight: 20,
+		},
+		"genesis since height in reverse": {
+			operation:   types.ContractCodeHistoryOperationTypeGenesis,
+			sinceHeight: 220,
+			reverse:     true,
+			expCodeIDs:  []uint64{41, 31},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := Querier(keeper)
+			var gotCodeIDs []uint64
+			var pageKey []byte
+			for {
+				got, err := q.ContractHistory(ctx, &types.QueryContractHistoryRequest{
+					Address:     myContractAddr.String(),
+					Operation:   spec.operation,
+					SinceHeight: spec.sinceHeight,
+					Pagination:  &query.PageRequest{Key: pageKey, Limit: 3, Reverse: spec.reverse},
+				})
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(got.Entries), 3)
+				for _, e := range got.Entries {
+					gotCodeIDs = append(gotCodeIDs, e.CodeID)
+				}
+				if len(got.Pagination.NextKey) == 0 {
+					break
+				}
+				pageKey = got.Pagination.NextKey
+			}
+			assert.Equal(t, spec.expCodeIDs, gotCodeIDs)
+		})
+	}
+}
+
+// codeIDRange returns all code ids from first to last inclusive
+func codeIDRange(first, last uint64) []uint64 {
+	r := make([]uint64, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		r = append(r, i)
+	}
+	return r
+}
+
+func TestQueryContractInfoAt(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	params := types.DefaultParams()
+	params.RecordContractInfoChanges = true
+	require.NoError(t, k.SetParams(parentCtx, params))
+
+	// instantiated at height 10
+	ctx := parentCtx.WithBlockHeight(10)
+	example := InstantiateHackatomExampleContract(t, ctx, keepers)
+	newAdmin := RandomAccountAddress(t)
+	// migrated at height 20
+	ctx = ctx.WithBlockHeight(20)
+	burnerCodeID := StoreBurnerExampleContract(t, ctx, keepers).CodeID
+	_, err := keepers.ContractKeeper.Migrate(ctx, example.Contract, example.CreatorAddr, burnerCodeID, BurnerExampleInitMsg{Payout: example.CreatorAddr}.GetBytes(t))
+	require.NoError(t, err)
+	// admin changed at height 30
+	ctx = ctx.WithBlockHeight(30)
+	require.NoError(t, k.setContractAdmin(ctx, example.Contract, example.CreatorAddr, newAdmin, DefaultAuthorizationPolicy{}))
+	// label changed at height 40
+	ctx = ctx.WithBlockHeight(40)
+	require.NoError(t, k.setContractLabel(ctx, example.Contract, newAdmin, "new label", DefaultAuthorizationPolicy{}))
+
+	history := k.GetContractHistory(ctx, example.Contract)
+	require.Len(t, history, 4)
+	assert.Equal(t, types.ContractCodeHistoryOperationTypeAdminChanged, history[2].Operation)
+	assert.Equal(t, types.ContractCodeHistoryOperationTypeLabelChanged, history[3].Operation)
+
+	specs := map[string]struct {
+		height    uint64
+		expCodeID uint64
+		expAdmin  string
+		expLabel  string
+		expErr    error
+	}{
+		"before instantiation": {
+			height: 9,
+			expErr: types.ErrNotFound,
+		},
+		"at instantiation": {
+			height:    10,
+			expCodeID: example.CodeID,
+			expAdmin:  example.CreatorAddr.String(),
+			expLabel:  example.Label,
+		},
+		"after migration": {
+			height:    25,
+			expCodeID: burnerCodeID,
+			expAdmin:  example.CreatorAddr.String(),
+			expLabel:  example.Label,
+		},
+		"at admin change": {
+			height:    30,
+			expCodeID: burnerCodeID,
+			expAdmin:  newAdmin.String(),
+			expLabel:  example.Label,
+		},
+		"after label change": {
+			height:    100,
+			expCodeID: burnerCodeID,
+			expAdmin:  newAdmin.String(),
+			expLabel:  "new label",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := Querier(k).ContractInfoAt(ctx, &types.QueryContractInfoAtRequest{Address: example.Contract.String(), Height: spec.height})
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCodeID, got.CodeID)
+			assert.Equal(t, spec.expAdmin, got.Admin)
+			assert.Equal(t, spec.expLabel, got.Label)
+			assert.Equal(t, example.CreatorAddr.String(), got.Creator)
+		})
+	}
+
+	// and changes are not recorded when disabled
+	require.NoError(t, k.SetParams(ctx, types.DefaultParams()))
+	require.NoError(t, k.setContractLabel(ctx, example.Contract, newAdmin, "other label", DefaultAuthorizationPolicy{}))
+	assert.Len(t, k.GetContractHistory(ctx, example.Contract), 4)
+}
+
 func TestQueryCodeList(t *testing.T) {
 	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -647,10 +1336,11 @@ func TestQueryContractInfo(t *testing.T) {
 		require.NoError(t, err)
 	}
 	specs := map[string]struct {
-		src    *types.QueryContractInfoRequest
-		stored types.ContractInfo
-		expRsp *types.QueryContractInfoResponse
-		expErr bool
+		src            *types.QueryContractInfoRequest
+		stored         types.ContractInfo
+		activityHeight uint64
+		expRsp         *types.QueryContractInfoResponse
+		expErr         bool
 	}{
 		"found": {
 			src:    &types.QueryContractInfoRequest{Address: contractAddr.String()},
@@ -668,6 +1358,16 @@ func TestQueryContractInfo(t *testing.T) {
 				ContractInfo: types.ContractInfoFixture(myExtension),
 			},
 		},
+		"with last activity": {
+			src:            &types.QueryContractInfoRequest{Address: contractAddr.String()},
+			stored:         types.ContractInfoFixture(),
+			activityHeight: 123,
+			expRsp: &types.QueryContractInfoResponse{
+				Address:            contractAddr.String(),
+				ContractInfo:       types.ContractInfoFixture(),
+				LastActivityHeight: 123,
+			},
+		},
 		"not found": {
 			src:    &types.QueryContractInfoRequest{Address: RandomBech32AccountAddress(t)},
 			stored: types.ContractInfoFixture(),
@@ -678,6 +1378,12 @@ func TestQueryContractInfo(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			xCtx, _ := ctx.CacheContext()
 			k.mustStoreContractInfo(xCtx, contractAddr, &spec.stored) //nolint:gosec
+			if spec.activityHeight != 0 {
+				params := k.GetParams(xCtx)
+				params.TrackContractActivity = true
+				require.NoError(t, k.SetParams(xCtx, params))
+				require.NoError(t, k.recordContractActivity(xCtx.WithBlockHeight(int64(spec.activityHeight)), contractAddr))
+			}
 			// when
 			gotRsp, gotErr := querier.ContractInfo(xCtx, spec.src)
 			if spec.expErr {
@@ -876,6 +1582,74 @@ func TestQueryCodeInfo(t *testing.T) {
 	}
 }
 
+func TestQueryCodeInfos(t *testing.T) {
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	for _, codeID := range []uint64{1, 7} {
+		require.NoError(t, keeper.importCode(ctx, codeID, codeInfo, wasmCode))
+	}
+	found := func(codeID uint64) types.CodeInfosResult {
+		return types.CodeInfosResult{CodeID: codeID, CodeInfo: &types.QueryCodeInfoResponse{
+			CodeID:                codeID,
+			Creator:               codeInfo.Creator,
+			Checksum:              codeInfo.CodeHash,
+			InstantiatePermission: codeInfo.InstantiateConfig,
+		}}
+	}
+	notFound := func(codeID uint64) types.CodeInfosResult {
+		return types.CodeInfosResult{CodeID: codeID, NotFound: true}
+	}
+
+	specs := map[string]struct {
+		codeIDs  []uint64
+		maxBatch int
+		exp      []types.CodeInfosResult
+		expErr   error
+	}{
+		"all found": {
+			codeIDs:  []uint64{1, 7},
+			maxBatch: 10,
+			exp:      []types.CodeInfosResult{found(1), found(7)},
+		},
+		"partial misses in request order": {
+			codeIDs:  []uint64{7, 2, 1, 0, 9, 7},
+			maxBatch: 10,
+			exp:      []types.CodeInfosResult{found(7), notFound(2), found(1), notFound(0), notFound(9), found(7)},
+		},
+		"max batch size": {
+			codeIDs:  []uint64{1, 2},
+			maxBatch: 2,
+			exp:      []types.CodeInfosResult{found(1), notFound(2)},
+		},
+		"exceeds max batch size": {
+			codeIDs:  []uint64{1, 2, 3},
+			maxBatch: 2,
+			expErr:   types.ErrLimit,
+		},
+		"empty code ids": {
+			maxBatch: 10,
+			expErr:   types.ErrEmpty,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			q := Querier(keeper)
+			q.maxCodeInfosBatchSize = spec.maxBatch
+			got, gotErr := q.CodeInfos(ctx, &types.QueryCodeInfosRequest{CodeIDs: spec.codeIDs})
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got.Results)
+		})
+	}
+}
+
 func TestQueryCode(t *testing.T) {
 	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
 	require.NoError(t, err)
@@ -995,6 +1769,179 @@ func TestQueryCodeInfoList(t *testing.T) {
 	require.EqualValues(t, allCodesResponse, got.CodeInfos)
 }
 
+func TestQueryCodesByUsage(t *testing.T) {
+	wasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+
+	codeInfo := types.CodeInfoFixture(types.WithSHA256CodeHash(wasmCode))
+	counts := map[uint64]uint64{1: 2, 2: 0, 3: 5}
+	for codeID := uint64(1); codeID <= 3; codeID++ {
+		require.NoError(t, keeper.importCode(ctx, codeID, codeInfo, wasmCode))
+		require.NoError(t, keeper.setCodeInstantiationCount(ctx, codeID, counts[codeID]))
+	}
+
+	q := Querier(keeper)
+	specs := map[string]struct {
+		srcQuery   *types.QueryCodesByUsageRequest
+		expCodeIDs []uint64
+		expErr     error
+	}{
+		"query all": {
+			srcQuery:   &types.QueryCodesByUsageRequest{},
+			expCodeIDs: []uint64{2, 1, 3},
+		},
+		"most used first": {
+			srcQuery: &types.QueryCodesByUsageRequest{
+				Pagination: &query.PageRequest{Reverse: true},
+			},
+			expCodeIDs: []uint64{3, 1, 2},
+		},
+		"with pagination limit": {
+			srcQuery: &types.QueryCodesByUsageRequest{
+				Pagination: &query.PageRequest{Limit: 1, Reverse: true},
+			},
+			expCodeIDs: []uint64{3},
+		},
+		"with pagination offset": {
+			srcQuery: &types.QueryCodesByUsageRequest{
+				Pagination: &query.PageRequest{Offset: 1},
+			},
+			expErr: errLegacyPaginationUnsupported,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, gotErr := q.CodesByUsage(ctx, spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.NotNil(t, got)
+			gotCodeIDs := make([]uint64, len(got.CodeInfos))
+			for i, v := range got.CodeInfos {
+				gotCodeIDs[i] = v.CodeID
+				assert.Equal(t, counts[v.CodeID], v.InstantiationCount)
+				assert.Equal(t, codeInfo.Creator, v.Creator)
+			}
+			assert.Equal(t, spec.expCodeIDs, gotCodeIDs)
+		})
+	}
+
+	// and code info query contains the count
+	gotInfo, err := q.CodeInfo(ctx, &types.QueryCodeInfoRequest{CodeId: 3})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), gotInfo.InstantiationCount)
+}
+
+func TestQueryCodeIdByChecksum(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	example1 := StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := StoreHackatomExampleContract(t, ctx, keepers)
+	example3 := StoreReflectContract(t, ctx, keepers)
+
+	q := Querier(keepers.WasmKeeper)
+	specs := map[string]struct {
+		srcQuery   *types.QueryCodeIdByChecksumRequest
+		expCodeID  uint64
+		expCodeIDs []uint64
+		expErr     error
+	}{
+		"uploaded twice": {
+			srcQuery:   &types.QueryCodeIdByChecksumRequest{Checksum: hex.EncodeToString(example1.Checksum)},
+			expCodeID:  example1.CodeID,
+			expCodeIDs: []uint64{example1.CodeID, example2.CodeID},
+		},
+		"uploaded once": {
+			srcQuery:   &types.QueryCodeIdByChecksumRequest{Checksum: hex.EncodeToString(example3.Checksum)},
+			expCodeID:  example3.CodeID,
+			expCodeIDs: []uint64{example3.CodeID},
+		},
+		"unknown checksum": {
+			srcQuery: &types.QueryCodeIdByChecksumRequest{Checksum: hex.EncodeToString(bytes.Repeat([]byte{1}, 32))},
+			expErr:   types.ErrNotFound,
+		},
+		"invalid checksum length": {
+			srcQuery: &types.QueryCodeIdByChecksumRequest{Checksum: "0102"},
+			expErr:   status.Error(codes.InvalidArgument, "checksum must be 32 bytes"),
+		},
+		"invalid hex": {
+			srcQuery: &types.QueryCodeIdByChecksumRequest{Checksum: "xyz"},
+			expErr:   status.Error(codes.InvalidArgument, "checksum: encoding/hex: invalid byte: U+0078 'x'"),
+		},
+		"nil request": {
+			expErr: status.Error(codes.InvalidArgument, "empty request"),
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			got, gotErr := q.CodeIdByChecksum(ctx, spec.srcQuery)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCodeID, got.CodeID)
+			assert.Equal(t, spec.expCodeIDs, got.CodeIDs)
+		})
+	}
+}
+
+func TestQueryEffectiveInstantiatePermission(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	mySender := RandomAccountAddress(t)
+	otherAddr := RandomAccountAddress(t)
+
+	uploadSpecs := map[string]struct {
+		src          types.AccessConfig
+		expCanUpload bool
+	}{
+		"upload by everybody":               {src: types.AllowEverybody, expCanUpload: true},
+		"upload by nobody":                  {src: types.AllowNobody},
+		"upload by any of including sender": {src: types.AccessTypeAnyOfAddresses.With(otherAddr, mySender), expCanUpload: true},
+		"upload by any of excluding sender": {src: types.AccessTypeAnyOfAddresses.With(otherAddr)},
+	}
+	instantiateSpecs := map[string]struct {
+		src       types.AccessType
+		expConfig types.AccessConfig
+	}{
+		"instantiate by everybody": {src: types.AccessTypeEverybody, expConfig: types.AllowEverybody},
+		"instantiate by nobody":    {src: types.AccessTypeNobody, expConfig: types.AllowNobody},
+		"instantiate by any of":    {src: types.AccessTypeAnyOfAddresses, expConfig: types.AccessTypeAnyOfAddresses.With(mySender)},
+	}
+	q := Querier(keeper)
+	for uploadName, uploadSpec := range uploadSpecs {
+		for instantiateName, instantiateSpec := range instantiateSpecs {
+			t.Run(uploadName+" - "+instantiateName, func(t *testing.T) {
+				params := types.DefaultParams()
+				params.CodeUploadAccess = uploadSpec.src
+				params.InstantiateDefaultPermission = instantiateSpec.src
+				require.NoError(t, keeper.SetParams(ctx, params))
+
+				got, gotErr := q.EffectiveInstantiatePermission(ctx, &types.QueryEffectiveInstantiatePermissionRequest{Sender: mySender.String()})
+				require.NoError(t, gotErr)
+				assert.Equal(t, uploadSpec.expCanUpload, got.CanUpload)
+				assert.Equal(t, instantiateSpec.expConfig, got.InstantiatePermission)
+
+				// and the store code result matches
+				_, _, err := keepers.ContractKeeper.Create(ctx, mySender, hackatomWasm, nil)
+				assert.Equal(t, uploadSpec.expCanUpload, err == nil, "create code: %v", err)
+			})
+		}
+	}
+
+	_, gotErr := q.EffectiveInstantiatePermission(ctx, &types.QueryEffectiveInstantiatePermissionRequest{Sender: "invalid"})
+	require.Error(t, gotErr)
+	_, gotErr = q.EffectiveInstantiatePermission(ctx, nil)
+	assert.ErrorIs(t, gotErr, status.Error(codes.InvalidArgument, "empty request"))
+}
+
 func TestQueryContractsByCreatorList(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 
@@ -1249,3 +2196,268 @@ func TestQueryBuildAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryFeelessExecutions(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	myConfig := types.FeelessExecutions{
+		Allowed: []types.FeelessExecution{{Contract: RandomBech32AccountAddress(t), MsgKey: "push_price"}},
+		MaxGas:  200_000,
+	}
+	specs := map[string]struct {
+		src    types.FeelessExecutions
+		req    *types.QueryFeelessExecutionsRequest
+		exp    types.FeelessExecutions
+		expErr error
+	}{
+		"configured": {
+			src: myConfig,
+			req: &types.QueryFeelessExecutionsRequest{},
+			exp: myConfig,
+		},
+		"not configured": {
+			req: &types.QueryFeelessExecutionsRequest{},
+			exp: types.FeelessExecutions{},
+		},
+		"nil request": {
+			expErr: status.Error(codes.InvalidArgument, "empty request"),
+		},
+	}
+	q := Querier(keeper)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			params := types.DefaultParams()
+			params.FeelessExecutions = spec.src
+			require.NoError(t, keeper.SetParams(ctx, params))
+
+			got, gotErr := q.FeelessExecutions(ctx, spec.req)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.Equal(t, spec.expErr, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got.FeelessExecutions)
+		})
+	}
+}
+
+func TestQueryContractGasBudgets(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keeper := keepers.WasmKeeper
+	myBudgets := []types.ContractGasBudget{{Contract: RandomBech32AccountAddress(t), MaxGasPerBlock: 1_000_000}}
+	specs := map[string]struct {
+		src    []types.ContractGasBudget
+		req    *types.QueryContractGasBudgetsRequest
+		exp    []types.ContractGasBudget
+		expErr error
+	}{
+		"configured": {
+			src: myBudgets,
+			req: &types.QueryContractGasBudgetsRequest{},
+			exp: myBudgets,
+		},
+		"not configured": {
+			req: &types.QueryContractGasBudgetsRequest{},
+		},
+		"nil request": {
+			expErr: status.Error(codes.InvalidArgument, "empty request"),
+		},
+	}
+	q := Querier(keeper)
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			params := types.DefaultParams()
+			params.ContractGasBudgets = spec.src
+			require.NoError(t, keeper.SetParams(ctx, params))
+
+			got, gotErr := q.ContractGasBudgets(ctx, spec.req)
+			if spec.expErr != nil {
+				require.Error(t, gotErr)
+				assert.Equal(t, spec.expErr, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got.Budgets)
+		})
+	}
+}
+
+func TestQueryDelegatedCapabilities(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = ctx.WithBlockTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	granter, grantee := RandomAccountAddress(t), RandomAccountAddress(t)
+	myContract := RandomBech32AccountAddress(t)
+	future, past := ctx.BlockTime().Add(time.Hour), ctx.BlockTime().Add(-time.Hour)
+
+	contractGrant, err := types.NewContractGrant(sdk.MustAccAddressFromBech32(myContract), types.NewMaxCallsLimit(1), types.NewAllowAllMessagesFilter())
+	require.NoError(t, err)
+	codeGrant := types.CodeGrant{CodeHash: []byte("*"), InstantiatePermission: &types.AllowEverybody}
+	executeAuthz := types.NewContractExecutionAuthorization(*contractGrant)
+	migrateAuthz := types.NewContractMigrationAuthorization(*contractGrant)
+	storeCodeAuthz := types.NewStoreCodeAuthorization(codeGrant)
+	genericAuthz := authz.NewGenericAuthorization(sdk.MsgTypeURL(&types.MsgClearAdmin{}))
+	allowance := &feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("denom", 100))}
+	expAllowance, err := codectypes.NewAnyWithValue(allowance)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		grants         map[string]mockGrant
+		allowance      feegrant.FeeAllowanceI
+		noFeeGrant     bool
+		expAuthzs      []types.DelegatedAuthorization
+		expAllowance   *codectypes.Any
+		expFeeGrantSup bool
+	}{
+		"wasm grants with allowance": {
+			grants: map[string]mockGrant{
+				executeAuthz.MsgTypeURL():   {authorization: executeAuthz, expiration: &future},
+				migrateAuthz.MsgTypeURL():   {authorization: migrateAuthz},
+				storeCodeAuthz.MsgTypeURL(): {authorization: storeCodeAuthz},
+				genericAuthz.MsgTypeURL():   {authorization: genericAuthz},
+			},
+			allowance: allowance,
+			expAuthzs: []types.DelegatedAuthorization{
+				{MsgTypeURL: genericAuthz.MsgTypeURL(), Generic: true},
+				{MsgTypeURL: executeAuthz.MsgTypeURL(), Expiration: &future, Contracts: []types.ContractGrant{*contractGrant}},
+				{MsgTypeURL: migrateAuthz.MsgTypeURL(), Contracts: []types.ContractGrant{*contractGrant}},
+				{MsgTypeURL: storeCodeAuthz.MsgTypeURL(), Codes: []types.CodeGrant{codeGrant}},
+			},
+			expAllowance:   expAllowance,
+			expFeeGrantSup: true,
+		},
+		"wasm grants without allowance": {
+			grants: map[string]mockGrant{
+				executeAuthz.MsgTypeURL(): {authorization: executeAuthz},
+			},
+			expAuthzs: []types.DelegatedAuthorization{
+				{MsgTypeURL: executeAuthz.MsgTypeURL(), Contracts: []types.ContractGrant{*contractGrant}},
+			},
+			expFeeGrantSup: true,
+		},
+		"allowance without grants": {
+			allowance:      allowance,
+			expAuthzs:      []types.DelegatedAuthorization{},
+			expAllowance:   expAllowance,
+			expFeeGrantSup: true,
+		},
+		"expired and non wasm grants skipped": {
+			grants: map[string]mockGrant{
+				executeAuthz.MsgTypeURL():      {authorization: executeAuthz, expiration: &past},
+				"/cosmos.bank.v1beta1.MsgSend": {authorization: authz.NewGenericAuthorization("/cosmos.bank.v1beta1.MsgSend")},
+			},
+			expAuthzs:      []types.DelegatedAuthorization{},
+			expFeeGrantSup: true,
+		},
+		"without feegrant module": {
+			grants: map[string]mockGrant{
+				executeAuthz.MsgTypeURL(): {authorization: executeAuthz},
+			},
+			noFeeGrant: true,
+			expAuthzs: []types.DelegatedAuthorization{
+				{MsgTypeURL: executeAuthz.MsgTypeURL(), Contracts: []types.ContractGrant{*contractGrant}},
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := Querier(keepers.WasmKeeper)
+			q.authzKeeper = &mockAuthzKeeper{granter: granter, grantee: grantee, grants: spec.grants}
+			if !spec.noFeeGrant {
+				q.feeGrantKeeper = &mockFeeGrantKeeper{granter: granter, grantee: grantee, allowance: spec.allowance}
+			}
+
+			// when
+			got, gotErr := q.DelegatedCapabilities(ctx, &types.QueryDelegatedCapabilitiesRequest{Granter: granter.String(), Grantee: grantee.String()})
+
+			// then
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expAuthzs, got.Authorizations)
+			assert.Equal(t, spec.expAllowance, got.FeeAllowance)
+			assert.Equal(t, spec.expFeeGrantSup, got.FeeGrantSupported)
+		})
+	}
+}
+
+func TestQueryDelegatedCapabilitiesErrors(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	myAddr := RandomBech32AccountAddress(t)
+	specs := map[string]struct {
+		req       *types.QueryDelegatedCapabilitiesRequest
+		noAuthz   bool
+		expErrMsg string
+	}{
+		"nil request": {
+			expErrMsg: "empty request",
+		},
+		"invalid granter": {
+			req:       &types.QueryDelegatedCapabilitiesRequest{Granter: "invalid", Grantee: myAddr},
+			expErrMsg: "granter",
+		},
+		"invalid grantee": {
+			req:       &types.QueryDelegatedCapabilitiesRequest{Granter: myAddr, Grantee: "invalid"},
+			expErrMsg: "grantee",
+		},
+		"without authz module": {
+			req:       &types.QueryDelegatedCapabilitiesRequest{Granter: myAddr, Grantee: myAddr},
+			noAuthz:   true,
+			expErrMsg: "authz keeper not set",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			q := Querier(keepers.WasmKeeper)
+			if !spec.noAuthz {
+				q.authzKeeper = &mockAuthzKeeper{}
+			}
+			_, gotErr := q.DelegatedCapabilities(ctx, spec.req)
+			require.Error(t, gotErr)
+			assert.Contains(t, gotErr.Error(), spec.expErrMsg)
+		})
+	}
+}
+
+type mockGrant struct {
+	authorization authz.Authorization
+	expiration    *time.Time
+}
+
+// mockAuthzKeeper returns the grants of a single granter and grantee. Expired grants are returned by
+// GetAuthorizations only, like in the sdk authz keeper.
+type mockAuthzKeeper struct {
+	granter, grantee sdk.AccAddress
+	grants           map[string]mockGrant
+}
+
+func (m mockAuthzKeeper) GetAuthorizations(_ context.Context, grantee, granter sdk.AccAddress) ([]authz.Authorization, error) {
+	if !grantee.Equals(m.grantee) || !granter.Equals(m.granter) {
+		return nil, nil
+	}
+	msgTypes := slices.Sorted(maps.Keys(m.grants))
+	r := make([]authz.Authorization, len(msgTypes))
+	for i, msgType := range msgTypes {
+		r[i] = m.grants[msgType].authorization
+	}
+	return r, nil
+}
+
+func (m mockAuthzKeeper) GetAuthorization(ctx context.Context, grantee, granter sdk.AccAddress, msgType string) (authz.Authorization, *time.Time) {
+	g, ok := m.grants[msgType]
+	if !ok || !grantee.Equals(m.grantee) || !granter.Equals(m.granter) ||
+		(g.expiration != nil && g.expiration.Before(sdk.UnwrapSDKContext(ctx).BlockTime())) {
+		return nil, nil
+	}
+	return g.authorization, g.expiration
+}
+
+type mockFeeGrantKeeper struct {
+	granter, grantee sdk.AccAddress
+	allowance        feegrant.FeeAllowanceI
+}
+
+func (m mockFeeGrantKeeper) GetAllowance(_ context.Context, granter, grantee sdk.AccAddress) (feegrant.FeeAllowanceI, error) {
+	if m.allowance == nil || !grantee.Equals(m.grantee) || !granter.Equals(m.granter) {
+		return nil, sdkErrors.ErrNotFound.Wrap("fee-grant not found")
+	}
+	return m.allowance, nil
+}
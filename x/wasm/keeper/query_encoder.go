@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/cosmos/gogoproto/proto"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// QueryJSONEncoder encodes a proto query response into the JSON that is passed to a contract.
+//
+// The JSON is part of the contract API: contracts decode it with their own types so that any change in the
+// field names, in omitting fields with default values or in the encoding of enums breaks them. The encoders
+// are therefore pinned here instead of using the JSON codec of the SDK that can change with an SDK upgrade.
+// A released encoding must never change. A new encoding is added as new version that chains opt into with
+// the query_json_encoding param in a coordinated upgrade.
+type QueryJSONEncoder func(msg proto.Message) ([]byte, error)
+
+// NewQueryJSONEncoder returns the encoder for the given version. Unspecified is handled as v1.
+func NewQueryJSONEncoder(version types.QueryJSONEncoding, resolver jsonpb.AnyResolver) (QueryJSONEncoder, error) {
+	var m jsonpb.Marshaler
+	switch version {
+	case types.QueryJSONEncodingUnspecified, types.QueryJSONEncodingV1:
+		// the encoding of the SDK v0.50 codec
+		m = jsonpb.Marshaler{OrigName: true, EmitDefaults: true, EnumsAsInts: false, AnyResolver: resolver}
+	case types.QueryJSONEncodingV2:
+		// the canonical proto3 encoding
+		m = jsonpb.Marshaler{OrigName: false, EmitDefaults: false, EnumsAsInts: false, AnyResolver: resolver}
+	default:
+		return nil, fmt.Errorf("unsupported query json encoding: %s", version)
+	}
+	return func(msg proto.Message) ([]byte, error) {
+		// the cached values of Any types are required by the marshaler
+		if err := codectypes.UnpackInterfaces(msg, codectypes.ProtoJSONPacker{JSONPBMarshaler: &m}); err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := m.Marshal(&buf, msg); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}, nil
+}
+
+// queryJSONEncoding returns the json encoding of proto query responses. The param is read without gas so that
+// the gas consumed by contract calls is not modified.
+func (k Keeper) queryJSONEncoding(ctx sdk.Context) types.QueryJSONEncoding {
+	return k.cachedParams(ctx).QueryJSONEncoding
+}
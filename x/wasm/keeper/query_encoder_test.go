@@ -0,0 +1,126 @@
+package keeper
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdkmath "cosmossdk.io/math"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	distributiontypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// TestQueryJSONEncoderGolden pins the json of the chain query responses that are passed to contracts.
+// The expected json of a released encoding must never be modified.
+func TestQueryJSONEncoderGolden(t *testing.T) {
+	cdc := MakeTestCodec(t)
+	myAddr := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	myValAddr := sdk.ValAddress(bytes.Repeat([]byte{2}, 20))
+	myPubKey, err := codectypes.NewAnyWithValue(&ed25519.PubKey{Key: bytes.Repeat([]byte{3}, ed25519.PubKeySize)})
+	require.NoError(t, err)
+	myAccount, err := codectypes.NewAnyWithValue(&authtypes.BaseAccount{Address: myAddr.String(), AccountNumber: 1})
+	require.NoError(t, err)
+	myCoin := sdk.NewInt64Coin("stake", 1)
+	myTime := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	specs := map[string]struct {
+		src   proto.Message
+		expV1 string
+		expV2 string
+	}{
+		"bank balance": {
+			src:   &banktypes.QueryBalanceResponse{Balance: &myCoin},
+			expV1: `{"balance":{"denom":"stake","amount":"1"}}`,
+			expV2: `{"balance":{"denom":"stake","amount":"1"}}`,
+		},
+		"bank all balances": {
+			src:   &banktypes.QueryAllBalancesResponse{Balances: sdk.NewCoins(myCoin), Pagination: &query.PageResponse{NextKey: []byte("next"), Total: 2}},
+			expV1: `{"balances":[{"denom":"stake","amount":"1"}],"pagination":{"next_key":"bmV4dA==","total":"2"}}`,
+			expV2: `{"balances":[{"denom":"stake","amount":"1"}],"pagination":{"nextKey":"bmV4dA==","total":"2"}}`,
+		},
+		"bank denom metadata": {
+			src:   &banktypes.QueryDenomMetadataResponse{Metadata: banktypes.Metadata{Base: "ustake", Display: "stake", DenomUnits: []*banktypes.DenomUnit{{Denom: "ustake"}, {Denom: "stake", Exponent: 6}}}},
+			expV1: `{"metadata":{"description":"","denom_units":[{"denom":"ustake","exponent":0,"aliases":[]},{"denom":"stake","exponent":6,"aliases":[]}],"base":"ustake","display":"stake","name":"","symbol":"","uri":"","uri_hash":""}}`,
+			expV2: `{"metadata":{"denomUnits":[{"denom":"ustake"},{"denom":"stake","exponent":6}],"base":"ustake","display":"stake"}}`,
+		},
+		"staking validator": {
+			src:   &stakingtypes.QueryValidatorResponse{Validator: stakingtypes.Validator{OperatorAddress: myValAddr.String(), ConsensusPubkey: myPubKey, Status: stakingtypes.Bonded, Tokens: sdkmath.NewInt(1), DelegatorShares: sdkmath.LegacyNewDec(1), Commission: stakingtypes.Commission{UpdateTime: myTime}, MinSelfDelegation: sdkmath.NewInt(1)}},
+			expV1: `{"validator":{"operator_address":"cosmosvaloper1qgpqyqszqgpqyqszqgpqyqszqgpqyqszxrnw2e","consensus_pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"AwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwM="},"jailed":false,"status":"BOND_STATUS_BONDED","tokens":"1","delegator_shares":"1.000000000000000000","description":{"moniker":"","identity":"","website":"","security_contact":"","details":""},"unbonding_height":"0","unbonding_time":"0001-01-01T00:00:00Z","commission":{"commission_rates":{"rate":"0.000000000000000000","max_rate":"0.000000000000000000","max_change_rate":"0.000000000000000000"},"update_time":"2030-01-01T00:00:00Z"},"min_self_delegation":"1","unbonding_on_hold_ref_count":"0","unbonding_ids":[]}}`,
+			expV2: `{"validator":{"operatorAddress":"cosmosvaloper1qgpqyqszqgpqyqszqgpqyqszqgpqyqszxrnw2e","consensusPubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"AwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwMDAwM="},"status":"BOND_STATUS_BONDED","tokens":"1","delegatorShares":"1.000000000000000000","description":{},"unbondingTime":"0001-01-01T00:00:00Z","commission":{"commissionRates":{"rate":"0.000000000000000000","maxRate":"0.000000000000000000","maxChangeRate":"0.000000000000000000"},"updateTime":"2030-01-01T00:00:00Z"},"minSelfDelegation":"1"}}`,
+		},
+		"staking delegation": {
+			src:   &stakingtypes.QueryDelegationResponse{DelegationResponse: &stakingtypes.DelegationResponse{Delegation: stakingtypes.Delegation{DelegatorAddress: myAddr.String(), ValidatorAddress: myValAddr.String(), Shares: sdkmath.LegacyNewDec(1)}, Balance: myCoin}},
+			expV1: `{"delegation_response":{"delegation":{"delegator_address":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du","validator_address":"cosmosvaloper1qgpqyqszqgpqyqszqgpqyqszqgpqyqszxrnw2e","shares":"1.000000000000000000"},"balance":{"denom":"stake","amount":"1"}}}`,
+			expV2: `{"delegationResponse":{"delegation":{"delegator_address":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du","validator_address":"cosmosvaloper1qgpqyqszqgpqyqszqgpqyqszqgpqyqszxrnw2e","shares":"1.000000000000000000"},"balance":{"denom":"stake","amount":"1"}}}`,
+		},
+		"staking params": {
+			src:   &stakingtypes.QueryParamsResponse{Params: stakingtypes.Params{UnbondingTime: 21 * 24 * time.Hour, MaxValidators: 100, BondDenom: "stake", MinCommissionRate: sdkmath.LegacyZeroDec()}},
+			expV1: `{"params":{"unbonding_time":"1814400s","max_validators":100,"max_entries":0,"historical_entries":0,"bond_denom":"stake","min_commission_rate":"0.000000000000000000"}}`,
+			expV2: `{"params":{"unbondingTime":"1814400s","maxValidators":100,"bondDenom":"stake","minCommissionRate":"0.000000000000000000"}}`,
+		},
+		"auth account": {
+			src:   &authtypes.QueryAccountResponse{Account: myAccount},
+			expV1: `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du","pub_key":null,"account_number":"1","sequence":"0"}}`,
+			expV2: `{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpjnp7du","accountNumber":"1"}}`,
+		},
+		"distribution delegation rewards": {
+			src:   &distributiontypes.QueryDelegationRewardsResponse{Rewards: sdk.NewDecCoins(sdk.NewDecCoinFromCoin(myCoin))},
+			expV1: `{"rewards":[{"denom":"stake","amount":"1.000000000000000000"}]}`,
+			expV2: `{"rewards":[{"denom":"stake","amount":"1.000000000000000000"}]}`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			bz, err := cdc.Marshal(spec.src)
+			require.NoError(t, err)
+			for encoding, exp := range map[types.QueryJSONEncoding]string{
+				types.QueryJSONEncodingUnspecified: spec.expV1,
+				types.QueryJSONEncodingV1:          spec.expV1,
+				types.QueryJSONEncodingV2:          spec.expV2,
+			} {
+				// the response is decoded into an empty message as in the querier
+				got, err := convertProtoToJSON(cdc, encoding, reflect.New(reflect.TypeOf(spec.src).Elem()).Interface().(proto.Message), bz)
+				require.NoError(t, err)
+				assert.Equal(t, exp, string(got), encoding.String())
+			}
+		})
+	}
+}
+
+func TestNewQueryJSONEncoderUnknownVersion(t *testing.T) {
+	_, err := NewQueryJSONEncoder(types.QueryJSONEncoding(99), MakeEncodingConfig(t).InterfaceRegistry)
+	assert.Error(t, err)
+}
+
+func TestQueryHandlerJSONEncoding(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	assert.Equal(t, types.QueryJSONEncodingV1, types.QueryJSONEncodingFromContext(k.newQueryHandler(ctx, RandomAccountAddress(t)).Ctx))
+
+	params := k.GetParams(ctx)
+	params.QueryJSONEncoding = types.QueryJSONEncodingV2
+	require.NoError(t, k.SetParams(ctx, params))
+	gasBefore := ctx.GasMeter().GasConsumed()
+
+	// when
+	q := k.newQueryHandler(ctx, RandomAccountAddress(t))
+
+	// then
+	assert.Equal(t, types.QueryJSONEncodingV2, types.QueryJSONEncodingFromContext(q.Ctx))
+	// and the param is read without gas
+	assert.Equal(t, gasBefore, ctx.GasMeter().GasConsumed())
+}
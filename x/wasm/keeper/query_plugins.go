@@ -406,6 +406,7 @@ type AcceptedQueries map[string]func() proto.Message
 
 // AcceptListStargateQuerier supports a preconfigured set of stargate queries only.
 // All arguments must be non nil.
+// The responses are encoded with the QueryJSONEncoder of the query_json_encoding param.
 //
 // Warning: Chains need to test and maintain their accept list carefully.
 // There were critical consensus breaking issues in the past with non-deterministic behavior in the SDK.
@@ -432,8 +433,13 @@ func AcceptListStargateQuerier(acceptList AcceptedQueries, queryRouter GRPCQuery
 			return nil, err
 		}
 
+		// a bare context without values is tolerated and falls back to the default encoding
+		encoding := types.QueryJSONEncodingV1
+		if ctx.Context() != nil {
+			encoding = types.QueryJSONEncodingFromContext(ctx)
+		}
 		protoResponse := protoResponseFn()
-		return ConvertProtoToJSONMarshal(codec, protoResponse, res.Value)
+		return convertProtoToJSON(codec, encoding, protoResponse, res.Value)
 	}
 }
 
@@ -850,14 +856,25 @@ func ConvertSdkDenomUnitsToWasmDenomUnits(denomUnits []*banktypes.DenomUnit) []w
 // ConvertProtoToJSONMarshal  unmarshals the given bytes into a proto message and then marshals it to json.
 // This is done so that clients calling stargate queries do not need to define their own proto unmarshalers,
 // being able to use response directly by json marshaling, which is supported in cosmwasm.
+// The json is encoded with the QueryJSONEncodingV1 encoder.
 func ConvertProtoToJSONMarshal(cdc codec.Codec, protoResponse proto.Message, bz []byte) ([]byte, error) {
+	return convertProtoToJSON(cdc, types.QueryJSONEncodingV1, protoResponse, bz)
+}
+
+// convertProtoToJSON unmarshals the given bytes into a proto message and then marshals it with the pinned
+// json encoder of the version
+func convertProtoToJSON(cdc codec.Codec, encoding types.QueryJSONEncoding, protoResponse proto.Message, bz []byte) ([]byte, error) {
+	encode, err := NewQueryJSONEncoder(encoding, cdc.InterfaceRegistry())
+	if err != nil {
+		return nil, err
+	}
 	// unmarshal binary into stargate response data structure
-	err := cdc.Unmarshal(bz, protoResponse)
+	err = cdc.Unmarshal(bz, protoResponse)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "to proto")
 	}
 
-	bz, err = cdc.MarshalJSON(protoResponse)
+	bz, err = encode(protoResponse)
 	if err != nil {
 		return nil, errorsmod.Wrap(err, "to json")
 	}
@@ -29,6 +29,7 @@ func (k Keeper) OnOpenChannel(
 	msg wasmvmtypes.IBCChannelOpenMsg,
 ) (string, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-open-channel")
+	ctx = k.withCachedParams(ctx)
 	_, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return "", err
@@ -43,6 +44,9 @@ func (k Keeper) OnOpenChannel(
 	if execErr != nil {
 		return "", errorsmod.Wrap(types.ErrExecuteFailed, execErr.Error())
 	}
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return "", err
+	}
 	if res != nil && res.Ok != nil {
 		return res.Ok.Version, nil
 	}
@@ -62,6 +66,7 @@ func (k Keeper) OnConnectChannel(
 	msg wasmvmtypes.IBCChannelConnectMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-connect-channel")
+	ctx = k.withCachedParams(ctx)
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
@@ -84,6 +89,9 @@ func (k Keeper) OnConnectChannel(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return err
+	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
@@ -99,6 +107,7 @@ func (k Keeper) OnCloseChannel(
 	msg wasmvmtypes.IBCChannelCloseMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-close-channel")
+	ctx = k.withCachedParams(ctx)
 
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
@@ -122,6 +131,9 @@ func (k Keeper) OnCloseChannel(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return err
+	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
@@ -137,6 +149,7 @@ func (k Keeper) OnRecvPacket(
 	msg wasmvmtypes.IBCPacketReceiveMsg,
 ) (ibcexported.Acknowledgement, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-recv-packet")
+	ctx = k.withCachedParams(ctx)
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return nil, err
@@ -164,6 +177,9 @@ func (k Keeper) OnRecvPacket(
 			Response: &channeltypes.Acknowledgement_Error{Error: res.Err},
 		}, nil
 	}
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return nil, err
+	}
 	// note submessage reply results can overwrite the `Acknowledgement` data
 	data, err := k.handleContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok.Messages, res.Ok.Attributes, res.Ok.Acknowledgement, res.Ok.Events)
 	if err != nil {
@@ -210,6 +226,7 @@ func (k Keeper) OnAckPacket(
 	msg wasmvmtypes.IBCPacketAckMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-ack-packet")
+	ctx = k.withCachedParams(ctx)
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
 		return err
@@ -232,6 +249,9 @@ func (k Keeper) OnAckPacket(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return err
+	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
@@ -244,6 +264,7 @@ func (k Keeper) OnTimeoutPacket(
 	msg wasmvmtypes.IBCPacketTimeoutMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-timeout-packet")
+	ctx = k.withCachedParams(ctx)
 
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
@@ -267,6 +288,9 @@ func (k Keeper) OnTimeoutPacket(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return err
+	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
@@ -278,6 +302,7 @@ func (k Keeper) IBCSourceCallback(
 	msg wasmvmtypes.IBCSourceCallbackMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-source-chain-callback")
+	ctx = k.withCachedParams(ctx)
 
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
@@ -301,6 +326,9 @@ func (k Keeper) IBCSourceCallback(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return err
+	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
@@ -312,6 +340,7 @@ func (k Keeper) IBCDestinationCallback(
 	msg wasmvmtypes.IBCDestinationCallbackMsg,
 ) error {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "ibc-destination-chain-callback")
+	ctx = k.withCachedParams(ctx)
 
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddr)
 	if err != nil {
@@ -335,6 +364,9 @@ func (k Keeper) IBCDestinationCallback(
 		return types.MarkErrorDeterministic(errorsmod.Wrap(types.ErrExecuteFailed, res.Err))
 	}
 
+	if err := k.recordContractActivity(ctx, contractAddr); err != nil {
+		return err
+	}
 	return k.handleIBCBasicContractResponse(ctx, contractAddr, contractInfo.IBCPortID, res.Ok)
 }
 
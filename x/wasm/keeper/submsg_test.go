@@ -601,7 +601,7 @@ func TestInstantiateGovSubMsgAuthzPropagated(t *testing.T) {
 	}{
 		"default policy - rejected": {
 			policy: DefaultAuthorizationPolicy{},
-			expErr: sdkerrors.ErrUnauthorized,
+			expErr: types.ErrInstantiateNotPermitted,
 		},
 		"propagating gov policy - accepted": {
 			policy: newGovAuthorizationPolicy(map[types.AuthorizationPolicyAction]struct{}{
@@ -610,13 +610,13 @@ func TestInstantiateGovSubMsgAuthzPropagated(t *testing.T) {
 		},
 		"non propagating gov policy - rejected in sub-msg": {
 			policy: newGovAuthorizationPolicy(nil),
-			expErr: sdkerrors.ErrUnauthorized,
+			expErr: types.ErrInstantiateNotPermitted,
 		},
 		"propagating gov policy with diff action - rejected": {
 			policy: newGovAuthorizationPolicy(map[types.AuthorizationPolicyAction]struct{}{
 				types.AuthZActionMigrateContract: {},
 			}),
-			expErr: sdkerrors.ErrUnauthorized,
+			expErr: types.ErrInstantiateNotPermitted,
 		},
 	}
 	for name, spec := range specs {
@@ -235,7 +235,7 @@ func createTestInput(
 	for _, v := range keys {
 		ms.MountStoreWithDB(v, storetypes.StoreTypeIAVL, db)
 	}
-	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := storetypes.NewTransientStoreKeys(paramstypes.TStoreKey, types.TStoreKey)
 	for _, v := range tkeys {
 		ms.MountStoreWithDB(v, storetypes.StoreTypeTransient, db)
 	}
@@ -387,7 +387,7 @@ func createTestInput(
 		vmConfig,
 		availableCapabilities,
 		authtypes.NewModuleAddress(govtypes.ModuleName).String(),
-		opts...,
+		append([]Option{WithTransientStoreService(runtime.NewTransientStoreService(tkeys[types.TStoreKey]))}, opts...)...,
 	)
 	require.NoError(t, keeper.SetParams(ctx, types.DefaultParams()))
 
@@ -0,0 +1,75 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// IsUploadSpamProtected returns true when code uploads of the creator are subject to the upload deposit and quota.
+// Only uploads by non-privileged accounts on chains where everybody can upload code are protected.
+func (k Keeper) IsUploadSpamProtected(ctx context.Context, creator sdk.AccAddress) bool {
+	return k.isUploadSpamProtected(k.GetParams(ctx), creator)
+}
+
+func (k Keeper) isUploadSpamProtected(params types.Params, creator sdk.AccAddress) bool {
+	return params.UploadSpamProtection.Enabled() &&
+		params.CodeUploadAccess.Permission == types.AccessTypeEverybody &&
+		creator.String() != k.authority
+}
+
+// chargeUpload applies the upload quota and burns the upload deposit for code stored by a non-privileged account.
+// The params are read without gas so that nothing is charged when the spam protection is disabled.
+func (k Keeper) chargeUpload(ctx sdk.Context, creator sdk.AccAddress) error {
+	params := k.GetParams(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()))
+	if !k.isUploadSpamProtected(params, creator) {
+		return nil
+	}
+	p := params.UploadSpamProtection
+	if p.MaxUploadsPerEpoch != 0 {
+		epoch := p.Epoch(ctx.BlockHeight())
+		uploads := k.GetUploadCount(ctx, creator, epoch)
+		if uploads >= p.MaxUploadsPerEpoch {
+			return errorsmod.Wrapf(types.ErrUploadQuotaExceeded, "%d of %d uploads until height %d", uploads, p.MaxUploadsPerEpoch, p.EpochEndHeight(epoch))
+		}
+		if err := k.setUploadCount(ctx, creator, epoch, uploads+1); err != nil {
+			return err
+		}
+	}
+	if !p.Deposit.IsZero() {
+		if err := k.burner.SendCoinsFromAccountToModule(ctx, creator, types.ModuleName, p.Deposit); err != nil {
+			return errorsmod.Wrap(err, "upload deposit")
+		}
+		if err := k.burner.BurnCoins(ctx, types.ModuleName, p.Deposit); err != nil {
+			return errorsmod.Wrap(err, "burn upload deposit")
+		}
+	}
+	return nil
+}
+
+// GetUploadCount returns the number of codes stored by the account within the epoch
+func (k Keeper) GetUploadCount(ctx context.Context, creator sdk.AccAddress, epoch uint64) uint64 {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.GetUploadQuotaKey(creator))
+	if err != nil {
+		panic(err)
+	}
+	if len(bz) != 16 || binary.BigEndian.Uint64(bz[:8]) != epoch {
+		// counter of a previous epoch
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz[8:])
+}
+
+// setUploadCount stores the number of codes stored by the account within the epoch: `<epoch><count>`
+func (k Keeper) setUploadCount(ctx context.Context, creator sdk.AccAddress, epoch, count uint64) error {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz[:8], epoch)
+	binary.BigEndian.PutUint64(bz[8:], count)
+	return k.storeService.OpenKVStore(ctx).Set(types.GetUploadQuotaKey(creator), bz)
+}
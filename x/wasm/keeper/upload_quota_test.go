@@ -0,0 +1,130 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestUploadQuota(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	creator := keepers.Faucet.NewFundedRandomAccount(parentCtx, sdk.NewInt64Coin("denom", 100000))
+	authority, err := sdk.AccAddressFromBech32(k.GetAuthority())
+	require.NoError(t, err)
+	params := types.DefaultParams()
+	params.UploadSpamProtection = types.UploadSpamProtection{MaxUploadsPerEpoch: 2, EpochLength: 10}
+	require.NoError(t, k.SetParams(parentCtx, params))
+	ctx := parentCtx.WithBlockHeight(10)
+
+	// when quota is used
+	for i := 0; i < 2; i++ {
+		_, _, err = k.create(ctx, creator, hackatomWasm, nil, DefaultAuthorizationPolicy{})
+		require.NoError(t, err)
+	}
+	// then further uploads in the same epoch are rejected
+	_, _, err = k.create(ctx.WithBlockHeight(19), creator, hackatomWasm, nil, DefaultAuthorizationPolicy{})
+	require.ErrorIs(t, err, types.ErrUploadQuotaExceeded)
+	assert.Contains(t, err.Error(), "until height 19")
+	// and privileged accounts are not limited
+	_, _, err = k.create(ctx, authority, hackatomWasm, nil, GovAuthorizationPolicy{})
+	require.NoError(t, err)
+
+	querier := NewGrpcQuerier(k.cdc, k.storeService, k, k.queryGasLimit)
+	res, err := querier.UploadQuota(ctx, &types.QueryUploadQuotaRequest{Address: creator.String()})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryUploadQuotaResponse{MaxUploadsPerEpoch: 2, Uploads: 2, EpochEndHeight: 19}, res)
+
+	// when the epoch rolls over
+	ctx = ctx.WithBlockHeight(20)
+	assert.Equal(t, uint64(0), k.GetUploadCount(ctx, creator, 2))
+	_, _, err = k.create(ctx, creator, hackatomWasm, nil, DefaultAuthorizationPolicy{})
+	require.NoError(t, err)
+	// then the quota starts again
+	assert.Equal(t, uint64(1), k.GetUploadCount(ctx, creator, 2))
+	res, err = querier.UploadQuota(ctx, &types.QueryUploadQuotaRequest{Address: creator.String()})
+	require.NoError(t, err)
+	assert.Equal(t, &types.QueryUploadQuotaResponse{MaxUploadsPerEpoch: 2, Uploads: 1, EpochEndHeight: 29}, res)
+}
+
+func TestUploadDeposit(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	myDeposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 100))
+
+	specs := map[string]struct {
+		uploadAccess types.AccessConfig
+		funds        sdk.Coins
+		expCharged   bool
+		expErr       bool
+	}{
+		"deposit burned": {
+			uploadAccess: types.AllowEverybody,
+			funds:        sdk.NewCoins(sdk.NewInt64Coin("denom", 150)),
+			expCharged:   true,
+		},
+		"insufficient funds": {
+			uploadAccess: types.AllowEverybody,
+			funds:        sdk.NewCoins(sdk.NewInt64Coin("denom", 99)),
+			expErr:       true,
+		},
+		"not charged with restricted upload access": {
+			uploadAccess: types.AccessTypeAnyOfAddresses.With(RandomAccountAddress(t)),
+			funds:        sdk.NewCoins(sdk.NewInt64Coin("denom", 150)),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := parentCtx.CacheContext()
+			creator := keepers.Faucet.NewFundedRandomAccount(ctx, spec.funds...)
+			params := types.DefaultParams()
+			params.CodeUploadAccess = spec.uploadAccess
+			params.UploadSpamProtection = types.UploadSpamProtection{Deposit: myDeposit}
+			require.NoError(t, k.SetParams(ctx, params))
+			supplyBefore := keepers.BankKeeper.GetSupply(ctx, "denom")
+			// the gov policy allows the upload with restricted access, the creator is still non-privileged
+			var authZ types.AuthorizationPolicy = DefaultAuthorizationPolicy{}
+			if spec.uploadAccess.Permission != types.AccessTypeEverybody {
+				authZ = GovAuthorizationPolicy{}
+			}
+
+			// when
+			_, _, gotErr := k.create(ctx, creator, hackatomWasm, nil, authZ)
+
+			// then
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Equal(t, spec.funds, keepers.BankKeeper.GetAllBalances(ctx, creator))
+				return
+			}
+			require.NoError(t, gotErr)
+			expBalance, expSupply := spec.funds, supplyBefore
+			if spec.expCharged {
+				expBalance = spec.funds.Sub(myDeposit...)
+				expSupply = supplyBefore.Sub(myDeposit[0])
+			}
+			assert.Equal(t, expBalance, keepers.BankKeeper.GetAllBalances(ctx, creator))
+			assert.Equal(t, expSupply, keepers.BankKeeper.GetSupply(ctx, "denom"))
+		})
+	}
+}
+
+func TestChargeUploadGasWhenDisabled(t *testing.T) {
+	parentCtx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	k := keepers.WasmKeeper
+	require.NoError(t, k.SetParams(parentCtx, types.DefaultParams()))
+	ctx := parentCtx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	// when
+	require.NoError(t, k.chargeUpload(ctx, RandomAccountAddress(t)))
+
+	// then
+	assert.Equal(t, storetypes.Gas(0), ctx.GasMeter().GasConsumed())
+}
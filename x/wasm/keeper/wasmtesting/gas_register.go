@@ -8,12 +8,14 @@ import (
 
 // MockGasRegister mock that implements keeper.GasRegister
 type MockGasRegister struct {
-	SetupContractCostFn func(discount bool, msgLen int) storetypes.Gas
-	ReplyCostFn         func(discount bool, reply wasmvmtypes.Reply) storetypes.Gas
-	EventCostsFn        func(evts []wasmvmtypes.EventAttribute) storetypes.Gas
-	ToWasmVMGasFn       func(source storetypes.Gas) uint64
-	FromWasmVMGasFn     func(source uint64) storetypes.Gas
-	UncompressCostsFn   func(byteLength int) storetypes.Gas
+	SetupContractCostFn     func(discount bool, msgLen int) storetypes.Gas
+	ReplyCostFn             func(discount bool, reply wasmvmtypes.Reply) storetypes.Gas
+	EventCostsFn            func(evts []wasmvmtypes.EventAttribute) storetypes.Gas
+	ToWasmVMGasFn           func(source storetypes.Gas) uint64
+	FromWasmVMGasFn         func(source uint64) storetypes.Gas
+	UncompressCostsFn       func(byteLength int) storetypes.Gas
+	ChecksumCostsFn         func(byteLength int) storetypes.Gas
+	ContractActivityCostsFn func() storetypes.Gas
 }
 
 func (m MockGasRegister) UncompressCosts(byteLength int) storetypes.Gas {
@@ -23,6 +25,13 @@ func (m MockGasRegister) UncompressCosts(byteLength int) storetypes.Gas {
 	return m.UncompressCostsFn(byteLength)
 }
 
+func (m MockGasRegister) ChecksumCosts(byteLength int) storetypes.Gas {
+	if m.ChecksumCostsFn == nil {
+		panic("not expected to be called")
+	}
+	return m.ChecksumCostsFn(byteLength)
+}
+
 func (m MockGasRegister) SetupContractCost(discount bool, msgLen int) storetypes.Gas {
 	if m.SetupContractCostFn == nil {
 		panic("not expected to be called")
@@ -57,3 +66,10 @@ func (m MockGasRegister) FromWasmVMGas(source uint64) storetypes.Gas {
 	}
 	return m.FromWasmVMGasFn(source)
 }
+
+func (m MockGasRegister) ContractActivityCosts() storetypes.Gas {
+	if m.ContractActivityCostsFn == nil {
+		panic("not expected to be called")
+	}
+	return m.ContractActivityCostsFn()
+}
@@ -0,0 +1,45 @@
+package v4
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// SetCodeInstantiationCountFn stores the number of instantiations for a code id
+type SetCodeInstantiationCountFn func(ctx context.Context, codeID, count uint64) error
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	IterateCodeInfos(ctx context.Context, cb func(uint64, types.CodeInfo) bool)
+	IterateContractsByCode(ctx context.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper                      wasmKeeper
+	setCodeInstantiationCountFn SetCodeInstantiationCountFn
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fn SetCodeInstantiationCountFn) Migrator {
+	return Migrator{keeper: k, setCodeInstantiationCountFn: fn}
+}
+
+// Migrate4to5 migrates from version 4 to 5.
+// The instantiation count for each code id is backfilled from the contracts-by-code index.
+func (m Migrator) Migrate4to5(ctx sdk.Context) error {
+	var err error
+	m.keeper.IterateCodeInfos(ctx, func(codeID uint64, _ types.CodeInfo) bool {
+		var count uint64
+		m.keeper.IterateContractsByCode(ctx, codeID, func(sdk.AccAddress) bool {
+			count++
+			return false
+		})
+		err = m.setCodeInstantiationCountFn(ctx, codeID, count)
+		return err != nil
+	})
+	return err
+}
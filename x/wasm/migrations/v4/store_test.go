@@ -0,0 +1,70 @@
+package v4_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/prefix"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate4To5(t *testing.T) {
+	AvailableCapabilities := []string{"iterator", "staking", "stargate", "cosmwasm_1_1"}
+	ctx, keepers := keeper.CreateTestInput(t, false, AvailableCapabilities)
+	wasmKeeper := keepers.WasmKeeper
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example1 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	unused := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+
+	initMsg := keeper.HackatomExampleInitMsg{
+		Verifier:    keeper.RandomAccountAddress(t),
+		Beneficiary: keeper.RandomAccountAddress(t),
+	}
+	initMsgBz, err := json.Marshal(initMsg)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, _, err := keepers.ContractKeeper.Instantiate(ctx, example1.CodeID, creator, nil, initMsgBz, "example 1", nil)
+		require.NoError(t, err)
+	}
+	_, _, err = keepers.ContractKeeper.Instantiate2(ctx, example2.CodeID, creator, nil, initMsgBz, "example 2", nil, []byte("salt"), false)
+	require.NoError(t, err)
+
+	// remove counters and index to simulate the pre-migration state
+	store := ctx.KVStore(keepers.WasmStoreKey)
+	for _, p := range [][]byte{types.CodeInstantiationCountPrefix, types.CodesByInstantiationCountPrefix} {
+		prefixStore := prefix.NewStore(store, p)
+		iter := prefixStore.Iterator(nil, nil)
+		var keys [][]byte
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, iter.Key())
+		}
+		require.NoError(t, iter.Close())
+		for _, k := range keys {
+			prefixStore.Delete(k)
+		}
+	}
+	require.Equal(t, uint64(0), wasmKeeper.GetCodeInstantiationCount(ctx, example1.CodeID))
+
+	// when
+	err = keeper.NewMigrator(*wasmKeeper, nil).Migrate4to5(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), wasmKeeper.GetCodeInstantiationCount(ctx, example1.CodeID))
+	assert.Equal(t, uint64(1), wasmKeeper.GetCodeInstantiationCount(ctx, example2.CodeID))
+	assert.Equal(t, uint64(0), wasmKeeper.GetCodeInstantiationCount(ctx, unused.CodeID))
+
+	// and the usage index is restored
+	assert.True(t, store.Has(types.GetCodesByInstantiationCountKey(3, example1.CodeID)))
+	assert.True(t, store.Has(types.GetCodesByInstantiationCountKey(1, example2.CodeID)))
+	assert.True(t, store.Has(types.GetCodesByInstantiationCountKey(0, unused.CodeID)))
+}
@@ -0,0 +1,39 @@
+package v5
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// AddToCodeIDsByChecksumIndexFn adds a code id to the checksum index
+type AddToCodeIDsByChecksumIndexFn func(ctx context.Context, checksum []byte, codeID uint64) error
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	IterateCodeInfos(ctx context.Context, cb func(uint64, types.CodeInfo) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper                        wasmKeeper
+	addToCodeIDsByChecksumIndexFn AddToCodeIDsByChecksumIndexFn
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fn AddToCodeIDsByChecksumIndexFn) Migrator {
+	return Migrator{keeper: k, addToCodeIDsByChecksumIndexFn: fn}
+}
+
+// Migrate5to6 migrates from version 5 to 6.
+// The checksum to code ids index is backfilled from the stored code infos.
+func (m Migrator) Migrate5to6(ctx sdk.Context) error {
+	var err error
+	m.keeper.IterateCodeInfos(ctx, func(codeID uint64, info types.CodeInfo) bool {
+		err = m.addToCodeIDsByChecksumIndexFn(ctx, info.CodeHash, codeID)
+		return err != nil
+	})
+	return err
+}
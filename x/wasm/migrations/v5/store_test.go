@@ -0,0 +1,44 @@
+package v5_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/store/prefix"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate5To6(t *testing.T) {
+	ctx, keepers := keeper.CreateTestInput(t, false, keeper.BuiltInCapabilities())
+	wasmKeeper := keepers.WasmKeeper
+
+	example1 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	example2 := keeper.StoreHackatomExampleContract(t, ctx, keepers)
+	example3 := keeper.StoreReflectContract(t, ctx, keepers)
+
+	// remove index to simulate the pre-migration state
+	store := ctx.KVStore(keepers.WasmStoreKey)
+	prefixStore := prefix.NewStore(store, types.CodeIDsByChecksumPrefix)
+	iter := prefixStore.Iterator(nil, nil)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	require.NoError(t, iter.Close())
+	for _, k := range keys {
+		prefixStore.Delete(k)
+	}
+	require.Empty(t, wasmKeeper.GetCodeIDsByChecksum(ctx, example1.Checksum))
+
+	// when
+	err := keeper.NewMigrator(*wasmKeeper, nil).Migrate5to6(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{example1.CodeID, example2.CodeID}, wasmKeeper.GetCodeIDsByChecksum(ctx, example1.Checksum))
+	assert.Equal(t, []uint64{example3.CodeID}, wasmKeeper.GetCodeIDsByChecksum(ctx, example3.Checksum))
+}
@@ -0,0 +1,50 @@
+package v6
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// AddToContractLabelIndexFn adds a contract to the label index
+type AddToContractLabelIndexFn func(ctx context.Context, label string, contractAddress sdk.AccAddress) error
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	GetParams(ctx context.Context) types.Params
+	SetParams(ctx context.Context, ps types.Params) error
+	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper                    wasmKeeper
+	addToContractLabelIndexFn AddToContractLabelIndexFn
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper, fn AddToContractLabelIndexFn) Migrator {
+	return Migrator{keeper: k, addToContractLabelIndexFn: fn}
+}
+
+// Migrate6to7 migrates from version 6 to 7.
+// The IndexContractLabels param is enabled and the label to contracts index is backfilled from
+// the stored contract infos.
+func (m Migrator) Migrate6to7(ctx sdk.Context) error {
+	params := m.keeper.GetParams(ctx)
+	if params.IndexContractLabels {
+		return nil
+	}
+	params.IndexContractLabels = true
+	if err := m.keeper.SetParams(ctx, params); err != nil {
+		return err
+	}
+	var err error
+	m.keeper.IterateContractInfo(ctx, func(contractAddr sdk.AccAddress, info types.ContractInfo) bool {
+		err = m.addToContractLabelIndexFn(ctx, info.Label, contractAddr)
+		return err != nil
+	})
+	return err
+}
@@ -0,0 +1,41 @@
+package v6_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate6To7(t *testing.T) {
+	ctx, keepers := keeper.CreateTestInput(t, false, keeper.BuiltInCapabilities())
+	wasmKeeper := keepers.WasmKeeper
+	// contracts are not indexed before the migration
+	example1 := keeper.InstantiateHackatomExampleContract(t, ctx, keepers)
+	example2 := keeper.InstantiateHackatomExampleContract(t, ctx, keepers)
+	example3 := keeper.InstantiateReflectExampleContract(t, ctx, keepers)
+	require.False(t, wasmKeeper.GetParams(ctx).IndexContractLabels)
+
+	// when
+	err := keeper.NewMigrator(*wasmKeeper, nil).Migrate6to7(ctx)
+
+	// then
+	require.NoError(t, err)
+	assert.True(t, wasmKeeper.GetParams(ctx).IndexContractLabels)
+	q := keeper.Querier(wasmKeeper)
+	got, err := q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: example1.Label})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{example1.Contract.String(), example2.Contract.String()}, got.Contracts)
+	got, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: example3.Label})
+	require.NoError(t, err)
+	assert.Equal(t, []string{example3.Contract.String()}, got.Contracts)
+
+	// and new contracts are indexed
+	example4 := keeper.InstantiateReflectExampleContract(t, ctx, keepers)
+	got, err = q.ContractsByLabel(ctx, &types.QueryContractsByLabelRequest{Label: example4.Label})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{example3.Contract.String(), example4.Contract.String()}, got.Contracts)
+}
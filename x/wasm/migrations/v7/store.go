@@ -0,0 +1,36 @@
+package v7
+
+import (
+	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+// wasmKeeper abstract keeper
+type wasmKeeper interface {
+	GetParams(ctx context.Context) types.Params
+	SetParams(ctx context.Context, ps types.Params) error
+}
+
+// Migrator is a struct for handling in-place store migrations.
+type Migrator struct {
+	keeper wasmKeeper
+}
+
+// NewMigrator returns a new Migrator.
+func NewMigrator(k wasmKeeper) Migrator {
+	return Migrator{keeper: k}
+}
+
+// Migrate7to8 migrates from version 7 to 8.
+// An unset default admin policy is set to error which keeps the behavior of previous versions.
+func (m Migrator) Migrate7to8(ctx sdk.Context) error {
+	params := m.keeper.GetParams(ctx)
+	if params.DefaultAdminPolicy != types.DefaultAdminPolicyUnspecified {
+		return nil
+	}
+	params.DefaultAdminPolicy = types.DefaultAdminPolicyError
+	return m.keeper.SetParams(ctx, params)
+}
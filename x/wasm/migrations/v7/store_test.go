@@ -0,0 +1,52 @@
+package v7_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CosmWasm/wasmd/x/wasm/keeper"
+	"github.com/CosmWasm/wasmd/x/wasm/types"
+)
+
+func TestMigrate7To8(t *testing.T) {
+	specs := map[string]struct {
+		src types.DefaultAdminPolicy
+		exp types.DefaultAdminPolicy
+	}{
+		"unspecified": {
+			src: types.DefaultAdminPolicyUnspecified,
+			exp: types.DefaultAdminPolicyError,
+		},
+		"error": {
+			src: types.DefaultAdminPolicyError,
+			exp: types.DefaultAdminPolicyError,
+		},
+		"creator": {
+			src: types.DefaultAdminPolicyCreator,
+			exp: types.DefaultAdminPolicyCreator,
+		},
+		"none": {
+			src: types.DefaultAdminPolicyNone,
+			exp: types.DefaultAdminPolicyNone,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, keepers := keeper.CreateTestInput(t, false, []string{"iterator", "staking", "stargate", "cosmwasm_1_1"})
+			wasmKeeper := keepers.WasmKeeper
+			params := types.DefaultParams()
+			params.DefaultAdminPolicy = spec.src
+			require.NoError(t, wasmKeeper.SetParams(ctx, params))
+
+			// when
+			err := keeper.NewMigrator(*wasmKeeper, nil).Migrate7to8(ctx)
+
+			// then
+			require.NoError(t, err)
+			params.DefaultAdminPolicy = spec.exp
+			assert.Equal(t, params, wasmKeeper.GetParams(ctx))
+		})
+	}
+}
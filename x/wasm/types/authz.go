@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"context"
+	"errors"
 	"strings"
 
 	wasmvm "github.com/CosmWasm/wasmvm/v2"
@@ -45,6 +46,8 @@ func (a StoreCodeAuthorization) MsgTypeURL() string {
 }
 
 // Accept implements Authorization.Accept.
+// Gzipped code is uncompressed before hashing so that grants always match the checksum of the uncompressed code
+// as shown in the code info.
 func (a *StoreCodeAuthorization) Accept(ctx context.Context, msg sdk.Msg) (authztypes.AcceptResponse, error) {
 	storeMsg, ok := msg.(*MsgStoreCode)
 	if !ok {
@@ -62,7 +65,10 @@ func (a *StoreCodeAuthorization) Accept(ctx context.Context, msg sdk.Msg) (authz
 		sdk.UnwrapSDKContext(ctx).GasMeter().
 			ConsumeGas(gasRegister.UncompressCosts(len(code)), "Uncompress gzip bytecode")
 		wasmCode, err := ioutils.Uncompress(code, int64(MaxWasmSize))
-		if err != nil {
+		switch {
+		case errors.Is(err, ioutils.ErrLimit):
+			return authztypes.AcceptResponse{}, ErrLimit.Wrapf("uncompressed wasm code exceeds max code size of %d bytes", MaxWasmSize)
+		case err != nil:
 			return authztypes.AcceptResponse{}, sdkerrors.ErrInvalidRequest.Wrap("uncompress wasm archive")
 		}
 		code = wasmCode
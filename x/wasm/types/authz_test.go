@@ -1,6 +1,8 @@
 package types
 
 import (
+	"context"
+	"crypto/sha256"
 	"math"
 	"strings"
 	"testing"
@@ -16,6 +18,8 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
 )
 
 func TestContractAuthzFilterValidate(t *testing.T) {
@@ -873,6 +877,16 @@ func TestStoreCodeAuthorizationAccept(t *testing.T) {
 	emptyPermissionReflectCodeGrant, err := NewCodeGrant(reflectCodeHash, nil)
 	require.NoError(t, err)
 
+	gzippedReflectCode, err := ioutils.GzipIt(reflectWasmCode)
+	require.NoError(t, err)
+	// the grant matches the gzipped bytes, while the authorization compares the checksum of the uncompressed code
+	gzippedReflectCodeHash := sha256.Sum256(gzippedReflectCode)
+	grantGzippedReflectCode, err := NewCodeGrant(gzippedReflectCodeHash[:], nil)
+	require.NoError(t, err)
+
+	oversizedCode, err := ioutils.GzipIt(append(append([]byte{}, reflectWasmCode[:4]...), make([]byte, MaxWasmSize)...))
+	require.NoError(t, err)
+
 	specs := map[string]struct {
 		auth      authztypes.Authorization
 		msg       sdk.Msg
@@ -923,6 +937,46 @@ func TestStoreCodeAuthorizationAccept(t *testing.T) {
 				Accept: true,
 			},
 		},
+		"accepted gzipped reflect code - grant on uncompressed checksum": {
+			auth: NewStoreCodeAuthorization(*grantReflectCode),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          gzippedReflectCode,
+				InstantiatePermission: &AllowNobody,
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: true,
+			},
+		},
+		"not accepted - grant on gzipped checksum": {
+			auth: NewStoreCodeAuthorization(*grantGzippedReflectCode),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          gzippedReflectCode,
+				InstantiatePermission: &AllowNobody,
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: false,
+			},
+		},
+		"uncompressed size exceeds max code size": {
+			auth: NewStoreCodeAuthorization(*grantWildcard),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          oversizedCode,
+				InstantiatePermission: &AllowEverybody,
+			},
+			expErr: ErrLimit,
+		},
+		"invalid gzip": {
+			auth: NewStoreCodeAuthorization(*grantWildcard),
+			msg: &MsgStoreCode{
+				Sender:                sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				WASMByteCode:          gzippedReflectCode[:len(gzippedReflectCode)/2],
+				InstantiatePermission: &AllowEverybody,
+			},
+			expErr: sdkerrors.ErrInvalidRequest,
+		},
 		"not accepted - no matching code": {
 			auth: NewStoreCodeAuthorization(*grantOtherCode),
 			msg: &MsgStoreCode{
@@ -961,7 +1015,7 @@ func TestStoreCodeAuthorizationAccept(t *testing.T) {
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			ctx := sdk.Context{}.WithGasMeter(storetypes.NewInfiniteGasMeter())
+			ctx := WithGasRegister(sdk.Context{}.WithContext(context.Background()).WithGasMeter(storetypes.NewInfiniteGasMeter()), NewDefaultWasmGasRegister())
 			gotResult, gotErr := spec.auth.Accept(ctx, spec.msg)
 			if spec.expErr != nil {
 				require.ErrorIs(t, gotErr, spec.expErr)
@@ -5,6 +5,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/msgservice"
+	"github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 )
@@ -28,6 +29,15 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
 	cdc.RegisterConcrete(&MsgRemoveCodeUploadParamsAddresses{}, "wasm/MsgRemoveCodeUploadParamsAddresses", nil)
 	cdc.RegisterConcrete(&MsgStoreAndMigrateContract{}, "wasm/MsgStoreAndMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateContractLabel{}, "wasm/MsgUpdateContractLabel", nil)
+	cdc.RegisterConcrete(&MsgSetContractState{}, "wasm/MsgSetContractState", nil)
+	cdc.RegisterConcrete(&MsgSetFeelessExecutions{}, "wasm/MsgSetFeelessExecutions", nil)
+	cdc.RegisterConcrete(&MsgSetContractGasBudgets{}, "wasm/MsgSetContractGasBudgets", nil)
+	cdc.RegisterConcrete(&MsgFreezeCodeByChecksum{}, "wasm/MsgFreezeCodeByChecksum", nil)
+	cdc.RegisterConcrete(&MsgDeprecateCode{}, "wasm/MsgDeprecateCode", nil)
+	cdc.RegisterConcrete(&MsgSetContractStateAccess{}, "wasm/MsgSetContractStateAccess", nil)
+	cdc.RegisterConcrete(&MsgForceMigrateWithoutAdminCheck{}, "wasm/MsgForceMigrateWithoutAdminCheck", nil)
+	cdc.RegisterConcrete(&MsgExecuteContractCompat{}, "wasm/MsgExecuteContractCompat", nil)
+	cdc.RegisterConcrete(&MsgPruneContractHistory{}, "wasm/MsgPruneContractHistory", nil)
 
 	cdc.RegisterInterface((*ContractInfoExtension)(nil), nil)
 
@@ -83,6 +93,15 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgRemoveCodeUploadParamsAddresses{},
 		&MsgStoreAndMigrateContract{},
 		&MsgUpdateContractLabel{},
+		&MsgSetContractState{},
+		&MsgSetFeelessExecutions{},
+		&MsgSetContractGasBudgets{},
+		&MsgFreezeCodeByChecksum{},
+		&MsgDeprecateCode{},
+		&MsgSetContractStateAccess{},
+		&MsgForceMigrateWithoutAdminCheck{},
+		&MsgExecuteContractCompat{},
+		&MsgPruneContractHistory{},
 	)
 	registry.RegisterInterface("cosmwasm.wasm.v1.ContractInfoExtension", (*ContractInfoExtension)(nil))
 
@@ -109,6 +128,11 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&ContractMigrationAuthorization{},
 	)
 
+	registry.RegisterImplementations(
+		(*tx.TxExtensionOptionI)(nil),
+		&GasBreakdownExtensionOption{},
+	)
+
 	msgservice.RegisterMsgServiceDesc(registry, &_Msg_serviceDesc)
 
 	// legacy gov v1beta1 types that may be used for unmarshalling stored gov data
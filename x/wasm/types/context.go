@@ -26,6 +26,24 @@ const (
 
 	// contextKeyExecModeSimulation contextKey = iota
 	_
+
+	// dispatch counter for submessages in the current tx message
+	contextKeyDispatchCounter contextKey = iota
+
+	// json encoding of the proto query responses passed to contracts
+	contextKeyQueryJSONEncoding contextKey = iota
+
+	// contracts that the gas limit of the current tx was charged to
+	contextKeyGasBudgetReservations contextKey = iota
+
+	// gas breakdown events requested for the simulated tx
+	contextKeyGasBreakdown contextKey = iota
+
+	// execution receipts of the current tx that wait for the tx result
+	contextKeyPendingExecutionReceipts contextKey = iota
+
+	// wasm params loaded for the current contract call and its nested calls
+	contextKeyParams contextKey = iota
 )
 
 // WithTXCounter stores a transaction counter value in the context
@@ -101,3 +119,120 @@ func TxContractsFromContext(ctx context.Context) (TxContracts, bool) {
 	val, ok := ctx.Value(contextKeyTxContracts).(TxContracts)
 	return val, ok
 }
+
+// DispatchCounter assigns the submessages dispatched within a tx message their position in the dispatch order
+type DispatchCounter struct {
+	next uint32
+}
+
+// Next returns the index of the next dispatched submessage
+func (c *DispatchCounter) Next() uint32 {
+	i := c.next
+	c.next++
+	return i
+}
+
+// WithDispatchCounter stores the dispatch counter into the context returned
+func WithDispatchCounter(ctx sdk.Context, c *DispatchCounter) sdk.Context {
+	if c == nil {
+		panic("dispatch counter must not be nil")
+	}
+	return ctx.WithValue(contextKeyDispatchCounter, c)
+}
+
+// DispatchCounterFromContext reads the dispatch counter from the context
+func DispatchCounterFromContext(ctx context.Context) (*DispatchCounter, bool) {
+	val, ok := ctx.Value(contextKeyDispatchCounter).(*DispatchCounter)
+	return val, ok
+}
+
+// WithQueryJSONEncoding stores the json encoding of proto query responses into the context returned
+func WithQueryJSONEncoding(ctx sdk.Context, e QueryJSONEncoding) sdk.Context {
+	return ctx.WithValue(contextKeyQueryJSONEncoding, e)
+}
+
+// QueryJSONEncodingFromContext reads the json encoding of proto query responses from the context.
+// Returns QueryJSONEncodingV1 when not set.
+func QueryJSONEncodingFromContext(ctx context.Context) QueryJSONEncoding {
+	val, ok := ctx.Value(contextKeyQueryJSONEncoding).(QueryJSONEncoding)
+	if !ok || val == QueryJSONEncodingUnspecified {
+		return QueryJSONEncodingV1
+	}
+	return val
+}
+
+// WithGasBudgetReservations stores the contracts that the gas limit of the current tx was charged to into the
+// context returned
+func WithGasBudgetReservations(ctx sdk.Context, contracts []sdk.AccAddress) sdk.Context {
+	return ctx.WithValue(contextKeyGasBudgetReservations, contracts)
+}
+
+// HasGasBudgetReservation returns true when the gas limit of the current tx was charged to the contract's gas budget
+func HasGasBudgetReservation(ctx context.Context, contractAddr sdk.AccAddress) bool {
+	val, _ := ctx.Value(contextKeyGasBudgetReservations).([]sdk.AccAddress)
+	for _, a := range val {
+		if a.Equals(contractAddr) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithGasBreakdown stores the opt-in for gas breakdown events into the context returned
+func WithGasBreakdown(ctx sdk.Context, enabled bool) sdk.Context {
+	return ctx.WithValue(contextKeyGasBreakdown, enabled)
+}
+
+// IsGasBreakdownEnabled returns true when gas breakdown events were requested. Defaults to false.
+func IsGasBreakdownEnabled(ctx context.Context) bool {
+	val, _ := ctx.Value(contextKeyGasBreakdown).(bool)
+	return val
+}
+
+// PendingExecutionReceipt is the receipt of a top level contract execution that waits for the result of the tx
+type PendingExecutionReceipt struct {
+	Contract sdk.AccAddress
+	Receipt  ExecutionReceipt
+}
+
+// PendingExecutionReceipts collects the receipts of the top level contract executions in the current tx
+type PendingExecutionReceipts struct {
+	entries []PendingExecutionReceipt
+}
+
+// Add appends the receipt of an execution of the contract
+func (p *PendingExecutionReceipts) Add(contractAddr sdk.AccAddress, r ExecutionReceipt) {
+	p.entries = append(p.entries, PendingExecutionReceipt{Contract: contractAddr, Receipt: r})
+}
+
+// Take returns the collected receipts in execution order and resets the collection
+func (p *PendingExecutionReceipts) Take() []PendingExecutionReceipt {
+	entries := p.entries
+	p.entries = nil
+	return entries
+}
+
+// WithPendingExecutionReceipts stores the receipts collection of the current tx into the context returned
+func WithPendingExecutionReceipts(ctx sdk.Context, p *PendingExecutionReceipts) sdk.Context {
+	if p == nil {
+		panic("pending execution receipts must not be nil")
+	}
+	return ctx.WithValue(contextKeyPendingExecutionReceipts, p)
+}
+
+// PendingExecutionReceiptsFromContext reads the receipts collection of the current tx from the context
+func PendingExecutionReceiptsFromContext(ctx context.Context) (*PendingExecutionReceipts, bool) {
+	val, ok := ctx.Value(contextKeyPendingExecutionReceipts).(*PendingExecutionReceipts)
+	return val, ok
+}
+
+// WithParams stores the wasm params into the context returned
+func WithParams(ctx sdk.Context, p Params) sdk.Context {
+	return ctx.WithValue(contextKeyParams, p)
+}
+
+// ParamsFromContext reads the wasm params from the context
+func ParamsFromContext(ctx context.Context) (Params, bool) {
+	val, ok := ctx.Value(contextKeyParams).(Params)
+	return val, ok
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
 
 	errorsmod "cosmossdk.io/errors"
@@ -92,8 +96,82 @@ var (
 
 	// ErrExceedMaxCallDepth error if max message stack size is exceeded
 	ErrExceedMaxCallDepth = errorsmod.Register(DefaultCodespace, 30, "max call depth exceeded")
+
+	// ErrDuplicateStoreCode error for wasm code stored more than once within a transaction
+	ErrDuplicateStoreCode = errorsmod.Register(DefaultCodespace, 31, "duplicate store code")
+
+	// ErrUnknownAdmin error for an admin address that is neither an account nor a contract
+	ErrUnknownAdmin = errorsmod.Register(DefaultCodespace, 32, "unknown admin")
+
+	// ErrRawStateWritesDisabled error if raw contract state writes are not allowed by the chain params
+	ErrRawStateWritesDisabled = errorsmod.Register(DefaultCodespace, 33, "raw state writes disabled")
+
+	// ErrUploadQuotaExceeded error if an account stored more codes than allowed within an epoch
+	ErrUploadQuotaExceeded = errorsmod.Register(DefaultCodespace, 34, "upload quota exceeded")
+
+	// ErrInstantiateNotPermitted error if the instantiate config of a code does not allow the actor.
+	// See InstantiateNotPermittedError
+	ErrInstantiateNotPermitted = errorsmod.Register(DefaultCodespace, 35, "instantiate not permitted")
+
+	// ErrRateLimited error if the execution gas budget of a contract in the current block is used up
+	ErrRateLimited = errorsmod.Register(DefaultCodespace, 36, "rate limited")
+
+	// ErrCodeDeprecated error if a deprecated code is instantiated or used as migration target
+	ErrCodeDeprecated = errorsmod.Register(DefaultCodespace, 37, "code deprecated")
+
+	// ErrStateAccessControlDisabled error if contract state access control is not allowed by the chain params
+	ErrStateAccessControlDisabled = errorsmod.Register(DefaultCodespace, 38, "contract state access control disabled")
 )
 
+// maxInstantiateNotPermittedAddresses is the max number of allowed addresses listed in an InstantiateNotPermittedError
+const maxInstantiateNotPermittedAddresses = 5
+
+// InstantiateNotPermittedError is returned when the instantiate config of a code does not allow the actor.
+// It is reported with the ErrInstantiateNotPermitted code.
+type InstantiateNotPermittedError struct {
+	// CodeID is the code that can not be instantiated
+	CodeID uint64
+	// Permission is the access type of the instantiate config
+	Permission AccessType
+	// Addresses are the allowed addresses of the instantiate config, capped
+	Addresses []string
+	// TotalAddresses is the number of allowed addresses of the instantiate config
+	TotalAddresses int
+}
+
+// NewInstantiateNotPermittedError constructor
+func NewInstantiateNotPermittedError(codeID uint64, config AccessConfig) InstantiateNotPermittedError {
+	addrs := config.AllAuthorizedAddresses()
+	return InstantiateNotPermittedError{
+		CodeID:         codeID,
+		Permission:     config.Permission,
+		Addresses:      slices.Clone(addrs[:min(len(addrs), maxInstantiateNotPermittedAddresses)]),
+		TotalAddresses: len(addrs),
+	}
+}
+
+// implements stdlib error
+func (e InstantiateNotPermittedError) Error() string {
+	msg := fmt.Sprintf("code id %d with access type %s", e.CodeID, e.Permission)
+	if e.Permission == AccessTypeAnyOfAddresses {
+		msg += fmt.Sprintf(", allowed addresses: %s", strings.Join(e.Addresses, ", "))
+		if more := e.TotalAddresses - len(e.Addresses); more > 0 {
+			msg += fmt.Sprintf(" and %d more", more)
+		}
+	}
+	return fmt.Sprintf("%s: %s", msg, ErrInstantiateNotPermitted)
+}
+
+// Unwrap implements the built-in errors.Unwrap
+func (e InstantiateNotPermittedError) Unwrap() error {
+	return ErrInstantiateNotPermitted
+}
+
+// Cause is the same as unwrap but used by errors.abci
+func (e InstantiateNotPermittedError) Cause() error {
+	return e.Unwrap()
+}
+
 // WasmVMErrorable mapped error type in wasmvm and are not redacted
 type WasmVMErrorable interface {
 	// ToWasmVMError convert instance to wasmvm friendly error if possible otherwise root cause. never nil
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"bytes"
 	"errors"
+	"strings"
 	"testing"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
@@ -9,6 +11,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	errorsmod "cosmossdk.io/errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
 )
 
 func TestWasmVMFlavouredError(t *testing.T) {
@@ -110,3 +115,58 @@ func TestDeterministicError(t *testing.T) {
 	assert.Equal(t, innerCodeSpace, codespace)
 	assert.Equal(t, innerCode, code)
 }
+
+func TestInstantiateNotPermittedError(t *testing.T) {
+	addrs := make([]sdk.AccAddress, 7)
+	bech32Addrs := make([]string, len(addrs))
+	for i := range addrs {
+		addrs[i] = bytes.Repeat([]byte{byte(i + 1)}, address.Len)
+		bech32Addrs[i] = addrs[i].String()
+	}
+	specs := map[string]struct {
+		src          AccessConfig
+		expAddresses []string
+		expTotal     int
+		expMsg       string
+	}{
+		"everybody": {
+			src:          AllowEverybody,
+			expAddresses: []string{},
+			expMsg:       "code id 1 with access type Everybody: instantiate not permitted",
+		},
+		"nobody": {
+			src:          AllowNobody,
+			expAddresses: []string{},
+			expMsg:       "code id 1 with access type Nobody: instantiate not permitted",
+		},
+		"any of addresses": {
+			src:          AccessTypeAnyOfAddresses.With(addrs[:2]...),
+			expAddresses: bech32Addrs[:2],
+			expTotal:     2,
+			expMsg:       "code id 1 with access type AnyOfAddresses, allowed addresses: " + bech32Addrs[0] + ", " + bech32Addrs[1] + ": instantiate not permitted",
+		},
+		"any of addresses - capped": {
+			src:          AccessTypeAnyOfAddresses.With(addrs...),
+			expAddresses: bech32Addrs[:5],
+			expTotal:     7,
+			expMsg:       "code id 1 with access type AnyOfAddresses, allowed addresses: " + strings.Join(bech32Addrs[:5], ", ") + " and 2 more: instantiate not permitted",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var err error = NewInstantiateNotPermittedError(1, spec.src)
+			var gotErr InstantiateNotPermittedError
+			require.True(t, errors.As(errorsmod.Wrap(err, "wrapped"), &gotErr))
+			assert.Equal(t, spec.src.Permission, gotErr.Permission)
+			assert.Equal(t, spec.expAddresses, gotErr.Addresses)
+			assert.Equal(t, spec.expTotal, gotErr.TotalAddresses)
+			assert.Equal(t, spec.expMsg, err.Error())
+			assert.ErrorIs(t, err, ErrInstantiateNotPermitted)
+
+			codespace, code, log := errorsmod.ABCIInfo(err, false)
+			assert.Equal(t, DefaultCodespace, codespace)
+			assert.Equal(t, uint32(35), code)
+			assert.Equal(t, spec.expMsg, log)
+		})
+	}
+}
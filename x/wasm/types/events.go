@@ -15,6 +15,7 @@ const (
 	CustomContractEventPrefix = "wasm-"
 
 	EventTypeStoreCode              = "store_code"
+	EventTypeStoreCodeDuplicate     = "store_code_duplicate"
 	EventTypeInstantiate            = "instantiate"
 	EventTypeExecute                = "execute"
 	EventTypeMigrate                = "migrate"
@@ -26,6 +27,11 @@ const (
 	EventTypeUpdateContractAdmin    = "update_contract_admin"
 	EventTypeUpdateContractLabel    = "update_contract_label"
 	EventTypeUpdateCodeAccessConfig = "update_code_access_config"
+	EventTypeDeprecateCode          = "deprecate_code"
+	EventTypeSetContractStateAccess = "set_contract_state_access"
+	EventTypeSetContractState       = "set_contract_state"
+	EventTypeForceMigrate           = "force_migrate"
+	EventTypePruneContractHistory   = "prune_contract_history"
 	EventTypePacketRecv             = "ibc_packet_received"
 	// add new types to IsAcceptedEventOnRecvPacketErrorAck
 )
@@ -59,12 +65,26 @@ const (
 	AttributeKeyContractAddr        = "_contract_address"
 	AttributeKeyCodeID              = "code_id"
 	AttributeKeyChecksum            = "code_checksum"
+	AttributeKeyCreator             = "creator"
 	AttributeKeyResultDataHex       = "result"
 	AttributeKeyRequiredCapability  = "required_capability"
+	AttributeKeyTxHash              = "tx_hash"
+	AttributeKeyCodeSource          = "code_source"
+	AttributeKeyBuilder             = "builder"
 	AttributeKeyNewAdmin            = "new_admin_address"
 	AttributeKeyNewLabel            = "new_label"
+	AttributeKeyOldLabel            = "old_label"
 	AttributeKeyCodePermission      = "code_permission"
 	AttributeKeyAuthorizedAddresses = "authorized_addresses"
 	AttributeKeyAckSuccess          = "success"
 	AttributeKeyAckError            = "error"
+	AttributeKeyKeyCount            = "key_count"
+	AttributeKeyRawQueryEnabled     = "raw_query_enabled"
+	AttributeKeySuccess             = "success"
+	AttributeKeyError               = "error"
+	AttributeKeyPrunedCount         = "pruned_count"
+	// AttributeKeyMsgIndex is the position of the submessage in the dispatch order of the tx message
+	AttributeKeyMsgIndex = "_msg_index"
+	// AttributeKeyCallDepth is the depth of the submessage or reply in the contract call tree
+	AttributeKeyCallDepth = "_call_depth"
 )
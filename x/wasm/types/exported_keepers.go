@@ -17,13 +17,27 @@ type ViewKeeper interface {
 	QueryRaw(ctx context.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	HasContractInfo(ctx context.Context, contractAddress sdk.AccAddress) bool
 	GetContractInfo(ctx context.Context, contractAddress sdk.AccAddress) *ContractInfo
+	GetContractLastActivityHeight(ctx context.Context, contractAddress sdk.AccAddress) uint64
+	GetContractHistoryPrunedHeight(ctx context.Context, contractAddress sdk.AccAddress) uint64
+	IsRawQueryEnabled(ctx context.Context, contractInfo ContractInfo) bool
 	IterateContractInfo(ctx context.Context, cb func(sdk.AccAddress, ContractInfo) bool)
 	IterateContractsByCreator(ctx context.Context, creator sdk.AccAddress, cb func(address sdk.AccAddress) bool)
 	IterateContractsByCode(ctx context.Context, codeID uint64, cb func(address sdk.AccAddress) bool)
 	IterateContractState(ctx context.Context, contractAddress sdk.AccAddress, cb func(key, value []byte) bool)
+	IterateContractStateKeys(ctx context.Context, contractAddress sdk.AccAddress, keyPrefix, start []byte, reverse bool, cb func(key []byte) bool)
 	GetCodeInfo(ctx context.Context, codeID uint64) *CodeInfo
 	IterateCodeInfos(ctx context.Context, cb func(uint64, CodeInfo) bool)
+	GetCodeInstantiationCount(ctx context.Context, codeID uint64) uint64
+	GetCodeProvenance(ctx context.Context, codeID uint64) *CodeProvenance
+	GetCodeIDsByChecksum(ctx context.Context, checksum []byte) []uint64
+	IsUploadSpamProtected(ctx context.Context, uploader sdk.AccAddress) bool
+	GetUploadCount(ctx context.Context, uploader sdk.AccAddress, epoch uint64) uint64
 	GetByteCode(ctx context.Context, codeID uint64) ([]byte, error)
+	GetContractFootprint(ctx context.Context, contractAddr sdk.AccAddress, maxStateEntries uint64) (*ContractFootprint, error)
+	GetRecentExecutions(contractAddr sdk.AccAddress, limit uint32) ([]ExecutionReceipt, bool)
+	CheckInstantiate2Address(ctx context.Context, creator sdk.AccAddress, codeID uint64, salt []byte, initMsg RawContractMessage, fixMsg bool) (*QueryCheckInstantiate2AddressResponse, error)
+	IterateModuleActivity(ctx context.Context, fromHeight, toHeight int64, cb func(ModuleActivity) bool)
+	IterateCodeGasUsage(ctx context.Context, fromBucket, toBucket uint64, cb func(bucket, codeID, gasUsed uint64) bool)
 	IsPinnedCode(ctx context.Context, codeID uint64) bool
 	GetParams(ctx context.Context) Params
 	GetWasmLimits() wasmvmtypes.WasmLimits
@@ -59,6 +59,9 @@ const (
 	DefaultPerCustomEventCost uint64 = 20
 	// DefaultEventAttributeDataFreeTier number of bytes of total attribute data we do not charge.
 	DefaultEventAttributeDataFreeTier = 100
+	// DefaultContractActivityCost is how much SDK gas we charge to record the height of a contract execution.
+	// This roughly reflects the costs of a store write with a 32 byte address.
+	DefaultContractActivityCost uint64 = 3_000
 )
 
 // default: 0.15 gas.
@@ -73,10 +76,23 @@ func DefaultPerByteUncompressCost() wasmvmtypes.UFraction {
 	return defaultPerByteUncompressCost
 }
 
+// default: 0.1 gas, hashing is cheaper than unpacking.
+var defaultPerByteChecksumCost = wasmvmtypes.UFraction{
+	Numerator:   1,
+	Denominator: 10,
+}
+
+// DefaultPerByteChecksumCost is how much SDK gas we charge per byte to calculate the checksum of wasm code
+func DefaultPerByteChecksumCost() wasmvmtypes.UFraction {
+	return defaultPerByteChecksumCost
+}
+
 // GasRegister abstract source for gas costs
 type GasRegister interface {
 	// UncompressCosts costs to unpack a new wasm contract
 	UncompressCosts(byteLength int) storetypes.Gas
+	// ChecksumCosts costs to calculate the checksum of a new wasm contract
+	ChecksumCosts(byteLength int) storetypes.Gas
 	// SetupContractCost are charged when interacting with a Wasm contract, i.e. every time
 	// the contract is prepared for execution through any entry point (execute/instantiate/sudo/query/ibc_*/...).
 	SetupContractCost(discount bool, msgLen int) storetypes.Gas
@@ -84,6 +100,8 @@ type GasRegister interface {
 	ReplyCosts(discount bool, reply wasmvmtypes.Reply) storetypes.Gas
 	// EventCosts costs to persist an event
 	EventCosts(attrs []wasmvmtypes.EventAttribute, events wasmvmtypes.Array[wasmvmtypes.Event]) storetypes.Gas
+	// ContractActivityCosts costs to record the block height of a contract execution
+	ContractActivityCosts() storetypes.Gas
 	// ToWasmVMGas converts from Cosmos SDK gas units to [CosmWasm gas] (aka. wasmvm gas)
 	//
 	// [CosmWasm gas]: https://github.com/CosmWasm/cosmwasm/blob/v1.3.1/docs/GAS.md
@@ -110,6 +128,8 @@ type WasmGasRegisterConfig struct {
 	CompileCost storetypes.Gas
 	// UncompressCost costs per byte to unpack a contract
 	UncompressCost wasmvmtypes.UFraction
+	// ChecksumCost costs per byte to calculate the checksum of a contract
+	ChecksumCost wasmvmtypes.UFraction
 	// GasMultiplier is how many cosmwasm gas points = 1 sdk gas point
 	// SDK reference costs can be found here: https://github.com/cosmos/cosmos-sdk/blob/02c6c9fafd58da88550ab4d7d494724a477c8a68/store/types/gas.go#L153-L164
 	GasMultiplier storetypes.Gas
@@ -126,6 +146,8 @@ type WasmGasRegisterConfig struct {
 	ContractMessageDataCost storetypes.Gas
 	// CustomEventCost cost per custom event
 	CustomEventCost uint64
+	// ContractActivityCost costs to record the block height of a contract execution
+	ContractActivityCost storetypes.Gas
 }
 
 // DefaultGasRegisterConfig default values
@@ -141,6 +163,8 @@ func DefaultGasRegisterConfig() WasmGasRegisterConfig {
 		EventAttributeDataFreeTier: DefaultEventAttributeDataFreeTier,
 		ContractMessageDataCost:    DefaultContractMessageDataCost,
 		UncompressCost:             DefaultPerByteUncompressCost(),
+		ChecksumCost:               DefaultPerByteChecksumCost(),
+		ContractActivityCost:       DefaultContractActivityCost,
 	}
 }
 
@@ -172,6 +196,14 @@ func (g WasmGasRegister) UncompressCosts(byteLength int) storetypes.Gas {
 	return g.c.UncompressCost.Mul(uint64(byteLength)).Floor()
 }
 
+// ChecksumCosts costs to calculate the checksum of a new wasm contract
+func (g WasmGasRegister) ChecksumCosts(byteLength int) storetypes.Gas {
+	if byteLength < 0 {
+		panic(errorsmod.Wrap(ErrInvalid, "negative length"))
+	}
+	return g.c.ChecksumCost.Mul(uint64(byteLength)).Floor()
+}
+
 // SetupContractCost costs when interacting with a wasm contract.
 // Set discount to true in cases where you can reasonably assume the contract
 // is loaded from an in-memory cache (e.g. pinned contracts or replies).
@@ -219,6 +251,11 @@ func (g WasmGasRegister) EventCosts(attrs []wasmvmtypes.EventAttribute, events w
 	return gas
 }
 
+// ContractActivityCosts costs to record the block height of a contract execution
+func (g WasmGasRegister) ContractActivityCosts() storetypes.Gas {
+	return g.c.ContractActivityCost
+}
+
 func (g WasmGasRegister) eventAttributeCosts(attrs []wasmvmtypes.EventAttribute, freeTier uint64) (storetypes.Gas, uint64) {
 	if len(attrs) == 0 {
 		return 0, freeTier
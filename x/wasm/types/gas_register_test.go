@@ -367,3 +367,63 @@ func TestUncompressCosts(t *testing.T) {
 		})
 	}
 }
+
+func TestChecksumCosts(t *testing.T) {
+	specs := map[string]struct {
+		lenIn    int
+		exp      storetypes.Gas
+		expPanic bool
+	}{
+		"0": {
+			exp: 0,
+		},
+		"even": {
+			lenIn: 100,
+			exp:   10,
+		},
+		"round down when uneven": {
+			lenIn: 19,
+			exp:   1,
+		},
+		"max len": {
+			lenIn: MaxWasmSize,
+			exp:   81920,
+		},
+		"invalid len": {
+			lenIn:    -1,
+			expPanic: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			if spec.expPanic {
+				assert.Panics(t, func() { NewDefaultWasmGasRegister().ChecksumCosts(spec.lenIn) })
+				return
+			}
+			got := NewDefaultWasmGasRegister().ChecksumCosts(spec.lenIn)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestContractActivityCosts(t *testing.T) {
+	specs := map[string]struct {
+		srcConfig WasmGasRegisterConfig
+		exp       storetypes.Gas
+	}{
+		"default": {
+			srcConfig: DefaultGasRegisterConfig(),
+			exp:       DefaultContractActivityCost,
+		},
+		"custom": {
+			srcConfig: WasmGasRegisterConfig{GasMultiplier: 1, ContractActivityCost: 1},
+			exp:       1,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := NewWasmGasRegister(spec.srcConfig).ContractActivityCosts()
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
@@ -47,6 +47,11 @@ func (c Code) ValidateBasic() error {
 	if err := validateWasmCode(c.CodeBytes, MaxProposalWasmSize); err != nil {
 		return errorsmod.Wrap(err, "code bytes")
 	}
+	if c.Provenance != nil {
+		if err := c.Provenance.ValidateBasic(); err != nil {
+			return errorsmod.Wrap(err, "provenance")
+		}
+	}
 	return nil
 }
 
@@ -109,6 +109,8 @@ type Code struct {
 	CodeBytes []byte   `protobuf:"bytes,3,opt,name=code_bytes,json=codeBytes,proto3" json:"code_bytes,omitempty"`
 	// Pinned to wasmvm cache
 	Pinned bool `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// Provenance of the code upload, optional
+	Provenance *CodeProvenance `protobuf:"bytes,5,opt,name=provenance,proto3" json:"provenance,omitempty"`
 }
 
 func (m *Code) Reset()         { *m = Code{} }
@@ -177,12 +179,23 @@ func (m *Code) GetPinned() bool {
 	return false
 }
 
+func (m *Code) GetProvenance() *CodeProvenance {
+	if m != nil {
+		return m.Provenance
+	}
+	return nil
+}
+
 // Contract struct encompasses ContractAddress, ContractInfo, and ContractState
 type Contract struct {
 	ContractAddress     string                     `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
 	ContractInfo        ContractInfo               `protobuf:"bytes,2,opt,name=contract_info,json=contractInfo,proto3" json:"contract_info"`
 	ContractState       []Model                    `protobuf:"bytes,3,rep,name=contract_state,json=contractState,proto3" json:"contract_state"`
 	ContractCodeHistory []ContractCodeHistoryEntry `protobuf:"bytes,4,rep,name=contract_code_history,json=contractCodeHistory,proto3" json:"contract_code_history"`
+	// HistoryPrunedHeight height before which the contract history was pruned, optional
+	HistoryPrunedHeight uint64 `protobuf:"varint,5,opt,name=history_pruned_height,json=historyPrunedHeight,proto3" json:"history_pruned_height,omitempty"`
+	// LastActivityHeight block height of the last recorded contract execution, optional
+	LastActivityHeight uint64 `protobuf:"varint,6,opt,name=last_activity_height,json=lastActivityHeight,proto3" json:"last_activity_height,omitempty"`
 }
 
 func (m *Contract) Reset()         { *m = Contract{} }
@@ -251,6 +264,20 @@ func (m *Contract) GetContractCodeHistory() []ContractCodeHistoryEntry {
 	return nil
 }
 
+func (m *Contract) GetHistoryPrunedHeight() uint64 {
+	if m != nil {
+		return m.HistoryPrunedHeight
+	}
+	return 0
+}
+
+func (m *Contract) GetLastActivityHeight() uint64 {
+	if m != nil {
+		return m.LastActivityHeight
+	}
+	return 0
+}
+
 // Sequence key and value of an id generation counter
 type Sequence struct {
 	IDKey []byte `protobuf:"bytes,1,opt,name=id_key,json=idKey,proto3" json:"id_key,omitempty"`
@@ -319,44 +346,49 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 590 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0x9b, 0xae, 0x0d, 0xad, 0x57, 0xd8, 0x30, 0x63, 0x84, 0x6a, 0xa4, 0x55, 0x91, 0x50,
-	0x35, 0x41, 0xa3, 0x8d, 0x23, 0x17, 0xc8, 0x86, 0xa0, 0x4c, 0x20, 0x94, 0x1e, 0x90, 0x76, 0xa9,
-	0xd2, 0xd8, 0xeb, 0x2c, 0x96, 0xb8, 0xc4, 0x6e, 0x21, 0xdf, 0x82, 0x4f, 0x81, 0x38, 0x72, 0xe0,
-	0x43, 0xec, 0xc6, 0x84, 0x84, 0xc4, 0xa9, 0x42, 0xed, 0x01, 0x89, 0x4f, 0x81, 0xfc, 0x27, 0x59,
-	0xd4, 0xae, 0x17, 0xab, 0xf6, 0xf3, 0xbe, 0xbf, 0xbe, 0xef, 0xe3, 0x37, 0x06, 0x76, 0x40, 0x59,
-	0xf8, 0xd1, 0x67, 0xa1, 0x23, 0x97, 0xc9, 0x9e, 0x33, 0xc4, 0x11, 0x66, 0x84, 0x75, 0x46, 0x31,
-	0xe5, 0x14, 0x6e, 0xa6, 0x7a, 0x47, 0x2e, 0x93, 0xbd, 0xfa, 0xd6, 0x90, 0x0e, 0xa9, 0x14, 0x1d,
-	0xf1, 0x4b, 0xc5, 0xd5, 0x77, 0x96, 0x38, 0x3c, 0x19, 0x61, 0x4d, 0xa9, 0xdf, 0xf4, 0x43, 0x12,
-	0x51, 0x47, 0xae, 0xfa, 0xe8, 0xae, 0x48, 0xa0, 0xac, 0xaf, 0x48, 0x6a, 0xa3, 0xa4, 0xd6, 0x8f,
-	0x22, 0xa8, 0xbd, 0x50, 0x55, 0xf4, 0xb8, 0xcf, 0x31, 0x7c, 0x02, 0xcc, 0x91, 0x1f, 0xfb, 0x21,
-	0xb3, 0x8c, 0xa6, 0xd1, 0x5e, 0xdf, 0xb7, 0x3a, 0x8b, 0x55, 0x75, 0xde, 0x4a, 0xdd, 0xad, 0x9e,
-	0x4f, 0x1b, 0x85, 0xaf, 0x7f, 0xbf, 0xed, 0x1a, 0x9e, 0x4e, 0x81, 0xaf, 0x40, 0x39, 0xa0, 0x08,
-	0x33, 0xab, 0xd8, 0x5c, 0x6b, 0xaf, 0xef, 0x6f, 0x2f, 0xe7, 0x1e, 0x50, 0x84, 0xdd, 0x1d, 0x91,
-	0xf9, 0x6f, 0xda, 0xd8, 0x90, 0xc1, 0x0f, 0x69, 0x48, 0x38, 0x0e, 0x47, 0x3c, 0x51, 0x30, 0x85,
-	0x80, 0xc7, 0xa0, 0x1a, 0xd0, 0x88, 0xc7, 0x7e, 0xc0, 0x99, 0xb5, 0x26, 0x79, 0xf5, 0xab, 0x78,
-	0x2a, 0xc4, 0x6d, 0x6a, 0xe6, 0xad, 0x2c, 0x69, 0x91, 0x7b, 0x89, 0x13, 0x6c, 0x86, 0x3f, 0x8c,
-	0x71, 0x14, 0x60, 0x66, 0x95, 0x56, 0xb1, 0x7b, 0x3a, 0xe4, 0x92, 0x9d, 0x25, 0x2d, 0xb1, 0x33,
-	0xa5, 0xf5, 0xc5, 0x00, 0x25, 0xd1, 0x25, 0xbc, 0x0f, 0xae, 0x89, 0x4e, 0xfa, 0x04, 0x49, 0x2b,
-	0x4b, 0x2e, 0x98, 0x4d, 0x1b, 0xa6, 0x90, 0xba, 0x87, 0x9e, 0x29, 0xa4, 0x2e, 0x82, 0xae, 0xe8,
-	0x52, 0x04, 0x45, 0x27, 0xd4, 0x2a, 0x4a, 0xc7, 0xeb, 0x57, 0xbb, 0xd6, 0x8d, 0x4e, 0x68, 0xde,
-	0xf3, 0x4a, 0xa0, 0x0f, 0xe1, 0x3d, 0x00, 0x24, 0x63, 0x90, 0x70, 0x2c, 0xac, 0x32, 0xda, 0x35,
-	0x4f, 0x52, 0x5d, 0x71, 0x00, 0xb7, 0x81, 0x39, 0x22, 0x51, 0x84, 0x91, 0x55, 0x6a, 0x1a, 0xed,
-	0x8a, 0xa7, 0x77, 0xad, 0x5f, 0x45, 0x50, 0x49, 0xed, 0x83, 0x07, 0x60, 0x33, 0xb5, 0xa7, 0xef,
-	0x23, 0x14, 0x63, 0xa6, 0x06, 0xa0, 0xea, 0x5a, 0x3f, 0xbf, 0x3f, 0xda, 0xd2, 0x33, 0xf3, 0x4c,
-	0x29, 0x3d, 0x1e, 0x93, 0x68, 0xe8, 0x6d, 0xa4, 0x19, 0xfa, 0x18, 0xbe, 0x01, 0xd7, 0x33, 0x48,
-	0xae, 0x21, 0x7b, 0xf5, 0xb5, 0x2d, 0x36, 0x55, 0x0b, 0x72, 0x02, 0xec, 0x82, 0x1b, 0x19, 0x8f,
-	0x89, 0xe9, 0xd4, 0x73, 0x70, 0x67, 0x19, 0xf8, 0x9a, 0x22, 0x7c, 0x96, 0x27, 0x65, 0x95, 0xa8,
-	0xb1, 0x26, 0xe0, 0x76, 0x86, 0x92, 0x66, 0x9d, 0x12, 0xc6, 0x69, 0x9c, 0xe8, 0xdb, 0xdf, 0x5d,
-	0x5d, 0xa2, 0xf0, 0xfe, 0xa5, 0x0a, 0x7e, 0x1e, 0xf1, 0x38, 0xc9, 0xff, 0x49, 0x36, 0x6c, 0xb9,
-	0xa0, 0x96, 0x0b, 0x2a, 0xe9, 0xe4, 0xc0, 0x26, 0x30, 0x09, 0xea, 0xbf, 0xc7, 0x89, 0x34, 0xb3,
-	0xe6, 0x56, 0x67, 0xd3, 0x46, 0xb9, 0x7b, 0x78, 0x84, 0x13, 0xaf, 0x4c, 0xd0, 0x11, 0x4e, 0xe0,
-	0x16, 0x28, 0x4f, 0xfc, 0xb3, 0x31, 0x96, 0x5e, 0x95, 0x3c, 0xb5, 0x71, 0x9f, 0x9e, 0xcf, 0x6c,
-	0xe3, 0x62, 0x66, 0x1b, 0x7f, 0x66, 0xb6, 0xf1, 0x79, 0x6e, 0x17, 0x2e, 0xe6, 0x76, 0xe1, 0xf7,
-	0xdc, 0x2e, 0x1c, 0x3f, 0x18, 0x12, 0x7e, 0x3a, 0x1e, 0x74, 0x02, 0x1a, 0x3a, 0x07, 0x94, 0x85,
-	0xef, 0xd2, 0x77, 0x00, 0x39, 0x9f, 0xd4, 0x7b, 0x20, 0x1f, 0x83, 0x81, 0x29, 0xbf, 0xef, 0xc7,
-	0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0x86, 0xe3, 0xf2, 0x58, 0x75, 0x04, 0x00, 0x00,
+	// 657 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0x41, 0x6f, 0xd3, 0x30,
+	0x14, 0xc7, 0x9b, 0xad, 0x0d, 0xad, 0x57, 0xd8, 0xf0, 0xba, 0x11, 0xaa, 0x91, 0x46, 0x45, 0x42,
+	0xd5, 0x04, 0x2d, 0x1b, 0x47, 0x2e, 0x5b, 0x36, 0xc4, 0xca, 0x04, 0x9a, 0xb2, 0x03, 0xd2, 0x2e,
+	0x51, 0x96, 0x78, 0xad, 0xc5, 0x62, 0x87, 0xd8, 0x2d, 0xe4, 0x5b, 0xf0, 0x31, 0x38, 0x72, 0xe0,
+	0xc6, 0x17, 0xd8, 0x8d, 0x89, 0x13, 0xa7, 0x0a, 0x75, 0x07, 0x10, 0x9f, 0x02, 0xc5, 0x76, 0x42,
+	0xb4, 0xae, 0x17, 0x6f, 0xf6, 0xff, 0xfd, 0x7f, 0x7e, 0x79, 0xef, 0xd5, 0xc0, 0xf4, 0x29, 0x0b,
+	0x3f, 0x78, 0x2c, 0xec, 0x89, 0x65, 0xbc, 0xd5, 0x1b, 0x20, 0x82, 0x18, 0x66, 0xdd, 0x28, 0xa6,
+	0x9c, 0xc2, 0x95, 0x4c, 0xef, 0x8a, 0x65, 0xbc, 0xd5, 0x6c, 0x0c, 0xe8, 0x80, 0x0a, 0xb1, 0x97,
+	0xfe, 0x27, 0xe3, 0x9a, 0x1b, 0x33, 0x1c, 0x9e, 0x44, 0x48, 0x51, 0x9a, 0x77, 0xbd, 0x10, 0x13,
+	0xda, 0x13, 0xab, 0x3a, 0xba, 0x9f, 0x1a, 0x28, 0x73, 0x25, 0x49, 0x6e, 0xa4, 0xd4, 0xfe, 0xbe,
+	0x00, 0xea, 0x2f, 0x65, 0x16, 0xc7, 0xdc, 0xe3, 0x08, 0x3e, 0x07, 0x7a, 0xe4, 0xc5, 0x5e, 0xc8,
+	0x0c, 0xcd, 0xd2, 0x3a, 0x4b, 0xdb, 0x46, 0xf7, 0x7a, 0x56, 0xdd, 0x23, 0xa1, 0xdb, 0xb5, 0xcf,
+	0xbf, 0xbf, 0x6c, 0x6a, 0x17, 0x93, 0x56, 0xc9, 0x51, 0x16, 0xf8, 0x0a, 0x54, 0x7c, 0x1a, 0x20,
+	0x66, 0x2c, 0x58, 0x8b, 0x9d, 0xa5, 0xed, 0xf5, 0x59, 0xef, 0x1e, 0x0d, 0x90, 0xbd, 0x91, 0x3b,
+	0xff, 0x4e, 0x5a, 0xcb, 0xc2, 0xf1, 0x98, 0x86, 0x98, 0xa3, 0x30, 0xe2, 0x89, 0x23, 0x11, 0xf0,
+	0x04, 0xd4, 0x7c, 0x4a, 0x78, 0xec, 0xf9, 0x9c, 0x19, 0x8b, 0x82, 0xd7, 0xbc, 0x89, 0x27, 0x43,
+	0x6c, 0xab, 0xc8, 0x5c, 0xcd, 0x9d, 0x05, 0xee, 0x7f, 0x5c, 0xca, 0x66, 0xe8, 0xfd, 0x08, 0x11,
+	0x1f, 0x31, 0xa3, 0x3c, 0x8f, 0x7d, 0xac, 0x42, 0xae, 0xb1, 0x73, 0x67, 0x91, 0x9d, 0x1f, 0xb6,
+	0xff, 0x68, 0xa0, 0x9c, 0x7e, 0x25, 0x7c, 0x08, 0x6e, 0xa5, 0x5f, 0xe2, 0xe2, 0x40, 0x94, 0xb2,
+	0x6c, 0x83, 0xe9, 0xa4, 0xa5, 0xa7, 0x52, 0x7f, 0xdf, 0xd1, 0x53, 0xa9, 0x1f, 0x40, 0x1b, 0xd4,
+	0x64, 0x10, 0x39, 0xa3, 0xc6, 0x82, 0xa5, 0xdd, 0x9c, 0x89, 0x30, 0x91, 0x33, 0x5a, 0xac, 0x79,
+	0xd5, 0x57, 0x87, 0xf0, 0x01, 0x00, 0x82, 0x71, 0x9a, 0x70, 0x94, 0x96, 0x4a, 0xeb, 0xd4, 0x1d,
+	0x41, 0xb5, 0xd3, 0x03, 0xb8, 0x0e, 0xf4, 0x08, 0x13, 0x82, 0x02, 0xa3, 0x6c, 0x69, 0x9d, 0xaa,
+	0xa3, 0x76, 0x70, 0x07, 0x80, 0x28, 0xa6, 0x63, 0x44, 0x3c, 0xe2, 0x23, 0xa3, 0x22, 0xee, 0xb6,
+	0x6e, 0xbe, 0xfb, 0x28, 0x8f, 0x73, 0x0a, 0x9e, 0xf6, 0xb7, 0x45, 0x50, 0xcd, 0x1a, 0x00, 0xf7,
+	0xc0, 0x4a, 0x56, 0x60, 0xd7, 0x0b, 0x82, 0x18, 0x31, 0x39, 0x42, 0x35, 0xdb, 0xf8, 0xf1, 0xf5,
+	0x49, 0x43, 0x4d, 0xdd, 0xae, 0x54, 0x8e, 0x79, 0x8c, 0xc9, 0xc0, 0x59, 0xce, 0x1c, 0xea, 0x18,
+	0xbe, 0x01, 0xb7, 0x73, 0x48, 0xa1, 0x24, 0xe6, 0xfc, 0xc6, 0x5f, 0x2f, 0x4b, 0xdd, 0x2f, 0x08,
+	0xb0, 0x0f, 0xee, 0xe4, 0x3c, 0x96, 0xce, 0xb7, 0x9a, 0xa4, 0x7b, 0xb3, 0xc0, 0xd7, 0x34, 0x40,
+	0xe7, 0x45, 0x52, 0x9e, 0x89, 0xfc, 0x61, 0x60, 0xb0, 0x96, 0xa3, 0x44, 0xb9, 0x87, 0x98, 0x71,
+	0x1a, 0x27, 0x6a, 0x7e, 0x36, 0xe7, 0xa7, 0x98, 0x56, 0xf0, 0x40, 0x06, 0xbf, 0x20, 0x3c, 0x4e,
+	0x8a, 0x97, 0xac, 0xfa, 0xb3, 0x41, 0x70, 0x1b, 0xac, 0x29, 0xb8, 0x1b, 0xc5, 0x23, 0x82, 0x02,
+	0x77, 0x88, 0xf0, 0x60, 0xc8, 0x45, 0x93, 0xca, 0xce, 0xaa, 0x12, 0x8f, 0x84, 0x76, 0x20, 0x24,
+	0xf8, 0x14, 0x34, 0xce, 0x3d, 0xc6, 0x5d, 0xcf, 0xe7, 0x78, 0x8c, 0x79, 0x92, 0x59, 0x74, 0x61,
+	0x81, 0xa9, 0xb6, 0xab, 0x24, 0xe9, 0x68, 0xdb, 0xa0, 0x9a, 0x4d, 0x38, 0xb4, 0x80, 0x8e, 0x03,
+	0xf7, 0x1d, 0x4a, 0x44, 0xcb, 0xea, 0x76, 0x6d, 0x3a, 0x69, 0x55, 0xfa, 0xfb, 0x87, 0x28, 0x71,
+	0x2a, 0x38, 0x38, 0x44, 0x09, 0x6c, 0x80, 0xca, 0xd8, 0x3b, 0x1f, 0x21, 0xd1, 0x91, 0xb2, 0x23,
+	0x37, 0xf6, 0xce, 0xc9, 0xa3, 0x01, 0xe6, 0xc3, 0xd1, 0x69, 0xd7, 0xa7, 0x61, 0x6f, 0x8f, 0xb2,
+	0xf0, 0x6d, 0xf6, 0x2e, 0x05, 0xbd, 0x8f, 0xe2, 0xaf, 0x7c, 0x9c, 0x2e, 0xa6, 0xa6, 0x76, 0x39,
+	0x35, 0xb5, 0x5f, 0x53, 0x53, 0xfb, 0x74, 0x65, 0x96, 0x2e, 0xaf, 0xcc, 0xd2, 0xcf, 0x2b, 0xb3,
+	0x74, 0xaa, 0x8b, 0x77, 0xe8, 0xd9, 0xbf, 0x01, 0x00, 0x9e, 0xd9, 0x97, 0x3b, 0x1d, 0x05, 0x00,
+	0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -454,6 +486,18 @@ func (m *Code) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Provenance != nil {
+		{
+			size, err := m.Provenance.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintGenesis(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Pinned {
 		i--
 		if m.Pinned {
@@ -509,6 +553,16 @@ func (m *Contract) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.LastActivityHeight != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.LastActivityHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.HistoryPrunedHeight != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.HistoryPrunedHeight))
+		i--
+		dAtA[i] = 0x28
+	}
 	if len(m.ContractCodeHistory) > 0 {
 		for iNdEx := len(m.ContractCodeHistory) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -651,6 +705,10 @@ func (m *Code) Size() (n int) {
 	if m.Pinned {
 		n += 2
 	}
+	if m.Provenance != nil {
+		l = m.Provenance.Size()
+		n += 1 + l + sovGenesis(uint64(l))
+	}
 	return n
 }
 
@@ -678,6 +736,12 @@ func (m *Contract) Size() (n int) {
 			n += 1 + l + sovGenesis(uint64(l))
 		}
 	}
+	if m.HistoryPrunedHeight != 0 {
+		n += 1 + sovGenesis(uint64(m.HistoryPrunedHeight))
+	}
+	if m.LastActivityHeight != 0 {
+		n += 1 + sovGenesis(uint64(m.LastActivityHeight))
+	}
 	return n
 }
 
@@ -1026,6 +1090,42 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Pinned = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Provenance == nil {
+				m.Provenance = &CodeProvenance{}
+			}
+			if err := m.Provenance.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -1210,6 +1310,44 @@ func (m *Contract) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HistoryPrunedHeight", wireType)
+			}
+			m.HistoryPrunedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HistoryPrunedHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastActivityHeight", wireType)
+			}
+			m.LastActivityHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastActivityHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
@@ -99,6 +99,17 @@ func TestCodeValidateBasic(t *testing.T) {
 			},
 			expError: true,
 		},
+		"provenance set": {
+			srcMutator: func(c *Code) {
+				c.Provenance = &CodeProvenance{TxHash: bytes.Repeat([]byte{0x1}, 32), Source: "https://example.com"}
+			},
+		},
+		"provenance with invalid tx hash": {
+			srcMutator: func(c *Code) {
+				c.Provenance = &CodeProvenance{TxHash: []byte{0x1}}
+			},
+			expError: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"crypto/sha256"
 	"encoding/binary"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -36,9 +37,41 @@ var (
 	ContractsByCreatorPrefix                       = []byte{0x09}
 	ParamsKey                                      = []byte{0x10}
 	AsyncAckKeyPrefix                              = []byte{0x11}
+	CodeInstantiationCountPrefix                   = []byte{0x12}
+	CodesByInstantiationCountPrefix                = []byte{0x13}
+	UploadQuotaPrefix                              = []byte{0x14}
+	CodeIDsByChecksumPrefix                        = []byte{0x15}
+	CodeProvenancePrefix                           = []byte{0x16}
+	ContractsByLabelPrefix                         = []byte{0x17}
+	ContractLastActivityPrefix                     = []byte{0x18}
+	ModuleActivityPrefix                           = []byte{0x19}
+	CodeGasUsagePrefix                             = []byte{0x1a}
+	ContractHistoryPrunedPrefix                    = []byte{0x1b}
 
 	KeySequenceCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
 	KeySequenceInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
+
+	// LastStoredCodeKey is the transient store key for the code id stored last in the current tx
+	LastStoredCodeKey = []byte{0x01}
+	// ContractGasUsedPrefix is the transient store prefix for the execution gas used by a contract in the current block
+	ContractGasUsedPrefix = []byte{0x02}
+	// BlockActivityKey is the transient store key for the wasm activity counters of the current block
+	BlockActivityKey = []byte{0x03}
+	// CodeGasUsedPrefix is the transient store prefix for the execution gas used by the contracts of a code in the
+	// current block
+	CodeGasUsedPrefix = []byte{0x04}
+	// StoredCodeInTxPrefix is the transient store prefix for the code ids by checksum stored in the current tx
+	StoredCodeInTxPrefix = []byte{0x05}
+)
+
+// ModuleActivityWindow is the number of blocks the module activity is kept for
+const ModuleActivityWindow = 10_000
+
+const (
+	// CodeGasUsageBucketLength is the number of blocks the execution gas per code is summed up for
+	CodeGasUsageBucketLength = 1_000
+	// CodeGasUsageWindow is the max number of blocks the execution gas per code can be queried for
+	CodeGasUsageWindow = 10_000
 )
 
 // GetCodeKey constructs the key for retrieving the ID for the WASM code
@@ -47,11 +80,94 @@ func GetCodeKey(codeID uint64) []byte {
 	return append(CodeKeyPrefix, contractIDBz...)
 }
 
+// GetCodeProvenanceKey returns the key for the upload provenance of a code
+func GetCodeProvenanceKey(codeID uint64) []byte {
+	return append(CodeProvenancePrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
 // GetContractAddressKey returns the key for the WASM contract instance
 func GetContractAddressKey(addr sdk.AccAddress) []byte {
 	return append(ContractKeyPrefix, addr...)
 }
 
+// GetUploadQuotaKey returns the key for the code upload quota of an account
+func GetUploadQuotaKey(addr sdk.AccAddress) []byte {
+	return append(UploadQuotaPrefix, addr...)
+}
+
+// GetCodeIDsByChecksumPrefix returns the prefix of the secondary index of code ids stored with a checksum
+func GetCodeIDsByChecksumPrefix(checksum []byte) []byte {
+	prefixLen := len(CodeIDsByChecksumPrefix)
+	r := make([]byte, prefixLen+len(checksum))
+	copy(r[0:], CodeIDsByChecksumPrefix)
+	copy(r[prefixLen:], checksum)
+	return r
+}
+
+// GetCodeIDByChecksumKey returns the key for the secondary index of code ids by checksum: `<prefix><checksum><codeID>`.
+// Entries must be removed together with the code info.
+func GetCodeIDByChecksumKey(checksum []byte, codeID uint64) []byte {
+	return append(GetCodeIDsByChecksumPrefix(checksum), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetContractsByLabelPrefix returns the prefix of the secondary index of contracts by label: `<prefix><sha256(label)>`
+func GetContractsByLabelPrefix(label string) []byte {
+	labelHash := sha256.Sum256([]byte(label))
+	prefixLen := len(ContractsByLabelPrefix)
+	r := make([]byte, prefixLen+len(labelHash))
+	copy(r[0:], ContractsByLabelPrefix)
+	copy(r[prefixLen:], labelHash[:])
+	return r
+}
+
+// GetContractByLabelKey returns the key for the secondary index of contracts by label: `<prefix><sha256(label)><contractAddr>`.
+// Entries must be moved together with label changes of the contract info.
+func GetContractByLabelKey(label string, contractAddr sdk.AccAddress) []byte {
+	return append(GetContractsByLabelPrefix(label), contractAddr...)
+}
+
+// GetContractLastActivityKey returns the key for the block height of the last execution of a contract
+func GetContractLastActivityKey(contractAddr sdk.AccAddress) []byte {
+	return append(ContractLastActivityPrefix, contractAddr...)
+}
+
+// GetContractHistoryPrunedKey returns the key for the height before which the contract history is incomplete
+func GetContractHistoryPrunedKey(contractAddr sdk.AccAddress) []byte {
+	return append(ContractHistoryPrunedPrefix, contractAddr...)
+}
+
+// GetModuleActivityKey returns the key for the recorded wasm activity of a block
+func GetModuleActivityKey(height int64) []byte {
+	return append(ModuleActivityPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// GetCodeGasUsageBucketPrefix returns the prefix for the execution gas per code of a bucket
+func GetCodeGasUsageBucketPrefix(bucket uint64) []byte {
+	return append(CodeGasUsagePrefix, sdk.Uint64ToBigEndian(bucket)...)
+}
+
+// GetCodeGasUsageKey returns the key for the execution gas of a code in a bucket
+func GetCodeGasUsageKey(bucket, codeID uint64) []byte {
+	return append(GetCodeGasUsageBucketPrefix(bucket), sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetCodeGasUsedKey returns the transient store key for the execution gas used by the contracts of a code in the
+// current block
+func GetCodeGasUsedKey(codeID uint64) []byte {
+	return append(CodeGasUsedPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
+// GetContractGasUsedKey returns the transient store key for the execution gas used by a contract in the current block
+func GetContractGasUsedKey(addr sdk.AccAddress) []byte {
+	return append(ContractGasUsedPrefix, addr...)
+}
+
+// GetStoredCodeInTxKey returns the transient store key for the code id of the wasm code with the given checksum
+// stored in the current tx
+func GetStoredCodeInTxKey(checksum []byte) []byte {
+	return append(StoredCodeInTxPrefix, checksum...)
+}
+
 // GetContractsByCreatorPrefix returns the contracts by creator prefix for the WASM contract instance
 func GetContractsByCreatorPrefix(addr sdk.AccAddress) []byte {
 	bz := address.MustLengthPrefix(addr)
@@ -149,3 +265,28 @@ func GetPinnedCodeIndexPrefix(codeID uint64) []byte {
 func ParsePinnedCodeIndex(s []byte) uint64 {
 	return sdk.BigEndianToUint64(s)
 }
+
+// GetCodeInstantiationCountKey returns the key for the number of instantiations of a code id: `<prefix><codeID>`
+func GetCodeInstantiationCountKey(codeID uint64) []byte {
+	prefixLen := len(CodeInstantiationCountPrefix)
+	r := make([]byte, prefixLen+8)
+	copy(r[0:], CodeInstantiationCountPrefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(codeID))
+	return r
+}
+
+// GetCodesByInstantiationCountKey returns the key for the secondary index of codes sorted by number of
+// instantiations: `<prefix><count><codeID>`
+func GetCodesByInstantiationCountKey(count, codeID uint64) []byte {
+	prefixLen := len(CodesByInstantiationCountPrefix)
+	r := make([]byte, prefixLen+8+8)
+	copy(r[0:], CodesByInstantiationCountPrefix)
+	copy(r[prefixLen:], sdk.Uint64ToBigEndian(count))
+	copy(r[prefixLen+8:], sdk.Uint64ToBigEndian(codeID))
+	return r
+}
+
+// ParseCodesByInstantiationCountIndex converts the serialized key without prefix back into count and code ID.
+func ParseCodesByInstantiationCountIndex(s []byte) (count, codeID uint64) {
+	return sdk.BigEndianToUint64(s[:8]), sdk.BigEndianToUint64(s[8:])
+}
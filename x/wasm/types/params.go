@@ -106,9 +106,148 @@ func (p Params) ValidateBasic() error {
 	if err := p.CodeUploadAccess.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "upload access")
 	}
+	if err := p.UploadSpamProtection.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "upload spam protection")
+	}
+	if err := p.FeelessExecutions.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "feeless executions")
+	}
+	if err := validateContractGasBudgets(p.ContractGasBudgets); err != nil {
+		return errors.Wrap(err, "contract gas budgets")
+	}
+	if err := p.DefaultAdminPolicy.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "default admin policy")
+	}
+	if err := p.QueryJSONEncoding.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "query json encoding")
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation
+func (p DefaultAdminPolicy) ValidateBasic() error {
+	if _, ok := DefaultAdminPolicy_name[int32(p)]; !ok {
+		return errorsmod.Wrapf(ErrInvalid, "unknown policy: %d", p)
+	}
+	return nil
+}
+
+// EmptyAdminAllowed returns true when clients can instantiate without an explicit admin choice.
+// Unspecified is handled as DefaultAdminPolicyError.
+func (p DefaultAdminPolicy) EmptyAdminAllowed() bool {
+	return p == DefaultAdminPolicyCreator || p == DefaultAdminPolicyNone
+}
+
+// ValidateBasic performs basic validation
+func (e QueryJSONEncoding) ValidateBasic() error {
+	if _, ok := QueryJSONEncoding_name[int32(e)]; !ok {
+		return errorsmod.Wrapf(ErrInvalid, "unknown encoding: %d", e)
+	}
+	return nil
+}
+
+// ValidateBasic performs basic validation
+func (p UploadSpamProtection) ValidateBasic() error {
+	if err := p.Deposit.Validate(); err != nil {
+		return errorsmod.Wrap(err, "deposit")
+	}
+	if p.MaxUploadsPerEpoch != 0 && p.EpochLength == 0 {
+		return errorsmod.Wrap(ErrEmpty, "epoch length")
+	}
+	return nil
+}
+
+// Enabled returns true when a deposit or an upload quota is set
+func (p UploadSpamProtection) Enabled() bool {
+	return !p.Deposit.IsZero() || p.MaxUploadsPerEpoch != 0
+}
+
+// Epoch returns the quota epoch of the block height
+func (p UploadSpamProtection) Epoch(height int64) uint64 {
+	return uint64(height) / p.EpochLength
+}
+
+// EpochEndHeight returns the last block height of the quota epoch
+func (p UploadSpamProtection) EpochEndHeight(epoch uint64) int64 {
+	return int64((epoch+1)*p.EpochLength - 1)
+}
+
+// ValidateBasic performs basic validation
+func (p FeelessExecutions) ValidateBasic() error {
+	if len(p.Allowed) != 0 && p.MaxGas == 0 {
+		return errorsmod.Wrap(ErrEmpty, "max gas")
+	}
+	idx := make(map[FeelessExecution]struct{}, len(p.Allowed))
+	for i, v := range p.Allowed {
+		if _, err := sdk.AccAddressFromBech32(v.Contract); err != nil {
+			return errorsmod.Wrapf(err, "contract at position %d", i)
+		}
+		if len(v.MsgKey) == 0 {
+			return errorsmod.Wrapf(ErrEmpty, "msg key at position %d", i)
+		}
+		if _, exists := idx[v]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract %s with msg key %q", v.Contract, v.MsgKey)
+		}
+		idx[v] = struct{}{}
+	}
 	return nil
 }
 
+// Enabled returns true when executions are allowed and a max gas is set
+func (p FeelessExecutions) Enabled() bool {
+	return len(p.Allowed) != 0 && p.MaxGas != 0
+}
+
+// Allows returns true when the contract and the top-level key of the execute message are in the allow-list
+func (p FeelessExecutions) Allows(msg *MsgExecuteContract) bool {
+	var keys []string
+	for _, v := range p.Allowed {
+		if v.Contract == msg.Contract {
+			keys = append(keys, v.MsgKey)
+		}
+	}
+	if len(keys) == 0 {
+		return false
+	}
+	ok, err := isJSONObjectWithTopLevelKey(msg.Msg, keys)
+	return err == nil && ok
+}
+
+// ValidateBasic performs basic validation
+func (b ContractGasBudget) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(b.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if b.MaxGasPerBlock == 0 {
+		return errorsmod.Wrap(ErrEmpty, "max gas per block")
+	}
+	return nil
+}
+
+func validateContractGasBudgets(budgets []ContractGasBudget) error {
+	idx := make(map[string]struct{}, len(budgets))
+	for i, b := range budgets {
+		if err := b.ValidateBasic(); err != nil {
+			return errorsmod.Wrapf(err, "position %d", i)
+		}
+		if _, exists := idx[b.Contract]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "contract %s", b.Contract)
+		}
+		idx[b.Contract] = struct{}{}
+	}
+	return nil
+}
+
+// ContractGasBudget returns the per block gas budget of the contract. False is returned for unlimited contracts.
+func (p Params) ContractGasBudget(contract sdk.AccAddress) (uint64, bool) {
+	for _, b := range p.ContractGasBudgets {
+		if b.Contract == contract.String() {
+			return b.MaxGasPerBlock, true
+		}
+	}
+	return 0, false
+}
+
 func validateAccessType(a AccessType) error {
 	if a == AccessTypeUnspecified {
 		return errorsmod.Wrap(ErrEmpty, "type")
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	sdkmath "cosmossdk.io/math"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -105,6 +107,152 @@ func TestValidateParams(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"all good with upload spam protection": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				UploadSpamProtection: UploadSpamProtection{
+					Deposit:            sdk.NewCoins(sdk.NewInt64Coin("denom", 1)),
+					MaxUploadsPerEpoch: 1,
+					EpochLength:        100,
+				},
+			},
+		},
+		"reject invalid upload deposit": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				UploadSpamProtection: UploadSpamProtection{
+					Deposit: sdk.Coins{sdk.Coin{Denom: "denom", Amount: sdkmath.NewInt(-1)}},
+				},
+			},
+			expErr: true,
+		},
+		"reject upload quota without epoch length": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				UploadSpamProtection:         UploadSpamProtection{MaxUploadsPerEpoch: 1},
+			},
+			expErr: true,
+		},
+		"all good with feeless executions": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				FeelessExecutions: FeelessExecutions{
+					Allowed: []FeelessExecution{{Contract: anyAddress.String(), MsgKey: "foo"}, {Contract: anyAddress.String(), MsgKey: "bar"}},
+					MaxGas:  100_000,
+				},
+			},
+		},
+		"reject feeless executions without max gas": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				FeelessExecutions: FeelessExecutions{
+					Allowed: []FeelessExecution{{Contract: anyAddress.String(), MsgKey: "foo"}},
+				},
+			},
+			expErr: true,
+		},
+		"reject feeless execution with invalid contract": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				FeelessExecutions: FeelessExecutions{
+					Allowed: []FeelessExecution{{Contract: invalidAddress, MsgKey: "foo"}},
+					MaxGas:  100_000,
+				},
+			},
+			expErr: true,
+		},
+		"reject feeless execution without msg key": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				FeelessExecutions: FeelessExecutions{
+					Allowed: []FeelessExecution{{Contract: anyAddress.String()}},
+					MaxGas:  100_000,
+				},
+			},
+			expErr: true,
+		},
+		"reject duplicate feeless executions": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				FeelessExecutions: FeelessExecutions{
+					Allowed: []FeelessExecution{{Contract: anyAddress.String(), MsgKey: "foo"}, {Contract: anyAddress.String(), MsgKey: "foo"}},
+					MaxGas:  100_000,
+				},
+			},
+			expErr: true,
+		},
+		"all good with contract gas budgets": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ContractGasBudgets:           []ContractGasBudget{{Contract: anyAddress.String(), MaxGasPerBlock: 1_000_000}},
+			},
+		},
+		"reject contract gas budget with invalid contract": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ContractGasBudgets:           []ContractGasBudget{{Contract: invalidAddress, MaxGasPerBlock: 1_000_000}},
+			},
+			expErr: true,
+		},
+		"all good with default admin policy": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				DefaultAdminPolicy:           DefaultAdminPolicyCreator,
+			},
+		},
+		"reject unknown default admin policy": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				DefaultAdminPolicy:           DefaultAdminPolicy(99),
+			},
+			expErr: true,
+		},
+		"all good with query json encoding": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				QueryJSONEncoding:            QueryJSONEncodingV2,
+			},
+		},
+		"reject unknown query json encoding": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				QueryJSONEncoding:            QueryJSONEncoding(99),
+			},
+			expErr: true,
+		},
+		"reject contract gas budget without max gas": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ContractGasBudgets:           []ContractGasBudget{{Contract: anyAddress.String()}},
+			},
+			expErr: true,
+		},
+		"reject duplicate contract gas budgets": {
+			src: Params{
+				CodeUploadAccess:             AllowEverybody,
+				InstantiateDefaultPermission: AccessTypeEverybody,
+				ContractGasBudgets: []ContractGasBudget{
+					{Contract: anyAddress.String(), MaxGasPerBlock: 1_000_000},
+					{Contract: anyAddress.String(), MaxGasPerBlock: 2_000_000},
+				},
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -118,6 +266,58 @@ func TestValidateParams(t *testing.T) {
 	}
 }
 
+func TestFeelessExecutionsAllows(t *testing.T) {
+	var (
+		myContract    = sdk.AccAddress(bytes.Repeat([]byte{1}, ContractAddrLen)).String()
+		otherContract = sdk.AccAddress(bytes.Repeat([]byte{2}, ContractAddrLen)).String()
+	)
+	config := FeelessExecutions{
+		Allowed: []FeelessExecution{{Contract: myContract, MsgKey: "foo"}, {Contract: otherContract, MsgKey: "bar"}},
+		MaxGas:  1,
+	}
+	specs := map[string]struct {
+		contract string
+		msg      string
+		exp      bool
+	}{
+		"allowed key": {
+			contract: myContract,
+			msg:      `{"foo":{}}`,
+			exp:      true,
+		},
+		"key allowed for other contract": {
+			contract: myContract,
+			msg:      `{"bar":{}}`,
+		},
+		"nested key": {
+			contract: myContract,
+			msg:      `{"baz":{"foo":{}}}`,
+		},
+		"multiple keys": {
+			contract: myContract,
+			msg:      `{"foo":{},"bar":{}}`,
+		},
+		"not an object": {
+			contract: myContract,
+			msg:      `"foo"`,
+		},
+		"invalid json": {
+			contract: myContract,
+			msg:      `{"foo":`,
+		},
+		"unknown contract": {
+			contract: sdk.AccAddress(bytes.Repeat([]byte{3}, ContractAddrLen)).String(),
+			msg:      `{"foo":{}}`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got := config.Allows(&MsgExecuteContract{Contract: spec.contract, Msg: RawContractMessage(spec.msg)})
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
 func TestAccessTypeMarshalJson(t *testing.T) {
 	specs := map[string]struct {
 		src AccessType
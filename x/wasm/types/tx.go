@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"unicode"
 
 	errorsmod "cosmossdk.io/errors"
 
@@ -14,6 +15,10 @@ import (
 
 const maxCodeIDCount = 50
 
+// LastStoredCodeID can be used as code id in MsgPinCodes to pin the code that was stored last
+// in the same tx. The code id is resolved on execution. Proposals are not supported.
+const LastStoredCodeID uint64 = 0
+
 // RawContractMessage defines a json message that is sent or returned by a wasm contract.
 // This type can hold any type of bytes. Until validateBasic is called there should not be
 // any assumptions made that the data is valid syntax or semantic.
@@ -105,6 +110,9 @@ func (msg MsgInstantiateContract) ValidateBasic() error {
 		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
 			return errorsmod.Wrap(err, "admin")
 		}
+		if msg.NoAdmin {
+			return errorsmod.Wrap(ErrInvalid, "admin can not be combined with no admin")
+		}
 	}
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "payload msg")
@@ -290,6 +298,9 @@ func (msg MsgInstantiateContract2) ValidateBasic() error {
 		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
 			return errorsmod.Wrap(err, "admin")
 		}
+		if msg.NoAdmin {
+			return errorsmod.Wrap(ErrInvalid, "admin can not be combined with no admin")
+		}
 	}
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return errorsmod.Wrap(err, "payload msg")
@@ -317,6 +328,13 @@ func (msg MsgUpdateInstantiateConfig) ValidateBasic() error {
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
 	}
 
+	if msg.ResetToDefault {
+		if msg.NewInstantiatePermission != nil {
+			return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "instantiate permission must not be set with reset to default")
+		}
+		return nil
+	}
+
 	if msg.NewInstantiatePermission == nil {
 		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "instantiate permission is required")
 	}
@@ -521,6 +539,90 @@ func (msg MsgStoreAndMigrateContract) ValidateBasic() error {
 	return nil
 }
 
+func (msg MsgSetFeelessExecutions) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetFeelessExecutions) Type() string {
+	return "set-feeless-executions"
+}
+
+func (msg MsgSetFeelessExecutions) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	return errorsmod.Wrap(msg.FeelessExecutions.ValidateBasic(), "feeless executions")
+}
+
+func (msg MsgSetContractGasBudgets) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractGasBudgets) Type() string {
+	return "set-contract-gas-budgets"
+}
+
+func (msg MsgSetContractGasBudgets) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	return errorsmod.Wrap(validateContractGasBudgets(msg.Budgets), "budgets")
+}
+
+func (msg MsgFreezeCodeByChecksum) Route() string {
+	return RouterKey
+}
+
+func (msg MsgFreezeCodeByChecksum) Type() string {
+	return "freeze-code-by-checksum"
+}
+
+func (msg MsgFreezeCodeByChecksum) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if len(msg.Checksum) != 32 {
+		return errorsmod.Wrap(ErrInvalid, "checksum must be 32 bytes")
+	}
+	return nil
+}
+
+func (msg MsgDeprecateCode) Route() string {
+	return RouterKey
+}
+
+func (msg MsgDeprecateCode) Type() string {
+	return "deprecate-code"
+}
+
+func (msg MsgDeprecateCode) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	return nil
+}
+
+func (msg MsgPruneContractHistory) Route() string {
+	return RouterKey
+}
+
+func (msg MsgPruneContractHistory) Type() string {
+	return "prune-contract-history"
+}
+
+func (msg MsgPruneContractHistory) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
 // returns true when slice contains any duplicates
 func hasDuplicates[T comparable](s []T) bool {
 	index := make(map[T]struct{}, len(s))
@@ -553,3 +655,152 @@ func (msg MsgUpdateContractLabel) ValidateBasic() error {
 	}
 	return nil
 }
+
+func (msg MsgSetContractStateAccess) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractStateAccess) Type() string {
+	return "set-contract-state-access"
+}
+
+func (msg MsgSetContractStateAccess) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	return nil
+}
+
+func (msg MsgSetContractState) Route() string {
+	return RouterKey
+}
+
+func (msg MsgSetContractState) Type() string {
+	return "set-contract-state"
+}
+
+func (msg MsgSetContractState) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if _, err := sdk.AccAddressFromBech32(msg.Contract); err != nil {
+		return errorsmod.Wrap(err, "contract")
+	}
+	if len(msg.Models) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "models")
+	}
+	keys := make(map[string]struct{}, len(msg.Models))
+	for i, m := range msg.Models {
+		if len(m.Key) == 0 {
+			return errorsmod.Wrapf(ErrEmpty, "key at position %d", i)
+		}
+		if _, exists := keys[string(m.Key)]; exists {
+			return errorsmod.Wrapf(ErrDuplicate, "key: %x", m.Key)
+		}
+		keys[string(m.Key)] = struct{}{}
+	}
+	return nil
+}
+
+func (msg MsgForceMigrateWithoutAdminCheck) Route() string {
+	return RouterKey
+}
+
+func (msg MsgForceMigrateWithoutAdminCheck) Type() string {
+	return "force-migrate-without-admin-check"
+}
+
+func (msg MsgForceMigrateWithoutAdminCheck) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Authority); err != nil {
+		return errorsmod.Wrap(err, "authority")
+	}
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(sdkerrors.ErrInvalidRequest, "code id is required")
+	}
+	if len(msg.Contracts) == 0 {
+		return errorsmod.Wrap(ErrEmpty, "contracts")
+	}
+	for i, c := range msg.Contracts {
+		if _, err := sdk.AccAddressFromBech32(c); err != nil {
+			return errorsmod.Wrapf(err, "contract at position %d", i)
+		}
+	}
+	if hasDuplicates(msg.Contracts) {
+		return errorsmod.Wrap(ErrDuplicate, "contracts")
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+func (msg MsgExecuteContractCompat) Route() string {
+	return RouterKey
+}
+
+func (msg MsgExecuteContractCompat) Type() string {
+	return "execute-compat"
+}
+
+func (msg MsgExecuteContractCompat) ValidateBasic() error {
+	execMsg, err := msg.ToExecuteContract()
+	if err != nil {
+		return err
+	}
+	return execMsg.ValidateBasic()
+}
+
+// ToExecuteContract converts the message into the equivalent MsgExecuteContract
+func (msg MsgExecuteContractCompat) ToExecuteContract() (MsgExecuteContract, error) {
+	funds, err := ParseCompatFunds(msg.Funds)
+	if err != nil {
+		return MsgExecuteContract{}, errorsmod.Wrap(err, "funds")
+	}
+	return MsgExecuteContract{
+		Sender:   msg.Sender,
+		Contract: msg.Contract,
+		Msg:      msg.Msg,
+		Funds:    funds,
+	}, nil
+}
+
+// NewMsgExecuteContractCompat converts the message into the equivalent MsgExecuteContractCompat
+func NewMsgExecuteContractCompat(msg MsgExecuteContract) MsgExecuteContractCompat {
+	return MsgExecuteContractCompat{
+		Sender:   msg.Sender,
+		Contract: msg.Contract,
+		Msg:      msg.Msg,
+		Funds:    msg.Funds.String(),
+	}
+}
+
+// ParseCompatFunds parses funds in the string form "100uatom,5uosmo". Unlike sdk.ParseCoinsNormalized,
+// nothing is normalized: the coins must be in their canonical form with integer amounts, sorted by denom,
+// unique and positive.
+func ParseCompatFunds(s string) (sdk.Coins, error) {
+	if s == "" {
+		return nil, nil
+	}
+	if strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidCoins, "must not contain whitespace")
+	}
+	parts := strings.Split(s, ",")
+	coins := make(sdk.Coins, len(parts))
+	for i, p := range parts {
+		decCoin, err := sdk.ParseDecCoin(p)
+		if err != nil {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidCoins, "%q", p)
+		}
+		coins[i] = sdk.Coin{Denom: decCoin.Denom, Amount: decCoin.Amount.TruncateInt()}
+		if !decCoin.Amount.IsInteger() || coins[i].String() != p {
+			return nil, errorsmod.Wrapf(sdkerrors.ErrInvalidCoins, "%q: not a coin with an integer amount in canonical form", p)
+		}
+	}
+	if err := coins.Validate(); err != nil {
+		return nil, errorsmod.Wrap(sdkerrors.ErrInvalidCoins, err.Error())
+	}
+	return coins, nil
+}
@@ -11,6 +11,7 @@ import (
 	sdkmath "cosmossdk.io/math"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 const (
@@ -101,6 +102,27 @@ func TestInstantiateContractValidation(t *testing.T) {
 			},
 			valid: true,
 		},
+		"no admin": {
+			msg: MsgInstantiateContract{
+				Sender:  goodAddress,
+				CodeID:  firstCodeID,
+				Label:   "foo",
+				Msg:     []byte("{}"),
+				NoAdmin: true,
+			},
+			valid: true,
+		},
+		"admin and no admin": {
+			msg: MsgInstantiateContract{
+				Sender:  goodAddress,
+				Admin:   goodAddress,
+				CodeID:  firstCodeID,
+				Label:   "foo",
+				Msg:     []byte("{}"),
+				NoAdmin: true,
+			},
+			valid: false,
+		},
 		"missing code": {
 			msg: MsgInstantiateContract{
 				Sender: goodAddress,
@@ -189,6 +211,36 @@ func TestInstantiateContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"unsorted funds": {
+			msg: MsgInstantiateContract{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}, sdk.Coin{Denom: "alx", Amount: sdkmath.NewInt(1)}},
+			},
+			valid: false,
+		},
+		"duplicate funds": {
+			msg: MsgInstantiateContract{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}, sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}},
+			},
+			valid: false,
+		},
+		"zero funds": {
+			msg: MsgInstantiateContract{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.ZeroInt()}},
+			},
+			valid: false,
+		},
 		"non json init msg": {
 			msg: MsgInstantiateContract{
 				Sender: goodAddress,
@@ -243,6 +295,29 @@ func TestInstantiateContract2Validation(t *testing.T) {
 			},
 			valid: true,
 		},
+		"no admin": {
+			msg: MsgInstantiateContract2{
+				Sender:  goodAddress,
+				CodeID:  firstCodeID,
+				Label:   "foo",
+				Msg:     []byte("{}"),
+				Salt:    []byte{0},
+				NoAdmin: true,
+			},
+			valid: true,
+		},
+		"admin and no admin": {
+			msg: MsgInstantiateContract2{
+				Sender:  goodAddress,
+				Admin:   goodAddress,
+				CodeID:  firstCodeID,
+				Label:   "foo",
+				Msg:     []byte("{}"),
+				Salt:    []byte{0},
+				NoAdmin: true,
+			},
+			valid: false,
+		},
 		"missing code": {
 			msg: MsgInstantiateContract2{
 				Sender: goodAddress,
@@ -302,6 +377,39 @@ func TestInstantiateContract2Validation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"unsorted funds": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Salt:   []byte{0},
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}, sdk.Coin{Denom: "alx", Amount: sdkmath.NewInt(1)}},
+			},
+			valid: false,
+		},
+		"duplicate funds": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Salt:   []byte{0},
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}, sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}},
+			},
+			valid: false,
+		},
+		"zero funds": {
+			msg: MsgInstantiateContract2{
+				Sender: goodAddress,
+				CodeID: firstCodeID,
+				Label:  "foo",
+				Msg:    []byte(`{"some": "data"}`),
+				Salt:   []byte{0},
+				Funds:  sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.ZeroInt()}},
+			},
+			valid: false,
+		},
 		"non json init msg": {
 			msg: MsgInstantiateContract2{
 				Sender: goodAddress,
@@ -430,6 +538,24 @@ func TestExecuteContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"unsorted funds": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+				Funds:    sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.NewInt(1)}, sdk.Coin{Denom: "alx", Amount: sdkmath.NewInt(1)}},
+			},
+			valid: false,
+		},
+		"zero funds": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+				Funds:    sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdkmath.ZeroInt()}},
+			},
+			valid: false,
+		},
 		"non json msg": {
 			msg: MsgExecuteContract{
 				Sender:   goodAddress,
@@ -706,6 +832,22 @@ func TestMsgUpdateInstantiateConfig(t *testing.T) {
 			},
 			expErr: true,
 		},
+		"reset to default": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:         goodAddress,
+				CodeID:         1,
+				ResetToDefault: true,
+			},
+		},
+		"reset to default with NewInstantiatePermission": {
+			src: MsgUpdateInstantiateConfig{
+				Sender:                   goodAddress,
+				CodeID:                   1,
+				NewInstantiatePermission: &AllowEverybody,
+				ResetToDefault:           true,
+			},
+			expErr: true,
+		},
 	}
 	for msg, spec := range specs {
 		t.Run(msg, func(t *testing.T) {
@@ -1484,3 +1626,637 @@ func TestMsgUpdateContractLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestMsgSetContractState(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+	models := []Model{{Key: []byte("foo"), Value: []byte("bar")}}
+
+	specs := map[string]struct {
+		src    MsgSetContractState
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetContractState{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Models:    models,
+			},
+		},
+		"empty value": {
+			src: MsgSetContractState{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Models:    []Model{{Key: []byte("foo")}},
+			},
+		},
+		"bad authority": {
+			src: MsgSetContractState{
+				Authority: badAddress,
+				Contract:  otherGoodAddress,
+				Models:    models,
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSetContractState{
+				Authority: goodAddress,
+				Contract:  badAddress,
+				Models:    models,
+			},
+			expErr: true,
+		},
+		"empty models": {
+			src: MsgSetContractState{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+			},
+			expErr: true,
+		},
+		"empty key": {
+			src: MsgSetContractState{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Models:    []Model{{Value: []byte("bar")}},
+			},
+			expErr: true,
+		},
+		"duplicate key": {
+			src: MsgSetContractState{
+				Authority: goodAddress,
+				Contract:  otherGoodAddress,
+				Models:    []Model{{Key: []byte("foo"), Value: []byte("bar")}, {Key: []byte("foo"), Value: []byte("baz")}},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetFeelessExecutions(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+	allowed := []FeelessExecution{{Contract: otherGoodAddress, MsgKey: "push_price"}}
+
+	specs := map[string]struct {
+		src    MsgSetFeelessExecutions
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetFeelessExecutions{
+				Authority:         goodAddress,
+				FeelessExecutions: FeelessExecutions{Allowed: allowed, MaxGas: 1},
+			},
+		},
+		"clear list": {
+			src: MsgSetFeelessExecutions{
+				Authority: goodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgSetFeelessExecutions{
+				Authority:         badAddress,
+				FeelessExecutions: FeelessExecutions{Allowed: allowed, MaxGas: 1},
+			},
+			expErr: true,
+		},
+		"max gas not set": {
+			src: MsgSetFeelessExecutions{
+				Authority:         goodAddress,
+				FeelessExecutions: FeelessExecutions{Allowed: allowed},
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSetFeelessExecutions{
+				Authority:         goodAddress,
+				FeelessExecutions: FeelessExecutions{Allowed: []FeelessExecution{{Contract: badAddress, MsgKey: "push_price"}}, MaxGas: 1},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetContractGasBudgets(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	otherGoodAddress := sdk.AccAddress(bytes.Repeat([]byte{0x1}, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSetContractGasBudgets
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetContractGasBudgets{
+				Authority: goodAddress,
+				Budgets:   []ContractGasBudget{{Contract: otherGoodAddress, MaxGasPerBlock: 1}},
+			},
+		},
+		"clear budgets": {
+			src: MsgSetContractGasBudgets{
+				Authority: goodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgSetContractGasBudgets{
+				Authority: badAddress,
+				Budgets:   []ContractGasBudget{{Contract: otherGoodAddress, MaxGasPerBlock: 1}},
+			},
+			expErr: true,
+		},
+		"bad contract addr": {
+			src: MsgSetContractGasBudgets{
+				Authority: goodAddress,
+				Budgets:   []ContractGasBudget{{Contract: badAddress, MaxGasPerBlock: 1}},
+			},
+			expErr: true,
+		},
+		"max gas not set": {
+			src: MsgSetContractGasBudgets{
+				Authority: goodAddress,
+				Budgets:   []ContractGasBudget{{Contract: otherGoodAddress}},
+			},
+			expErr: true,
+		},
+		"duplicate contract": {
+			src: MsgSetContractGasBudgets{
+				Authority: goodAddress,
+				Budgets:   []ContractGasBudget{{Contract: otherGoodAddress, MaxGasPerBlock: 1}, {Contract: otherGoodAddress, MaxGasPerBlock: 2}},
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgFreezeCodeByChecksum(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgFreezeCodeByChecksum
+		expErr bool
+	}{
+		"all good": {
+			src: MsgFreezeCodeByChecksum{
+				Authority: goodAddress,
+				Checksum:  bytes.Repeat([]byte{0x1}, 32),
+			},
+		},
+		"bad authority": {
+			src: MsgFreezeCodeByChecksum{
+				Authority: badAddress,
+				Checksum:  bytes.Repeat([]byte{0x1}, 32),
+			},
+			expErr: true,
+		},
+		"empty checksum": {
+			src: MsgFreezeCodeByChecksum{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+		"checksum too short": {
+			src: MsgFreezeCodeByChecksum{
+				Authority: goodAddress,
+				Checksum:  bytes.Repeat([]byte{0x1}, 31),
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgDeprecateCode(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgDeprecateCode
+		expErr bool
+	}{
+		"all good": {
+			src: MsgDeprecateCode{
+				Authority: goodAddress,
+				CodeID:    1,
+			},
+		},
+		"bad authority": {
+			src: MsgDeprecateCode{
+				Authority: badAddress,
+				CodeID:    1,
+			},
+			expErr: true,
+		},
+		"empty code id": {
+			src: MsgDeprecateCode{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgPruneContractHistory(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgPruneContractHistory
+		expErr bool
+	}{
+		"all good": {
+			src: MsgPruneContractHistory{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+				KeepLastN: 1,
+			},
+		},
+		"keep none": {
+			src: MsgPruneContractHistory{
+				Authority: goodAddress,
+				Contract:  goodAddress,
+			},
+		},
+		"bad authority": {
+			src: MsgPruneContractHistory{
+				Authority: badAddress,
+				Contract:  goodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgPruneContractHistory{
+				Authority: goodAddress,
+				Contract:  badAddress,
+			},
+			expErr: true,
+		},
+		"empty contract": {
+			src: MsgPruneContractHistory{
+				Authority: goodAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgSetContractStateAccess(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	specs := map[string]struct {
+		src    MsgSetContractStateAccess
+		expErr bool
+	}{
+		"all good": {
+			src: MsgSetContractStateAccess{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+			},
+		},
+		"bad sender": {
+			src: MsgSetContractStateAccess{
+				Sender:   badAddress,
+				Contract: goodAddress,
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgSetContractStateAccess{
+				Sender:   goodAddress,
+				Contract: badAddress,
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgForceMigrateWithoutAdminCheckValidation(t *testing.T) {
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	firstContract := sdk.AccAddress(bytes.Repeat([]byte{1}, 32)).String()
+	secondContract := sdk.AccAddress(bytes.Repeat([]byte{2}, 32)).String()
+
+	specs := map[string]struct {
+		src    MsgForceMigrateWithoutAdminCheck
+		expErr bool
+	}{
+		"all good": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				Contracts: []string{firstContract, secondContract},
+				CodeID:    1,
+				Msg:       []byte("{}"),
+			},
+		},
+		"atomic": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				Contracts: []string{firstContract},
+				CodeID:    1,
+				Msg:       []byte("{}"),
+				Atomic:    true,
+			},
+		},
+		"bad authority": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: badAddress,
+				Contracts: []string{firstContract},
+				CodeID:    1,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"empty contracts": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				CodeID:    1,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				Contracts: []string{firstContract, badAddress},
+				CodeID:    1,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"duplicate contracts": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				Contracts: []string{firstContract, firstContract},
+				CodeID:    1,
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"empty code id": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				Contracts: []string{firstContract},
+				Msg:       []byte("{}"),
+			},
+			expErr: true,
+		},
+		"invalid msg": {
+			src: MsgForceMigrateWithoutAdminCheck{
+				Authority: goodAddress,
+				Contracts: []string{firstContract},
+				CodeID:    1,
+				Msg:       []byte("not json"),
+			},
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseCompatFunds(t *testing.T) {
+	specs := map[string]struct {
+		src    string
+		exp    sdk.Coins
+		expErr bool
+	}{
+		"empty": {},
+		"single coin": {
+			src: "100uatom",
+			exp: sdk.Coins{sdk.NewInt64Coin("uatom", 100)},
+		},
+		"multiple coins": {
+			src: "100uatom,5uosmo",
+			exp: sdk.Coins{sdk.NewInt64Coin("uatom", 100), sdk.NewInt64Coin("uosmo", 5)},
+		},
+		"ibc denom": {
+			src: "1ibc/7F1D3FCF4AE79E1554D670D1AD949A9BA4E4A3C76C63093E17E446A46061A7A2",
+			exp: sdk.Coins{sdk.NewInt64Coin("ibc/7F1D3FCF4AE79E1554D670D1AD949A9BA4E4A3C76C63093E17E446A46061A7A2", 1)},
+		},
+		"unsorted": {
+			src:    "5uosmo,100uatom",
+			expErr: true,
+		},
+		"duplicate denom": {
+			src:    "100uatom,5uatom",
+			expErr: true,
+		},
+		"zero amount": {
+			src:    "0uatom",
+			expErr: true,
+		},
+		"decimal amount": {
+			src:    "1.5uatom",
+			expErr: true,
+		},
+		"decimal amount with integer value": {
+			src:    "1.0uatom",
+			expErr: true,
+		},
+		"leading zeros": {
+			src:    "0100uatom",
+			expErr: true,
+		},
+		"negative amount": {
+			src:    "-1uatom",
+			expErr: true,
+		},
+		"whitespace": {
+			src:    "100uatom, 5uosmo",
+			expErr: true,
+		},
+		"trailing separator": {
+			src:    "100uatom,",
+			expErr: true,
+		},
+		"missing amount": {
+			src:    "uatom",
+			expErr: true,
+		},
+		"invalid denom": {
+			src:    "100u",
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseCompatFunds(spec.src)
+			if spec.expErr {
+				require.ErrorIs(t, gotErr, sdkerrors.ErrInvalidCoins)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestMsgExecuteContractCompatValidation(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	specs := map[string]struct {
+		src    MsgExecuteContractCompat
+		expErr bool
+	}{
+		"all good": {
+			src: MsgExecuteContractCompat{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+				Funds:    "100uatom,5uosmo",
+			},
+		},
+		"no funds": {
+			src: MsgExecuteContractCompat{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+			},
+		},
+		"bad sender": {
+			src: MsgExecuteContractCompat{
+				Sender:   badAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+			},
+			expErr: true,
+		},
+		"bad contract": {
+			src: MsgExecuteContractCompat{
+				Sender:   goodAddress,
+				Contract: badAddress,
+				Msg:      []byte(`{"some": "data"}`),
+			},
+			expErr: true,
+		},
+		"empty msg": {
+			src: MsgExecuteContractCompat{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+			},
+			expErr: true,
+		},
+		"invalid funds": {
+			src: MsgExecuteContractCompat{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+				Funds:    "5uosmo,100uatom",
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMsgExecuteContractCompatConversion(t *testing.T) {
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+	specs := map[string]sdk.Coins{
+		"no funds":       nil,
+		"single coin":    sdk.NewCoins(sdk.NewInt64Coin("uatom", 100)),
+		"multiple coins": sdk.NewCoins(sdk.NewInt64Coin("uosmo", 5), sdk.NewInt64Coin("uatom", 100)),
+	}
+	for name, funds := range specs {
+		t.Run(name, func(t *testing.T) {
+			src := MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"some": "data"}`),
+				Funds:    funds,
+			}
+			compat := NewMsgExecuteContractCompat(src)
+			require.NoError(t, compat.ValidateBasic())
+
+			got, err := compat.ToExecuteContract()
+			require.NoError(t, err)
+			assert.Equal(t, src, got)
+		})
+	}
+}
@@ -2,10 +2,13 @@ package types
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/v2/types"
+	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cosmos/gogoproto/proto"
 
 	errorsmod "cosmossdk.io/errors"
@@ -47,6 +50,14 @@ func (c CodeInfo) ValidateBasic() error {
 	return nil
 }
 
+// ValidateBasic checks the tx hash length when set. Source and builder are not validated as they are claims only.
+func (p CodeProvenance) ValidateBasic() error {
+	if len(p.TxHash) != 0 && len(p.TxHash) != tmhash.Size {
+		return errorsmod.Wrapf(ErrInvalid, "tx hash length: %d", len(p.TxHash))
+	}
+	return nil
+}
+
 // NewCodeInfo fills a new CodeInfo struct
 func NewCodeInfo(codeHash []byte, creator sdk.AccAddress, instantiatePermission AccessConfig) CodeInfo {
 	return CodeInfo{
@@ -58,6 +69,14 @@ func NewCodeInfo(codeHash []byte, creator sdk.AccAddress, instantiatePermission
 
 var AllCodeHistoryTypes = []ContractCodeHistoryOperationType{ContractCodeHistoryOperationTypeGenesis, ContractCodeHistoryOperationTypeInit, ContractCodeHistoryOperationTypeMigrate}
 
+// AllContractInfoHistoryTypes are the history operations that record a contract info change without a code change
+var AllContractInfoHistoryTypes = []ContractCodeHistoryOperationType{ContractCodeHistoryOperationTypeAdminChanged, ContractCodeHistoryOperationTypeLabelChanged}
+
+// IsCodeChange returns true for the operations that set the contract code
+func (o ContractCodeHistoryOperationType) IsCodeChange() bool {
+	return slices.Contains(AllCodeHistoryTypes, o)
+}
+
 // NewContractInfo creates a new instance of a given WASM contract info
 func NewContractInfo(codeID uint64, creator, admin sdk.AccAddress, label string, createdAt *AbsoluteTxPosition) ContractInfo {
 	var adminAddr string
@@ -176,6 +195,26 @@ func (c *ContractInfo) AddMigration(ctx sdk.Context, codeID uint64, msg []byte)
 	return h
 }
 
+// ContractInfoChange is the msg of a history entry that records an admin or label change
+type ContractInfoChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// NewContractInfoChangeEntry returns the history entry for an admin or label change of the contract
+func NewContractInfoChangeEntry(ctx sdk.Context, operation ContractCodeHistoryOperationType, codeID uint64, oldValue, newValue string) ContractCodeHistoryEntry {
+	msg, err := json.Marshal(ContractInfoChange{Old: oldValue, New: newValue})
+	if err != nil { // should never happen
+		panic(err.Error())
+	}
+	return ContractCodeHistoryEntry{
+		Operation: operation,
+		CodeID:    codeID,
+		Updated:   NewAbsoluteTxPosition(ctx),
+		Msg:       msg,
+	}
+}
+
 // AdminAddr convert into sdk.AccAddress or nil when not set
 func (c *ContractInfo) AdminAddr() sdk.AccAddress {
 	if c.Admin == "" {
@@ -250,14 +289,7 @@ func (a *AbsoluteTxPosition) Bytes() []byte {
 
 // ValidateBasic syntax checks
 func (c ContractCodeHistoryEntry) ValidateBasic() error {
-	var found bool
-	for _, v := range AllCodeHistoryTypes {
-		if c.Operation == v {
-			found = true
-			break
-		}
-	}
-	if !found {
+	if !c.Operation.IsCodeChange() && !slices.Contains(AllContractInfoHistoryTypes, c.Operation) {
 		return ErrInvalid.Wrap("operation")
 	}
 	if c.CodeID == 0 {
@@ -333,6 +365,10 @@ type NodeConfig struct {
 	MemoryCacheSize uint32 `mapstructure:"memory_cache_size"`
 	// ContractDebugMode log what contract print
 	ContractDebugMode bool
+	// ExecutionReceiptsLimit is the number of recent executions per contract that are kept in memory for the
+	// RecentExecutions query. The receipts are node local and not part of the consensus state. Only the 1000 most
+	// recently executed contracts are tracked. 0 disables them.
+	ExecutionReceiptsLimit uint32 `mapstructure:"execution_receipts_limit"`
 }
 
 // DefaultNodeConfig returns the default settings for NodeConfig
@@ -368,16 +404,22 @@ memory_cache_size = %d
 # Simulation gas limit is the max gas to be used in a tx simulation call.
 # When not set the consensus max block gas is used instead
 %s
-`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit)
+
+# Number of recent executions per contract that are kept in memory for the
+# recent executions query. They are node local and not part of the consensus
+# state. Set to 0 to disable.
+execution_receipts_limit = %d
+`, c.SmartQueryGasLimit, c.MemoryCacheSize, simGasLimit, c.ExecutionReceiptsLimit)
 }
 
-// VerifyAddressLen ensures that the address matches the expected length
-func VerifyAddressLen() func(addr []byte) error {
+// VerifyAddressLen ensures that the address matches the expected length of a contract or sdk address.
+// App chains with other account address lengths can accept them via additional lengths.
+func VerifyAddressLen(additionalLens ...int) func(addr []byte) error {
 	return func(addr []byte) error {
-		if len(addr) != ContractAddrLen && len(addr) != SDKAddrLen {
-			return sdkerrors.ErrInvalidAddress
+		if len(addr) == ContractAddrLen || len(addr) == SDKAddrLen || slices.Contains(additionalLens, len(addr)) {
+			return nil
 		}
-		return nil
+		return sdkerrors.ErrInvalidAddress
 	}
 }
 
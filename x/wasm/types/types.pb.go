@@ -12,7 +12,9 @@ import (
 
 	github_com_cometbft_cometbft_libs_bytes "github.com/cometbft/cometbft/libs/bytes"
 	_ "github.com/cosmos/cosmos-proto"
-	types "github.com/cosmos/cosmos-sdk/codec/types"
+	types1 "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/cosmos/cosmos-sdk/types/tx/amino"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/cosmos/gogoproto/proto"
@@ -63,6 +65,81 @@ func (AccessType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e6155d98fa173e02, []int{0}
 }
 
+// DefaultAdminPolicy defines how an instantiation without admin is handled
+type DefaultAdminPolicy int32
+
+const (
+	// DefaultAdminPolicyUnspecified placeholder for empty value, handled as
+	// DefaultAdminPolicyError
+	DefaultAdminPolicyUnspecified DefaultAdminPolicy = 0
+	// DefaultAdminPolicyError the contract has no admin. Clients require an
+	// explicit choice for no admin.
+	DefaultAdminPolicyError DefaultAdminPolicy = 1
+	// DefaultAdminPolicyCreator the creator becomes the contract admin unless
+	// no admin is set explicitly
+	DefaultAdminPolicyCreator DefaultAdminPolicy = 2
+	// DefaultAdminPolicyNone the contract has no admin
+	DefaultAdminPolicyNone DefaultAdminPolicy = 3
+)
+
+var DefaultAdminPolicy_name = map[int32]string{
+	0: "DEFAULT_ADMIN_POLICY_UNSPECIFIED",
+	1: "DEFAULT_ADMIN_POLICY_ERROR",
+	2: "DEFAULT_ADMIN_POLICY_CREATOR",
+	3: "DEFAULT_ADMIN_POLICY_NONE",
+}
+
+var DefaultAdminPolicy_value = map[string]int32{
+	"DEFAULT_ADMIN_POLICY_UNSPECIFIED": 0,
+	"DEFAULT_ADMIN_POLICY_ERROR":       1,
+	"DEFAULT_ADMIN_POLICY_CREATOR":     2,
+	"DEFAULT_ADMIN_POLICY_NONE":        3,
+}
+
+func (x DefaultAdminPolicy) String() string {
+	return proto.EnumName(DefaultAdminPolicy_name, int32(x))
+}
+
+func (DefaultAdminPolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{1}
+}
+
+// QueryJSONEncoding defines the JSON encoding of the proto query responses
+// that are passed to contracts. A version must never change once released.
+type QueryJSONEncoding int32
+
+const (
+	// QueryJSONEncodingUnspecified placeholder for empty value, handled as
+	// QueryJSONEncodingV1
+	QueryJSONEncodingUnspecified QueryJSONEncoding = 0
+	// QueryJSONEncodingV1 proto field names, fields with default values are
+	// included and enums are encoded by name
+	QueryJSONEncodingV1 QueryJSONEncoding = 1
+	// QueryJSONEncodingV2 canonical proto3 JSON: lowerCamelCase field names,
+	// fields with default values are omitted and enums are encoded by name
+	QueryJSONEncodingV2 QueryJSONEncoding = 2
+)
+
+var QueryJSONEncoding_name = map[int32]string{
+	0: "QUERY_JSON_ENCODING_UNSPECIFIED",
+	1: "QUERY_JSON_ENCODING_V1",
+	2: "QUERY_JSON_ENCODING_V2",
+}
+
+var QueryJSONEncoding_value = map[string]int32{
+	"QUERY_JSON_ENCODING_UNSPECIFIED": 0,
+	"QUERY_JSON_ENCODING_V1":          1,
+	"QUERY_JSON_ENCODING_V2":          2,
+}
+
+func (x QueryJSONEncoding) String() string {
+	return proto.EnumName(QueryJSONEncoding_name, int32(x))
+}
+
+func (QueryJSONEncoding) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{2}
+}
+
 // ContractCodeHistoryOperationType actions that caused a code change
 type ContractCodeHistoryOperationType int32
 
@@ -75,6 +152,12 @@ const (
 	ContractCodeHistoryOperationTypeMigrate ContractCodeHistoryOperationType = 2
 	// ContractCodeHistoryOperationTypeGenesis based on genesis data
 	ContractCodeHistoryOperationTypeGenesis ContractCodeHistoryOperationType = 3
+	// ContractCodeHistoryOperationTypeAdminChanged contract admin update or clear.
+	// Recorded only when enabled in the params.
+	ContractCodeHistoryOperationTypeAdminChanged ContractCodeHistoryOperationType = 4
+	// ContractCodeHistoryOperationTypeLabelChanged contract label update.
+	// Recorded only when enabled in the params.
+	ContractCodeHistoryOperationTypeLabelChanged ContractCodeHistoryOperationType = 5
 )
 
 var ContractCodeHistoryOperationType_name = map[int32]string{
@@ -82,13 +165,17 @@ var ContractCodeHistoryOperationType_name = map[int32]string{
 	1: "CONTRACT_CODE_HISTORY_OPERATION_TYPE_INIT",
 	2: "CONTRACT_CODE_HISTORY_OPERATION_TYPE_MIGRATE",
 	3: "CONTRACT_CODE_HISTORY_OPERATION_TYPE_GENESIS",
+	4: "CONTRACT_CODE_HISTORY_OPERATION_TYPE_ADMIN_CHANGED",
+	5: "CONTRACT_CODE_HISTORY_OPERATION_TYPE_LABEL_CHANGED",
 }
 
 var ContractCodeHistoryOperationType_value = map[string]int32{
-	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_UNSPECIFIED": 0,
-	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_INIT":        1,
-	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_MIGRATE":     2,
-	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_GENESIS":     3,
+	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_UNSPECIFIED":   0,
+	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_INIT":          1,
+	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_MIGRATE":       2,
+	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_GENESIS":       3,
+	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_ADMIN_CHANGED": 4,
+	"CONTRACT_CODE_HISTORY_OPERATION_TYPE_LABEL_CHANGED": 5,
 }
 
 func (x ContractCodeHistoryOperationType) String() string {
@@ -96,7 +183,58 @@ func (x ContractCodeHistoryOperationType) String() string {
 }
 
 func (ContractCodeHistoryOperationType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{1}
+	return fileDescriptor_e6155d98fa173e02, []int{3}
+}
+
+// ContractManagementChangeType is the kind of change reported by
+// EventContractManagementChanged
+type ContractManagementChangeType int32
+
+const (
+	// ContractManagementChangeTypeUnspecified placeholder for empty value
+	ContractManagementChangeTypeUnspecified ContractManagementChangeType = 0
+	// ContractManagementChangeTypeAdmin the contract admin was updated or
+	// cleared. Values are the admin addresses, empty when not set.
+	ContractManagementChangeTypeAdmin ContractManagementChangeType = 1
+	// ContractManagementChangeTypeLabel the contract label was updated. Values
+	// are the labels.
+	ContractManagementChangeTypeLabel ContractManagementChangeType = 2
+	// ContractManagementChangeTypeInstantiateConfig the instantiate config of
+	// the contract code was updated. Values are the permission followed by the
+	// authorized addresses, if any: `AnyOfAddresses:<addr1>,<addr2>`
+	ContractManagementChangeTypeInstantiateConfig ContractManagementChangeType = 3
+	// ContractManagementChangeTypePin the contract code was pinned or unpinned.
+	// Values are the pin status: `true` or `false`
+	ContractManagementChangeTypePin ContractManagementChangeType = 4
+	// ContractManagementChangeTypeMigrate the contract was migrated. Values are
+	// the code ids.
+	ContractManagementChangeTypeMigrate ContractManagementChangeType = 5
+)
+
+var ContractManagementChangeType_name = map[int32]string{
+	0: "CONTRACT_MANAGEMENT_CHANGE_TYPE_UNSPECIFIED",
+	1: "CONTRACT_MANAGEMENT_CHANGE_TYPE_ADMIN",
+	2: "CONTRACT_MANAGEMENT_CHANGE_TYPE_LABEL",
+	3: "CONTRACT_MANAGEMENT_CHANGE_TYPE_INSTANTIATE_CONFIG",
+	4: "CONTRACT_MANAGEMENT_CHANGE_TYPE_PIN",
+	5: "CONTRACT_MANAGEMENT_CHANGE_TYPE_MIGRATE",
+}
+
+var ContractManagementChangeType_value = map[string]int32{
+	"CONTRACT_MANAGEMENT_CHANGE_TYPE_UNSPECIFIED":        0,
+	"CONTRACT_MANAGEMENT_CHANGE_TYPE_ADMIN":              1,
+	"CONTRACT_MANAGEMENT_CHANGE_TYPE_LABEL":              2,
+	"CONTRACT_MANAGEMENT_CHANGE_TYPE_INSTANTIATE_CONFIG": 3,
+	"CONTRACT_MANAGEMENT_CHANGE_TYPE_PIN":                4,
+	"CONTRACT_MANAGEMENT_CHANGE_TYPE_MIGRATE":            5,
+}
+
+func (x ContractManagementChangeType) String() string {
+	return proto.EnumName(ContractManagementChangeType_name, int32(x))
+}
+
+func (ContractManagementChangeType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{4}
 }
 
 // AccessTypeParam
@@ -190,6 +328,52 @@ var xxx_messageInfo_AccessConfig proto.InternalMessageInfo
 type Params struct {
 	CodeUploadAccess             AccessConfig `protobuf:"bytes,1,opt,name=code_upload_access,json=codeUploadAccess,proto3" json:"code_upload_access" yaml:"code_upload_access"`
 	InstantiateDefaultPermission AccessType   `protobuf:"varint,2,opt,name=instantiate_default_permission,json=instantiateDefaultPermission,proto3,enum=cosmwasm.wasm.v1.AccessType" json:"instantiate_default_permission,omitempty" yaml:"instantiate_default_permission"`
+	// DeduplicateStoreCode when set, a MsgStoreCode with wasm code that was
+	// already stored within the same transaction by the same creator and with
+	// the same instantiate permission returns the existing code id instead of
+	// failing
+	DeduplicateStoreCode bool `protobuf:"varint,3,opt,name=deduplicate_store_code,json=deduplicateStoreCode,proto3" json:"deduplicate_store_code,omitempty" yaml:"deduplicate_store_code"`
+	// StrictAdminValidation when set, a contract admin must be an existing
+	// account or contract
+	StrictAdminValidation bool `protobuf:"varint,4,opt,name=strict_admin_validation,json=strictAdminValidation,proto3" json:"strict_admin_validation,omitempty" yaml:"strict_admin_validation"`
+	// AllowRawStateWrites when set, MsgSetContractState can write directly to
+	// the contract store. This is meant for local or dev chains and can only be
+	// set at genesis.
+	AllowRawStateWrites bool `protobuf:"varint,5,opt,name=allow_raw_state_writes,json=allowRawStateWrites,proto3" json:"allow_raw_state_writes,omitempty" yaml:"allow_raw_state_writes"`
+	// UploadSpamProtection restricts code uploads by non-privileged accounts
+	// when everybody can upload code
+	UploadSpamProtection UploadSpamProtection `protobuf:"bytes,6,opt,name=upload_spam_protection,json=uploadSpamProtection,proto3" json:"upload_spam_protection" yaml:"upload_spam_protection"`
+	// RecordContractInfoChanges when set, admin and label changes are appended
+	// to the contract history
+	RecordContractInfoChanges bool `protobuf:"varint,7,opt,name=record_contract_info_changes,json=recordContractInfoChanges,proto3" json:"record_contract_info_changes,omitempty" yaml:"record_contract_info_changes"`
+	// FeelessExecutions are the contract executions that bypass the min fee
+	// check when a tx consists of them only
+	FeelessExecutions FeelessExecutions `protobuf:"bytes,8,opt,name=feeless_executions,json=feelessExecutions,proto3" json:"feeless_executions" yaml:"feeless_executions"`
+	// ContractGasBudgets limit the execution gas of contracts per block.
+	// Contracts without a budget are unlimited.
+	ContractGasBudgets []ContractGasBudget `protobuf:"bytes,9,rep,name=contract_gas_budgets,json=contractGasBudgets,proto3" json:"contract_gas_budgets" yaml:"contract_gas_budgets"`
+	// ContractStateAccessControl when set, contract admins can disable the raw
+	// state queries of their contracts with MsgSetContractStateAccess
+	ContractStateAccessControl bool `protobuf:"varint,10,opt,name=contract_state_access_control,json=contractStateAccessControl,proto3" json:"contract_state_access_control,omitempty" yaml:"contract_state_access_control"`
+	// TrackContractActivity when set, the block height of the last execute,
+	// sudo or ibc call is recorded per contract
+	TrackContractActivity bool `protobuf:"varint,11,opt,name=track_contract_activity,json=trackContractActivity,proto3" json:"track_contract_activity,omitempty" yaml:"track_contract_activity"`
+	// DefaultAdminPolicy defines how an instantiation without admin is handled
+	DefaultAdminPolicy DefaultAdminPolicy `protobuf:"varint,12,opt,name=default_admin_policy,json=defaultAdminPolicy,proto3,enum=cosmwasm.wasm.v1.DefaultAdminPolicy" json:"default_admin_policy,omitempty" yaml:"default_admin_policy"`
+	// RecordModuleActivity when set, the wasm activity per block is persisted
+	// for a rolling window of blocks
+	RecordModuleActivity bool `protobuf:"varint,13,opt,name=record_module_activity,json=recordModuleActivity,proto3" json:"record_module_activity,omitempty" yaml:"record_module_activity"`
+	// QueryJSONEncoding is the JSON encoding of the proto query responses that
+	// are passed to contracts. Changing it requires a coordinated upgrade with
+	// the contracts that query the chain.
+	QueryJSONEncoding QueryJSONEncoding `protobuf:"varint,14,opt,name=query_json_encoding,json=queryJsonEncoding,proto3,enum=cosmwasm.wasm.v1.QueryJSONEncoding" json:"query_json_encoding,omitempty" yaml:"query_json_encoding"`
+	// RecordCodeGasUsage when set, the execution gas per code id is summed up in
+	// buckets of blocks for the gas leaderboard query
+	RecordCodeGasUsage bool `protobuf:"varint,15,opt,name=record_code_gas_usage,json=recordCodeGasUsage,proto3" json:"record_code_gas_usage,omitempty" yaml:"record_code_gas_usage"`
+	// IndexContractLabels when set, contracts are indexed by their exact label
+	// for the ContractsByLabel query. It can only be set at genesis or by
+	// migration as the index is not rebuilt on param changes.
+	IndexContractLabels bool `protobuf:"varint,16,opt,name=index_contract_labels,json=indexContractLabels,proto3" json:"index_contract_labels,omitempty" yaml:"index_contract_labels"`
 }
 
 func (m *Params) Reset()      { *m = Params{} }
@@ -229,6 +413,196 @@ func (m *Params) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Params proto.InternalMessageInfo
 
+// UploadSpamProtection defines the deposit and quota for code uploads by
+// non-privileged accounts. It is disabled when no deposit and no quota is set.
+type UploadSpamProtection struct {
+	// Deposit is burned from the uploader account for each stored code
+	Deposit github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,1,rep,name=deposit,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"deposit"`
+	// MaxUploadsPerEpoch is the max number of codes an account can store within
+	// an epoch. Zero means no quota.
+	MaxUploadsPerEpoch uint64 `protobuf:"varint,2,opt,name=max_uploads_per_epoch,json=maxUploadsPerEpoch,proto3" json:"max_uploads_per_epoch,omitempty"`
+	// EpochLength is the number of blocks of an upload quota epoch
+	EpochLength uint64 `protobuf:"varint,3,opt,name=epoch_length,json=epochLength,proto3" json:"epoch_length,omitempty"`
+}
+
+func (m *UploadSpamProtection) Reset()         { *m = UploadSpamProtection{} }
+func (m *UploadSpamProtection) String() string { return proto.CompactTextString(m) }
+func (*UploadSpamProtection) ProtoMessage()    {}
+func (*UploadSpamProtection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{3}
+}
+
+func (m *UploadSpamProtection) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *UploadSpamProtection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UploadSpamProtection.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *UploadSpamProtection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UploadSpamProtection.Merge(m, src)
+}
+
+func (m *UploadSpamProtection) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *UploadSpamProtection) XXX_DiscardUnknown() {
+	xxx_messageInfo_UploadSpamProtection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UploadSpamProtection proto.InternalMessageInfo
+
+// FeelessExecutions defines the allow-list of contract executions that can be
+// sent without fees. It is disabled when the list or the max gas is empty.
+type FeelessExecutions struct {
+	// Allowed are the contract and top-level message key pairs
+	Allowed []FeelessExecution `protobuf:"bytes,1,rep,name=allowed,proto3" json:"allowed"`
+	// MaxGas is the max gas limit of a fee-less tx
+	MaxGas uint64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
+}
+
+func (m *FeelessExecutions) Reset()         { *m = FeelessExecutions{} }
+func (m *FeelessExecutions) String() string { return proto.CompactTextString(m) }
+func (*FeelessExecutions) ProtoMessage()    {}
+func (*FeelessExecutions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{4}
+}
+
+func (m *FeelessExecutions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *FeelessExecutions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeelessExecutions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *FeelessExecutions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeelessExecutions.Merge(m, src)
+}
+
+func (m *FeelessExecutions) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *FeelessExecutions) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeelessExecutions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeelessExecutions proto.InternalMessageInfo
+
+// FeelessExecution is a contract execution that can be sent without fees
+type FeelessExecution struct {
+	// Contract is the address of the smart contract
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// MsgKey is the top-level key of the json execute message
+	MsgKey string `protobuf:"bytes,2,opt,name=msg_key,json=msgKey,proto3" json:"msg_key,omitempty"`
+}
+
+func (m *FeelessExecution) Reset()         { *m = FeelessExecution{} }
+func (m *FeelessExecution) String() string { return proto.CompactTextString(m) }
+func (*FeelessExecution) ProtoMessage()    {}
+func (*FeelessExecution) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{5}
+}
+
+func (m *FeelessExecution) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *FeelessExecution) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeelessExecution.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *FeelessExecution) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeelessExecution.Merge(m, src)
+}
+
+func (m *FeelessExecution) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *FeelessExecution) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeelessExecution.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeelessExecution proto.InternalMessageInfo
+
+// ContractGasBudget is the max execution gas of a contract per block
+type ContractGasBudget struct {
+	// Contract is the address of the smart contract
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// MaxGasPerBlock is the max cumulative execution gas of the contract within
+	// a block
+	MaxGasPerBlock uint64 `protobuf:"varint,2,opt,name=max_gas_per_block,json=maxGasPerBlock,proto3" json:"max_gas_per_block,omitempty"`
+}
+
+func (m *ContractGasBudget) Reset()         { *m = ContractGasBudget{} }
+func (m *ContractGasBudget) String() string { return proto.CompactTextString(m) }
+func (*ContractGasBudget) ProtoMessage()    {}
+func (*ContractGasBudget) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{6}
+}
+
+func (m *ContractGasBudget) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *ContractGasBudget) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractGasBudget.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *ContractGasBudget) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractGasBudget.Merge(m, src)
+}
+
+func (m *ContractGasBudget) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *ContractGasBudget) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractGasBudget.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractGasBudget proto.InternalMessageInfo
+
 // CodeInfo is data for the uploaded contract WASM code
 type CodeInfo struct {
 	// CodeHash is the unique identifier created by wasmvm
@@ -237,13 +611,16 @@ type CodeInfo struct {
 	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
 	// InstantiateConfig access control to apply on contract creation, optional
 	InstantiateConfig AccessConfig `protobuf:"bytes,5,opt,name=instantiate_config,json=instantiateConfig,proto3" json:"instantiate_config"`
+	// Deprecated codes can not be instantiated or used as migration target.
+	// Existing contracts are not affected.
+	Deprecated bool `protobuf:"varint,6,opt,name=deprecated,proto3" json:"deprecated,omitempty"`
 }
 
 func (m *CodeInfo) Reset()         { *m = CodeInfo{} }
 func (m *CodeInfo) String() string { return proto.CompactTextString(m) }
 func (*CodeInfo) ProtoMessage()    {}
 func (*CodeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{3}
+	return fileDescriptor_e6155d98fa173e02, []int{7}
 }
 
 func (m *CodeInfo) XXX_Unmarshal(b []byte) error {
@@ -277,6 +654,55 @@ func (m *CodeInfo) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CodeInfo proto.InternalMessageInfo
 
+// CodeProvenance is the record of the code upload origin
+type CodeProvenance struct {
+	// TxHash is the hash of the transaction that stored the code. Not set for
+	// codes stored outside of a transaction, like by gov proposals.
+	TxHash github_com_cometbft_cometbft_libs_bytes.HexBytes `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3,casttype=github.com/cometbft/cometbft/libs/bytes.HexBytes" json:"tx_hash,omitempty"`
+	// Source is the claimed URL to the source code, optional
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// Builder is the claimed docker image used to build the code, optional
+	Builder string `protobuf:"bytes,3,opt,name=builder,proto3" json:"builder,omitempty"`
+}
+
+func (m *CodeProvenance) Reset()         { *m = CodeProvenance{} }
+func (m *CodeProvenance) String() string { return proto.CompactTextString(m) }
+func (*CodeProvenance) ProtoMessage()    {}
+func (*CodeProvenance) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{8}
+}
+
+func (m *CodeProvenance) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *CodeProvenance) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CodeProvenance.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *CodeProvenance) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CodeProvenance.Merge(m, src)
+}
+
+func (m *CodeProvenance) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *CodeProvenance) XXX_DiscardUnknown() {
+	xxx_messageInfo_CodeProvenance.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CodeProvenance proto.InternalMessageInfo
+
 // ContractInfo stores a WASM contract instance
 type ContractInfo struct {
 	// CodeID is the reference to the stored Wasm code
@@ -292,14 +718,18 @@ type ContractInfo struct {
 	IBCPortID string              `protobuf:"bytes,6,opt,name=ibc_port_id,json=ibcPortId,proto3" json:"ibc_port_id,omitempty"`
 	// Extension is an extension point to store custom metadata within the
 	// persistence model.
-	Extension *types.Any `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
+	Extension *types1.Any `protobuf:"bytes,7,opt,name=extension,proto3" json:"extension,omitempty"`
+	// RawQueryDisabled when set, the raw state of the contract can not be
+	// queried via gRPC while the contract state access control is enabled in
+	// the params
+	RawQueryDisabled bool `protobuf:"varint,8,opt,name=raw_query_disabled,json=rawQueryDisabled,proto3" json:"raw_query_disabled,omitempty"`
 }
 
 func (m *ContractInfo) Reset()         { *m = ContractInfo{} }
 func (m *ContractInfo) String() string { return proto.CompactTextString(m) }
 func (*ContractInfo) ProtoMessage()    {}
 func (*ContractInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{4}
+	return fileDescriptor_e6155d98fa173e02, []int{9}
 }
 
 func (m *ContractInfo) XXX_Unmarshal(b []byte) error {
@@ -347,7 +777,7 @@ func (m *ContractCodeHistoryEntry) Reset()         { *m = ContractCodeHistoryEnt
 func (m *ContractCodeHistoryEntry) String() string { return proto.CompactTextString(m) }
 func (*ContractCodeHistoryEntry) ProtoMessage()    {}
 func (*ContractCodeHistoryEntry) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{5}
+	return fileDescriptor_e6155d98fa173e02, []int{10}
 }
 
 func (m *ContractCodeHistoryEntry) XXX_Unmarshal(b []byte) error {
@@ -395,7 +825,7 @@ func (m *AbsoluteTxPosition) Reset()         { *m = AbsoluteTxPosition{} }
 func (m *AbsoluteTxPosition) String() string { return proto.CompactTextString(m) }
 func (*AbsoluteTxPosition) ProtoMessage()    {}
 func (*AbsoluteTxPosition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{6}
+	return fileDescriptor_e6155d98fa173e02, []int{11}
 }
 
 func (m *AbsoluteTxPosition) XXX_Unmarshal(b []byte) error {
@@ -441,7 +871,7 @@ func (m *Model) Reset()         { *m = Model{} }
 func (m *Model) String() string { return proto.CompactTextString(m) }
 func (*Model) ProtoMessage()    {}
 func (*Model) Descriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{7}
+	return fileDescriptor_e6155d98fa173e02, []int{12}
 }
 
 func (m *Model) XXX_Unmarshal(b []byte) error {
@@ -475,166 +905,494 @@ func (m *Model) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Model proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("cosmwasm.wasm.v1.AccessType", AccessType_name, AccessType_value)
-	proto.RegisterEnum("cosmwasm.wasm.v1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
-	proto.RegisterType((*AccessTypeParam)(nil), "cosmwasm.wasm.v1.AccessTypeParam")
-	proto.RegisterType((*AccessConfig)(nil), "cosmwasm.wasm.v1.AccessConfig")
-	proto.RegisterType((*Params)(nil), "cosmwasm.wasm.v1.Params")
-	proto.RegisterType((*CodeInfo)(nil), "cosmwasm.wasm.v1.CodeInfo")
-	proto.RegisterType((*ContractInfo)(nil), "cosmwasm.wasm.v1.ContractInfo")
-	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1.ContractCodeHistoryEntry")
-	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1.AbsoluteTxPosition")
-	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1.Model")
+// EventGasBreakdown reports the gas consumed by a wasm message per category.
+// It is emitted in simulations with a GasBreakdownExtensionOption only.
+type EventGasBreakdown struct {
+	// Setup is the gas for loading, compiling and instantiating contract code
+	Setup uint64 `protobuf:"varint,1,opt,name=setup,proto3" json:"setup,omitempty"`
+	// Execution is the gas for running the contracts in the VM
+	Execution uint64 `protobuf:"varint,2,opt,name=execution,proto3" json:"execution,omitempty"`
+	// Storage is the gas for state reads and writes
+	Storage uint64 `protobuf:"varint,3,opt,name=storage,proto3" json:"storage,omitempty"`
+	// Events is the gas for custom contract event attributes
+	Events uint64 `protobuf:"varint,4,opt,name=events,proto3" json:"events,omitempty"`
+	// Submessages is the gas for submessages with a gas limit
+	Submessages uint64 `protobuf:"varint,5,opt,name=submessages,proto3" json:"submessages,omitempty"`
+	// Other is the gas that is not assigned to any of the categories above. It
+	// is 0 when refunds exceed the unassigned gas, the categories can sum up to
+	// more than the total then.
+	Other uint64 `protobuf:"varint,6,opt,name=other,proto3" json:"other,omitempty"`
+	// Total is the gas consumed by the wasm message
+	Total uint64 `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
 }
 
-func init() { proto.RegisterFile("cosmwasm/wasm/v1/types.proto", fileDescriptor_e6155d98fa173e02) }
-
-var fileDescriptor_e6155d98fa173e02 = []byte{
-	// 1198 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0xcf, 0x6f, 0x1b, 0xc5,
-	0x17, 0xf7, 0xda, 0x4e, 0x62, 0x4f, 0xf2, 0xfd, 0xb2, 0x1d, 0x52, 0xd5, 0x31, 0x91, 0x6d, 0x96,
-	0x12, 0xda, 0xb4, 0xb5, 0xdb, 0x80, 0x2a, 0xd4, 0x43, 0x25, 0xff, 0xd8, 0x36, 0x5b, 0x29, 0xb6,
-	0x35, 0x76, 0x29, 0x41, 0x2a, 0xab, 0xf5, 0xee, 0xd8, 0x19, 0x6a, 0xef, 0x58, 0x3b, 0xe3, 0xd4,
-	0xbe, 0x72, 0x42, 0x46, 0x48, 0x1c, 0x38, 0x20, 0x24, 0x4b, 0x48, 0x20, 0xe8, 0xb1, 0x87, 0xfe,
-	0x03, 0xdc, 0x2a, 0x4e, 0x15, 0x27, 0x4e, 0x16, 0xa4, 0x87, 0x72, 0xce, 0x81, 0x43, 0x4f, 0x68,
-	0x67, 0x62, 0x76, 0x45, 0x9b, 0xc6, 0x70, 0x59, 0xcd, 0xbc, 0xf7, 0x3e, 0x9f, 0xf7, 0xde, 0x67,
-	0x66, 0xdf, 0x2e, 0x58, 0xb7, 0x29, 0xeb, 0xdd, 0xb7, 0x58, 0xaf, 0x20, 0x1e, 0xfb, 0x57, 0x0a,
-	0x7c, 0xd4, 0xc7, 0x2c, 0xdf, 0xf7, 0x28, 0xa7, 0x50, 0x9d, 0x79, 0xf3, 0xe2, 0xb1, 0x7f, 0x25,
-	0xbd, 0xe6, 0x5b, 0x28, 0x33, 0x85, 0xbf, 0x20, 0x37, 0x32, 0x38, 0xbd, 0xda, 0xa1, 0x1d, 0x2a,
-	0xed, 0xfe, 0xea, 0xc8, 0xba, 0xd6, 0xa1, 0xb4, 0xd3, 0xc5, 0x05, 0xb1, 0x6b, 0x0d, 0xda, 0x05,
-	0xcb, 0x1d, 0x1d, 0xb9, 0x4e, 0x59, 0x3d, 0xe2, 0xd2, 0x82, 0x78, 0x4a, 0x93, 0x76, 0x17, 0xbc,
-	0x56, 0xb4, 0x6d, 0xcc, 0x58, 0x73, 0xd4, 0xc7, 0x75, 0xcb, 0xb3, 0x7a, 0xb0, 0x02, 0x16, 0xf6,
-	0xad, 0xee, 0x00, 0xa7, 0x94, 0x9c, 0x72, 0xee, 0xff, 0x5b, 0xeb, 0xf9, 0x7f, 0xd6, 0x94, 0x0f,
-	0x10, 0x25, 0xf5, 0x70, 0x9a, 0x5d, 0x19, 0x59, 0xbd, 0xee, 0x35, 0x4d, 0x80, 0x34, 0x24, 0xc1,
-	0xd7, 0xe2, 0x5f, 0x7f, 0x9b, 0x55, 0xb4, 0x1f, 0x15, 0xb0, 0x22, 0xa3, 0xcb, 0xd4, 0x6d, 0x93,
-	0x0e, 0x6c, 0x00, 0xd0, 0xc7, 0x5e, 0x8f, 0x30, 0x46, 0xa8, 0x3b, 0x57, 0x86, 0xd3, 0x87, 0xd3,
-	0xec, 0x29, 0x99, 0x21, 0x40, 0x6a, 0x28, 0x44, 0x03, 0xaf, 0x82, 0xa4, 0xe5, 0x38, 0x1e, 0x66,
-	0x0c, 0xb3, 0x54, 0x2c, 0x17, 0x3b, 0x97, 0x2c, 0xa5, 0x7e, 0x79, 0x74, 0x69, 0xf5, 0x48, 0xad,
-	0xa2, 0xf4, 0x35, 0xb8, 0x47, 0xdc, 0x0e, 0x0a, 0x42, 0x65, 0x8d, 0xb7, 0xe2, 0x89, 0xa8, 0x1a,
-	0xd3, 0xbe, 0x8a, 0x82, 0x45, 0xd1, 0x3f, 0x83, 0x1c, 0x40, 0x9b, 0x3a, 0xd8, 0x1c, 0xf4, 0xbb,
-	0xd4, 0x72, 0x4c, 0x4b, 0xd4, 0x22, 0x6a, 0x5d, 0xde, 0xca, 0x1c, 0x57, 0xab, 0xec, 0xaf, 0xb4,
-	0xf1, 0x78, 0x9a, 0x8d, 0x1c, 0x4e, 0xb3, 0x6b, 0xb2, 0xe2, 0x17, 0x79, 0xb4, 0x07, 0xcf, 0x1e,
-	0x6e, 0x2a, 0x48, 0xf5, 0x3d, 0xb7, 0x85, 0x43, 0xe2, 0xe1, 0x17, 0x0a, 0xc8, 0x10, 0x97, 0x71,
-	0xcb, 0xe5, 0xc4, 0xe2, 0xd8, 0x74, 0x70, 0xdb, 0x1a, 0x74, 0xb9, 0x19, 0x92, 0x2b, 0x3a, 0x87,
-	0x5c, 0xe7, 0x0f, 0xa7, 0xd9, 0xb7, 0x65, 0xf2, 0x57, 0xb3, 0x69, 0x68, 0x3d, 0x14, 0x50, 0x91,
-	0xfe, 0xfa, 0xdf, 0x6e, 0x21, 0x4e, 0x44, 0xfb, 0x49, 0x01, 0x89, 0x32, 0x75, 0xb0, 0xe1, 0xb6,
-	0x29, 0x7c, 0x03, 0x24, 0x45, 0x43, 0x7b, 0x16, 0xdb, 0x13, 0x7a, 0xac, 0xa0, 0x84, 0x6f, 0xd8,
-	0xb6, 0xd8, 0x1e, 0xdc, 0x02, 0x4b, 0xb6, 0x87, 0x2d, 0x4e, 0x3d, 0x51, 0xe7, 0xab, 0x8e, 0x60,
-	0x16, 0x08, 0x3f, 0x04, 0x30, 0x5c, 0xa4, 0x2d, 0x34, 0x4c, 0x2d, 0xcc, 0xa5, 0x74, 0xd2, 0x57,
-	0x5a, 0x8a, 0x79, 0x2a, 0x44, 0x22, 0xbd, 0xb7, 0xe2, 0x89, 0x98, 0x1a, 0xbf, 0x15, 0x4f, 0xc4,
-	0xd5, 0x05, 0xed, 0xd3, 0x18, 0x58, 0x29, 0x53, 0x97, 0x7b, 0x96, 0xcd, 0x45, 0x1f, 0x6f, 0x81,
-	0x25, 0xd1, 0x07, 0x71, 0x44, 0x17, 0xf1, 0x12, 0x38, 0x98, 0x66, 0x17, 0x45, 0x9b, 0x15, 0xb4,
-	0xe8, 0xbb, 0x0c, 0xe7, 0x3f, 0xf5, 0x93, 0x07, 0x0b, 0x96, 0xd3, 0x23, 0x6e, 0x2a, 0x76, 0x02,
-	0x42, 0x86, 0xc1, 0x55, 0xb0, 0xd0, 0xb5, 0x5a, 0xb8, 0x9b, 0x8a, 0xfb, 0xf1, 0x48, 0x6e, 0xe0,
-	0xf5, 0xa3, 0xcc, 0xd8, 0x39, 0x92, 0xe2, 0xec, 0x4b, 0xa4, 0x68, 0x31, 0xda, 0x1d, 0x70, 0xdc,
-	0x1c, 0xd6, 0x29, 0x23, 0x9c, 0x50, 0x17, 0xcd, 0x40, 0xf0, 0x12, 0x58, 0x26, 0x2d, 0xdb, 0xec,
-	0x53, 0x8f, 0xfb, 0x2d, 0x2e, 0x8a, 0x5a, 0xfe, 0x77, 0x30, 0xcd, 0x26, 0x8d, 0x52, 0xb9, 0x4e,
-	0x3d, 0x6e, 0x54, 0x50, 0x92, 0xb4, 0x6c, 0xb1, 0x74, 0xe0, 0xc7, 0x20, 0x89, 0x87, 0x1c, 0xbb,
-	0xe2, 0x8a, 0x2d, 0x89, 0x84, 0xab, 0x79, 0x39, 0x44, 0xf2, 0xb3, 0x21, 0x92, 0x2f, 0xba, 0xa3,
-	0xd2, 0xe6, 0xcf, 0x8f, 0x2e, 0x6d, 0xbc, 0x50, 0x49, 0x58, 0x59, 0x7d, 0xc6, 0x83, 0x02, 0xca,
-	0x6b, 0xf1, 0x3f, 0xfc, 0x49, 0xf0, 0x79, 0x14, 0xa4, 0x66, 0xa1, 0xbe, 0xd2, 0xdb, 0x84, 0x71,
-	0xea, 0x8d, 0x74, 0x97, 0x7b, 0x23, 0x58, 0x07, 0x49, 0xda, 0xc7, 0x9e, 0xc5, 0x83, 0xa1, 0xb0,
-	0x95, 0x3f, 0x36, 0x53, 0x08, 0x5e, 0x9b, 0xa1, 0xfc, 0xbb, 0x8f, 0x02, 0x92, 0xf0, 0x11, 0x47,
-	0x8f, 0x3d, 0xe2, 0xeb, 0x60, 0x69, 0xd0, 0x77, 0x84, 0xd0, 0xb1, 0x7f, 0x23, 0xf4, 0x11, 0x08,
-	0xbe, 0x0f, 0x62, 0x3d, 0xd6, 0x11, 0x87, 0xb7, 0x52, 0xda, 0x78, 0x3e, 0xcd, 0x42, 0x64, 0xdd,
-	0x9f, 0x55, 0xb9, 0x83, 0x19, 0xb3, 0x3a, 0xf8, 0x9b, 0x67, 0x0f, 0x37, 0x97, 0x89, 0xdb, 0x25,
-	0x2e, 0x36, 0x3f, 0x61, 0xd4, 0x45, 0x3e, 0x44, 0x43, 0x00, 0xbe, 0x48, 0x0c, 0xdf, 0x04, 0x2b,
-	0xad, 0x2e, 0xb5, 0xef, 0x99, 0x7b, 0x98, 0x74, 0xf6, 0xb8, 0xbc, 0x9c, 0x68, 0x59, 0xd8, 0xb6,
-	0x85, 0x09, 0xae, 0x81, 0x04, 0x1f, 0x9a, 0xc4, 0x75, 0xf0, 0x50, 0x36, 0x86, 0x96, 0xf8, 0xd0,
-	0xf0, 0xb7, 0x1a, 0x06, 0x0b, 0x3b, 0xd4, 0xc1, 0x5d, 0x78, 0x03, 0xc4, 0xee, 0xe1, 0x91, 0x7c,
-	0x41, 0x4b, 0xef, 0x3d, 0x9f, 0x66, 0x2f, 0x77, 0x08, 0xdf, 0x1b, 0xb4, 0xf2, 0x36, 0xed, 0x15,
-	0x6c, 0xda, 0xc3, 0xbc, 0xd5, 0xe6, 0xc1, 0xa2, 0x4b, 0x5a, 0xac, 0xd0, 0x1a, 0x71, 0xcc, 0xf2,
-	0xdb, 0x78, 0x58, 0xf2, 0x17, 0xc8, 0x27, 0xf0, 0x6f, 0xa7, 0xfc, 0x10, 0x44, 0xc5, 0xab, 0x2e,
-	0x37, 0x9b, 0x7f, 0x2a, 0x00, 0x04, 0xf3, 0x06, 0x5e, 0x05, 0x67, 0x8a, 0xe5, 0xb2, 0xde, 0x68,
-	0x98, 0xcd, 0xdd, 0xba, 0x6e, 0xde, 0xae, 0x36, 0xea, 0x7a, 0xd9, 0xb8, 0x61, 0xe8, 0x15, 0x35,
-	0x92, 0x5e, 0x1b, 0x4f, 0x72, 0xa7, 0x83, 0xe0, 0xdb, 0x2e, 0xeb, 0x63, 0x9b, 0xb4, 0x09, 0x76,
-	0xe0, 0x45, 0x00, 0xc3, 0xb8, 0x6a, 0xad, 0x54, 0xab, 0xec, 0xaa, 0x4a, 0x7a, 0x75, 0x3c, 0xc9,
-	0xa9, 0x01, 0xa4, 0x4a, 0x5b, 0xd4, 0x19, 0xc1, 0x2d, 0x70, 0x3a, 0x1c, 0xad, 0x7f, 0xa0, 0xa3,
-	0x5d, 0x01, 0x88, 0xa5, 0xcf, 0x8c, 0x27, 0xb9, 0xd7, 0x03, 0x80, 0xbe, 0x8f, 0xbd, 0x91, 0xc0,
-	0x5c, 0x07, 0xeb, 0x61, 0x4c, 0xb1, 0xba, 0x6b, 0xd6, 0x6e, 0x98, 0xc5, 0x4a, 0x05, 0xe9, 0x8d,
-	0x86, 0xde, 0x50, 0xe3, 0xe9, 0xf5, 0xf1, 0x24, 0x97, 0x0a, 0xa0, 0x45, 0x77, 0x54, 0x6b, 0x17,
-	0x67, 0x5f, 0x87, 0x74, 0xe2, 0xb3, 0xef, 0x32, 0x91, 0x07, 0xdf, 0x67, 0x22, 0x9a, 0xff, 0x85,
-	0x88, 0x6e, 0xfe, 0x10, 0x03, 0xb9, 0x93, 0xae, 0x20, 0xc4, 0xe0, 0x72, 0xb9, 0x56, 0x6d, 0xa2,
-	0x62, 0xb9, 0x69, 0x96, 0x6b, 0x15, 0xdd, 0xdc, 0x36, 0x1a, 0xcd, 0x1a, 0xda, 0x35, 0x6b, 0x75,
-	0x1d, 0x15, 0x9b, 0x46, 0xad, 0xfa, 0x32, 0x9d, 0x0a, 0xe3, 0x49, 0xee, 0xc2, 0x49, 0xdc, 0x61,
-	0xf5, 0xee, 0x80, 0xf3, 0x73, 0xa5, 0x31, 0xaa, 0x46, 0x53, 0x55, 0xd2, 0xe7, 0xc6, 0x93, 0xdc,
-	0xd9, 0x93, 0xf8, 0x0d, 0x97, 0x70, 0x78, 0x17, 0x5c, 0x9c, 0x8b, 0x78, 0xc7, 0xb8, 0x89, 0x8a,
-	0x4d, 0x5d, 0x8d, 0xa6, 0x2f, 0x8c, 0x27, 0xb9, 0x77, 0x4e, 0xe2, 0xde, 0x21, 0x1d, 0xcf, 0xe2,
-	0x78, 0x6e, 0xfa, 0x9b, 0x7a, 0x55, 0x6f, 0x18, 0x0d, 0x35, 0x36, 0x1f, 0xfd, 0x4d, 0xec, 0x62,
-	0x46, 0x58, 0x3a, 0xee, 0x1f, 0x59, 0x69, 0xfb, 0xf1, 0xef, 0x99, 0xc8, 0x83, 0x83, 0x8c, 0xf2,
-	0xf8, 0x20, 0xa3, 0x3c, 0x39, 0xc8, 0x28, 0xbf, 0x1d, 0x64, 0x94, 0x2f, 0x9f, 0x66, 0x22, 0x4f,
-	0x9e, 0x66, 0x22, 0xbf, 0x3e, 0xcd, 0x44, 0x3e, 0xda, 0x08, 0xbd, 0x10, 0x65, 0xca, 0x7a, 0x77,
-	0x66, 0xff, 0x63, 0x4e, 0x61, 0x28, 0xff, 0xcb, 0xc4, 0x4f, 0x59, 0x6b, 0x51, 0xcc, 0xbf, 0x77,
-	0xff, 0x0a, 0x00, 0x00, 0xff, 0xff, 0xba, 0x63, 0x50, 0xbd, 0xb5, 0x09, 0x00, 0x00,
+func (m *EventGasBreakdown) Reset()         { *m = EventGasBreakdown{} }
+func (m *EventGasBreakdown) String() string { return proto.CompactTextString(m) }
+func (*EventGasBreakdown) ProtoMessage()    {}
+func (*EventGasBreakdown) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{13}
 }
 
-func (this *AccessTypeParam) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *EventGasBreakdown) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
 
-	that1, ok := that.(*AccessTypeParam)
-	if !ok {
-		that2, ok := that.(AccessTypeParam)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+func (m *EventGasBreakdown) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventGasBreakdown.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Value != that1.Value {
-		return false
-	}
-	return true
 }
 
-func (this *AccessConfig) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *EventGasBreakdown) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventGasBreakdown.Merge(m, src)
+}
 
-	that1, ok := that.(*AccessConfig)
-	if !ok {
-		that2, ok := that.(AccessConfig)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.Permission != that1.Permission {
-		return false
-	}
-	if len(this.Addresses) != len(that1.Addresses) {
-		return false
-	}
-	for i := range this.Addresses {
-		if this.Addresses[i] != that1.Addresses[i] {
-			return false
-		}
-	}
-	return true
+func (m *EventGasBreakdown) XXX_Size() int {
+	return m.Size()
 }
 
-func (this *Params) Equal(that interface{}) bool {
+func (m *EventGasBreakdown) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventGasBreakdown.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventGasBreakdown proto.InternalMessageInfo
+
+// EventContractManagementChanged is emitted alongside the regular events for
+// any change that affects the management of a contract. Changes of the
+// contract code are emitted once per code without contract address and for
+// each of the first 100 contracts of the code.
+type EventContractManagementChanged struct {
+	// ContractAddress is the address of the contract, empty for the event per
+	// code
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	// CodeID is the code id of the contract after the change
+	CodeID uint64 `protobuf:"varint,2,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+	// ChangeType is the kind of change
+	ChangeType ContractManagementChangeType `protobuf:"varint,3,opt,name=change_type,json=changeType,proto3,enum=cosmwasm.wasm.v1.ContractManagementChangeType" json:"change_type,omitempty"`
+	// OldValue is the value before the change
+	OldValue string `protobuf:"bytes,4,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	// NewValue is the value after the change
+	NewValue string `protobuf:"bytes,5,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+}
+
+func (m *EventContractManagementChanged) Reset()         { *m = EventContractManagementChanged{} }
+func (m *EventContractManagementChanged) String() string { return proto.CompactTextString(m) }
+func (*EventContractManagementChanged) ProtoMessage()    {}
+func (*EventContractManagementChanged) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{14}
+}
+
+func (m *EventContractManagementChanged) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *EventContractManagementChanged) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventContractManagementChanged.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *EventContractManagementChanged) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventContractManagementChanged.Merge(m, src)
+}
+
+func (m *EventContractManagementChanged) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *EventContractManagementChanged) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventContractManagementChanged.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventContractManagementChanged proto.InternalMessageInfo
+
+// ModuleActivity counts the wasm messages and the gas they consumed within a
+// block. It is emitted as typed event at the end of each block with wasm
+// activity.
+type ModuleActivity struct {
+	// Height is the block height
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Stores is the number of stored codes
+	Stores uint64 `protobuf:"varint,2,opt,name=stores,proto3" json:"stores,omitempty"`
+	// Instantiations is the number of instantiated contracts
+	Instantiations uint64 `protobuf:"varint,3,opt,name=instantiations,proto3" json:"instantiations,omitempty"`
+	// Executions is the number of contract executions
+	Executions uint64 `protobuf:"varint,4,opt,name=executions,proto3" json:"executions,omitempty"`
+	// Migrations is the number of migrated contracts
+	Migrations uint64 `protobuf:"varint,5,opt,name=migrations,proto3" json:"migrations,omitempty"`
+	// GasUsed is the gas consumed on the tx gas meter by the successful top level
+	// wasm messages, from the start of the message handler until it returned,
+	// including its deferred work
+	GasUsed uint64 `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+}
+
+func (m *ModuleActivity) Reset()         { *m = ModuleActivity{} }
+func (m *ModuleActivity) String() string { return proto.CompactTextString(m) }
+func (*ModuleActivity) ProtoMessage()    {}
+func (*ModuleActivity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{15}
+}
+
+func (m *ModuleActivity) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *ModuleActivity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ModuleActivity.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *ModuleActivity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ModuleActivity.Merge(m, src)
+}
+
+func (m *ModuleActivity) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *ModuleActivity) XXX_DiscardUnknown() {
+	xxx_messageInfo_ModuleActivity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ModuleActivity proto.InternalMessageInfo
+
+// EventSubmessageFailed is emitted when a submessage failed and the error is
+// passed to the reply of the contract. The error is redacted like the result
+// of the reply, unless it is deterministic.
+type EventSubmessageFailed struct {
+	// ContractAddress is the address of the contract that dispatched the
+	// submessage
+	ContractAddress string `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	// MsgIndex is the position of the submessage in the dispatch order of the tx
+	// message
+	MsgIndex uint32 `protobuf:"varint,2,opt,name=msg_index,json=msgIndex,proto3" json:"msg_index,omitempty"`
+	// ReplyID is the id of the submessage
+	ReplyID uint64 `protobuf:"varint,3,opt,name=reply_id,json=replyId,proto3" json:"reply_id,omitempty"`
+	// Codespace is the codespace of the error
+	Codespace string `protobuf:"bytes,4,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// Code is the ABCI code of the error
+	Code uint32 `protobuf:"varint,5,opt,name=code,proto3" json:"code,omitempty"`
+	// Error is the error that is passed to the reply
+	Error string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *EventSubmessageFailed) Reset()         { *m = EventSubmessageFailed{} }
+func (m *EventSubmessageFailed) String() string { return proto.CompactTextString(m) }
+func (*EventSubmessageFailed) ProtoMessage()    {}
+func (*EventSubmessageFailed) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{16}
+}
+
+func (m *EventSubmessageFailed) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *EventSubmessageFailed) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventSubmessageFailed.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *EventSubmessageFailed) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventSubmessageFailed.Merge(m, src)
+}
+
+func (m *EventSubmessageFailed) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *EventSubmessageFailed) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventSubmessageFailed.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventSubmessageFailed proto.InternalMessageInfo
+
+// GasBreakdownExtensionOption is set as non-critical extension option of a simulated tx to
+// request an EventGasBreakdown for each wasm message of the tx.
+type GasBreakdownExtensionOption struct {
+}
+
+func (m *GasBreakdownExtensionOption) Reset()         { *m = GasBreakdownExtensionOption{} }
+func (m *GasBreakdownExtensionOption) String() string { return proto.CompactTextString(m) }
+func (*GasBreakdownExtensionOption) ProtoMessage()    {}
+func (*GasBreakdownExtensionOption) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{17}
+}
+
+func (m *GasBreakdownExtensionOption) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+
+func (m *GasBreakdownExtensionOption) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GasBreakdownExtensionOption.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+
+func (m *GasBreakdownExtensionOption) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GasBreakdownExtensionOption.Merge(m, src)
+}
+
+func (m *GasBreakdownExtensionOption) XXX_Size() int {
+	return m.Size()
+}
+
+func (m *GasBreakdownExtensionOption) XXX_DiscardUnknown() {
+	xxx_messageInfo_GasBreakdownExtensionOption.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GasBreakdownExtensionOption proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("cosmwasm.wasm.v1.AccessType", AccessType_name, AccessType_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.DefaultAdminPolicy", DefaultAdminPolicy_name, DefaultAdminPolicy_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.QueryJSONEncoding", QueryJSONEncoding_name, QueryJSONEncoding_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.ContractManagementChangeType", ContractManagementChangeType_name, ContractManagementChangeType_value)
+	proto.RegisterType((*AccessTypeParam)(nil), "cosmwasm.wasm.v1.AccessTypeParam")
+	proto.RegisterType((*AccessConfig)(nil), "cosmwasm.wasm.v1.AccessConfig")
+	proto.RegisterType((*Params)(nil), "cosmwasm.wasm.v1.Params")
+	proto.RegisterType((*UploadSpamProtection)(nil), "cosmwasm.wasm.v1.UploadSpamProtection")
+	proto.RegisterType((*FeelessExecutions)(nil), "cosmwasm.wasm.v1.FeelessExecutions")
+	proto.RegisterType((*FeelessExecution)(nil), "cosmwasm.wasm.v1.FeelessExecution")
+	proto.RegisterType((*ContractGasBudget)(nil), "cosmwasm.wasm.v1.ContractGasBudget")
+	proto.RegisterType((*CodeInfo)(nil), "cosmwasm.wasm.v1.CodeInfo")
+	proto.RegisterType((*CodeProvenance)(nil), "cosmwasm.wasm.v1.CodeProvenance")
+	proto.RegisterType((*ContractInfo)(nil), "cosmwasm.wasm.v1.ContractInfo")
+	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1.ContractCodeHistoryEntry")
+	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1.AbsoluteTxPosition")
+	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1.Model")
+	proto.RegisterType((*EventGasBreakdown)(nil), "cosmwasm.wasm.v1.EventGasBreakdown")
+	proto.RegisterType((*EventContractManagementChanged)(nil), "cosmwasm.wasm.v1.EventContractManagementChanged")
+	proto.RegisterType((*ModuleActivity)(nil), "cosmwasm.wasm.v1.ModuleActivity")
+	proto.RegisterType((*EventSubmessageFailed)(nil), "cosmwasm.wasm.v1.EventSubmessageFailed")
+	proto.RegisterType((*GasBreakdownExtensionOption)(nil), "cosmwasm.wasm.v1.GasBreakdownExtensionOption")
+}
+
+func init() { proto.RegisterFile("cosmwasm/wasm/v1/types.proto", fileDescriptor_e6155d98fa173e02) }
+
+var fileDescriptor_e6155d98fa173e02 = []byte{
+	// 2781 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x59, 0xcb, 0x6f, 0x1b, 0xc7,
+	0x19, 0xd7, 0x8a, 0x94, 0x48, 0x8e, 0x64, 0x67, 0x35, 0x96, 0x6c, 0x8a, 0x96, 0x49, 0x7a, 0x9d,
+	0xd8, 0xb2, 0x12, 0x53, 0x91, 0x12, 0x04, 0x6d, 0x0a, 0xa4, 0xe0, 0x63, 0x25, 0xd1, 0x95, 0x48,
+	0x66, 0x48, 0xdb, 0x55, 0xd0, 0x74, 0xb1, 0xdc, 0x1d, 0x51, 0x5b, 0x2f, 0x77, 0xd9, 0x9d, 0xa5,
+	0x24, 0xb6, 0x87, 0x5e, 0x03, 0x16, 0x05, 0x7a, 0x6b, 0x51, 0x94, 0x40, 0x81, 0x16, 0x68, 0xd0,
+	0x53, 0x0e, 0xe9, 0x5f, 0xd0, 0x43, 0x83, 0x5c, 0x1a, 0xf4, 0xd4, 0x13, 0xdb, 0x28, 0x05, 0xd2,
+	0x5e, 0x75, 0xe8, 0x21, 0xbd, 0x14, 0x33, 0xb3, 0x4b, 0x6e, 0x48, 0xea, 0x91, 0xf4, 0x22, 0xef,
+	0x7c, 0x8f, 0xdf, 0xcc, 0x7c, 0xef, 0xa1, 0xc1, 0x8a, 0x66, 0x93, 0xe6, 0xb1, 0x4a, 0x9a, 0xeb,
+	0xec, 0xcf, 0xd1, 0xc6, 0xba, 0xdb, 0x69, 0x61, 0x92, 0x69, 0x39, 0xb6, 0x6b, 0x43, 0xd1, 0xe7,
+	0x66, 0xd8, 0x9f, 0xa3, 0x8d, 0xc4, 0x32, 0xa5, 0xd8, 0x44, 0x61, 0xfc, 0x75, 0xbe, 0xe0, 0xc2,
+	0x89, 0xc5, 0x86, 0xdd, 0xb0, 0x39, 0x9d, 0x7e, 0x79, 0xd4, 0xe5, 0x86, 0x6d, 0x37, 0x4c, 0xbc,
+	0xce, 0x56, 0xf5, 0xf6, 0xc1, 0xba, 0x6a, 0x75, 0x3c, 0xd6, 0x82, 0xda, 0x34, 0x2c, 0x7b, 0x9d,
+	0xfd, 0xf5, 0x48, 0x49, 0x8e, 0xb8, 0x5e, 0x57, 0x09, 0x5e, 0x3f, 0xda, 0xa8, 0x63, 0x57, 0xdd,
+	0x58, 0xd7, 0x6c, 0xc3, 0xe2, 0x7c, 0xe9, 0x5d, 0xf0, 0x42, 0x56, 0xd3, 0x30, 0x21, 0xb5, 0x4e,
+	0x0b, 0x57, 0x54, 0x47, 0x6d, 0xc2, 0x02, 0x98, 0x39, 0x52, 0xcd, 0x36, 0x8e, 0x0b, 0x69, 0x61,
+	0xf5, 0xfa, 0xe6, 0x4a, 0x66, 0xf4, 0xcc, 0x99, 0xa1, 0x46, 0x4e, 0x3c, 0xeb, 0xa7, 0xe6, 0x3b,
+	0x6a, 0xd3, 0x7c, 0x53, 0x62, 0x4a, 0x12, 0xe2, 0xca, 0x6f, 0x86, 0x7f, 0xf9, 0x9b, 0x94, 0x20,
+	0xfd, 0x5e, 0x00, 0xf3, 0x5c, 0x3a, 0x6f, 0x5b, 0x07, 0x46, 0x03, 0x56, 0x01, 0x68, 0x61, 0xa7,
+	0x69, 0x10, 0x62, 0xd8, 0xd6, 0x95, 0x76, 0x58, 0x3a, 0xeb, 0xa7, 0x16, 0xf8, 0x0e, 0x43, 0x4d,
+	0x09, 0x05, 0x60, 0xe0, 0x1b, 0x20, 0xa6, 0xea, 0xba, 0x83, 0x09, 0xc1, 0x24, 0x1e, 0x4a, 0x87,
+	0x56, 0x63, 0xb9, 0xf8, 0x5f, 0x3f, 0x7c, 0xb4, 0xe8, 0x59, 0x33, 0xcb, 0x79, 0x55, 0xd7, 0x31,
+	0xac, 0x06, 0x1a, 0x8a, 0xf2, 0x33, 0x3e, 0x0e, 0x47, 0xa7, 0xc5, 0x90, 0xf4, 0xcf, 0x6b, 0x60,
+	0x96, 0xdd, 0x9f, 0x40, 0x17, 0x40, 0xcd, 0xd6, 0xb1, 0xd2, 0x6e, 0x99, 0xb6, 0xaa, 0x2b, 0x2a,
+	0x3b, 0x0b, 0x3b, 0xeb, 0xdc, 0x66, 0xf2, 0xbc, 0xb3, 0xf2, 0xfb, 0xe5, 0xee, 0xbf, 0xff, 0xf9,
+	0x07, 0x6b, 0xc2, 0x47, 0xfd, 0xd4, 0xd4, 0x59, 0x3f, 0xb5, 0xcc, 0x8f, 0x3d, 0x0e, 0x26, 0x21,
+	0x91, 0x12, 0x9f, 0x30, 0x1a, 0xd7, 0x87, 0x3f, 0x13, 0x40, 0xd2, 0xb0, 0x88, 0xab, 0x5a, 0xae,
+	0xa1, 0xba, 0x58, 0xd1, 0xf1, 0x81, 0xda, 0x36, 0x5d, 0x25, 0x60, 0xae, 0xe9, 0x2b, 0x98, 0xeb,
+	0xe1, 0x59, 0x3f, 0xf5, 0x12, 0xdf, 0xf7, 0x62, 0x34, 0x09, 0xad, 0x04, 0x04, 0x0a, 0x9c, 0x5f,
+	0x19, 0x1a, 0xf5, 0x19, 0xb8, 0xa9, 0x63, 0xbd, 0xdd, 0x32, 0x0d, 0x8d, 0x02, 0x10, 0xd7, 0x76,
+	0xb0, 0x42, 0x4f, 0x1d, 0x0f, 0xa5, 0x85, 0xd5, 0x68, 0xee, 0xee, 0x59, 0x3f, 0x75, 0x87, 0x6f,
+	0x34, 0x59, 0x4e, 0x42, 0x8b, 0x01, 0x46, 0x95, 0xd2, 0xf3, 0xb6, 0x8e, 0xe1, 0x3b, 0xe0, 0x16,
+	0x71, 0x1d, 0x43, 0x73, 0x15, 0x55, 0x6f, 0x1a, 0x96, 0x72, 0xa4, 0x9a, 0x86, 0xae, 0xba, 0xf4,
+	0x82, 0x61, 0x86, 0x2c, 0x9d, 0xf5, 0x53, 0x49, 0x8e, 0x7c, 0x8e, 0xa0, 0x84, 0x96, 0x38, 0x27,
+	0x4b, 0x19, 0x4f, 0x07, 0x74, 0xf8, 0x14, 0xdc, 0x54, 0x4d, 0xd3, 0x3e, 0x56, 0x1c, 0xf5, 0x58,
+	0x21, 0x2e, 0x3d, 0xd0, 0xb1, 0x63, 0xb8, 0x98, 0xc4, 0x67, 0x46, 0x0f, 0x3d, 0x59, 0x4e, 0x42,
+	0x37, 0x18, 0x03, 0xa9, 0xc7, 0x55, 0x4a, 0x7e, 0xc6, 0xa8, 0xf0, 0x3d, 0x01, 0xdc, 0xf4, 0x3c,
+	0x48, 0x5a, 0x6a, 0x93, 0x65, 0x2b, 0xd6, 0xd8, 0x99, 0x67, 0x59, 0x5c, 0xdc, 0x1f, 0x77, 0x0a,
+	0xf7, 0x6e, 0xb5, 0xa5, 0x36, 0x2b, 0x03, 0xe9, 0xdc, 0x5a, 0x30, 0x3e, 0xbc, 0x93, 0x4c, 0x06,
+	0x96, 0xd0, 0x62, 0x7b, 0x02, 0x02, 0x3c, 0x04, 0x2b, 0x0e, 0xd6, 0x6c, 0x47, 0x57, 0x34, 0xdb,
+	0x72, 0x1d, 0x55, 0x73, 0x15, 0xc3, 0x3a, 0xb0, 0x15, 0xed, 0x50, 0xb5, 0x1a, 0x98, 0xc4, 0x23,
+	0xec, 0xa2, 0x0f, 0xce, 0xfa, 0xa9, 0x7b, 0x1c, 0xfe, 0x22, 0x69, 0x09, 0x2d, 0x73, 0x76, 0xde,
+	0xe3, 0x16, 0xad, 0x03, 0x3b, 0xcf, 0x79, 0xf0, 0x47, 0x00, 0x1e, 0x60, 0x6c, 0x62, 0x42, 0x14,
+	0x7c, 0x82, 0xb5, 0x36, 0xdd, 0x9e, 0xc4, 0xa3, 0xec, 0xbe, 0xf7, 0xc6, 0xef, 0xbb, 0xc5, 0x65,
+	0xe5, 0x81, 0xe8, 0xc4, 0x64, 0x18, 0x47, 0x94, 0xd0, 0xc2, 0xc1, 0xa8, 0x2a, 0xfc, 0x09, 0x58,
+	0x1c, 0x1c, 0xb8, 0xa1, 0x12, 0xa5, 0xde, 0xd6, 0x1b, 0xd8, 0x25, 0xf1, 0x58, 0x3a, 0x34, 0x79,
+	0x77, 0xff, 0x02, 0xdb, 0x2a, 0xc9, 0x31, 0xd9, 0xdc, 0x6a, 0x70, 0xf7, 0xdb, 0x7e, 0x2a, 0x8e,
+	0x63, 0x4a, 0x08, 0x6a, 0xa3, 0xca, 0x04, 0x3e, 0x07, 0x77, 0x06, 0xc2, 0x3c, 0x40, 0x78, 0xea,
+	0x72, 0x3b, 0xda, 0x66, 0x1c, 0x30, 0x3b, 0xaf, 0x9e, 0xf5, 0x53, 0x2f, 0x8e, 0x60, 0x4f, 0x12,
+	0x97, 0x50, 0xc2, 0xe7, 0xb3, 0xb8, 0x1a, 0x14, 0x0d, 0xca, 0xa4, 0x29, 0x41, 0x59, 0xcf, 0x87,
+	0x4e, 0x52, 0x35, 0xd7, 0x38, 0x32, 0xdc, 0x4e, 0x7c, 0x6e, 0x34, 0x25, 0xce, 0x11, 0x94, 0xd0,
+	0x12, 0xe3, 0xf8, 0x76, 0xc8, 0x7a, 0x74, 0x78, 0x0c, 0x16, 0xfd, 0xe4, 0xe7, 0x69, 0xd4, 0xb2,
+	0x4d, 0x43, 0xeb, 0xc4, 0xe7, 0x59, 0x31, 0x79, 0x71, 0xdc, 0x92, 0x5e, 0x29, 0x60, 0xa9, 0x55,
+	0x61, 0xb2, 0xb9, 0xd4, 0xd0, 0x82, 0x93, 0xb0, 0x24, 0x04, 0xf5, 0x31, 0x25, 0x5a, 0x40, 0xbc,
+	0xd0, 0x6b, 0xda, 0x7a, 0xdb, 0xc4, 0xc3, 0x3b, 0x5d, 0x1b, 0xcd, 0xc5, 0xc9, 0x72, 0x12, 0x5a,
+	0xe4, 0x8c, 0x3d, 0x46, 0x1f, 0xdc, 0xe8, 0x3d, 0x01, 0xdc, 0xf8, 0x61, 0x1b, 0x3b, 0x1d, 0xe5,
+	0x07, 0xc4, 0xb6, 0x14, 0x6c, 0x69, 0xb6, 0x6e, 0x58, 0x8d, 0xf8, 0x75, 0x76, 0xa3, 0x09, 0xb1,
+	0xf1, 0x36, 0x15, 0x7e, 0x5c, 0x2d, 0x97, 0x64, 0x4f, 0x34, 0xf7, 0xda, 0x69, 0x3f, 0xb5, 0x30,
+	0x46, 0x3e, 0xeb, 0xa7, 0x12, 0xfc, 0x40, 0x13, 0xe0, 0x25, 0xb4, 0xc0, 0xa8, 0x8f, 0x89, 0x6d,
+	0xf9, 0x0a, 0xb0, 0x0a, 0x96, 0x06, 0xe9, 0xa5, 0x63, 0x16, 0x55, 0x6d, 0xa2, 0x36, 0x70, 0xfc,
+	0x05, 0x76, 0xc5, 0xf4, 0x59, 0x3f, 0xb5, 0x32, 0x92, 0x85, 0x41, 0x31, 0x09, 0x41, 0x3f, 0xfd,
+	0x74, 0xbc, 0xad, 0x92, 0x27, 0x94, 0x08, 0x6b, 0x60, 0xc9, 0xb0, 0x74, 0x7c, 0x32, 0x74, 0xb2,
+	0xa9, 0xd6, 0xb1, 0x49, 0xe2, 0xe2, 0x28, 0xe8, 0x44, 0x31, 0x09, 0xdd, 0x60, 0x74, 0x3f, 0x12,
+	0x76, 0x19, 0x95, 0x35, 0xbb, 0x29, 0xe9, 0xdf, 0x02, 0x58, 0x9c, 0x54, 0x98, 0xe0, 0x8f, 0x41,
+	0x44, 0xc7, 0x2d, 0x9b, 0x18, 0x6e, 0x5c, 0x60, 0x39, 0xb6, 0x9c, 0xf1, 0xda, 0x27, 0x1d, 0x1d,
+	0x32, 0xde, 0xe8, 0x90, 0xc9, 0xdb, 0x86, 0x95, 0xdb, 0xfa, 0xd5, 0xe7, 0x1f, 0xac, 0xcd, 0x9b,
+	0xb8, 0xa1, 0x6a, 0x1d, 0x85, 0x0e, 0x13, 0x64, 0x90, 0x6a, 0x7f, 0xf8, 0x7b, 0x6a, 0xb5, 0x61,
+	0xb8, 0x87, 0xed, 0x7a, 0x46, 0xb3, 0x9b, 0xde, 0x3c, 0xe3, 0xfd, 0xf3, 0x88, 0xe8, 0xcf, 0xbd,
+	0x69, 0x88, 0xc2, 0x10, 0xe4, 0xef, 0x08, 0x37, 0xc0, 0x52, 0x53, 0x3d, 0xf1, 0x7a, 0x24, 0xa1,
+	0x4d, 0x4a, 0xc1, 0x2d, 0x5b, 0x3b, 0x64, 0x1d, 0x2f, 0x8c, 0x60, 0x53, 0x3d, 0xe1, 0x87, 0x26,
+	0x15, 0xec, 0xc8, 0x94, 0x03, 0xef, 0x82, 0x79, 0x26, 0xa2, 0x98, 0xd8, 0x6a, 0xb8, 0x87, 0xac,
+	0x29, 0x85, 0xd1, 0x1c, 0xa3, 0xed, 0x32, 0x92, 0xd4, 0x06, 0x0b, 0x63, 0x35, 0x09, 0x6e, 0x83,
+	0x08, 0x2b, 0xf0, 0x58, 0xf7, 0xee, 0x29, 0x5d, 0x5e, 0xc9, 0x72, 0xb1, 0xc1, 0xfd, 0x90, 0xaf,
+	0x0d, 0x6f, 0x81, 0x08, 0x3d, 0x73, 0x43, 0x25, 0xde, 0x29, 0x67, 0x9b, 0xea, 0xc9, 0xb6, 0x4a,
+	0x24, 0x15, 0x88, 0xa3, 0x00, 0xf0, 0x75, 0x10, 0xf5, 0xbd, 0xc4, 0x06, 0x89, 0x8b, 0x06, 0x94,
+	0x81, 0x24, 0xdb, 0x82, 0x34, 0x94, 0xe7, 0xb8, 0xc3, 0xb6, 0x88, 0xa1, 0xd9, 0x26, 0x69, 0x7c,
+	0x07, 0x77, 0x24, 0x17, 0x2c, 0x8c, 0xd5, 0xbb, 0xaf, 0xb9, 0xc7, 0x43, 0xb0, 0xe0, 0x5d, 0x83,
+	0x99, 0xbd, 0x6e, 0xda, 0xda, 0x73, 0xef, 0x42, 0xd7, 0xf9, 0x85, 0x2a, 0xd8, 0xc9, 0x51, 0xaa,
+	0xf4, 0xa9, 0x00, 0xa2, 0x34, 0x50, 0x69, 0x8f, 0x80, 0xb7, 0x41, 0x8c, 0xc5, 0xf2, 0xa1, 0x4a,
+	0x0e, 0xd9, 0x76, 0xf3, 0x14, 0x54, 0xc7, 0x3b, 0x2a, 0x39, 0x84, 0x9b, 0x20, 0xa2, 0x39, 0x58,
+	0x75, 0x6d, 0x27, 0x3e, 0x7d, 0xc9, 0x49, 0x7c, 0x41, 0xf8, 0x5d, 0x00, 0x83, 0x03, 0x8b, 0xc6,
+	0xe6, 0xa9, 0xf8, 0xcc, 0x95, 0xa6, 0xae, 0x80, 0x7f, 0x16, 0x02, 0x20, 0x9c, 0x0b, 0x93, 0x00,
+	0xe8, 0xb8, 0xe5, 0x60, 0x3a, 0x86, 0xe8, 0xac, 0x5f, 0x47, 0x51, 0x80, 0xf2, 0x38, 0x1c, 0x0d,
+	0x89, 0xe1, 0xc7, 0xe1, 0x68, 0x58, 0x9c, 0x91, 0x7e, 0x21, 0x80, 0xeb, 0xf4, 0x8e, 0x15, 0xc7,
+	0x3e, 0xc2, 0x96, 0x6a, 0x69, 0x18, 0xee, 0x81, 0x88, 0x7b, 0x12, 0xb8, 0x67, 0xee, 0xf5, 0x2f,
+	0xfa, 0xa9, 0x57, 0xbf, 0x14, 0xe4, 0x4d, 0xec, 0xd6, 0x0f, 0xdc, 0xe1, 0x87, 0x69, 0xd4, 0xc9,
+	0x7a, 0xbd, 0xe3, 0x62, 0x92, 0xd9, 0xc1, 0x27, 0x39, 0xfa, 0x81, 0x66, 0xdd, 0x13, 0x66, 0x9b,
+	0x9b, 0x60, 0x96, 0xd8, 0x6d, 0x47, 0xc3, 0xbe, 0x4f, 0xf9, 0x0a, 0xc6, 0x41, 0xa4, 0xde, 0x36,
+	0x4c, 0x1d, 0x3b, 0x2c, 0x96, 0x63, 0xc8, 0x5f, 0xbe, 0x19, 0xfe, 0x17, 0x1d, 0xa5, 0x3f, 0x08,
+	0x81, 0xf9, 0x60, 0x97, 0x86, 0xf7, 0x40, 0x84, 0x79, 0xc0, 0xd0, 0xd9, 0xb9, 0xc2, 0x39, 0x70,
+	0xda, 0x4f, 0xcd, 0x32, 0x07, 0x15, 0xd0, 0x2c, 0x65, 0x15, 0xf5, 0xaf, 0xe5, 0x89, 0x0c, 0x98,
+	0x61, 0xd5, 0x3d, 0x1e, 0xba, 0x44, 0x83, 0x8b, 0xc1, 0x45, 0x30, 0xc3, 0x2a, 0x0f, 0x1b, 0xdf,
+	0x62, 0x88, 0x2f, 0xe0, 0x5b, 0xde, 0xce, 0x58, 0xf7, 0x9c, 0x38, 0xa1, 0xd5, 0x64, 0xeb, 0xc4,
+	0x36, 0xdb, 0x2e, 0xae, 0x9d, 0x54, 0x68, 0x1d, 0x30, 0x6c, 0x0b, 0xf9, 0x4a, 0xf0, 0x11, 0x98,
+	0x33, 0xea, 0x9a, 0xd2, 0xb2, 0x1d, 0x57, 0x31, 0xb8, 0xdb, 0x62, 0xb9, 0x6b, 0xa7, 0xfd, 0x54,
+	0xac, 0x98, 0xcb, 0x57, 0x6c, 0xc7, 0x2d, 0x16, 0x50, 0xcc, 0xa8, 0x6b, 0xec, 0x53, 0x87, 0xdf,
+	0x07, 0x31, 0x7c, 0xe2, 0x62, 0x8b, 0x0d, 0xca, 0x11, 0xb6, 0xe1, 0x62, 0x86, 0x3f, 0x95, 0x32,
+	0xfe, 0x53, 0x29, 0x93, 0xb5, 0x3a, 0xb9, 0xb5, 0x8f, 0x3f, 0x7c, 0x74, 0xff, 0xdc, 0xf1, 0x81,
+	0x5a, 0x56, 0xf6, 0x71, 0xd0, 0x10, 0x12, 0xbe, 0x02, 0x20, 0x9d, 0x15, 0x79, 0x63, 0xd0, 0x0d,
+	0xa2, 0xd6, 0x4d, 0xac, 0xb3, 0x61, 0x28, 0x8a, 0x44, 0x47, 0x3d, 0x66, 0xcd, 0xa4, 0xe0, 0xd1,
+	0x3d, 0x97, 0xfd, 0x74, 0x1a, 0xc4, 0x7d, 0x60, 0xea, 0x97, 0x1d, 0x83, 0x4e, 0xc7, 0x1d, 0xd9,
+	0x72, 0x9d, 0x0e, 0xac, 0x80, 0x98, 0xdd, 0xc2, 0x0e, 0x1f, 0x7c, 0xf9, 0x43, 0x68, 0xf3, 0xfc,
+	0xb1, 0x26, 0xa0, 0x5e, 0xf6, 0xb5, 0xe8, 0xbc, 0x8f, 0x86, 0x20, 0xc1, 0x80, 0x98, 0x3e, 0x37,
+	0x20, 0xde, 0x02, 0x91, 0x76, 0x4b, 0x67, 0x6e, 0x09, 0x7d, 0x15, 0xb7, 0x78, 0x4a, 0xf0, 0x1b,
+	0x20, 0xd4, 0x24, 0x0d, 0xe6, 0xea, 0xf9, 0xdc, 0x7d, 0xda, 0x08, 0xe6, 0x0c, 0xcb, 0x34, 0x2c,
+	0xcc, 0xba, 0xe5, 0x17, 0xfd, 0x14, 0x44, 0xea, 0xb1, 0x7f, 0xea, 0x3d, 0x4c, 0x68, 0x47, 0x43,
+	0x54, 0x45, 0x42, 0x00, 0x8e, 0x03, 0xd3, 0x3a, 0xce, 0x6a, 0x8e, 0x72, 0x88, 0x8d, 0xc6, 0x21,
+	0xaf, 0x5c, 0x61, 0x34, 0xc7, 0x68, 0x3b, 0x8c, 0x04, 0x97, 0x41, 0xd4, 0x3d, 0x51, 0x58, 0x4f,
+	0xf3, 0x2a, 0x53, 0xc4, 0x3d, 0x29, 0xd2, 0xa5, 0x84, 0xc1, 0xcc, 0x9e, 0xad, 0x63, 0x13, 0x6e,
+	0x81, 0x10, 0x2d, 0x93, 0xff, 0x4f, 0x82, 0x52, 0x00, 0x1a, 0xcb, 0xfc, 0xf1, 0x3b, 0xcd, 0x4a,
+	0x1a, 0x5f, 0x48, 0x7f, 0x16, 0xc0, 0x82, 0x7c, 0x84, 0x2d, 0x56, 0x6d, 0x1d, 0xac, 0x3e, 0xd7,
+	0xed, 0x63, 0x16, 0xf7, 0x04, 0xbb, 0xed, 0x96, 0x77, 0x66, 0xbe, 0x80, 0x2b, 0x34, 0x10, 0xbd,
+	0xba, 0xef, 0x1d, 0x77, 0x48, 0xa0, 0x59, 0x4e, 0x9d, 0x48, 0x47, 0x04, 0xde, 0xb1, 0xfc, 0x25,
+	0xad, 0x0b, 0x98, 0x6e, 0x41, 0x98, 0x6d, 0xc3, 0xc8, 0x5b, 0xc1, 0x34, 0x98, 0x23, 0xed, 0x7a,
+	0x93, 0x5b, 0x92, 0xbf, 0x63, 0xc2, 0x28, 0x48, 0xa2, 0xe7, 0xb0, 0xdd, 0x43, 0xec, 0xb0, 0x1c,
+	0x09, 0x23, 0xbe, 0xa0, 0x54, 0xd7, 0x76, 0x55, 0x93, 0x25, 0x43, 0x18, 0xf1, 0x85, 0xf4, 0x5f,
+	0x01, 0x24, 0xd9, 0x4d, 0x06, 0x2e, 0x52, 0x2d, 0xb5, 0x81, 0x9b, 0x94, 0xc2, 0xc6, 0x7e, 0x1d,
+	0x3e, 0x04, 0xe2, 0x70, 0xba, 0xe4, 0xf9, 0xce, 0xfb, 0x09, 0x7a, 0xc1, 0xa7, 0x7b, 0x65, 0xe0,
+	0x6a, 0x11, 0x57, 0x06, 0x73, 0xfc, 0xb5, 0xa1, 0xd0, 0xde, 0xcf, 0xae, 0x7d, 0x7d, 0x33, 0x73,
+	0x7e, 0xa8, 0x8f, 0x9e, 0x88, 0x85, 0x39, 0xd0, 0x06, 0xdf, 0xb4, 0xf5, 0xd8, 0xa6, 0xae, 0x70,
+	0x3f, 0xf1, 0x9a, 0x13, 0xb5, 0x4d, 0xfd, 0x29, 0x5d, 0x53, 0xa6, 0x85, 0x8f, 0x3d, 0xe6, 0x0c,
+	0x67, 0x5a, 0xf8, 0x98, 0x31, 0xa5, 0x3f, 0x09, 0xe0, 0xfa, 0xc8, 0x30, 0x79, 0x13, 0xcc, 0x06,
+	0x22, 0x2f, 0x84, 0xbc, 0x15, 0xa5, 0xb3, 0xa7, 0xec, 0xa0, 0xbb, 0xf3, 0x15, 0xbc, 0x0f, 0xae,
+	0x0f, 0x3b, 0x0c, 0x7b, 0x10, 0x71, 0x3f, 0x8e, 0x50, 0x69, 0xd3, 0x09, 0x3c, 0x9a, 0xb8, 0x4b,
+	0x03, 0x14, 0xca, 0x6f, 0x1a, 0x0d, 0xc7, 0xc3, 0xe0, 0x5e, 0x0d, 0x50, 0x68, 0xd0, 0xf3, 0x31,
+	0xd1, 0x6b, 0x59, 0x61, 0x14, 0x69, 0xd0, 0x01, 0x11, 0xeb, 0xd2, 0x5f, 0x04, 0xb0, 0xc4, 0x7c,
+	0x58, 0x1d, 0x04, 0xc1, 0x96, 0x6a, 0x98, 0x5f, 0xcd, 0x75, 0xb7, 0x41, 0x8c, 0xce, 0x16, 0xc3,
+	0xac, 0xba, 0x86, 0xa2, 0x4d, 0xd2, 0x60, 0x69, 0x05, 0xef, 0x83, 0xa8, 0x83, 0x5b, 0x66, 0x87,
+	0x3a, 0x96, 0x5d, 0x2f, 0x37, 0x77, 0xda, 0x4f, 0x45, 0x10, 0xa5, 0x15, 0x0b, 0x28, 0xc2, 0x98,
+	0x45, 0x9d, 0xc6, 0x3a, 0x75, 0x32, 0x69, 0xa9, 0x9a, 0xef, 0x89, 0x21, 0x01, 0x42, 0x10, 0xa6,
+	0x0b, 0x76, 0xb9, 0x6b, 0x88, 0x7d, 0xd3, 0xa8, 0xc4, 0x8e, 0x63, 0xf3, 0x58, 0x8d, 0x21, 0xbe,
+	0x90, 0xee, 0x80, 0xdb, 0xc1, 0xcc, 0x1a, 0x14, 0xe0, 0x72, 0x8b, 0x1a, 0x63, 0xed, 0x3f, 0x02,
+	0x00, 0xc3, 0x9f, 0x38, 0xe0, 0x1b, 0xe0, 0x56, 0x36, 0x9f, 0x97, 0xab, 0x55, 0xa5, 0xb6, 0x5f,
+	0x91, 0x95, 0x27, 0xa5, 0x6a, 0x45, 0xce, 0x17, 0xb7, 0x8a, 0x72, 0x41, 0x9c, 0x4a, 0x2c, 0x77,
+	0x7b, 0xe9, 0xa5, 0xa1, 0xf0, 0x13, 0x8b, 0xb4, 0xb0, 0x66, 0x1c, 0x18, 0x58, 0xa7, 0x25, 0x3c,
+	0xa8, 0x57, 0x2a, 0xe7, 0xca, 0x85, 0x7d, 0x51, 0x48, 0x2c, 0x76, 0x7b, 0x69, 0x71, 0xa8, 0x52,
+	0xb2, 0xeb, 0xb6, 0xde, 0x81, 0x9b, 0x60, 0x29, 0x28, 0x2d, 0x3f, 0x95, 0xd1, 0x3e, 0x53, 0x08,
+	0x25, 0x6e, 0x75, 0x7b, 0xe9, 0x1b, 0x43, 0x05, 0xf9, 0x08, 0x3b, 0x1d, 0xa6, 0xf3, 0x16, 0x58,
+	0x09, 0xea, 0x64, 0x4b, 0xfb, 0x4a, 0x79, 0x4b, 0xc9, 0x16, 0x0a, 0x48, 0xae, 0x56, 0xe5, 0xaa,
+	0x18, 0x4e, 0xac, 0x74, 0x7b, 0xe9, 0xf8, 0x50, 0x35, 0x6b, 0x75, 0xca, 0x07, 0x59, 0xff, 0x07,
+	0xa9, 0x44, 0xf4, 0xbd, 0xdf, 0x26, 0xa7, 0xde, 0xff, 0x5d, 0x72, 0x4a, 0xa2, 0x3f, 0x4a, 0x4d,
+	0xaf, 0xfd, 0x7a, 0x1a, 0xc0, 0xf1, 0xe7, 0x18, 0xdc, 0x06, 0xe9, 0x82, 0xbc, 0x95, 0x7d, 0xb2,
+	0x5b, 0x53, 0xb2, 0x85, 0xbd, 0x62, 0x49, 0xa9, 0x94, 0x77, 0x8b, 0xf9, 0xfd, 0x11, 0x4b, 0xdc,
+	0xed, 0xf6, 0xd2, 0x77, 0xc6, 0xb5, 0x83, 0x16, 0xf9, 0x16, 0x48, 0x4c, 0x04, 0x92, 0x11, 0x2a,
+	0x23, 0x51, 0x48, 0xdc, 0xee, 0xf6, 0xd2, 0xb7, 0xc6, 0x21, 0x64, 0xea, 0x34, 0xf8, 0x6d, 0xb0,
+	0x32, 0x51, 0x39, 0x8f, 0xe4, 0x6c, 0xad, 0x8c, 0xc4, 0xe9, 0xc4, 0x9d, 0x6e, 0x2f, 0xbd, 0x3c,
+	0xae, 0x9e, 0xf7, 0xe6, 0x8c, 0x6f, 0x82, 0xe5, 0x89, 0x00, 0xa5, 0x72, 0x49, 0x16, 0x43, 0x89,
+	0x44, 0xb7, 0x97, 0xbe, 0x39, 0xae, 0x5d, 0xb2, 0x2d, 0x9c, 0x08, 0x53, 0x43, 0xad, 0x7d, 0x2c,
+	0x80, 0xf1, 0x47, 0x1c, 0x94, 0x41, 0xea, 0xed, 0x27, 0x32, 0xda, 0x57, 0x28, 0x55, 0x91, 0x4b,
+	0xf9, 0x72, 0xa1, 0x58, 0xda, 0x1e, 0x31, 0x4e, 0xba, 0xdb, 0x4b, 0xaf, 0x8c, 0xe9, 0x06, 0x6d,
+	0xf3, 0x1a, 0xb8, 0x39, 0x09, 0xe6, 0xe9, 0x86, 0x28, 0xf0, 0x00, 0x18, 0xd3, 0x7e, 0xba, 0x71,
+	0xae, 0xd2, 0xa6, 0x38, 0x7d, 0x9e, 0xd2, 0xa6, 0x77, 0x99, 0x2f, 0xc2, 0x20, 0x7d, 0x59, 0xb7,
+	0x87, 0x18, 0xbc, 0x9a, 0x2f, 0x97, 0x6a, 0x28, 0x9b, 0xaf, 0x29, 0xf9, 0x72, 0x41, 0x56, 0x76,
+	0x8a, 0xd5, 0x5a, 0x19, 0xed, 0x2b, 0xe5, 0x8a, 0x8c, 0xb2, 0xb5, 0x62, 0xb9, 0x34, 0x29, 0x27,
+	0xd6, 0xbb, 0xbd, 0xf4, 0xcb, 0x97, 0x61, 0x07, 0xef, 0xfe, 0x0c, 0x3c, 0xbc, 0xd2, 0x36, 0xc5,
+	0x52, 0xb1, 0x26, 0x0a, 0x89, 0xd5, 0x6e, 0x2f, 0xfd, 0xe2, 0x65, 0xf8, 0x45, 0xcb, 0x70, 0xe1,
+	0xbb, 0xe0, 0x95, 0x2b, 0x01, 0xef, 0x15, 0xb7, 0x51, 0xb6, 0x26, 0x8b, 0xd3, 0x89, 0x97, 0xbb,
+	0xbd, 0xf4, 0x83, 0xcb, 0xb0, 0xf7, 0x58, 0xdd, 0xc4, 0x57, 0x86, 0xdf, 0x96, 0x4b, 0x72, 0xb5,
+	0x58, 0x15, 0x43, 0x57, 0x83, 0xdf, 0xc6, 0x16, 0x26, 0x06, 0x81, 0x87, 0x60, 0xf3, 0x4a, 0xf0,
+	0x3c, 0x9a, 0xf3, 0x3b, 0xd9, 0xd2, 0xb6, 0x5c, 0x10, 0xc3, 0x89, 0x57, 0xbb, 0xbd, 0xf4, 0x2b,
+	0x97, 0x6d, 0xc2, 0x42, 0xdc, 0xef, 0xc1, 0x57, 0xdd, 0x69, 0x37, 0x9b, 0x93, 0x77, 0x07, 0x3b,
+	0xcd, 0x5c, 0x6d, 0x27, 0xf6, 0x83, 0x80, 0xb7, 0x93, 0x17, 0x7c, 0x7f, 0x0c, 0x83, 0x95, 0x8b,
+	0xfa, 0x2f, 0xfc, 0x1e, 0x78, 0x79, 0x70, 0xa0, 0xbd, 0x6c, 0x29, 0xbb, 0x2d, 0xef, 0xc9, 0xa5,
+	0x9a, 0xb7, 0xf3, 0xa4, 0x98, 0xfb, 0x92, 0x61, 0x27, 0x41, 0x06, 0xe3, 0xad, 0x02, 0x5e, 0xba,
+	0x0c, 0x9d, 0xd9, 0x54, 0x14, 0x12, 0x2f, 0x75, 0x7b, 0xe9, 0xbb, 0x17, 0xe1, 0x32, 0x3b, 0x5e,
+	0x05, 0x91, 0xd9, 0x4e, 0x9c, 0xbe, 0x1c, 0x91, 0xd9, 0x0b, 0x1a, 0x60, 0xf3, 0x32, 0xc4, 0x62,
+	0xa9, 0x5a, 0xcb, 0x96, 0x6a, 0xc5, 0x6c, 0x4d, 0x56, 0xf2, 0xe5, 0xd2, 0x56, 0x71, 0x5b, 0x0c,
+	0x25, 0x36, 0xba, 0xbd, 0xf4, 0xa3, 0x8b, 0xe0, 0x8b, 0x63, 0x0f, 0xd6, 0x5d, 0x70, 0xef, 0xb2,
+	0xad, 0x2a, 0xc5, 0x92, 0x18, 0x4e, 0xdc, 0xeb, 0xf6, 0xd2, 0xa9, 0x8b, 0xb0, 0x2b, 0x86, 0x05,
+	0x6b, 0xe0, 0xc1, 0x65, 0x68, 0x7e, 0xba, 0xcd, 0x24, 0x1e, 0x74, 0x7b, 0xe9, 0x7b, 0x17, 0x21,
+	0x7a, 0xa9, 0xc6, 0xe3, 0x26, 0xb7, 0xf3, 0xce, 0xfd, 0xc0, 0x9c, 0x9d, 0xb7, 0x49, 0xf3, 0x99,
+	0xff, 0x5f, 0x5f, 0xfa, 0xfa, 0x09, 0xfb, 0x97, 0xff, 0xe2, 0xf3, 0xd1, 0xa7, 0xc9, 0xa9, 0xf7,
+	0x4f, 0x93, 0xc2, 0x47, 0xa7, 0x49, 0xe1, 0x93, 0xd3, 0xa4, 0xf0, 0x8f, 0xd3, 0xa4, 0xf0, 0xf3,
+	0xcf, 0x92, 0x53, 0x9f, 0x7c, 0x96, 0x9c, 0xfa, 0xdb, 0x67, 0xc9, 0xa9, 0xfa, 0x2c, 0x7b, 0xa4,
+	0xbd, 0xf6, 0xbf, 0x01, 0x00, 0x89, 0xe5, 0xda, 0x94, 0x40, 0x1b, 0x00, 0x00,
+}
+
+func (this *AccessTypeParam) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Params)
+	that1, ok := that.(*AccessTypeParam)
 	if !ok {
-		that2, ok := that.(Params)
+		that2, ok := that.(AccessTypeParam)
 		if ok {
 			that1 = &that2
 		} else {
@@ -646,23 +1404,20 @@ func (this *Params) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !this.CodeUploadAccess.Equal(&that1.CodeUploadAccess) {
-		return false
-	}
-	if this.InstantiateDefaultPermission != that1.InstantiateDefaultPermission {
+	if this.Value != that1.Value {
 		return false
 	}
 	return true
 }
 
-func (this *CodeInfo) Equal(that interface{}) bool {
+func (this *AccessConfig) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*CodeInfo)
+	that1, ok := that.(*AccessConfig)
 	if !ok {
-		that2, ok := that.(CodeInfo)
+		that2, ok := that.(AccessConfig)
 		if ok {
 			that1 = &that2
 		} else {
@@ -674,26 +1429,28 @@ func (this *CodeInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.CodeHash, that1.CodeHash) {
+	if this.Permission != that1.Permission {
 		return false
 	}
-	if this.Creator != that1.Creator {
+	if len(this.Addresses) != len(that1.Addresses) {
 		return false
 	}
-	if !this.InstantiateConfig.Equal(&that1.InstantiateConfig) {
-		return false
+	for i := range this.Addresses {
+		if this.Addresses[i] != that1.Addresses[i] {
+			return false
+		}
 	}
 	return true
 }
 
-func (this *ContractInfo) Equal(that interface{}) bool {
+func (this *Params) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ContractInfo)
+	that1, ok := that.(*Params)
 	if !ok {
-		that2, ok := that.(ContractInfo)
+		that2, ok := that.(Params)
 		if ok {
 			that1 = &that2
 		} else {
@@ -705,38 +1462,70 @@ func (this *ContractInfo) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.CodeID != that1.CodeID {
+	if !this.CodeUploadAccess.Equal(&that1.CodeUploadAccess) {
 		return false
 	}
-	if this.Creator != that1.Creator {
+	if this.InstantiateDefaultPermission != that1.InstantiateDefaultPermission {
 		return false
 	}
-	if this.Admin != that1.Admin {
+	if this.DeduplicateStoreCode != that1.DeduplicateStoreCode {
 		return false
 	}
-	if this.Label != that1.Label {
+	if this.StrictAdminValidation != that1.StrictAdminValidation {
 		return false
 	}
-	if !this.Created.Equal(that1.Created) {
+	if this.AllowRawStateWrites != that1.AllowRawStateWrites {
 		return false
 	}
-	if this.IBCPortID != that1.IBCPortID {
+	if !this.UploadSpamProtection.Equal(&that1.UploadSpamProtection) {
 		return false
 	}
-	if !this.Extension.Equal(that1.Extension) {
+	if this.RecordContractInfoChanges != that1.RecordContractInfoChanges {
+		return false
+	}
+	if !this.FeelessExecutions.Equal(&that1.FeelessExecutions) {
+		return false
+	}
+	if len(this.ContractGasBudgets) != len(that1.ContractGasBudgets) {
+		return false
+	}
+	for i := range this.ContractGasBudgets {
+		if !this.ContractGasBudgets[i].Equal(&that1.ContractGasBudgets[i]) {
+			return false
+		}
+	}
+	if this.ContractStateAccessControl != that1.ContractStateAccessControl {
+		return false
+	}
+	if this.TrackContractActivity != that1.TrackContractActivity {
+		return false
+	}
+	if this.DefaultAdminPolicy != that1.DefaultAdminPolicy {
+		return false
+	}
+	if this.RecordModuleActivity != that1.RecordModuleActivity {
+		return false
+	}
+	if this.QueryJSONEncoding != that1.QueryJSONEncoding {
+		return false
+	}
+	if this.RecordCodeGasUsage != that1.RecordCodeGasUsage {
+		return false
+	}
+	if this.IndexContractLabels != that1.IndexContractLabels {
 		return false
 	}
 	return true
 }
 
-func (this *ContractCodeHistoryEntry) Equal(that interface{}) bool {
+func (this *UploadSpamProtection) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*ContractCodeHistoryEntry)
+	that1, ok := that.(*UploadSpamProtection)
 	if !ok {
-		that2, ok := that.(ContractCodeHistoryEntry)
+		that2, ok := that.(UploadSpamProtection)
 		if ok {
 			that1 = &that2
 		} else {
@@ -748,29 +1537,31 @@ func (this *ContractCodeHistoryEntry) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Operation != that1.Operation {
+	if len(this.Deposit) != len(that1.Deposit) {
 		return false
 	}
-	if this.CodeID != that1.CodeID {
-		return false
+	for i := range this.Deposit {
+		if !this.Deposit[i].Equal(&that1.Deposit[i]) {
+			return false
+		}
 	}
-	if !this.Updated.Equal(that1.Updated) {
+	if this.MaxUploadsPerEpoch != that1.MaxUploadsPerEpoch {
 		return false
 	}
-	if !bytes.Equal(this.Msg, that1.Msg) {
+	if this.EpochLength != that1.EpochLength {
 		return false
 	}
 	return true
 }
 
-func (this *AbsoluteTxPosition) Equal(that interface{}) bool {
+func (this *FeelessExecutions) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*AbsoluteTxPosition)
+	that1, ok := that.(*FeelessExecutions)
 	if !ok {
-		that2, ok := that.(AbsoluteTxPosition)
+		that2, ok := that.(FeelessExecutions)
 		if ok {
 			that1 = &that2
 		} else {
@@ -782,23 +1573,28 @@ func (this *AbsoluteTxPosition) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.BlockHeight != that1.BlockHeight {
+	if len(this.Allowed) != len(that1.Allowed) {
 		return false
 	}
-	if this.TxIndex != that1.TxIndex {
+	for i := range this.Allowed {
+		if !this.Allowed[i].Equal(&that1.Allowed[i]) {
+			return false
+		}
+	}
+	if this.MaxGas != that1.MaxGas {
 		return false
 	}
 	return true
 }
 
-func (this *Model) Equal(that interface{}) bool {
+func (this *FeelessExecution) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Model)
+	that1, ok := that.(*FeelessExecution)
 	if !ok {
-		that2, ok := that.(Model)
+		that2, ok := that.(FeelessExecution)
 		if ok {
 			that1 = &that2
 		} else {
@@ -810,575 +1606,3433 @@ func (this *Model) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Key, that1.Key) {
+	if this.Contract != that1.Contract {
 		return false
 	}
-	if !bytes.Equal(this.Value, that1.Value) {
+	if this.MsgKey != that1.MsgKey {
 		return false
 	}
 	return true
 }
 
-func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ContractGasBudget) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	that1, ok := that.(*ContractGasBudget)
+	if !ok {
+		that2, ok := that.(ContractGasBudget)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Contract != that1.Contract {
+		return false
+	}
+	if this.MaxGasPerBlock != that1.MaxGasPerBlock {
+		return false
+	}
+	return true
 }
 
-func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Value != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Value))
-		i--
-		dAtA[i] = 0x8
+func (this *CodeInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return len(dAtA) - i, nil
-}
 
-func (m *AccessConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *AccessConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Addresses) > 0 {
-		for iNdEx := len(m.Addresses) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Addresses[iNdEx])
-			copy(dAtA[i:], m.Addresses[iNdEx])
-			i = encodeVarintTypes(dAtA, i, uint64(len(m.Addresses[iNdEx])))
-			i--
-			dAtA[i] = 0x1a
+	that1, ok := that.(*CodeInfo)
+	if !ok {
+		that2, ok := that.(CodeInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if m.Permission != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Permission))
-		i--
-		dAtA[i] = 0x8
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Params) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.CodeHash, that1.CodeHash) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Params) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.InstantiateDefaultPermission != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.InstantiateDefaultPermission))
-		i--
-		dAtA[i] = 0x10
+	if this.Creator != that1.Creator {
+		return false
 	}
-	{
-		size, err := m.CodeUploadAccess.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintTypes(dAtA, i, uint64(size))
+	if !this.InstantiateConfig.Equal(&that1.InstantiateConfig) {
+		return false
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
-}
-
-func (m *CodeInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Deprecated != that1.Deprecated {
+		return false
 	}
-	return dAtA[:n], nil
+	return true
 }
 
-func (m *CodeInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
+func (this *CodeProvenance) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
+	that1, ok := that.(*CodeProvenance)
+	if !ok {
+		that2, ok := that.(CodeProvenance)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i -= size
-		i = encodeVarintTypes(dAtA, i, uint64(size))
 	}
-	i--
-	dAtA[i] = 0x2a
-	if len(m.Creator) > 0 {
-		i -= len(m.Creator)
-		copy(dAtA[i:], m.Creator)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
-		i--
-		dAtA[i] = 0x12
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.CodeHash) > 0 {
-		i -= len(m.CodeHash)
-		copy(dAtA[i:], m.CodeHash)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.CodeHash)))
-		i--
-		dAtA[i] = 0xa
+	if !bytes.Equal(this.TxHash, that1.TxHash) {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ContractInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Source != that1.Source {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.Builder != that1.Builder {
+		return false
+	}
+	return true
 }
 
-func (m *ContractInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
+func (this *ContractInfo) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *ContractInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Extension != nil {
-		{
-			size, err := m.Extension.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
+	that1, ok := that.(*ContractInfo)
+	if !ok {
+		that2, ok := that.(ContractInfo)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x3a
 	}
-	if len(m.IBCPortID) > 0 {
-		i -= len(m.IBCPortID)
-		copy(dAtA[i:], m.IBCPortID)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.IBCPortID)))
-		i--
-		dAtA[i] = 0x32
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Created != nil {
-		{
-			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+	if this.CodeID != that1.CodeID {
+		return false
 	}
-	if len(m.Label) > 0 {
-		i -= len(m.Label)
-		copy(dAtA[i:], m.Label)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Label)))
-		i--
-		dAtA[i] = 0x22
+	if this.Creator != that1.Creator {
+		return false
 	}
-	if len(m.Admin) > 0 {
-		i -= len(m.Admin)
-		copy(dAtA[i:], m.Admin)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Admin)))
-		i--
-		dAtA[i] = 0x1a
+	if this.Admin != that1.Admin {
+		return false
 	}
-	if len(m.Creator) > 0 {
-		i -= len(m.Creator)
-		copy(dAtA[i:], m.Creator)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
-		i--
-		dAtA[i] = 0x12
+	if this.Label != that1.Label {
+		return false
 	}
-	if m.CodeID != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
-		i--
-		dAtA[i] = 0x8
+	if !this.Created.Equal(that1.Created) {
+		return false
 	}
-	return len(dAtA) - i, nil
+	if this.IBCPortID != that1.IBCPortID {
+		return false
+	}
+	if !this.Extension.Equal(that1.Extension) {
+		return false
+	}
+	if this.RawQueryDisabled != that1.RawQueryDisabled {
+		return false
+	}
+	return true
 }
 
-func (m *ContractCodeHistoryEntry) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ContractCodeHistoryEntry) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *ContractCodeHistoryEntry) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ContractCodeHistoryEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Msg) > 0 {
-		i -= len(m.Msg)
-		copy(dAtA[i:], m.Msg)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Updated != nil {
-		{
-			size, err := m.Updated.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintTypes(dAtA, i, uint64(size))
+	that1, ok := that.(*ContractCodeHistoryEntry)
+	if !ok {
+		that2, ok := that.(ContractCodeHistoryEntry)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		i--
-		dAtA[i] = 0x1a
 	}
-	if m.CodeID != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
-		i--
-		dAtA[i] = 0x10
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Operation != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.Operation))
-		i--
-		dAtA[i] = 0x8
+	if this.Operation != that1.Operation {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *AbsoluteTxPosition) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.CodeID != that1.CodeID {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *AbsoluteTxPosition) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *AbsoluteTxPosition) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.TxIndex != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.TxIndex))
-		i--
-		dAtA[i] = 0x10
+	if !this.Updated.Equal(that1.Updated) {
+		return false
 	}
-	if m.BlockHeight != 0 {
-		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
-		i--
-		dAtA[i] = 0x8
+	if !bytes.Equal(this.Msg, that1.Msg) {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
 
-func (m *Model) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *AbsoluteTxPosition) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *Model) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x12
+	that1, ok := that.(*AbsoluteTxPosition)
+	if !ok {
+		that2, ok := that.(AbsoluteTxPosition)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.Key) > 0 {
-		i -= len(m.Key)
-		copy(dAtA[i:], m.Key)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+	if this.BlockHeight != that1.BlockHeight {
+		return false
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	if this.TxIndex != that1.TxIndex {
+		return false
+	}
+	return true
 }
 
-func (m *AccessTypeParam) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Value != 0 {
-		n += 1 + sovTypes(uint64(m.Value))
+func (this *Model) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return n
-}
 
-func (m *AccessConfig) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*Model)
+	if !ok {
+		that2, ok := that.(Model)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	if m.Permission != 0 {
-		n += 1 + sovTypes(uint64(m.Permission))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if len(m.Addresses) > 0 {
-		for _, s := range m.Addresses {
-			l = len(s)
-			n += 1 + l + sovTypes(uint64(l))
-		}
+	if !bytes.Equal(this.Key, that1.Key) {
+		return false
 	}
-	return n
+	if !bytes.Equal(this.Value, that1.Value) {
+		return false
+	}
+	return true
 }
 
-func (m *Params) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.CodeUploadAccess.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	if m.InstantiateDefaultPermission != 0 {
-		n += 1 + sovTypes(uint64(m.InstantiateDefaultPermission))
+func (this *EventGasBreakdown) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return n
-}
 
-func (m *CodeInfo) Size() (n int) {
-	if m == nil {
-		return 0
+	that1, ok := that.(*EventGasBreakdown)
+	if !ok {
+		that2, ok := that.(EventGasBreakdown)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	var l int
-	_ = l
-	l = len(m.CodeHash)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	l = len(m.Creator)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Setup != that1.Setup {
+		return false
 	}
-	l = m.InstantiateConfig.Size()
-	n += 1 + l + sovTypes(uint64(l))
-	return n
-}
-
-func (m *ContractInfo) Size() (n int) {
-	if m == nil {
-		return 0
+	if this.Execution != that1.Execution {
+		return false
 	}
-	var l int
-	_ = l
-	if m.CodeID != 0 {
-		n += 1 + sovTypes(uint64(m.CodeID))
+	if this.Storage != that1.Storage {
+		return false
 	}
-	l = len(m.Creator)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Events != that1.Events {
+		return false
 	}
-	l = len(m.Admin)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Submessages != that1.Submessages {
+		return false
 	}
-	l = len(m.Label)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Other != that1.Other {
+		return false
 	}
-	if m.Created != nil {
-		l = m.Created.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Total != that1.Total {
+		return false
 	}
-	l = len(m.IBCPortID)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	return true
+}
+
+func (this *EventContractManagementChanged) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	if m.Extension != nil {
-		l = m.Extension.Size()
-		n += 1 + l + sovTypes(uint64(l))
+
+	that1, ok := that.(*EventContractManagementChanged)
+	if !ok {
+		that2, ok := that.(EventContractManagementChanged)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return n
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ContractAddress != that1.ContractAddress {
+		return false
+	}
+	if this.CodeID != that1.CodeID {
+		return false
+	}
+	if this.ChangeType != that1.ChangeType {
+		return false
+	}
+	if this.OldValue != that1.OldValue {
+		return false
+	}
+	if this.NewValue != that1.NewValue {
+		return false
+	}
+	return true
 }
 
-func (m *ContractCodeHistoryEntry) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *ModuleActivity) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	var l int
-	_ = l
-	if m.Operation != 0 {
-		n += 1 + sovTypes(uint64(m.Operation))
+
+	that1, ok := that.(*ModuleActivity)
+	if !ok {
+		that2, ok := that.(ModuleActivity)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.CodeID != 0 {
-		n += 1 + sovTypes(uint64(m.CodeID))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.Updated != nil {
-		l = m.Updated.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Height != that1.Height {
+		return false
 	}
-	l = len(m.Msg)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if this.Stores != that1.Stores {
+		return false
 	}
-	return n
+	if this.Instantiations != that1.Instantiations {
+		return false
+	}
+	if this.Executions != that1.Executions {
+		return false
+	}
+	if this.Migrations != that1.Migrations {
+		return false
+	}
+	if this.GasUsed != that1.GasUsed {
+		return false
+	}
+	return true
 }
 
-func (m *AbsoluteTxPosition) Size() (n int) {
-	if m == nil {
-		return 0
+func (this *EventSubmessageFailed) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*EventSubmessageFailed)
+	if !ok {
+		that2, ok := that.(EventSubmessageFailed)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.ContractAddress != that1.ContractAddress {
+		return false
+	}
+	if this.MsgIndex != that1.MsgIndex {
+		return false
+	}
+	if this.ReplyID != that1.ReplyID {
+		return false
+	}
+	if this.Codespace != that1.Codespace {
+		return false
+	}
+	if this.Code != that1.Code {
+		return false
+	}
+	if this.Error != that1.Error {
+		return false
+	}
+	return true
+}
+func (this *GasBreakdownExtensionOption) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*GasBreakdownExtensionOption)
+	if !ok {
+		that2, ok := that.(GasBreakdownExtensionOption)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
+}
+
+func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessTypeParam) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessTypeParam) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.BlockHeight != 0 {
-		n += 1 + sovTypes(uint64(m.BlockHeight))
-	}
-	if m.TxIndex != 0 {
-		n += 1 + sovTypes(uint64(m.TxIndex))
+	if m.Value != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Value))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Model) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *AccessConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *AccessConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AccessConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if len(m.Addresses) > 0 {
+		for iNdEx := len(m.Addresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Addresses[iNdEx])
+			copy(dAtA[i:], m.Addresses[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Addresses[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Permission != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Permission))
+		i--
+		dAtA[i] = 0x8
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *Params) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
 
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *Params) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowTypes
+func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.IndexContractLabels {
+		i--
+		if m.IndexContractLabels {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.RecordCodeGasUsage {
+		i--
+		if m.RecordCodeGasUsage {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
+	}
+	if m.QueryJSONEncoding != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.QueryJSONEncoding))
+		i--
+		dAtA[i] = 0x70
+	}
+	if m.RecordModuleActivity {
+		i--
+		if m.RecordModuleActivity {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.DefaultAdminPolicy != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.DefaultAdminPolicy))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.TrackContractActivity {
+		i--
+		if m.TrackContractActivity {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.ContractStateAccessControl {
+		i--
+		if m.ContractStateAccessControl {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.ContractGasBudgets) > 0 {
+		for iNdEx := len(m.ContractGasBudgets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ContractGasBudgets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	{
+		size, err := m.FeelessExecutions.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x42
+	if m.RecordContractInfoChanges {
+		i--
+		if m.RecordContractInfoChanges {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	{
+		size, err := m.UploadSpamProtection.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	if m.AllowRawStateWrites {
+		i--
+		if m.AllowRawStateWrites {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.StrictAdminValidation {
+		i--
+		if m.StrictAdminValidation {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.DeduplicateStoreCode {
+		i--
+		if m.DeduplicateStoreCode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.InstantiateDefaultPermission != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.InstantiateDefaultPermission))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.CodeUploadAccess.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *UploadSpamProtection) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UploadSpamProtection) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UploadSpamProtection) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.EpochLength != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.EpochLength))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxUploadsPerEpoch != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxUploadsPerEpoch))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Deposit) > 0 {
+		for iNdEx := len(m.Deposit) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Deposit[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeelessExecutions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeelessExecutions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeelessExecutions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxGas != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGas))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Allowed) > 0 {
+		for iNdEx := len(m.Allowed) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Allowed[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeelessExecution) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeelessExecution) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeelessExecution) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MsgKey) > 0 {
+		i -= len(m.MsgKey)
+		copy(dAtA[i:], m.MsgKey)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.MsgKey)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractGasBudget) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractGasBudget) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractGasBudget) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxGasPerBlock != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxGasPerBlock))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Deprecated {
+		i--
+		if m.Deprecated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	{
+		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Creator) > 0 {
+		i -= len(m.Creator)
+		copy(dAtA[i:], m.Creator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.CodeHash) > 0 {
+		i -= len(m.CodeHash)
+		copy(dAtA[i:], m.CodeHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.CodeHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CodeProvenance) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CodeProvenance) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CodeProvenance) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Builder) > 0 {
+		i -= len(m.Builder)
+		copy(dAtA[i:], m.Builder)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Builder)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TxHash) > 0 {
+		i -= len(m.TxHash)
+		copy(dAtA[i:], m.TxHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.TxHash)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RawQueryDisabled {
+		i--
+		if m.RawQueryDisabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Extension != nil {
+		{
+			size, err := m.Extension.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.IBCPortID) > 0 {
+		i -= len(m.IBCPortID)
+		copy(dAtA[i:], m.IBCPortID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.IBCPortID)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Created != nil {
+		{
+			size, err := m.Created.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Label) > 0 {
+		i -= len(m.Label)
+		copy(dAtA[i:], m.Label)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Label)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Admin) > 0 {
+		i -= len(m.Admin)
+		copy(dAtA[i:], m.Admin)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Admin)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Creator) > 0 {
+		i -= len(m.Creator)
+		copy(dAtA[i:], m.Creator)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Creator)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractCodeHistoryEntry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractCodeHistoryEntry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractCodeHistoryEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Updated != nil {
+		{
+			size, err := m.Updated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Operation != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Operation))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AbsoluteTxPosition) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AbsoluteTxPosition) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AbsoluteTxPosition) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TxIndex != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TxIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.BlockHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BlockHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Model) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Model) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventGasBreakdown) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventGasBreakdown) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventGasBreakdown) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Total != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Other != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Other))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Submessages != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Submessages))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Events != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Events))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Storage != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Storage))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Execution != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Execution))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Setup != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Setup))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventContractManagementChanged) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventContractManagementChanged) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventContractManagementChanged) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NewValue) > 0 {
+		i -= len(m.NewValue)
+		copy(dAtA[i:], m.NewValue)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NewValue)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.OldValue) > 0 {
+		i -= len(m.OldValue)
+		copy(dAtA[i:], m.OldValue)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.OldValue)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ChangeType != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChangeType))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CodeID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CodeID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ModuleActivity) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ModuleActivity) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ModuleActivity) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GasUsed != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.GasUsed))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Migrations != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Migrations))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Executions != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Executions))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Instantiations != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Instantiations))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Stores != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Stores))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EventSubmessageFailed) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventSubmessageFailed) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EventSubmessageFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Code != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Codespace) > 0 {
+		i -= len(m.Codespace)
+		copy(dAtA[i:], m.Codespace)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Codespace)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.ReplyID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ReplyID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MsgIndex != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MsgIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ContractAddress) > 0 {
+		i -= len(m.ContractAddress)
+		copy(dAtA[i:], m.ContractAddress)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ContractAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GasBreakdownExtensionOption) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GasBreakdownExtensionOption) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GasBreakdownExtensionOption) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+
+func (m *AccessTypeParam) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != 0 {
+		n += 1 + sovTypes(uint64(m.Value))
+	}
+	return n
+}
+
+func (m *AccessConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Permission != 0 {
+		n += 1 + sovTypes(uint64(m.Permission))
+	}
+	if len(m.Addresses) > 0 {
+		for _, s := range m.Addresses {
+			l = len(s)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.CodeUploadAccess.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.InstantiateDefaultPermission != 0 {
+		n += 1 + sovTypes(uint64(m.InstantiateDefaultPermission))
+	}
+	if m.DeduplicateStoreCode {
+		n += 2
+	}
+	if m.StrictAdminValidation {
+		n += 2
+	}
+	if m.AllowRawStateWrites {
+		n += 2
+	}
+	l = m.UploadSpamProtection.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.RecordContractInfoChanges {
+		n += 2
+	}
+	l = m.FeelessExecutions.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ContractGasBudgets) > 0 {
+		for _, e := range m.ContractGasBudgets {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ContractStateAccessControl {
+		n += 2
+	}
+	if m.TrackContractActivity {
+		n += 2
+	}
+	if m.DefaultAdminPolicy != 0 {
+		n += 1 + sovTypes(uint64(m.DefaultAdminPolicy))
+	}
+	if m.RecordModuleActivity {
+		n += 2
+	}
+	if m.QueryJSONEncoding != 0 {
+		n += 1 + sovTypes(uint64(m.QueryJSONEncoding))
+	}
+	if m.RecordCodeGasUsage {
+		n += 2
+	}
+	if m.IndexContractLabels {
+		n += 3
+	}
+	return n
+}
+
+func (m *UploadSpamProtection) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Deposit) > 0 {
+		for _, e := range m.Deposit {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxUploadsPerEpoch != 0 {
+		n += 1 + sovTypes(uint64(m.MaxUploadsPerEpoch))
+	}
+	if m.EpochLength != 0 {
+		n += 1 + sovTypes(uint64(m.EpochLength))
+	}
+	return n
+}
+
+func (m *FeelessExecutions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Allowed) > 0 {
+		for _, e := range m.Allowed {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.MaxGas != 0 {
+		n += 1 + sovTypes(uint64(m.MaxGas))
+	}
+	return n
+}
+
+func (m *FeelessExecution) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.MsgKey)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ContractGasBudget) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.MaxGasPerBlock != 0 {
+		n += 1 + sovTypes(uint64(m.MaxGasPerBlock))
+	}
+	return n
+}
+
+func (m *CodeInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CodeHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Creator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = m.InstantiateConfig.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Deprecated {
+		n += 2
+	}
+	return n
+}
+
+func (m *CodeProvenance) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Builder)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ContractInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	l = len(m.Creator)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Admin)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Label)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Created != nil {
+		l = m.Created.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.IBCPortID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Extension != nil {
+		l = m.Extension.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.RawQueryDisabled {
+		n += 2
+	}
+	return n
+}
+
+func (m *ContractCodeHistoryEntry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Operation != 0 {
+		n += 1 + sovTypes(uint64(m.Operation))
+	}
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	if m.Updated != nil {
+		l = m.Updated.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *AbsoluteTxPosition) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BlockHeight))
+	}
+	if m.TxIndex != 0 {
+		n += 1 + sovTypes(uint64(m.TxIndex))
+	}
+	return n
+}
+
+func (m *Model) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *EventGasBreakdown) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Setup != 0 {
+		n += 1 + sovTypes(uint64(m.Setup))
+	}
+	if m.Execution != 0 {
+		n += 1 + sovTypes(uint64(m.Execution))
+	}
+	if m.Storage != 0 {
+		n += 1 + sovTypes(uint64(m.Storage))
+	}
+	if m.Events != 0 {
+		n += 1 + sovTypes(uint64(m.Events))
+	}
+	if m.Submessages != 0 {
+		n += 1 + sovTypes(uint64(m.Submessages))
+	}
+	if m.Other != 0 {
+		n += 1 + sovTypes(uint64(m.Other))
+	}
+	if m.Total != 0 {
+		n += 1 + sovTypes(uint64(m.Total))
+	}
+	return n
+}
+
+func (m *EventContractManagementChanged) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.CodeID != 0 {
+		n += 1 + sovTypes(uint64(m.CodeID))
+	}
+	if m.ChangeType != 0 {
+		n += 1 + sovTypes(uint64(m.ChangeType))
+	}
+	l = len(m.OldValue)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NewValue)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ModuleActivity) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Stores != 0 {
+		n += 1 + sovTypes(uint64(m.Stores))
+	}
+	if m.Instantiations != 0 {
+		n += 1 + sovTypes(uint64(m.Instantiations))
+	}
+	if m.Executions != 0 {
+		n += 1 + sovTypes(uint64(m.Executions))
+	}
+	if m.Migrations != 0 {
+		n += 1 + sovTypes(uint64(m.Migrations))
+	}
+	if m.GasUsed != 0 {
+		n += 1 + sovTypes(uint64(m.GasUsed))
+	}
+	return n
+}
+
+func (m *EventSubmessageFailed) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ContractAddress)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.MsgIndex != 0 {
+		n += 1 + sovTypes(uint64(m.MsgIndex))
+	}
+	if m.ReplyID != 0 {
+		n += 1 + sovTypes(uint64(m.ReplyID))
+	}
+	l = len(m.Codespace)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Code != 0 {
+		n += 1 + sovTypes(uint64(m.Code))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *GasBreakdownExtensionOption) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+
+func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessTypeParam: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessTypeParam: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			m.Value = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Value |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *AccessConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AccessConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AccessConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Permission", wireType)
+			}
+			m.Permission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Permission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Addresses = append(m.Addresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *Params) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+			}
+			m.InstantiateDefaultPermission = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeduplicateStoreCode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DeduplicateStoreCode = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StrictAdminValidation", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StrictAdminValidation = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllowRawStateWrites", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllowRawStateWrites = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UploadSpamProtection", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.UploadSpamProtection.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordContractInfoChanges", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RecordContractInfoChanges = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeelessExecutions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.FeelessExecutions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractGasBudgets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractGasBudgets = append(m.ContractGasBudgets, ContractGasBudget{})
+			if err := m.ContractGasBudgets[len(m.ContractGasBudgets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractStateAccessControl", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ContractStateAccessControl = bool(v != 0)
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TrackContractActivity", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TrackContractActivity = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultAdminPolicy", wireType)
+			}
+			m.DefaultAdminPolicy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultAdminPolicy |= DefaultAdminPolicy(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordModuleActivity", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RecordModuleActivity = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryJSONEncoding", wireType)
+			}
+			m.QueryJSONEncoding = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QueryJSONEncoding |= QueryJSONEncoding(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RecordCodeGasUsage", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RecordCodeGasUsage = bool(v != 0)
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexContractLabels", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IndexContractLabels = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *UploadSpamProtection) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UploadSpamProtection: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UploadSpamProtection: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deposit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Deposit = append(m.Deposit, types.Coin{})
+			if err := m.Deposit[len(m.Deposit)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxUploadsPerEpoch", wireType)
+			}
+			m.MaxUploadsPerEpoch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxUploadsPerEpoch |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EpochLength", wireType)
+			}
+			m.EpochLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EpochLength |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *FeelessExecutions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeelessExecutions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeelessExecutions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Allowed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Allowed = append(m.Allowed, FeelessExecution{})
+			if err := m.Allowed[len(m.Allowed)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGas", wireType)
+			}
+			m.MaxGas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *FeelessExecution) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeelessExecution: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeelessExecution: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MsgKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *ContractGasBudget) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractGasBudget: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractGasBudget: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxGasPerBlock", wireType)
+			}
+			m.MaxGasPerBlock = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxGasPerBlock |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.CodeHash == nil {
+				m.CodeHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deprecated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deprecated = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *CodeProvenance) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CodeProvenance: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CodeProvenance: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHash = append(m.TxHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxHash == nil {
+				m.TxHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Source = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Builder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Builder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+
+func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Creator = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if iNdEx >= l {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Admin = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: AccessTypeParam: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessTypeParam: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Label = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Created == nil {
+				m.Created = &AbsoluteTxPosition{}
+			}
+			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IBCPortID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Extension == nil {
+				m.Extension = &types1.Any{}
+			}
+			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RawQueryDisabled", wireType)
 			}
-			m.Value = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1388,11 +5042,12 @@ func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Value |= AccessType(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			m.RawQueryDisabled = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1415,7 +5070,7 @@ func (m *AccessTypeParam) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *AccessConfig) Unmarshal(dAtA []byte) error {
+func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1438,17 +5093,17 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AccessConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AccessConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Permission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
 			}
-			m.Permission = 0
+			m.Operation = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1458,16 +5113,35 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Permission |= AccessType(b&0x7F) << shift
+				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+			}
+			m.CodeID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeID |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Addresses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1477,23 +5151,61 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Addresses = append(m.Addresses, string(dAtA[iNdEx:postIndex]))
+			if m.Updated == nil {
+				m.Updated = &AbsoluteTxPosition{}
+			}
+			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1517,7 +5229,7 @@ func (m *AccessConfig) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *Params) Unmarshal(dAtA []byte) error {
+func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1540,17 +5252,17 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Params: wiretype end group for non-group")
+			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Params: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeUploadAccess", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
 			}
-			var msglen int
+			m.BlockHeight = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1560,30 +5272,16 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.BlockHeight |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := m.CodeUploadAccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateDefaultPermission", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
 			}
-			m.InstantiateDefaultPermission = 0
+			m.TxIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1593,7 +5291,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.InstantiateDefaultPermission |= AccessType(b&0x7F) << shift
+				m.TxIndex |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1620,7 +5318,7 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *CodeInfo) Unmarshal(dAtA []byte) error {
+func (m *Model) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1643,15 +5341,15 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CodeInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: Model: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1678,48 +5376,16 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CodeHash = append(m.CodeHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.CodeHash == nil {
-				m.CodeHash = []byte{}
+			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
+			if m.Key == nil {
+				m.Key = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowTypes
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InstantiateConfig", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1729,23 +5395,24 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.InstantiateConfig.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1770,7 +5437,7 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *ContractInfo) Unmarshal(dAtA []byte) error {
+func (m *EventGasBreakdown) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1793,17 +5460,17 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractInfo: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventGasBreakdown: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractInfo: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventGasBreakdown: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Setup", wireType)
 			}
-			m.CodeID = 0
+			m.Setup = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1813,16 +5480,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CodeID |= uint64(b&0x7F) << shift
+				m.Setup |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Creator", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Execution", wireType)
 			}
-			var stringLen uint64
+			m.Execution = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1832,29 +5499,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Execution |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Creator = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Admin", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Storage", wireType)
 			}
-			var stringLen uint64
+			m.Storage = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1864,29 +5518,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Storage |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Admin = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Label", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
-			var stringLen uint64
+			m.Events = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1896,29 +5537,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Events |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Label = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Created", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Submessages", wireType)
 			}
-			var msglen int
+			m.Submessages = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1928,33 +5556,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Submessages |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Created == nil {
-				m.Created = &AbsoluteTxPosition{}
-			}
-			if err := m.Created.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IBCPortID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Other", wireType)
 			}
-			var stringLen uint64
+			m.Other = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1964,29 +5575,16 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Other |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.IBCPortID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Extension", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
 			}
-			var msglen int
+			m.Total = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -1996,28 +5594,11 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Total |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthTypes
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthTypes
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Extension == nil {
-				m.Extension = &types.Any{}
-			}
-			if err := m.Extension.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2040,7 +5621,7 @@ func (m *ContractInfo) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
+func (m *EventContractManagementChanged) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2063,17 +5644,17 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventContractManagementChanged: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ContractCodeHistoryEntry: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventContractManagementChanged: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
 			}
-			m.Operation = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2083,11 +5664,24 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Operation |= ContractCodeHistoryOperationType(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field CodeID", wireType)
@@ -2108,10 +5702,29 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 			}
 		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeType", wireType)
+			}
+			m.ChangeType = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChangeType |= ContractManagementChangeType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field OldValue", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2121,33 +5734,29 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Updated == nil {
-				m.Updated = &AbsoluteTxPosition{}
-			}
-			if err := m.Updated.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.OldValue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewValue", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2157,25 +5766,23 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
-			if m.Msg == nil {
-				m.Msg = []byte{}
-			}
+			m.NewValue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2199,7 +5806,7 @@ func (m *ContractCodeHistoryEntry) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
+func (m *ModuleActivity) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2222,17 +5829,17 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: wiretype end group for non-group")
+			return fmt.Errorf("proto: ModuleActivity: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AbsoluteTxPosition: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ModuleActivity: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.BlockHeight = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2242,16 +5849,16 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.BlockHeight |= uint64(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TxIndex", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Stores", wireType)
 			}
-			m.TxIndex = 0
+			m.Stores = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2261,7 +5868,83 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.TxIndex |= uint64(b&0x7F) << shift
+				m.Stores |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Instantiations", wireType)
+			}
+			m.Instantiations = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Instantiations |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Executions", wireType)
+			}
+			m.Executions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Executions |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Migrations", wireType)
+			}
+			m.Migrations = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Migrations |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2288,7 +5971,7 @@ func (m *AbsoluteTxPosition) Unmarshal(dAtA []byte) error {
 	return nil
 }
 
-func (m *Model) Unmarshal(dAtA []byte) error {
+func (m *EventSubmessageFailed) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2311,17 +5994,17 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Model: wiretype end group for non-group")
+			return fmt.Errorf("proto: EventSubmessageFailed: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Model: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: EventSubmessageFailed: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ContractAddress", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2331,31 +6014,67 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append(m.Key[:0], dAtA[iNdEx:postIndex]...)
-			if m.Key == nil {
-				m.Key = []byte{}
-			}
+			m.ContractAddress = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MsgIndex", wireType)
+			}
+			m.MsgIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MsgIndex |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReplyID", wireType)
+			}
+			m.ReplyID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ReplyID |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Codespace", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowTypes
@@ -2365,25 +6084,74 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Codespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
+			m.Error = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2406,6 +6174,56 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *GasBreakdownExtensionOption) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GasBreakdownExtensionOption: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GasBreakdownExtensionOption: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
@@ -340,8 +340,9 @@ func TestNewEnv(t *testing.T) {
 
 func TestVerifyAddressLen(t *testing.T) {
 	specs := map[string]struct {
-		src    []byte
-		expErr bool
+		src            []byte
+		additionalLens []int
+		expErr         bool
 	}{
 		"valid contract address": {
 			src: bytes.Repeat([]byte{1}, 32),
@@ -365,10 +366,19 @@ func TestVerifyAddressLen(t *testing.T) {
 			src:    bytes.Repeat([]byte{1}, 33),
 			expErr: true,
 		},
+		"additional length": {
+			src:            bytes.Repeat([]byte{1}, 33),
+			additionalLens: []int{33},
+		},
+		"not an additional length": {
+			src:            bytes.Repeat([]byte{1}, 34),
+			additionalLens: []int{33},
+			expErr:         true,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
-			gotErr := VerifyAddressLen()(spec.src)
+			gotErr := VerifyAddressLen(spec.additionalLens...)(spec.src)
 			if spec.expErr {
 				require.Error(t, gotErr)
 				return
@@ -602,6 +612,16 @@ func TestContractCodeHistoryEntryValidation(t *testing.T) {
 		"all good": {
 			src: ContractCodeHistoryEntryFixture(),
 		},
+		"admin changed": {
+			src: ContractCodeHistoryEntryFixture(func(entry *ContractCodeHistoryEntry) {
+				entry.Operation = ContractCodeHistoryOperationTypeAdminChanged
+			}),
+		},
+		"label changed": {
+			src: ContractCodeHistoryEntryFixture(func(entry *ContractCodeHistoryEntry) {
+				entry.Operation = ContractCodeHistoryOperationTypeLabelChanged
+			}),
+		},
 		"unknown operation": {
 			src: ContractCodeHistoryEntryFixture(func(entry *ContractCodeHistoryEntry) {
 				entry.Operation = 0